@@ -0,0 +1,229 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package agent runs the basic loop of a tool-calling agent on Toolbox tools:
+// it asks a model for the next message of a conversation, invokes the tools
+// the model calls, returns their results to the model, and repeats until the
+// model answers without calling tools. It lets simple applications use
+// Toolbox tools with any model without adopting a full agent framework.
+//
+// This package is experimental: its API may change in backward-incompatible
+// ways.
+package agent
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/googleapis/mcp-toolbox-sdk-go/core"
+)
+
+// Role is the author of a Message.
+type Role string
+
+const (
+	RoleSystem    Role = "system"
+	RoleUser      Role = "user"
+	RoleAssistant Role = "assistant"
+	// RoleTool is the role of the messages holding tool results.
+	RoleTool Role = "tool"
+)
+
+// Message is a message of a conversation.
+type Message struct {
+	Role    Role   `json:"role"`
+	Content string `json:"content,omitempty"`
+	// ToolCalls are the tools the model calls in an assistant message.
+	ToolCalls []ToolCall `json:"toolCalls,omitempty"`
+	// ToolCallID and ToolName identify the call a tool message answers.
+	ToolCallID string `json:"toolCallId,omitempty"`
+	ToolName   string `json:"toolName,omitempty"`
+	// IsError reports whether the content of a tool message is an error.
+	IsError bool `json:"isError,omitempty"`
+}
+
+// ToolCall is a call of a tool requested by the model.
+type ToolCall struct {
+	ID        string          `json:"id,omitempty"`
+	Name      string          `json:"name"`
+	Arguments json.RawMessage `json:"arguments,omitempty"`
+}
+
+// ToolDefinition describes a tool to the model.
+type ToolDefinition struct {
+	Name        string          `json:"name"`
+	Description string          `json:"description"`
+	InputSchema json.RawMessage `json:"inputSchema"`
+}
+
+// Request is the input of a Model.
+type Request struct {
+	Messages []Message
+	Tools    []ToolDefinition
+}
+
+// Model generates the next assistant message of a conversation. Adapters for
+// model APIs convert the request into the API's format, and the tool calls of
+// its response back into ToolCalls.
+type Model interface {
+	Generate(ctx context.Context, req *Request) (*Message, error)
+}
+
+// ModelFunc adapts a function to the Model interface.
+type ModelFunc func(ctx context.Context, req *Request) (*Message, error)
+
+// Generate calls f(ctx, req).
+func (f ModelFunc) Generate(ctx context.Context, req *Request) (*Message, error) {
+	return f(ctx, req)
+}
+
+// ErrMaxIterations is returned by Run when the model still calls tools after
+// the maximum number of iterations.
+var ErrMaxIterations = errors.New("agent: maximum number of iterations reached")
+
+// Result is the outcome of Run.
+type Result struct {
+	// Messages is the whole conversation, including the messages added by
+	// the run.
+	Messages []Message
+	// Output is the content of the final answer of the model.
+	Output string
+	// Iterations is the number of times the model was called.
+	Iterations int
+}
+
+// Agent runs conversations with a model and a set of tools. An Agent is safe
+// for concurrent use.
+type Agent struct {
+	model Model
+	cfg   *config
+	tools map[string]*core.ToolboxTool
+	defs  []ToolDefinition
+}
+
+// New creates an Agent letting model call tools, which must have distinct
+// names.
+//
+// Inputs:
+//   - model: The Model generating the messages of the agent.
+//   - tools: The tools the model may call, such as the result of
+//     core.ToolboxClient.LoadToolset.
+//   - opts: A variadic list of Option functions, such as WithMaxIterations.
+//
+// Returns:
+//
+//	The *Agent, or an error if the options or tools are invalid.
+func New(model Model, tools core.Toolset, opts ...Option) (*Agent, error) {
+	if model == nil {
+		return nil, fmt.Errorf("New: model cannot be nil")
+	}
+	cfg, err := newConfig(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	byName, err := tools.ByName()
+	if err != nil {
+		return nil, fmt.Errorf("New: %w", err)
+	}
+	a := &Agent{model: model, cfg: cfg, tools: byName}
+	for _, tool := range tools {
+		schema, err := tool.InputSchema()
+		if err != nil {
+			return nil, fmt.Errorf("error generating the input schema of tool '%s': %w", tool.Name(), err)
+		}
+		a.defs = append(a.defs, ToolDefinition{Name: tool.Name(), Description: tool.Description(), InputSchema: schema})
+	}
+	return a, nil
+}
+
+// Tools returns the definitions of the tools sent to the model.
+func (a *Agent) Tools() []ToolDefinition {
+	return slices.Clone(a.defs)
+}
+
+// Run continues the conversation in messages until the model answers without
+// calling tools, and returns the answer along with the updated conversation.
+// The tools called in a message are invoked in order.
+//
+// If the run fails, because the model or, under StopOnError, a tool returned
+// an error, or because the model was called the maximum number of times, the
+// partial Result is returned along with the error.
+func (a *Agent) Run(ctx context.Context, messages []Message) (*Result, error) {
+	result := &Result{Messages: slices.Clone(messages)}
+	for result.Iterations < a.cfg.maxIterations {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+
+		reply, err := a.model.Generate(ctx, &Request{Messages: slices.Clone(result.Messages), Tools: a.Tools()})
+		result.Iterations++
+		if err != nil {
+			return result, fmt.Errorf("agent: model error: %w", err)
+		}
+		if reply == nil {
+			return result, fmt.Errorf("agent: model returned no message")
+		}
+		if reply.Role == "" {
+			reply.Role = RoleAssistant
+		}
+		result.Messages = append(result.Messages, *reply)
+		if len(reply.ToolCalls) == 0 {
+			result.Output = reply.Content
+			return result, nil
+		}
+
+		for _, call := range reply.ToolCalls {
+			msg, err := a.invoke(ctx, call)
+			if err != nil && a.cfg.errorPolicy == StopOnError {
+				return result, err
+			}
+			result.Messages = append(result.Messages, msg)
+		}
+	}
+	return result, ErrMaxIterations
+}
+
+// invoke runs a tool call and returns the tool message holding its result.
+// Failures are described in the message and also returned.
+func (a *Agent) invoke(ctx context.Context, call ToolCall) (Message, error) {
+	msg := Message{Role: RoleTool, ToolCallID: call.ID, ToolName: call.Name}
+	fail := func(err error) (Message, error) {
+		msg.Content, msg.IsError = err.Error(), true
+		return msg, err
+	}
+
+	tool, ok := a.tools[call.Name]
+	if !ok {
+		return fail(fmt.Errorf("unknown tool '%s'", call.Name))
+	}
+	output, err := tool.InvokeRaw(ctx, call.Arguments, a.cfg.invokeOptions...)
+	if err != nil {
+		return fail(fmt.Errorf("error invoking the tool %s: %w", call.Name, err))
+	}
+
+	if s, ok := output.(string); ok {
+		msg.Content = s
+		return msg, nil
+	}
+	encoded, err := json.Marshal(output)
+	if err != nil {
+		return fail(fmt.Errorf("error encoding the result of tool %s: %w", call.Name, err))
+	}
+	msg.Content = string(encoded)
+	return msg, nil
+}
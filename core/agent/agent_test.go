@@ -0,0 +1,265 @@
+//go:build unit
+
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agent
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/googleapis/mcp-toolbox-sdk-go/core"
+	"github.com/googleapis/mcp-toolbox-sdk-go/core/toolboxtest"
+	"github.com/googleapis/mcp-toolbox-sdk-go/core/transport"
+)
+
+// loadToolset loads a "weather" tool echoing its city and a "fail" tool that
+// always errors.
+func loadToolset(t *testing.T) core.Toolset {
+	t.Helper()
+	return toolboxtest.LoadToolset(t, &toolboxtest.Transport{
+		Tools: map[string]transport.ToolSchema{
+			"weather": {
+				Description: "Get the weather",
+				Parameters:  []core.ParameterSchema{{Name: "city", Type: "string", Required: true}},
+			},
+			"fail": {Description: "Always fails"},
+		},
+		Invoke: func(_ context.Context, name string, payload map[string]any) (any, error) {
+			if name == "fail" {
+				return nil, errors.New("backend unavailable")
+			}
+			return fmt.Sprintf("sunny in %v", payload["city"]), nil
+		},
+	})
+}
+
+// scriptedModel returns the given replies in order and records its requests.
+type scriptedModel struct {
+	replies  []*Message
+	requests []*Request
+}
+
+func (m *scriptedModel) Generate(_ context.Context, req *Request) (*Message, error) {
+	m.requests = append(m.requests, req)
+	if len(m.requests) > len(m.replies) {
+		return nil, errors.New("no more replies")
+	}
+	return m.replies[len(m.requests)-1], nil
+}
+
+func call(id, name, args string) *Message {
+	return &Message{Role: RoleAssistant, ToolCalls: []ToolCall{{ID: id, Name: name, Arguments: json.RawMessage(args)}}}
+}
+
+func TestNew(t *testing.T) {
+	tools := loadToolset(t)
+	model := &scriptedModel{}
+
+	t.Run("tool definitions", func(t *testing.T) {
+		a, err := New(model, tools)
+		if err != nil {
+			t.Fatalf("New() error = %v", err)
+		}
+		defs := a.Tools()
+		if len(defs) != 2 {
+			t.Fatalf("Tools() returned %d definitions, want 2", len(defs))
+		}
+		for _, def := range defs {
+			if def.Name == "weather" {
+				if def.Description != "Get the weather" || !strings.Contains(string(def.InputSchema), `"city"`) {
+					t.Errorf("unexpected definition: %+v", def)
+				}
+			}
+		}
+	})
+
+	errCases := []struct {
+		name    string
+		model   Model
+		tools   core.Toolset
+		opts    []Option
+		wantErr string
+	}{
+		{"nil model", nil, tools, nil, "model cannot be nil"},
+		{"nil tool", model, core.Toolset{nil}, nil, "tool 0 is nil"},
+		{"duplicate tool", model, core.Toolset{tools[0], tools[0]}, nil, "duplicate tool name"},
+		{"nil option", model, tools, []Option{nil}, "received a nil Option"},
+		{"invalid max iterations", model, tools, []Option{WithMaxIterations(0)}, "must be positive"},
+		{"max iterations set twice", model, tools, []Option{WithMaxIterations(1), WithMaxIterations(2)}, "already set"},
+		{"unknown policy", model, tools, []Option{WithErrorPolicy(ErrorPolicy(7))}, "unknown policy"},
+		{"policy set twice", model, tools, []Option{WithErrorPolicy(StopOnError), WithErrorPolicy(ReportErrors)}, "already set"},
+		{"nil invoke option", model, tools, []Option{WithInvokeOptions(nil)}, "nil InvokeOption"},
+	}
+	for _, tc := range errCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := New(tc.model, tc.tools, tc.opts...)
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Errorf("New() error = %v, want it to contain %q", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestRun(t *testing.T) {
+	tools := loadToolset(t)
+	question := []Message{{Role: RoleUser, Content: "Weather in Paris?"}}
+
+	t.Run("dispatches tool calls", func(t *testing.T) {
+		model := &scriptedModel{replies: []*Message{
+			call("c1", "weather", `{"city":"Paris"}`),
+			{Content: "It is sunny."},
+		}}
+		a, err := New(model, tools)
+		if err != nil {
+			t.Fatalf("New() error = %v", err)
+		}
+
+		result, err := a.Run(context.Background(), question)
+		if err != nil {
+			t.Fatalf("Run() error = %v", err)
+		}
+		if result.Output != "It is sunny." || result.Iterations != 2 {
+			t.Errorf("Run() = %+v", result)
+		}
+		if len(result.Messages) != 4 {
+			t.Fatalf("Run() returned %d messages, want 4", len(result.Messages))
+		}
+		want := Message{Role: RoleTool, Content: "sunny in Paris", ToolCallID: "c1", ToolName: "weather"}
+		if got := result.Messages[2]; got.Role != want.Role || got.Content != want.Content || got.ToolCallID != want.ToolCallID || got.ToolName != want.ToolName || got.IsError {
+			t.Errorf("tool message = %+v, want %+v", got, want)
+		}
+		if result.Messages[3].Role != RoleAssistant {
+			t.Errorf("final message role = %q, want %q", result.Messages[3].Role, RoleAssistant)
+		}
+		if got := len(model.requests[1].Messages); got != 3 {
+			t.Errorf("second request has %d messages, want 3", got)
+		}
+		if got := len(model.requests[0].Tools); got != 2 {
+			t.Errorf("request has %d tools, want 2", got)
+		}
+		if len(question) != 1 {
+			t.Errorf("Run() modified its input messages")
+		}
+	})
+
+	t.Run("reports errors to the model", func(t *testing.T) {
+		model := &scriptedModel{replies: []*Message{
+			{Role: RoleAssistant, ToolCalls: []ToolCall{
+				{ID: "c1", Name: "fail"},
+				{ID: "c2", Name: "missing"},
+			}},
+			{Content: "Sorry."},
+		}}
+		a, err := New(model, tools)
+		if err != nil {
+			t.Fatalf("New() error = %v", err)
+		}
+
+		result, err := a.Run(context.Background(), question)
+		if err != nil {
+			t.Fatalf("Run() error = %v", err)
+		}
+		failed, missing := result.Messages[2], result.Messages[3]
+		if !failed.IsError || !strings.Contains(failed.Content, "backend unavailable") {
+			t.Errorf("failed tool message = %+v", failed)
+		}
+		if !missing.IsError || !strings.Contains(missing.Content, "unknown tool 'missing'") {
+			t.Errorf("unknown tool message = %+v", missing)
+		}
+		if result.Output != "Sorry." {
+			t.Errorf("Output = %q, want %q", result.Output, "Sorry.")
+		}
+	})
+
+	t.Run("stops on error", func(t *testing.T) {
+		model := &scriptedModel{replies: []*Message{call("c1", "fail", `{}`)}}
+		a, err := New(model, tools, WithErrorPolicy(StopOnError))
+		if err != nil {
+			t.Fatalf("New() error = %v", err)
+		}
+
+		result, err := a.Run(context.Background(), question)
+		if err == nil || !strings.Contains(err.Error(), "backend unavailable") {
+			t.Fatalf("Run() error = %v, want the tool error", err)
+		}
+		if result == nil || len(result.Messages) != 2 || result.Iterations != 1 {
+			t.Errorf("partial result = %+v", result)
+		}
+	})
+
+	t.Run("max iterations", func(t *testing.T) {
+		model := &scriptedModel{replies: []*Message{
+			call("c1", "weather", `{"city":"Paris"}`),
+			call("c2", "weather", `{"city":"Rome"}`),
+			{Content: "unreached"},
+		}}
+		a, err := New(model, tools, WithMaxIterations(2))
+		if err != nil {
+			t.Fatalf("New() error = %v", err)
+		}
+
+		result, err := a.Run(context.Background(), question)
+		if !errors.Is(err, ErrMaxIterations) {
+			t.Fatalf("Run() error = %v, want ErrMaxIterations", err)
+		}
+		if result.Iterations != 2 || len(result.Messages) != 5 {
+			t.Errorf("partial result = %+v", result)
+		}
+	})
+
+	t.Run("model error", func(t *testing.T) {
+		model := ModelFunc(func(context.Context, *Request) (*Message, error) {
+			return nil, errors.New("quota exceeded")
+		})
+		a, err := New(model, tools)
+		if err != nil {
+			t.Fatalf("New() error = %v", err)
+		}
+		if _, err := a.Run(context.Background(), question); err == nil || !strings.Contains(err.Error(), "quota exceeded") {
+			t.Errorf("Run() error = %v, want the model error", err)
+		}
+	})
+
+	t.Run("nil reply", func(t *testing.T) {
+		a, err := New(ModelFunc(func(context.Context, *Request) (*Message, error) { return nil, nil }), tools)
+		if err != nil {
+			t.Fatalf("New() error = %v", err)
+		}
+		if _, err := a.Run(context.Background(), question); err == nil || !strings.Contains(err.Error(), "no message") {
+			t.Errorf("Run() error = %v, want a missing message error", err)
+		}
+	})
+
+	t.Run("canceled context", func(t *testing.T) {
+		model := &scriptedModel{replies: []*Message{{Content: "unreached"}}}
+		a, err := New(model, tools)
+		if err != nil {
+			t.Fatalf("New() error = %v", err)
+		}
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		if _, err := a.Run(ctx, question); !errors.Is(err, context.Canceled) {
+			t.Errorf("Run() error = %v, want context.Canceled", err)
+		}
+		if len(model.requests) != 0 {
+			t.Errorf("model was called %d times, want 0", len(model.requests))
+		}
+	})
+}
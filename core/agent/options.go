@@ -0,0 +1,107 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agent
+
+import (
+	"fmt"
+
+	"github.com/googleapis/mcp-toolbox-sdk-go/core"
+)
+
+// defaultMaxIterations bounds the number of model calls of a run unless
+// WithMaxIterations is used.
+const defaultMaxIterations = 10
+
+// ErrorPolicy decides what a run does when a tool call fails.
+type ErrorPolicy int
+
+const (
+	// ReportErrors returns failed tool calls to the model as tool messages
+	// with IsError set, so that it can correct itself. It is the default.
+	ReportErrors ErrorPolicy = iota
+	// StopOnError ends the run with the error of the first failed tool call.
+	StopOnError
+)
+
+// Option configures an Agent.
+type Option func(*config) error
+
+// config holds the settings of an Agent.
+type config struct {
+	maxIterations  int
+	errorPolicy    ErrorPolicy
+	errorPolicySet bool
+	invokeOptions  []core.InvokeOption
+}
+
+// newConfig applies opts to the default settings of an Agent.
+func newConfig(opts []Option) (*config, error) {
+	cfg := &config{}
+	for _, opt := range opts {
+		if opt == nil {
+			return nil, fmt.Errorf("New: received a nil Option")
+		}
+		if err := opt(cfg); err != nil {
+			return nil, err
+		}
+	}
+	if cfg.maxIterations == 0 {
+		cfg.maxIterations = defaultMaxIterations
+	}
+	return cfg, nil
+}
+
+// WithMaxIterations sets the maximum number of times the model is called in
+// a run, which defaults to 10.
+func WithMaxIterations(n int) Option {
+	return func(c *config) error {
+		if n <= 0 {
+			return fmt.Errorf("WithMaxIterations: the maximum must be positive, got %d", n)
+		}
+		if c.maxIterations != 0 {
+			return fmt.Errorf("maximum iterations are already set and cannot be overridden")
+		}
+		c.maxIterations = n
+		return nil
+	}
+}
+
+// WithErrorPolicy sets what a run does when a tool call fails.
+func WithErrorPolicy(policy ErrorPolicy) Option {
+	return func(c *config) error {
+		if policy != ReportErrors && policy != StopOnError {
+			return fmt.Errorf("WithErrorPolicy: unknown policy %d", policy)
+		}
+		if c.errorPolicySet {
+			return fmt.Errorf("error policy is already set and cannot be overridden")
+		}
+		c.errorPolicy, c.errorPolicySet = policy, true
+		return nil
+	}
+}
+
+// WithInvokeOptions sets options applied to every tool invocation, such as
+// core.WithInvokeTimeout.
+func WithInvokeOptions(opts ...core.InvokeOption) Option {
+	return func(c *config) error {
+		for _, opt := range opts {
+			if opt == nil {
+				return fmt.Errorf("WithInvokeOptions: received a nil InvokeOption")
+			}
+		}
+		c.invokeOptions = append(c.invokeOptions, opts...)
+		return nil
+	}
+}
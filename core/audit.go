@@ -0,0 +1,85 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+)
+
+// AuditOutcome is the outcome of an audited invocation.
+type AuditOutcome string
+
+const (
+	AuditOutcomeSuccess AuditOutcome = "success"
+	AuditOutcomeError   AuditOutcome = "error"
+)
+
+// AuditRecord describes a tool invocation for an audit log. It identifies the
+// parameters by hash so that records can be correlated without storing
+// sensitive values.
+type AuditRecord struct {
+	// Tool is the name of the invoked tool.
+	Tool string
+	// Principal identifies the caller on whose behalf the tool was invoked,
+	// as set with WithInvokePrincipal, or is empty.
+	Principal string
+	// ParametersHash is the hex-encoded SHA-256 hash of the JSON encoding of
+	// the parameters sent to the server, including bound parameters. It is
+	// empty if the invocation failed before the parameters were built.
+	ParametersHash string
+	// Outcome reports whether the invocation succeeded.
+	Outcome AuditOutcome
+	// Err is the error the invocation failed with, if any.
+	Err error
+	// Start is when the invocation started.
+	Start time.Time
+	// Latency is how long the invocation took.
+	Latency time.Duration
+}
+
+// AuditLogger receives a record of every invocation of a tool, once it
+// completed. It is called synchronously, so it should hand records off
+// quickly, such as to a buffered channel or a logger.
+type AuditLogger func(ctx context.Context, record AuditRecord)
+
+// hashParameters returns the hex-encoded SHA-256 hash of the JSON encoding of
+// payload, whose map keys are sorted, or an empty string if it cannot be
+// encoded.
+func hashParameters(payload map[string]any) string {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// audit passes the record of a completed invocation to the tool's audit
+// loggers.
+func (tt *ToolboxTool) audit(ctx context.Context, record AuditRecord, err error) {
+	record.Tool = tt.name
+	record.Latency = time.Since(record.Start)
+	record.Outcome = AuditOutcomeSuccess
+	if err != nil {
+		record.Outcome, record.Err = AuditOutcomeError, err
+	}
+	for _, logger := range tt.auditLoggers {
+		logger(ctx, record)
+	}
+}
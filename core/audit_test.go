@@ -0,0 +1,166 @@
+//go:build unit
+
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestToolboxTool_AuditLogger(t *testing.T) {
+	newTool := func(tr *capturingTransport, records *[]AuditRecord) *ToolboxTool {
+		return &ToolboxTool{
+			name:      "weather",
+			transport: tr,
+			parameters: []ParameterSchema{
+				{Name: "city", Type: "string"},
+			},
+			boundParams: map[string]any{"units": "metric"},
+			auditLoggers: []AuditLogger{func(ctx context.Context, record AuditRecord) {
+				*records = append(*records, record)
+			}},
+		}
+	}
+
+	t.Run("Records a successful invocation", func(t *testing.T) {
+		var records []AuditRecord
+		tool := newTool(&capturingTransport{result: "ok"}, &records)
+
+		_, err := tool.Invoke(context.Background(), map[string]any{"city": "London"}, WithInvokePrincipal("user@example.com"))
+		if err != nil {
+			t.Fatalf("Invoke failed unexpectedly: %v", err)
+		}
+		if len(records) != 1 {
+			t.Fatalf("Expected 1 audit record, got %d", len(records))
+		}
+		record := records[0]
+		if record.Tool != "weather" || record.Principal != "user@example.com" {
+			t.Errorf("Unexpected tool or principal in %+v", record)
+		}
+		if record.Outcome != AuditOutcomeSuccess || record.Err != nil {
+			t.Errorf("Expected a successful outcome, got %+v", record)
+		}
+		if expected := hashParameters(map[string]any{"city": "London", "units": "metric"}); record.ParametersHash != expected {
+			t.Errorf("Expected parameters hash %q, got %q", expected, record.ParametersHash)
+		}
+		if record.Start.IsZero() || record.Latency < 0 {
+			t.Errorf("Expected a start time and latency, got %+v", record)
+		}
+	})
+
+	t.Run("Does not record parameter values", func(t *testing.T) {
+		var records []AuditRecord
+		tool := newTool(&capturingTransport{result: "ok"}, &records)
+
+		if _, err := tool.Invoke(context.Background(), map[string]any{"city": "secret-city"}); err != nil {
+			t.Fatalf("Invoke failed unexpectedly: %v", err)
+		}
+		if strings.Contains(fmt.Sprintf("%+v", records[0]), "secret-city") {
+			t.Errorf("Expected the record not to contain parameter values, got %+v", records[0])
+		}
+		if records[0].Principal != "" {
+			t.Errorf("Expected an empty principal, got %q", records[0].Principal)
+		}
+	})
+
+	t.Run("Records a failed invocation", func(t *testing.T) {
+		var records []AuditRecord
+		tool := newTool(&capturingTransport{result: "ok"}, &records)
+
+		_, err := tool.Invoke(context.Background(), map[string]any{"country": "UK"})
+		if err == nil {
+			t.Fatal("Expected an error for an unknown parameter, got nil")
+		}
+		if len(records) != 1 {
+			t.Fatalf("Expected 1 audit record, got %d", len(records))
+		}
+		if records[0].Outcome != AuditOutcomeError || !errors.Is(records[0].Err, err) {
+			t.Errorf("Expected an error outcome with %v, got %+v", err, records[0])
+		}
+		if records[0].ParametersHash != "" {
+			t.Errorf("Expected no parameters hash before the parameters are built, got %q", records[0].ParametersHash)
+		}
+	})
+
+	t.Run("Is copied by ToolFrom", func(t *testing.T) {
+		var records []AuditRecord
+		tool := newTool(&capturingTransport{result: "ok"}, &records)
+		other, err := tool.ToolFrom(WithAuditLogger(func(ctx context.Context, record AuditRecord) {
+			records = append(records, record)
+		}))
+		if err != nil {
+			t.Fatalf("ToolFrom failed unexpectedly: %v", err)
+		}
+
+		if _, err := other.Invoke(context.Background(), nil); err != nil {
+			t.Fatalf("Invoke failed unexpectedly: %v", err)
+		}
+		if len(records) != 2 {
+			t.Errorf("Expected both loggers to be called, got %d records", len(records))
+		}
+	})
+}
+
+func TestWithAuditLogger(t *testing.T) {
+	config := &ToolConfig{}
+	logger := func(ctx context.Context, record AuditRecord) {}
+	if err := WithAuditLogger(logger)(config); err != nil {
+		t.Fatalf("WithAuditLogger failed unexpectedly: %v", err)
+	}
+	if err := WithAuditLogger(logger)(config); err != nil {
+		t.Fatalf("WithAuditLogger failed unexpectedly: %v", err)
+	}
+	if len(config.AuditLoggers) != 2 {
+		t.Errorf("Expected 2 audit loggers, got %d", len(config.AuditLoggers))
+	}
+	if err := WithAuditLogger(nil)(config); err == nil {
+		t.Error("Expected an error for a nil logger, got nil")
+	}
+}
+
+func TestWithInvokePrincipal(t *testing.T) {
+	config := newInvokeConfig()
+	if err := WithInvokePrincipal("")(config); err == nil {
+		t.Error("Expected an error for an empty principal, got nil")
+	}
+	if err := WithInvokePrincipal("alice")(config); err != nil {
+		t.Fatalf("WithInvokePrincipal failed unexpectedly: %v", err)
+	}
+	if err := WithInvokePrincipal("bob")(config); err == nil {
+		t.Error("Expected an error when overriding the principal, got nil")
+	}
+	if config.Principal != "alice" {
+		t.Errorf("Expected principal 'alice', got %q", config.Principal)
+	}
+}
+
+func TestHashParameters(t *testing.T) {
+	a := hashParameters(map[string]any{"a": 1, "b": "x"})
+	b := hashParameters(map[string]any{"b": "x", "a": 1})
+	if a != b || len(a) != 64 {
+		t.Errorf("Expected equal 64-character hashes, got %q and %q", a, b)
+	}
+	if c := hashParameters(map[string]any{"a": 2, "b": "x"}); c == a {
+		t.Error("Expected different parameters to hash differently")
+	}
+	if h := hashParameters(map[string]any{"f": func() {}}); h != "" {
+		t.Errorf("Expected an empty hash for an unencodable payload, got %q", h)
+	}
+}
@@ -16,8 +16,16 @@ package core
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/json"
 	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
 	"sync"
+	"time"
 
 	"golang.org/x/oauth2"
 	"google.golang.org/api/idtoken"
@@ -68,3 +76,156 @@ func GetGoogleIDToken(ctx context.Context, audience string) (string, error) {
 	// Return the token with the "Bearer " prefix.
 	return "Bearer " + token.AccessToken, nil
 }
+
+// validateAuthToken checks that the token for the given auth service has not
+// expired and, for JWTs, that its claims match the expected audience.
+//
+// Inputs:
+//   - service: The name of the auth service, used in error messages.
+//   - token: The token to check.
+//   - audience: The audience the token must carry, or empty to skip the check.
+//   - now: The current time.
+//
+// Returns:
+//
+//	An error describing the first problem found, or nil if the token is valid.
+func validateAuthToken(service string, token *oauth2.Token, audience string, now time.Time) error {
+	if token == nil || token.AccessToken == "" {
+		return fmt.Errorf("token for service '%s' is empty", service)
+	}
+	if !token.Expiry.IsZero() && !now.Before(token.Expiry) {
+		return fmt.Errorf("token for service '%s' expired at %s", service, token.Expiry.Format(time.RFC3339))
+	}
+
+	claims, ok := decodeJWTClaims(strings.TrimPrefix(token.AccessToken, "Bearer "))
+	if !ok {
+		// Opaque tokens cannot be inspected further.
+		return nil
+	}
+	if exp, ok := claims["exp"].(float64); ok {
+		expiry := time.Unix(int64(exp), 0)
+		if !now.Before(expiry) {
+			return fmt.Errorf("token for service '%s' expired at %s", service, expiry.UTC().Format(time.RFC3339))
+		}
+	}
+	if audience != "" && !hasAudience(claims["aud"], audience) {
+		return fmt.Errorf("token for service '%s' is not valid for audience '%s'", service, audience)
+	}
+	return nil
+}
+
+// decodeJWTClaims decodes the payload of a JWT without verifying its
+// signature. It reports false if the token is not a well-formed JWT.
+func decodeJWTClaims(token string) (map[string]any, bool) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, false
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, false
+	}
+	var claims map[string]any
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return nil, false
+	}
+	return claims, true
+}
+
+// hasAudience reports whether the "aud" claim, which may be a string or a
+// list of strings, contains the expected audience.
+func hasAudience(aud any, audience string) bool {
+	switch v := aud.(type) {
+	case string:
+		return v == audience
+	case []any:
+		return slices.ContainsFunc(v, func(a any) bool { return a == audience })
+	default:
+		return false
+	}
+}
+
+// idTokenAudience derives the ID token audience, the scheme and host of the
+// server, from the client's base URL.
+func idTokenAudience(baseURL string) (string, error) {
+	u, err := url.Parse(baseURL)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return "", fmt.Errorf("WithAutoIDToken: cannot derive an ID token audience from URL '%s'", baseURL)
+	}
+	return u.Scheme + "://" + u.Host, nil
+}
+
+// idTokenRetryTransport retries requests rejected with 401 or 403 using a
+// Google ID token for the configured audience.
+type idTokenRetryTransport struct {
+	base     http.RoundTripper
+	audience string
+
+	mu     sync.Mutex
+	active bool
+}
+
+func newIDTokenRetryTransport(base http.RoundTripper, audience string) *idTokenRetryTransport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &idTokenRetryTransport{base: base, audience: audience}
+}
+
+// RoundTrip sends the request, retrying it once with an ID token if the
+// server rejects it as unauthenticated.
+func (t *idTokenRetryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Header.Get("Authorization") != "" {
+		return t.base.RoundTrip(req)
+	}
+
+	t.mu.Lock()
+	active := t.active
+	t.mu.Unlock()
+	if active {
+		token, err := GetGoogleIDToken(req.Context(), t.audience)
+		if err != nil {
+			return nil, err
+		}
+		return t.base.RoundTrip(withAuthorization(req, token, nil))
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil || (resp.StatusCode != http.StatusUnauthorized && resp.StatusCode != http.StatusForbidden) {
+		return resp, err
+	}
+	if req.Body != nil && req.GetBody == nil {
+		// The body cannot be replayed, so the request cannot be retried.
+		return resp, nil
+	}
+
+	token, tokenErr := GetGoogleIDToken(req.Context(), t.audience)
+	if tokenErr != nil {
+		// Surface the server's original response if no token is available.
+		return resp, nil
+	}
+	var body io.ReadCloser
+	if req.GetBody != nil {
+		if body, err = req.GetBody(); err != nil {
+			return resp, nil
+		}
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+
+	t.mu.Lock()
+	t.active = true
+	t.mu.Unlock()
+	return t.base.RoundTrip(withAuthorization(req, token, body))
+}
+
+// withAuthorization returns a copy of req carrying the given Authorization
+// header and, if not nil, body.
+func withAuthorization(req *http.Request, authorization string, body io.ReadCloser) *http.Request {
+	clone := req.Clone(req.Context())
+	clone.Header.Set("Authorization", authorization)
+	if body != nil {
+		clone.Body = body
+	}
+	return clone
+}
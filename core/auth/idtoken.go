@@ -0,0 +1,73 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package auth provides token sources for authenticating to a Toolbox server.
+package auth
+
+import (
+	"context"
+	"fmt"
+
+	"golang.org/x/oauth2"
+	"google.golang.org/api/idtoken"
+)
+
+// By assigning the real function to a variable, we can replace it during
+// tests with a mock function.
+var newIDTokenSource = idtoken.NewTokenSource
+
+// NewGoogleIDTokenSource returns a token source that mints Google ID tokens
+// for the given audience using Application Default Credentials.
+//
+// The returned tokens carry the "Bearer " prefix in their AccessToken, so the
+// source can be passed directly to core.WithClientHeaderTokenSource for the
+// "Authorization" header, for example when Toolbox runs on Cloud Run behind
+// IAM. Tokens are cached and refreshed automatically shortly before they
+// expire.
+//
+// Inputs:
+//   - ctx: The context used to look up credentials.
+//   - audience: The recipient of the token, typically the URL of the Toolbox
+//     server.
+//
+// Returns:
+//
+//	An oauth2.TokenSource, or an error if the credentials could not be found.
+func NewGoogleIDTokenSource(ctx context.Context, audience string) (oauth2.TokenSource, error) {
+	if audience == "" {
+		return nil, fmt.Errorf("NewGoogleIDTokenSource: audience cannot be empty")
+	}
+	ts, err := newIDTokenSource(ctx, audience)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create ID token source: %w", err)
+	}
+	return oauth2.ReuseTokenSource(nil, &bearerTokenSource{base: ts}), nil
+}
+
+// bearerTokenSource prefixes the tokens of its base source with "Bearer ".
+type bearerTokenSource struct {
+	base oauth2.TokenSource
+}
+
+// Token returns a copy of the base token formatted as a bearer credential.
+func (b *bearerTokenSource) Token() (*oauth2.Token, error) {
+	token, err := b.base.Token()
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve ID token: %w", err)
+	}
+	bearer := *token
+	bearer.AccessToken = "Bearer " + token.AccessToken
+	bearer.TokenType = "Bearer"
+	return &bearer, nil
+}
@@ -0,0 +1,114 @@
+//go:build unit
+
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/oauth2"
+	"google.golang.org/api/option"
+)
+
+// countingTokenSource returns a fixed token and counts how often it is called.
+type countingTokenSource struct {
+	token *oauth2.Token
+	err   error
+	calls int
+}
+
+func (c *countingTokenSource) Token() (*oauth2.Token, error) {
+	c.calls++
+	return c.token, c.err
+}
+
+// mockNewIDTokenSource replaces newIDTokenSource for the duration of a test.
+func mockNewIDTokenSource(t *testing.T, fn func(context.Context, string, ...option.ClientOption) (oauth2.TokenSource, error)) {
+	original := newIDTokenSource
+	newIDTokenSource = fn
+	t.Cleanup(func() { newIDTokenSource = original })
+}
+
+func TestNewGoogleIDTokenSource(t *testing.T) {
+	t.Run("Returns cached bearer tokens", func(t *testing.T) {
+		base := &countingTokenSource{token: &oauth2.Token{AccessToken: "id-token", Expiry: time.Now().Add(time.Hour)}}
+		var gotAudience string
+		mockNewIDTokenSource(t, func(_ context.Context, audience string, _ ...option.ClientOption) (oauth2.TokenSource, error) {
+			gotAudience = audience
+			return base, nil
+		})
+
+		ts, err := NewGoogleIDTokenSource(context.Background(), "https://toolbox.example.com")
+		if err != nil {
+			t.Fatalf("NewGoogleIDTokenSource failed: %v", err)
+		}
+		if gotAudience != "https://toolbox.example.com" {
+			t.Errorf("Expected audience to be passed through, got %q", gotAudience)
+		}
+
+		for i := 0; i < 3; i++ {
+			token, err := ts.Token()
+			if err != nil {
+				t.Fatalf("Token failed: %v", err)
+			}
+			if token.AccessToken != "Bearer id-token" {
+				t.Errorf("Expected a bearer token, got %q", token.AccessToken)
+			}
+		}
+		if base.calls != 1 {
+			t.Errorf("Expected the base source to be called once, got %d", base.calls)
+		}
+		if base.token.AccessToken != "id-token" {
+			t.Error("Expected the base token to be left unmodified")
+		}
+	})
+
+	t.Run("Negative Test - Empty audience", func(t *testing.T) {
+		_, err := NewGoogleIDTokenSource(context.Background(), "")
+		if err == nil || !strings.Contains(err.Error(), "audience cannot be empty") {
+			t.Errorf("Expected an empty audience error, got %v", err)
+		}
+	})
+
+	t.Run("Negative Test - Credentials unavailable", func(t *testing.T) {
+		mockNewIDTokenSource(t, func(context.Context, string, ...option.ClientOption) (oauth2.TokenSource, error) {
+			return nil, errors.New("no credentials")
+		})
+
+		_, err := NewGoogleIDTokenSource(context.Background(), "aud")
+		if err == nil || !strings.Contains(err.Error(), "failed to create ID token source: no credentials") {
+			t.Errorf("Expected a wrapped credentials error, got %v", err)
+		}
+	})
+
+	t.Run("Negative Test - Token retrieval fails", func(t *testing.T) {
+		mockNewIDTokenSource(t, func(context.Context, string, ...option.ClientOption) (oauth2.TokenSource, error) {
+			return &countingTokenSource{err: errors.New("metadata server down")}, nil
+		})
+
+		ts, err := NewGoogleIDTokenSource(context.Background(), "aud")
+		if err != nil {
+			t.Fatalf("NewGoogleIDTokenSource failed: %v", err)
+		}
+		if _, err := ts.Token(); err == nil || !strings.Contains(err.Error(), "metadata server down") {
+			t.Errorf("Expected the token error to be surfaced, got %v", err)
+		}
+	})
+}
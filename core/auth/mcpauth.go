@@ -0,0 +1,603 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+
+	"golang.org/x/oauth2"
+)
+
+// ProtectedResourceMetadata describes an OAuth 2.0 protected resource, such
+// as an MCP server, as defined by RFC 9728.
+type ProtectedResourceMetadata struct {
+	// Resource is the resource identifier, passed to the authorization
+	// server to request tokens for this resource.
+	Resource string `json:"resource"`
+	// AuthorizationServers are the issuers of the authorization servers that
+	// can issue tokens for the resource.
+	AuthorizationServers   []string `json:"authorization_servers"`
+	ScopesSupported        []string `json:"scopes_supported,omitempty"`
+	BearerMethodsSupported []string `json:"bearer_methods_supported,omitempty"`
+}
+
+// AuthorizationServerMetadata describes an OAuth 2.0 authorization server, as
+// defined by RFC 8414 and OpenID Connect Discovery.
+type AuthorizationServerMetadata struct {
+	Issuer                        string   `json:"issuer"`
+	AuthorizationEndpoint         string   `json:"authorization_endpoint"`
+	TokenEndpoint                 string   `json:"token_endpoint"`
+	RegistrationEndpoint          string   `json:"registration_endpoint,omitempty"`
+	ScopesSupported               []string `json:"scopes_supported,omitempty"`
+	CodeChallengeMethodsSupported []string `json:"code_challenge_methods_supported,omitempty"`
+}
+
+// ParseBearerChallenge extracts the parameters of the Bearer challenge in a
+// WWW-Authenticate header, such as "resource_metadata", "scope" and "error".
+// Parameter names are lowercased.
+//
+// Inputs:
+//   - header: The value of the WWW-Authenticate header, which may hold
+//     challenges for several schemes.
+//
+// Returns:
+//
+//	The challenge parameters and true, or nil and false if the header does
+//	not hold a Bearer challenge.
+func ParseBearerChallenge(header string) (map[string]string, bool) {
+	rest := header
+	for {
+		rest = strings.TrimLeft(rest, " \t,")
+		if rest == "" {
+			return nil, false
+		}
+		scheme, after, _ := strings.Cut(rest, " ")
+		var params map[string]string
+		params, rest = parseAuthParams(after)
+		if strings.EqualFold(scheme, "Bearer") {
+			return params, true
+		}
+	}
+}
+
+// parseAuthParams parses the comma-separated auth-params of a challenge,
+// stopping at the start of the next challenge, which is returned as rest.
+func parseAuthParams(s string) (params map[string]string, rest string) {
+	params = make(map[string]string)
+	for {
+		s = strings.TrimLeft(s, " \t,")
+		end := strings.IndexAny(s, "= \t,")
+		if end <= 0 || s[end] != '=' {
+			// A token that is not followed by '=' starts a new challenge.
+			return params, s
+		}
+		name := strings.ToLower(s[:end])
+		s = strings.TrimLeft(s[end+1:], " \t")
+
+		var value strings.Builder
+		if strings.HasPrefix(s, `"`) {
+			i := 1
+			for ; i < len(s) && s[i] != '"'; i++ {
+				if s[i] == '\\' && i+1 < len(s) {
+					i++
+				}
+				value.WriteByte(s[i])
+			}
+			s = s[min(i+1, len(s)):]
+		} else {
+			end := strings.IndexAny(s, " \t,")
+			if end < 0 {
+				end = len(s)
+			}
+			value.WriteString(s[:end])
+			s = s[end:]
+		}
+		params[name] = value.String()
+	}
+}
+
+// DiscoverProtectedResource fetches the metadata of a protected resource.
+//
+// Inputs:
+//   - ctx: The context for the metadata requests.
+//   - client: The HTTP client for the requests. Defaults to http.DefaultClient.
+//   - resourceURL: The URL of the resource, such as the MCP server endpoint.
+//   - metadataURL: The metadata URL announced by the resource in the
+//     "resource_metadata" parameter of its challenge. If empty, the
+//     well-known locations derived from resourceURL are tried in turn.
+//
+// Returns:
+//
+//	The metadata, or an error if none could be fetched.
+func DiscoverProtectedResource(ctx context.Context, client *http.Client, resourceURL, metadataURL string) (*ProtectedResourceMetadata, error) {
+	candidates := []string{metadataURL}
+	if metadataURL == "" {
+		u, err := url.Parse(resourceURL)
+		if err != nil || u.Host == "" {
+			return nil, fmt.Errorf("invalid resource URL '%s'", resourceURL)
+		}
+		candidates = wellKnownURLs(u, "oauth-protected-resource")
+	}
+
+	var metadata ProtectedResourceMetadata
+	if err := getFirstJSON(ctx, client, candidates, &metadata); err != nil {
+		return nil, fmt.Errorf("failed to discover protected resource metadata: %w", err)
+	}
+	if len(metadata.AuthorizationServers) == 0 {
+		return nil, fmt.Errorf("protected resource metadata does not list any authorization servers")
+	}
+	return &metadata, nil
+}
+
+// DiscoverAuthorizationServer fetches the metadata of an authorization
+// server from its OAuth 2.0 or OpenID Connect well-known locations.
+//
+// Inputs:
+//   - ctx: The context for the metadata requests.
+//   - client: The HTTP client for the requests. Defaults to http.DefaultClient.
+//   - issuer: The issuer identifier of the authorization server.
+//
+// Returns:
+//
+//	The metadata, or an error if none could be fetched.
+func DiscoverAuthorizationServer(ctx context.Context, client *http.Client, issuer string) (*AuthorizationServerMetadata, error) {
+	u, err := url.Parse(issuer)
+	if err != nil || u.Host == "" {
+		return nil, fmt.Errorf("invalid issuer '%s'", issuer)
+	}
+	candidates := append(wellKnownURLs(u, "oauth-authorization-server"), wellKnownURLs(u, "openid-configuration")...)
+	if path := strings.TrimSuffix(u.Path, "/"); path != "" {
+		// OpenID Connect Discovery appends the well-known path to the issuer.
+		candidates = append(candidates, u.Scheme+"://"+u.Host+path+"/.well-known/openid-configuration")
+	}
+
+	var metadata AuthorizationServerMetadata
+	if err := getFirstJSON(ctx, client, candidates, &metadata); err != nil {
+		return nil, fmt.Errorf("failed to discover authorization server metadata: %w", err)
+	}
+	if metadata.AuthorizationEndpoint == "" || metadata.TokenEndpoint == "" {
+		return nil, fmt.Errorf("authorization server metadata does not include the authorization and token endpoints")
+	}
+	return &metadata, nil
+}
+
+// wellKnownURLs returns the well-known URLs of the given suffix for u, with
+// the path of u inserted after the well-known path first, if any.
+func wellKnownURLs(u *url.URL, suffix string) []string {
+	root := u.Scheme + "://" + u.Host + "/.well-known/" + suffix
+	if path := strings.TrimSuffix(u.Path, "/"); path != "" {
+		return []string{root + path, root}
+	}
+	return []string{root}
+}
+
+// getFirstJSON decodes into dest the first of the given URLs that answers
+// with a JSON document.
+func getFirstJSON(ctx context.Context, client *http.Client, urls []string, dest any) error {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	var errs []error
+	for _, u := range urls {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Accept", "application/json")
+		resp, err := client.Do(req)
+		if err != nil {
+			if ctx.Err() != nil {
+				return err
+			}
+			errs = append(errs, err)
+			continue
+		}
+		body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+		resp.Body.Close()
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to read %s: %w", u, err))
+			continue
+		}
+		if resp.StatusCode != http.StatusOK {
+			errs = append(errs, fmt.Errorf("%s returned status %d", u, resp.StatusCode))
+			continue
+		}
+		if err := json.Unmarshal(body, dest); err != nil {
+			return fmt.Errorf("failed to decode %s: %w", u, err)
+		}
+		return nil
+	}
+	return errors.Join(errs...)
+}
+
+// AuthorizationHandler sends the user to authURL to grant consent, for
+// example by opening a browser or by displaying the URL to open on another
+// device, and returns the code and state received on the redirect.
+type AuthorizationHandler func(ctx context.Context, authURL string) (code, state string, err error)
+
+// MCPAuthorizer authorizes the client to MCP servers protected as described
+// by the MCP authorization specification. When a server rejects a request
+// with a Bearer challenge, it discovers the server's authorization server,
+// registers the client if no ClientID is set, runs the authorization code
+// flow with PKCE through Handler, and attaches the resulting token to all
+// later requests to the server. Tokens are refreshed as they expire.
+//
+// Use Transport to wrap the round tripper of the client's http.Client:
+//
+//	httpClient := &http.Client{Transport: authorizer.Transport(nil)}
+//	client, err := core.NewToolboxClient(url, core.WithHTTPClient(httpClient))
+type MCPAuthorizer struct {
+	// ClientID and ClientSecret identify the client to the authorization
+	// server. If ClientID is empty, the client is registered dynamically
+	// (RFC 7591) as a public client.
+	ClientID     string
+	ClientSecret string
+	// ClientName is the name under which the client is registered. Defaults
+	// to "toolbox-core-go".
+	ClientName string
+	// RedirectURL is the URL the user is redirected to after granting
+	// consent, where Handler receives the code.
+	RedirectURL string
+	// Scopes are the scopes to request. Defaults to the scopes in the
+	// server's challenge, or else the scopes listed in its metadata.
+	Scopes []string
+	// Handler sends the user to the authorization URL and returns the code.
+	Handler AuthorizationHandler
+	// Store persists tokens, keyed by resource, so that users are not asked
+	// for consent again while a stored token can be refreshed. Defaults to a
+	// MemoryTokenStore.
+	Store TokenStore
+	// HTTPClient is used for discovery, registration and token requests.
+	// Defaults to http.DefaultClient.
+	HTTPClient *http.Client
+
+	// mu guards the maps below. It is never held during network requests or
+	// consent; concurrent requests rejected by the same server instead wait
+	// for the single authorization in flights.
+	mu      sync.Mutex
+	sources map[string]oauth2.TokenSource
+	clients map[string]*oauth2.Config
+	flights map[string]*authorization
+}
+
+// authorization is an authorization of a server in progress, whose result
+// is set before done is closed.
+type authorization struct {
+	done   chan struct{}
+	source oauth2.TokenSource
+	err    error
+}
+
+// Transport returns a round tripper that sends requests with base, which
+// defaults to http.DefaultTransport, authorizing them as needed.
+func (a *MCPAuthorizer) Transport(base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &authorizingTransport{base: base, authorizer: a}
+}
+
+// tokenSource returns the token source of the server at serverURL, or nil if
+// it has not been authorized yet.
+func (a *MCPAuthorizer) tokenSource(serverURL string) oauth2.TokenSource {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.sources[serverURL]
+}
+
+// authorize returns a token source for the server at serverURL, which
+// rejected a request with the given challenge. A stored token is used if
+// there is one and rejected is nil; otherwise the user is asked for consent.
+// Concurrent calls for the same server share a single authorization.
+func (a *MCPAuthorizer) authorize(ctx context.Context, serverURL, challenge string, rejected oauth2.TokenSource) (oauth2.TokenSource, error) {
+	a.mu.Lock()
+	if current := a.sources[serverURL]; current != nil && current != rejected {
+		// Another request authorized the server in the meantime.
+		a.mu.Unlock()
+		return current, nil
+	}
+	if flight, ok := a.flights[serverURL]; ok {
+		a.mu.Unlock()
+		select {
+		case <-flight.done:
+			return flight.source, flight.err
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	if a.Handler == nil {
+		a.mu.Unlock()
+		return nil, fmt.Errorf("MCPAuthorizer: Handler cannot be nil")
+	}
+	if a.Store == nil {
+		a.Store = NewMemoryTokenStore()
+	}
+	if a.flights == nil {
+		a.flights = make(map[string]*authorization)
+	}
+	flight := &authorization{done: make(chan struct{})}
+	a.flights[serverURL] = flight
+	a.mu.Unlock()
+
+	flight.source, flight.err = a.runAuthorization(ctx, serverURL, challenge, rejected)
+
+	a.mu.Lock()
+	delete(a.flights, serverURL)
+	if flight.err == nil {
+		if a.sources == nil {
+			a.sources = make(map[string]oauth2.TokenSource)
+		}
+		a.sources[serverURL] = flight.source
+	} else if a.sources[serverURL] == rejected {
+		// Drop the rejected source, so that the next request asks again.
+		delete(a.sources, serverURL)
+	}
+	a.mu.Unlock()
+	close(flight.done)
+	return flight.source, flight.err
+}
+
+// runAuthorization discovers the authorization server of the server at
+// serverURL and obtains a token source for it, from the store unless the
+// stored token was rejected, or else through the user's consent.
+func (a *MCPAuthorizer) runAuthorization(ctx context.Context, serverURL, challenge string, rejected oauth2.TokenSource) (oauth2.TokenSource, error) {
+	params, _ := ParseBearerChallenge(challenge)
+	resource, err := DiscoverProtectedResource(ctx, a.HTTPClient, serverURL, params["resource_metadata"])
+	if err != nil {
+		return nil, err
+	}
+	config, err := a.clientConfig(ctx, resource.AuthorizationServers[0])
+	if err != nil {
+		return nil, err
+	}
+	config.Scopes = a.Scopes
+	if len(config.Scopes) == 0 {
+		if scope := params["scope"]; scope != "" {
+			config.Scopes = strings.Fields(scope)
+		} else {
+			config.Scopes = resource.ScopesSupported
+		}
+	}
+	resourceID := resource.Resource
+	if resourceID == "" {
+		resourceID = serverURL
+	}
+	flow := &UserFlow{Config: config, Store: a.Store, Key: resourceID}
+	// Tokens are refreshed outside of the request that triggered the flow.
+	refreshCtx := context.Background()
+	if a.HTTPClient != nil {
+		refreshCtx = context.WithValue(refreshCtx, oauth2.HTTPClient, a.HTTPClient)
+	}
+
+	if rejected == nil {
+		if source, err := flow.TokenSource(refreshCtx); err == nil {
+			return source, nil
+		}
+	}
+
+	resourceParam := oauth2.SetAuthURLParam("resource", resourceID)
+	state := rand.Text()
+	authURL, verifier := flow.AuthCodeURL(state, resourceParam)
+	code, gotState, err := a.Handler(ctx, authURL)
+	if err != nil {
+		return nil, fmt.Errorf("authorization was not granted: %w", err)
+	}
+	if gotState != state {
+		return nil, fmt.Errorf("authorization response state does not match the request")
+	}
+	if a.HTTPClient != nil {
+		ctx = context.WithValue(ctx, oauth2.HTTPClient, a.HTTPClient)
+	}
+	if _, err := flow.Exchange(ctx, code, verifier, resourceParam); err != nil {
+		return nil, err
+	}
+	return flow.TokenSource(refreshCtx)
+}
+
+// dropSource forgets the token source of the server at serverURL if it is
+// still source, so that the server is authorized again.
+func (a *MCPAuthorizer) dropSource(serverURL string, source oauth2.TokenSource) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.sources[serverURL] == source {
+		delete(a.sources, serverURL)
+	}
+}
+
+// clientConfig returns the OAuth 2.0 configuration of the client for the
+// given authorization server, registering the client first if needed.
+func (a *MCPAuthorizer) clientConfig(ctx context.Context, issuer string) (*oauth2.Config, error) {
+	a.mu.Lock()
+	config, ok := a.clients[issuer]
+	a.mu.Unlock()
+	if ok {
+		clone := *config
+		return &clone, nil
+	}
+	metadata, err := DiscoverAuthorizationServer(ctx, a.HTTPClient, issuer)
+	if err != nil {
+		return nil, err
+	}
+	if methods := metadata.CodeChallengeMethodsSupported; len(methods) > 0 && !slices.Contains(methods, "S256") {
+		return nil, fmt.Errorf("authorization server '%s' does not support PKCE with S256", issuer)
+	}
+
+	config = &oauth2.Config{
+		ClientID:     a.ClientID,
+		ClientSecret: a.ClientSecret,
+		RedirectURL:  a.RedirectURL,
+		Endpoint: oauth2.Endpoint{
+			AuthURL:  metadata.AuthorizationEndpoint,
+			TokenURL: metadata.TokenEndpoint,
+		},
+	}
+	if config.ClientID == "" {
+		if metadata.RegistrationEndpoint == "" {
+			return nil, fmt.Errorf("authorization server '%s' does not support dynamic client registration and no ClientID is set", issuer)
+		}
+		if config.ClientID, config.ClientSecret, err = a.register(ctx, metadata.RegistrationEndpoint); err != nil {
+			return nil, err
+		}
+	}
+	a.mu.Lock()
+	if a.clients == nil {
+		a.clients = make(map[string]*oauth2.Config)
+	}
+	if registered, ok := a.clients[issuer]; ok {
+		// Another server of the same issuer registered the client first.
+		config = registered
+	} else {
+		a.clients[issuer] = config
+	}
+	a.mu.Unlock()
+	clone := *config
+	return &clone, nil
+}
+
+// register registers the client at a registration endpoint (RFC 7591) and
+// returns its credentials.
+func (a *MCPAuthorizer) register(ctx context.Context, endpoint string) (string, string, error) {
+	name := a.ClientName
+	if name == "" {
+		name = "toolbox-core-go"
+	}
+	payload, err := json.Marshal(map[string]any{
+		"client_name":                name,
+		"redirect_uris":              []string{a.RedirectURL},
+		"grant_types":                []string{"authorization_code", "refresh_token"},
+		"response_types":             []string{"code"},
+		"token_endpoint_auth_method": "none",
+	})
+	if err != nil {
+		return "", "", err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return "", "", fmt.Errorf("failed to create registration request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	client := a.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", "", fmt.Errorf("client registration request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return "", "", fmt.Errorf("failed to read client registration response: %w", err)
+	}
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+		return "", "", fmt.Errorf("client registration failed with status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var result struct {
+		ClientID     string `json:"client_id"`
+		ClientSecret string `json:"client_secret"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return "", "", fmt.Errorf("failed to decode client registration response: %w", err)
+	}
+	if result.ClientID == "" {
+		return "", "", fmt.Errorf("client registration response does not contain a client_id")
+	}
+	return result.ClientID, result.ClientSecret, nil
+}
+
+// authorizingTransport attaches the tokens of an MCPAuthorizer to requests,
+// authorizing the server when it rejects a request with a Bearer challenge.
+type authorizingTransport struct {
+	base       http.RoundTripper
+	authorizer *MCPAuthorizer
+}
+
+// RoundTrip sends the request with the server's token, if any. If the
+// server rejects it, the server is authorized and the request retried, once
+// with a stored token if one exists and once more after the user consents.
+// A token that can no longer be obtained, for example because its refresh
+// token was revoked, counts as rejected.
+func (t *authorizingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Header.Get("Authorization") != "" {
+		return t.base.RoundTrip(req)
+	}
+	serverURL := resourceURL(req.URL)
+	source := t.authorizer.tokenSource(serverURL)
+
+	for attempt := 0; ; attempt++ {
+		var token *oauth2.Token
+		if source != nil {
+			var err error
+			if token, err = source.Token(); err != nil {
+				// Send the request without a token to get the server's
+				// challenge, and authorize anew.
+				t.authorizer.dropSource(serverURL, source)
+				token = nil
+			}
+		}
+		resp, err := t.send(req, token, attempt > 0)
+		if err != nil || resp.StatusCode != http.StatusUnauthorized || attempt == 2 {
+			return resp, err
+		}
+		challenge := resp.Header.Get("WWW-Authenticate")
+		if _, ok := ParseBearerChallenge(challenge); !ok || (req.Body != nil && req.GetBody == nil) {
+			return resp, nil
+		}
+		_, _ = io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+
+		if source, err = t.authorizer.authorize(req.Context(), serverURL, challenge, source); err != nil {
+			return nil, fmt.Errorf("failed to authorize with '%s': %w", serverURL, err)
+		}
+	}
+}
+
+// send sends a copy of req carrying token, if not nil, and a fresh body if
+// rewind is set.
+func (t *authorizingTransport) send(req *http.Request, token *oauth2.Token, rewind bool) (*http.Response, error) {
+	if token == nil && !rewind {
+		return t.base.RoundTrip(req)
+	}
+	clone := req.Clone(req.Context())
+	if token != nil {
+		clone.Header.Set("Authorization", "Bearer "+token.AccessToken)
+	}
+	if rewind && req.GetBody != nil {
+		var err error
+		if clone.Body, err = req.GetBody(); err != nil {
+			return nil, err
+		}
+	}
+	return t.base.RoundTrip(clone)
+}
+
+// resourceURL returns the URL of the resource a request is sent to, without
+// its query and fragment.
+func resourceURL(u *url.URL) string {
+	return u.Scheme + "://" + u.Host + u.Path
+}
@@ -0,0 +1,371 @@
+//go:build unit
+
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+func TestParseBearerChallenge(t *testing.T) {
+	testCases := []struct {
+		name   string
+		header string
+		want   map[string]string
+		ok     bool
+	}{
+		{
+			name:   "Quoted parameters",
+			header: `Bearer resource_metadata="https://mcp.example.com/.well-known/oauth-protected-resource", scope="files:read files:write"`,
+			want:   map[string]string{"resource_metadata": "https://mcp.example.com/.well-known/oauth-protected-resource", "scope": "files:read files:write"},
+			ok:     true,
+		},
+		{
+			name:   "Among other challenges",
+			header: `Basic realm="x", bearer Error=invalid_token, error_description="say \"hi\""`,
+			want:   map[string]string{"error": "invalid_token", "error_description": `say "hi"`},
+			ok:     true,
+		},
+		{
+			name:   "Followed by another challenge",
+			header: `Bearer realm="mcp" , DPoP algs="ES256"`,
+			want:   map[string]string{"realm": "mcp"},
+			ok:     true,
+		},
+		{name: "Without parameters", header: "Bearer", want: map[string]string{}, ok: true},
+		{name: "No Bearer challenge", header: `Basic realm="x"`},
+		{name: "Empty", header: ""},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, ok := ParseBearerChallenge(tc.header)
+			if ok != tc.ok || (tc.ok && !reflect.DeepEqual(got, tc.want)) {
+				t.Errorf("ParseBearerChallenge(%q) = %v, %v; want %v, %v", tc.header, got, ok, tc.want, tc.ok)
+			}
+		})
+	}
+}
+
+// protectedServer is an MCP server protected by its own authorization
+// server, which supports dynamic client registration.
+type protectedServer struct {
+	*httptest.Server
+	registrations atomic.Int32
+	exchanges     atomic.Int32
+	tokenForm     url.Values
+	// accepted is the access token the MCP endpoint accepts.
+	accepted string
+	// revoked makes the token endpoint reject refresh tokens.
+	revoked bool
+}
+
+func newProtectedServer(t *testing.T) *protectedServer {
+	s := &protectedServer{accepted: "access-1"}
+	mux := http.NewServeMux()
+	writeJSON := func(w http.ResponseWriter, status int, v any) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		_ = json.NewEncoder(w).Encode(v)
+	}
+	mux.HandleFunc("/mcp", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer "+s.accepted {
+			w.Header().Set("WWW-Authenticate", `Bearer resource_metadata="`+s.URL+`/.well-known/oauth-protected-resource/mcp", scope="tools"`)
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		_, _ = w.Write(body)
+	})
+	mux.HandleFunc("/.well-known/oauth-protected-resource/mcp", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, ProtectedResourceMetadata{Resource: s.URL + "/mcp", AuthorizationServers: []string{s.URL + "/as"}})
+	})
+	mux.HandleFunc("/.well-known/oauth-authorization-server/as", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, AuthorizationServerMetadata{
+			Issuer:                        s.URL + "/as",
+			AuthorizationEndpoint:         s.URL + "/as/authorize",
+			TokenEndpoint:                 s.URL + "/as/token",
+			RegistrationEndpoint:          s.URL + "/as/register",
+			CodeChallengeMethodsSupported: []string{"S256"},
+		})
+	})
+	mux.HandleFunc("/as/register", func(w http.ResponseWriter, r *http.Request) {
+		s.registrations.Add(1)
+		writeJSON(w, http.StatusCreated, map[string]any{"client_id": "registered-client"})
+	})
+	mux.HandleFunc("/as/token", func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		if s.revoked && r.PostForm.Get("grant_type") == "refresh_token" {
+			writeJSON(w, http.StatusBadRequest, map[string]any{"error": "invalid_grant"})
+			return
+		}
+		s.tokenForm = r.PostForm
+		s.exchanges.Add(1)
+		writeJSON(w, http.StatusOK, map[string]any{"access_token": s.accepted, "token_type": "Bearer", "expires_in": 3600})
+	})
+	s.Server = httptest.NewServer(mux)
+	t.Cleanup(s.Close)
+	return s
+}
+
+// consentHandler returns an AuthorizationHandler that grants consent
+// immediately, recording the authorization URLs it is sent to.
+func consentHandler(authURLs *[]*url.URL) AuthorizationHandler {
+	return func(_ context.Context, authURL string) (string, string, error) {
+		u, err := url.Parse(authURL)
+		if err != nil {
+			return "", "", err
+		}
+		*authURLs = append(*authURLs, u)
+		return "code-1", u.Query().Get("state"), nil
+	}
+}
+
+func TestMCPAuthorizer(t *testing.T) {
+	post := func(t *testing.T, client *http.Client, target string) (*http.Response, string) {
+		t.Helper()
+		resp, err := client.Post(target, "application/json", strings.NewReader(`{"id":1}`))
+		if err != nil {
+			t.Fatalf("Request failed: %v", err)
+		}
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		return resp, string(body)
+	}
+
+	t.Run("Authorizes on the first challenge", func(t *testing.T) {
+		server := newProtectedServer(t)
+		var authURLs []*url.URL
+		authorizer := &MCPAuthorizer{RedirectURL: "http://localhost/callback", Handler: consentHandler(&authURLs)}
+		client := &http.Client{Transport: authorizer.Transport(nil)}
+
+		resp, body := post(t, client, server.URL+"/mcp")
+		if resp.StatusCode != http.StatusOK || body != `{"id":1}` {
+			t.Fatalf("Expected the request to be retried with a token, got %d %q", resp.StatusCode, body)
+		}
+		if len(authURLs) != 1 {
+			t.Fatalf("Expected a single consent, got %d", len(authURLs))
+		}
+		query := authURLs[0].Query()
+		if authURLs[0].Path != "/as/authorize" || query.Get("client_id") != "registered-client" ||
+			query.Get("resource") != server.URL+"/mcp" || query.Get("scope") != "tools" ||
+			query.Get("code_challenge_method") != "S256" || query.Get("redirect_uri") != "http://localhost/callback" {
+			t.Errorf("Unexpected authorization URL: %s", authURLs[0])
+		}
+		if server.tokenForm.Get("resource") != server.URL+"/mcp" || server.tokenForm.Get("code_verifier") == "" {
+			t.Errorf("Unexpected token request: %v", server.tokenForm)
+		}
+
+		post(t, client, server.URL+"/mcp")
+		if len(authURLs) != 1 || server.exchanges.Load() != 1 || server.registrations.Load() != 1 {
+			t.Errorf("Expected the token to be reused")
+		}
+	})
+
+	t.Run("Uses a stored token before asking for consent", func(t *testing.T) {
+		server := newProtectedServer(t)
+		store := NewMemoryTokenStore()
+		_ = store.Save(context.Background(), server.URL+"/mcp", &oauth2.Token{AccessToken: "access-1"})
+		var authURLs []*url.URL
+		authorizer := &MCPAuthorizer{ClientID: "client", Handler: consentHandler(&authURLs), Store: store}
+		client := &http.Client{Transport: authorizer.Transport(nil)}
+
+		if resp, _ := post(t, client, server.URL+"/mcp"); resp.StatusCode != http.StatusOK {
+			t.Fatalf("Expected the stored token to be accepted, got %d", resp.StatusCode)
+		}
+		if len(authURLs) != 0 || server.registrations.Load() != 0 {
+			t.Errorf("Expected no consent or registration")
+		}
+
+		// A revoked token leads to a new consent.
+		server.accepted = "access-2"
+		if resp, _ := post(t, client, server.URL+"/mcp"); resp.StatusCode != http.StatusOK {
+			t.Fatalf("Expected a new token to be accepted, got %d", resp.StatusCode)
+		}
+		if len(authURLs) != 1 {
+			t.Errorf("Expected a single consent, got %d", len(authURLs))
+		}
+	})
+
+	t.Run("Asks for consent again when the refresh token is revoked", func(t *testing.T) {
+		server := newProtectedServer(t)
+		server.revoked = true
+		store := NewMemoryTokenStore()
+		expired := &oauth2.Token{AccessToken: "access-0", RefreshToken: "refresh-0", Expiry: time.Now().Add(-time.Hour)}
+		_ = store.Save(context.Background(), server.URL+"/mcp", expired)
+		var authURLs []*url.URL
+		authorizer := &MCPAuthorizer{ClientID: "client", Handler: consentHandler(&authURLs), Store: store}
+		client := &http.Client{Transport: authorizer.Transport(nil)}
+
+		if resp, body := post(t, client, server.URL+"/mcp"); resp.StatusCode != http.StatusOK || body != `{"id":1}` {
+			t.Fatalf("Expected the request to succeed after a new consent, got %d %q", resp.StatusCode, body)
+		}
+		if len(authURLs) != 1 {
+			t.Errorf("Expected a single consent, got %d", len(authURLs))
+		}
+	})
+
+	t.Run("Does not block other servers during consent", func(t *testing.T) {
+		slow, fast := newProtectedServer(t), newProtectedServer(t)
+		release := make(chan struct{})
+		var consents atomic.Int32
+		authorizer := &MCPAuthorizer{ClientID: "client", Handler: func(ctx context.Context, authURL string) (string, string, error) {
+			consents.Add(1)
+			u, _ := url.Parse(authURL)
+			if strings.HasPrefix(authURL, slow.URL) {
+				select {
+				case <-release:
+				case <-ctx.Done():
+					return "", "", ctx.Err()
+				}
+			}
+			return "code-1", u.Query().Get("state"), nil
+		}}
+		client := &http.Client{Transport: authorizer.Transport(nil)}
+
+		var wg sync.WaitGroup
+		statuses := make([]int, 2)
+		for i := range statuses {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				resp, _ := post(t, client, slow.URL+"/mcp")
+				statuses[i] = resp.StatusCode
+			}()
+		}
+		if resp, _ := post(t, client, fast.URL+"/mcp"); resp.StatusCode != http.StatusOK {
+			t.Fatalf("Expected the other server to be authorized, got %d", resp.StatusCode)
+		}
+		close(release)
+		wg.Wait()
+		if statuses[0] != http.StatusOK || statuses[1] != http.StatusOK {
+			t.Errorf("Expected both requests to succeed, got %v", statuses)
+		}
+		if got := consents.Load(); got != 2 {
+			t.Errorf("Expected one consent per server, got %d", got)
+		}
+	})
+
+	t.Run("Negative Test - Consent fails", func(t *testing.T) {
+		server := newProtectedServer(t)
+		testCases := []struct {
+			name    string
+			handler AuthorizationHandler
+			wantErr string
+		}{
+			{"Denied", func(context.Context, string) (string, string, error) { return "", "", errors.New("access_denied") }, "access_denied"},
+			{"State mismatch", func(context.Context, string) (string, string, error) { return "code", "forged", nil }, "state does not match"},
+			{"No handler", nil, "Handler cannot be nil"},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				authorizer := &MCPAuthorizer{Handler: tc.handler}
+				client := &http.Client{Transport: authorizer.Transport(nil)}
+				_, err := client.Post(server.URL+"/mcp", "application/json", strings.NewReader("{}"))
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Errorf("Expected error containing %q, got %v", tc.wantErr, err)
+				}
+			})
+		}
+	})
+}
+
+func TestDiscover(t *testing.T) {
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		switch r.URL.Path {
+		case "/.well-known/oauth-protected-resource":
+			_, _ = w.Write([]byte(`{"resource":"https://mcp.example.com","authorization_servers":["https://as.example.com"]}`))
+		case "/tenant/.well-known/openid-configuration":
+			_, _ = w.Write([]byte(`{"issuer":"x","authorization_endpoint":"https://as/authorize","token_endpoint":"https://as/token"}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+	ctx := context.Background()
+
+	t.Run("Falls back to the root resource metadata", func(t *testing.T) {
+		paths = nil
+		metadata, err := DiscoverProtectedResource(ctx, server.Client(), server.URL+"/mcp", "")
+		if err != nil {
+			t.Fatalf("DiscoverProtectedResource failed: %v", err)
+		}
+		if metadata.AuthorizationServers[0] != "https://as.example.com" {
+			t.Errorf("Unexpected metadata: %+v", metadata)
+		}
+		want := []string{"/.well-known/oauth-protected-resource/mcp", "/.well-known/oauth-protected-resource"}
+		if !reflect.DeepEqual(paths, want) {
+			t.Errorf("Unexpected requests: %v", paths)
+		}
+	})
+
+	t.Run("Tries every authorization server location", func(t *testing.T) {
+		paths = nil
+		metadata, err := DiscoverAuthorizationServer(ctx, server.Client(), server.URL+"/tenant")
+		if err != nil {
+			t.Fatalf("DiscoverAuthorizationServer failed: %v", err)
+		}
+		if metadata.TokenEndpoint != "https://as/token" {
+			t.Errorf("Unexpected metadata: %+v", metadata)
+		}
+		if len(paths) != 5 {
+			t.Errorf("Unexpected requests: %v", paths)
+		}
+	})
+
+	t.Run("Skips locations that cannot be reached", func(t *testing.T) {
+		paths = nil
+		client := &http.Client{Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+			if strings.HasSuffix(r.URL.Path, "/mcp") {
+				return nil, errors.New("connection reset")
+			}
+			return http.DefaultTransport.RoundTrip(r)
+		})}
+		metadata, err := DiscoverProtectedResource(ctx, client, server.URL+"/mcp", "")
+		if err != nil {
+			t.Fatalf("DiscoverProtectedResource failed: %v", err)
+		}
+		if metadata.Resource != "https://mcp.example.com" {
+			t.Errorf("Unexpected metadata: %+v", metadata)
+		}
+	})
+
+	t.Run("Negative Test - No metadata", func(t *testing.T) {
+		_, err := DiscoverAuthorizationServer(ctx, server.Client(), server.URL)
+		if err == nil || !strings.Contains(err.Error(), "returned status 404") {
+			t.Errorf("Expected a discovery error, got %v", err)
+		}
+	})
+}
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) { return f(r) }
@@ -0,0 +1,246 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/googleapis/mcp-toolbox-sdk-go/core/internal/tokencache"
+	"golang.org/x/oauth2"
+)
+
+// Token type identifiers defined by RFC 8693.
+const (
+	TokenTypeAccessToken  = "urn:ietf:params:oauth:token-type:access_token"
+	TokenTypeRefreshToken = "urn:ietf:params:oauth:token-type:refresh_token"
+	TokenTypeIDToken      = "urn:ietf:params:oauth:token-type:id_token"
+	TokenTypeJWT          = "urn:ietf:params:oauth:token-type:jwt"
+)
+
+const tokenExchangeGrantType = "urn:ietf:params:oauth:grant-type:token-exchange"
+
+// TokenExchangeConfig describes an OAuth 2.0 token exchange (RFC 8693), in
+// which a subject token, such as an incoming end-user credential, is traded
+// at a security token service for the token a downstream tool requires.
+type TokenExchangeConfig struct {
+	// TokenURL is the token endpoint of the security token service.
+	TokenURL string
+	// SubjectTokenSource provides the token to exchange.
+	SubjectTokenSource oauth2.TokenSource
+	// SubjectTokenType is the type of the subject token. Defaults to
+	// TokenTypeAccessToken.
+	SubjectTokenType string
+	// ActorTokenSource optionally provides a token for the acting party.
+	ActorTokenSource oauth2.TokenSource
+	// ActorTokenType is the type of the actor token. Defaults to
+	// TokenTypeAccessToken when ActorTokenSource is set.
+	ActorTokenType string
+	// RequestedTokenType is the type of token to request, if any.
+	RequestedTokenType string
+	// Audience is the logical name of the service the token is for.
+	Audience string
+	// Resource is the URI of the service the token is for.
+	Resource string
+	// Scopes are the scopes to request.
+	Scopes []string
+	// ClientID and ClientSecret, if set, authenticate the client to the
+	// security token service with HTTP Basic authentication.
+	ClientID     string
+	ClientSecret string
+	// HTTPClient is used for the exchange. Defaults to http.DefaultClient.
+	HTTPClient *http.Client
+}
+
+// NewTokenExchangeSource returns a token source that performs the configured
+// token exchange whenever a new token is needed. Exchanged tokens are cached
+// until shortly before they expire, and never outlive the subject token they
+// were exchanged for: a token expires no later than its subject token, and a
+// new subject token, for example after rotation, is exchanged again. Tokens
+// whose lifetime is unknown, because neither the response nor the subject
+// token carries an expiry, are not cached.
+//
+// Inputs:
+//   - ctx: The context used for exchange requests.
+//   - config: The exchange configuration.
+//
+// Returns:
+//
+//	An oauth2.TokenSource, or an error if the configuration is incomplete.
+func NewTokenExchangeSource(ctx context.Context, config TokenExchangeConfig) (oauth2.TokenSource, error) {
+	if config.TokenURL == "" {
+		return nil, fmt.Errorf("NewTokenExchangeSource: TokenURL cannot be empty")
+	}
+	if config.SubjectTokenSource == nil {
+		return nil, fmt.Errorf("NewTokenExchangeSource: SubjectTokenSource cannot be nil")
+	}
+	if config.SubjectTokenType == "" {
+		config.SubjectTokenType = TokenTypeAccessToken
+	}
+	if config.ActorTokenSource != nil && config.ActorTokenType == "" {
+		config.ActorTokenType = TokenTypeAccessToken
+	}
+	if config.HTTPClient == nil {
+		config.HTTPClient = http.DefaultClient
+	}
+	return &tokenExchangeSource{ctx: ctx, config: config}, nil
+}
+
+// tokenExchangeSource caches the tokens exchanged for the current subject
+// token.
+type tokenExchangeSource struct {
+	ctx    context.Context
+	config TokenExchangeConfig
+
+	mu      sync.Mutex
+	subject string
+	cached  oauth2.TokenSource
+}
+
+// Token returns a cached token exchanged for the current subject token, or
+// exchanges the subject token for a new one.
+func (s *tokenExchangeSource) Token() (*oauth2.Token, error) {
+	subject, err := s.config.SubjectTokenSource.Token()
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve subject token: %w", err)
+	}
+	s.mu.Lock()
+	if s.cached == nil || subject.AccessToken != s.subject {
+		s.subject = subject.AccessToken
+		s.cached = tokencache.Reuse(&subjectExchange{source: s, subject: subject}, tokencache.DefaultSkew)
+	}
+	cached := s.cached
+	s.mu.Unlock()
+	return cached.Token()
+}
+
+// subjectExchange exchanges a fixed subject token.
+type subjectExchange struct {
+	source  *tokenExchangeSource
+	subject *oauth2.Token
+}
+
+func (e *subjectExchange) Token() (*oauth2.Token, error) {
+	return e.source.exchange(e.subject)
+}
+
+// tokenExchangeResponse is the successful response of a token exchange.
+type tokenExchangeResponse struct {
+	AccessToken     string `json:"access_token"`
+	IssuedTokenType string `json:"issued_token_type"`
+	TokenType       string `json:"token_type"`
+	ExpiresIn       int64  `json:"expires_in"`
+	RefreshToken    string `json:"refresh_token"`
+	Scope           string `json:"scope"`
+}
+
+// tokenExchangeError is the error response of a token exchange.
+type tokenExchangeError struct {
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+// exchange exchanges subject for a new token, which expires no later than
+// subject.
+func (s *tokenExchangeSource) exchange(subject *oauth2.Token) (*oauth2.Token, error) {
+	cfg := s.config
+	form := url.Values{
+		"grant_type":         {tokenExchangeGrantType},
+		"subject_token":      {subject.AccessToken},
+		"subject_token_type": {cfg.SubjectTokenType},
+	}
+	if cfg.ActorTokenSource != nil {
+		actor, err := cfg.ActorTokenSource.Token()
+		if err != nil {
+			return nil, fmt.Errorf("failed to retrieve actor token: %w", err)
+		}
+		form.Set("actor_token", actor.AccessToken)
+		form.Set("actor_token_type", cfg.ActorTokenType)
+	}
+	if cfg.RequestedTokenType != "" {
+		form.Set("requested_token_type", cfg.RequestedTokenType)
+	}
+	if cfg.Audience != "" {
+		form.Set("audience", cfg.Audience)
+	}
+	if cfg.Resource != "" {
+		form.Set("resource", cfg.Resource)
+	}
+	if len(cfg.Scopes) > 0 {
+		form.Set("scope", strings.Join(cfg.Scopes, " "))
+	}
+
+	req, err := http.NewRequestWithContext(s.ctx, http.MethodPost, cfg.TokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create token exchange request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	if cfg.ClientID != "" {
+		req.SetBasicAuth(url.QueryEscape(cfg.ClientID), url.QueryEscape(cfg.ClientSecret))
+	}
+
+	resp, err := cfg.HTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("token exchange request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read token exchange response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		var exchangeErr tokenExchangeError
+		if json.Unmarshal(body, &exchangeErr) == nil && exchangeErr.Error != "" {
+			if exchangeErr.ErrorDescription != "" {
+				return nil, fmt.Errorf("token exchange failed with status %d: %s: %s", resp.StatusCode, exchangeErr.Error, exchangeErr.ErrorDescription)
+			}
+			return nil, fmt.Errorf("token exchange failed with status %d: %s", resp.StatusCode, exchangeErr.Error)
+		}
+		return nil, fmt.Errorf("token exchange failed with status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var result tokenExchangeResponse
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("failed to decode token exchange response: %w", err)
+	}
+	if result.AccessToken == "" {
+		return nil, fmt.Errorf("token exchange response does not contain an access_token")
+	}
+
+	token := &oauth2.Token{
+		AccessToken:  result.AccessToken,
+		TokenType:    result.TokenType,
+		RefreshToken: result.RefreshToken,
+	}
+	if result.ExpiresIn > 0 {
+		token.Expiry = time.Now().Add(time.Duration(result.ExpiresIn) * time.Second)
+	}
+	if !subject.Expiry.IsZero() && (token.Expiry.IsZero() || subject.Expiry.Before(token.Expiry)) {
+		token.Expiry = subject.Expiry
+	}
+	return token.WithExtra(map[string]any{
+		"issued_token_type": result.IssuedTokenType,
+		"scope":             result.Scope,
+	}), nil
+}
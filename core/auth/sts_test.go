@@ -0,0 +1,161 @@
+//go:build unit
+
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+func TestNewTokenExchangeSource(t *testing.T) {
+	subject := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "user-token"})
+
+	t.Run("Exchanges and caches the subject token", func(t *testing.T) {
+		var form url.Values
+		var user, pass string
+		calls := 0
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls++
+			_ = r.ParseForm()
+			form = r.PostForm
+			user, pass, _ = r.BasicAuth()
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"access_token":"downstream","issued_token_type":"urn:ietf:params:oauth:token-type:access_token","token_type":"Bearer","expires_in":3600}`))
+		}))
+		defer server.Close()
+
+		ts, err := NewTokenExchangeSource(context.Background(), TokenExchangeConfig{
+			TokenURL:           server.URL,
+			SubjectTokenSource: subject,
+			Audience:           "toolbox",
+			Scopes:             []string{"a", "b"},
+			ClientID:           "client",
+			ClientSecret:       "secret",
+		})
+		if err != nil {
+			t.Fatalf("NewTokenExchangeSource failed: %v", err)
+		}
+
+		for i := 0; i < 2; i++ {
+			token, err := ts.Token()
+			if err != nil {
+				t.Fatalf("Token failed: %v", err)
+			}
+			if token.AccessToken != "downstream" {
+				t.Errorf("Expected the exchanged token, got %q", token.AccessToken)
+			}
+		}
+		if calls != 1 {
+			t.Errorf("Expected one exchange request, got %d", calls)
+		}
+
+		want := url.Values{
+			"grant_type":         {"urn:ietf:params:oauth:grant-type:token-exchange"},
+			"subject_token":      {"user-token"},
+			"subject_token_type": {TokenTypeAccessToken},
+			"audience":           {"toolbox"},
+			"scope":              {"a b"},
+		}
+		for k, v := range want {
+			if form.Get(k) != v[0] {
+				t.Errorf("Expected form value %s=%q, got %q", k, v[0], form.Get(k))
+			}
+		}
+		if user != "client" || pass != "secret" {
+			t.Errorf("Expected basic auth client:secret, got %s:%s", user, pass)
+		}
+	})
+
+	t.Run("Ties cached tokens to the subject token", func(t *testing.T) {
+		var subjects []string
+		response := `{"access_token":"downstream","expires_in":3600}`
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_ = r.ParseForm()
+			subjects = append(subjects, r.PostForm.Get("subject_token"))
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(response))
+		}))
+		defer server.Close()
+
+		subjectExpiry := time.Now().Add(time.Minute)
+		current := &oauth2.Token{AccessToken: "user-1", Expiry: subjectExpiry}
+		rotating := tokenSourceFunc(func() (*oauth2.Token, error) { return current, nil })
+		ts, err := NewTokenExchangeSource(context.Background(), TokenExchangeConfig{TokenURL: server.URL, SubjectTokenSource: rotating})
+		if err != nil {
+			t.Fatalf("NewTokenExchangeSource failed: %v", err)
+		}
+
+		token, err := ts.Token()
+		if err != nil {
+			t.Fatalf("Token failed: %v", err)
+		}
+		if !token.Expiry.Equal(subjectExpiry) {
+			t.Errorf("Expected the token to expire with the subject token at %v, got %v", subjectExpiry, token.Expiry)
+		}
+		_, _ = ts.Token()
+
+		current = &oauth2.Token{AccessToken: "user-2", Expiry: subjectExpiry}
+		_, _ = ts.Token()
+
+		// Without any expiry, the lifetime of the token is unknown.
+		current = &oauth2.Token{AccessToken: "user-3"}
+		response = `{"access_token":"downstream"}`
+		_, _ = ts.Token()
+		_, _ = ts.Token()
+
+		if want := []string{"user-1", "user-2", "user-3", "user-3"}; !reflect.DeepEqual(subjects, want) {
+			t.Errorf("Expected exchanges for subjects %v, got %v", want, subjects)
+		}
+	})
+
+	t.Run("Negative Test - Surfaces OAuth errors", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"error":"invalid_target","error_description":"unknown audience"}`))
+		}))
+		defer server.Close()
+
+		ts, _ := NewTokenExchangeSource(context.Background(), TokenExchangeConfig{TokenURL: server.URL, SubjectTokenSource: subject})
+		_, err := ts.Token()
+		if err == nil || !strings.Contains(err.Error(), "status 400: invalid_target: unknown audience") {
+			t.Errorf("Expected an OAuth error, got %v", err)
+		}
+	})
+
+	t.Run("Negative Test - Invalid configuration", func(t *testing.T) {
+		if _, err := NewTokenExchangeSource(context.Background(), TokenExchangeConfig{SubjectTokenSource: subject}); err == nil {
+			t.Error("Expected an error for a missing TokenURL")
+		}
+		if _, err := NewTokenExchangeSource(context.Background(), TokenExchangeConfig{TokenURL: "https://sts"}); err == nil {
+			t.Error("Expected an error for a missing SubjectTokenSource")
+		}
+	})
+}
+
+// tokenSourceFunc adapts a function to oauth2.TokenSource.
+type tokenSourceFunc func() (*oauth2.Token, error)
+
+func (f tokenSourceFunc) Token() (*oauth2.Token, error) { return f() }
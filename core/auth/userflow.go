@@ -0,0 +1,305 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+// idTokenRefreshSkew is how long before the expiry of an ID token a new one
+// is requested.
+const idTokenRefreshSkew = 10 * time.Second
+
+// ErrTokenNotFound is returned by a TokenStore when no token is stored under
+// the requested key.
+var ErrTokenNotFound = errors.New("token not found")
+
+// TokenStore persists end-user OAuth tokens between sessions.
+type TokenStore interface {
+	// Load returns the token stored under key, or ErrTokenNotFound.
+	Load(ctx context.Context, key string) (*oauth2.Token, error)
+	// Save stores token under key, replacing any previous token.
+	Save(ctx context.Context, key string, token *oauth2.Token) error
+}
+
+// MemoryTokenStore is a TokenStore that keeps tokens in memory. It is safe
+// for concurrent use.
+type MemoryTokenStore struct {
+	mu     sync.Mutex
+	tokens map[string]*oauth2.Token
+}
+
+// NewMemoryTokenStore creates an empty MemoryTokenStore.
+func NewMemoryTokenStore() *MemoryTokenStore {
+	return &MemoryTokenStore{tokens: make(map[string]*oauth2.Token)}
+}
+
+// Load returns the token stored under key, or ErrTokenNotFound.
+func (m *MemoryTokenStore) Load(_ context.Context, key string) (*oauth2.Token, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	token, ok := m.tokens[key]
+	if !ok {
+		return nil, ErrTokenNotFound
+	}
+	return token, nil
+}
+
+// Save stores token under key, replacing any previous token.
+func (m *MemoryTokenStore) Save(_ context.Context, key string, token *oauth2.Token) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.tokens[key] = token
+	return nil
+}
+
+// UserFlow drives the OAuth 2.0 authorization code flow with PKCE for an end
+// user, and turns the resulting tokens into a token source that can be passed
+// to core.WithAuthTokenSource.
+//
+// A typical interactive application redirects the user to the URL returned by
+// AuthCodeURL, calls Exchange with the code received on the redirect, and then
+// uses TokenSource for every subsequent session.
+type UserFlow struct {
+	// Config is the OAuth 2.0 client configuration, including the endpoint,
+	// client credentials, redirect URL and scopes.
+	Config *oauth2.Config
+	// Store persists the user's tokens. Refreshed tokens are saved back to it.
+	Store TokenStore
+	// Key identifies the user's token in Store.
+	Key string
+	// UseIDToken makes the token source return the OpenID Connect ID token
+	// instead of the access token, as required by Toolbox auth services that
+	// verify Google ID tokens. The ID token expires at its "exp" claim.
+	// Stores cannot persist the ID token of an oauth2.Token, so the token is
+	// refreshed to obtain a new ID token after it is loaded from the store.
+	UseIDToken bool
+}
+
+// AuthCodeURL returns the URL to which the user should be sent to grant
+// consent, together with the PKCE verifier that must later be passed to
+// Exchange.
+//
+// Inputs:
+//   - state: An opaque value that protects against CSRF and is echoed back on
+//     the redirect.
+//
+// Returns:
+//
+//	The consent URL and the PKCE verifier.
+func (f *UserFlow) AuthCodeURL(state string, opts ...oauth2.AuthCodeOption) (string, string) {
+	verifier := oauth2.GenerateVerifier()
+	opts = append(opts, oauth2.AccessTypeOffline, oauth2.S256ChallengeOption(verifier))
+	return f.Config.AuthCodeURL(state, opts...), verifier
+}
+
+// Exchange trades an authorization code for a token and saves it to the
+// store.
+//
+// Inputs:
+//   - ctx: The context for the token request.
+//   - code: The authorization code received on the redirect.
+//   - verifier: The PKCE verifier returned by AuthCodeURL.
+//   - opts: Additional parameters of the token request.
+//
+// Returns:
+//
+//	The token on success, or an error if the exchange or save fails.
+func (f *UserFlow) Exchange(ctx context.Context, code string, verifier string, opts ...oauth2.AuthCodeOption) (*oauth2.Token, error) {
+	token, err := f.Config.Exchange(ctx, code, append(opts, oauth2.VerifierOption(verifier))...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to exchange authorization code: %w", err)
+	}
+	if err := f.Store.Save(ctx, f.Key, token); err != nil {
+		return nil, fmt.Errorf("failed to save token: %w", err)
+	}
+	return token, nil
+}
+
+// TokenSource returns a token source for the user's stored token. The token
+// is refreshed automatically when it expires, and refreshed tokens are saved
+// back to the store.
+//
+// Inputs:
+//   - ctx: The context used for token refresh requests.
+//
+// Returns:
+//
+//	An oauth2.TokenSource, or an error wrapping ErrTokenNotFound if the user
+//	has not completed the flow yet.
+func (f *UserFlow) TokenSource(ctx context.Context) (oauth2.TokenSource, error) {
+	token, err := f.Store.Load(ctx, f.Key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load token for '%s': %w", f.Key, err)
+	}
+	ts := &storingTokenSource{
+		ctx:     ctx,
+		config:  f.Config,
+		base:    f.Config.TokenSource(ctx, token),
+		store:   f.Store,
+		key:     f.Key,
+		current: token,
+	}
+	if f.UseIDToken {
+		return &idTokenSource{base: ts}, nil
+	}
+	return ts, nil
+}
+
+// storingTokenSource saves tokens to a TokenStore whenever they change.
+type storingTokenSource struct {
+	ctx    context.Context
+	config *oauth2.Config
+	store  TokenStore
+	key    string
+
+	mu      sync.Mutex
+	base    oauth2.TokenSource
+	current *oauth2.Token
+}
+
+// Token returns a valid token, saving it to the store if it was refreshed.
+func (s *storingTokenSource) Token() (*oauth2.Token, error) {
+	s.mu.Lock()
+	base := s.base
+	s.mu.Unlock()
+	token, err := base.Token()
+	if err != nil {
+		return nil, err
+	}
+	return token, s.save(token)
+}
+
+// refresh obtains a new token with the refresh token, even if the current
+// token is still valid, and saves it to the store.
+func (s *storingTokenSource) refresh() (*oauth2.Token, error) {
+	s.mu.Lock()
+	refreshToken := s.current.RefreshToken
+	s.mu.Unlock()
+	if refreshToken == "" {
+		return nil, fmt.Errorf("no refresh token")
+	}
+	token, err := s.config.TokenSource(s.ctx, &oauth2.Token{RefreshToken: refreshToken}).Token()
+	if err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	s.base = s.config.TokenSource(s.ctx, token)
+	s.mu.Unlock()
+	return token, s.save(token)
+}
+
+// save stores token if it differs from the current one.
+func (s *storingTokenSource) save(token *oauth2.Token) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if token.AccessToken == s.current.AccessToken {
+		return nil
+	}
+	if err := s.store.Save(s.ctx, s.key, token); err != nil {
+		return fmt.Errorf("failed to save refreshed token: %w", err)
+	}
+	s.current = token
+	return nil
+}
+
+// idTokenSource exposes the OpenID Connect ID token of its base source's
+// tokens as their access token, refreshing the base token when it carries no
+// ID token or an expired one.
+type idTokenSource struct {
+	base *storingTokenSource
+
+	mu      sync.Mutex
+	idToken *oauth2.Token
+}
+
+// Token returns a token carrying a valid ID token.
+func (s *idTokenSource) Token() (*oauth2.Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.idToken != nil && unexpired(s.idToken.Expiry) {
+		return s.idToken, nil
+	}
+
+	token, err := s.base.Token()
+	if err != nil {
+		return nil, err
+	}
+	idToken, expiry := idTokenOf(token)
+	if idToken == "" || !unexpired(expiry) {
+		// The token was loaded from the store, which keeps no ID token, or
+		// its ID token outlived the access token.
+		if token, err = s.base.refresh(); err != nil {
+			return nil, fmt.Errorf("token does not contain an id_token and could not be refreshed: %w", err)
+		}
+		if idToken, expiry = idTokenOf(token); idToken == "" {
+			return nil, fmt.Errorf("token response does not contain an id_token")
+		}
+	}
+	withID := *token
+	withID.AccessToken = idToken
+	withID.Expiry = expiry
+	s.idToken = &withID
+	return s.idToken, nil
+}
+
+// unexpired reports whether a token with the given expiry, where zero means
+// none, is valid for a while longer.
+func unexpired(expiry time.Time) bool {
+	return expiry.IsZero() || time.Now().Add(idTokenRefreshSkew).Before(expiry)
+}
+
+// idTokenOf returns the ID token carried by a token response and its expiry,
+// taken from the "exp" claim of the ID token, or from the token if the ID
+// token cannot be decoded.
+func idTokenOf(token *oauth2.Token) (string, time.Time) {
+	idToken, _ := token.Extra("id_token").(string)
+	if idToken == "" {
+		return "", time.Time{}
+	}
+	if exp, ok := jwtExpiry(idToken); ok {
+		return idToken, exp
+	}
+	return idToken, token.Expiry
+}
+
+// jwtExpiry returns the time of the "exp" claim of a JWT, without verifying
+// its signature.
+func jwtExpiry(jwt string) (time.Time, bool) {
+	parts := strings.Split(jwt, ".")
+	if len(parts) != 3 {
+		return time.Time{}, false
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return time.Time{}, false
+	}
+	var claims struct {
+		Exp float64 `json:"exp"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil || claims.Exp == 0 {
+		return time.Time{}, false
+	}
+	return time.Unix(int64(claims.Exp), 0), true
+}
@@ -0,0 +1,238 @@
+//go:build unit
+
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+// newTokenServer returns an OAuth token endpoint that records the form values
+// of the last request and answers with the given access token.
+func newTokenServer(t *testing.T, accessToken string, form *url.Values) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Errorf("failed to parse form: %v", err)
+		}
+		*form = r.PostForm
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"access_token":  accessToken,
+			"token_type":    "Bearer",
+			"expires_in":    3600,
+			"refresh_token": "refresh-1",
+			"id_token":      "id-" + accessToken,
+		})
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// jsonTokenStore is a TokenStore that serializes tokens, like a store backed
+// by a file or a database, and so drops their extra fields.
+type jsonTokenStore struct {
+	tokens map[string][]byte
+}
+
+func (s *jsonTokenStore) Load(_ context.Context, key string) (*oauth2.Token, error) {
+	data, ok := s.tokens[key]
+	if !ok {
+		return nil, ErrTokenNotFound
+	}
+	var token oauth2.Token
+	err := json.Unmarshal(data, &token)
+	return &token, err
+}
+
+func (s *jsonTokenStore) Save(_ context.Context, key string, token *oauth2.Token) error {
+	data, err := json.Marshal(token)
+	s.tokens[key] = data
+	return err
+}
+
+func newTestFlow(tokenURL string) *UserFlow {
+	return &UserFlow{
+		Config: &oauth2.Config{
+			ClientID:    "client",
+			RedirectURL: "http://localhost/callback",
+			Endpoint: oauth2.Endpoint{
+				AuthURL:   "https://auth.example.com/authorize",
+				TokenURL:  tokenURL,
+				AuthStyle: oauth2.AuthStyleInParams,
+			},
+		},
+		Store: NewMemoryTokenStore(),
+		Key:   "user-1",
+	}
+}
+
+func TestUserFlow(t *testing.T) {
+	t.Run("AuthCodeURL includes a PKCE challenge", func(t *testing.T) {
+		flow := newTestFlow("https://auth.example.com/token")
+		authURL, verifier := flow.AuthCodeURL("state-1")
+
+		parsed, err := url.Parse(authURL)
+		if err != nil {
+			t.Fatalf("invalid auth URL: %v", err)
+		}
+		query := parsed.Query()
+		if query.Get("state") != "state-1" || query.Get("code_challenge_method") != "S256" {
+			t.Errorf("Unexpected auth URL query: %v", query)
+		}
+		if query.Get("code_challenge") != oauth2.S256ChallengeFromVerifier(verifier) {
+			t.Error("Expected the code challenge to match the verifier")
+		}
+	})
+
+	t.Run("Exchange sends the verifier and stores the token", func(t *testing.T) {
+		var form url.Values
+		server := newTokenServer(t, "access-1", &form)
+		flow := newTestFlow(server.URL)
+
+		token, err := flow.Exchange(context.Background(), "code-1", "verifier-1")
+		if err != nil {
+			t.Fatalf("Exchange failed: %v", err)
+		}
+		if form.Get("code") != "code-1" || form.Get("code_verifier") != "verifier-1" {
+			t.Errorf("Unexpected token request: %v", form)
+		}
+		stored, err := flow.Store.Load(context.Background(), "user-1")
+		if err != nil || stored.AccessToken != token.AccessToken {
+			t.Errorf("Expected token to be stored, got %v, %v", stored, err)
+		}
+	})
+
+	t.Run("TokenSource refreshes and saves expired tokens", func(t *testing.T) {
+		var form url.Values
+		server := newTokenServer(t, "access-2", &form)
+		flow := newTestFlow(server.URL)
+		expired := &oauth2.Token{AccessToken: "access-1", RefreshToken: "refresh-1", Expiry: time.Now().Add(-time.Hour)}
+		_ = flow.Store.Save(context.Background(), "user-1", expired)
+
+		ts, err := flow.TokenSource(context.Background())
+		if err != nil {
+			t.Fatalf("TokenSource failed: %v", err)
+		}
+		token, err := ts.Token()
+		if err != nil {
+			t.Fatalf("Token failed: %v", err)
+		}
+		if token.AccessToken != "access-2" || form.Get("grant_type") != "refresh_token" {
+			t.Errorf("Expected a refreshed token, got %q (request %v)", token.AccessToken, form)
+		}
+		stored, _ := flow.Store.Load(context.Background(), "user-1")
+		if stored.AccessToken != "access-2" {
+			t.Errorf("Expected the refreshed token to be saved, got %q", stored.AccessToken)
+		}
+	})
+
+	t.Run("TokenSource can return the ID token", func(t *testing.T) {
+		var form url.Values
+		server := newTokenServer(t, "access-3", &form)
+		flow := newTestFlow(server.URL)
+		flow.UseIDToken = true
+		if _, err := flow.Exchange(context.Background(), "code", "verifier"); err != nil {
+			t.Fatalf("Exchange failed: %v", err)
+		}
+
+		ts, err := flow.TokenSource(context.Background())
+		if err != nil {
+			t.Fatalf("TokenSource failed: %v", err)
+		}
+		token, err := ts.Token()
+		if err != nil {
+			t.Fatalf("Token failed: %v", err)
+		}
+		if token.AccessToken != "id-access-3" {
+			t.Errorf("Expected the ID token, got %q", token.AccessToken)
+		}
+	})
+
+	t.Run("TokenSource refreshes to obtain the ID token of a reloaded token", func(t *testing.T) {
+		var form url.Values
+		server := newTokenServer(t, "access-4", &form)
+		flow := newTestFlow(server.URL)
+		flow.UseIDToken = true
+		flow.Store = &jsonTokenStore{tokens: map[string][]byte{}}
+		if _, err := flow.Exchange(context.Background(), "code", "verifier"); err != nil {
+			t.Fatalf("Exchange failed: %v", err)
+		}
+		form = nil
+
+		ts, err := flow.TokenSource(context.Background())
+		if err != nil {
+			t.Fatalf("TokenSource failed: %v", err)
+		}
+		token, err := ts.Token()
+		if err != nil {
+			t.Fatalf("Token failed: %v", err)
+		}
+		if token.AccessToken != "id-access-4" || form.Get("grant_type") != "refresh_token" {
+			t.Errorf("Expected the ID token of a refreshed token, got %q (request %v)", token.AccessToken, form)
+		}
+	})
+
+	t.Run("ID tokens expire at their exp claim", func(t *testing.T) {
+		exp := time.Now().Add(5 * time.Minute).Truncate(time.Second)
+		claims, _ := json.Marshal(map[string]any{"exp": exp.Unix()})
+		jwt := "e30." + base64.RawURLEncoding.EncodeToString(claims) + ".sig"
+		flow := newTestFlow("https://auth.example.com/token")
+		flow.UseIDToken = true
+		stored := (&oauth2.Token{AccessToken: "a", Expiry: time.Now().Add(time.Hour)}).WithExtra(map[string]any{"id_token": jwt})
+		_ = flow.Store.Save(context.Background(), "user-1", stored)
+
+		ts, _ := flow.TokenSource(context.Background())
+		token, err := ts.Token()
+		if err != nil {
+			t.Fatalf("Token failed: %v", err)
+		}
+		if token.AccessToken != jwt || !token.Expiry.Equal(exp) {
+			t.Errorf("Expected the ID token expiring at %v, got %q expiring at %v", exp, token.AccessToken, token.Expiry)
+		}
+	})
+
+	t.Run("Negative Test - TokenSource without a stored token", func(t *testing.T) {
+		flow := newTestFlow("https://auth.example.com/token")
+		_, err := flow.TokenSource(context.Background())
+		if !errors.Is(err, ErrTokenNotFound) {
+			t.Errorf("Expected ErrTokenNotFound, got %v", err)
+		}
+	})
+
+	t.Run("Negative Test - ID token missing from the response", func(t *testing.T) {
+		flow := newTestFlow("https://auth.example.com/token")
+		flow.UseIDToken = true
+		_ = flow.Store.Save(context.Background(), "user-1", &oauth2.Token{AccessToken: "a", Expiry: time.Now().Add(time.Hour)})
+
+		ts, _ := flow.TokenSource(context.Background())
+		_, err := ts.Token()
+		if err == nil || !strings.Contains(err.Error(), "does not contain an id_token") {
+			t.Errorf("Expected a missing id_token error, got %v", err)
+		}
+	})
+}
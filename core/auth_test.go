@@ -17,8 +17,15 @@
 package core
 
 import (
+	"bytes"
 	"context"
+	"encoding/base64"
+	"encoding/json"
 	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
 	"strings"
 	"testing"
 	"time"
@@ -142,3 +149,207 @@ func TestGetGoogleIDToken_TokenFetchError(t *testing.T) {
 		t.Errorf("Expected error message to contain '%s', but got: %v", expectedErr.Error(), err)
 	}
 }
+
+// makeJWT builds an unsigned JWT carrying the given claims.
+func makeJWT(t *testing.T, claims map[string]any) string {
+	t.Helper()
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		t.Fatalf("failed to marshal claims: %v", err)
+	}
+	return "eyJhbGciOiJSUzI1NiJ9." + base64.RawURLEncoding.EncodeToString(payload) + ".sig"
+}
+
+func TestValidateAuthToken(t *testing.T) {
+	now := time.Now()
+	future := float64(now.Add(time.Hour).Unix())
+	past := float64(now.Add(-time.Hour).Unix())
+
+	testCases := []struct {
+		name     string
+		token    *oauth2.Token
+		audience string
+		wantErr  string
+	}{
+		{
+			name:  "Opaque token without expiry",
+			token: &oauth2.Token{AccessToken: "opaque"},
+		},
+		{
+			name:    "Empty token",
+			token:   &oauth2.Token{},
+			wantErr: "token for service 'my-auth' is empty",
+		},
+		{
+			name:    "Expired oauth2 token",
+			token:   &oauth2.Token{AccessToken: "opaque", Expiry: now.Add(-time.Minute)},
+			wantErr: "token for service 'my-auth' expired",
+		},
+		{
+			name:     "Valid JWT with matching audience",
+			token:    &oauth2.Token{AccessToken: makeJWT(t, map[string]any{"exp": future, "aud": "https://toolbox"})},
+			audience: "https://toolbox",
+		},
+		{
+			name:     "Bearer JWT with audience list",
+			token:    &oauth2.Token{AccessToken: "Bearer " + makeJWT(t, map[string]any{"aud": []any{"a", "https://toolbox"}})},
+			audience: "https://toolbox",
+		},
+		{
+			name:    "Expired JWT",
+			token:   &oauth2.Token{AccessToken: makeJWT(t, map[string]any{"exp": past})},
+			wantErr: "token for service 'my-auth' expired",
+		},
+		{
+			name:     "JWT with wrong audience",
+			token:    &oauth2.Token{AccessToken: makeJWT(t, map[string]any{"exp": future, "aud": "other"})},
+			audience: "https://toolbox",
+			wantErr:  "token for service 'my-auth' is not valid for audience 'https://toolbox'",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateAuthToken("my-auth", tc.token, tc.audience, now)
+			if tc.wantErr == "" {
+				if err != nil {
+					t.Errorf("Expected no error, got %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Errorf("Expected error containing %q, got %v", tc.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestIDTokenRetryTransport(t *testing.T) {
+	newServer := func(t *testing.T, seen *[]string) *httptest.Server {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			*seen = append(*seen, r.Header.Get("Authorization")+"|"+string(body))
+			if r.Header.Get("Authorization") != "Bearer id-token" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		}))
+		t.Cleanup(server.Close)
+		return server
+	}
+	mockIDToken := func(aud *string) {
+		newTokenSource = func(ctx context.Context, audience string, opts ...option.ClientOption) (oauth2.TokenSource, error) {
+			*aud = audience
+			return &mockAuthTokenSource{tokenToReturn: &oauth2.Token{AccessToken: "id-token"}}, nil
+		}
+	}
+
+	t.Run("Retries with an ID token after a 401", func(t *testing.T) {
+		setup(t)
+		var audience string
+		mockIDToken(&audience)
+		var seen []string
+		server := newServer(t, &seen)
+
+		rt := newIDTokenRetryTransport(nil, server.URL)
+		client := &http.Client{Transport: rt}
+		for i := 0; i < 2; i++ {
+			req, _ := http.NewRequest(http.MethodPost, server.URL, bytes.NewBufferString("payload"))
+			resp, err := client.Do(req)
+			if err != nil {
+				t.Fatalf("request failed: %v", err)
+			}
+			resp.Body.Close()
+			if resp.StatusCode != http.StatusOK {
+				t.Errorf("Expected 200 after retry, got %d", resp.StatusCode)
+			}
+		}
+
+		want := []string{"|payload", "Bearer id-token|payload", "Bearer id-token|payload"}
+		if !reflect.DeepEqual(seen, want) {
+			t.Errorf("Expected requests %v, got %v", want, seen)
+		}
+		if audience != server.URL {
+			t.Errorf("Expected audience %q, got %q", server.URL, audience)
+		}
+	})
+
+	t.Run("Returns the original response if no token is available", func(t *testing.T) {
+		setup(t)
+		newTokenSource = func(ctx context.Context, audience string, opts ...option.ClientOption) (oauth2.TokenSource, error) {
+			return nil, errors.New("no credentials")
+		}
+		var seen []string
+		server := newServer(t, &seen)
+
+		client := &http.Client{Transport: newIDTokenRetryTransport(nil, server.URL)}
+		resp, err := client.Post(server.URL, "text/plain", strings.NewReader("payload"))
+		if err != nil {
+			t.Fatalf("request failed: %v", err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusUnauthorized || len(seen) != 1 {
+			t.Errorf("Expected a single 401, got %d after %d requests", resp.StatusCode, len(seen))
+		}
+	})
+
+	t.Run("Leaves explicit Authorization headers alone", func(t *testing.T) {
+		setup(t)
+		var seen []string
+		server := newServer(t, &seen)
+
+		client := &http.Client{Transport: newIDTokenRetryTransport(nil, server.URL)}
+		req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+		req.Header.Set("Authorization", "Bearer user-token")
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatalf("request failed: %v", err)
+		}
+		resp.Body.Close()
+		if len(seen) != 1 || resp.StatusCode != http.StatusUnauthorized {
+			t.Errorf("Expected no retry, got %v", seen)
+		}
+	})
+}
+
+func TestWithAutoIDToken(t *testing.T) {
+	t.Run("Wraps a copy of the HTTP client", func(t *testing.T) {
+		original := &http.Client{}
+		client, err := NewToolboxClient("https://toolbox.example.com/api", WithHTTPClient(original), WithAutoIDToken())
+		if err != nil {
+			t.Fatalf("NewToolboxClient failed: %v", err)
+		}
+		rt, ok := client.httpClient.Transport.(*idTokenRetryTransport)
+		if !ok {
+			t.Fatalf("Expected an idTokenRetryTransport, got %T", client.httpClient.Transport)
+		}
+		if rt.audience != "https://toolbox.example.com" {
+			t.Errorf("Expected audience derived from the base URL, got %q", rt.audience)
+		}
+		if original.Transport != nil {
+			t.Error("Expected the caller's http.Client to be left unmodified")
+		}
+	})
+
+	t.Run("Negative Test - Duplicate option", func(t *testing.T) {
+		_, err := NewToolboxClient("https://toolbox.example.com", WithAutoIDToken(), WithAutoIDToken())
+		if err == nil || !strings.Contains(err.Error(), "automatic ID token authentication is already set") {
+			t.Errorf("Expected a duplicate option error, got %v", err)
+		}
+	})
+
+	t.Run("Negative Test - WebSocket transport", func(t *testing.T) {
+		_, err := NewToolboxClient("wss://toolbox.example.com/mcp", WithAutoIDToken(), WithProtocol(MCPv20250618WebSocket))
+		if err == nil || !strings.Contains(err.Error(), "cannot be combined with the WebSocket transport") {
+			t.Errorf("Expected a combination error, got %v", err)
+		}
+	})
+
+	t.Run("Negative Test - URL without a host", func(t *testing.T) {
+		_, err := NewToolboxClient("not-a-url", WithAutoIDToken())
+		if err == nil || !strings.Contains(err.Error(), "cannot derive an ID token audience") {
+			t.Errorf("Expected an audience error, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,157 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+)
+
+// resultCache is a size-bounded, least-recently-used cache of invocation
+// results whose entries expire after a fixed time-to-live.
+type resultCache struct {
+	mu         sync.Mutex
+	ttl        time.Duration
+	maxEntries int
+	order      *list.List
+	entries    map[string]*list.Element
+}
+
+type cacheEntry struct {
+	key     string
+	value   any
+	expires time.Time
+}
+
+func newResultCache(ttl time.Duration, maxEntries int) *resultCache {
+	return &resultCache{
+		ttl:        ttl,
+		maxEntries: maxEntries,
+		order:      list.New(),
+		entries:    make(map[string]*list.Element),
+	}
+}
+
+// get returns a copy of the cached value for key if it is present and has not
+// expired.
+func (c *resultCache) get(key string, now time.Time) (any, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	elem, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	entry := elem.Value.(*cacheEntry)
+	if now.After(entry.expires) {
+		c.order.Remove(elem)
+		delete(c.entries, key)
+		return nil, false
+	}
+	c.order.MoveToFront(elem)
+	return copyResult(entry.value), true
+}
+
+// put stores a copy of value under key, evicting the least recently used entry if the
+// cache is full.
+func (c *resultCache) put(key string, value any, now time.Time) {
+	value = copyResult(value)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if elem, ok := c.entries[key]; ok {
+		entry := elem.Value.(*cacheEntry)
+		entry.value = value
+		entry.expires = now.Add(c.ttl)
+		c.order.MoveToFront(elem)
+		return
+	}
+	c.entries[key] = c.order.PushFront(&cacheEntry{key: key, value: value, expires: now.Add(c.ttl)})
+	for c.order.Len() > c.maxEntries {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*cacheEntry).key)
+	}
+}
+
+// copyResult returns a deep copy of the JSON values and content blocks in a
+// result, so that callers and after-invoke hooks cannot modify cached results.
+func copyResult(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		c := make(map[string]any, len(v))
+		for k, e := range v {
+			c[k] = copyResult(e)
+		}
+		return c
+	case []any:
+		c := make([]any, len(v))
+		for i, e := range v {
+			c[i] = copyResult(e)
+		}
+		return c
+	case []ContentBlock:
+		c := make([]ContentBlock, len(v))
+		for i, b := range v {
+			if b.Resource != nil {
+				r := *b.Resource
+				b.Resource = &r
+			}
+			c[i] = b
+		}
+		return c
+	default:
+		return v
+	}
+}
+
+// resultCacheIgnoredHeaders lists the request headers that vary between
+// otherwise identical calls without affecting their results, and so are left
+// out of result cache keys.
+var resultCacheIgnoredHeaders = []string{"Idempotency-Key"}
+
+// resultCacheKey derives a cache key from the canonical JSON encoding of the
+// tool name, the payload and the request headers. Map keys are sorted by
+// encoding/json, so equal inputs always produce the same key. Every header
+// except those in resultCacheIgnoredHeaders is included, so that results
+// fetched for one caller, tenant or credential are never served to another.
+func resultCacheKey(tool string, payload map[string]any, headers map[string]string) (string, error) {
+	keyed := make(map[string]string, len(headers))
+	for k, v := range headers {
+		if !slices.Contains(resultCacheIgnoredHeaders, http.CanonicalHeaderKey(k)) {
+			keyed[k] = v
+		}
+	}
+	b, err := json.Marshal(struct {
+		Tool    string            `json:"tool"`
+		Payload map[string]any    `json:"payload"`
+		Headers map[string]string `json:"headers"`
+	}{tool, payload, keyed})
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// isCacheable reports whether the tool's annotations mark it as read-only or
+// idempotent, which makes its results safe to reuse.
+func isCacheable(annotations map[string]any) bool {
+	return annotations["readOnlyHint"] == true || annotations["idempotentHint"] == true
+}
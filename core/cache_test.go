@@ -0,0 +1,144 @@
+//go:build unit
+
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestResultCache(t *testing.T) {
+	now := time.Now()
+
+	t.Run("Returns stored values until they expire", func(t *testing.T) {
+		cache := newResultCache(time.Minute, 10)
+		cache.put("k", "v", now)
+
+		if got, ok := cache.get("k", now.Add(30*time.Second)); !ok || got != "v" {
+			t.Errorf("Expected a cache hit, got %v, %t", got, ok)
+		}
+		if _, ok := cache.get("k", now.Add(2*time.Minute)); ok {
+			t.Error("Expected the entry to have expired")
+		}
+		if len(cache.entries) != 0 {
+			t.Errorf("Expected expired entry to be removed, got %d entries", len(cache.entries))
+		}
+	})
+
+	t.Run("Stores and returns copies of values", func(t *testing.T) {
+		cache := newResultCache(time.Minute, 10)
+		value := map[string]any{"rows": []any{map[string]any{"id": "a"}}}
+		cache.put("k", value, now)
+		value["rows"].([]any)[0].(map[string]any)["id"] = "changed"
+
+		got, _ := cache.get("k", now)
+		got.(map[string]any)["rows"] = nil
+
+		want := map[string]any{"rows": []any{map[string]any{"id": "a"}}}
+		if got, _ := cache.get("k", now); !reflect.DeepEqual(got, want) {
+			t.Errorf("Expected the cached value to be unchanged, got %v", got)
+		}
+	})
+
+	t.Run("Stores and returns copies of content blocks", func(t *testing.T) {
+		cache := newResultCache(time.Minute, 10)
+		value := []ContentBlock{{Type: "resource", Resource: &ResourceContents{URI: "file:///a", Text: "a"}}}
+		cache.put("k", value, now)
+		value[0].Resource.Text = "changed"
+
+		got, _ := cache.get("k", now)
+		got.([]ContentBlock)[0].Text = "changed"
+		got.([]ContentBlock)[0].Resource.URI = "file:///changed"
+
+		want := []ContentBlock{{Type: "resource", Resource: &ResourceContents{URI: "file:///a", Text: "a"}}}
+		if got, _ := cache.get("k", now); !reflect.DeepEqual(got, want) {
+			t.Errorf("Expected the cached value to be unchanged, got %v", got)
+		}
+	})
+
+	t.Run("Evicts the least recently used entry", func(t *testing.T) {
+		cache := newResultCache(time.Minute, 2)
+		cache.put("a", 1, now)
+		cache.put("b", 2, now)
+		cache.get("a", now)
+		cache.put("c", 3, now)
+
+		if _, ok := cache.get("b", now); ok {
+			t.Error("Expected 'b' to have been evicted")
+		}
+		if _, ok := cache.get("a", now); !ok {
+			t.Error("Expected 'a' to remain cached")
+		}
+		if _, ok := cache.get("c", now); !ok {
+			t.Error("Expected 'c' to remain cached")
+		}
+	})
+}
+
+func TestResultCacheKey(t *testing.T) {
+	headers := map[string]string{"Authorization": "Bearer a", "google_token": "g", "Idempotency-Key": "1"}
+	k1, err := resultCacheKey("lookup", map[string]any{"a": 1, "b": "x"}, headers)
+	if err != nil {
+		t.Fatalf("resultCacheKey failed: %v", err)
+	}
+	k2, _ := resultCacheKey("lookup", map[string]any{"b": "x", "a": 1}, headers)
+	if k1 != k2 {
+		t.Error("Expected equal payloads to produce the same key")
+	}
+
+	testCases := []struct {
+		name    string
+		tool    string
+		headers map[string]string
+		same    bool
+	}{
+		{"Different tool", "search", headers, false},
+		{"Different Authorization header", "lookup", map[string]string{"Authorization": "Bearer b", "google_token": "g", "Idempotency-Key": "1"}, false},
+		{"Different auth token", "lookup", map[string]string{"Authorization": "Bearer a", "google_token": "h", "Idempotency-Key": "1"}, false},
+		{"Different idempotency key", "lookup", map[string]string{"Authorization": "Bearer a", "google_token": "g", "Idempotency-Key": "2"}, true},
+		{"Different tenant header", "lookup", map[string]string{"Authorization": "Bearer a", "google_token": "g", "Idempotency-Key": "1", "X-Tenant": "t"}, false},
+		{"Different API key", "lookup", map[string]string{"Authorization": "Bearer a", "google_token": "g", "Idempotency-Key": "1", "X-API-Key": "k"}, false},
+		{"Missing idempotency key", "lookup", map[string]string{"Authorization": "Bearer a", "google_token": "g"}, true},
+		{"Lowercase idempotency key", "lookup", map[string]string{"Authorization": "Bearer a", "google_token": "g", "idempotency-key": "2"}, true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			k, _ := resultCacheKey(tc.tool, map[string]any{"a": 1, "b": "x"}, tc.headers)
+			if (k == k1) != tc.same {
+				t.Errorf("Expected the keys to be equal: %t", tc.same)
+			}
+		})
+	}
+}
+
+func TestIsCacheable(t *testing.T) {
+	testCases := []struct {
+		annotations map[string]any
+		want        bool
+	}{
+		{map[string]any{"readOnlyHint": true}, true},
+		{map[string]any{"idempotentHint": true}, true},
+		{map[string]any{"readOnlyHint": false}, false},
+		{nil, false},
+	}
+	for _, tc := range testCases {
+		if got := isCacheable(tc.annotations); got != tc.want {
+			t.Errorf("isCacheable(%v) = %t, want %t", tc.annotations, got, tc.want)
+		}
+	}
+}
@@ -16,35 +16,97 @@ package core
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
+	"iter"
 	"log"
+	"log/slog"
+	"maps"
 	"net/http"
+	"net/url"
 	"strings"
+	"sync"
+	"time"
 
 	"slices"
 
 	"github.com/googleapis/mcp-toolbox-sdk-go/core/transport"
+	"github.com/googleapis/mcp-toolbox-sdk-go/core/transport/mcp"
+	mcpsse "github.com/googleapis/mcp-toolbox-sdk-go/core/transport/mcp/sse"
 	mcp20241105 "github.com/googleapis/mcp-toolbox-sdk-go/core/transport/mcp/v20241105"
 	mcp20250326 "github.com/googleapis/mcp-toolbox-sdk-go/core/transport/mcp/v20250326"
 	mcp20250618 "github.com/googleapis/mcp-toolbox-sdk-go/core/transport/mcp/v20250618"
 	mcp20251125 "github.com/googleapis/mcp-toolbox-sdk-go/core/transport/mcp/v20251125"
+	mcpws "github.com/googleapis/mcp-toolbox-sdk-go/core/transport/mcp/ws"
 	"golang.org/x/oauth2"
 )
 
 // The synchronous interface for a Toolbox service client.
+//
+// A ToolboxClient is safe for concurrent use by multiple goroutines once it
+// is created, and so are the tools it loads. Client headers can be changed
+// at any time with SetClientHeader and RemoveClientHeader; requests that
+// have already resolved their headers are not affected. All other settings
+// are fixed by the options passed to NewToolboxClient.
 type ToolboxClient struct {
 	baseURL             string
 	httpClient          *http.Client
 	protocol            Protocol
 	protocolSet         bool
 	transport           transport.Transport
-	clientHeaderSources map[string]oauth2.TokenSource
 	defaultToolOptions  []ToolOption
 	defaultOptionsSet   bool
 	clientName          string
 	clientVersion       string
+	clientTitle         string
+	maxResponseBytes    int64
+	tokenRefreshSkew    time.Duration
+	tokenRefreshSkewSet bool
+	autoIDToken         bool
+	customTransport     transport.Transport
+
+	toolsChangedHandlers []func()
+
+	// headersMu guards clientHeaderSources, the client-wide header sources
+	// keyed by header name. Once the client is created, the map is replaced
+	// rather than modified, so snapshots can be read without the lock.
+	headersMu           sync.RWMutex
+	clientHeaderSources map[string]oauth2.TokenSource
+
+	// subscriptionsMu guards subscriptions, the update callbacks of
+	// subscribed resources keyed by URI.
+	subscriptionsMu sync.Mutex
+	subscriptions   map[string]func()
+
+	samplingHandler    SamplingHandler
+	elicitationHandler ElicitationHandler
+	roots              []Root
+	clientCapabilities map[string]map[string]any
+
+	logHandler   func(LogMessage)
+	serverLogger *slog.Logger
+
+	keepAliveInterval time.Duration
+	keepAliveFailures int
+
+	transportRetry    *transport.RetryPolicy
+	transportObserver TransportObserver
+	requestTimeout    time.Duration
+
+	debugDump          io.Writer
+	debugDumpBodyLimit int
+
+	manifestCache  *ManifestCache
+	conflictPolicy ConflictPolicy
+	queryParams    url.Values
+	warningHandler WarningHandler
 }
 
+// toolsListChangedMethod is the notification a server sends when its list of
+// tools changes.
+const toolsListChangedMethod = "notifications/tools/list_changed"
+
 // NewToolboxClient creates and configures a new, immutable client for interacting with a
 // Toolbox server.
 //
@@ -79,8 +141,73 @@ func NewToolboxClient(url string, opts ...ClientOption) (*ToolboxClient, error)
 		}
 	}
 
+	// Cache header tokens so that sources are only consulted again shortly
+	// before the current token expires.
+	for name, source := range tc.clientHeaderSources {
+		if _, ok := source.(headerFunc); ok {
+			continue
+		}
+		tc.clientHeaderSources[name] = reuseTokenSource(source, tc.tokenRefreshSkew, tc.tokenRefreshSkewSet)
+	}
+
 	checkSecureHeaders(tc.baseURL, len(tc.clientHeaderSources) > 0)
 
+	// The WebSocket transport dials the server itself and only reuses the
+	// proxy and TLS settings of the HTTP client, so the round trippers below
+	// would never see its upgrade request.
+	if tc.protocol == MCPv20250618WebSocket {
+		if tc.debugDump != nil {
+			return nil, fmt.Errorf("WithDebugDump cannot be combined with the WebSocket transport")
+		}
+		if tc.autoIDToken {
+			return nil, fmt.Errorf("WithAutoIDToken cannot be combined with the WebSocket transport; send the token with WithClientHeaderTokenSource instead")
+		}
+		if rt := tc.httpClient.Transport; rt != nil {
+			if _, ok := rt.(*http.Transport); !ok {
+				log.Printf("WARNING: The WebSocket upgrade request is not sent through the %T of the HTTP client, so the headers, proxy and TLS settings it applies are not used.", rt)
+			}
+		}
+	}
+
+	if tc.debugDump != nil {
+		if tc.customTransport != nil {
+			return nil, fmt.Errorf("WithDebugDump cannot be combined with WithCustomTransport")
+		}
+		limit := tc.debugDumpBodyLimit
+		if limit == 0 {
+			limit = defaultDebugDumpBodyLimit
+		}
+		// Copy the client so the caller's http.Client is left untouched. The
+		// dump wraps the innermost transport so that it shows the headers
+		// actually sent, including ID tokens added below.
+		httpClient := *tc.httpClient
+		httpClient.Transport = newDebugDumpTransport(httpClient.Transport, tc.debugDump, limit)
+		tc.httpClient = &httpClient
+	}
+
+	if tc.autoIDToken {
+		audience, err := idTokenAudience(tc.baseURL)
+		if err != nil {
+			return nil, err
+		}
+		// Copy the client so the caller's http.Client is left untouched.
+		httpClient := *tc.httpClient
+		httpClient.Transport = newIDTokenRetryTransport(httpClient.Transport, audience)
+		tc.httpClient = &httpClient
+	}
+
+	// A custom transport replaces the protocol-based selection entirely.
+	if tc.customTransport != nil {
+		if tc.protocolSet {
+			return nil, fmt.Errorf("WithCustomTransport cannot be combined with WithProtocol")
+		}
+		tc.transport = tc.customTransport
+		if err := tc.setupTransport(); err != nil {
+			return nil, err
+		}
+		return tc, nil
+	}
+
 	// Initialize the Transport based on the selected Protocol.
 	var transportErr error
 
@@ -90,18 +217,168 @@ func NewToolboxClient(url string, opts ...ClientOption) (*ToolboxClient, error)
 
 	switch tc.protocol {
 	case MCPv20251125:
-		tc.transport, transportErr = mcp20251125.New(tc.baseURL, tc.httpClient, tc.clientName, tc.clientVersion)
+		tc.transport, transportErr = mcp20251125.New(tc.baseURL, tc.httpClient, tc.clientName, tc.clientVersion, tc.transportOptions()...)
 	case MCPv20250618:
-		tc.transport, transportErr = mcp20250618.New(tc.baseURL, tc.httpClient, tc.clientName, tc.clientVersion)
+		tc.transport, transportErr = mcp20250618.New(tc.baseURL, tc.httpClient, tc.clientName, tc.clientVersion, tc.transportOptions()...)
 	case MCPv20250326:
-		tc.transport, transportErr = mcp20250326.New(tc.baseURL, tc.httpClient, tc.clientName, tc.clientVersion)
+		tc.transport, transportErr = mcp20250326.New(tc.baseURL, tc.httpClient, tc.clientName, tc.clientVersion, tc.transportOptions()...)
 	case MCPv20241105:
-		tc.transport, transportErr = mcp20241105.New(tc.baseURL, tc.httpClient, tc.clientName, tc.clientVersion)
+		tc.transport, transportErr = mcp20241105.New(tc.baseURL, tc.httpClient, tc.clientName, tc.clientVersion, tc.transportOptions()...)
+	case MCPv20241105SSE:
+		tc.transport, transportErr = mcpsse.New(tc.baseURL, tc.httpClient, tc.clientName, tc.clientVersion, tc.transportOptions()...)
+	case MCPv20250618WebSocket:
+		tc.transport, transportErr = mcpws.New(tc.baseURL, tc.httpClient, tc.clientName, tc.clientVersion, tc.transportOptions()...)
 	default:
-		return nil, fmt.Errorf("unsupported protocol version: %s", tc.protocol)
+		factory, ok := lookupTransport(tc.protocol)
+		if !ok {
+			return nil, fmt.Errorf("unsupported protocol version: %s", tc.protocol)
+		}
+		tc.transport, transportErr = factory(tc.baseURL, tc.httpClient, tc.clientName, tc.clientVersion)
+	}
+
+	if transportErr != nil {
+		return tc, transportErr
 	}
+	if err := tc.setupTransport(); err != nil {
+		return nil, err
+	}
+	return tc, nil
+}
 
-	return tc, transportErr
+// transportOptions returns the options of the built-in MCP transports.
+func (tc *ToolboxClient) transportOptions() []mcp.Option {
+	var opts []mcp.Option
+	if tc.clientTitle != "" {
+		opts = append(opts, mcp.WithClientTitle(tc.clientTitle))
+	}
+	return opts
+}
+
+// setupTransport applies the client options that configure the transport
+// once it is created.
+func (tc *ToolboxClient) setupTransport() error {
+	tc.subscribeNotifications()
+	if err := tc.registerRequestHandlers(); err != nil {
+		return err
+	}
+	if tc.keepAliveInterval > 0 {
+		pinger, ok := tc.transport.(transport.Pinger)
+		if !ok {
+			return fmt.Errorf("transport %T does not keep a connection open and cannot be kept alive", tc.transport)
+		}
+		pinger.SetKeepAlive(tc.keepAliveInterval, tc.keepAliveFailures)
+	}
+	if tc.transportRetry != nil {
+		retrier, ok := tc.transport.(transport.Retrier)
+		if !ok {
+			return fmt.Errorf("transport %T does not support retries", tc.transport)
+		}
+		retrier.SetRetryPolicy(*tc.transportRetry)
+	}
+	if tc.transportObserver != nil {
+		observable, ok := tc.transport.(transport.Observable)
+		if !ok {
+			return fmt.Errorf("transport %T cannot be observed", tc.transport)
+		}
+		observable.SetObserver(tc.transportObserver)
+	}
+	if tc.requestTimeout > 0 {
+		timeouter, ok := tc.transport.(transport.RequestTimeouter)
+		if !ok {
+			return fmt.Errorf("transport %T does not support request timeouts", tc.transport)
+		}
+		timeouter.SetRequestTimeout(tc.requestTimeout)
+	}
+	return nil
+}
+
+// Close shuts down the connection to the server: it terminates the MCP
+// session, closes open event streams or WebSocket connections, and stops
+// servers started over stdio. Tools loaded by the client cannot be invoked
+// over the closed transport, except for HTTP transports, which start a new
+// session as needed.
+//
+// Inputs:
+//   - ctx: The context to control the lifecycle of the shutdown.
+//
+// Returns:
+//
+//	A nil error on success, or an error if the transport failed to shut
+//	down cleanly.
+func (tc *ToolboxClient) Close(ctx context.Context) error {
+	return tc.transport.Close(ctx)
+}
+
+// subscribeNotifications registers the client for the notifications the
+// server sends outside of any request, if the transport can deliver them.
+func (tc *ToolboxClient) subscribeNotifications() {
+	if len(tc.toolsChangedHandlers) == 0 && tc.logHandler == nil && tc.serverLogger == nil && tc.manifestCache == nil {
+		return
+	}
+	if source, ok := tc.transport.(transport.NotificationSource); ok {
+		source.SetNotificationHandler(tc.handleServerNotification)
+	}
+}
+
+// handleServerNotification reacts to a notification sent by the server.
+func (tc *ToolboxClient) handleServerNotification(n transport.Notification) {
+	switch n.Method {
+	case toolsListChangedMethod:
+		if tc.manifestCache != nil {
+			tc.manifestCache.invalidateURL(tc.baseURL)
+		}
+		for _, handler := range tc.toolsChangedHandlers {
+			handler()
+		}
+	case resourceUpdatedMethod:
+		tc.handleResourceUpdated(n.Params)
+	case logMessageMethod:
+		tc.handleLogMessage(n.Params)
+	}
+}
+
+// limitResponseSize applies the client-wide response size limit, if any, to ctx.
+func (tc *ToolboxClient) limitResponseSize(ctx context.Context) context.Context {
+	if tc.maxResponseBytes > 0 {
+		return transport.WithMaxResponseBytes(ctx, tc.maxResponseBytes)
+	}
+	return ctx
+}
+
+// withQueryParams returns a copy of ctx that adds the client's query
+// parameters, and those given for a single load, which replace them, to the
+// requests that load manifests.
+func (tc *ToolboxClient) withQueryParams(ctx context.Context, params url.Values) context.Context {
+	if len(tc.queryParams) == 0 && len(params) == 0 {
+		return ctx
+	}
+	return transport.WithQueryParams(transport.WithQueryParams(ctx, tc.queryParams), params)
+}
+
+// fetchManifest fetches a manifest of the given kind with fetch, through the
+// client's manifest cache if it has one. Deprecations announced in the
+// response headers are reported to the client's warning handler.
+func (tc *ToolboxClient) fetchManifest(ctx context.Context, kind, name string, headers map[string]string, fetch func(ctx context.Context) (*ManifestSchema, error)) (*ManifestSchema, error) {
+	fetchWithHeaders := func() (*ManifestSchema, error) {
+		var responseHeaders transport.ResponseHeaders
+		manifest, err := fetch(transport.WithResponseHeaders(ctx, &responseHeaders))
+		if header := responseHeaders.Header(); header != nil {
+			// Pass the headers on to the caller's ResponseHeaders, if any.
+			transport.RecordResponseHeaders(ctx, header)
+		}
+		if err == nil {
+			tc.warnDeprecatedManifest(kind, name, &responseHeaders)
+		}
+		return manifest, err
+	}
+	if tc.manifestCache == nil {
+		return fetchWithHeaders()
+	}
+	key, err := manifestCacheKey(tc.baseURL, kind, name, headers, transport.QueryParams(ctx))
+	if err != nil {
+		return nil, err
+	}
+	return tc.manifestCache.load(ctx, key, tc.baseURL, fetchWithHeaders)
 }
 
 // newToolboxTool is an internal factory method that constructs a
@@ -171,7 +448,8 @@ func (tc *ToolboxClient) newToolboxTool(
 
 	// In strict mode, ensure that all provided bound parameters actually exist
 	// on the tool's schema.
-	if isStrict {
+	// Unused bindings that are allowed are reported by the callers instead.
+	if isStrict && !finalConfig.AllowUnused {
 		for boundName := range finalConfig.BoundParams {
 			if _, exists := paramSchema[boundName]; !exists {
 				return nil, nil, nil, fmt.Errorf("unable to bind parameter: no parameter named '%s' found on tool '%s'", boundName, name)
@@ -195,6 +473,7 @@ func (tc *ToolboxClient) newToolboxTool(
 	// Construct the final tool object.
 	tt := &ToolboxTool{
 		name:                name,
+		title:               schema.Title,
 		description:         schema.Description,
 		parameters:          finalParameters,
 		transport:           tr,
@@ -203,7 +482,38 @@ func (tc *ToolboxClient) newToolboxTool(
 		boundParamSchemas:   localBoundSchemas,
 		requiredAuthnParams: remainingAuthnParams,
 		requiredAuthzTokens: remainingAuthzTokens,
-		clientHeaderSources: tc.clientHeaderSources,
+		requiresAuth:        len(authnParams) > 0 || len(schema.AuthRequired) > 0,
+		clientHeaders:       tc.headerSources,
+		tags:                schema.Tags,
+		annotations:         schema.Annotations,
+		examples:            schema.Examples,
+		outputSchema:        schema.OutputSchema,
+		deprecation:         schema.Deprecation,
+		beforeInvoke:        slices.Clone(finalConfig.BeforeInvoke),
+		afterInvoke:         slices.Clone(finalConfig.AfterInvoke),
+		auditLoggers:        slices.Clone(finalConfig.AuditLoggers),
+		maxResponseBytes:    tc.maxResponseBytes,
+	}
+	if finalConfig.MaxResponseBytes > 0 {
+		tt.maxResponseBytes = finalConfig.MaxResponseBytes
+	}
+	if finalConfig.ResultCacheTTL > 0 {
+		if !isCacheable(tt.annotations) {
+			return nil, nil, nil, fmt.Errorf("WithResultCache: tool '%s' is not marked read-only or idempotent, so its results cannot be cached", name)
+		}
+		tt.resultCache = newResultCache(finalConfig.ResultCacheTTL, finalConfig.ResultCacheSize)
+	}
+	if finalConfig.MaxConcurrent > 0 {
+		tt.invokeSem = make(chan struct{}, finalConfig.MaxConcurrent)
+	}
+	if finalConfig.ValidateTokens {
+		tt.validateTokens = true
+		tt.tokenAudience = finalConfig.TokenAudience
+	}
+	tt.typeCoercion = finalConfig.TypeCoercion
+	if finalConfig.ToolNamePrefix != "" {
+		tt.remoteName = name
+		tt.name = finalConfig.ToolNamePrefix + name
 	}
 
 	return tt, usedAuthKeys, usedBoundKeys, nil
@@ -241,15 +551,34 @@ func (tc *ToolboxClient) LoadTool(name string, ctx context.Context, opts ...Tool
 		}
 	}
 
+	if err := validateLoadConfig(finalConfig); err != nil {
+		return nil, fmt.Errorf("LoadTool: %w", err)
+	}
+	if finalConfig.tagFilterSet {
+		return nil, fmt.Errorf("LoadTool: WithTagFilter option is only applicable to LoadToolset")
+	}
+	if finalConfig.AllowEmpty {
+		return nil, fmt.Errorf("LoadTool: WithAllowEmptyToolset option is only applicable to LoadToolset")
+	}
+	if finalConfig.toolFilterSet {
+		return nil, fmt.Errorf("LoadTool: WithToolFilter option is only applicable to LoadToolset")
+	}
+	if finalConfig.ToolNameRegexp != nil {
+		return nil, fmt.Errorf("LoadTool: WithToolNameRegexp option is only applicable to LoadToolset")
+	}
+
 	checkSecureHeaders(tc.baseURL, len(finalConfig.AuthTokenSources) > 0)
 
-	resolvedHeaders, err := resolveClientHeaders(tc.clientHeaderSources)
+	resolvedHeaders, err := resolveClientHeaders(ctx, tc.headerSources())
 	if err != nil {
 		return nil, err
 	}
 
 	// Fetch the manifest for the specified tool.
-	manifest, err := tc.transport.GetTool(ctx, name, resolvedHeaders)
+	ctx = tc.withQueryParams(ctx, finalConfig.QueryParams)
+	manifest, err := tc.fetchManifest(ctx, "tool", name, resolvedHeaders, func(ctx context.Context) (*ManifestSchema, error) {
+		return tc.transport.GetTool(transport.WithToolName(tc.limitResponseSize(ctx), name), name, resolvedHeaders)
+	})
 
 	if err != nil {
 		return nil, fmt.Errorf("failed to load tool manifest for '%s': %w", name, err)
@@ -298,25 +627,137 @@ func (tc *ToolboxClient) LoadTool(name string, ctx context.Context, opts ...Tool
 		errorMessages = append(errorMessages, fmt.Sprintf("unused bound parameters: %s", strings.Join(unusedBound, ", ")))
 	}
 	if len(errorMessages) > 0 {
-		return nil, fmt.Errorf("validation failed for tool '%s': %s", name, strings.Join(errorMessages, "; "))
+		err := fmt.Errorf("validation failed for tool '%s': %s", name, strings.Join(errorMessages, "; "))
+		if err := reportUnused(finalConfig, err); err != nil {
+			return nil, err
+		}
 	}
 
+	tc.warnDeprecatedTool(tool, "")
 	return tool, nil
 }
 
+// ErrToolsetNotFound is returned by LoadToolset and GetToolsetManifest when the server reports that
+// the requested toolset does not exist.
+var ErrToolsetNotFound = errors.New("toolset not found")
+
 // LoadToolset fetches a manifest for a collection of tools.
 //
 // Inputs:
 //   - name: Name of the toolset to be loaded.Set this arg to "" to load the default toolset
 //   - ctx: The context to control the lifecycle of the request.
-//   - opts: A variadic list of ToolOption functions. These can include WithStrict
+//   - opts: A variadic list of ToolOption functions. These can include WithStrict,
+//     WithTagFilter, WithToolFilter, WithToolNameRegexp, WithAllowEmptyToolset
 //     and options for auth or bound params that may apply to tools in the set.
 //
 // Returns:
 //
-//	A slice of configured *ToolboxTool and a nil error on success, or a nil
-//	slice and an error if loading or validation fails.
+//	A slice of configured *ToolboxTool, sorted by name, and a nil error on
+//	success, or a nil slice and an error if loading or validation fails. The
+//	error wraps ErrToolsetNotFound if the server does not know the toolset.
 func (tc *ToolboxClient) LoadToolset(name string, ctx context.Context, opts ...ToolOption) ([]*ToolboxTool, error) {
+	return tc.loadToolset(name, ctx, opts)
+}
+
+// Tools returns an iterator over the tools of a toolset, which lets
+// applications register each tool with a framework as soon as it is
+// constructed. It accepts the same options as LoadToolset. When the iteration
+// starts, the manifest is fetched and the options are checked against the
+// parameters and auth requirements it lists, including the check that all
+// bound parameters and auth tokens apply to some tool. The tools are then
+// constructed and yielded one at a time, in order of their names. If loading,
+// validation or the construction of a tool fails, the iterator yields a nil
+// tool and the error, and stops.
+func (tc *ToolboxClient) Tools(ctx context.Context, toolsetName string, opts ...ToolOption) iter.Seq2[*ToolboxTool, error] {
+	return func(yield func(*ToolboxTool, error) bool) {
+		loader, err := tc.prepareToolset(toolsetName, ctx, opts)
+		if err != nil {
+			yield(nil, err)
+			return
+		}
+		for _, toolName := range loader.toolNames {
+			tool, err := loader.tool(toolName)
+			if !yield(tool, err) || err != nil {
+				return
+			}
+		}
+	}
+}
+
+// GetToolsetManifest fetches the raw manifest of a toolset, without
+// constructing any tools from it, so that applications can inspect the tool
+// schemas, compare manifests, or forward them to other systems. Set name to
+// "" to fetch the default toolset. The manifest is fetched with the client
+// headers and through the manifest cache of the client, if any; it may be
+// shared with the cache and must not be modified. The error wraps
+// ErrToolsetNotFound if the server does not know the toolset.
+func (tc *ToolboxClient) GetToolsetManifest(ctx context.Context, name string) (*ManifestSchema, error) {
+	resolvedHeaders, err := resolveClientHeaders(ctx, tc.headerSources())
+	if err != nil {
+		return nil, err
+	}
+	return tc.toolsetManifest(tc.withQueryParams(ctx, nil), name, resolvedHeaders)
+}
+
+// toolsetManifest fetches the manifest of a toolset via the transport.
+func (tc *ToolboxClient) toolsetManifest(ctx context.Context, name string, headers map[string]string) (*ManifestSchema, error) {
+	manifest, err := tc.fetchManifest(ctx, "toolset", name, headers, func(ctx context.Context) (*ManifestSchema, error) {
+		return tc.transport.ListTools(transport.WithToolsetName(tc.limitResponseSize(ctx), name), name, headers)
+	})
+	if err != nil {
+		var httpErr *ToolInvocationError
+		if errors.As(err, &httpErr) && httpErr.StatusCode == http.StatusNotFound {
+			return nil, fmt.Errorf("%w: '%s' (%w)", ErrToolsetNotFound, name, err)
+		}
+		return nil, fmt.Errorf("failed to load toolset manifest for '%s': %w", name, err)
+	}
+	return manifest, nil
+}
+
+// loadToolset loads the tools of a toolset, sorted by name, once all of them
+// are constructed and validated.
+func (tc *ToolboxClient) loadToolset(name string, ctx context.Context, opts []ToolOption) ([]*ToolboxTool, error) {
+	loader, err := tc.prepareToolset(name, ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	tools := make([]*ToolboxTool, 0, len(loader.toolNames))
+	for _, toolName := range loader.toolNames {
+		tool, err := loader.tool(toolName)
+		if err != nil {
+			return nil, err
+		}
+		tools = append(tools, tool)
+	}
+	return tools, nil
+}
+
+// toolsetLoader holds the manifest and the validated options of a toolset,
+// from which its tools are constructed.
+type toolsetLoader struct {
+	tc        *ToolboxClient
+	name      string
+	config    *ToolConfig
+	manifest  *ManifestSchema
+	toolNames []string
+}
+
+// tool constructs the tool with the given name from the manifest.
+func (l *toolsetLoader) tool(toolName string) (*ToolboxTool, error) {
+	tool, _, _, err := l.tc.newToolboxTool(toolName, l.manifest.Tools[toolName], l.config, l.config.Strict, l.tc.transport)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create tool '%s': %w", toolName, err)
+	}
+	l.tc.warnDeprecatedTool(tool, l.name)
+	return tool, nil
+}
+
+// prepareToolset applies the options for loading a toolset, fetches its
+// manifest and selects the tools to load. It checks that the bound parameters
+// and auth tokens apply to the selected tools using the parameter names and
+// auth requirements listed in the manifest, so that no tool needs to be
+// constructed first.
+func (tc *ToolboxClient) prepareToolset(name string, ctx context.Context, opts []ToolOption) (*toolsetLoader, error) {
 	finalConfig := newToolConfig()
 	// Apply client-wide default options first.
 	for _, opt := range tc.defaultToolOptions {
@@ -335,24 +776,32 @@ func (tc *ToolboxClient) LoadToolset(name string, ctx context.Context, opts ...T
 		}
 	}
 
+	if err := validateLoadConfig(finalConfig); err != nil {
+		return nil, fmt.Errorf("LoadToolset: %w", err)
+	}
+
 	checkSecureHeaders(tc.baseURL, len(finalConfig.AuthTokenSources) > 0)
 
 	// Fetch the manifest for the toolset.
-	resolvedHeaders, err := resolveClientHeaders(tc.clientHeaderSources)
+	resolvedHeaders, err := resolveClientHeaders(ctx, tc.headerSources())
 	if err != nil {
 		return nil, err
 	}
-
-	// Fetch Manifest via Transport
-	manifest, err := tc.transport.ListTools(ctx, name, resolvedHeaders)
+	manifest, err := tc.toolsetManifest(tc.withQueryParams(ctx, finalConfig.QueryParams), name, resolvedHeaders)
 	if err != nil {
-		return nil, fmt.Errorf("failed to load toolset manifest for '%s': %w", name, err)
+		return nil, err
 	}
-	if manifest.Tools == nil {
-		return nil, fmt.Errorf("toolset '%s' not found (manifest contains no tools)", name)
+	loader := &toolsetLoader{tc: tc, name: name, config: finalConfig, manifest: manifest}
+	if len(manifest.Tools) == 0 {
+		if finalConfig.AllowEmpty {
+			return loader, nil
+		}
+		if name == "" {
+			name = "default"
+		}
+		return nil, fmt.Errorf("toolset '%s' contains no tools", name)
 	}
 
-	var tools []*ToolboxTool
 	overallUsedAuthKeys := make(map[string]struct{})
 	overallUsedBoundParams := make(map[string]struct{})
 
@@ -365,17 +814,33 @@ func (tc *ToolboxClient) LoadToolset(name string, ctx context.Context, opts ...T
 		providedBoundKeys[k] = struct{}{}
 	}
 
-	for toolName, schema := range manifest.Tools {
-		// Construct each tool from its schema and the shared configuration.
-		tool, usedAuthKeys, usedBoundKeys, err := tc.newToolboxTool(toolName, schema, finalConfig, finalConfig.Strict, tc.transport)
-		if err != nil {
-			return nil, fmt.Errorf("failed to create tool '%s': %w", toolName, err)
+	for _, toolName := range slices.Sorted(maps.Keys(manifest.Tools)) {
+		schema := manifest.Tools[toolName]
+		// Skip tools that do not carry any of the requested tags.
+		if finalConfig.tagFilterSet && !hasAnyTag(schema.Tags, finalConfig.TagFilter) {
+			continue
 		}
-		tools = append(tools, tool)
+		// Skip tools left out by the tool filters.
+		if !finalConfig.includesTool(toolName) {
+			continue
+		}
+		loader.toolNames = append(loader.toolNames, toolName)
+
+		usedAuthKeys, usedBoundKeys := optionUsage(schema, finalConfig)
 
 		// Validation behavior depends on whether strict mode is enabled.
 		if finalConfig.Strict {
-			// In strict mode, validate each tool individually for unused options.
+			// In strict mode, every bound parameter must exist on every
+			// tool, unless unused bindings are allowed.
+			if !finalConfig.AllowUnused {
+				for _, boundName := range slices.Sorted(maps.Keys(finalConfig.BoundParams)) {
+					if !slices.ContainsFunc(schema.Parameters, func(p ParameterSchema) bool { return p.Name == boundName }) {
+						return nil, fmt.Errorf("failed to create tool '%s': unable to bind parameter: no parameter named '%s' found on tool '%s'", toolName, boundName, toolName)
+					}
+				}
+			}
+
+			// Validate each tool individually for unused options.
 			usedAuthSet := make(map[string]struct{})
 			for _, k := range usedAuthKeys {
 				usedAuthSet[k] = struct{}{}
@@ -396,7 +861,10 @@ func (tc *ToolboxClient) LoadToolset(name string, ctx context.Context, opts ...T
 				errorMessages = append(errorMessages, fmt.Sprintf("unused bound parameters: %s", strings.Join(unusedBound, ", ")))
 			}
 			if len(errorMessages) > 0 {
-				return nil, fmt.Errorf("validation failed for tool '%s': %s", toolName, strings.Join(errorMessages, "; "))
+				err := fmt.Errorf("validation failed for tool '%s': %s", toolName, strings.Join(errorMessages, "; "))
+				if err := reportUnused(finalConfig, err); err != nil {
+					return nil, err
+				}
 			}
 		} else {
 			// In non-strict mode, aggregate all used keys across all tools.
@@ -427,9 +895,49 @@ func (tc *ToolboxClient) LoadToolset(name string, ctx context.Context, opts ...T
 			if name == "" {
 				name = "default"
 			}
-			return nil, fmt.Errorf("validation failed for toolset '%s': %s", name, strings.Join(errorMessages, "; "))
+			err := fmt.Errorf("validation failed for toolset '%s': %s", name, strings.Join(errorMessages, "; "))
+			if err := reportUnused(finalConfig, err); err != nil {
+				return nil, err
+			}
 		}
 	}
+	return loader, nil
+}
 
-	return tools, nil
+// optionUsage returns the auth token sources and bound parameters of config
+// that apply to the tool described by schema, as newToolboxTool would use
+// them.
+func optionUsage(schema ToolSchema, config *ToolConfig) (usedAuthKeys, usedBoundKeys []string) {
+	authnParams := make(map[string][]string)
+	for _, p := range schema.Parameters {
+		if len(p.AuthSources) > 0 {
+			authnParams[p.Name] = p.AuthSources
+		} else if _, isBound := config.BoundParams[p.Name]; isBound {
+			usedBoundKeys = append(usedBoundKeys, p.Name)
+		}
+	}
+	_, _, usedAuthKeys = identifyAuthRequirements(authnParams, schema.AuthRequired, config.AuthTokenSources)
+	return usedAuthKeys, usedBoundKeys
+}
+
+// LoadToolsets loads several toolsets and combines their tools with
+// MergeTools, according to the client's conflict policy, set with
+// WithConflictPolicy. The options apply to every toolset, and tools are
+// returned in the order of names. A tool that belongs to several of the
+// toolsets is returned once. With ConflictPrefixSource, conflicting tools are
+// prefixed with the name of their toolset, or "default" for the default
+// toolset.
+func (tc *ToolboxClient) LoadToolsets(names []string, ctx context.Context, opts ...ToolOption) ([]*ToolboxTool, error) {
+	sources := make([]ToolSource, 0, len(names))
+	for _, name := range names {
+		tools, err := tc.LoadToolset(name, ctx, opts...)
+		if err != nil {
+			return nil, err
+		}
+		if name == "" {
+			name = "default"
+		}
+		sources = append(sources, ToolSource{Name: name, Tools: tools})
+	}
+	return MergeTools(tc.conflictPolicy, sources...)
 }
@@ -21,15 +21,24 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
 	"log"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"os"
+	"reflect"
+	"regexp"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/googleapis/mcp-toolbox-sdk-go/core/transport"
+	mcpsse "github.com/googleapis/mcp-toolbox-sdk-go/core/transport/mcp/sse"
+	mcpws "github.com/googleapis/mcp-toolbox-sdk-go/core/transport/mcp/ws"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"golang.org/x/oauth2"
@@ -58,6 +67,7 @@ type mcpTool struct {
 	Name        string         `json:"name"`
 	Description string         `json:"description,omitempty"`
 	InputSchema map[string]any `json:"inputSchema"`
+	Annotations map[string]any `json:"annotations,omitempty"`
 	Meta        map[string]any `json:"_meta,omitempty"`
 }
 
@@ -137,6 +147,26 @@ func TestNewToolboxClient(t *testing.T) {
 
 	})
 
+	t.Run("Selects the HTTP+SSE transport", func(t *testing.T) {
+		client, err := NewToolboxClient("https://api.example.com", WithProtocol(MCPv20241105SSE))
+		if err != nil {
+			t.Fatalf("NewToolboxClient() returned an error: %v", err)
+		}
+		if _, ok := client.transport.(*mcpsse.McpTransport); !ok {
+			t.Errorf("expected an SSE transport, got %T", client.transport)
+		}
+	})
+
+	t.Run("Selects the WebSocket transport", func(t *testing.T) {
+		client, err := NewToolboxClient("wss://api.example.com/mcp", WithProtocol(MCPv20250618WebSocket))
+		if err != nil {
+			t.Fatalf("NewToolboxClient() returned an error: %v", err)
+		}
+		if _, ok := client.transport.(*mcpws.McpTransport); !ok {
+			t.Errorf("expected a WebSocket transport, got %T", client.transport)
+		}
+	})
+
 	t.Run("Returns error when a nil option is provided", func(t *testing.T) {
 		_, err := NewToolboxClient("https://toolbox.example.com", nil)
 		if err == nil {
@@ -158,6 +188,50 @@ func TestNewToolboxClient(t *testing.T) {
 		}
 	})
 
+	t.Run("Caches client header tokens", func(t *testing.T) {
+		src := &countingTokenSource{ttl: time.Hour}
+		client, err := NewToolboxClient("https://toolbox.example.com",
+			WithClientHeaderTokenSource("Authorization", src),
+			WithTokenRefreshSkew(time.Minute),
+		)
+		if err != nil {
+			t.Fatalf("NewToolboxClient failed: %v", err)
+		}
+
+		for i := 0; i < 2; i++ {
+			if _, err := client.clientHeaderSources["Authorization"].Token(); err != nil {
+				t.Fatalf("Token failed: %v", err)
+			}
+		}
+		if src.calls != 1 {
+			t.Errorf("Expected the header source to be called once, got %d", src.calls)
+		}
+	})
+
+	t.Run("Calls dynamic header providers on every request", func(t *testing.T) {
+		server := newHeaderEchoServer(t, "X-Request-Counter")
+		defer server.Close()
+
+		var calls atomic.Int32
+		provider := func() string { return fmt.Sprint(calls.Add(1)) }
+		client, err := NewToolboxClient(server.URL,
+			WithHTTPClient(server.Client()),
+			WithClientHeaderTokenSource("X-Request-Counter", NewCustomTokenSource(provider)),
+		)
+		if err != nil {
+			t.Fatalf("NewToolboxClient failed: %v", err)
+		}
+		tool, err := client.LoadTool("echo", context.Background())
+		if err != nil {
+			t.Fatalf("LoadTool failed: %v", err)
+		}
+
+		first := echoedHeader(t, context.Background(), tool)
+		second := echoedHeader(t, context.Background(), tool)
+		if first == second {
+			t.Errorf("Expected a new header value for every request, got %q twice", first)
+		}
+	})
 }
 
 func TestNewToolboxClient_ProtocolWarnings(t *testing.T) {
@@ -434,6 +508,118 @@ func TestLoadToolAndLoadToolset(t *testing.T) {
 		}
 	})
 
+	t.Run("LoadTool - Reports Auth Requirements", func(t *testing.T) {
+		client, _ := NewToolboxClient(server.URL, WithHTTPClient(server.Client()))
+
+		satisfied, err := client.LoadTool("toolA", context.Background(), WithAuthTokenString("google", "token-google"))
+		require.NoError(t, err)
+		assert.True(t, satisfied.RequiresAuth())
+		ok, missing := satisfied.AuthSatisfied()
+		assert.True(t, ok)
+		assert.Empty(t, missing)
+
+		unsatisfied, err := client.LoadTool("toolB", context.Background())
+		require.NoError(t, err)
+		assert.True(t, unsatisfied.RequiresAuth())
+		ok, missing = unsatisfied.AuthSatisfied()
+		assert.False(t, ok)
+		assert.Equal(t, []string{"github"}, missing)
+		assert.Equal(t, []string{"github"}, unsatisfied.RequiredAuthzServices())
+	})
+
+	t.Run("LoadTool - Surfaces Annotations", func(t *testing.T) {
+		annotatedServer := newMockMCPServer(t, []mcpTool{
+			{
+				Name:        "dropTable",
+				InputSchema: map[string]any{"type": "object", "properties": map[string]any{}},
+				Annotations: map[string]any{"destructiveHint": true},
+			},
+		})
+		defer annotatedServer.Close()
+
+		client, _ := NewToolboxClient(annotatedServer.URL, WithHTTPClient(annotatedServer.Client()))
+		tool, err := client.LoadTool("dropTable", context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, map[string]any{"destructiveHint": true}, tool.Annotations())
+	})
+
+	t.Run("LoadTool - Rejects Result Caching Of Tools With Side Effects", func(t *testing.T) {
+		annotatedServer := newMockMCPServer(t, []mcpTool{
+			{
+				Name:        "dropTable",
+				InputSchema: map[string]any{"type": "object", "properties": map[string]any{}},
+				Annotations: map[string]any{"destructiveHint": true},
+			},
+		})
+		defer annotatedServer.Close()
+
+		client, _ := NewToolboxClient(annotatedServer.URL, WithHTTPClient(annotatedServer.Client()))
+		_, err := client.LoadTool("dropTable", context.Background(), WithResultCache(time.Minute, 10))
+		assert.ErrorContains(t, err, "tool 'dropTable' is not marked read-only or idempotent")
+	})
+
+	t.Run("LoadToolset - Filters By Tag", func(t *testing.T) {
+		taggedServer := newMockMCPServer(t, []mcpTool{
+			{
+				Name:        "listRows",
+				InputSchema: map[string]any{"type": "object", "properties": map[string]any{}},
+				Meta:        map[string]any{"toolbox/tags": []string{"read-only"}},
+			},
+			{
+				Name:        "dropTable",
+				InputSchema: map[string]any{"type": "object", "properties": map[string]any{}},
+				Meta:        map[string]any{"toolbox/tags": []string{"destructive"}},
+			},
+		})
+		defer taggedServer.Close()
+
+		client, _ := NewToolboxClient(taggedServer.URL, WithHTTPClient(taggedServer.Client()))
+		tools, err := client.LoadToolset("", context.Background(), WithTagFilter("read-only"))
+		require.NoError(t, err)
+		require.Len(t, tools, 1)
+		assert.Equal(t, "listRows", tools[0].Name())
+		assert.Equal(t, []string{"read-only"}, tools[0].Tags())
+
+		_, err = client.LoadTool("listRows", context.Background(), WithTagFilter("read-only"))
+		assert.ErrorContains(t, err, "WithTagFilter option is only applicable to LoadToolset")
+	})
+
+	t.Run("LoadToolset - Filters by tool name", func(t *testing.T) {
+		emptySchema := map[string]any{"type": "object", "properties": map[string]any{}}
+		filterServer := newMockMCPServer(t, []mcpTool{
+			{Name: "listRows", InputSchema: emptySchema},
+			{Name: "getRow", InputSchema: emptySchema},
+			{Name: "dropTable", InputSchema: emptySchema},
+		})
+		defer filterServer.Close()
+
+		client, _ := NewToolboxClient(filterServer.URL, WithHTTPClient(filterServer.Client()))
+		tools, err := client.LoadToolset("", context.Background(), WithToolFilter([]string{"listRows", "getRow"}, nil))
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"getRow", "listRows"}, toolNames(tools))
+
+		tools, err = client.LoadToolset("", context.Background(), WithToolFilter(nil, []string{"dropTable"}))
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"getRow", "listRows"}, toolNames(tools))
+
+		tools, err = client.LoadToolset("", context.Background(), WithToolFilter([]string{"listRows", "dropTable"}, []string{"dropTable"}))
+		require.NoError(t, err)
+		assert.Equal(t, []string{"listRows"}, toolNames(tools))
+
+		tools, err = client.LoadToolset("", context.Background(), WithToolFilter([]string{"*Row*"}, []string{"get*"}))
+		require.NoError(t, err)
+		assert.Equal(t, []string{"listRows"}, toolNames(tools))
+
+		tools, err = client.LoadToolset("", context.Background(), WithToolNameRegexp(regexp.MustCompile(`^(get|drop)`)), WithToolFilter(nil, []string{"dropTable"}))
+		require.NoError(t, err)
+		assert.Equal(t, []string{"getRow"}, toolNames(tools))
+
+		_, err = client.LoadTool("listRows", context.Background(), WithToolFilter([]string{"listRows"}, nil))
+		assert.ErrorContains(t, err, "WithToolFilter option is only applicable to LoadToolset")
+		_, err = client.LoadTool("listRows", context.Background(), WithToolNameRegexp(regexp.MustCompile("list")))
+		assert.ErrorContains(t, err, "WithToolNameRegexp option is only applicable to LoadToolset")
+	})
+
 	t.Run("LoadTool - Delayed Validation for Bound Parameters", func(t *testing.T) {
 		client, _ := NewToolboxClient(server.URL, WithHTTPClient(server.Client()))
 		// param1 expects a string, but we bind an int. LoadTool should not error.
@@ -579,6 +765,274 @@ func TestLoadToolAndLoadToolset(t *testing.T) {
 	})
 }
 
+func TestLoadTool_WithToolNamePrefix(t *testing.T) {
+	emptySchema := map[string]any{"type": "object", "properties": map[string]any{}}
+	server := newMockMCPServer(t, []mcpTool{
+		{Name: "search", InputSchema: emptySchema},
+		{Name: "report", InputSchema: emptySchema},
+	})
+	defer server.Close()
+
+	// Answer tool calls with the name the server received.
+	mockHandler := server.Config.Handler
+	server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var req struct {
+			ID     any            `json:"id"`
+			Method string         `json:"method"`
+			Params map[string]any `json:"params"`
+		}
+		if json.Unmarshal(body, &req) == nil && req.Method == "tools/call" {
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"jsonrpc": "2.0",
+				"id":      req.ID,
+				"result":  map[string]any{"content": []any{map[string]any{"type": "text", "text": req.Params["name"]}}},
+			})
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		mockHandler.ServeHTTP(w, r)
+	})
+
+	client, err := NewToolboxClient(server.URL, WithHTTPClient(server.Client()))
+	if err != nil {
+		t.Fatalf("NewToolboxClient failed: %v", err)
+	}
+
+	tool, err := client.LoadTool("search", context.Background(), WithToolNamePrefix("analytics_"))
+	if err != nil {
+		t.Fatalf("LoadTool failed: %v", err)
+	}
+	if tool.Name() != "analytics_search" {
+		t.Errorf("Expected the prefixed name, got %q", tool.Name())
+	}
+	result, err := tool.Invoke(context.Background(), map[string]any{})
+	if err != nil {
+		t.Fatalf("Invoke failed: %v", err)
+	}
+	if result != "search" {
+		t.Errorf("Expected the server to be called with the original name, got %v", result)
+	}
+
+	tools, err := client.LoadToolset("", context.Background(),
+		WithToolNamePrefix("analytics_"),
+		WithToolFilter([]string{"report"}, nil),
+	)
+	if err != nil {
+		t.Fatalf("LoadToolset failed: %v", err)
+	}
+	if len(tools) != 1 || tools[0].Name() != "analytics_report" {
+		t.Errorf("Expected only analytics_report, got %v", toolNames(tools))
+	}
+}
+
+func TestTools(t *testing.T) {
+	emptySchema := map[string]any{"type": "object", "properties": map[string]any{}}
+	server := newMockMCPServer(t, []mcpTool{
+		{Name: "c", InputSchema: emptySchema},
+		{Name: "a", InputSchema: emptySchema, Annotations: map[string]any{"readOnlyHint": true}},
+		{Name: "b", InputSchema: map[string]any{"type": "object", "properties": map[string]any{"q": map[string]any{"type": "string"}}}},
+	})
+	defer server.Close()
+
+	client, err := NewToolboxClient(server.URL, WithHTTPClient(server.Client()))
+	if err != nil {
+		t.Fatalf("NewToolboxClient failed: %v", err)
+	}
+
+	t.Run("Yields the tools in order of their names", func(t *testing.T) {
+		var names []string
+		for tool, err := range client.Tools(context.Background(), "") {
+			if err != nil {
+				t.Fatalf("Tools failed: %v", err)
+			}
+			names = append(names, tool.Name())
+		}
+		if !reflect.DeepEqual(names, []string{"a", "b", "c"}) {
+			t.Errorf("Expected tools [a b c], got %v", names)
+		}
+	})
+
+	t.Run("Stops when the loop breaks", func(t *testing.T) {
+		var names []string
+		for tool, err := range client.Tools(context.Background(), "") {
+			if err != nil {
+				t.Fatalf("Tools failed: %v", err)
+			}
+			names = append(names, tool.Name())
+			break
+		}
+		if !reflect.DeepEqual(names, []string{"a"}) {
+			t.Errorf("Expected only tool a, got %v", names)
+		}
+	})
+
+	t.Run("Yields validation errors before any tool", func(t *testing.T) {
+		var names []string
+		var lastErr error
+		for tool, err := range client.Tools(context.Background(), "", WithBindParamString("unused", "x")) {
+			if err != nil {
+				lastErr = err
+				continue
+			}
+			names = append(names, tool.Name())
+		}
+		if len(names) != 0 || lastErr == nil || !strings.Contains(lastErr.Error(), "unused bound parameters could not be applied to any tool: unused") {
+			t.Errorf("Expected no tools and an unused parameter error, got %v and %v", names, lastErr)
+		}
+	})
+
+	t.Run("Yields each tool before constructing the next", func(t *testing.T) {
+		// Only tool a can be cached, so constructing tool b fails.
+		var names []string
+		var errs []error
+		for tool, err := range client.Tools(context.Background(), "", WithResultCache(time.Minute, 10)) {
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			names = append(names, tool.Name())
+		}
+		if !reflect.DeepEqual(names, []string{"a"}) || len(errs) != 1 || !strings.Contains(errs[0].Error(), "failed to create tool 'b'") {
+			t.Errorf("Expected tool a and then an error for tool b, got %v and %v", names, errs)
+		}
+
+		if _, err := client.LoadToolset("", context.Background(), WithResultCache(time.Minute, 10)); err == nil {
+			t.Error("Expected LoadToolset to fail")
+		}
+	})
+
+	t.Run("Yields strict validation errors before the tool", func(t *testing.T) {
+		var names []string
+		var lastErr error
+		for tool, err := range client.Tools(context.Background(), "", WithStrict(true), WithBindParamString("q", "x")) {
+			if err != nil {
+				lastErr = err
+				continue
+			}
+			names = append(names, tool.Name())
+		}
+		if len(names) != 0 || lastErr == nil || !strings.Contains(lastErr.Error(), "no parameter named 'q' found on tool 'a'") {
+			t.Errorf("Expected no tools and a strict mode error, got %v and %v", names, lastErr)
+		}
+	})
+}
+
+func TestLoad_WithQueryParams(t *testing.T) {
+	server := newMockMCPServer(t, []mcpTool{
+		{Name: "search", InputSchema: map[string]any{"type": "object", "properties": map[string]any{}}},
+	})
+	defer server.Close()
+
+	// Record the query of every request by JSON-RPC method, and answer tool
+	// calls.
+	var mu sync.Mutex
+	queries := map[string][]string{}
+	mockHandler := server.Config.Handler
+	server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var req struct {
+			ID     any    `json:"id"`
+			Method string `json:"method"`
+		}
+		_ = json.Unmarshal(body, &req)
+		mu.Lock()
+		queries[req.Method] = append(queries[req.Method], r.URL.RawQuery)
+		mu.Unlock()
+		if req.Method == "tools/call" {
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"jsonrpc": "2.0",
+				"id":      req.ID,
+				"result":  map[string]any{"content": []any{map[string]any{"type": "text", "text": "ok"}}},
+			})
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		mockHandler.ServeHTTP(w, r)
+	})
+
+	client, err := NewToolboxClient(server.URL,
+		WithHTTPClient(server.Client()),
+		WithQueryParams(url.Values{"version": {"2"}}),
+		WithManifestCache(NewManifestCache(time.Minute)),
+	)
+	if err != nil {
+		t.Fatalf("NewToolboxClient failed: %v", err)
+	}
+
+	tool, err := client.LoadTool("search", context.Background())
+	if err != nil {
+		t.Fatalf("LoadTool failed: %v", err)
+	}
+	if _, err := client.LoadToolset("", context.Background(), WithManifestQueryParams(url.Values{"version": {"3"}, "beta": {"true"}})); err != nil {
+		t.Fatalf("LoadToolset failed: %v", err)
+	}
+	if _, err := tool.Invoke(context.Background(), map[string]any{}); err != nil {
+		t.Fatalf("Invoke failed: %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if want := []string{"version=2", "beta=true&version=3"}; !reflect.DeepEqual(queries["tools/list"], want) {
+		t.Errorf("Expected manifest requests with queries %q, got %q", want, queries["tools/list"])
+	}
+	if want := []string{""}; !reflect.DeepEqual(queries["tools/call"], want) {
+		t.Errorf("Expected tool calls without a query, got %q", queries["tools/call"])
+	}
+}
+
+func TestGetToolsetManifest(t *testing.T) {
+	server := newMockMCPServer(t, []mcpTool{
+		{
+			Name:        "search",
+			Description: "Search for hotels.",
+			InputSchema: map[string]any{
+				"type":       "object",
+				"properties": map[string]any{"city": map[string]any{"type": "string", "description": "The city."}},
+				"required":   []string{"city"},
+			},
+		},
+	})
+	defer server.Close()
+	mockHandler := server.Config.Handler
+	server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/missing") {
+			http.Error(w, `{"error": "toolset \"missing\" does not exist"}`, http.StatusNotFound)
+			return
+		}
+		mockHandler.ServeHTTP(w, r)
+	})
+
+	client, err := NewToolboxClient(server.URL, WithHTTPClient(server.Client()))
+	if err != nil {
+		t.Fatalf("NewToolboxClient failed: %v", err)
+	}
+
+	t.Run("Returns the raw schemas", func(t *testing.T) {
+		manifest, err := client.GetToolsetManifest(context.Background(), "")
+		if err != nil {
+			t.Fatalf("GetToolsetManifest failed: %v", err)
+		}
+		schema, ok := manifest.Tools["search"]
+		if !ok || len(manifest.Tools) != 1 {
+			t.Fatalf("Expected a manifest with the search tool, got %+v", manifest.Tools)
+		}
+		want := []ParameterSchema{{Name: "city", Type: "string", Description: "The city.", Required: true}}
+		if schema.Description != "Search for hotels." || !reflect.DeepEqual(schema.Parameters, want) {
+			t.Errorf("Unexpected schema: %+v", schema)
+		}
+	})
+
+	t.Run("Reports missing toolsets", func(t *testing.T) {
+		_, err := client.GetToolsetManifest(context.Background(), "missing")
+		if !errors.Is(err, ErrToolsetNotFound) {
+			t.Errorf("Expected ErrToolsetNotFound, got: %v", err)
+		}
+	})
+}
+
 func TestLoadTool_HTTPWarning(t *testing.T) {
 	// Setup a mock HTTP server (not HTTPS) using MCP
 	mcpTools := []mcpTool{
@@ -709,6 +1163,35 @@ func TestDefaultOptionOverwriting(t *testing.T) {
 			t.Errorf("Expected error message to contain %q, but got: %v", expectedErrorMsg, err)
 		}
 	})
+
+	t.Run("LoadToolset - Overrides defaults with WithOverride", func(t *testing.T) {
+		client, err := NewToolboxClient(server.URL,
+			WithHTTPClient(server.Client()),
+			WithDefaultToolOptions(
+				WithBindParamString("user_id", "default_user"),
+				WithAuthTokenString("google", "default_google_token"),
+			),
+		)
+		if err != nil {
+			t.Fatalf("Client creation with default options failed unexpectedly: %v", err)
+		}
+
+		tools, err := client.LoadToolset("", context.Background(), WithOverride(
+			WithBindParamString("user_id", "override_user"),
+			WithAuthTokenString("google", "override_google_token"),
+		))
+		if err != nil {
+			t.Fatalf("Expected WithOverride to replace the defaults, but got: %v", err)
+		}
+
+		tool := tools[0]
+		if tool.boundParams["user_id"] != "override_user" {
+			t.Errorf("Expected the overridden binding, got %v", tool.boundParams["user_id"])
+		}
+		if token, _ := tool.authTokenSources["google"].Token(); token.AccessToken != "override_google_token" {
+			t.Errorf("Expected the overridden auth token, got %q", token.AccessToken)
+		}
+	})
 }
 
 func TestNegativeAndEdgeCases(t *testing.T) {
@@ -924,6 +1407,19 @@ func TestLoadToolAndLoadToolset_ErrorPaths(t *testing.T) {
 		}
 	})
 
+	t.Run("LoadTool and LoadToolset reject ToolFrom-only options", func(t *testing.T) {
+		client, _ := NewToolboxClient(server.URL, WithHTTPClient(server.Client()))
+		_, err := client.LoadTool("toolA", context.Background(), WithUnbindParam("param1"))
+		if err == nil || !strings.Contains(err.Error(), "WithUnbindParam option is only applicable to ToolFrom") {
+			t.Errorf("Expected WithUnbindParam to be rejected by LoadTool. Got: %v", err)
+		}
+
+		_, err = client.LoadToolset("", context.Background(), WithRebindParam("param1", "value"))
+		if err == nil || !strings.Contains(err.Error(), "WithRebindParam option is only applicable to ToolFrom") {
+			t.Errorf("Expected WithRebindParam to be rejected by LoadToolset. Got: %v", err)
+		}
+	})
+
 	t.Run("LoadToolset fails with unused parameters in strict mode", func(t *testing.T) {
 		client, _ := NewToolboxClient(server.URL, WithHTTPClient(server.Client()))
 		_, err := client.LoadToolset(
@@ -958,4 +1454,468 @@ func TestLoadToolAndLoadToolset_ErrorPaths(t *testing.T) {
 			t.Errorf("Incorrect error for completely unused param. Got: %v", err)
 		}
 	})
+
+	t.Run("Unused bindings are logged when allowed", func(t *testing.T) {
+		buf.Reset()
+		client, _ := NewToolboxClient(server.URL, WithHTTPClient(server.Client()),
+			WithDefaultToolOptions(
+				WithAllowUnusedBindings(true),
+				WithBindParamString("param1", "value-for-tool-a"),
+				WithBindParamString("completely-unused-param", "value"),
+			),
+		)
+
+		tool, err := client.LoadTool("toolB", context.Background())
+		if err != nil {
+			t.Fatalf("Expected LoadTool to succeed, got: %v", err)
+		}
+		if _, ok := tool.boundParams["param1"]; ok {
+			t.Error("Expected the unused binding not to be applied to the tool")
+		}
+
+		if _, err := client.LoadToolset("", context.Background(), WithStrict(true)); err != nil {
+			t.Errorf("Expected strict LoadToolset to succeed, got: %v", err)
+		}
+		tools, err := client.LoadToolset("", context.Background())
+		if err != nil || len(tools) != 2 {
+			t.Errorf("Expected LoadToolset to load both tools, got %d tools and %v", len(tools), err)
+		}
+
+		logs := buf.String()
+		for _, want := range []string{
+			"WARNING: validation failed for tool 'toolB': unused bound parameters: ",
+			"WARNING: validation failed for toolset 'default': unused bound parameters could not be applied to any tool: completely-unused-param",
+		} {
+			if !strings.Contains(logs, want) {
+				t.Errorf("Expected the logs to contain %q, got:\n%s", want, logs)
+			}
+		}
+	})
+
+	t.Run("LoadToolset distinguishes missing and empty toolsets", func(t *testing.T) {
+		mock := newMockMCPServer(t, []mcpTool{})
+		defer mock.Close()
+		mockHandler := mock.Config.Handler
+		mock.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if strings.HasSuffix(r.URL.Path, "/missing") {
+				http.Error(w, `{"error": "toolset \"missing\" does not exist"}`, http.StatusNotFound)
+				return
+			}
+			mockHandler.ServeHTTP(w, r)
+		})
+		client, _ := NewToolboxClient(mock.URL, WithHTTPClient(mock.Client()))
+
+		_, err := client.LoadToolset("missing", context.Background())
+		if !errors.Is(err, ErrToolsetNotFound) {
+			t.Errorf("Expected ErrToolsetNotFound for a missing toolset. Got: %v", err)
+		}
+
+		_, err = client.LoadToolset("", context.Background())
+		if err == nil || errors.Is(err, ErrToolsetNotFound) || !strings.Contains(err.Error(), "toolset 'default' contains no tools") {
+			t.Errorf("Expected an empty toolset error. Got: %v", err)
+		}
+
+		tools, err := client.LoadToolset("", context.Background(), WithAllowEmptyToolset())
+		if err != nil || tools == nil || len(tools) != 0 {
+			t.Errorf("Expected an empty slice with WithAllowEmptyToolset. Got: %v, %v", tools, err)
+		}
+
+		_, err = client.LoadTool("toolA", context.Background(), WithAllowEmptyToolset())
+		if err == nil || !strings.Contains(err.Error(), "WithAllowEmptyToolset option is only applicable to LoadToolset") {
+			t.Errorf("Expected WithAllowEmptyToolset to be rejected by LoadTool. Got: %v", err)
+		}
+	})
+}
+
+// notifyingTransport is a dummyTransport that can deliver server notifications.
+type notifyingTransport struct {
+	dummyTransport
+	handler transport.NotificationHandler
+}
+
+func (n *notifyingTransport) SetNotificationHandler(handler transport.NotificationHandler) {
+	n.handler = handler
+}
+
+func TestToolsChangedHandler(t *testing.T) {
+	t.Run("Runs handlers when the tool list changes", func(t *testing.T) {
+		custom := &notifyingTransport{}
+		var calls []string
+		_, err := NewToolboxClient("https://custom",
+			WithCustomTransport(custom),
+			WithToolsChangedHandler(func() { calls = append(calls, "first") }),
+			WithToolsChangedHandler(func() { calls = append(calls, "second") }),
+		)
+		if err != nil {
+			t.Fatalf("NewToolboxClient failed: %v", err)
+		}
+		if custom.handler == nil {
+			t.Fatal("Expected the client to subscribe to notifications")
+		}
+
+		custom.handler(transport.Notification{Method: "notifications/message"})
+		if len(calls) != 0 {
+			t.Errorf("Expected other notifications to be ignored, got %v", calls)
+		}
+		custom.handler(transport.Notification{Method: "notifications/tools/list_changed"})
+		if !reflect.DeepEqual(calls, []string{"first", "second"}) {
+			t.Errorf("Expected both handlers to run in order, got %v", calls)
+		}
+	})
+
+	t.Run("Does not subscribe without handlers", func(t *testing.T) {
+		custom := &notifyingTransport{}
+		if _, err := NewToolboxClient("https://custom", WithCustomTransport(custom)); err != nil {
+			t.Fatalf("NewToolboxClient failed: %v", err)
+		}
+		if custom.handler != nil {
+			t.Error("Expected no notification handler to be set")
+		}
+	})
+}
+
+// closingTransport is a dummyTransport that records Close calls.
+type closingTransport struct {
+	dummyTransport
+	closed   int
+	closeErr error
+}
+
+func (c *closingTransport) Close(ctx context.Context) error {
+	c.closed++
+	return c.closeErr
+}
+
+func TestClose(t *testing.T) {
+	custom := &closingTransport{closeErr: errors.New("shutdown failed")}
+	client, err := NewToolboxClient("https://custom", WithCustomTransport(custom))
+	if err != nil {
+		t.Fatalf("NewToolboxClient failed: %v", err)
+	}
+
+	err = client.Close(context.Background())
+	if custom.closed != 1 {
+		t.Errorf("Expected the transport to be closed once, got %d", custom.closed)
+	}
+	if !errors.Is(err, custom.closeErr) {
+		t.Errorf("Expected the transport error, got %v", err)
+	}
+}
+
+// pingingTransport is a dummyTransport that can be kept alive.
+type pingingTransport struct {
+	dummyTransport
+	interval    time.Duration
+	maxFailures int
+}
+
+func (p *pingingTransport) SetKeepAlive(interval time.Duration, maxFailures int) {
+	p.interval = interval
+	p.maxFailures = maxFailures
+}
+
+func TestWithKeepAlive(t *testing.T) {
+	t.Run("Configures the transport", func(t *testing.T) {
+		custom := &pingingTransport{}
+		_, err := NewToolboxClient("https://custom", WithCustomTransport(custom), WithKeepAlive(time.Minute, 3))
+		if err != nil {
+			t.Fatalf("NewToolboxClient failed: %v", err)
+		}
+		if custom.interval != time.Minute || custom.maxFailures != 3 {
+			t.Errorf("Unexpected keep-alive settings: %s, %d", custom.interval, custom.maxFailures)
+		}
+	})
+
+	t.Run("Surfaces rate limits once retries are exhausted", func(t *testing.T) {
+		var attempts atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			attempts.Add(1)
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusTooManyRequests)
+			_, _ = w.Write([]byte(`{"error": {"message": "quota exceeded", "retryAfter": 0.001}}`))
+		}))
+		defer server.Close()
+
+		client, err := NewToolboxClient(server.URL, WithHTTPClient(server.Client()), WithProtocol(MCPv20250618), WithTransportRetry(3, time.Hour, 0))
+		if err != nil {
+			t.Fatalf("NewToolboxClient failed: %v", err)
+		}
+		_, err = client.LoadToolset("", context.Background())
+		if !errors.Is(err, ErrRateLimited) {
+			t.Fatalf("Expected ErrRateLimited, got %v", err)
+		}
+		var invocationErr *ToolInvocationError
+		if !errors.As(err, &invocationErr) || invocationErr.RetryAfter != time.Millisecond {
+			t.Errorf("Expected the retry hint of the server, got %v", err)
+		}
+		if attempts.Load() != 3 {
+			t.Errorf("Expected 3 attempts, got %d", attempts.Load())
+		}
+	})
+
+	t.Run("Surfaces rate limits longer than the maximum delay", func(t *testing.T) {
+		var attempts atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			attempts.Add(1)
+			w.Header().Set("Retry-After", "60")
+			w.WriteHeader(http.StatusTooManyRequests)
+		}))
+		defer server.Close()
+
+		client, err := NewToolboxClient(server.URL, WithHTTPClient(server.Client()), WithProtocol(MCPv20250618), WithTransportRetry(3, time.Millisecond, time.Second))
+		if err != nil {
+			t.Fatalf("NewToolboxClient failed: %v", err)
+		}
+		_, err = client.LoadToolset("", context.Background())
+		var invocationErr *ToolInvocationError
+		if !errors.Is(err, ErrRateLimited) || !errors.As(err, &invocationErr) || invocationErr.RetryAfter != time.Minute {
+			t.Fatalf("Expected ErrRateLimited with the retry hint of the server, got %v", err)
+		}
+		if attempts.Load() != 1 {
+			t.Errorf("Expected a single attempt, got %d", attempts.Load())
+		}
+	})
+
+	t.Run("Negative Test - Invalid usage", func(t *testing.T) {
+		testCases := []struct {
+			name    string
+			opts    []ClientOption
+			wantErr string
+		}{
+			{"Zero interval", []ClientOption{WithKeepAlive(0, 3)}, "interval must be positive"},
+			{"Zero failures", []ClientOption{WithKeepAlive(time.Minute, 0)}, "maxFailures must be at least 1"},
+			{"Duplicate", []ClientOption{WithKeepAlive(time.Minute, 1), WithKeepAlive(time.Second, 1)}, "already set"},
+			{"Unsupported transport", []ClientOption{WithCustomTransport(&dummyTransport{}), WithKeepAlive(time.Minute, 1)}, "cannot be kept alive"},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				_, err := NewToolboxClient("https://custom", tc.opts...)
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Errorf("Expected error containing %q, got %v", tc.wantErr, err)
+				}
+			})
+		}
+	})
+}
+
+func TestWithTransportRetry(t *testing.T) {
+	t.Run("Retries requests over HTTP transports", func(t *testing.T) {
+		var attempts atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if attempts.Add(1) == 1 {
+				w.Header().Set("Retry-After", "0")
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			var req struct {
+				ID     any    `json:"id"`
+				Method string `json:"method"`
+			}
+			_ = json.NewDecoder(r.Body).Decode(&req)
+			var result any = map[string]any{}
+			if req.Method == "initialize" {
+				result = map[string]any{
+					"protocolVersion": "2025-06-18",
+					"capabilities":    map[string]any{"tools": map[string]any{}},
+					"serverInfo":      map[string]any{"name": "mock", "version": "1.0.0"},
+				}
+			} else if req.Method == "tools/list" {
+				result = map[string]any{"tools": []any{}}
+			}
+			if req.ID == nil {
+				w.WriteHeader(http.StatusAccepted)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(map[string]any{"jsonrpc": "2.0", "id": req.ID, "result": result})
+		}))
+		defer server.Close()
+
+		client, err := NewToolboxClient(server.URL, WithHTTPClient(server.Client()), WithProtocol(MCPv20250618), WithTransportRetry(2, time.Millisecond, 0))
+		if err != nil {
+			t.Fatalf("NewToolboxClient failed: %v", err)
+		}
+		if _, err := client.LoadToolset("", context.Background(), WithAllowEmptyToolset()); err != nil {
+			t.Fatalf("LoadToolset failed: %v", err)
+		}
+		if attempts.Load() < 2 {
+			t.Errorf("Expected the rejected request to be retried")
+		}
+	})
+
+	t.Run("Resends tool calls only for idempotent tools", func(t *testing.T) {
+		schema := map[string]any{"type": "object", "properties": map[string]any{}}
+		server := newMockMCPServer(t, []mcpTool{
+			{Name: "book", Description: "Book a hotel.", InputSchema: schema},
+			{Name: "lookup", Description: "Look up a hotel.", InputSchema: schema, Annotations: map[string]any{"idempotentHint": true}},
+		})
+		defer server.Close()
+
+		// Reject the first call of every tool, and count the calls.
+		var mu sync.Mutex
+		calls := map[string]int{}
+		mockHandler := server.Config.Handler
+		server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			var req struct {
+				ID     any    `json:"id"`
+				Method string `json:"method"`
+				Params struct {
+					Name string `json:"name"`
+				} `json:"params"`
+			}
+			_ = json.Unmarshal(body, &req)
+			if req.Method != "tools/call" {
+				r.Body = io.NopCloser(bytes.NewReader(body))
+				mockHandler.ServeHTTP(w, r)
+				return
+			}
+			mu.Lock()
+			calls[req.Params.Name]++
+			first := calls[req.Params.Name] == 1
+			mu.Unlock()
+			if first {
+				w.Header().Set("Retry-After", "0")
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"jsonrpc": "2.0",
+				"id":      req.ID,
+				"result":  map[string]any{"content": []any{map[string]any{"type": "text", "text": "ok"}}},
+			})
+		})
+
+		client, err := NewToolboxClient(server.URL, WithHTTPClient(server.Client()), WithTransportRetry(3, time.Millisecond, 0))
+		if err != nil {
+			t.Fatalf("NewToolboxClient failed: %v", err)
+		}
+		tools, err := client.LoadToolset("", context.Background())
+		if err != nil {
+			t.Fatalf("LoadToolset failed: %v", err)
+		}
+		for _, tool := range tools {
+			_, _ = tool.Invoke(context.Background(), map[string]any{})
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		if want := map[string]int{"book": 1, "lookup": 2}; !reflect.DeepEqual(calls, want) {
+			t.Errorf("Expected tool calls %v, got %v", want, calls)
+		}
+	})
+
+	t.Run("Negative Test - Invalid usage", func(t *testing.T) {
+		testCases := []struct {
+			name    string
+			opts    []ClientOption
+			wantErr string
+		}{
+			{"Single attempt", []ClientOption{WithTransportRetry(1, time.Second, 0)}, "maxAttempts must be at least 2"},
+			{"Negative delay", []ClientOption{WithTransportRetry(3, -time.Second, 0)}, "delays cannot be negative"},
+			{"Duplicate", []ClientOption{WithTransportRetry(2, 0, 0), WithTransportRetry(3, 0, 0)}, "already set"},
+			{"Unsupported transport", []ClientOption{WithCustomTransport(&dummyTransport{}), WithTransportRetry(2, 0, 0)}, "does not support retries"},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				_, err := NewToolboxClient("https://custom", tc.opts...)
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Errorf("Expected error containing %q, got %v", tc.wantErr, err)
+				}
+			})
+		}
+	})
+}
+
+// observedTransport is a dummyTransport that can be observed.
+type observedTransport struct {
+	dummyTransport
+	observer TransportObserver
+}
+
+func (o *observedTransport) SetObserver(observer TransportObserver) {
+	o.observer = observer
+}
+
+// nopObserver is a TransportObserver that ignores all requests.
+type nopObserver struct{}
+
+func (nopObserver) OnRPCStart(context.Context, string)                     {}
+func (nopObserver) OnRPCEnd(context.Context, string, time.Duration, error) {}
+
+func TestWithTransportObserver(t *testing.T) {
+	t.Run("Configures the transport", func(t *testing.T) {
+		custom := &observedTransport{}
+		_, err := NewToolboxClient("https://custom", WithCustomTransport(custom), WithTransportObserver(nopObserver{}))
+		if err != nil {
+			t.Fatalf("NewToolboxClient failed: %v", err)
+		}
+		if custom.observer != (nopObserver{}) {
+			t.Errorf("Expected the observer to be set on the transport, got %v", custom.observer)
+		}
+	})
+
+	t.Run("Negative Test - Invalid usage", func(t *testing.T) {
+		testCases := []struct {
+			name    string
+			opts    []ClientOption
+			wantErr string
+		}{
+			{"Nil observer", []ClientOption{WithTransportObserver(nil)}, "cannot be nil"},
+			{"Duplicate", []ClientOption{WithTransportObserver(nopObserver{}), WithTransportObserver(nopObserver{})}, "already set"},
+			{"Unsupported transport", []ClientOption{WithCustomTransport(&dummyTransport{}), WithTransportObserver(nopObserver{})}, "cannot be observed"},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				_, err := NewToolboxClient("https://custom", tc.opts...)
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Errorf("Expected error containing %q, got %v", tc.wantErr, err)
+				}
+			})
+		}
+	})
+}
+
+// timedTransport is a dummyTransport that supports request timeouts.
+type timedTransport struct {
+	dummyTransport
+	timeout time.Duration
+}
+
+func (tt *timedTransport) SetRequestTimeout(timeout time.Duration) {
+	tt.timeout = timeout
+}
+
+func TestWithRequestTimeout(t *testing.T) {
+	t.Run("Configures the transport", func(t *testing.T) {
+		custom := &timedTransport{}
+		_, err := NewToolboxClient("https://custom", WithCustomTransport(custom), WithRequestTimeout(30*time.Second))
+		if err != nil {
+			t.Fatalf("NewToolboxClient failed: %v", err)
+		}
+		if custom.timeout != 30*time.Second {
+			t.Errorf("Expected the timeout to be set on the transport, got %s", custom.timeout)
+		}
+	})
+
+	t.Run("Negative Test - Invalid usage", func(t *testing.T) {
+		testCases := []struct {
+			name    string
+			opts    []ClientOption
+			wantErr string
+		}{
+			{"Zero timeout", []ClientOption{WithRequestTimeout(0)}, "must be positive"},
+			{"Duplicate", []ClientOption{WithRequestTimeout(time.Second), WithRequestTimeout(time.Second)}, "already set"},
+			{"Unsupported transport", []ClientOption{WithCustomTransport(&dummyTransport{}), WithRequestTimeout(time.Second)}, "does not support request timeouts"},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				_, err := NewToolboxClient("https://custom", tc.opts...)
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Errorf("Expected error containing %q, got %v", tc.wantErr, err)
+				}
+			})
+		}
+	})
 }
@@ -0,0 +1,82 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"fmt"
+	"maps"
+
+	"golang.org/x/oauth2"
+)
+
+// SetClientHeader sets a client-wide HTTP header from a TokenSource, replacing
+// any source already set for it, so that long-lived processes can rotate API
+// keys or switch identities without creating a new client. Use
+// oauth2.StaticTokenSource for a fixed value. As with
+// WithClientHeaderTokenSource, the token is cached until it is about to
+// expire.
+//
+// The header applies to every request that resolves its headers after the
+// call returns, including invocations of tools that were loaded before it.
+// SetClientHeader is safe to call concurrently with other methods of the
+// client and its tools.
+func (tc *ToolboxClient) SetClientHeader(headerName string, source oauth2.TokenSource) error {
+	if source == nil {
+		return fmt.Errorf("SetClientHeader: provided oauth2.TokenSource for header '%s' cannot be nil", headerName)
+	}
+	source = reuseTokenSource(source, tc.tokenRefreshSkew, tc.tokenRefreshSkewSet)
+	checkSecureHeaders(tc.baseURL, true)
+
+	tc.headersMu.Lock()
+	defer tc.headersMu.Unlock()
+	sources := maps.Clone(tc.clientHeaderSources)
+	if sources == nil {
+		sources = make(map[string]oauth2.TokenSource)
+	}
+	sources[headerName] = source
+	tc.clientHeaderSources = sources
+	return nil
+}
+
+// RemoveClientHeader removes a client-wide HTTP header, if it is set. Like
+// SetClientHeader, it applies to every request that resolves its headers
+// after the call returns, and is safe for concurrent use.
+func (tc *ToolboxClient) RemoveClientHeader(headerName string) {
+	tc.headersMu.Lock()
+	defer tc.headersMu.Unlock()
+	if _, ok := tc.clientHeaderSources[headerName]; !ok {
+		return
+	}
+	sources := maps.Clone(tc.clientHeaderSources)
+	delete(sources, headerName)
+	tc.clientHeaderSources = sources
+}
+
+// headerSources returns the current client header sources. The returned map
+// must not be modified.
+func (tc *ToolboxClient) headerSources() map[string]oauth2.TokenSource {
+	tc.headersMu.RLock()
+	defer tc.headersMu.RUnlock()
+	return tc.clientHeaderSources
+}
+
+// headerSources returns the client header sources of the tool, which follow
+// the client that loaded it, if any. The returned map must not be modified.
+func (tt *ToolboxTool) headerSources() map[string]oauth2.TokenSource {
+	if tt.clientHeaders != nil {
+		return tt.clientHeaders()
+	}
+	return tt.clientHeaderSources
+}
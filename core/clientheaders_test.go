@@ -0,0 +1,214 @@
+//go:build unit
+
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"golang.org/x/oauth2"
+)
+
+// newHeaderEchoServer creates a mock server with a single tool, "echo", which
+// returns the value of the given request header prefixed with "key=".
+func newHeaderEchoServer(t *testing.T, header string) *httptest.Server {
+	server := newMockMCPServer(t, []mcpTool{
+		{Name: "echo", InputSchema: map[string]any{"type": "object", "properties": map[string]any{}}},
+	})
+	mockHandler := server.Config.Handler
+	server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var req struct {
+			ID     any    `json:"id"`
+			Method string `json:"method"`
+		}
+		if json.Unmarshal(body, &req) == nil && req.Method == "tools/call" {
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"jsonrpc": "2.0",
+				"id":      req.ID,
+				"result":  map[string]any{"content": []any{map[string]any{"type": "text", "text": "key=" + r.Header.Get(header)}}},
+			})
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		mockHandler.ServeHTTP(w, r)
+	})
+	return server
+}
+
+// echoedHeader invokes a tool created by newHeaderEchoServer.
+func echoedHeader(t *testing.T, ctx context.Context, tool *ToolboxTool) string {
+	t.Helper()
+	result, err := tool.Invoke(ctx, map[string]any{})
+	if err != nil {
+		t.Fatalf("Invoke failed: %v", err)
+	}
+	return fmt.Sprint(result)
+}
+
+func TestSetClientHeader(t *testing.T) {
+	server := newHeaderEchoServer(t, "X-Api-Key")
+	defer server.Close()
+
+	client, err := NewToolboxClient(server.URL,
+		WithHTTPClient(server.Client()),
+		WithClientHeaderString("X-Api-Key", "old-key"),
+	)
+	if err != nil {
+		t.Fatalf("NewToolboxClient failed: %v", err)
+	}
+	tool, err := client.LoadTool("echo", context.Background())
+	if err != nil {
+		t.Fatalf("LoadTool failed: %v", err)
+	}
+	derived, err := tool.ToolFrom()
+	if err != nil {
+		t.Fatalf("ToolFrom failed: %v", err)
+	}
+
+	apiKey := func(tool *ToolboxTool) string {
+		t.Helper()
+		return echoedHeader(t, context.Background(), tool)
+	}
+
+	if got := apiKey(tool); got != "key=old-key" {
+		t.Errorf("Expected the initial key, got %q", got)
+	}
+
+	t.Run("Applies to loaded and derived tools", func(t *testing.T) {
+		if err := client.SetClientHeader("X-Api-Key", oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "new-key"})); err != nil {
+			t.Fatalf("SetClientHeader failed: %v", err)
+		}
+		if got := apiKey(tool); got != "key=new-key" {
+			t.Errorf("Expected the loaded tool to use the new key, got %q", got)
+		}
+		if got := apiKey(derived); got != "key=new-key" {
+			t.Errorf("Expected the derived tool to use the new key, got %q", got)
+		}
+	})
+
+	t.Run("Removes headers", func(t *testing.T) {
+		client.RemoveClientHeader("X-Api-Key")
+		client.RemoveClientHeader("X-Not-Set")
+		if got := apiKey(tool); got != "key=" {
+			t.Errorf("Expected no key after RemoveClientHeader, got %q", got)
+		}
+	})
+
+	t.Run("Rejects nil sources", func(t *testing.T) {
+		err := client.SetClientHeader("X-Api-Key", nil)
+		if err == nil || !strings.Contains(err.Error(), "cannot be nil") {
+			t.Errorf("Expected a nil source error, got %v", err)
+		}
+	})
+
+	t.Run("Is safe for concurrent use", func(t *testing.T) {
+		var wg sync.WaitGroup
+		for i := range 10 {
+			wg.Go(func() {
+				key := fmt.Sprintf("key-%d", i)
+				if err := client.SetClientHeader("X-Api-Key", oauth2.StaticTokenSource(&oauth2.Token{AccessToken: key})); err != nil {
+					t.Errorf("SetClientHeader failed: %v", err)
+				}
+				if _, err := tool.Invoke(context.Background(), map[string]any{}); err != nil {
+					t.Errorf("Invoke failed: %v", err)
+				}
+				client.RemoveClientHeader("X-Api-Key")
+			})
+		}
+		wg.Wait()
+	})
+}
+
+type tenantKey struct{}
+
+func TestWithClientHeaderFunc(t *testing.T) {
+	server := newHeaderEchoServer(t, "X-Tenant")
+	defer server.Close()
+
+	var manifestTenant string
+	echoHandler := server.Config.Handler
+	server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if manifestTenant == "" {
+			manifestTenant = r.Header.Get("X-Tenant")
+		}
+		echoHandler.ServeHTTP(w, r)
+	})
+
+	tenant := func(ctx context.Context) (string, error) {
+		id, ok := ctx.Value(tenantKey{}).(string)
+		if !ok {
+			return "", errors.New("no tenant in context")
+		}
+		return id, nil
+	}
+	client, err := NewToolboxClient(server.URL,
+		WithHTTPClient(server.Client()),
+		WithClientHeaderFunc("X-Tenant", tenant),
+	)
+	if err != nil {
+		t.Fatalf("NewToolboxClient failed: %v", err)
+	}
+
+	tool, err := client.LoadTool("echo", context.WithValue(context.Background(), tenantKey{}, "loader"))
+	if err != nil {
+		t.Fatalf("LoadTool failed: %v", err)
+	}
+	if manifestTenant != "loader" {
+		t.Errorf("Expected the manifest to be loaded for the loading tenant, got %q", manifestTenant)
+	}
+
+	t.Run("Resolves headers from each request context", func(t *testing.T) {
+		for _, id := range []string{"acme", "globex"} {
+			ctx := context.WithValue(context.Background(), tenantKey{}, id)
+			if got := echoedHeader(t, ctx, tool); got != "key="+id {
+				t.Errorf("Expected tenant %q, got %q", id, got)
+			}
+		}
+	})
+
+	t.Run("Fails requests the function fails", func(t *testing.T) {
+		_, err := tool.Invoke(context.Background(), map[string]any{})
+		if err == nil || !strings.Contains(err.Error(), "no tenant in context") {
+			t.Errorf("Expected the header function error, got %v", err)
+		}
+	})
+
+	t.Run("Validates the option", func(t *testing.T) {
+		_, err := NewToolboxClient(server.URL, WithClientHeaderFunc("X-Tenant", nil))
+		if err == nil || !strings.Contains(err.Error(), "WithClientHeaderFunc: provided function for header 'X-Tenant' cannot be nil") {
+			t.Errorf("Expected a nil function error, got %v", err)
+		}
+		_, err = NewToolboxClient(server.URL,
+			WithClientHeaderString("X-Tenant", "static"),
+			WithClientHeaderFunc("X-Tenant", tenant),
+		)
+		if err == nil || !strings.Contains(err.Error(), "client header 'X-Tenant' is already set and cannot be overridden") {
+			t.Errorf("Expected a duplicate header error, got %v", err)
+		}
+	})
+}
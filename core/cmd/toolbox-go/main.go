@@ -0,0 +1,375 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Command toolbox-go lists, describes and invokes the tools of a Toolbox
+// server from the command line, which helps when debugging a server
+// configuration without writing Go code.
+//
+// Usage:
+//
+//	toolbox-go [flags] toolsets [toolset]...
+//	toolbox-go [flags] list [toolset]
+//	toolbox-go [flags] describe <tool>
+//	toolbox-go [flags] invoke [-param name=value]... [-stdin] <tool>
+//
+// The toolsets command lists the tools of the default toolset and of the
+// named toolsets, one toolset per line. Toolbox servers do not enumerate their
+// toolsets, so toolsets other than the default one must be named.
+//
+// The arguments of invoke are given with -param flags, whose values are
+// parsed as JSON unless the parameter is a string, or as a JSON object on the
+// standard input with -stdin. Flags given with -param override the same
+// arguments read from the standard input.
+//
+// The flags are:
+//
+//	-url string
+//		The URL of the Toolbox server (default $TOOLBOX_URL or
+//		http://127.0.0.1:5000).
+//	-header 'Name: value'
+//		A header sent with every request, such as an Authorization header.
+//		Can be repeated.
+//	-auth service=token
+//		An ID token for the named auth service, sent as the
+//		"<service>_token" header of invocations and taken into account
+//		when describing the auth services a tool is missing. Can be
+//		repeated.
+//	-timeout duration
+//		The timeout of the whole command (default 30s).
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/googleapis/mcp-toolbox-sdk-go/core"
+)
+
+const usage = `Usage:
+  toolbox-go [flags] toolsets [toolset]...
+  toolbox-go [flags] list [toolset]
+  toolbox-go [flags] describe <tool>
+  toolbox-go [flags] invoke [-param name=value]... [-stdin] <tool>
+
+Flags:
+`
+
+func main() {
+	os.Exit(run(context.Background(), os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+// run runs the command given by args and returns its exit code: 0 on
+// success, 1 if the command failed and 2 if it was misused.
+func run(ctx context.Context, args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("toolbox-go", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		fmt.Fprint(stderr, usage)
+		fs.PrintDefaults()
+	}
+
+	defaultURL := os.Getenv("TOOLBOX_URL")
+	if defaultURL == "" {
+		defaultURL = "http://127.0.0.1:5000"
+	}
+	url := fs.String("url", defaultURL, "the URL of the Toolbox server")
+	var headers, authTokens pairsFlag
+	headers.sep = ":"
+	authTokens.sep = "="
+	fs.Var(&headers, "header", "a 'Name: value' header sent with every request; can be repeated")
+	fs.Var(&authTokens, "auth", "a 'service=token' ID token for an auth service; can be repeated")
+	timeout := fs.Duration("timeout", 30*time.Second, "the timeout of the whole command")
+
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		return 2
+	}
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return 2
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, *timeout)
+	defer cancel()
+
+	var clientOpts []core.ClientOption
+	for _, h := range headers.pairs {
+		clientOpts = append(clientOpts, core.WithClientHeaderString(h.key, h.value))
+	}
+	client, err := core.NewToolboxClient(*url, clientOpts...)
+	if err != nil {
+		fmt.Fprintf(stderr, "toolbox-go: %v\n", err)
+		return 1
+	}
+	defer client.Close(context.Background())
+
+	cmd, cmdArgs := fs.Arg(0), fs.Args()[1:]
+	switch cmd {
+	case "toolsets":
+		err = toolsets(ctx, client, cmdArgs, stdout)
+	case "list":
+		if len(cmdArgs) > 1 {
+			fmt.Fprintln(stderr, "toolbox-go: list takes at most one toolset name")
+			return 2
+		}
+		var toolset string
+		if len(cmdArgs) == 1 {
+			toolset = cmdArgs[0]
+		}
+		err = list(ctx, client, toolset, stdout)
+	case "describe":
+		if len(cmdArgs) != 1 {
+			fmt.Fprintln(stderr, "toolbox-go: describe takes a tool name")
+			return 2
+		}
+		var toolOpts []core.ToolOption
+		for _, a := range authTokens.pairs {
+			toolOpts = append(toolOpts, core.WithAuthTokenString(a.key, a.value))
+		}
+		err = describe(ctx, client, cmdArgs[0], toolOpts, stdout)
+	case "invoke":
+		var invokeOpts []core.InvokeOption
+		for _, a := range authTokens.pairs {
+			invokeOpts = append(invokeOpts, core.WithInvokeAuthTokenString(a.key, a.value))
+		}
+		return invoke(ctx, client, cmdArgs, invokeOpts, stdin, stdout, stderr)
+	default:
+		fmt.Fprintf(stderr, "toolbox-go: unknown command %q\n", cmd)
+		fs.Usage()
+		return 2
+	}
+	if err != nil {
+		fmt.Fprintf(stderr, "toolbox-go: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+// toolsets prints the names of the tools of the default toolset and of the
+// named toolsets.
+func toolsets(ctx context.Context, client *core.ToolboxClient, names []string, stdout io.Writer) error {
+	tw := tabwriter.NewWriter(stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "TOOLSET\tTOOLS")
+	for _, name := range append([]string{""}, names...) {
+		manifest, err := client.GetToolsetManifest(ctx, name)
+		if err != nil {
+			return err
+		}
+		if name == "" {
+			name = "default"
+		}
+		fmt.Fprintf(tw, "%s\t%s\n", name, strings.Join(slices.Sorted(maps.Keys(manifest.Tools)), ", "))
+	}
+	return tw.Flush()
+}
+
+// list prints the name and the first line of the description of the tools of
+// a toolset.
+func list(ctx context.Context, client *core.ToolboxClient, toolset string, stdout io.Writer) error {
+	tools, err := client.LoadToolset(toolset, ctx)
+	if err != nil {
+		return err
+	}
+	tw := tabwriter.NewWriter(stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "NAME\tDESCRIPTION")
+	for _, tool := range tools {
+		description, _, _ := strings.Cut(tool.Description(), "\n")
+		fmt.Fprintf(tw, "%s\t%s\n", tool.Name(), description)
+	}
+	return tw.Flush()
+}
+
+// describe prints the definition of a tool, including its schemas and the
+// auth services it is missing once opts are applied, as JSON.
+func describe(ctx context.Context, client *core.ToolboxClient, name string, opts []core.ToolOption, stdout io.Writer) error {
+	tool, err := client.LoadTool(name, ctx)
+	if err != nil {
+		return err
+	}
+	// The auth tokens are applied to the loaded tool, as LoadTool would reject
+	// those of services the tool does not use.
+	if len(opts) > 0 {
+		if tool, err = tool.ToolFrom(opts...); err != nil {
+			return err
+		}
+	}
+	inputSchema, err := tool.InputSchema()
+	if err != nil {
+		return err
+	}
+	outputSchema, err := tool.OutputSchema()
+	if err != nil {
+		return err
+	}
+	_, authServices := tool.AuthSatisfied()
+
+	return printJSON(stdout, struct {
+		Name         string          `json:"name"`
+		Title        string          `json:"title,omitempty"`
+		Description  string          `json:"description"`
+		AuthServices []string        `json:"authServices,omitempty"`
+		Annotations  map[string]any  `json:"annotations,omitempty"`
+		InputSchema  json.RawMessage `json:"inputSchema"`
+		OutputSchema json.RawMessage `json:"outputSchema,omitempty"`
+	}{
+		Name:         tool.Name(),
+		Title:        tool.Title(),
+		Description:  tool.Description(),
+		AuthServices: authServices,
+		Annotations:  tool.Annotations(),
+		InputSchema:  inputSchema,
+		OutputSchema: outputSchema,
+	})
+}
+
+// invoke parses the arguments of the invoke command, invokes the tool and
+// prints its result.
+func invoke(ctx context.Context, client *core.ToolboxClient, args []string, opts []core.InvokeOption, stdin io.Reader, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("invoke", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	params := pairsFlag{sep: "="}
+	fs.Var(&params, "param", "a 'name=value' argument of the tool; can be repeated")
+	fromStdin := fs.Bool("stdin", false, "read the arguments as a JSON object from the standard input")
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		return 2
+	}
+	if fs.NArg() != 1 {
+		fmt.Fprintln(stderr, "toolbox-go: invoke takes a tool name")
+		return 2
+	}
+
+	result, err := func() (any, error) {
+		tool, err := client.LoadTool(fs.Arg(0), ctx)
+		if err != nil {
+			return nil, err
+		}
+		input, err := buildInput(tool, params.pairs, *fromStdin, stdin)
+		if err != nil {
+			return nil, err
+		}
+		return tool.InvokeRaw(ctx, input, opts...)
+	}()
+	if err == nil {
+		err = printResult(stdout, result)
+	}
+	if err != nil {
+		fmt.Fprintf(stderr, "toolbox-go: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+// buildInput assembles the JSON arguments of tool from the standard input and
+// the -param flags.
+func buildInput(tool *core.ToolboxTool, params []pair, fromStdin bool, stdin io.Reader) (json.RawMessage, error) {
+	input := make(map[string]json.RawMessage)
+	if fromStdin {
+		if err := json.NewDecoder(stdin).Decode(&input); err != nil {
+			return nil, fmt.Errorf("error reading the arguments from the standard input: %w", err)
+		}
+		if input == nil {
+			input = make(map[string]json.RawMessage)
+		}
+	}
+
+	types := make(map[string]string)
+	for _, p := range tool.Parameters() {
+		types[p.Name] = p.Type
+	}
+	for _, p := range params {
+		paramType, ok := types[p.key]
+		if !ok {
+			return nil, fmt.Errorf("tool '%s' has no parameter '%s'", tool.Name(), p.key)
+		}
+		if paramType == "string" {
+			encoded, err := json.Marshal(p.value)
+			if err != nil {
+				return nil, err
+			}
+			input[p.key] = encoded
+			continue
+		}
+		if !json.Valid([]byte(p.value)) {
+			return nil, fmt.Errorf("the value of parameter '%s' is not valid JSON: %s", p.key, p.value)
+		}
+		input[p.key] = json.RawMessage(p.value)
+	}
+	return json.Marshal(input)
+}
+
+// printResult prints text results as they are and anything else as JSON.
+func printResult(w io.Writer, result any) error {
+	if s, ok := result.(string); ok {
+		_, err := fmt.Fprintln(w, s)
+		return err
+	}
+	return printJSON(w, result)
+}
+
+func printJSON(w io.Writer, v any) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// pair is a key and a value given on the command line.
+type pair struct {
+	key, value string
+}
+
+// pairsFlag is a repeatable flag whose values are pairs separated by sep.
+type pairsFlag struct {
+	sep   string
+	pairs []pair
+}
+
+func (f *pairsFlag) String() string {
+	if f == nil {
+		return ""
+	}
+	var parts []string
+	for _, p := range f.pairs {
+		parts = append(parts, p.key+f.sep+p.value)
+	}
+	return strings.Join(parts, ", ")
+}
+
+func (f *pairsFlag) Set(value string) error {
+	key, val, ok := strings.Cut(value, f.sep)
+	key = strings.TrimSpace(key)
+	if !ok || key == "" {
+		return fmt.Errorf("expected 'key%svalue', got %q", f.sep, value)
+	}
+	if f.sep == ":" {
+		// Headers are written 'Name: value'.
+		val = strings.TrimSpace(val)
+	}
+	f.pairs = append(f.pairs, pair{key: key, value: val})
+	return nil
+}
@@ -0,0 +1,254 @@
+//go:build unit
+
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// newMockServer starts an MCP server with an "echo" tool, which returns its
+// arguments and the headers of the request as JSON, and a "drop" tool, which
+// requires the "my-auth" auth service. The "admin" toolset holds only the
+// "drop" tool.
+func newMockServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	echo := map[string]any{
+		"name":        "echo",
+		"description": "Echo the arguments\nand more.",
+		"inputSchema": map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"text":  map[string]any{"type": "string"},
+				"count": map[string]any{"type": "integer"},
+			},
+			"required": []any{"text"},
+		},
+	}
+	drop := map[string]any{
+		"name":        "drop",
+		"description": "Drop a table.",
+		"inputSchema": map[string]any{"type": "object", "properties": map[string]any{}},
+		"_meta":       map[string]any{"toolbox/authInvoke": []any{"my-auth"}},
+	}
+	toolsets := map[string][]any{
+		"":      {echo, drop},
+		"admin": {drop},
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Method string         `json:"method"`
+			ID     any            `json:"id"`
+			Params map[string]any `json:"params"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "bad request", http.StatusBadRequest)
+			return
+		}
+
+		var result any
+		switch req.Method {
+		case "initialize":
+			result = map[string]any{
+				"protocolVersion": "2025-06-18",
+				"capabilities":    map[string]any{"tools": map[string]any{}},
+				"serverInfo":      map[string]any{"name": "mock-server", "version": "1.0.0"},
+			}
+		case "tools/list":
+			tools, ok := toolsets[strings.Trim(strings.TrimPrefix(r.URL.Path, "/mcp"), "/")]
+			if !ok {
+				http.Error(w, "toolset not found", http.StatusNotFound)
+				return
+			}
+			result = map[string]any{"tools": tools}
+		case "tools/call":
+			echoed, _ := json.Marshal(map[string]any{
+				"arguments": req.Params["arguments"],
+				"header":    r.Header.Get("X-Test"),
+				"auth":      r.Header.Get("my-auth_token"),
+			})
+			result = map[string]any{"content": []any{map[string]any{"type": "text", "text": string(echoed)}}}
+		default:
+			w.WriteHeader(http.StatusAccepted)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{"jsonrpc": "2.0", "id": req.ID, "result": result})
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// runCLI runs the command with args against server and returns its exit
+// code and output.
+func runCLI(t *testing.T, server *httptest.Server, stdin string, args ...string) (int, string, string) {
+	t.Helper()
+	var stdout, stderr bytes.Buffer
+	args = append([]string{"-url", server.URL, "-header", "X-Test: from-flag"}, args...)
+	code := run(context.Background(), args, strings.NewReader(stdin), &stdout, &stderr)
+	return code, stdout.String(), stderr.String()
+}
+
+func TestToolsets(t *testing.T) {
+	server := newMockServer(t)
+
+	code, stdout, stderr := runCLI(t, server, "", "toolsets", "admin")
+	if code != 0 {
+		t.Fatalf("Expected exit code 0, got %d: %s", code, stderr)
+	}
+	lines := strings.Split(strings.TrimSpace(stdout), "\n")
+	if len(lines) != 3 || !strings.HasPrefix(lines[0], "TOOLSET") ||
+		strings.Join(strings.Fields(lines[1]), " ") != "default drop, echo" ||
+		strings.Join(strings.Fields(lines[2]), " ") != "admin drop" {
+		t.Errorf("Unexpected output:\n%s", stdout)
+	}
+
+	code, _, stderr = runCLI(t, server, "", "toolsets", "missing")
+	if code != 1 || !strings.Contains(stderr, "toolset not found") {
+		t.Errorf("Expected a toolset not found error, got %d: %s", code, stderr)
+	}
+}
+
+func TestList(t *testing.T) {
+	code, stdout, stderr := runCLI(t, newMockServer(t), "", "list")
+	if code != 0 {
+		t.Fatalf("Expected exit code 0, got %d: %s", code, stderr)
+	}
+	lines := strings.Split(strings.TrimSpace(stdout), "\n")
+	if len(lines) != 3 || !strings.HasPrefix(lines[0], "NAME") || strings.Join(strings.Fields(lines[2]), " ") != "echo Echo the arguments" {
+		t.Errorf("Unexpected output:\n%s", stdout)
+	}
+}
+
+func TestDescribe(t *testing.T) {
+	code, stdout, stderr := runCLI(t, newMockServer(t), "", "describe", "echo")
+	if code != 0 {
+		t.Fatalf("Expected exit code 0, got %d: %s", code, stderr)
+	}
+	var got map[string]any
+	if err := json.Unmarshal([]byte(stdout), &got); err != nil {
+		t.Fatalf("Expected JSON output, got %s", stdout)
+	}
+	if got["name"] != "echo" {
+		t.Errorf("Unexpected name: %v", got["name"])
+	}
+	schema, _ := got["inputSchema"].(map[string]any)
+	if _, ok := schema["properties"].(map[string]any)["count"]; !ok {
+		t.Errorf("Expected the input schema, got %v", got["inputSchema"])
+	}
+}
+
+func TestDescribeAuth(t *testing.T) {
+	server := newMockServer(t)
+	testCases := []struct {
+		name string
+		args []string
+		want any
+	}{
+		{"Missing", []string{"describe", "drop"}, []any{"my-auth"}},
+		{"Provided", []string{"-auth", "my-auth=secret", "describe", "drop"}, nil},
+		{"Unused", []string{"-auth", "other=secret", "describe", "drop"}, []any{"my-auth"}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			code, stdout, stderr := runCLI(t, server, "", tc.args...)
+			if code != 0 {
+				t.Fatalf("Expected exit code 0, got %d: %s", code, stderr)
+			}
+			var got map[string]any
+			if err := json.Unmarshal([]byte(stdout), &got); err != nil {
+				t.Fatalf("Expected JSON output, got %s", stdout)
+			}
+			if !reflect.DeepEqual(got["authServices"], tc.want) {
+				t.Errorf("Expected missing auth services %v, got %v", tc.want, got["authServices"])
+			}
+		})
+	}
+}
+
+func TestInvoke(t *testing.T) {
+	server := newMockServer(t)
+
+	t.Run("Params", func(t *testing.T) {
+		code, stdout, stderr := runCLI(t, server, "", "-auth", "my-auth=secret", "invoke", "-param", "text=a b", "-param", "count=3", "echo")
+		if code != 0 {
+			t.Fatalf("Expected exit code 0, got %d: %s", code, stderr)
+		}
+		var got map[string]any
+		if err := json.Unmarshal([]byte(stdout), &got); err != nil {
+			t.Fatalf("Expected the echoed JSON, got %s", stdout)
+		}
+		if args, _ := got["arguments"].(map[string]any); args["text"] != "a b" || args["count"] != 3.0 {
+			t.Errorf("Unexpected arguments: %v", got["arguments"])
+		}
+		if got["header"] != "from-flag" || got["auth"] != "secret" {
+			t.Errorf("Expected the header and auth token to be sent, got %v", got)
+		}
+	})
+
+	t.Run("Stdin", func(t *testing.T) {
+		code, stdout, stderr := runCLI(t, server, `{"text": "from stdin", "count": 1}`, "invoke", "-stdin", "-param", "count=2", "echo")
+		if code != 0 {
+			t.Fatalf("Expected exit code 0, got %d: %s", code, stderr)
+		}
+		if !strings.Contains(stdout, `"text":"from stdin"`) || !strings.Contains(stdout, `"count":2`) {
+			t.Errorf("Expected the flags to override the standard input, got %s", stdout)
+		}
+	})
+
+	t.Run("UnknownParam", func(t *testing.T) {
+		code, _, stderr := runCLI(t, server, "", "invoke", "-param", "nope=1", "echo")
+		if code != 1 || !strings.Contains(stderr, "tool 'echo' has no parameter 'nope'") {
+			t.Errorf("Expected an unknown parameter error, got %d: %s", code, stderr)
+		}
+	})
+
+	t.Run("InvalidJSON", func(t *testing.T) {
+		code, _, stderr := runCLI(t, server, "", "invoke", "-param", "text=x", "-param", "count=three", "echo")
+		if code != 1 || !strings.Contains(stderr, "parameter 'count' is not valid JSON") {
+			t.Errorf("Expected an invalid JSON error, got %d: %s", code, stderr)
+		}
+	})
+}
+
+func TestUsage(t *testing.T) {
+	server := newMockServer(t)
+	testCases := []struct {
+		name string
+		args []string
+	}{
+		{name: "NoCommand"},
+		{name: "UnknownCommand", args: []string{"frobnicate"}},
+		{name: "DescribeWithoutTool", args: []string{"describe"}},
+		{name: "InvokeWithoutTool", args: []string{"invoke"}},
+		{name: "MalformedParam", args: []string{"invoke", "-param", "novalue", "echo"}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if code, _, _ := runCLI(t, server, "", tc.args...); code != 2 {
+				t.Errorf("Expected exit code 2, got %d", code)
+			}
+		})
+	}
+}
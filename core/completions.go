@@ -0,0 +1,113 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/googleapis/mcp-toolbox-sdk-go/core/transport"
+	"golang.org/x/oauth2"
+)
+
+// complete asks the server behind tr to complete an argument.
+func complete(ctx context.Context, tr transport.Transport, headerSources map[string]oauth2.TokenSource, req transport.CompletionRequest) (*Completion, error) {
+	completer, ok := tr.(transport.Completer)
+	if !ok {
+		return nil, fmt.Errorf("transport %T does not support completions", tr)
+	}
+	resolvedHeaders, err := resolveClientHeaders(ctx, headerSources)
+	if err != nil {
+		return nil, err
+	}
+	return completer.Complete(ctx, req, resolvedHeaders)
+}
+
+// CompletePromptArgument asks the server for the values an argument of a
+// prompt may take, for example to offer autocompletion in a UI. The server
+// must declare the completions capability.
+//
+// Inputs:
+//   - ctx: The context to control the lifecycle of the request.
+//   - promptName: The name of the prompt.
+//   - argName: The name of the argument to complete.
+//   - partial: The partial value typed so far.
+//   - resolved: The values of other arguments already chosen, which the
+//     server may use to narrow down the suggestions. May be nil.
+//
+// Returns:
+//
+//	The suggestions and a nil error on success, or nil and an error if the
+//	request fails.
+func (tc *ToolboxClient) CompletePromptArgument(ctx context.Context, promptName, argName, partial string, resolved map[string]string) (*Completion, error) {
+	if promptName == "" || argName == "" {
+		return nil, fmt.Errorf("CompletePromptArgument: prompt and argument names cannot be empty")
+	}
+	return complete(ctx, tc.transport, tc.headerSources(), transport.CompletionRequest{
+		Ref:          transport.CompletionRef{Type: transport.CompletionRefPrompt, Name: promptName},
+		ArgumentName: argName,
+		Value:        partial,
+		Arguments:    resolved,
+	})
+}
+
+// CompleteArgument asks the server for the values a variable of the URI
+// template may take. The server must declare the completions capability.
+//
+// Inputs:
+//   - ctx: The context to control the lifecycle of the request.
+//   - varName: The name of the template variable to complete.
+//   - partial: The partial value typed so far.
+//   - resolved: The values of other variables already chosen. May be nil.
+//
+// Returns:
+//
+//	The suggestions and a nil error on success, or nil and an error if the
+//	request fails.
+func (rt *ResourceTemplate) CompleteArgument(ctx context.Context, varName, partial string, resolved map[string]string) (*Completion, error) {
+	return complete(ctx, rt.client.transport, rt.client.headerSources(), transport.CompletionRequest{
+		Ref:          transport.CompletionRef{Type: transport.CompletionRefResource, URI: rt.descriptor.URITemplate},
+		ArgumentName: varName,
+		Value:        partial,
+		Arguments:    resolved,
+	})
+}
+
+// CompleteArgument asks the server for the values an argument of the tool
+// may take, for example to offer autocompletion in a UI. MCP only defines
+// completions for prompts and resource templates, so this requires a server
+// that declares the completions capability and also completes tool
+// arguments; other servers reject the request.
+//
+// Inputs:
+//   - ctx: The context to control the lifecycle of the request.
+//   - argName: The name of an unbound argument of the tool.
+//   - partial: The partial value typed so far.
+//
+// Returns:
+//
+//	The suggestions and a nil error on success, or nil and an error if the
+//	argument is unknown or the request fails.
+func (tt *ToolboxTool) CompleteArgument(ctx context.Context, argName, partial string) (*Completion, error) {
+	if !slices.ContainsFunc(tt.parameters, func(p ParameterSchema) bool { return p.Name == argName }) {
+		return nil, fmt.Errorf("tool '%s' has no argument '%s' to complete", tt.name, argName)
+	}
+	return complete(ctx, tt.transport, tt.headerSources(), transport.CompletionRequest{
+		Ref:          transport.CompletionRef{Type: transport.CompletionRefTool, Name: tt.serverName()},
+		ArgumentName: argName,
+		Value:        partial,
+	})
+}
@@ -0,0 +1,101 @@
+//go:build unit
+
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/googleapis/mcp-toolbox-sdk-go/core/transport"
+)
+
+// completingTransport is a dummyTransport that records completion requests.
+type completingTransport struct {
+	dummyTransport
+	requests []transport.CompletionRequest
+}
+
+func (c *completingTransport) Complete(_ context.Context, req transport.CompletionRequest, _ map[string]string) (*transport.Completion, error) {
+	c.requests = append(c.requests, req)
+	return &transport.Completion{Values: []string{req.Value + "1", req.Value + "2"}, HasMore: true}, nil
+}
+
+func TestCompletions(t *testing.T) {
+	ctx := context.Background()
+	tr := &completingTransport{}
+	client, err := NewToolboxClient("https://custom", WithCustomTransport(tr))
+	if err != nil {
+		t.Fatalf("NewToolboxClient failed: %v", err)
+	}
+
+	t.Run("Completes prompt arguments", func(t *testing.T) {
+		completion, err := client.CompletePromptArgument(ctx, "review", "language", "py", map[string]string{"style": "strict"})
+		if err != nil {
+			t.Fatalf("CompletePromptArgument failed: %v", err)
+		}
+		if !reflect.DeepEqual(completion.Values, []string{"py1", "py2"}) || !completion.HasMore {
+			t.Errorf("Unexpected completion: %+v", completion)
+		}
+		expected := transport.CompletionRequest{
+			Ref:          transport.CompletionRef{Type: "ref/prompt", Name: "review"},
+			ArgumentName: "language",
+			Value:        "py",
+			Arguments:    map[string]string{"style": "strict"},
+		}
+		if !reflect.DeepEqual(tr.requests[len(tr.requests)-1], expected) {
+			t.Errorf("Unexpected request: %+v", tr.requests[len(tr.requests)-1])
+		}
+	})
+
+	t.Run("Completes resource template variables", func(t *testing.T) {
+		template := &ResourceTemplate{descriptor: transport.ResourceTemplate{URITemplate: "db://{table}"}, client: client}
+		if _, err := template.CompleteArgument(ctx, "table", "us", nil); err != nil {
+			t.Fatalf("CompleteArgument failed: %v", err)
+		}
+		ref := tr.requests[len(tr.requests)-1].Ref
+		if ref != (transport.CompletionRef{Type: "ref/resource", URI: "db://{table}"}) {
+			t.Errorf("Unexpected reference: %+v", ref)
+		}
+	})
+
+	t.Run("Completes tool arguments", func(t *testing.T) {
+		tool := &ToolboxTool{name: "search", parameters: []ParameterSchema{{Name: "query", Type: "string"}}, transport: tr}
+		if _, err := tool.CompleteArgument(ctx, "query", "go"); err != nil {
+			t.Fatalf("CompleteArgument failed: %v", err)
+		}
+		req := tr.requests[len(tr.requests)-1]
+		if req.Ref != (transport.CompletionRef{Type: "ref/tool", Name: "search"}) || req.ArgumentName != "query" {
+			t.Errorf("Unexpected request: %+v", req)
+		}
+
+		if _, err := tool.CompleteArgument(ctx, "missing", ""); err == nil || !strings.Contains(err.Error(), "has no argument 'missing'") {
+			t.Errorf("Expected an unknown argument error, got %v", err)
+		}
+	})
+
+	t.Run("Negative Test - Invalid usage", func(t *testing.T) {
+		if _, err := client.CompletePromptArgument(ctx, "", "arg", "", nil); err == nil {
+			t.Error("Expected an error for an empty prompt name")
+		}
+		unsupported, _ := NewToolboxClient("https://custom", WithCustomTransport(&dummyTransport{}))
+		if _, err := unsupported.CompletePromptArgument(ctx, "review", "language", "", nil); err == nil || !strings.Contains(err.Error(), "does not support completions") {
+			t.Errorf("Expected an unsupported transport error, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,136 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+)
+
+// ConflictPolicy decides how tools with the same name from different
+// sources, such as toolsets or servers, are combined.
+type ConflictPolicy string
+
+const (
+	// ConflictError fails when more than one source provides a tool with the
+	// same name. This is the default.
+	ConflictError ConflictPolicy = "error"
+	// ConflictFirstWins keeps the tool of the first source that provides a
+	// name and drops the tools of later sources.
+	ConflictFirstWins ConflictPolicy = "first-wins"
+	// ConflictPrefixSource renames every tool whose name is provided by more
+	// than one source to "<source>_<name>". Tools without conflicts keep their
+	// names.
+	ConflictPrefixSource ConflictPolicy = "prefix-source"
+)
+
+// ToolSource is a named collection of tools, such as a toolset or the tools
+// of a single server.
+type ToolSource struct {
+	Name  string
+	Tools []*ToolboxTool
+}
+
+// MergeTools combines the tools of several sources into a single list,
+// resolving name collisions with policy. Tools with the same name that invoke
+// the same tool of the same transport, such as a tool that belongs to several
+// toolsets, are not a collision: only the first of them is kept. Tools are
+// returned in the order of their sources, so the result is deterministic.
+// Renamed tools still invoke the server's tool under its original name.
+func MergeTools(policy ConflictPolicy, sources ...ToolSource) ([]*ToolboxTool, error) {
+	if policy == "" {
+		policy = ConflictError
+	}
+	if !policy.valid() {
+		return nil, fmt.Errorf("MergeTools: unknown conflict policy '%s'", policy)
+	}
+
+	// Record the first source of every name, the distinct tools with every
+	// name, and which names are provided by more than one of them. Repeated
+	// tools are recorded by their position in sources.
+	owners := make(map[string]string)
+	byName := make(map[string][]*ToolboxTool)
+	conflicts := make(map[string]bool)
+	duplicates := make(map[[2]int]bool)
+	for i, source := range sources {
+		for j, tool := range source.Tools {
+			if tool == nil {
+				continue
+			}
+			owner, ok := owners[tool.name]
+			if !ok {
+				owners[tool.name] = source.Name
+				byName[tool.name] = append(byName[tool.name], tool)
+				continue
+			}
+			if slices.ContainsFunc(byName[tool.name], tool.sameServerTool) {
+				duplicates[[2]int{i, j}] = true
+				continue
+			}
+			if policy == ConflictError {
+				return nil, fmt.Errorf("tool name '%s' is provided by both '%s' and '%s'", tool.name, owner, source.Name)
+			}
+			byName[tool.name] = append(byName[tool.name], tool)
+			conflicts[tool.name] = true
+		}
+	}
+
+	merged := make([]*ToolboxTool, 0, len(owners))
+	seen := make(map[string]string, len(owners))
+	for i, source := range sources {
+		for j, tool := range source.Tools {
+			if tool == nil || duplicates[[2]int{i, j}] {
+				continue
+			}
+			if conflicts[tool.name] {
+				if policy == ConflictFirstWins {
+					if _, ok := seen[tool.name]; ok {
+						continue
+					}
+				} else {
+					tool = tool.renamed(source.Name + "_" + tool.name)
+				}
+			}
+			// A prefixed name can still clash, for example with a tool
+			// that already carries the prefix.
+			if owner, ok := seen[tool.name]; ok {
+				return nil, fmt.Errorf("tool name '%s' is provided by both '%s' and '%s'", tool.name, owner, source.Name)
+			}
+			seen[tool.name] = source.Name
+			merged = append(merged, tool)
+		}
+	}
+	return merged, nil
+}
+
+// sameServerTool reports whether tt and other invoke the same tool on the
+// same transport. Tools without a transport, or whose transports cannot be
+// compared, are never the same.
+func (tt *ToolboxTool) sameServerTool(other *ToolboxTool) bool {
+	if tt.serverName() != other.serverName() || tt.transport == nil || other.transport == nil {
+		return false
+	}
+	a, b := reflect.ValueOf(tt.transport), reflect.ValueOf(other.transport)
+	return a.Type() == b.Type() && a.Comparable() && a.Equal(b)
+}
+
+func (p ConflictPolicy) valid() bool {
+	switch p {
+	case ConflictError, ConflictFirstWins, ConflictPrefixSource:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,163 @@
+//go:build unit
+
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func toolNames(tools []*ToolboxTool) []string {
+	names := make([]string, len(tools))
+	for i, tool := range tools {
+		names[i] = tool.Name()
+	}
+	return names
+}
+
+func TestMergeTools(t *testing.T) {
+	sources := []ToolSource{
+		{Name: "hotels", Tools: []*ToolboxTool{{name: "search", description: "hotels"}, {name: "book"}}},
+		{Name: "flights", Tools: []*ToolboxTool{{name: "search", description: "flights"}, {name: "status"}, nil}},
+	}
+
+	t.Run("Error by default", func(t *testing.T) {
+		_, err := MergeTools("", sources...)
+		if err == nil || !strings.Contains(err.Error(), "tool name 'search' is provided by both 'hotels' and 'flights'") {
+			t.Errorf("Expected a conflict error, got: %v", err)
+		}
+	})
+
+	t.Run("First wins", func(t *testing.T) {
+		tools, err := MergeTools(ConflictFirstWins, sources...)
+		if err != nil {
+			t.Fatalf("MergeTools failed: %v", err)
+		}
+		if got, want := toolNames(tools), []string{"search", "book", "status"}; !slices.Equal(got, want) {
+			t.Errorf("Expected tools %v, got %v", want, got)
+		}
+		if tools[0].Description() != "hotels" {
+			t.Errorf("Expected the tool of the first source, got %q", tools[0].Description())
+		}
+	})
+
+	t.Run("Prefix with source", func(t *testing.T) {
+		tools, err := MergeTools(ConflictPrefixSource, sources...)
+		if err != nil {
+			t.Fatalf("MergeTools failed: %v", err)
+		}
+		if got, want := toolNames(tools), []string{"hotels_search", "book", "flights_search", "status"}; !slices.Equal(got, want) {
+			t.Errorf("Expected tools %v, got %v", want, got)
+		}
+		if sources[0].Tools[0].Name() != "search" {
+			t.Errorf("Expected the original tool to keep its name, got %q", sources[0].Tools[0].Name())
+		}
+	})
+
+	t.Run("Prefixed name clashes", func(t *testing.T) {
+		_, err := MergeTools(ConflictPrefixSource,
+			ToolSource{Name: "a", Tools: []*ToolboxTool{{name: "x"}, {name: "b_x"}}},
+			ToolSource{Name: "b", Tools: []*ToolboxTool{{name: "x"}}},
+		)
+		if err == nil || !strings.Contains(err.Error(), "tool name 'b_x' is provided by both 'a' and 'b'") {
+			t.Errorf("Expected a conflict error for the prefixed name, got: %v", err)
+		}
+	})
+
+	t.Run("Keeps one of identical tools", func(t *testing.T) {
+		tr := &capturingTransport{}
+		other := &capturingTransport{}
+		search := &ToolboxTool{name: "search", description: "first", transport: tr}
+		for _, policy := range []ConflictPolicy{ConflictError, ConflictFirstWins, ConflictPrefixSource} {
+			tools, err := MergeTools(policy,
+				ToolSource{Name: "a", Tools: []*ToolboxTool{search, {name: "book", transport: tr}}},
+				ToolSource{Name: "b", Tools: []*ToolboxTool{{name: "search", description: "second", transport: tr}}},
+				ToolSource{Name: "c", Tools: []*ToolboxTool{search}},
+			)
+			if err != nil {
+				t.Fatalf("MergeTools(%s) failed: %v", policy, err)
+			}
+			if got, want := toolNames(tools), []string{"search", "book"}; !slices.Equal(got, want) {
+				t.Errorf("MergeTools(%s): expected tools %v, got %v", policy, want, got)
+			}
+			if tools[0] != search {
+				t.Errorf("MergeTools(%s): expected the first tool to be kept", policy)
+			}
+		}
+
+		// The same tool of another transport is a different tool.
+		_, err := MergeTools(ConflictError,
+			ToolSource{Name: "a", Tools: []*ToolboxTool{search}},
+			ToolSource{Name: "b", Tools: []*ToolboxTool{{name: "search", transport: other}}},
+		)
+		if err == nil || !strings.Contains(err.Error(), "tool name 'search' is provided by both 'a' and 'b'") {
+			t.Errorf("Expected a conflict error, got: %v", err)
+		}
+	})
+
+	t.Run("Unknown policy", func(t *testing.T) {
+		if _, err := MergeTools("last-wins", sources...); err == nil {
+			t.Error("Expected an error for an unknown policy, got nil")
+		}
+	})
+}
+
+func TestMergeTools_RenamedToolInvokesOriginalName(t *testing.T) {
+	tr := &capturingTransport{result: "ok"}
+	tools, err := MergeTools(ConflictPrefixSource,
+		ToolSource{Name: "a", Tools: []*ToolboxTool{{name: "search", transport: &capturingTransport{}}}},
+		ToolSource{Name: "b", Tools: []*ToolboxTool{{name: "search", transport: tr}}},
+	)
+	if err != nil {
+		t.Fatalf("MergeTools failed: %v", err)
+	}
+
+	renamed := tools[1]
+	if _, err := renamed.Invoke(context.Background(), map[string]any{}); err != nil {
+		t.Fatalf("Invoke failed: %v", err)
+	}
+	if tr.toolName != "search" {
+		t.Errorf("Expected the server's tool name to be invoked, got %q", tr.toolName)
+	}
+
+	// Renaming a renamed tool still refers to the tool on the server.
+	if got := renamed.renamed("c_search").serverName(); got != "search" {
+		t.Errorf("Expected the original server name, got %q", got)
+	}
+}
+
+func TestLoadToolsets(t *testing.T) {
+	server := newMockMCPServer(t, []mcpTool{
+		{Name: "search", InputSchema: map[string]any{"type": "object", "properties": map[string]any{}}},
+	})
+	defer server.Close()
+
+	client, err := NewToolboxClient(server.URL, WithHTTPClient(server.Client()))
+	if err != nil {
+		t.Fatalf("NewToolboxClient failed: %v", err)
+	}
+	// The mock server serves the same tools in every toolset.
+	tools, err := client.LoadToolsets([]string{"", "extra"}, context.Background())
+	if err != nil {
+		t.Fatalf("LoadToolsets failed: %v", err)
+	}
+	if got, want := toolNames(tools), []string{"search"}; !slices.Equal(got, want) {
+		t.Errorf("Expected tools %v, got %v", want, got)
+	}
+}
@@ -0,0 +1,176 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// defaultDebugDumpBodyLimit is the number of bytes of each body written by
+// WithDebugDump unless WithDebugDumpBodyLimit is used.
+const defaultDebugDumpBodyLimit = 64 << 10
+
+// redactedValue replaces the values of sensitive headers in debug dumps.
+const redactedValue = "[REDACTED]"
+
+// debugDumpTransport writes the HTTP requests sent through it, and their
+// responses, to an io.Writer.
+type debugDumpTransport struct {
+	base  http.RoundTripper
+	limit int
+
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func newDebugDumpTransport(base http.RoundTripper, w io.Writer, limit int) *debugDumpTransport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &debugDumpTransport{base: base, w: w, limit: limit}
+}
+
+// RoundTrip dumps the request before sending it, and the response once its
+// body has been read or closed, so that streamed responses are dumped whole.
+func (t *debugDumpTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil && req.Body != http.NoBody {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		req = req.Clone(req.Context())
+		req.Body = io.NopCloser(bytes.NewReader(body))
+		req.GetBody = func() (io.ReadCloser, error) { return io.NopCloser(bytes.NewReader(body)), nil }
+	}
+
+	var dump bytes.Buffer
+	fmt.Fprintf(&dump, "--> %s %s\n", req.Method, req.URL.Redacted())
+	t.writeHeaders(&dump, req.Header)
+	t.writeBody(&dump, body, int64(len(body)))
+	t.write(dump.Bytes())
+
+	start := time.Now()
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		t.write(fmt.Appendf(nil, "<-- %s %s failed after %v: %v\n\n", req.Method, req.URL.Redacted(), time.Since(start), err))
+		return nil, err
+	}
+
+	dump.Reset()
+	fmt.Fprintf(&dump, "<-- %s %s %s (%v)\n", resp.Status, req.Method, req.URL.Redacted(), time.Since(start))
+	t.writeHeaders(&dump, resp.Header)
+	resp.Body = &debugDumpBody{ReadCloser: resp.Body, transport: t, head: dump.Bytes()}
+	return resp, nil
+}
+
+// write writes a whole dump, so that concurrent dumps do not interleave.
+func (t *debugDumpTransport) write(dump []byte) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	_, _ = t.w.Write(dump)
+}
+
+// writeHeaders writes headers sorted by name, redacting the credentials.
+func (t *debugDumpTransport) writeHeaders(dump *bytes.Buffer, header http.Header) {
+	names := make([]string, 0, len(header))
+	for name := range header {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		for _, value := range header[name] {
+			if isSensitiveHeader(name) {
+				value = redactedValue
+			}
+			fmt.Fprintf(dump, "%s: %s\n", name, value)
+		}
+	}
+	dump.WriteString("\n")
+}
+
+// writeBody writes the first bytes of a body of the given total size.
+func (t *debugDumpTransport) writeBody(dump *bytes.Buffer, body []byte, size int64) {
+	if len(body) > t.limit {
+		body = body[:t.limit]
+	}
+	dump.Write(body)
+	if len(body) > 0 && body[len(body)-1] != '\n' {
+		dump.WriteString("\n")
+	}
+	if truncated := size - int64(len(body)); truncated > 0 {
+		fmt.Fprintf(dump, "[%d more bytes truncated]\n", truncated)
+	}
+	dump.WriteString("\n")
+}
+
+// isSensitiveHeader reports whether the value of a header holds credentials,
+// such as the Authorization header or the <service>_token headers of
+// authenticated tools.
+func isSensitiveHeader(name string) bool {
+	switch name = strings.ToLower(name); name {
+	case "authorization", "proxy-authorization", "cookie", "set-cookie":
+		return true
+	}
+	return strings.HasSuffix(name, "_token")
+}
+
+// debugDumpBody records a response body as it is read, and dumps the
+// response once the body is exhausted or closed.
+type debugDumpBody struct {
+	io.ReadCloser
+	transport *debugDumpTransport
+	head      []byte
+
+	body []byte
+	size int64
+	once sync.Once
+}
+
+func (b *debugDumpBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	b.size += int64(n)
+	if keep := min(n, b.transport.limit-len(b.body)); keep > 0 {
+		b.body = append(b.body, p[:keep]...)
+	}
+	if err == io.EOF {
+		b.dump()
+	}
+	return n, err
+}
+
+func (b *debugDumpBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.dump()
+	return err
+}
+
+// dump writes the response the first time it is called.
+func (b *debugDumpBody) dump() {
+	b.once.Do(func() {
+		dump := bytes.NewBuffer(b.head)
+		b.transport.writeBody(dump, b.body, b.size)
+		b.transport.write(dump.Bytes())
+	})
+}
@@ -0,0 +1,137 @@
+//go:build unit
+
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// dumpRoundTripFunc adapts a function to the http.RoundTripper interface.
+type dumpRoundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f dumpRoundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) { return f(req) }
+
+func TestWithDebugDump(t *testing.T) {
+	t.Run("Dumps requests and responses with redacted credentials", func(t *testing.T) {
+		server := newMockMCPServer(t, []mcpTool{{Name: "search", InputSchema: map[string]any{"type": "object"}}})
+		defer server.Close()
+
+		var dump bytes.Buffer
+		client, err := NewToolboxClient(server.URL,
+			WithDebugDump(&dump),
+			WithClientHeaderString("Authorization", "Bearer client-secret"),
+			WithClientHeaderString("my-auth_token", "tool-secret"),
+		)
+		if err != nil {
+			t.Fatalf("NewToolboxClient failed: %v", err)
+		}
+		if _, err := client.LoadToolset("", context.Background()); err != nil {
+			t.Fatalf("LoadToolset failed: %v", err)
+		}
+
+		got := dump.String()
+		for _, want := range []string{
+			"--> POST " + server.URL,
+			`"method":"initialize"`,
+			`"method":"tools/list"`,
+			"<-- 200 OK POST " + server.URL,
+			`"name":"search"`,
+			"Authorization: [REDACTED]",
+			"My-Auth_token: [REDACTED]",
+		} {
+			if !strings.Contains(got, want) {
+				t.Errorf("Expected the dump to contain %q, got:\n%s", want, got)
+			}
+		}
+		if strings.Contains(got, "client-secret") || strings.Contains(got, "tool-secret") {
+			t.Errorf("Expected credentials to be redacted, got:\n%s", got)
+		}
+	})
+
+	t.Run("Truncates bodies and dumps streamed responses once read", func(t *testing.T) {
+		var dump bytes.Buffer
+		base := dumpRoundTripFunc(func(req *http.Request) (*http.Response, error) {
+			body, _ := io.ReadAll(req.Body)
+			if string(body) != "0123456789abcdef" {
+				t.Errorf("Expected the full body to be sent, got %q", body)
+			}
+			return &http.Response{
+				Status:     "200 OK",
+				StatusCode: http.StatusOK,
+				Header:     http.Header{"Content-Type": {"text/event-stream"}, "Set-Cookie": {"session=secret"}},
+				Body:       io.NopCloser(strings.NewReader("data: streamed response\n")),
+			}, nil
+		})
+		rt := newDebugDumpTransport(base, &dump, 10)
+
+		req, _ := http.NewRequest(http.MethodPost, "http://example.com/mcp/", strings.NewReader("0123456789abcdef"))
+		resp, err := rt.RoundTrip(req)
+		if err != nil {
+			t.Fatalf("RoundTrip failed: %v", err)
+		}
+		if strings.Contains(dump.String(), "<--") {
+			t.Errorf("Expected the response to be dumped only once read, got:\n%s", dump.String())
+		}
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if string(body) != "data: streamed response\n" {
+			t.Errorf("Expected the response body to be unchanged, got %q", body)
+		}
+
+		got := dump.String()
+		for _, want := range []string{
+			"0123456789\n[6 more bytes truncated]",
+			"data: stre\n[14 more bytes truncated]",
+			"Set-Cookie: [REDACTED]",
+		} {
+			if !strings.Contains(got, want) {
+				t.Errorf("Expected the dump to contain %q, got:\n%s", want, got)
+			}
+		}
+		if strings.Count(got, "<-- 200 OK") != 1 {
+			t.Errorf("Expected the response to be dumped once, got:\n%s", got)
+		}
+	})
+
+	t.Run("Negative Test - Invalid usage", func(t *testing.T) {
+		testCases := []struct {
+			name    string
+			opts    []ClientOption
+			wantErr string
+		}{
+			{"Nil writer", []ClientOption{WithDebugDump(nil)}, "cannot be nil"},
+			{"Duplicate", []ClientOption{WithDebugDump(io.Discard), WithDebugDump(io.Discard)}, "already set"},
+			{"Invalid limit", []ClientOption{WithDebugDumpBodyLimit(0)}, "must be positive"},
+			{"Duplicate limit", []ClientOption{WithDebugDumpBodyLimit(1), WithDebugDumpBodyLimit(2)}, "already set"},
+			{"Custom transport", []ClientOption{WithDebugDump(io.Discard), WithCustomTransport(&dummyTransport{})}, "cannot be combined"},
+			{"WebSocket", []ClientOption{WithDebugDump(io.Discard), WithProtocol(MCPv20250618WebSocket)}, "cannot be combined with the WebSocket transport"},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				_, err := NewToolboxClient("https://custom", tc.opts...)
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Errorf("Expected error containing %q, got %v", tc.wantErr, err)
+				}
+			})
+		}
+	})
+}
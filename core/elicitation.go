@@ -0,0 +1,111 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/googleapis/mcp-toolbox-sdk-go/core/transport"
+)
+
+// elicitationMethod is the request a server sends to ask the user for input.
+const elicitationMethod = "elicitation/create"
+
+// ElicitationAction is the user's response to an elicitation request.
+type ElicitationAction string
+
+const (
+	// ElicitationAccept submits the requested input.
+	ElicitationAccept ElicitationAction = "accept"
+	// ElicitationDecline explicitly refuses to provide the input.
+	ElicitationDecline ElicitationAction = "decline"
+	// ElicitationCancel dismisses the request without a choice.
+	ElicitationCancel ElicitationAction = "cancel"
+)
+
+// ElicitationProperty describes a single field of the requested input. Only
+// primitive types are allowed: "string", "number", "integer" and "boolean".
+type ElicitationProperty struct {
+	Type        string `json:"type"`
+	Title       string `json:"title,omitempty"`
+	Description string `json:"description,omitempty"`
+	// Enum restricts a string to the given values, displayed with the
+	// matching EnumNames, if any.
+	Enum      []string `json:"enum,omitempty"`
+	EnumNames []string `json:"enumNames,omitempty"`
+	// Format is "email", "uri", "date" or "date-time" for strings.
+	Format    string   `json:"format,omitempty"`
+	MinLength *int     `json:"minLength,omitempty"`
+	MaxLength *int     `json:"maxLength,omitempty"`
+	Minimum   *float64 `json:"minimum,omitempty"`
+	Maximum   *float64 `json:"maximum,omitempty"`
+	Default   any      `json:"default,omitempty"`
+}
+
+// ElicitationSchema is the flat object schema of the requested input.
+type ElicitationSchema struct {
+	Type       string                         `json:"type"`
+	Properties map[string]ElicitationProperty `json:"properties"`
+	Required   []string                       `json:"required,omitempty"`
+}
+
+// ElicitationRequest is a request from the server for structured input from
+// the user.
+type ElicitationRequest struct {
+	// Message is the prompt to show the user.
+	Message         string            `json:"message"`
+	RequestedSchema ElicitationSchema `json:"requestedSchema"`
+}
+
+// ElicitationResult is the user's answer to an ElicitationRequest.
+type ElicitationResult struct {
+	Action ElicitationAction `json:"action"`
+	// Content holds the submitted input, keyed by property name. It is only
+	// sent with ElicitationAccept.
+	Content map[string]any `json:"content,omitempty"`
+}
+
+// ElicitationHandler answers elicitation requests from the server, typically
+// by prompting the user. Returning an error rejects the request, while a
+// result with ElicitationDecline or ElicitationCancel reports the user's
+// choice to the server.
+type ElicitationHandler func(ctx context.Context, req *ElicitationRequest) (*ElicitationResult, error)
+
+// handleElicitationRequest decodes an elicitation request and passes it to
+// the configured ElicitationHandler.
+func (tc *ToolboxClient) handleElicitationRequest(ctx context.Context, params json.RawMessage) (any, error) {
+	var req ElicitationRequest
+	if err := json.Unmarshal(params, &req); err != nil {
+		return nil, &transport.ToolInvocationError{Code: invalidParamsCode, Message: fmt.Sprintf("invalid elicitation request: %v", err)}
+	}
+
+	result, err := tc.elicitationHandler(ctx, &req)
+	if err != nil {
+		return nil, err
+	}
+	if result == nil {
+		return nil, fmt.Errorf("elicitation handler returned no result")
+	}
+	switch result.Action {
+	case ElicitationAccept:
+	case ElicitationDecline, ElicitationCancel:
+		result.Content = nil
+	default:
+		return nil, fmt.Errorf("elicitation handler returned an invalid action '%s'", result.Action)
+	}
+	return result, nil
+}
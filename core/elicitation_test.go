@@ -0,0 +1,128 @@
+//go:build unit
+
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestWithElicitationHandler(t *testing.T) {
+	ctx := context.Background()
+
+	// newHandler returns the elicitation/create handler registered by a
+	// client using handler.
+	newHandler := func(t *testing.T, handler ElicitationHandler) *receivingTransport {
+		t.Helper()
+		tr := &receivingTransport{}
+		if _, err := NewToolboxClient("https://custom", WithCustomTransport(tr), WithElicitationHandler(handler)); err != nil {
+			t.Fatalf("NewToolboxClient failed: %v", err)
+		}
+		if tr.handlers["elicitation/create"] == nil {
+			t.Fatal("Expected an elicitation request handler")
+		}
+		return tr
+	}
+
+	t.Run("Answers elicitation requests", func(t *testing.T) {
+		var got *ElicitationRequest
+		tr := newHandler(t, func(_ context.Context, req *ElicitationRequest) (*ElicitationResult, error) {
+			got = req
+			return &ElicitationResult{Action: ElicitationAccept, Content: map[string]any{"name": "octocat"}}, nil
+		})
+		if _, ok := tr.capabilities["elicitation"]; !ok {
+			t.Error("Expected the elicitation capability to be declared")
+		}
+		if _, ok := tr.capabilities["sampling"]; ok {
+			t.Error("Expected the sampling capability not to be declared")
+		}
+
+		params := json.RawMessage(`{"message":"Your GitHub username?","requestedSchema":{"type":"object","properties":{"name":{"type":"string","minLength":1}},"required":["name"]}}`)
+		result, err := tr.handlers["elicitation/create"](ctx, params)
+		if err != nil {
+			t.Fatalf("Handler failed: %v", err)
+		}
+		if got.Message != "Your GitHub username?" || got.RequestedSchema.Properties["name"].Type != "string" ||
+			*got.RequestedSchema.Properties["name"].MinLength != 1 || !reflect.DeepEqual(got.RequestedSchema.Required, []string{"name"}) {
+			t.Errorf("Unexpected decoded request: %+v", got)
+		}
+		encoded, _ := json.Marshal(result)
+		if string(encoded) != `{"action":"accept","content":{"name":"octocat"}}` {
+			t.Errorf("Unexpected result: %s", encoded)
+		}
+	})
+
+	t.Run("Drops content when declining", func(t *testing.T) {
+		tr := newHandler(t, func(context.Context, *ElicitationRequest) (*ElicitationResult, error) {
+			return &ElicitationResult{Action: ElicitationDecline, Content: map[string]any{"name": "ignored"}}, nil
+		})
+		result, err := tr.handlers["elicitation/create"](ctx, json.RawMessage(`{"message":"?"}`))
+		if err != nil {
+			t.Fatalf("Handler failed: %v", err)
+		}
+		encoded, _ := json.Marshal(result)
+		if string(encoded) != `{"action":"decline"}` {
+			t.Errorf("Unexpected result: %s", encoded)
+		}
+	})
+
+	t.Run("Reports handler errors", func(t *testing.T) {
+		results := []*ElicitationResult{nil, {Action: "maybe"}}
+		for _, res := range results {
+			tr := newHandler(t, func(context.Context, *ElicitationRequest) (*ElicitationResult, error) { return res, nil })
+			if _, err := tr.handlers["elicitation/create"](ctx, json.RawMessage(`{"message":"?"}`)); err == nil {
+				t.Errorf("Expected an error for result %+v", res)
+			}
+		}
+
+		tr := newHandler(t, func(context.Context, *ElicitationRequest) (*ElicitationResult, error) {
+			return nil, errors.New("no user present")
+		})
+		if _, err := tr.handlers["elicitation/create"](ctx, json.RawMessage(`{"message":"?"}`)); err == nil || err.Error() != "no user present" {
+			t.Errorf("Expected the handler error, got %v", err)
+		}
+		var invocationErr *ToolInvocationError
+		if _, err := tr.handlers["elicitation/create"](ctx, json.RawMessage(`{"message":1}`)); !errors.As(err, &invocationErr) || invocationErr.Code != -32602 {
+			t.Errorf("Expected an invalid params error, got %v", err)
+		}
+	})
+
+	t.Run("Negative Test - Invalid usage", func(t *testing.T) {
+		handler := func(context.Context, *ElicitationRequest) (*ElicitationResult, error) { return nil, nil }
+		testCases := []struct {
+			name    string
+			opts    []ClientOption
+			wantErr string
+		}{
+			{"Nil handler", []ClientOption{WithElicitationHandler(nil)}, "cannot be nil"},
+			{"Duplicate handler", []ClientOption{WithElicitationHandler(handler), WithElicitationHandler(handler)}, "already set"},
+			{"Unsupported transport", []ClientOption{WithCustomTransport(&dummyTransport{}), WithElicitationHandler(handler)}, "cannot answer server requests"},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				_, err := NewToolboxClient("https://custom", tc.opts...)
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Errorf("Expected error containing %q, got %v", tc.wantErr, err)
+				}
+			})
+		}
+	})
+}
@@ -0,0 +1,60 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package tokencache caches OAuth 2.0 tokens until shortly before they
+// expire.
+package tokencache
+
+import (
+	"sync"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+// DefaultSkew is how long before their expiry cached tokens are refreshed by
+// default, as in oauth2.
+const DefaultSkew = 10 * time.Second
+
+// Reuse wraps src so that its tokens are cached until they are within skew
+// of expiring. Unlike oauth2.ReuseTokenSource, tokens without an expiry are
+// not cached, since they may stop being valid at any time.
+func Reuse(src oauth2.TokenSource, skew time.Duration) oauth2.TokenSource {
+	return &expiringTokenSource{src: src, skew: skew}
+}
+
+// expiringTokenSource caches the tokens of src that carry an expiry.
+type expiringTokenSource struct {
+	mu    sync.Mutex
+	src   oauth2.TokenSource
+	skew  time.Duration
+	token *oauth2.Token
+}
+
+func (s *expiringTokenSource) Token() (*oauth2.Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.token != nil && time.Now().Add(s.skew).Before(s.token.Expiry) {
+		return s.token, nil
+	}
+	token, err := s.src.Token()
+	if err != nil {
+		return nil, err
+	}
+	s.token = nil
+	if !token.Expiry.IsZero() {
+		s.token = token
+	}
+	return token, nil
+}
@@ -0,0 +1,245 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"encoding/json"
+	"maps"
+	"slices"
+	"strconv"
+
+	"github.com/invopop/jsonschema"
+)
+
+// ToolInputJSONSchema returns the JSON Schema of the tool's unbound input
+// parameters as a jsonschema.Schema, so that adapters and validators can
+// consume it without parsing the output of InputSchema. It returns nil for a
+// nil tool.
+func ToolInputJSONSchema(tool *ToolboxTool) *jsonschema.Schema {
+	if tool == nil {
+		return nil
+	}
+	schema := &jsonschema.Schema{
+		Type:       "object",
+		Properties: jsonschema.NewProperties(),
+	}
+	for _, p := range tool.parameters {
+		schema.Properties.Set(p.Name, ParameterJSONSchema(p))
+		if p.Required {
+			schema.Required = append(schema.Required, p.Name)
+		}
+	}
+	return schema
+}
+
+// ParameterJSONSchema converts a single tool parameter into a
+// jsonschema.Schema. Float parameters are declared as JSON Schema numbers.
+func ParameterJSONSchema(p ParameterSchema) *jsonschema.Schema {
+	schema := &jsonschema.Schema{
+		Type:        p.Type,
+		Description: p.Description,
+		Default:     p.Default,
+	}
+	if p.Type == "float" {
+		schema.Type = "number"
+	}
+	if p.Nullable {
+		schema.Extras = map[string]any{"nullable": true}
+	}
+	if p.Minimum != nil {
+		schema.Minimum = floatNumber(*p.Minimum)
+	}
+	if p.Maximum != nil {
+		schema.Maximum = floatNumber(*p.Maximum)
+	}
+	schema.MinLength = uintPtr(p.MinLength)
+	schema.MaxLength = uintPtr(p.MaxLength)
+	schema.Pattern = p.Pattern
+	schema.MinItems = uintPtr(p.MinItems)
+	schema.MaxItems = uintPtr(p.MaxItems)
+	if p.Type == "array" && p.Items != nil {
+		schema.Items = ParameterJSONSchema(*p.Items)
+	}
+	if p.Type == "object" {
+		switch ap := p.AdditionalProperties.(type) {
+		case *ParameterSchema:
+			schema.AdditionalProperties = ParameterJSONSchema(*ap)
+		case bool:
+			schema.AdditionalProperties = boolJSONSchema(ap)
+		}
+	}
+	return schema
+}
+
+func floatNumber(f float64) json.Number {
+	return json.Number(strconv.FormatFloat(f, 'g', -1, 64))
+}
+
+func uintPtr(n *int) *uint64 {
+	if n == nil || *n < 0 {
+		return nil
+	}
+	u := uint64(*n)
+	return &u
+}
+
+// ToolOutputJSONSchema returns the JSON Schema of the tool's structured output
+// as a jsonschema.Schema, or nil if the server did not advertise one. The
+// schema is built from the advertised JSON rather than by reflecting on a Go
+// type; keywords that jsonschema.Schema has no field for are kept in its
+// Extras.
+func ToolOutputJSONSchema(tool *ToolboxTool) *jsonschema.Schema {
+	if tool == nil || tool.outputSchema == nil {
+		return nil
+	}
+	return mapToJSONSchema(tool.outputSchema)
+}
+
+// mapToJSONSchema builds a jsonschema.Schema from a decoded JSON Schema
+// object.
+func mapToJSONSchema(m map[string]any) *jsonschema.Schema {
+	schema := &jsonschema.Schema{}
+	for key, value := range m {
+		if !setJSONSchemaKeyword(schema, key, value) {
+			if schema.Extras == nil {
+				schema.Extras = make(map[string]any)
+			}
+			schema.Extras[key] = value
+		}
+	}
+	return schema
+}
+
+// setJSONSchemaKeyword sets the field of schema for keyword, and reports
+// whether the keyword is known and its value well-formed.
+func setJSONSchemaKeyword(schema *jsonschema.Schema, keyword string, value any) bool {
+	var ok bool
+	switch keyword {
+	case "type":
+		schema.Type, ok = value.(string)
+	case "title":
+		schema.Title, ok = value.(string)
+	case "description":
+		schema.Description, ok = value.(string)
+	case "format":
+		schema.Format, ok = value.(string)
+	case "pattern":
+		schema.Pattern, ok = value.(string)
+	case "$ref":
+		schema.Ref, ok = value.(string)
+	case "default":
+		schema.Default, ok = value, true
+	case "const":
+		schema.Const, ok = value, true
+	case "enum":
+		schema.Enum, ok = value.([]any)
+	case "examples":
+		schema.Examples, ok = value.([]any)
+	case "required":
+		schema.Required, ok = stringList(value)
+	case "items":
+		schema.Items, ok = anyToJSONSchema(value)
+	case "not":
+		schema.Not, ok = anyToJSONSchema(value)
+	case "additionalProperties":
+		schema.AdditionalProperties, ok = anyToJSONSchema(value)
+	case "allOf":
+		schema.AllOf, ok = schemaList(value)
+	case "anyOf":
+		schema.AnyOf, ok = schemaList(value)
+	case "oneOf":
+		schema.OneOf, ok = schemaList(value)
+	case "properties":
+		var properties map[string]any
+		if properties, ok = value.(map[string]any); ok {
+			schema.Properties = jsonschema.NewProperties()
+			// Sort the names so that the schema is stable across calls.
+			for _, name := range slices.Sorted(maps.Keys(properties)) {
+				property, valid := anyToJSONSchema(properties[name])
+				if !valid {
+					schema.Properties = nil
+					return false
+				}
+				schema.Properties.Set(name, property)
+			}
+		}
+	case "$defs":
+		var defs map[string]any
+		if defs, ok = value.(map[string]any); ok {
+			schema.Definitions = make(jsonschema.Definitions, len(defs))
+			for name, def := range defs {
+				d, valid := anyToJSONSchema(def)
+				if !valid {
+					schema.Definitions = nil
+					return false
+				}
+				schema.Definitions[name] = d
+			}
+		}
+	}
+	return ok
+}
+
+// anyToJSONSchema converts a decoded sub-schema, which is either an object or
+// a boolean.
+func anyToJSONSchema(value any) (*jsonschema.Schema, bool) {
+	switch v := value.(type) {
+	case map[string]any:
+		return mapToJSONSchema(v), true
+	case bool:
+		return boolJSONSchema(v), true
+	default:
+		return nil, false
+	}
+}
+
+func schemaList(value any) ([]*jsonschema.Schema, bool) {
+	list, ok := value.([]any)
+	if !ok {
+		return nil, false
+	}
+	schemas := make([]*jsonschema.Schema, 0, len(list))
+	for _, item := range list {
+		schema, ok := anyToJSONSchema(item)
+		if !ok {
+			return nil, false
+		}
+		schemas = append(schemas, schema)
+	}
+	return schemas, true
+}
+
+func stringList(value any) ([]string, bool) {
+	list, ok := value.([]any)
+	if !ok {
+		return nil, false
+	}
+	strs := make([]string, 0, len(list))
+	for _, item := range list {
+		s, ok := item.(string)
+		if !ok {
+			return nil, false
+		}
+		strs = append(strs, s)
+	}
+	return strs, true
+}
+
+func boolJSONSchema(b bool) *jsonschema.Schema {
+	if b {
+		return jsonschema.TrueSchema
+	}
+	return jsonschema.FalseSchema
+}
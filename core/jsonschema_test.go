@@ -0,0 +1,134 @@
+//go:build unit
+
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestToolInputJSONSchema(t *testing.T) {
+	maxLength, minimum := 100, 1.0
+	tool := &ToolboxTool{parameters: []ParameterSchema{
+		{Name: "query", Type: "string", Description: "The search query", Required: true, MaxLength: &maxLength, Pattern: "\\S"},
+		{Name: "limit", Type: "integer", Default: 10.0, Minimum: &minimum},
+		{Name: "min_price", Type: "float", Nullable: true},
+		{Name: "tags", Type: "array", Items: &ParameterSchema{Type: "string"}},
+		{Name: "filters", Type: "object", AdditionalProperties: &ParameterSchema{Type: "boolean"}},
+		{Name: "extra", Type: "object", AdditionalProperties: false},
+	}}
+
+	schema := ToolInputJSONSchema(tool)
+	if schema == nil {
+		t.Fatal("ToolInputJSONSchema returned nil")
+	}
+
+	t.Run("MatchesInputSchema", func(t *testing.T) {
+		got, err := json.Marshal(schema)
+		if err != nil {
+			t.Fatalf("Failed to marshal the schema: %v", err)
+		}
+		want, err := tool.InputSchema()
+		if err != nil {
+			t.Fatalf("InputSchema() returned an unexpected error: %v", err)
+		}
+		var gotMap, wantMap map[string]any
+		if err := json.Unmarshal(got, &gotMap); err != nil {
+			t.Fatalf("Failed to unmarshal the schema: %v", err)
+		}
+		if err := json.Unmarshal(want, &wantMap); err != nil {
+			t.Fatalf("Failed to unmarshal the input schema: %v", err)
+		}
+		if !reflect.DeepEqual(gotMap, wantMap) {
+			t.Errorf("Expected the schema to match InputSchema()\n got: %s\nwant: %s", got, want)
+		}
+	})
+
+	t.Run("KeepsParameterOrder", func(t *testing.T) {
+		var names []string
+		for pair := schema.Properties.Oldest(); pair != nil; pair = pair.Next() {
+			names = append(names, pair.Key)
+		}
+		want := []string{"query", "limit", "min_price", "tags", "filters", "extra"}
+		if !reflect.DeepEqual(names, want) {
+			t.Errorf("Expected properties %v, got %v", want, names)
+		}
+	})
+
+	t.Run("NilTool", func(t *testing.T) {
+		if ToolInputJSONSchema(nil) != nil {
+			t.Error("Expected nil for a nil tool")
+		}
+	})
+}
+
+func TestToolOutputJSONSchema(t *testing.T) {
+	raw := `{
+		"type": "object",
+		"title": "Result",
+		"properties": {
+			"rows": {"type": "array", "items": {"$ref": "#/$defs/row"}},
+			"count": {"type": "integer", "minimum": 0},
+			"next": {"anyOf": [{"type": "string"}, {"type": "null"}]}
+		},
+		"required": ["rows", "count"],
+		"additionalProperties": false,
+		"$defs": {"row": {"type": "object", "additionalProperties": true}},
+		"x-origin": "toolbox"
+	}`
+	var outputSchema map[string]any
+	if err := json.Unmarshal([]byte(raw), &outputSchema); err != nil {
+		t.Fatalf("Failed to unmarshal the test schema: %v", err)
+	}
+
+	schema := ToolOutputJSONSchema(&ToolboxTool{outputSchema: outputSchema})
+	if schema == nil {
+		t.Fatal("ToolOutputJSONSchema returned nil")
+	}
+	if schema.Type != "object" || schema.Title != "Result" || !reflect.DeepEqual(schema.Required, []string{"rows", "count"}) {
+		t.Errorf("Unexpected top-level keywords: %+v", schema)
+	}
+	if rows, _ := schema.Properties.Get("rows"); rows == nil || rows.Items == nil || rows.Items.Ref != "#/$defs/row" {
+		t.Errorf("Expected the items of rows to reference the row definition, got %+v", rows)
+	}
+	if schema.Extras["x-origin"] != "toolbox" {
+		t.Errorf("Expected unknown keywords to be kept in Extras, got %v", schema.Extras)
+	}
+
+	// Encoding the schema must reproduce the advertised one.
+	encoded, err := json.Marshal(schema)
+	if err != nil {
+		t.Fatalf("Failed to marshal the schema: %v", err)
+	}
+	var got map[string]any
+	if err := json.Unmarshal(encoded, &got); err != nil {
+		t.Fatalf("Failed to unmarshal the schema: %v", err)
+	}
+	if !reflect.DeepEqual(got, outputSchema) {
+		t.Errorf("Expected the advertised schema to round-trip\n got: %s\nwant: %s", encoded, raw)
+	}
+
+	t.Run("NotAdvertised", func(t *testing.T) {
+		if ToolOutputJSONSchema(&ToolboxTool{}) != nil {
+			t.Error("Expected nil when no output schema was advertised")
+		}
+		if ToolOutputJSONSchema(nil) != nil {
+			t.Error("Expected nil for a nil tool")
+		}
+	})
+}
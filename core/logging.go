@@ -0,0 +1,94 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+)
+
+// logMessageMethod is the notification a server sends to log a message.
+const logMessageMethod = "notifications/message"
+
+// LogMessage is a log message sent by the server.
+type LogMessage struct {
+	// Level is the syslog severity of the message: "debug", "info",
+	// "notice", "warning", "error", "critical", "alert" or "emergency".
+	Level string `json:"level"`
+	// Logger optionally names the server component that logged the message.
+	Logger string `json:"logger,omitempty"`
+	// Data is the logged value, usually a string or an object.
+	Data json.RawMessage `json:"data"`
+}
+
+// SlogLevel maps the severity of the message to a slog.Level. Severities
+// without a slog equivalent are placed between the standard levels, so that
+// "notice" is above Info and "critical", "alert" and "emergency" are above
+// Error. Unknown severities map to Info.
+func (m LogMessage) SlogLevel() slog.Level {
+	switch m.Level {
+	case "debug":
+		return slog.LevelDebug
+	case "notice":
+		return slog.LevelInfo + 2
+	case "warning":
+		return slog.LevelWarn
+	case "error":
+		return slog.LevelError
+	case "critical":
+		return slog.LevelError + 1
+	case "alert":
+		return slog.LevelError + 2
+	case "emergency":
+		return slog.LevelError + 3
+	default:
+		return slog.LevelInfo
+	}
+}
+
+// handleLogMessage passes a notifications/message notification to the
+// configured log handler and logger.
+func (tc *ToolboxClient) handleLogMessage(params json.RawMessage) {
+	var msg LogMessage
+	if err := json.Unmarshal(params, &msg); err != nil {
+		return
+	}
+	if tc.logHandler != nil {
+		tc.logHandler(msg)
+	}
+	if tc.serverLogger != nil {
+		logToSlog(tc.serverLogger, msg)
+	}
+}
+
+// logToSlog writes msg to logger. String data becomes the log message; other
+// data is attached as the "data" attribute.
+func logToSlog(logger *slog.Logger, msg LogMessage) {
+	text := "MCP server log"
+	attrs := []any{}
+	var data any
+	if err := json.Unmarshal(msg.Data, &data); err == nil {
+		if s, ok := data.(string); ok {
+			text = s
+		} else if data != nil {
+			attrs = append(attrs, slog.Any("data", data))
+		}
+	}
+	if msg.Logger != "" {
+		attrs = append(attrs, slog.String("logger", msg.Logger))
+	}
+	logger.Log(context.Background(), msg.SlogLevel(), text, attrs...)
+}
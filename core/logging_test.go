@@ -0,0 +1,106 @@
+//go:build unit
+
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/googleapis/mcp-toolbox-sdk-go/core/transport"
+)
+
+func TestLogMessage_SlogLevel(t *testing.T) {
+	testCases := map[string]slog.Level{
+		"debug":     slog.LevelDebug,
+		"info":      slog.LevelInfo,
+		"notice":    slog.LevelInfo + 2,
+		"warning":   slog.LevelWarn,
+		"error":     slog.LevelError,
+		"critical":  slog.LevelError + 1,
+		"alert":     slog.LevelError + 2,
+		"emergency": slog.LevelError + 3,
+		"unknown":   slog.LevelInfo,
+	}
+	for level, want := range testCases {
+		if got := (LogMessage{Level: level}).SlogLevel(); got != want {
+			t.Errorf("SlogLevel() for %q = %v, want %v", level, got, want)
+		}
+	}
+}
+
+func TestServerLogging(t *testing.T) {
+	t.Run("Routes log messages to the handler and logger", func(t *testing.T) {
+		custom := &notifyingTransport{}
+		var buf bytes.Buffer
+		logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+		var received []LogMessage
+
+		_, err := NewToolboxClient("https://custom",
+			WithCustomTransport(custom),
+			WithServerLogHandler(func(m LogMessage) { received = append(received, m) }),
+			WithServerLogger(logger),
+		)
+		if err != nil {
+			t.Fatalf("NewToolboxClient failed: %v", err)
+		}
+		if custom.handler == nil {
+			t.Fatal("Expected the client to subscribe to notifications")
+		}
+
+		custom.handler(transport.Notification{Method: "notifications/message", Params: []byte(`{"level":"warning","logger":"db","data":"slow query"}`)})
+		custom.handler(transport.Notification{Method: "notifications/message", Params: []byte(`{"level":"error","data":{"code":7}}`)})
+
+		if len(received) != 2 || received[0].Level != "warning" || received[0].Logger != "db" {
+			t.Errorf("Unexpected messages: %+v", received)
+		}
+		out := buf.String()
+		for _, want := range []string{`level=WARN msg="slow query" logger=db`, `level=ERROR msg="MCP server log" data=map[code:7]`} {
+			if !strings.Contains(out, want) {
+				t.Errorf("Expected log output to contain %q, got:\n%s", want, out)
+			}
+		}
+	})
+
+	t.Run("Negative Test - Invalid usage", func(t *testing.T) {
+		handler := func(LogMessage) {}
+		testCases := []struct {
+			name    string
+			opt     ClientOption
+			wantErr string
+		}{
+			{"Nil handler", WithServerLogHandler(nil), "cannot be nil"},
+			{"Nil logger", WithServerLogger(nil), "cannot be nil"},
+		}
+		for _, tc := range testCases {
+			if err := tc.opt(newTestClient()); err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Errorf("%s: expected error containing %q, got %v", tc.name, tc.wantErr, err)
+			}
+		}
+
+		client := newTestClient()
+		_ = WithServerLogHandler(handler)(client)
+		if err := WithServerLogHandler(handler)(client); err == nil || !strings.Contains(err.Error(), "already set") {
+			t.Errorf("Expected a duplicate handler error, got %v", err)
+		}
+		_ = WithServerLogger(slog.Default())(client)
+		if err := WithServerLogger(slog.Default())(client); err == nil || !strings.Contains(err.Error(), "already set") {
+			t.Errorf("Expected a duplicate logger error, got %v", err)
+		}
+	})
+}
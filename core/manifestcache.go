@@ -0,0 +1,143 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// ManifestCache is a cache of tool and toolset manifests that can be shared
+// by several clients, such as clients derived per tenant from the same
+// configuration, so that identical manifests are only fetched once. Entries
+// are keyed by server URL, tool or toolset name, and client headers, so that
+// manifests fetched with one client's credentials are never served to a
+// client with different ones. Concurrent loads of the same manifest wait for
+// a single fetch. A ManifestCache is safe for concurrent use.
+type ManifestCache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	entries map[string]*manifestCacheEntry
+}
+
+type manifestCacheEntry struct {
+	baseURL  string
+	ready    chan struct{}
+	manifest *ManifestSchema
+	err      error
+	expires  time.Time
+}
+
+// NewManifestCache creates a manifest cache whose entries expire after ttl.
+// Entries never expire if ttl is zero or negative; use Invalidate to drop
+// them. Entries for a server are also dropped when it announces that its
+// tools changed, over transports that deliver such notifications.
+func NewManifestCache(ttl time.Duration) *ManifestCache {
+	return &ManifestCache{
+		ttl:     ttl,
+		entries: make(map[string]*manifestCacheEntry),
+	}
+}
+
+// Invalidate drops all the entries of the cache.
+func (c *ManifestCache) Invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	clear(c.entries)
+}
+
+// invalidateURL drops the entries fetched from the server at baseURL.
+func (c *ManifestCache) invalidateURL(baseURL string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for key, entry := range c.entries {
+		if entry.baseURL == baseURL {
+			delete(c.entries, key)
+		}
+	}
+}
+
+// load returns the manifest cached under key, calling fetch to fetch it if
+// it is missing or expired. Failed fetches are not cached, and callers that
+// waited for a fetch that failed fetch the manifest themselves.
+func (c *ManifestCache) load(ctx context.Context, key, baseURL string, fetch func() (*ManifestSchema, error)) (*ManifestSchema, error) {
+	c.mu.Lock()
+	entry, ok := c.entries[key]
+	if ok && c.expired(entry) {
+		ok = false
+	}
+	if !ok {
+		entry = &manifestCacheEntry{baseURL: baseURL, ready: make(chan struct{})}
+		c.entries[key] = entry
+		c.mu.Unlock()
+
+		entry.manifest, entry.err = fetch()
+		entry.expires = time.Now().Add(c.ttl)
+		if entry.err != nil {
+			c.mu.Lock()
+			if c.entries[key] == entry {
+				delete(c.entries, key)
+			}
+			c.mu.Unlock()
+		}
+		close(entry.ready)
+		return entry.manifest, entry.err
+	}
+	c.mu.Unlock()
+
+	select {
+	case <-entry.ready:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	if entry.err != nil {
+		return fetch()
+	}
+	return entry.manifest, nil
+}
+
+// expired reports whether a completed entry has expired. Entries still being
+// fetched never expire. It must be called with c.mu held.
+func (c *ManifestCache) expired(entry *manifestCacheEntry) bool {
+	select {
+	case <-entry.ready:
+		return c.ttl > 0 && time.Now().After(entry.expires)
+	default:
+		return false
+	}
+}
+
+// manifestCacheKey derives a cache key from the server URL, the kind and
+// name of the manifest, and the client headers and query parameters it is
+// fetched with.
+func manifestCacheKey(baseURL, kind, name string, headers map[string]string, query url.Values) (string, error) {
+	b, err := json.Marshal(struct {
+		URL     string            `json:"url"`
+		Kind    string            `json:"kind"`
+		Name    string            `json:"name"`
+		Headers map[string]string `json:"headers"`
+		Query   string            `json:"query,omitempty"`
+	}{baseURL, kind, name, headers, query.Encode()})
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:]), nil
+}
@@ -0,0 +1,196 @@
+//go:build unit
+
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestManifestCache_SharedByClients(t *testing.T) {
+	mock := newMockMCPServer(t, []mcpTool{{Name: "search", InputSchema: map[string]any{"type": "object"}}})
+	defer mock.Close()
+	var lists atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if strings.Contains(string(body), `"tools/list"`) {
+			lists.Add(1)
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		mock.Config.Handler.ServeHTTP(w, r)
+	}))
+	defer server.Close()
+
+	newClient := func(cache *ManifestCache, opts ...ClientOption) *ToolboxClient {
+		client, err := NewToolboxClient(server.URL, append(opts, WithManifestCache(cache))...)
+		if err != nil {
+			t.Fatalf("NewToolboxClient failed: %v", err)
+		}
+		return client
+	}
+	load := func(client *ToolboxClient) {
+		tools, err := client.LoadToolset("", context.Background())
+		if err != nil {
+			t.Fatalf("LoadToolset failed: %v", err)
+		}
+		if len(tools) != 1 || tools[0].Name() != "search" {
+			t.Fatalf("Expected the 'search' tool, got %v", tools)
+		}
+	}
+
+	t.Run("Fetches identical toolsets once", func(t *testing.T) {
+		lists.Store(0)
+		cache := NewManifestCache(0)
+		load(newClient(cache))
+		load(newClient(cache))
+		if got := lists.Load(); got != 1 {
+			t.Errorf("Expected 1 tools/list request, got %d", got)
+		}
+	})
+
+	t.Run("Keeps clients with different headers apart", func(t *testing.T) {
+		lists.Store(0)
+		cache := NewManifestCache(0)
+		load(newClient(cache, WithClientHeaderString("X-Tenant", "a")))
+		load(newClient(cache, WithClientHeaderString("X-Tenant", "b")))
+		load(newClient(cache, WithClientHeaderString("X-Tenant", "a")))
+		if got := lists.Load(); got != 2 {
+			t.Errorf("Expected 2 tools/list requests, got %d", got)
+		}
+	})
+
+	t.Run("Fetches again once entries expire", func(t *testing.T) {
+		lists.Store(0)
+		cache := NewManifestCache(time.Millisecond)
+		client := newClient(cache)
+		load(client)
+		time.Sleep(5 * time.Millisecond)
+		load(client)
+		if got := lists.Load(); got != 2 {
+			t.Errorf("Expected 2 tools/list requests, got %d", got)
+		}
+	})
+
+	t.Run("Fetches again after Invalidate", func(t *testing.T) {
+		lists.Store(0)
+		cache := NewManifestCache(0)
+		client := newClient(cache)
+		load(client)
+		cache.Invalidate()
+		load(client)
+		if got := lists.Load(); got != 2 {
+			t.Errorf("Expected 2 tools/list requests, got %d", got)
+		}
+	})
+
+	t.Run("Drops the entries of a server whose tools changed", func(t *testing.T) {
+		lists.Store(0)
+		cache := NewManifestCache(0)
+		client := newClient(cache)
+		load(client)
+		client.handleServerNotification(Notification{Method: toolsListChangedMethod})
+		load(client)
+		if got := lists.Load(); got != 2 {
+			t.Errorf("Expected 2 tools/list requests, got %d", got)
+		}
+	})
+}
+
+func TestManifestCache_Load(t *testing.T) {
+	manifest := &ManifestSchema{ServerVersion: "1.0.0"}
+
+	t.Run("Shares a single fetch between concurrent loads", func(t *testing.T) {
+		cache := NewManifestCache(0)
+		var fetches atomic.Int32
+		release := make(chan struct{})
+		fetch := func() (*ManifestSchema, error) {
+			fetches.Add(1)
+			<-release
+			return manifest, nil
+		}
+
+		var wg sync.WaitGroup
+		results := make([]*ManifestSchema, 5)
+		for i := range results {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				results[i], _ = cache.load(context.Background(), "key", "http://toolbox.test", fetch)
+			}()
+		}
+		time.Sleep(10 * time.Millisecond)
+		close(release)
+		wg.Wait()
+
+		if got := fetches.Load(); got != 1 {
+			t.Errorf("Expected 1 fetch, got %d", got)
+		}
+		for _, result := range results {
+			if result != manifest {
+				t.Errorf("Expected the fetched manifest, got %v", result)
+			}
+		}
+	})
+
+	t.Run("Does not cache failed fetches", func(t *testing.T) {
+		cache := NewManifestCache(0)
+		failure := errors.New("unavailable")
+		if _, err := cache.load(context.Background(), "key", "", func() (*ManifestSchema, error) { return nil, failure }); !errors.Is(err, failure) {
+			t.Fatalf("Expected %v, got %v", failure, err)
+		}
+		result, err := cache.load(context.Background(), "key", "", func() (*ManifestSchema, error) { return manifest, nil })
+		if err != nil || result != manifest {
+			t.Errorf("Expected the manifest to be fetched again, got %v, %v", result, err)
+		}
+	})
+
+	t.Run("Stops waiting when the context is done", func(t *testing.T) {
+		cache := NewManifestCache(0)
+		release := make(chan struct{})
+		defer close(release)
+		go cache.load(context.Background(), "key", "", func() (*ManifestSchema, error) {
+			<-release
+			return manifest, nil
+		})
+		time.Sleep(10 * time.Millisecond)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		if _, err := cache.load(ctx, "key", "", func() (*ManifestSchema, error) { return manifest, nil }); !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("Expected context.DeadlineExceeded, got %v", err)
+		}
+	})
+}
+
+func TestWithManifestCache(t *testing.T) {
+	if _, err := NewToolboxClient("http://toolbox.test", WithManifestCache(nil)); err == nil {
+		t.Error("Expected an error for a nil cache, got nil")
+	}
+	cache := NewManifestCache(0)
+	if _, err := NewToolboxClient("http://toolbox.test", WithManifestCache(cache), WithManifestCache(cache)); err == nil {
+		t.Error("Expected an error when overriding the cache, got nil")
+	}
+}
@@ -0,0 +1,214 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package openapi renders a set of Toolbox tools as an OpenAPI 3.1 document,
+// with one POST operation per tool, for publishing the tools to API gateways
+// and to LLM platforms that import OpenAPI, such as GPT Actions.
+//
+// By default, the operations describe the HTTP API of the Toolbox server
+// itself: each tool is invoked by POSTing its arguments to
+// /api/tool/{name}/invoke, and its result is returned in the "result" field of
+// the response.
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/googleapis/mcp-toolbox-sdk-go/core"
+)
+
+// Version is the OpenAPI version of the generated documents.
+const Version = "3.1.0"
+
+// Document is an OpenAPI document. Only the objects used to describe tools
+// are declared.
+type Document struct {
+	OpenAPI    string              `json:"openapi"`
+	Info       Info                `json:"info"`
+	Servers    []Server            `json:"servers,omitempty"`
+	Paths      map[string]PathItem `json:"paths"`
+	Components *Components         `json:"components,omitempty"`
+}
+
+// Info describes the API.
+type Info struct {
+	Title       string `json:"title"`
+	Version     string `json:"version"`
+	Description string `json:"description,omitempty"`
+}
+
+// Server is a base URL the API is served at.
+type Server struct {
+	URL string `json:"url"`
+}
+
+// PathItem holds the operations of a path. Tools are only ever POSTed to.
+type PathItem struct {
+	Post *Operation `json:"post,omitempty"`
+}
+
+// Operation describes the invocation of a tool.
+type Operation struct {
+	OperationID string                `json:"operationId"`
+	Summary     string                `json:"summary,omitempty"`
+	Description string                `json:"description,omitempty"`
+	Tags        []string              `json:"tags,omitempty"`
+	RequestBody *RequestBody          `json:"requestBody,omitempty"`
+	Responses   map[string]Response   `json:"responses"`
+	Security    []map[string][]string `json:"security,omitempty"`
+}
+
+// RequestBody describes the arguments of a tool.
+type RequestBody struct {
+	Required bool                 `json:"required,omitempty"`
+	Content  map[string]MediaType `json:"content"`
+}
+
+// Response describes a response of an operation.
+type Response struct {
+	Description string               `json:"description"`
+	Content     map[string]MediaType `json:"content,omitempty"`
+}
+
+// MediaType holds the JSON Schema of a request or response body.
+type MediaType struct {
+	Schema json.RawMessage `json:"schema"`
+}
+
+// Components holds the security schemes referenced by the operations.
+type Components struct {
+	SecuritySchemes map[string]SecurityScheme `json:"securitySchemes,omitempty"`
+}
+
+// SecurityScheme describes how an auth token is passed to the API.
+type SecurityScheme struct {
+	Type        string `json:"type"`
+	Name        string `json:"name,omitempty"`
+	In          string `json:"in,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// defaultResultSchema is the response schema of tools without an output
+// schema, matching the HTTP API of the Toolbox server.
+var defaultResultSchema = json.RawMessage(`{"type":"object","properties":{"result":{"type":"string","description":"The result of the tool."}}}`)
+
+// Generate renders tools as an OpenAPI document. Only the unbound parameters
+// of the tools are part of their request schemas, and tools whose auth
+// services are not all satisfied by their token sources require the
+// "<service>_token" header of each missing service.
+//
+// Inputs:
+//   - tools: The tools to describe, which must have distinct names.
+//   - opts: A variadic list of Option functions, such as WithServerURL.
+//
+// Returns:
+//
+//	The Document, ready to be encoded as JSON, or an error if the options are
+//	invalid or a tool cannot be described.
+func Generate(tools []*core.ToolboxTool, opts ...Option) (*Document, error) {
+	cfg, err := newConfig(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	doc := &Document{
+		OpenAPI: Version,
+		Info:    Info{Title: cfg.title, Version: cfg.version, Description: cfg.description},
+		Paths:   make(map[string]PathItem, len(tools)),
+	}
+	for _, serverURL := range cfg.serverURLs {
+		doc.Servers = append(doc.Servers, Server{URL: serverURL})
+	}
+
+	if _, err := core.Toolset(tools).ByName(); err != nil {
+		return nil, fmt.Errorf("Generate: %w", err)
+	}
+	schemes := make(map[string]SecurityScheme)
+	for _, tool := range tools {
+		op, err := newOperation(tool, schemes)
+		if err != nil {
+			return nil, err
+		}
+		path := cfg.pathFunc(tool.Name())
+		if !strings.HasPrefix(path, "/") {
+			return nil, fmt.Errorf("Generate: the path '%s' of tool '%s' does not start with '/'", path, tool.Name())
+		}
+		if _, exists := doc.Paths[path]; exists {
+			return nil, fmt.Errorf("Generate: tools share the path '%s'", path)
+		}
+		doc.Paths[path] = PathItem{Post: op}
+	}
+	if len(schemes) > 0 {
+		doc.Components = &Components{SecuritySchemes: schemes}
+	}
+	return doc, nil
+}
+
+// newOperation describes the invocation of tool, adding the security schemes
+// it requires to schemes.
+func newOperation(tool *core.ToolboxTool, schemes map[string]SecurityScheme) (*Operation, error) {
+	inputSchema, err := tool.InputSchema()
+	if err != nil {
+		return nil, fmt.Errorf("error generating the input schema of tool '%s': %w", tool.Name(), err)
+	}
+	outputSchema, err := tool.OutputSchema()
+	if err != nil {
+		return nil, fmt.Errorf("error generating the output schema of tool '%s': %w", tool.Name(), err)
+	}
+	if outputSchema == nil {
+		outputSchema = defaultResultSchema
+	}
+
+	op := &Operation{
+		OperationID: tool.Name(),
+		Summary:     tool.Title(),
+		Description: tool.Description(),
+		Tags:        tool.Tags(),
+		RequestBody: &RequestBody{
+			Required: true,
+			Content:  map[string]MediaType{"application/json": {Schema: inputSchema}},
+		},
+		Responses: map[string]Response{
+			"200": {
+				Description: "The tool was invoked successfully.",
+				Content:     map[string]MediaType{"application/json": {Schema: outputSchema}},
+			},
+			"default": {Description: "The tool could not be invoked."},
+		},
+	}
+
+	if ok, missing := tool.AuthSatisfied(); !ok {
+		requirement := make(map[string][]string, len(missing))
+		for _, service := range missing {
+			requirement[service] = []string{}
+			schemes[service] = SecurityScheme{
+				Type:        "apiKey",
+				Name:        service + "_token",
+				In:          "header",
+				Description: fmt.Sprintf("An ID token issued by the '%s' auth service.", service),
+			}
+		}
+		op.Security = []map[string][]string{requirement}
+	}
+	return op, nil
+}
+
+// defaultPath returns the path of a tool in the HTTP API of the Toolbox
+// server.
+func defaultPath(name string) string {
+	return "/api/tool/" + url.PathEscape(name) + "/invoke"
+}
@@ -0,0 +1,190 @@
+//go:build unit
+
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/googleapis/mcp-toolbox-sdk-go/core"
+	"github.com/googleapis/mcp-toolbox-sdk-go/core/toolboxtest"
+	"github.com/googleapis/mcp-toolbox-sdk-go/core/transport"
+)
+
+func loadTools(t *testing.T, names ...string) []*core.ToolboxTool {
+	t.Helper()
+	tr := &toolboxtest.Transport{Tools: map[string]transport.ToolSchema{
+		"search": {
+			Title:       "Search",
+			Description: "Search the catalog",
+			Tags:        []string{"catalog"},
+			Parameters: []core.ParameterSchema{
+				{Name: "query", Type: "string", Required: true},
+				{Name: "user_id", Type: "string", AuthSources: []string{"google"}},
+			},
+		},
+		"stats": {
+			Description:  "Catalog statistics",
+			OutputSchema: map[string]any{"type": "object", "properties": map[string]any{"rows": map[string]any{"type": "integer"}}},
+		},
+	}}
+	var tools []*core.ToolboxTool
+	for _, name := range names {
+		tools = append(tools, toolboxtest.LoadTool(t, tr, name))
+	}
+	return tools
+}
+
+// encode returns doc as decoded JSON.
+func encode(t *testing.T, doc *Document) map[string]any {
+	t.Helper()
+	data, err := json.Marshal(doc)
+	if err != nil {
+		t.Fatalf("Failed to encode the document: %v", err)
+	}
+	var decoded map[string]any
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Failed to decode the document: %v", err)
+	}
+	return decoded
+}
+
+func TestGenerate(t *testing.T) {
+	doc, err := Generate(loadTools(t, "search", "stats"), WithServerURL("https://toolbox.example.com/"))
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	got := encode(t, doc)
+
+	if got["openapi"] != "3.1.0" {
+		t.Errorf("Expected OpenAPI 3.1.0, got %v", got["openapi"])
+	}
+	if !reflect.DeepEqual(got["info"], map[string]any{"title": "Toolbox Tools", "version": "1.0.0"}) {
+		t.Errorf("Unexpected info: %v", got["info"])
+	}
+	if !reflect.DeepEqual(got["servers"], []any{map[string]any{"url": "https://toolbox.example.com"}}) {
+		t.Errorf("Unexpected servers: %v", got["servers"])
+	}
+
+	paths, _ := got["paths"].(map[string]any)
+	if len(paths) != 2 {
+		t.Fatalf("Expected a path per tool, got %v", paths)
+	}
+
+	search := paths["/api/tool/search/invoke"].(map[string]any)["post"].(map[string]any)
+	if search["operationId"] != "search" || search["summary"] != "Search" || search["description"] != "Search the catalog" {
+		t.Errorf("Unexpected operation metadata: %v", search)
+	}
+	if !reflect.DeepEqual(search["tags"], []any{"catalog"}) {
+		t.Errorf("Expected the tags to be kept, got %v", search["tags"])
+	}
+	requestSchema := search["requestBody"].(map[string]any)["content"].(map[string]any)["application/json"].(map[string]any)["schema"]
+	expectedRequest := map[string]any{
+		"type":       "object",
+		"properties": map[string]any{"query": map[string]any{"type": "string"}},
+		"required":   []any{"query"},
+	}
+	if !reflect.DeepEqual(requestSchema, expectedRequest) {
+		t.Errorf("Expected request schema %v, got %v", expectedRequest, requestSchema)
+	}
+	if !reflect.DeepEqual(search["security"], []any{map[string]any{"google": []any{}}}) {
+		t.Errorf("Expected the google auth service to be required, got %v", search["security"])
+	}
+	expectedScheme := map[string]any{
+		"type":        "apiKey",
+		"name":        "google_token",
+		"in":          "header",
+		"description": "An ID token issued by the 'google' auth service.",
+	}
+	schemes := got["components"].(map[string]any)["securitySchemes"].(map[string]any)
+	if !reflect.DeepEqual(schemes["google"], expectedScheme) {
+		t.Errorf("Expected scheme %v, got %v", expectedScheme, schemes["google"])
+	}
+
+	stats := paths["/api/tool/stats/invoke"].(map[string]any)["post"].(map[string]any)
+	if _, ok := stats["security"]; ok {
+		t.Errorf("Expected no security for a tool without auth, got %v", stats["security"])
+	}
+	responseSchema := stats["responses"].(map[string]any)["200"].(map[string]any)["content"].(map[string]any)["application/json"].(map[string]any)["schema"]
+	if !reflect.DeepEqual(responseSchema, map[string]any{"type": "object", "properties": map[string]any{"rows": map[string]any{"type": "integer"}}}) {
+		t.Errorf("Expected the output schema as the response schema, got %v", responseSchema)
+	}
+	searchResponse := search["responses"].(map[string]any)["200"].(map[string]any)["content"].(map[string]any)["application/json"].(map[string]any)["schema"].(map[string]any)
+	if _, ok := searchResponse["properties"].(map[string]any)["result"]; !ok {
+		t.Errorf("Expected the Toolbox result schema for a tool without an output schema, got %v", searchResponse)
+	}
+}
+
+func TestGenerate_Options(t *testing.T) {
+	doc, err := Generate(loadTools(t, "stats"),
+		WithInfo("Catalog", "2.0.0"),
+		WithDescription("Catalog tools"),
+		WithPathFunc(func(name string) string { return "/tools/" + name }),
+	)
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	if doc.Info != (Info{Title: "Catalog", Version: "2.0.0", Description: "Catalog tools"}) {
+		t.Errorf("Unexpected info: %+v", doc.Info)
+	}
+	if _, ok := doc.Paths["/tools/stats"]; !ok {
+		t.Errorf("Expected the custom path to be used, got %v", doc.Paths)
+	}
+	if doc.Servers != nil || doc.Components != nil {
+		t.Errorf("Expected no servers or components, got %+v, %+v", doc.Servers, doc.Components)
+	}
+}
+
+func TestGenerate_Errors(t *testing.T) {
+	tools := loadTools(t, "search", "stats")
+
+	testCases := []struct {
+		name  string
+		tools []*core.ToolboxTool
+		opts  []Option
+		want  string
+	}{
+		{name: "NilTool", tools: []*core.ToolboxTool{nil}, want: "Generate: tool 0 is nil"},
+		{name: "DuplicateTool", tools: []*core.ToolboxTool{tools[0], tools[0]}, want: "Generate: duplicate tool name 'search'"},
+		{name: "NilOption", opts: []Option{nil}, want: "Generate: received a nil Option"},
+		{name: "RelativeServerURL", opts: []Option{WithServerURL("/api")}, want: "WithServerURL: '/api' is not an absolute URL"},
+		{name: "DuplicateInfo", opts: []Option{WithInfo("a", "1"), WithInfo("b", "2")}, want: "info is already set and cannot be overridden"},
+		{name: "NilPathFunc", opts: []Option{WithPathFunc(nil)}, want: "WithPathFunc: provided function cannot be nil"},
+		{
+			name:  "SharedPath",
+			tools: tools,
+			opts:  []Option{WithPathFunc(func(string) string { return "/invoke" })},
+			want:  "Generate: tools share the path '/invoke'",
+		},
+		{
+			name:  "RelativePath",
+			tools: tools[:1],
+			opts:  []Option{WithPathFunc(func(name string) string { return name })},
+			want:  "does not start with '/'",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := Generate(tc.tools, tc.opts...)
+			if err == nil || !strings.Contains(err.Error(), tc.want) {
+				t.Errorf("Expected an error containing %q, got %v", tc.want, err)
+			}
+		})
+	}
+}
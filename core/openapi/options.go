@@ -0,0 +1,112 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// Option configures the document rendered by Generate.
+type Option func(*config) error
+
+// config holds the settings of a document.
+type config struct {
+	title       string
+	version     string
+	description string
+	serverURLs  []string
+	pathFunc    func(toolName string) string
+}
+
+// newConfig applies opts to the default settings of a document.
+func newConfig(opts []Option) (*config, error) {
+	cfg := &config{}
+	for _, opt := range opts {
+		if opt == nil {
+			return nil, fmt.Errorf("Generate: received a nil Option")
+		}
+		if err := opt(cfg); err != nil {
+			return nil, err
+		}
+	}
+	if cfg.title == "" {
+		cfg.title, cfg.version = "Toolbox Tools", "1.0.0"
+	}
+	if cfg.pathFunc == nil {
+		cfg.pathFunc = defaultPath
+	}
+	return cfg, nil
+}
+
+// WithInfo sets the title and version of the API, which default to
+// "Toolbox Tools" and "1.0.0".
+func WithInfo(title, version string) Option {
+	return func(c *config) error {
+		if title == "" || version == "" {
+			return fmt.Errorf("WithInfo: title and version cannot be empty")
+		}
+		if c.title != "" {
+			return fmt.Errorf("info is already set and cannot be overridden")
+		}
+		c.title, c.version = title, version
+		return nil
+	}
+}
+
+// WithDescription sets the description of the API.
+func WithDescription(description string) Option {
+	return func(c *config) error {
+		if description == "" {
+			return fmt.Errorf("WithDescription: description cannot be empty")
+		}
+		if c.description != "" {
+			return fmt.Errorf("description is already set and cannot be overridden")
+		}
+		c.description = description
+		return nil
+	}
+}
+
+// WithServerURL adds a base URL the API is served at, such as the URL of the
+// Toolbox server. It can be given more than once; GPT Actions require exactly
+// one absolute URL.
+func WithServerURL(serverURL string) Option {
+	return func(c *config) error {
+		u, err := url.Parse(serverURL)
+		if err != nil || u.Scheme == "" || u.Host == "" {
+			return fmt.Errorf("WithServerURL: '%s' is not an absolute URL", serverURL)
+		}
+		c.serverURLs = append(c.serverURLs, strings.TrimRight(serverURL, "/"))
+		return nil
+	}
+}
+
+// WithPathFunc sets the function returning the path each tool is invoked at,
+// for APIs other than the one of the Toolbox server, such as a gateway
+// forwarding /tools/{name} to the tools.
+func WithPathFunc(pathFunc func(toolName string) string) Option {
+	return func(c *config) error {
+		if pathFunc == nil {
+			return fmt.Errorf("WithPathFunc: provided function cannot be nil")
+		}
+		if c.pathFunc != nil {
+			return fmt.Errorf("path function is already set and cannot be overridden")
+		}
+		c.pathFunc = pathFunc
+		return nil
+	}
+}
@@ -15,9 +15,20 @@
 package core
 
 import (
+	"context"
 	"fmt"
+	"io"
+	"log/slog"
+	"maps"
 	"net/http"
+	"net/url"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
 
+	"github.com/googleapis/mcp-toolbox-sdk-go/core/transport"
 	"golang.org/x/oauth2"
 )
 
@@ -31,6 +42,8 @@ func newToolConfig() *ToolConfig {
 	return &ToolConfig{
 		AuthTokenSources: make(map[string]oauth2.TokenSource),
 		BoundParams:      make(map[string]any),
+		UnboundParams:    make(map[string]struct{}),
+		ReboundParams:    make(map[string]any),
 	}
 }
 
@@ -52,6 +65,40 @@ func WithClientVersion(version string) ClientOption {
 	}
 }
 
+// WithClientTitle sets the human-readable client title, such as the display
+// name of the calling application, declared in the MCP protocol handshake
+// from protocol version 2025-06-18 on. Only the built-in transports declare
+// the title.
+func WithClientTitle(title string) ClientOption {
+	return func(tc *ToolboxClient) error {
+		tc.clientTitle = title
+		return nil
+	}
+}
+
+// WithClientCapability declares an additional client capability, such as
+// "experimental", in the MCP protocol handshake. Capabilities of features
+// configured with other options, such as sampling, are declared
+// automatically.
+func WithClientCapability(name string, value map[string]any) ClientOption {
+	return func(tc *ToolboxClient) error {
+		if name == "" {
+			return fmt.Errorf("WithClientCapability: capability name cannot be empty")
+		}
+		if tc.clientCapabilities == nil {
+			tc.clientCapabilities = make(map[string]map[string]any)
+		}
+		if _, ok := tc.clientCapabilities[name]; ok {
+			return fmt.Errorf("client capability '%s' is already set and cannot be overridden", name)
+		}
+		if value == nil {
+			value = map[string]any{}
+		}
+		tc.clientCapabilities[name] = value
+		return nil
+	}
+}
+
 // WithProtocol provides a the underlying transport protocol to the ToolboxClient..
 func WithProtocol(p Protocol) ClientOption {
 	return func(tc *ToolboxClient) error {
@@ -64,6 +111,23 @@ func WithProtocol(p Protocol) ClientOption {
 	}
 }
 
+// WithCustomTransport makes the client use the given transport instead of one
+// selected by protocol, so that proprietary protocols can reuse the client's
+// tool loading, auth and parameter binding. It cannot be combined with
+// WithProtocol.
+func WithCustomTransport(t transport.Transport) ClientOption {
+	return func(tc *ToolboxClient) error {
+		if t == nil {
+			return fmt.Errorf("WithCustomTransport: provided transport cannot be nil")
+		}
+		if tc.customTransport != nil {
+			return fmt.Errorf("custom transport is already set and cannot be overridden")
+		}
+		tc.customTransport = t
+		return nil
+	}
+}
+
 // WithHTTPClient provides a custom http.Client to the ToolboxClient.
 func WithHTTPClient(client *http.Client) ClientOption {
 	return func(tc *ToolboxClient) error {
@@ -88,6 +152,9 @@ func WithClientHeaderString(headerName string, value string) ClientOption {
 }
 
 // WithClientHeaderTokenSource adds a dynamic client-wide HTTP header from a TokenSource.
+// The token is cached and the source is only called again once the token is
+// about to expire; tokens without an expiry, such as those of
+// NewCustomTokenSource, are fetched again for every request.
 func WithClientHeaderTokenSource(headerName string, value oauth2.TokenSource) ClientOption {
 	return func(tc *ToolboxClient) error {
 		if _, exists := tc.clientHeaderSources[headerName]; exists {
@@ -101,6 +168,70 @@ func WithClientHeaderTokenSource(headerName string, value oauth2.TokenSource) Cl
 	}
 }
 
+// WithClientHeaderFunc adds a client-wide HTTP header whose value fn computes
+// for every request, from the context of the request. Unlike token sources,
+// values are not cached, so fn can derive them from request-scoped values
+// such as the end user, tenant, or trace ID. Manifests are loaded with the
+// context passed to LoadTool or LoadToolset, and tools are invoked with the
+// context passed to Invoke. If fn fails, so does the request.
+func WithClientHeaderFunc(headerName string, fn func(ctx context.Context) (string, error)) ClientOption {
+	return func(tc *ToolboxClient) error {
+		if _, exists := tc.clientHeaderSources[headerName]; exists {
+			return fmt.Errorf("client header '%s' is already set and cannot be overridden", headerName)
+		}
+		if fn == nil {
+			return fmt.Errorf("WithClientHeaderFunc: provided function for header '%s' cannot be nil", headerName)
+		}
+		tc.clientHeaderSources[headerName] = headerFunc(fn)
+		return nil
+	}
+}
+
+// WithTokenRefreshSkew sets how long before its expiry a cached client header
+// token is refreshed. By default tokens are refreshed 10 seconds early.
+func WithTokenRefreshSkew(skew time.Duration) ClientOption {
+	return func(tc *ToolboxClient) error {
+		if tc.tokenRefreshSkewSet {
+			return fmt.Errorf("token refresh skew is already set and cannot be overridden")
+		}
+		if skew < 0 {
+			return fmt.Errorf("WithTokenRefreshSkew: skew cannot be negative, got %s", skew)
+		}
+		tc.tokenRefreshSkew = skew
+		tc.tokenRefreshSkewSet = true
+		return nil
+	}
+}
+
+// WithAutoIDToken enables automatic Google ID token authentication. When the
+// server rejects a request with 401 Unauthorized or 403 Forbidden, the client
+// fetches an ID token for the server URL using Application Default Credentials
+// and retries the request with it. Later requests send the token upfront.
+// Requests that already carry an Authorization header are left unchanged. It
+// cannot be combined with the WebSocket transport.
+func WithAutoIDToken() ClientOption {
+	return func(tc *ToolboxClient) error {
+		if tc.autoIDToken {
+			return fmt.Errorf("automatic ID token authentication is already set and cannot be overridden")
+		}
+		tc.autoIDToken = true
+		return nil
+	}
+}
+
+// WithClientMaxResponseBytes limits the size of every response body read by
+// the client, including tool manifests and invocation results. Tools can
+// override the limit with WithMaxResponseBytes.
+func WithClientMaxResponseBytes(limit int64) ClientOption {
+	return func(tc *ToolboxClient) error {
+		if limit <= 0 {
+			return fmt.Errorf("WithClientMaxResponseBytes: limit must be positive, got %d", limit)
+		}
+		tc.maxResponseBytes = limit
+		return nil
+	}
+}
+
 // WithDefaultToolOptions provides default Options that will be applied to every tool
 // loaded by this client.
 func WithDefaultToolOptions(opts ...ToolOption) ClientOption {
@@ -114,19 +245,548 @@ func WithDefaultToolOptions(opts ...ToolOption) ClientOption {
 	}
 }
 
+// WithManifestCache makes the client load tool and toolset manifests
+// through cache. Share one cache between clients to avoid fetching identical
+// manifests more than once.
+func WithManifestCache(cache *ManifestCache) ClientOption {
+	return func(tc *ToolboxClient) error {
+		if cache == nil {
+			return fmt.Errorf("WithManifestCache: provided cache cannot be nil")
+		}
+		if tc.manifestCache != nil {
+			return fmt.Errorf("manifest cache is already set and cannot be overridden")
+		}
+		tc.manifestCache = cache
+		return nil
+	}
+}
+
+// WithQueryParams adds query parameters to the requests that load tool and
+// toolset manifests, such as feature flags or API versions understood by the
+// server. Use WithManifestQueryParams to add parameters to a single load.
+func WithQueryParams(params url.Values) ClientOption {
+	return func(tc *ToolboxClient) error {
+		if len(params) == 0 {
+			return fmt.Errorf("WithQueryParams: query parameters cannot be empty")
+		}
+		if tc.queryParams == nil {
+			tc.queryParams = make(url.Values, len(params))
+		}
+		for key, values := range params {
+			if _, exists := tc.queryParams[key]; exists {
+				return fmt.Errorf("query parameter '%s' is already set and cannot be overridden", key)
+			}
+			tc.queryParams[key] = slices.Clone(values)
+		}
+		return nil
+	}
+}
+
+// WithWarningHandler sets the function called with the warnings the server
+// issues while tools are loaded, such as the deprecation of a tool or a
+// toolset. It is called on the goroutine loading the tools, each time they
+// are loaded. Without a handler, warnings are logged.
+func WithWarningHandler(handler WarningHandler) ClientOption {
+	return func(tc *ToolboxClient) error {
+		if handler == nil {
+			return fmt.Errorf("WithWarningHandler: provided handler cannot be nil")
+		}
+		if tc.warningHandler != nil {
+			return fmt.Errorf("warning handler is already set and cannot be overridden")
+		}
+		tc.warningHandler = handler
+		return nil
+	}
+}
+
+// WithConflictPolicy sets how LoadToolsets combines different tools with the
+// same name from different toolsets. The default is ConflictError. A tool
+// that belongs to several toolsets is not a conflict.
+func WithConflictPolicy(policy ConflictPolicy) ClientOption {
+	return func(tc *ToolboxClient) error {
+		if !policy.valid() {
+			return fmt.Errorf("WithConflictPolicy: unknown conflict policy '%s'", policy)
+		}
+		if tc.conflictPolicy != "" {
+			return fmt.Errorf("conflict policy is already set and cannot be overridden")
+		}
+		tc.conflictPolicy = policy
+		return nil
+	}
+}
+
+// WithToolsChangedHandler registers a callback that runs when the server
+// announces, with notifications/tools/list_changed, that its tools changed.
+// Tools loaded earlier may be outdated at that point and can be reloaded.
+// Notifications are only received over transports that keep a connection
+// open, such as the SSE, WebSocket and stdio transports. The option may be
+// given more than once.
+func WithToolsChangedHandler(handler func()) ClientOption {
+	return func(tc *ToolboxClient) error {
+		if handler == nil {
+			return fmt.Errorf("WithToolsChangedHandler: provided handler cannot be nil")
+		}
+		tc.toolsChangedHandlers = append(tc.toolsChangedHandlers, handler)
+		return nil
+	}
+}
+
+// WithSamplingHandler lets the server sample an LLM through the client, by
+// answering sampling/createMessage requests with handler. The client then
+// declares the sampling capability in the handshake. Sampling requests are
+// only received over transports that can carry server requests.
+func WithSamplingHandler(handler SamplingHandler) ClientOption {
+	return func(tc *ToolboxClient) error {
+		if handler == nil {
+			return fmt.Errorf("WithSamplingHandler: provided handler cannot be nil")
+		}
+		if tc.samplingHandler != nil {
+			return fmt.Errorf("sampling handler is already set and cannot be overridden")
+		}
+		tc.samplingHandler = handler
+		return nil
+	}
+}
+
+// WithElicitationHandler lets the server ask the user for structured input
+// through the client, by answering elicitation/create requests with handler.
+// The client then declares the elicitation capability in the handshake.
+// Servers only send elicitation requests from protocol version 2025-06-18
+// on, and only over transports that can carry server requests.
+func WithElicitationHandler(handler ElicitationHandler) ClientOption {
+	return func(tc *ToolboxClient) error {
+		if handler == nil {
+			return fmt.Errorf("WithElicitationHandler: provided handler cannot be nil")
+		}
+		if tc.elicitationHandler != nil {
+			return fmt.Errorf("elicitation handler is already set and cannot be overridden")
+		}
+		tc.elicitationHandler = handler
+		return nil
+	}
+}
+
+// WithRoots declares the given roots, such as workspace directories, in the
+// handshake and answers roots/list requests with them, for servers that
+// scope their behavior to the client's roots. Roots are only listed over
+// transports that can carry server requests.
+func WithRoots(roots ...Root) ClientOption {
+	return func(tc *ToolboxClient) error {
+		if len(roots) == 0 {
+			return fmt.Errorf("WithRoots: at least one root must be provided")
+		}
+		if tc.roots != nil {
+			return fmt.Errorf("roots are already set and cannot be overridden")
+		}
+		for _, root := range roots {
+			if !strings.HasPrefix(root.URI, "file://") {
+				return fmt.Errorf("WithRoots: root URI '%s' must start with file://", root.URI)
+			}
+		}
+		tc.roots = slices.Clone(roots)
+		return nil
+	}
+}
+
+// WithKeepAlive makes the client ping the server every interval over
+// transports that keep a connection open, such as the SSE and WebSocket
+// transports, and reconnect after maxFailures consecutive pings fail or go
+// unanswered. Pings from the server are always answered.
+func WithKeepAlive(interval time.Duration, maxFailures int) ClientOption {
+	return func(tc *ToolboxClient) error {
+		if interval <= 0 {
+			return fmt.Errorf("WithKeepAlive: interval must be positive, got %s", interval)
+		}
+		if maxFailures < 1 {
+			return fmt.Errorf("WithKeepAlive: maxFailures must be at least 1, got %d", maxFailures)
+		}
+		if tc.keepAliveInterval > 0 {
+			return fmt.Errorf("keep-alive is already set and cannot be overridden")
+		}
+		tc.keepAliveInterval = interval
+		tc.keepAliveFailures = maxFailures
+		return nil
+	}
+}
+
+// WithTransportRetry makes the MCP transports resend JSON-RPC requests that
+// fail with a connection error or with a 429 (Too Many Requests) or 503
+// (Service Unavailable) response, up to maxAttempts attempts in total. The
+// delay between attempts starts at baseDelay and doubles for each retry,
+// randomized by up to half its length, unless the server requests a delay
+// with a Retry-After header or a retryAfter field in its error body. No delay
+// exceeds maxDelay, if positive: requests for which the server asks for a
+// longer delay are not retried. Requests still rate limited after the last
+// attempt, or not retried for that reason, fail with an error matching
+// ErrRateLimited, whose ToolInvocationError carries the delay requested by
+// the server.
+//
+// These retries happen below the tool level, so they apply to every request,
+// including listing tools and the initialization handshake, and are
+// independent of any retries of tool invocations. Because a failed tool call
+// may still have run on the server, calls to tools not marked read-only or
+// idempotent are only resent when they were rejected with a 429 or when the
+// connection to the server could not be established. The stdio and WebSocket
+// transports, which do not send a request per message, are not affected.
+func WithTransportRetry(maxAttempts int, baseDelay, maxDelay time.Duration) ClientOption {
+	return func(tc *ToolboxClient) error {
+		if maxAttempts < 2 {
+			return fmt.Errorf("WithTransportRetry: maxAttempts must be at least 2, got %d", maxAttempts)
+		}
+		if baseDelay < 0 || maxDelay < 0 {
+			return fmt.Errorf("WithTransportRetry: delays cannot be negative")
+		}
+		if tc.transportRetry != nil {
+			return fmt.Errorf("transport retry is already set and cannot be overridden")
+		}
+		tc.transportRetry = &transport.RetryPolicy{MaxAttempts: maxAttempts, BaseDelay: baseDelay, MaxDelay: maxDelay}
+		return nil
+	}
+}
+
+// WithTransportObserver reports the start and end of every JSON-RPC request
+// the transport sends, including the initialization handshake, to observer,
+// with the method, duration and error of each request. This covers requests
+// made for any purpose, such as listing tools, invoking them or reading
+// resources, across all transports. The name of the tool or toolset a request
+// is made for is available from its context with transport.ToolName and
+// transport.ToolsetName, and retries are reported to observers implementing
+// transport.RetryObserver.
+func WithTransportObserver(observer TransportObserver) ClientOption {
+	return func(tc *ToolboxClient) error {
+		if observer == nil {
+			return fmt.Errorf("WithTransportObserver: provided observer cannot be nil")
+		}
+		if tc.transportObserver != nil {
+			return fmt.Errorf("transport observer is already set and cannot be overridden")
+		}
+		tc.transportObserver = observer
+		return nil
+	}
+}
+
+// WithRequestTimeout bounds every JSON-RPC request the transport sends, such
+// as a tools/call, by timeout, so that a stuck request fails even if the
+// caller's context has no deadline. An earlier deadline of the caller's
+// context still applies. Each request is bounded separately, including the
+// initialization handshake, and a request that times out is reported to the
+// server as cancelled.
+func WithRequestTimeout(timeout time.Duration) ClientOption {
+	return func(tc *ToolboxClient) error {
+		if timeout <= 0 {
+			return fmt.Errorf("WithRequestTimeout: timeout must be positive, got %s", timeout)
+		}
+		if tc.requestTimeout > 0 {
+			return fmt.Errorf("request timeout is already set and cannot be overridden")
+		}
+		tc.requestTimeout = timeout
+		return nil
+	}
+}
+
+// WithServerLogHandler registers a callback for the log messages the server
+// sends with notifications/message, which are otherwise dropped.
+func WithServerLogHandler(handler func(LogMessage)) ClientOption {
+	return func(tc *ToolboxClient) error {
+		if handler == nil {
+			return fmt.Errorf("WithServerLogHandler: provided handler cannot be nil")
+		}
+		if tc.logHandler != nil {
+			return fmt.Errorf("server log handler is already set and cannot be overridden")
+		}
+		tc.logHandler = handler
+		return nil
+	}
+}
+
+// WithServerLogger writes the log messages the server sends with
+// notifications/message to logger, mapping their severity with
+// LogMessage.SlogLevel.
+func WithServerLogger(logger *slog.Logger) ClientOption {
+	return func(tc *ToolboxClient) error {
+		if logger == nil {
+			return fmt.Errorf("WithServerLogger: provided logger cannot be nil")
+		}
+		if tc.serverLogger != nil {
+			return fmt.Errorf("server logger is already set and cannot be overridden")
+		}
+		tc.serverLogger = logger
+		return nil
+	}
+}
+
+// WithDebugDump writes the HTTP requests the client sends, such as tool loads
+// and invocations, and the responses it receives to w, with their headers and
+// bodies, to help diagnose problems with a server. The values of the
+// Authorization, Cookie and <service>_token headers are redacted, and bodies
+// are truncated to 64 KiB unless WithDebugDumpBodyLimit is used. Dumps may
+// still contain sensitive tool inputs and outputs, so they should only be
+// enabled while debugging. It only applies to HTTP-based transports and cannot
+// be combined with WithCustomTransport or the WebSocket transport.
+func WithDebugDump(w io.Writer) ClientOption {
+	return func(tc *ToolboxClient) error {
+		if w == nil {
+			return fmt.Errorf("WithDebugDump: provided writer cannot be nil")
+		}
+		if tc.debugDump != nil {
+			return fmt.Errorf("debug dump is already set and cannot be overridden")
+		}
+		tc.debugDump = w
+		return nil
+	}
+}
+
+// WithDebugDumpBodyLimit sets the number of bytes of each request and response
+// body written by WithDebugDump. The rest of the body is replaced by a note of
+// its size.
+func WithDebugDumpBodyLimit(limit int) ClientOption {
+	return func(tc *ToolboxClient) error {
+		if limit <= 0 {
+			return fmt.Errorf("WithDebugDumpBodyLimit: limit must be positive, got %d", limit)
+		}
+		if tc.debugDumpBodyLimit != 0 {
+			return fmt.Errorf("debug dump body limit is already set and cannot be overridden")
+		}
+		tc.debugDumpBodyLimit = limit
+		return nil
+	}
+}
+
+// ----- Invoke Options -----
+
+// ResultFormat controls how the result of an invocation is returned.
+type ResultFormat string
+
+const (
+	// ResultFormatText returns the result as produced by the server, which is
+	// typically a string, or a map[string]any for MCP tools that return
+	// structured content. This is the default.
+	ResultFormatText ResultFormat = "text"
+	// ResultFormatJSON decodes a string result as JSON before returning it.
+	ResultFormatJSON ResultFormat = "json"
+)
+
+// InvokeConfig holds the per-call settings for a single tool invocation.
+type InvokeConfig struct {
+	Timeout          time.Duration
+	Headers          map[string]string
+	IdempotencyKey   string
+	ResultFormat     ResultFormat
+	AuthTokenSources map[string]oauth2.TokenSource
+	OnNotification   NotificationHandler
+	OnProgress       ProgressHandler
+	// Meta is attached to the _meta of the tools/call request.
+	Meta map[string]any
+	// ResolveResourceLinks replaces the resource links in the result with
+	// the contents of the linked resources.
+	ResolveResourceLinks bool
+	// Principal identifies the caller in audit records.
+	Principal string
+}
+
+// InvokeOption configures a single call to Invoke.
+type InvokeOption func(*InvokeConfig) error
+
+// newInvokeConfig initializes an InvokeConfig with its default values.
+func newInvokeConfig() *InvokeConfig {
+	return &InvokeConfig{
+		Headers:          make(map[string]string),
+		ResultFormat:     ResultFormatText,
+		AuthTokenSources: make(map[string]oauth2.TokenSource),
+	}
+}
+
+// WithInvokeTimeout bounds the duration of a single invocation.
+func WithInvokeTimeout(timeout time.Duration) InvokeOption {
+	return func(c *InvokeConfig) error {
+		if timeout <= 0 {
+			return fmt.Errorf("WithInvokeTimeout: timeout must be positive, got %s", timeout)
+		}
+		c.Timeout = timeout
+		return nil
+	}
+}
+
+// WithInvokeHeader adds an HTTP header to a single invocation. It takes
+// precedence over client and auth headers with the same name.
+func WithInvokeHeader(headerName string, value string) InvokeOption {
+	return func(c *InvokeConfig) error {
+		if _, exists := c.Headers[headerName]; exists {
+			return fmt.Errorf("invoke header '%s' is already set and cannot be overridden", headerName)
+		}
+		c.Headers[headerName] = value
+		return nil
+	}
+}
+
+// WithInvokeMeta attaches a key to the _meta of the MCP tools/call request
+// of a single invocation, such as tracing baggage or tenant hints. A
+// "progressToken" key is replaced by the token of WithProgressHandler, if
+// used.
+func WithInvokeMeta(key string, value any) InvokeOption {
+	return func(c *InvokeConfig) error {
+		if key == "" {
+			return fmt.Errorf("WithInvokeMeta: key cannot be empty")
+		}
+		if _, exists := c.Meta[key]; exists {
+			return fmt.Errorf("invoke meta '%s' is already set and cannot be overridden", key)
+		}
+		if c.Meta == nil {
+			c.Meta = make(map[string]any)
+		}
+		c.Meta[key] = value
+		return nil
+	}
+}
+
+// WithIdempotencyKey sends the given key in the Idempotency-Key header so the
+// server can safely deduplicate retried invocations.
+func WithIdempotencyKey(key string) InvokeOption {
+	return func(c *InvokeConfig) error {
+		if key == "" {
+			return fmt.Errorf("WithIdempotencyKey: key cannot be empty")
+		}
+		c.IdempotencyKey = key
+		return nil
+	}
+}
+
+// WithInvokePrincipal identifies the caller on whose behalf the tool is
+// invoked, such as an end user, in the records passed to the loggers
+// registered with WithAuditLogger. It is not sent to the server.
+func WithInvokePrincipal(principal string) InvokeOption {
+	return func(c *InvokeConfig) error {
+		if principal == "" {
+			return fmt.Errorf("WithInvokePrincipal: principal cannot be empty")
+		}
+		if c.Principal != "" {
+			return fmt.Errorf("principal is already set and cannot be overridden")
+		}
+		c.Principal = principal
+		return nil
+	}
+}
+
+// WithResultFormat selects how the invocation result is returned.
+func WithResultFormat(format ResultFormat) InvokeOption {
+	return func(c *InvokeConfig) error {
+		switch format {
+		case ResultFormatText, ResultFormatJSON:
+			c.ResultFormat = format
+			return nil
+		default:
+			return fmt.Errorf("WithResultFormat: unsupported result format '%s'", format)
+		}
+	}
+}
+
+// WithInvokeAuthTokenSource provides an authentication token source for a
+// single invocation, overriding any source of the same name on the tool.
+func WithInvokeAuthTokenSource(authSourceName string, idToken oauth2.TokenSource) InvokeOption {
+	return func(c *InvokeConfig) error {
+		if idToken == nil {
+			return fmt.Errorf("WithInvokeAuthTokenSource: provided oauth2.TokenSource for '%s' cannot be nil", authSourceName)
+		}
+		if _, exists := c.AuthTokenSources[authSourceName]; exists {
+			return fmt.Errorf("authentication source '%s' is already set and cannot be overridden", authSourceName)
+		}
+		c.AuthTokenSources[authSourceName] = idToken
+		return nil
+	}
+}
+
+// WithInvokeAuthTokenString provides a static authentication token for a
+// single invocation, overriding any source of the same name on the tool.
+func WithInvokeAuthTokenString(authSourceName string, idToken string) InvokeOption {
+	return WithInvokeAuthTokenSource(authSourceName, oauth2.StaticTokenSource(&oauth2.Token{AccessToken: idToken}))
+}
+
+// WithNotificationHandler receives the notifications, such as progress
+// updates, that the server streams while the invocation is in progress.
+func WithNotificationHandler(handler NotificationHandler) InvokeOption {
+	return func(c *InvokeConfig) error {
+		if handler == nil {
+			return fmt.Errorf("WithNotificationHandler: provided handler cannot be nil")
+		}
+		if c.OnNotification != nil {
+			return fmt.Errorf("notification handler is already set and cannot be overridden")
+		}
+		c.OnNotification = handler
+		return nil
+	}
+}
+
+// WithProgressHandler asks the server to report the progress of the
+// invocation and passes each update to handler. Servers that do not support
+// progress reporting ignore the request.
+func WithProgressHandler(handler ProgressHandler) InvokeOption {
+	return func(c *InvokeConfig) error {
+		if handler == nil {
+			return fmt.Errorf("WithProgressHandler: provided handler cannot be nil")
+		}
+		if c.OnProgress != nil {
+			return fmt.Errorf("progress handler is already set and cannot be overridden")
+		}
+		c.OnProgress = handler
+		return nil
+	}
+}
+
+// WithResolveResourceLinks follows the resource links in the result of an MCP
+// tool, replacing each with the embedded contents of the linked resource as
+// read from the server.
+func WithResolveResourceLinks() InvokeOption {
+	return func(c *InvokeConfig) error {
+		c.ResolveResourceLinks = true
+		return nil
+	}
+}
+
 // ----- Tool Options -----
 
 // ToolConfig holds all configurable aspects for creating or deriving a tool.
 type ToolConfig struct {
 	AuthTokenSources map[string]oauth2.TokenSource
 	BoundParams      map[string]any
+	UnboundParams    map[string]struct{}
+	ReboundParams    map[string]any
+	TagFilter        []string
+	IncludeTools     []string
+	ExcludeTools     []string
+	ToolNameRegexp   *regexp.Regexp
+	ToolNamePrefix   string
+	QueryParams      url.Values
+	BeforeInvoke     []BeforeInvokeHook
+	AfterInvoke      []AfterInvokeHook
+	AuditLoggers     []AuditLogger
+	MaxResponseBytes int64
+	ResultCacheTTL   time.Duration
+	ResultCacheSize  int
+	MaxConcurrent    int
+	ValidateTokens   bool
+	TokenAudience    string
+	TypeCoercion     bool
+	AllowEmpty       bool
+	AllowUnused      bool
 	Strict           bool
 	strictSet        bool
+	allowUnusedSet   bool
+	tagFilterSet     bool
+	toolFilterSet    bool
 }
 
 // ToolOption defines a single, universal type for a functional option that configures a tool.
 type ToolOption func(*ToolConfig) error
 
+// BeforeInvokeHook is called with the validated payload, including bound
+// parameters, before a tool is invoked. The returned map is sent to the server
+// in place of the original payload.
+type BeforeInvokeHook func(ctx context.Context, toolName string, payload map[string]any) (map[string]any, error)
+
+// AfterInvokeHook is called with the result of a successful invocation. The
+// returned value is handed to the caller in place of the original result.
+type AfterInvokeHook func(ctx context.Context, toolName string, result any) (any, error)
+
 type Integer interface {
 	~int | ~int8 | ~int16 | ~int32 | ~int64 |
 		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64
@@ -148,6 +808,333 @@ func WithStrict(strict bool) ToolOption {
 	}
 }
 
+// WithOverride applies opts on top of the options set before it, such as the
+// client's default tool options, replacing the bound parameters, auth token
+// sources and settings they configure instead of failing as duplicates.
+// Hooks and audit loggers are added to the existing ones.
+//
+//	client, _ := core.NewToolboxClient(url,
+//		core.WithDefaultToolOptions(core.WithBindParamString("region", "eu")),
+//	)
+//	tool, _ := client.LoadTool("search", ctx,
+//		core.WithOverride(core.WithBindParamString("region", "us")),
+//	)
+func WithOverride(opts ...ToolOption) ToolOption {
+	return func(c *ToolConfig) error {
+		override := newToolConfig()
+		for _, opt := range opts {
+			if opt == nil {
+				return fmt.Errorf("WithOverride: received a nil ToolOption in options list")
+			}
+			if err := opt(override); err != nil {
+				return err
+			}
+		}
+		c.override(override)
+		return nil
+	}
+}
+
+// override copies everything that is set in o to c. It must handle every
+// field of ToolConfig.
+func (c *ToolConfig) override(o *ToolConfig) {
+	maps.Copy(c.AuthTokenSources, o.AuthTokenSources)
+	maps.Copy(c.BoundParams, o.BoundParams)
+	maps.Copy(c.UnboundParams, o.UnboundParams)
+	maps.Copy(c.ReboundParams, o.ReboundParams)
+	c.BeforeInvoke = append(c.BeforeInvoke, o.BeforeInvoke...)
+	c.AfterInvoke = append(c.AfterInvoke, o.AfterInvoke...)
+	c.AuditLoggers = append(c.AuditLoggers, o.AuditLoggers...)
+	if o.tagFilterSet {
+		c.TagFilter, c.tagFilterSet = o.TagFilter, true
+	}
+	if o.toolFilterSet {
+		c.IncludeTools, c.ExcludeTools, c.toolFilterSet = o.IncludeTools, o.ExcludeTools, true
+	}
+	if o.ToolNameRegexp != nil {
+		c.ToolNameRegexp = o.ToolNameRegexp
+	}
+	if o.ToolNamePrefix != "" {
+		c.ToolNamePrefix = o.ToolNamePrefix
+	}
+	if len(o.QueryParams) > 0 {
+		if c.QueryParams == nil {
+			c.QueryParams = make(url.Values, len(o.QueryParams))
+		}
+		maps.Copy(c.QueryParams, o.QueryParams)
+	}
+	if o.MaxResponseBytes != 0 {
+		c.MaxResponseBytes = o.MaxResponseBytes
+	}
+	if o.ResultCacheTTL != 0 {
+		c.ResultCacheTTL, c.ResultCacheSize = o.ResultCacheTTL, o.ResultCacheSize
+	}
+	if o.MaxConcurrent != 0 {
+		c.MaxConcurrent = o.MaxConcurrent
+	}
+	if o.ValidateTokens {
+		c.ValidateTokens, c.TokenAudience = true, o.TokenAudience
+	}
+	if o.TypeCoercion {
+		c.TypeCoercion = true
+	}
+	if o.AllowEmpty {
+		c.AllowEmpty = true
+	}
+	if o.allowUnusedSet {
+		c.AllowUnused, c.allowUnusedSet = o.AllowUnused, true
+	}
+	if o.strictSet {
+		c.Strict, c.strictSet = o.Strict, true
+	}
+}
+
+// WithAllowUnusedBindings makes LoadTool and LoadToolset log a warning,
+// rather than fail, when a bound parameter or auth token is not used by any
+// tool. This allows sharing a common set of options, for example with
+// WithDefaultToolOptions, across toolsets with different parameters.
+func WithAllowUnusedBindings(allow bool) ToolOption {
+	return func(c *ToolConfig) error {
+		if c.allowUnusedSet {
+			return fmt.Errorf("allow unused bindings is already set and cannot be overridden")
+		}
+		c.AllowUnused = allow
+		c.allowUnusedSet = true
+		return nil
+	}
+}
+
+// WithTagFilter restricts LoadToolset to tools carrying at least one of the
+// given tags.
+func WithTagFilter(tags ...string) ToolOption {
+	return func(c *ToolConfig) error {
+		if c.tagFilterSet {
+			return fmt.Errorf("tag filter is already set and cannot be overridden")
+		}
+		if len(tags) == 0 {
+			return fmt.Errorf("WithTagFilter: at least one tag must be provided")
+		}
+		c.TagFilter = tags
+		c.tagFilterSet = true
+		return nil
+	}
+}
+
+// WithAllowEmptyToolset lets LoadToolset return an empty slice for a toolset
+// without tools. By default, an empty toolset is reported as an error, as it
+// usually points to a misconfigured server.
+func WithAllowEmptyToolset() ToolOption {
+	return func(c *ToolConfig) error {
+		c.AllowEmpty = true
+		return nil
+	}
+}
+
+// WithToolFilter restricts LoadToolset to a subset of the tools in a
+// toolset, before the tools are constructed. If include is not empty, only
+// the matching tools are loaded; tools matching exclude are never loaded.
+// Entries are tool names or glob patterns in the syntax of path.Match, such
+// as "bq-*".
+func WithToolFilter(include []string, exclude []string) ToolOption {
+	return func(c *ToolConfig) error {
+		if c.toolFilterSet {
+			return fmt.Errorf("tool filter is already set and cannot be overridden")
+		}
+		if len(include) == 0 && len(exclude) == 0 {
+			return fmt.Errorf("WithToolFilter: at least one tool to include or exclude must be provided")
+		}
+		for _, pattern := range slices.Concat(include, exclude) {
+			if _, err := path.Match(pattern, ""); err != nil {
+				return fmt.Errorf("WithToolFilter: invalid pattern '%s': %w", pattern, err)
+			}
+		}
+		c.IncludeTools = slices.Clone(include)
+		c.ExcludeTools = slices.Clone(exclude)
+		c.toolFilterSet = true
+		return nil
+	}
+}
+
+// WithToolNameRegexp restricts LoadToolset to the tools whose names match re.
+// It can be combined with WithToolFilter, in which case tools must pass both.
+func WithToolNameRegexp(re *regexp.Regexp) ToolOption {
+	return func(c *ToolConfig) error {
+		if re == nil {
+			return fmt.Errorf("WithToolNameRegexp: provided regexp cannot be nil")
+		}
+		if c.ToolNameRegexp != nil {
+			return fmt.Errorf("tool name regexp is already set and cannot be overridden")
+		}
+		c.ToolNameRegexp = re
+		return nil
+	}
+}
+
+// WithToolNamePrefix prepends prefix to the names of the loaded tools, so
+// that tools from different servers or toolsets can be used side by side.
+// The tools are still invoked under their names on the server, and tool
+// filters match those names too.
+func WithToolNamePrefix(prefix string) ToolOption {
+	return func(c *ToolConfig) error {
+		if prefix == "" {
+			return fmt.Errorf("WithToolNamePrefix: prefix cannot be empty")
+		}
+		if c.ToolNamePrefix != "" {
+			return fmt.Errorf("tool name prefix is already set and cannot be overridden")
+		}
+		c.ToolNamePrefix = prefix
+		return nil
+	}
+}
+
+// WithManifestQueryParams adds query parameters to the requests made by
+// LoadTool or LoadToolset to load manifests. They replace the parameters with
+// the same keys set with WithQueryParams.
+func WithManifestQueryParams(params url.Values) ToolOption {
+	return func(c *ToolConfig) error {
+		if len(params) == 0 {
+			return fmt.Errorf("WithManifestQueryParams: query parameters cannot be empty")
+		}
+		if c.QueryParams == nil {
+			c.QueryParams = make(url.Values, len(params))
+		}
+		for key, values := range params {
+			if _, exists := c.QueryParams[key]; exists {
+				return fmt.Errorf("query parameter '%s' is already set and cannot be overridden", key)
+			}
+			c.QueryParams[key] = slices.Clone(values)
+		}
+		return nil
+	}
+}
+
+// WithBeforeInvoke registers a hook that can inspect or rewrite the payload
+// before every invocation. Multiple hooks run in the order they are provided.
+func WithBeforeInvoke(hook BeforeInvokeHook) ToolOption {
+	return func(c *ToolConfig) error {
+		if hook == nil {
+			return fmt.Errorf("WithBeforeInvoke: provided hook cannot be nil")
+		}
+		c.BeforeInvoke = append(c.BeforeInvoke, hook)
+		return nil
+	}
+}
+
+// WithAfterInvoke registers a hook that can inspect or transform the result of
+// every invocation. Multiple hooks run in the order they are provided.
+func WithAfterInvoke(hook AfterInvokeHook) ToolOption {
+	return func(c *ToolConfig) error {
+		if hook == nil {
+			return fmt.Errorf("WithAfterInvoke: provided hook cannot be nil")
+		}
+		c.AfterInvoke = append(c.AfterInvoke, hook)
+		return nil
+	}
+}
+
+// WithAuditLogger registers a logger that receives an AuditRecord for every
+// invocation of the tool, successful or not. Multiple loggers run in the
+// order they are provided. Use WithDefaultToolOptions to audit all the tools
+// of a client.
+func WithAuditLogger(logger AuditLogger) ToolOption {
+	return func(c *ToolConfig) error {
+		if logger == nil {
+			return fmt.Errorf("WithAuditLogger: provided logger cannot be nil")
+		}
+		c.AuditLoggers = append(c.AuditLoggers, logger)
+		return nil
+	}
+}
+
+// WithMaxResponseBytes limits the size of the response body read when the
+// tool is invoked. Exceeding the limit returns a *ResponseTooLargeError.
+func WithMaxResponseBytes(limit int64) ToolOption {
+	return func(c *ToolConfig) error {
+		if c.MaxResponseBytes != 0 {
+			return fmt.Errorf("maximum response size is already set and cannot be overridden")
+		}
+		if limit <= 0 {
+			return fmt.Errorf("WithMaxResponseBytes: limit must be positive, got %d", limit)
+		}
+		c.MaxResponseBytes = limit
+		return nil
+	}
+}
+
+// WithResultCache memoizes invocation results for up to ttl, keeping at most
+// maxEntries results. Results are keyed by the tool, the canonicalized
+// payload and every resolved request header, including client headers, auth
+// service tokens and per-call headers, so that callers with different
+// credentials or tenants never share results; only the Idempotency-Key header
+// is ignored. Callers receive copies of cached results, which they may modify.
+// Caching only applies to tools the server marks as read-only or idempotent:
+// loading any other tool with this option, including as part of a toolset,
+// fails.
+func WithResultCache(ttl time.Duration, maxEntries int) ToolOption {
+	return func(c *ToolConfig) error {
+		if c.ResultCacheTTL != 0 {
+			return fmt.Errorf("result cache is already set and cannot be overridden")
+		}
+		if ttl <= 0 {
+			return fmt.Errorf("WithResultCache: ttl must be positive, got %s", ttl)
+		}
+		if maxEntries <= 0 {
+			return fmt.Errorf("WithResultCache: maxEntries must be positive, got %d", maxEntries)
+		}
+		c.ResultCacheTTL = ttl
+		c.ResultCacheSize = maxEntries
+		return nil
+	}
+}
+
+// WithMaxConcurrentInvocations limits the number of invocations of the tool
+// that may be in flight at once. Further calls to Invoke wait until a slot is
+// free or their context is done.
+func WithMaxConcurrentInvocations(n int) ToolOption {
+	return func(c *ToolConfig) error {
+		if c.MaxConcurrent != 0 {
+			return fmt.Errorf("maximum concurrent invocations is already set and cannot be overridden")
+		}
+		if n <= 0 {
+			return fmt.Errorf("WithMaxConcurrentInvocations: n must be positive, got %d", n)
+		}
+		c.MaxConcurrent = n
+		return nil
+	}
+}
+
+// WithTokenValidation checks auth tokens before each invocation and fails
+// with a descriptive error if a token has expired. If audience is not empty,
+// tokens that are JWTs must also list it in their "aud" claim. The token
+// signature is not verified; that remains the job of the server.
+func WithTokenValidation(audience string) ToolOption {
+	return func(c *ToolConfig) error {
+		if c.ValidateTokens {
+			return fmt.Errorf("token validation is already set and cannot be overridden")
+		}
+		c.ValidateTokens = true
+		c.TokenAudience = audience
+		return nil
+	}
+}
+
+// WithTypeCoercion converts invocation inputs to the types of their
+// parameters before validation when the two are compatible, as models often
+// return numbers and booleans as strings. For example, "3" becomes an integer
+// for an integer parameter, 2 becomes 2.0 for a float parameter, and 42
+// becomes "42" for a string parameter. Array items and the values of typed
+// objects are converted too. Inputs that cannot be converted still fail
+// validation.
+func WithTypeCoercion() ToolOption {
+	return func(c *ToolConfig) error {
+		if c.TypeCoercion {
+			return fmt.Errorf("type coercion is already set and cannot be overridden")
+		}
+		c.TypeCoercion = true
+		return nil
+	}
+}
+
 // WithAuthTokenSource provides an authentication token from a standard TokenSource.
 func WithAuthTokenSource(authSourceName string, idToken oauth2.TokenSource) ToolOption {
 	return func(c *ToolConfig) error {
@@ -182,6 +1169,31 @@ func createBoundParamToolOption(name string, value any) ToolOption {
 	}
 }
 
+// WithUnbindParam removes an existing parameter binding from a tool derived
+// with ToolFrom, so the parameter must once again be provided at invocation time.
+func WithUnbindParam(name string) ToolOption {
+	return func(c *ToolConfig) error {
+		if _, exists := c.UnboundParams[name]; exists {
+			return fmt.Errorf("duplicate parameter unbinding: parameter '%s' is already unbound", name)
+		}
+		c.UnboundParams[name] = struct{}{}
+		return nil
+	}
+}
+
+// WithRebindParam replaces the value of an existing parameter binding on a tool
+// derived with ToolFrom. The value may be static or one of the supported
+// bound parameter functions.
+func WithRebindParam(name string, value any) ToolOption {
+	return func(c *ToolConfig) error {
+		if _, exists := c.ReboundParams[name]; exists {
+			return fmt.Errorf("duplicate parameter rebinding: parameter '%s' is already rebound", name)
+		}
+		c.ReboundParams[name] = value
+		return nil
+	}
+}
+
 // WithBindParamString binds a static string value to a parameter.
 func WithBindParamString(name string, value string) ToolOption {
 	return createBoundParamToolOption(name, value)
@@ -377,3 +1389,35 @@ func WithBindParamAnyMap(name string, value map[string]any) ToolOption {
 func WithBindParamAnyMapFunc(name string, fn func() (map[string]any, error)) ToolOption {
 	return createBoundParamToolOption(name, fn)
 }
+
+// WithBindParamMap binds a static map with values of any single type to a parameter.
+func WithBindParamMap[T any](name string, value map[string]T) ToolOption {
+	return createBoundParamToolOption(name, value)
+}
+
+// WithBindParamMapFunc binds a function that returns a map with values of any
+// single type to a parameter.
+func WithBindParamMapFunc[T any](name string, fn func() (map[string]T, error)) ToolOption {
+	return createBoundParamToolOption(name, func() (any, error) {
+		v, err := fn()
+		if err != nil {
+			return nil, err
+		}
+		return v, nil
+	})
+}
+
+// --- Generic Bindings ---
+
+// WithBindParamAny binds a static value of any type to a parameter. The value
+// is validated against the parameter's schema at invocation time.
+func WithBindParamAny(name string, value any) ToolOption {
+	return createBoundParamToolOption(name, value)
+}
+
+// WithBindParamAnyFunc binds a function that returns a value of any type to a
+// parameter. The value is validated against the parameter's schema at
+// invocation time.
+func WithBindParamAnyFunc(name string, fn func() (any, error)) ToolOption {
+	return createBoundParamToolOption(name, fn)
+}
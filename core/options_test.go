@@ -17,8 +17,11 @@
 package core
 
 import (
+	"context"
 	"net/http"
+	"net/url"
 	"reflect"
+	"regexp"
 	"strings"
 	"testing"
 	"time"
@@ -86,6 +89,48 @@ func TestWithClientVersion(t *testing.T) {
 	})
 }
 
+func TestWithClientTitle(t *testing.T) {
+	client := newTestClient()
+	if err := WithClientTitle("My Agent")(client); err != nil {
+		t.Errorf("Expected no error, but got: %v", err)
+	}
+	if client.clientTitle != "My Agent" {
+		t.Errorf("Expected clientTitle to be %q, got %q", "My Agent", client.clientTitle)
+	}
+	if opts := client.transportOptions(); len(opts) != 1 {
+		t.Errorf("Expected the title to be passed to the transport, got %d options", len(opts))
+	}
+}
+
+func TestWithClientCapability(t *testing.T) {
+	t.Run("Declares the capabilities", func(t *testing.T) {
+		tr := &receivingTransport{}
+		_, err := NewToolboxClient("https://custom",
+			WithCustomTransport(tr),
+			WithClientCapability("experimental", map[string]any{"feature": true}),
+			WithClientCapability("extra", nil),
+		)
+		if err != nil {
+			t.Fatalf("NewToolboxClient failed: %v", err)
+		}
+		expected := map[string]map[string]any{"experimental": {"feature": true}, "extra": {}}
+		if !reflect.DeepEqual(tr.capabilities, expected) {
+			t.Errorf("Unexpected capabilities: %v", tr.capabilities)
+		}
+	})
+
+	t.Run("Negative Test - Invalid usage", func(t *testing.T) {
+		client := newTestClient()
+		if err := WithClientCapability("", nil)(client); err == nil {
+			t.Error("Expected an error for an empty name")
+		}
+		_ = WithClientCapability("experimental", nil)(client)
+		if err := WithClientCapability("experimental", nil)(client); err == nil || !strings.Contains(err.Error(), "already set") {
+			t.Errorf("Expected a duplicate error, got %v", err)
+		}
+	})
+}
+
 func TestWithProtocol(t *testing.T) {
 	// Verify all protocols can be set individually
 	tests := []struct {
@@ -96,6 +141,8 @@ func TestWithProtocol(t *testing.T) {
 		{"Sets MCP v2025-11-25", MCPv20251125},
 		{"Sets MCP v2025-03-26", MCPv20250326},
 		{"Sets MCP v2024-11-05", MCPv20241105},
+		{"Sets MCP v2024-11-05 over SSE", MCPv20241105SSE},
+		{"Sets MCP v2025-06-18 over WebSocket", MCPv20250618WebSocket},
 	}
 
 	for _, tc := range tests {
@@ -216,6 +263,44 @@ func TestWithClientHeaderTokenSource(t *testing.T) {
 	})
 }
 
+func TestWithToolsChangedHandler(t *testing.T) {
+	t.Run("Appends handlers", func(t *testing.T) {
+		client := newTestClient()
+		handler := func() {}
+		for i := 0; i < 2; i++ {
+			if err := WithToolsChangedHandler(handler)(client); err != nil {
+				t.Fatalf("Expected no error, but got: %v", err)
+			}
+		}
+		if len(client.toolsChangedHandlers) != 2 {
+			t.Errorf("Expected 2 handlers, but got %d", len(client.toolsChangedHandlers))
+		}
+	})
+
+	t.Run("Failure on nil handler", func(t *testing.T) {
+		err := WithToolsChangedHandler(nil)(newTestClient())
+		if err == nil || !strings.Contains(err.Error(), "cannot be nil") {
+			t.Errorf("Expected a nil handler error, got %v", err)
+		}
+	})
+}
+
+func TestWithConflictPolicy(t *testing.T) {
+	client := newTestClient()
+	if err := WithConflictPolicy(ConflictFirstWins)(client); err != nil {
+		t.Fatalf("Expected no error, but got: %v", err)
+	}
+	if client.conflictPolicy != ConflictFirstWins {
+		t.Errorf("Expected the policy to be set, got %q", client.conflictPolicy)
+	}
+	if err := WithConflictPolicy(ConflictError)(client); err == nil || !strings.Contains(err.Error(), "already set") {
+		t.Errorf("Expected an override error, got %v", err)
+	}
+	if err := WithConflictPolicy("last-wins")(newTestClient()); err == nil || !strings.Contains(err.Error(), "unknown conflict policy") {
+		t.Errorf("Expected an unknown policy error, got %v", err)
+	}
+}
+
 func TestWithDefaultToolOptions(t *testing.T) {
 	// A dummy ToolOption for testing purposes.
 	dummyOpt := func(c *ToolConfig) error { return nil }
@@ -613,6 +698,259 @@ func TestMapAndMapFuncOptions(t *testing.T) {
 	})
 }
 
+func TestWithOverride(t *testing.T) {
+	config := newToolConfig()
+	defaults := []ToolOption{
+		WithBindParamString("region", "eu"),
+		WithAuthTokenString("google", "old-token"),
+		WithStrict(true),
+		WithMaxResponseBytes(1024),
+		WithTagFilter("read-only"),
+		WithAuditLogger(func(context.Context, AuditRecord) {}),
+	}
+	for _, opt := range defaults {
+		if err := opt(config); err != nil {
+			t.Fatalf("Applying option failed unexpectedly: %v", err)
+		}
+	}
+
+	if err := WithBindParamString("region", "us")(config); err == nil {
+		t.Fatal("Expected a duplicate binding error without WithOverride, got nil")
+	}
+
+	err := WithOverride(
+		WithBindParamString("region", "us"),
+		WithBindParamInt("limit", 5),
+		WithAuthTokenString("google", "new-token"),
+		WithStrict(false),
+		WithMaxResponseBytes(2048),
+		WithTagFilter("write"),
+		WithResultCache(time.Minute, 10),
+		WithAuditLogger(func(context.Context, AuditRecord) {}),
+	)(config)
+	if err != nil {
+		t.Fatalf("WithOverride failed unexpectedly: %v", err)
+	}
+
+	if config.BoundParams["region"] != "us" || config.BoundParams["limit"] != 5 {
+		t.Errorf("Expected the bound parameters to be replaced, got %v", config.BoundParams)
+	}
+	if token, _ := config.AuthTokenSources["google"].Token(); token.AccessToken != "new-token" {
+		t.Errorf("Expected the auth token to be replaced, got %q", token.AccessToken)
+	}
+	if config.Strict || config.MaxResponseBytes != 2048 || config.ResultCacheTTL != time.Minute || config.ResultCacheSize != 10 {
+		t.Errorf("Expected the settings to be replaced, got %+v", config)
+	}
+	if !reflect.DeepEqual(config.TagFilter, []string{"write"}) {
+		t.Errorf("Expected the tag filter to be replaced, got %v", config.TagFilter)
+	}
+	if len(config.AuditLoggers) != 2 {
+		t.Errorf("Expected the audit loggers to be added, got %d", len(config.AuditLoggers))
+	}
+
+	if err := WithOverride(WithBindParamString("a", "1"), WithBindParamString("a", "2"))(newToolConfig()); err == nil {
+		t.Error("Expected duplicates within WithOverride to fail, got nil")
+	}
+	if err := WithOverride(nil)(newToolConfig()); err == nil || !strings.Contains(err.Error(), "nil ToolOption") {
+		t.Errorf("Expected a nil option error, got %v", err)
+	}
+}
+
+func TestWithAllowUnusedBindings(t *testing.T) {
+	config := newToolConfig()
+	if err := WithAllowUnusedBindings(true)(config); err != nil {
+		t.Fatalf("Expected no error, but got: %v", err)
+	}
+	if !config.AllowUnused {
+		t.Error("Expected AllowUnused to be true")
+	}
+	if err := WithAllowUnusedBindings(false)(config); err == nil || !strings.Contains(err.Error(), "already set") {
+		t.Errorf("Expected an override error, got %v", err)
+	}
+}
+
+func TestWithToolFilter(t *testing.T) {
+	config := newToolConfig()
+	include := []string{"a"}
+	if err := WithToolFilter(include, []string{"b"})(config); err != nil {
+		t.Fatalf("Expected no error, but got: %v", err)
+	}
+	include[0] = "changed"
+	if !config.includesTool("a") || config.includesTool("b") || config.includesTool("c") {
+		t.Errorf("Unexpected filter result for %v, %v", config.IncludeTools, config.ExcludeTools)
+	}
+	if err := WithToolFilter(nil, []string{"c"})(config); err == nil || !strings.Contains(err.Error(), "already set") {
+		t.Errorf("Expected an override error, got %v", err)
+	}
+	if err := WithToolFilter(nil, nil)(newToolConfig()); err == nil || !strings.Contains(err.Error(), "at least one tool") {
+		t.Errorf("Expected an error for an empty filter, got %v", err)
+	}
+	if err := WithToolFilter([]string{"bq-["}, nil)(newToolConfig()); err == nil || !strings.Contains(err.Error(), "invalid pattern 'bq-['") {
+		t.Errorf("Expected an invalid pattern error, got %v", err)
+	}
+}
+
+func TestWithQueryParams(t *testing.T) {
+	client := newTestClient()
+	if err := WithQueryParams(url.Values{"version": {"2"}})(client); err != nil {
+		t.Fatalf("Expected no error, but got: %v", err)
+	}
+	if err := WithQueryParams(url.Values{"beta": {"true"}})(client); err != nil {
+		t.Fatalf("Expected no error, but got: %v", err)
+	}
+	if got := client.queryParams.Encode(); got != "beta=true&version=2" {
+		t.Errorf("Expected both parameters to be set, got %q", got)
+	}
+	if err := WithQueryParams(url.Values{"version": {"3"}})(client); err == nil || !strings.Contains(err.Error(), "already set") {
+		t.Errorf("Expected an override error, got %v", err)
+	}
+	if err := WithQueryParams(nil)(newTestClient()); err == nil || !strings.Contains(err.Error(), "cannot be empty") {
+		t.Errorf("Expected an empty parameters error, got %v", err)
+	}
+}
+
+func TestWithManifestQueryParams(t *testing.T) {
+	config := newToolConfig()
+	if err := WithManifestQueryParams(url.Values{"version": {"2"}})(config); err != nil {
+		t.Fatalf("Expected no error, but got: %v", err)
+	}
+	if got := config.QueryParams.Encode(); got != "version=2" {
+		t.Errorf("Expected the parameter to be set, got %q", got)
+	}
+	if err := WithManifestQueryParams(url.Values{"version": {"3"}})(config); err == nil || !strings.Contains(err.Error(), "already set") {
+		t.Errorf("Expected an override error, got %v", err)
+	}
+	if err := WithManifestQueryParams(url.Values{})(newToolConfig()); err == nil || !strings.Contains(err.Error(), "cannot be empty") {
+		t.Errorf("Expected an empty parameters error, got %v", err)
+	}
+
+	if err := WithOverride(WithManifestQueryParams(url.Values{"version": {"3"}}))(config); err != nil {
+		t.Fatalf("Expected WithOverride to succeed, got: %v", err)
+	}
+	if got := config.QueryParams.Encode(); got != "version=3" {
+		t.Errorf("Expected WithOverride to replace the parameter, got %q", got)
+	}
+}
+
+func TestWithToolNamePrefix(t *testing.T) {
+	config := newToolConfig()
+	if err := WithToolNamePrefix("a_")(config); err != nil {
+		t.Fatalf("Expected no error, but got: %v", err)
+	}
+	if config.ToolNamePrefix != "a_" {
+		t.Errorf("Expected the prefix to be set, got %q", config.ToolNamePrefix)
+	}
+	if err := WithToolNamePrefix("b_")(config); err == nil || !strings.Contains(err.Error(), "already set") {
+		t.Errorf("Expected an override error, got %v", err)
+	}
+	if err := WithToolNamePrefix("")(newToolConfig()); err == nil || !strings.Contains(err.Error(), "cannot be empty") {
+		t.Errorf("Expected an empty prefix error, got %v", err)
+	}
+}
+
+func TestWithToolNameRegexp(t *testing.T) {
+	config := newToolConfig()
+	if err := WithToolNameRegexp(regexp.MustCompile(`^bq-`))(config); err != nil {
+		t.Fatalf("Expected no error, but got: %v", err)
+	}
+	if !config.includesTool("bq-query") || config.includesTool("sql-execute") {
+		t.Errorf("Unexpected filter result for %v", config.ToolNameRegexp)
+	}
+	if err := WithToolNameRegexp(regexp.MustCompile(`x`))(config); err == nil || !strings.Contains(err.Error(), "already set") {
+		t.Errorf("Expected an override error, got %v", err)
+	}
+	if err := WithToolNameRegexp(nil)(newToolConfig()); err == nil || !strings.Contains(err.Error(), "cannot be nil") {
+		t.Errorf("Expected a nil regexp error, got %v", err)
+	}
+}
+
+func TestWithTagFilter(t *testing.T) {
+	t.Run("Sets the tag filter", func(t *testing.T) {
+		config := newToolConfig()
+		if err := WithTagFilter("read-only", "search")(config); err != nil {
+			t.Fatalf("WithTagFilter failed unexpectedly: %v", err)
+		}
+		if len(config.TagFilter) != 2 || config.TagFilter[0] != "read-only" {
+			t.Errorf("Unexpected tag filter: %v", config.TagFilter)
+		}
+	})
+
+	t.Run("Fails without tags", func(t *testing.T) {
+		config := newToolConfig()
+		if err := WithTagFilter()(config); err == nil {
+			t.Error("Expected an error when no tags are provided, but got nil")
+		}
+	})
+
+	t.Run("Fails when set twice", func(t *testing.T) {
+		config := newToolConfig()
+		_ = WithTagFilter("a")(config)
+		err := WithTagFilter("b")(config)
+		if err == nil || !strings.Contains(err.Error(), "tag filter is already set") {
+			t.Errorf("Expected duplicate tag filter error, got: %v", err)
+		}
+	})
+}
+
+func TestInvokeHookOptions(t *testing.T) {
+	before := func(ctx context.Context, name string, payload map[string]any) (map[string]any, error) {
+		return payload, nil
+	}
+	after := func(ctx context.Context, name string, result any) (any, error) {
+		return result, nil
+	}
+
+	t.Run("Appends hooks in order", func(t *testing.T) {
+		config := newToolConfig()
+		for _, opt := range []ToolOption{WithBeforeInvoke(before), WithBeforeInvoke(before), WithAfterInvoke(after)} {
+			if err := opt(config); err != nil {
+				t.Fatalf("Applying hook option failed unexpectedly: %v", err)
+			}
+		}
+		if len(config.BeforeInvoke) != 2 || len(config.AfterInvoke) != 1 {
+			t.Errorf("Expected 2 before and 1 after hooks, got %d and %d", len(config.BeforeInvoke), len(config.AfterInvoke))
+		}
+	})
+
+	t.Run("Fails with nil hooks", func(t *testing.T) {
+		config := newToolConfig()
+		if err := WithBeforeInvoke(nil)(config); err == nil {
+			t.Error("Expected an error for a nil before invoke hook, but got nil")
+		}
+		if err := WithAfterInvoke(nil)(config); err == nil {
+			t.Error("Expected an error for a nil after invoke hook, but got nil")
+		}
+	})
+}
+
+func TestMaxResponseBytesOptions(t *testing.T) {
+	t.Run("Client option rejects non-positive limits", func(t *testing.T) {
+		_, err := NewToolboxClient("http://example.com", WithClientMaxResponseBytes(0))
+		if err == nil || !strings.Contains(err.Error(), "limit must be positive") {
+			t.Errorf("Expected a non-positive limit error, got: %v", err)
+		}
+	})
+
+	t.Run("Client option sets the limit", func(t *testing.T) {
+		client, err := NewToolboxClient("http://example.com", WithClientMaxResponseBytes(2048))
+		if err != nil {
+			t.Fatalf("NewToolboxClient failed unexpectedly: %v", err)
+		}
+		if client.maxResponseBytes != 2048 {
+			t.Errorf("Expected maxResponseBytes 2048, got %d", client.maxResponseBytes)
+		}
+	})
+
+	t.Run("Tool option cannot be set twice", func(t *testing.T) {
+		config := newToolConfig()
+		_ = WithMaxResponseBytes(10)(config)
+		err := WithMaxResponseBytes(20)(config)
+		if err == nil || !strings.Contains(err.Error(), "maximum response size is already set") {
+			t.Errorf("Expected a duplicate limit error, got: %v", err)
+		}
+	})
+}
+
 func TestNewToolConfig(t *testing.T) {
 	// Call the function to get a new config.
 	config := newToolConfig()
@@ -635,3 +973,189 @@ func TestNewToolConfig(t *testing.T) {
 		t.Errorf("Expected Strict to be false, but got %t", config.Strict)
 	}
 }
+
+func TestInvokeOptions(t *testing.T) {
+	t.Run("Defaults", func(t *testing.T) {
+		config := newInvokeConfig()
+		if config.ResultFormat != ResultFormatText {
+			t.Errorf("Expected default result format %q, got %q", ResultFormatText, config.ResultFormat)
+		}
+		if config.Headers == nil || config.AuthTokenSources == nil {
+			t.Error("Expected maps to be initialized")
+		}
+	})
+
+	t.Run("Negative Test - Rejects a non-positive timeout", func(t *testing.T) {
+		err := WithInvokeTimeout(0)(newInvokeConfig())
+		if err == nil || !strings.Contains(err.Error(), "timeout must be positive") {
+			t.Errorf("Expected a timeout error, got: %v", err)
+		}
+	})
+
+	t.Run("Negative Test - Rejects a duplicate header", func(t *testing.T) {
+		config := newInvokeConfig()
+		_ = WithInvokeHeader("X-Test", "a")(config)
+		err := WithInvokeHeader("X-Test", "b")(config)
+		if err == nil || !strings.Contains(err.Error(), "invoke header 'X-Test' is already set") {
+			t.Errorf("Expected a duplicate header error, got: %v", err)
+		}
+	})
+
+	t.Run("Negative Test - Rejects empty and duplicate meta keys", func(t *testing.T) {
+		config := newInvokeConfig()
+		if err := WithInvokeMeta("", "a")(config); err == nil || !strings.Contains(err.Error(), "key cannot be empty") {
+			t.Errorf("Expected an empty key error, got: %v", err)
+		}
+		_ = WithInvokeMeta("tenant", "a")(config)
+		err := WithInvokeMeta("tenant", "b")(config)
+		if err == nil || !strings.Contains(err.Error(), "invoke meta 'tenant' is already set") {
+			t.Errorf("Expected a duplicate key error, got: %v", err)
+		}
+	})
+
+	t.Run("Negative Test - Rejects an empty idempotency key", func(t *testing.T) {
+		err := WithIdempotencyKey("")(newInvokeConfig())
+		if err == nil {
+			t.Error("Expected an error for an empty idempotency key")
+		}
+	})
+
+	t.Run("Negative Test - Rejects an unknown result format", func(t *testing.T) {
+		err := WithResultFormat("xml")(newInvokeConfig())
+		if err == nil || !strings.Contains(err.Error(), "unsupported result format 'xml'") {
+			t.Errorf("Expected an unsupported format error, got: %v", err)
+		}
+	})
+
+	t.Run("Negative Test - Rejects nil and duplicate auth sources", func(t *testing.T) {
+		config := newInvokeConfig()
+		if err := WithInvokeAuthTokenSource("google", nil)(config); err == nil {
+			t.Error("Expected an error for a nil token source")
+		}
+		_ = WithInvokeAuthTokenString("google", "a")(config)
+		err := WithInvokeAuthTokenString("google", "b")(config)
+		if err == nil || !strings.Contains(err.Error(), "authentication source 'google' is already set") {
+			t.Errorf("Expected a duplicate auth source error, got: %v", err)
+		}
+	})
+
+	t.Run("Negative Test - Rejects nil and duplicate notification handlers", func(t *testing.T) {
+		config := newInvokeConfig()
+		if err := WithNotificationHandler(nil)(config); err == nil {
+			t.Error("Expected an error for a nil handler")
+		}
+		_ = WithNotificationHandler(func(Notification) {})(config)
+		err := WithNotificationHandler(func(Notification) {})(config)
+		if err == nil || !strings.Contains(err.Error(), "notification handler is already set") {
+			t.Errorf("Expected a duplicate handler error, got: %v", err)
+		}
+	})
+
+	t.Run("Negative Test - Rejects nil and duplicate progress handlers", func(t *testing.T) {
+		config := newInvokeConfig()
+		if err := WithProgressHandler(nil)(config); err == nil {
+			t.Error("Expected an error for a nil handler")
+		}
+		_ = WithProgressHandler(func(Progress) {})(config)
+		err := WithProgressHandler(func(Progress) {})(config)
+		if err == nil || !strings.Contains(err.Error(), "progress handler is already set") {
+			t.Errorf("Expected a duplicate handler error, got: %v", err)
+		}
+	})
+}
+
+func TestWithResultCache(t *testing.T) {
+	t.Run("Sets the cache configuration", func(t *testing.T) {
+		config := newToolConfig()
+		if err := WithResultCache(time.Minute, 5)(config); err != nil {
+			t.Fatalf("WithResultCache failed: %v", err)
+		}
+		if config.ResultCacheTTL != time.Minute || config.ResultCacheSize != 5 {
+			t.Errorf("Unexpected cache configuration: %v, %d", config.ResultCacheTTL, config.ResultCacheSize)
+		}
+	})
+
+	t.Run("Negative Test - Rejects invalid values", func(t *testing.T) {
+		if err := WithResultCache(0, 5)(newToolConfig()); err == nil {
+			t.Error("Expected an error for a zero ttl")
+		}
+		if err := WithResultCache(time.Minute, 0)(newToolConfig()); err == nil {
+			t.Error("Expected an error for zero entries")
+		}
+	})
+
+	t.Run("Negative Test - Rejects a duplicate cache", func(t *testing.T) {
+		config := newToolConfig()
+		_ = WithResultCache(time.Minute, 5)(config)
+		err := WithResultCache(time.Second, 1)(config)
+		if err == nil || !strings.Contains(err.Error(), "result cache is already set") {
+			t.Errorf("Expected a duplicate cache error, got: %v", err)
+		}
+	})
+}
+
+func TestWithMaxConcurrentInvocations(t *testing.T) {
+	config := newToolConfig()
+	if err := WithMaxConcurrentInvocations(3)(config); err != nil {
+		t.Fatalf("WithMaxConcurrentInvocations failed: %v", err)
+	}
+	if config.MaxConcurrent != 3 {
+		t.Errorf("Expected MaxConcurrent to be 3, got %d", config.MaxConcurrent)
+	}
+
+	err := WithMaxConcurrentInvocations(4)(config)
+	if err == nil || !strings.Contains(err.Error(), "maximum concurrent invocations is already set") {
+		t.Errorf("Expected a duplicate limit error, got: %v", err)
+	}
+	if err := WithMaxConcurrentInvocations(0)(newToolConfig()); err == nil {
+		t.Error("Expected an error for a non-positive limit")
+	}
+}
+
+func TestWithTokenRefreshSkew(t *testing.T) {
+	client := newTestClient()
+	if err := WithTokenRefreshSkew(time.Minute)(client); err != nil {
+		t.Fatalf("WithTokenRefreshSkew failed: %v", err)
+	}
+	if client.tokenRefreshSkew != time.Minute || !client.tokenRefreshSkewSet {
+		t.Errorf("Expected skew to be set to 1m, got %v", client.tokenRefreshSkew)
+	}
+
+	err := WithTokenRefreshSkew(time.Second)(client)
+	if err == nil || !strings.Contains(err.Error(), "token refresh skew is already set") {
+		t.Errorf("Expected a duplicate skew error, got: %v", err)
+	}
+	if err := WithTokenRefreshSkew(-time.Second)(newTestClient()); err == nil {
+		t.Error("Expected an error for a negative skew")
+	}
+}
+
+func TestWithTypeCoercion(t *testing.T) {
+	config := newToolConfig()
+	if err := WithTypeCoercion()(config); err != nil {
+		t.Fatalf("WithTypeCoercion failed: %v", err)
+	}
+	if !config.TypeCoercion {
+		t.Error("Expected type coercion to be enabled")
+	}
+
+	err := WithTypeCoercion()(config)
+	if err == nil || !strings.Contains(err.Error(), "type coercion is already set") {
+		t.Errorf("Expected a duplicate coercion error, got: %v", err)
+	}
+}
+
+func TestWithTokenValidation(t *testing.T) {
+	config := newToolConfig()
+	if err := WithTokenValidation("https://toolbox")(config); err != nil {
+		t.Fatalf("WithTokenValidation failed: %v", err)
+	}
+	if !config.ValidateTokens || config.TokenAudience != "https://toolbox" {
+		t.Errorf("Unexpected validation config: %t, %q", config.ValidateTokens, config.TokenAudience)
+	}
+
+	err := WithTokenValidation("")(config)
+	if err == nil || !strings.Contains(err.Error(), "token validation is already set") {
+		t.Errorf("Expected a duplicate validation error, got: %v", err)
+	}
+}
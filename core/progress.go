@@ -0,0 +1,64 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/googleapis/mcp-toolbox-sdk-go/core/transport"
+)
+
+// progressMethod is the notification a server sends to report the progress
+// of a request.
+const progressMethod = "notifications/progress"
+
+// Progress is a progress update for a tool invocation.
+type Progress struct {
+	// Progress increases with each update, even if Total is unknown.
+	Progress float64 `json:"progress"`
+	// Total is the value Progress reaches on completion, or 0 if unknown.
+	Total float64 `json:"total,omitempty"`
+	// Message optionally describes the current step.
+	Message string `json:"message,omitempty"`
+}
+
+// ProgressHandler receives the progress updates of a tool invocation.
+type ProgressHandler func(Progress)
+
+// withProgressReporting returns a copy of ctx that requests progress updates
+// with a new progress token and passes the updates tagged with it to
+// onProgress. All notifications, including the updates, are also passed to
+// onNotification if it is not nil.
+func withProgressReporting(ctx context.Context, onProgress ProgressHandler, onNotification NotificationHandler) context.Context {
+	token := uuid.New().String()
+	ctx = transport.WithProgressToken(ctx, token)
+	return transport.WithNotificationHandler(ctx, func(n transport.Notification) {
+		if n.Method == progressMethod {
+			var update struct {
+				Progress
+				ProgressToken any `json:"progressToken"`
+			}
+			if err := json.Unmarshal(n.Params, &update); err == nil && fmt.Sprint(update.ProgressToken) == token {
+				onProgress(update.Progress)
+			}
+		}
+		if onNotification != nil {
+			onNotification(n)
+		}
+	})
+}
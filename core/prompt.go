@@ -0,0 +1,214 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// PromptFormat selects the layout produced by PromptDescription.
+type PromptFormat string
+
+const (
+	// PromptFormatPlain renders the tool as indented plain text.
+	PromptFormatPlain PromptFormat = "plain"
+	// PromptFormatMarkdown renders the tool as a Markdown section.
+	PromptFormatMarkdown PromptFormat = "markdown"
+	// PromptFormatTypeScript renders the tool as a TypeScript function
+	// signature with doc comments.
+	PromptFormatTypeScript PromptFormat = "typescript"
+)
+
+// PromptDescription renders the tool's name, description and unbound
+// parameters in a format suitable for embedding directly in an LLM prompt,
+// such as a ReAct-style system prompt.
+//
+// Inputs:
+//   - format: The layout to produce. One of PromptFormatPlain,
+//     PromptFormatMarkdown or PromptFormatTypeScript.
+//
+// Returns:
+//
+//	The formatted description, or an error if the format is not supported.
+func (tt *ToolboxTool) PromptDescription(format PromptFormat) (string, error) {
+	switch format {
+	case PromptFormatPlain:
+		return tt.plainPrompt(), nil
+	case PromptFormatMarkdown:
+		return tt.markdownPrompt(), nil
+	case PromptFormatTypeScript:
+		return tt.typeScriptPrompt(), nil
+	default:
+		return "", fmt.Errorf("unsupported prompt format '%s'", format)
+	}
+}
+
+func (tt *ToolboxTool) plainPrompt() string {
+	var sb strings.Builder
+	sb.WriteString(tt.name)
+	if tt.description != "" {
+		fmt.Fprintf(&sb, ": %s", tt.description)
+	}
+	sb.WriteString("\n")
+	if len(tt.parameters) == 0 {
+		sb.WriteString("Parameters: none\n")
+		return sb.String()
+	}
+	sb.WriteString("Parameters:\n")
+	for _, p := range tt.parameters {
+		fmt.Fprintf(&sb, "  - %s (%s)", p.Name, paramAttributes(p))
+		if p.Description != "" {
+			fmt.Fprintf(&sb, ": %s", p.Description)
+		}
+		sb.WriteString("\n")
+	}
+	if len(tt.examples) > 0 {
+		sb.WriteString("Examples:\n")
+		for _, ex := range tt.examples {
+			fmt.Fprintf(&sb, "  - %s\n", exampleLine(ex, ""))
+		}
+	}
+	return sb.String()
+}
+
+func (tt *ToolboxTool) markdownPrompt() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "### `%s`\n\n", tt.name)
+	if tt.description != "" {
+		fmt.Fprintf(&sb, "%s\n\n", tt.description)
+	}
+	if len(tt.parameters) == 0 {
+		sb.WriteString("This tool takes no parameters.\n")
+		return sb.String()
+	}
+	sb.WriteString("**Parameters:**\n\n")
+	for _, p := range tt.parameters {
+		fmt.Fprintf(&sb, "- `%s` (%s)", p.Name, paramAttributes(p))
+		if p.Description != "" {
+			fmt.Fprintf(&sb, ": %s", p.Description)
+		}
+		sb.WriteString("\n")
+	}
+	if len(tt.examples) > 0 {
+		sb.WriteString("\n**Examples:**\n\n")
+		for _, ex := range tt.examples {
+			fmt.Fprintf(&sb, "- %s\n", exampleLine(ex, "`"))
+		}
+	}
+	return sb.String()
+}
+
+func (tt *ToolboxTool) typeScriptPrompt() string {
+	var sb strings.Builder
+	if tt.description != "" {
+		fmt.Fprintf(&sb, "/** %s */\n", tt.description)
+	}
+	if len(tt.parameters) == 0 {
+		fmt.Fprintf(&sb, "function %s(): any;\n", tt.name)
+		return sb.String()
+	}
+	fmt.Fprintf(&sb, "function %s(args: {\n", tt.name)
+	for _, p := range tt.parameters {
+		comment := p.Description
+		if p.Default != nil {
+			comment = strings.TrimSpace(fmt.Sprintf("%s (default: %s)", comment, compactJSON(p.Default)))
+		}
+		if comment != "" {
+			fmt.Fprintf(&sb, "  /** %s */\n", comment)
+		}
+		optional := ""
+		if !p.Required {
+			optional = "?"
+		}
+		fmt.Fprintf(&sb, "  %s%s: %s;\n", p.Name, optional, typeScriptType(&p))
+	}
+	sb.WriteString("}): any;\n")
+	for _, ex := range tt.examples {
+		fmt.Fprintf(&sb, "// Example: %s(%s)\n", tt.name, compactJSON(ex.Input))
+	}
+	return sb.String()
+}
+
+// exampleLine renders an example as "description: input -> output", wrapping
+// the JSON values in the given quote string.
+func exampleLine(ex ToolExample, quote string) string {
+	line := quote + compactJSON(ex.Input) + quote
+	if ex.Output != nil {
+		line += " -> " + quote + compactJSON(ex.Output) + quote
+	}
+	if ex.Description != "" {
+		line = ex.Description + ": " + line
+	}
+	return line
+}
+
+// paramAttributes returns the type, required flag and default value of a
+// parameter as a comma-separated list, e.g. "array<string>, required".
+func paramAttributes(p ParameterSchema) string {
+	attrs := []string{typeLabel(&p)}
+	if p.Required {
+		attrs = append(attrs, "required")
+	} else {
+		attrs = append(attrs, "optional")
+	}
+	if p.Default != nil {
+		attrs = append(attrs, "default: "+compactJSON(p.Default))
+	}
+	return strings.Join(attrs, ", ")
+}
+
+// typeLabel returns a compact, human-readable name for a parameter type,
+// including the element type of arrays.
+func typeLabel(p *ParameterSchema) string {
+	if p.Type == "array" && p.Items != nil {
+		return fmt.Sprintf("array<%s>", typeLabel(p.Items))
+	}
+	return p.Type
+}
+
+// typeScriptType maps a parameter schema to the equivalent TypeScript type.
+func typeScriptType(p *ParameterSchema) string {
+	switch p.Type {
+	case "string":
+		return "string"
+	case "integer", "float", "number":
+		return "number"
+	case "boolean":
+		return "boolean"
+	case "array":
+		if p.Items == nil {
+			return "any[]"
+		}
+		return typeScriptType(p.Items) + "[]"
+	case "object":
+		if schema, ok := p.AdditionalProperties.(*ParameterSchema); ok && schema != nil {
+			return fmt.Sprintf("Record<string, %s>", typeScriptType(schema))
+		}
+		return "Record<string, any>"
+	default:
+		return "any"
+	}
+}
+
+// compactJSON renders a value as compact JSON so strings are quoted.
+func compactJSON(v any) string {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+	return string(b)
+}
@@ -0,0 +1,131 @@
+//go:build unit
+
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPromptDescription(t *testing.T) {
+	tool := &ToolboxTool{
+		name:        "search_hotels",
+		description: "Search hotels by city.",
+		parameters: []ParameterSchema{
+			{Name: "city", Type: "string", Description: "The city name.", Required: true},
+			{Name: "limit", Type: "integer", Description: "Maximum results.", Default: 10},
+			{Name: "amenities", Type: "array", Items: &ParameterSchema{Type: "string"}},
+			{Name: "filters", Type: "object", AdditionalProperties: &ParameterSchema{Type: "boolean"}},
+		},
+	}
+
+	testCases := []struct {
+		name     string
+		format   PromptFormat
+		expected string
+	}{
+		{
+			name:   "Plain",
+			format: PromptFormatPlain,
+			expected: `search_hotels: Search hotels by city.
+Parameters:
+  - city (string, required): The city name.
+  - limit (integer, optional, default: 10): Maximum results.
+  - amenities (array<string>, optional)
+  - filters (object, optional)
+`,
+		},
+		{
+			name:   "Markdown",
+			format: PromptFormatMarkdown,
+			expected: "### `search_hotels`\n\nSearch hotels by city.\n\n**Parameters:**\n\n" +
+				"- `city` (string, required): The city name.\n" +
+				"- `limit` (integer, optional, default: 10): Maximum results.\n" +
+				"- `amenities` (array<string>, optional)\n" +
+				"- `filters` (object, optional)\n",
+		},
+		{
+			name:   "TypeScript",
+			format: PromptFormatTypeScript,
+			expected: `/** Search hotels by city. */
+function search_hotels(args: {
+  /** The city name. */
+  city: string;
+  /** Maximum results. (default: 10) */
+  limit?: number;
+  amenities?: string[];
+  filters?: Record<string, boolean>;
+}): any;
+`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := tool.PromptDescription(tc.format)
+			if err != nil {
+				t.Fatalf("PromptDescription returned an unexpected error: %v", err)
+			}
+			if got != tc.expected {
+				t.Errorf("Mismatched output.\nGot:\n%s\nWant:\n%s", got, tc.expected)
+			}
+		})
+	}
+
+	t.Run("Tool without parameters", func(t *testing.T) {
+		empty := &ToolboxTool{name: "ping"}
+		got, _ := empty.PromptDescription(PromptFormatTypeScript)
+		if got != "function ping(): any;\n" {
+			t.Errorf("Unexpected output: %q", got)
+		}
+		got, _ = empty.PromptDescription(PromptFormatPlain)
+		if !strings.Contains(got, "Parameters: none") {
+			t.Errorf("Unexpected output: %q", got)
+		}
+	})
+
+	t.Run("Includes examples", func(t *testing.T) {
+		withExamples := &ToolboxTool{
+			name:       "search_hotels",
+			parameters: []ParameterSchema{{Name: "city", Type: "string", Required: true}},
+			examples: []ToolExample{
+				{Description: "Hotels in Paris", Input: map[string]any{"city": "Paris"}, Output: "3 hotels"},
+			},
+		}
+		expected := map[PromptFormat]string{
+			PromptFormatPlain:      `  - Hotels in Paris: {"city":"Paris"} -> "3 hotels"` + "\n",
+			PromptFormatMarkdown:   "- Hotels in Paris: `{\"city\":\"Paris\"}` -> `\"3 hotels\"`\n",
+			PromptFormatTypeScript: `// Example: search_hotels({"city":"Paris"})` + "\n",
+		}
+		for format, want := range expected {
+			got, err := withExamples.PromptDescription(format)
+			if err != nil {
+				t.Fatalf("PromptDescription(%s) returned an unexpected error: %v", format, err)
+			}
+			if !strings.HasSuffix(got, want) {
+				t.Errorf("PromptDescription(%s) = %q, want suffix %q", format, got, want)
+			}
+		}
+	})
+
+	t.Run("Negative Test - Unsupported format", func(t *testing.T) {
+		_, err := tool.PromptDescription("yaml")
+		if err == nil || !strings.Contains(err.Error(), "unsupported prompt format 'yaml'") {
+			t.Errorf("Expected an unsupported format error, got: %v", err)
+		}
+	})
+}
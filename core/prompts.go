@@ -0,0 +1,81 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/googleapis/mcp-toolbox-sdk-go/core/transport"
+)
+
+// promptSource returns the transport as a PromptSource, or an error if it
+// cannot fetch prompts.
+func (tc *ToolboxClient) promptSource() (transport.PromptSource, error) {
+	source, ok := tc.transport.(transport.PromptSource)
+	if !ok {
+		return nil, fmt.Errorf("transport %T does not support prompts", tc.transport)
+	}
+	return source, nil
+}
+
+// ListPrompts fetches the prompt templates offered by the server.
+//
+// Inputs:
+//   - ctx: The context to control the lifecycle of the request.
+//
+// Returns:
+//
+//	The prompts and a nil error on success, or a nil slice and an error if
+//	the request fails.
+func (tc *ToolboxClient) ListPrompts(ctx context.Context) ([]Prompt, error) {
+	source, err := tc.promptSource()
+	if err != nil {
+		return nil, err
+	}
+	resolvedHeaders, err := resolveClientHeaders(ctx, tc.headerSources())
+	if err != nil {
+		return nil, err
+	}
+	return source.ListPrompts(tc.limitResponseSize(ctx), resolvedHeaders)
+}
+
+// GetPrompt asks the server to render a prompt template, substituting args
+// for the template's arguments.
+//
+// Inputs:
+//   - ctx: The context to control the lifecycle of the request.
+//   - name: The name of the prompt.
+//   - args: The argument values, keyed by argument name. May be nil for
+//     prompts without arguments.
+//
+// Returns:
+//
+//	The rendered messages and a nil error on success, or nil and an error if
+//	the request fails.
+func (tc *ToolboxClient) GetPrompt(ctx context.Context, name string, args map[string]string) (*PromptResult, error) {
+	if name == "" {
+		return nil, fmt.Errorf("GetPrompt: prompt name cannot be empty")
+	}
+	source, err := tc.promptSource()
+	if err != nil {
+		return nil, err
+	}
+	resolvedHeaders, err := resolveClientHeaders(ctx, tc.headerSources())
+	if err != nil {
+		return nil, err
+	}
+	return source.GetPrompt(tc.limitResponseSize(ctx), name, args, resolvedHeaders)
+}
@@ -0,0 +1,109 @@
+//go:build unit
+
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// promptTransport is a dummyTransport that serves prompts.
+type promptTransport struct {
+	dummyTransport
+	headers map[string]string
+	args    map[string]string
+}
+
+func (p *promptTransport) ListPrompts(ctx context.Context, headers map[string]string) ([]Prompt, error) {
+	p.headers = headers
+	return []Prompt{{Name: "greet", Arguments: []PromptArgument{{Name: "name", Required: true}}}}, nil
+}
+
+func (p *promptTransport) GetPrompt(ctx context.Context, name string, args map[string]string, headers map[string]string) (*PromptResult, error) {
+	p.headers = headers
+	p.args = args
+	return &PromptResult{Messages: []PromptMessage{{
+		Role:    "user",
+		Content: PromptContent{Type: "text", Text: "Hello, " + args["name"]},
+	}}}, nil
+}
+
+func TestListPrompts(t *testing.T) {
+	t.Run("Returns the server's prompts", func(t *testing.T) {
+		custom := &promptTransport{}
+		client, err := NewToolboxClient("https://custom",
+			WithCustomTransport(custom),
+			WithClientHeaderString("X-Api-Key", "secret"),
+		)
+		if err != nil {
+			t.Fatalf("NewToolboxClient failed: %v", err)
+		}
+
+		prompts, err := client.ListPrompts(context.Background())
+		if err != nil {
+			t.Fatalf("ListPrompts failed: %v", err)
+		}
+		if len(prompts) != 1 || prompts[0].Name != "greet" {
+			t.Errorf("Unexpected prompts: %+v", prompts)
+		}
+		if custom.headers["X-Api-Key"] != "secret" {
+			t.Errorf("Expected client headers to be sent, got %v", custom.headers)
+		}
+	})
+
+	t.Run("Negative Test - Transport without prompts", func(t *testing.T) {
+		client, err := NewToolboxClient("https://custom", WithCustomTransport(&dummyTransport{}))
+		if err != nil {
+			t.Fatalf("NewToolboxClient failed: %v", err)
+		}
+		_, err = client.ListPrompts(context.Background())
+		if err == nil || !strings.Contains(err.Error(), "does not support prompts") {
+			t.Errorf("Expected an unsupported error, got %v", err)
+		}
+	})
+}
+
+func TestGetPrompt(t *testing.T) {
+	custom := &promptTransport{}
+	client, err := NewToolboxClient("https://custom", WithCustomTransport(custom))
+	if err != nil {
+		t.Fatalf("NewToolboxClient failed: %v", err)
+	}
+
+	t.Run("Passes the arguments", func(t *testing.T) {
+		args := map[string]string{"name": "Ada"}
+		result, err := client.GetPrompt(context.Background(), "greet", args)
+		if err != nil {
+			t.Fatalf("GetPrompt failed: %v", err)
+		}
+		if !reflect.DeepEqual(custom.args, args) {
+			t.Errorf("Expected arguments %v, got %v", args, custom.args)
+		}
+		if got := result.Messages[0].Content.Text; got != "Hello, Ada" {
+			t.Errorf("Unexpected message text: %q", got)
+		}
+	})
+
+	t.Run("Negative Test - Empty name", func(t *testing.T) {
+		_, err := client.GetPrompt(context.Background(), "", nil)
+		if err == nil || !strings.Contains(err.Error(), "prompt name cannot be empty") {
+			t.Errorf("Expected an empty name error, got %v", err)
+		}
+	})
+}
@@ -26,6 +26,15 @@ const (
 	MCPv20250326 Protocol = "2025-03-26"
 	MCPv20241105 Protocol = "2024-11-05"
 
+	// MCPv20241105SSE selects the legacy HTTP+SSE transport of MCP
+	// 2024-11-05, for servers that do not accept plain POST requests.
+	MCPv20241105SSE Protocol = "2024-11-05+sse"
+
+	// MCPv20250618WebSocket selects the WebSocket transport for servers
+	// exposing a ws:// or wss:// endpoint. The URL passed to the client is
+	// used as the endpoint as-is.
+	MCPv20250618WebSocket Protocol = "2025-06-18+ws"
+
 	// MCP is the default alias pointing to the newest supported version.
 	MCP = MCPv20250618
 
@@ -47,5 +56,70 @@ type ManifestSchema = transport.ManifestSchema
 // ToolSchema defines a single tool in the manifest.
 type ToolSchema = transport.ToolSchema
 
+// ToolHints are the behavior hints of a tool, such as whether it is
+// read-only or destructive.
+type ToolHints = transport.ToolHints
+
 // ParameterSchema defines the structure and validation logic for tool parameters.
 type ParameterSchema = transport.ParameterSchema
+
+// ResponseTooLargeError is returned when a response exceeds the configured
+// maximum size.
+type ResponseTooLargeError = transport.ResponseTooLargeError
+
+// Deprecation describes the deprecation of a tool announced by the server.
+type Deprecation = transport.Deprecation
+
+// ToolExample is a sample invocation of a tool, suitable for few-shot
+// prompting.
+type ToolExample = transport.ToolExample
+
+// ToolInvocationError describes an error reported by the Toolbox server.
+type ToolInvocationError = transport.ToolInvocationError
+
+// ErrRateLimited matches, with errors.Is, the errors of requests rejected by
+// the server with a 429 response. The RetryAfter field of the
+// ToolInvocationError holds the delay the server asked for, if any.
+var ErrRateLimited = transport.ErrRateLimited
+
+// ResponseHeaders collects the headers of the HTTP responses received for
+// the requests made with a context. See WithResponseHeaders.
+type ResponseHeaders = transport.ResponseHeaders
+
+// Notification is a message the server sends while a request is in progress.
+type Notification = transport.Notification
+
+// NotificationHandler receives the notifications sent during an invocation.
+type NotificationHandler = transport.NotificationHandler
+
+// Prompt describes a prompt template offered by the server.
+type Prompt = transport.Prompt
+
+// PromptArgument describes a value substituted into a prompt template.
+type PromptArgument = transport.PromptArgument
+
+// PromptResult is a prompt rendered by the server.
+type PromptResult = transport.PromptResult
+
+// PromptMessage is a single message of a rendered prompt.
+type PromptMessage = transport.PromptMessage
+
+// PromptContent is the content of a prompt message.
+type PromptContent = transport.PromptContent
+
+// ResourceContents is the content of a resource. Exactly one of Text and
+// Blob is set; Blob holds base64-encoded binary data.
+type ResourceContents = transport.ResourceContents
+
+// ContentBlock is a single block of content in a tool result. MCP tools that
+// return non-text content, such as images or embedded resources, are invoked
+// with a []ContentBlock result holding every block.
+type ContentBlock = transport.ContentBlock
+
+// TransportObserver receives the start and end of every JSON-RPC request the
+// client's transport sends. See WithTransportObserver.
+type TransportObserver = transport.Observer
+
+// Completion holds the values the server suggests for an argument. See
+// ToolboxClient.CompletePromptArgument.
+type Completion = transport.Completion
@@ -0,0 +1,65 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"sync"
+
+	"github.com/googleapis/mcp-toolbox-sdk-go/core/transport"
+)
+
+// TransportFactory creates a transport for a registered protocol. It
+// receives the client's base URL, HTTP client and handshake identity.
+type TransportFactory func(baseURL string, httpClient *http.Client, clientName string, clientVersion string) (transport.Transport, error)
+
+var (
+	transportRegistryMu sync.RWMutex
+	transportRegistry   = make(map[Protocol]TransportFactory)
+)
+
+// RegisterTransport makes a third-party transport available to clients
+// created with WithProtocol(p). It is typically called from an init function.
+// The built-in MCP protocol versions cannot be replaced, and each protocol can
+// only be registered once.
+func RegisterTransport(p Protocol, factory TransportFactory) error {
+	if p == "" {
+		return fmt.Errorf("RegisterTransport: protocol cannot be empty")
+	}
+	if factory == nil {
+		return fmt.Errorf("RegisterTransport: factory for protocol '%s' cannot be nil", p)
+	}
+	if slices.Contains(GetSupportedMcpVersions(), string(p)) {
+		return fmt.Errorf("RegisterTransport: protocol '%s' is built in and cannot be replaced", p)
+	}
+
+	transportRegistryMu.Lock()
+	defer transportRegistryMu.Unlock()
+	if _, exists := transportRegistry[p]; exists {
+		return fmt.Errorf("RegisterTransport: protocol '%s' is already registered", p)
+	}
+	transportRegistry[p] = factory
+	return nil
+}
+
+// lookupTransport returns the factory registered for p, if any.
+func lookupTransport(p Protocol) (TransportFactory, bool) {
+	transportRegistryMu.RLock()
+	defer transportRegistryMu.RUnlock()
+	factory, ok := transportRegistry[p]
+	return factory, ok
+}
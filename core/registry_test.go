@@ -0,0 +1,108 @@
+//go:build unit
+
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/googleapis/mcp-toolbox-sdk-go/core/transport"
+)
+
+// resetTransportRegistry clears registered transports after a test.
+func resetTransportRegistry(t *testing.T) {
+	t.Cleanup(func() {
+		transportRegistryMu.Lock()
+		transportRegistry = make(map[Protocol]TransportFactory)
+		transportRegistryMu.Unlock()
+	})
+}
+
+func TestRegisterTransport(t *testing.T) {
+	factory := func(baseURL string, _ *http.Client, _ string, _ string) (transport.Transport, error) {
+		return &dummyTransport{baseURL: baseURL}, nil
+	}
+
+	t.Run("Clients use registered transports", func(t *testing.T) {
+		resetTransportRegistry(t)
+		if err := RegisterTransport("acme-rpc", factory); err != nil {
+			t.Fatalf("RegisterTransport failed: %v", err)
+		}
+
+		client, err := NewToolboxClient("https://acme.example.com", WithProtocol("acme-rpc"))
+		if err != nil {
+			t.Fatalf("NewToolboxClient failed: %v", err)
+		}
+		if _, ok := client.transport.(*dummyTransport); !ok {
+			t.Errorf("Expected the registered transport, got %T", client.transport)
+		}
+	})
+
+	t.Run("Negative Test - Invalid registrations", func(t *testing.T) {
+		resetTransportRegistry(t)
+		_ = RegisterTransport("acme-rpc", factory)
+
+		testCases := []struct {
+			name     string
+			protocol Protocol
+			factory  TransportFactory
+			wantErr  string
+		}{
+			{"Empty protocol", "", factory, "protocol cannot be empty"},
+			{"Nil factory", "other", nil, "cannot be nil"},
+			{"Built-in protocol", MCPv20250618, factory, "is built in"},
+			{"Duplicate protocol", "acme-rpc", factory, "is already registered"},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				err := RegisterTransport(tc.protocol, tc.factory)
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Errorf("Expected error containing %q, got %v", tc.wantErr, err)
+				}
+			})
+		}
+	})
+}
+
+func TestWithCustomTransport(t *testing.T) {
+	t.Run("Uses the provided transport", func(t *testing.T) {
+		custom := &dummyTransport{baseURL: "https://custom"}
+		client, err := NewToolboxClient("https://custom", WithCustomTransport(custom))
+		if err != nil {
+			t.Fatalf("NewToolboxClient failed: %v", err)
+		}
+		if client.transport != custom {
+			t.Errorf("Expected the custom transport, got %T", client.transport)
+		}
+	})
+
+	t.Run("Negative Test - Invalid usage", func(t *testing.T) {
+		custom := &dummyTransport{}
+		if _, err := NewToolboxClient("u", WithCustomTransport(nil)); err == nil {
+			t.Error("Expected an error for a nil transport")
+		}
+		_, err := NewToolboxClient("u", WithCustomTransport(custom), WithCustomTransport(custom))
+		if err == nil || !strings.Contains(err.Error(), "custom transport is already set") {
+			t.Errorf("Expected a duplicate transport error, got %v", err)
+		}
+		_, err = NewToolboxClient("u", WithCustomTransport(custom), WithProtocol(MCP))
+		if err == nil || !strings.Contains(err.Error(), "cannot be combined with WithProtocol") {
+			t.Errorf("Expected a conflict error, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,361 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/googleapis/mcp-toolbox-sdk-go/core/transport"
+)
+
+// resourceUpdatedMethod is the notification a server sends when a subscribed
+// resource changes.
+const resourceUpdatedMethod = "notifications/resources/updated"
+
+// Resource is a piece of data offered by the server, identified by a URI.
+type Resource struct {
+	descriptor transport.ResourceDescriptor
+	client     *ToolboxClient
+}
+
+// URI returns the URI identifying the resource.
+func (r *Resource) URI() string {
+	return r.descriptor.URI
+}
+
+// Name returns the name of the resource.
+func (r *Resource) Name() string {
+	return r.descriptor.Name
+}
+
+// Title returns the human-readable title of the resource, if any.
+func (r *Resource) Title() string {
+	return r.descriptor.Title
+}
+
+// Description returns the description of the resource.
+func (r *Resource) Description() string {
+	return r.descriptor.Description
+}
+
+// MimeType returns the MIME type of the resource, if known.
+func (r *Resource) MimeType() string {
+	return r.descriptor.MimeType
+}
+
+// Size returns the size of the resource in bytes, or 0 if unknown.
+func (r *Resource) Size() int64 {
+	return r.descriptor.Size
+}
+
+// Read fetches the current contents of the resource.
+func (r *Resource) Read(ctx context.Context) ([]ResourceContents, error) {
+	return r.client.ReadResource(ctx, r.URI())
+}
+
+// Subscribe calls onUpdate each time the server reports that the resource
+// changed. See ToolboxClient.SubscribeResource.
+func (r *Resource) Subscribe(ctx context.Context, onUpdate func()) error {
+	return r.client.SubscribeResource(ctx, r.URI(), onUpdate)
+}
+
+// Unsubscribe cancels a subscription made with Subscribe.
+func (r *Resource) Unsubscribe(ctx context.Context) error {
+	return r.client.UnsubscribeResource(ctx, r.URI())
+}
+
+// ResourceTemplate describes a family of resources whose URIs are built from
+// an RFC 6570 URI template, such as "db://table/{name}".
+type ResourceTemplate struct {
+	descriptor transport.ResourceTemplate
+	client     *ToolboxClient
+}
+
+// URITemplate returns the URI template of the resources.
+func (rt *ResourceTemplate) URITemplate() string {
+	return rt.descriptor.URITemplate
+}
+
+// Name returns the name of the template.
+func (rt *ResourceTemplate) Name() string {
+	return rt.descriptor.Name
+}
+
+// Title returns the human-readable title of the template, if any.
+func (rt *ResourceTemplate) Title() string {
+	return rt.descriptor.Title
+}
+
+// Description returns the description of the template.
+func (rt *ResourceTemplate) Description() string {
+	return rt.descriptor.Description
+}
+
+// MimeType returns the MIME type of the resources, if known.
+func (rt *ResourceTemplate) MimeType() string {
+	return rt.descriptor.MimeType
+}
+
+// Expand builds the URI of a resource by substituting vars into the URI
+// template. See ExpandURITemplate.
+func (rt *ResourceTemplate) Expand(vars map[string]string) (string, error) {
+	return ExpandURITemplate(rt.descriptor.URITemplate, vars)
+}
+
+// Read expands the URI template with vars and fetches the contents of the
+// resulting resource.
+func (rt *ResourceTemplate) Read(ctx context.Context, vars map[string]string) ([]ResourceContents, error) {
+	uri, err := rt.Expand(vars)
+	if err != nil {
+		return nil, err
+	}
+	return rt.client.ReadResource(ctx, uri)
+}
+
+// resourceSource returns the transport as a ResourceSource, or an error if it
+// cannot fetch resources.
+func (tc *ToolboxClient) resourceSource() (transport.ResourceSource, error) {
+	source, ok := tc.transport.(transport.ResourceSource)
+	if !ok {
+		return nil, fmt.Errorf("transport %T does not support resources", tc.transport)
+	}
+	return source, nil
+}
+
+// ListResources fetches the resources offered by the server.
+//
+// Inputs:
+//   - ctx: The context to control the lifecycle of the request.
+//
+// Returns:
+//
+//	The resources and a nil error on success, or a nil slice and an error if
+//	the request fails.
+func (tc *ToolboxClient) ListResources(ctx context.Context) ([]*Resource, error) {
+	source, err := tc.resourceSource()
+	if err != nil {
+		return nil, err
+	}
+	resolvedHeaders, err := resolveClientHeaders(ctx, tc.headerSources())
+	if err != nil {
+		return nil, err
+	}
+
+	descriptors, err := source.ListResources(tc.limitResponseSize(ctx), resolvedHeaders)
+	if err != nil {
+		return nil, err
+	}
+	resources := make([]*Resource, len(descriptors))
+	for i, d := range descriptors {
+		resources[i] = &Resource{descriptor: d, client: tc}
+	}
+	return resources, nil
+}
+
+// ListResourceTemplates fetches the resource templates offered by the server.
+//
+// Inputs:
+//   - ctx: The context to control the lifecycle of the request.
+//
+// Returns:
+//
+//	The templates and a nil error on success, or a nil slice and an error if
+//	the request fails.
+func (tc *ToolboxClient) ListResourceTemplates(ctx context.Context) ([]*ResourceTemplate, error) {
+	source, err := tc.resourceSource()
+	if err != nil {
+		return nil, err
+	}
+	resolvedHeaders, err := resolveClientHeaders(ctx, tc.headerSources())
+	if err != nil {
+		return nil, err
+	}
+
+	descriptors, err := source.ListResourceTemplates(tc.limitResponseSize(ctx), resolvedHeaders)
+	if err != nil {
+		return nil, err
+	}
+	templates := make([]*ResourceTemplate, len(descriptors))
+	for i, d := range descriptors {
+		templates[i] = &ResourceTemplate{descriptor: d, client: tc}
+	}
+	return templates, nil
+}
+
+// ReadResource fetches the contents of the resource identified by uri. A
+// resource may consist of several parts, each with its own URI.
+//
+// Inputs:
+//   - ctx: The context to control the lifecycle of the request.
+//   - uri: The URI of the resource.
+//
+// Returns:
+//
+//	The contents and a nil error on success, or a nil slice and an error if
+//	the request fails.
+func (tc *ToolboxClient) ReadResource(ctx context.Context, uri string) ([]ResourceContents, error) {
+	if uri == "" {
+		return nil, fmt.Errorf("ReadResource: resource URI cannot be empty")
+	}
+	source, err := tc.resourceSource()
+	if err != nil {
+		return nil, err
+	}
+	resolvedHeaders, err := resolveClientHeaders(ctx, tc.headerSources())
+	if err != nil {
+		return nil, err
+	}
+	return source.ReadResource(tc.limitResponseSize(ctx), uri, resolvedHeaders)
+}
+
+// SubscribeResource asks the server to report changes to the resource
+// identified by uri, and calls onUpdate each time it does. Subscribing to the
+// same URI again replaces the previous callback.
+//
+// Updates are only received over transports that keep a connection open,
+// such as the SSE, WebSocket and stdio transports.
+//
+// Inputs:
+//   - ctx: The context to control the lifecycle of the request.
+//   - uri: The URI of the resource.
+//   - onUpdate: The callback to run when the resource changes.
+//
+// Returns:
+//
+//	A nil error on success, or an error if the subscription fails.
+func (tc *ToolboxClient) SubscribeResource(ctx context.Context, uri string, onUpdate func()) error {
+	if uri == "" {
+		return fmt.Errorf("SubscribeResource: resource URI cannot be empty")
+	}
+	if onUpdate == nil {
+		return fmt.Errorf("SubscribeResource: provided callback cannot be nil")
+	}
+	source, err := tc.resourceSource()
+	if err != nil {
+		return err
+	}
+	notifications, ok := tc.transport.(transport.NotificationSource)
+	if !ok {
+		return fmt.Errorf("transport %T does not deliver notifications", tc.transport)
+	}
+	resolvedHeaders, err := resolveClientHeaders(ctx, tc.headerSources())
+	if err != nil {
+		return err
+	}
+
+	// Register the callback first so that no update sent right after the
+	// subscription is missed.
+	tc.subscriptionsMu.Lock()
+	if tc.subscriptions == nil {
+		tc.subscriptions = make(map[string]func())
+	}
+	previous, hadPrevious := tc.subscriptions[uri]
+	tc.subscriptions[uri] = onUpdate
+	tc.subscriptionsMu.Unlock()
+	notifications.SetNotificationHandler(tc.handleServerNotification)
+
+	if err := source.SubscribeResource(ctx, uri, resolvedHeaders); err != nil {
+		tc.subscriptionsMu.Lock()
+		if hadPrevious {
+			tc.subscriptions[uri] = previous
+		} else {
+			delete(tc.subscriptions, uri)
+		}
+		tc.subscriptionsMu.Unlock()
+		return err
+	}
+	return nil
+}
+
+// UnsubscribeResource cancels a subscription made with SubscribeResource.
+// The callback is removed even if the server request fails.
+//
+// Inputs:
+//   - ctx: The context to control the lifecycle of the request.
+//   - uri: The URI of the resource.
+//
+// Returns:
+//
+//	A nil error on success, or an error if the request fails.
+func (tc *ToolboxClient) UnsubscribeResource(ctx context.Context, uri string) error {
+	source, err := tc.resourceSource()
+	if err != nil {
+		return err
+	}
+
+	tc.subscriptionsMu.Lock()
+	_, subscribed := tc.subscriptions[uri]
+	delete(tc.subscriptions, uri)
+	tc.subscriptionsMu.Unlock()
+	if !subscribed {
+		return fmt.Errorf("UnsubscribeResource: not subscribed to resource '%s'", uri)
+	}
+
+	resolvedHeaders, err := resolveClientHeaders(ctx, tc.headerSources())
+	if err != nil {
+		return err
+	}
+	return source.UnsubscribeResource(ctx, uri, resolvedHeaders)
+}
+
+// handleResourceUpdated runs the callback subscribed to the resource named in
+// a notifications/resources/updated notification.
+func (tc *ToolboxClient) handleResourceUpdated(params json.RawMessage) {
+	var updated struct {
+		URI string `json:"uri"`
+	}
+	if err := json.Unmarshal(params, &updated); err != nil {
+		return
+	}
+
+	tc.subscriptionsMu.Lock()
+	onUpdate := tc.subscriptions[updated.URI]
+	tc.subscriptionsMu.Unlock()
+	if onUpdate != nil {
+		onUpdate()
+	}
+}
+
+// resolveResourceLinks replaces the resource links in an invocation result
+// with the contents of the linked resources, each embedded as a "resource"
+// content block. Results without content blocks are returned as they are.
+func (tt *ToolboxTool) resolveResourceLinks(ctx context.Context, result any, headers map[string]string) (any, error) {
+	blocks, ok := result.([]ContentBlock)
+	if !ok {
+		return result, nil
+	}
+
+	resolved := make([]ContentBlock, 0, len(blocks))
+	for _, block := range blocks {
+		if block.Type != "resource_link" {
+			resolved = append(resolved, block)
+			continue
+		}
+		source, ok := tt.transport.(transport.ResourceSource)
+		if !ok {
+			return nil, fmt.Errorf("transport %T does not support resources", tt.transport)
+		}
+		contents, err := source.ReadResource(ctx, block.URI, headers)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve resource link '%s': %w", block.URI, err)
+		}
+		for i := range contents {
+			resolved = append(resolved, ContentBlock{Type: "resource", Resource: &contents[i]})
+		}
+	}
+	return resolved, nil
+}
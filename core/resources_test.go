@@ -0,0 +1,263 @@
+//go:build unit
+
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/googleapis/mcp-toolbox-sdk-go/core/transport"
+)
+
+// resourceTransport is a notifyingTransport that serves resources.
+type resourceTransport struct {
+	notifyingTransport
+	subscribed   []string
+	unsubscribed []string
+	subscribeErr error
+}
+
+func (r *resourceTransport) ListResources(ctx context.Context, headers map[string]string) ([]transport.ResourceDescriptor, error) {
+	return []transport.ResourceDescriptor{{
+		URI:      "file:///notes.txt",
+		Name:     "notes",
+		Title:    "Notes",
+		MimeType: "text/plain",
+		Size:     5,
+	}}, nil
+}
+
+func (r *resourceTransport) ListResourceTemplates(ctx context.Context, headers map[string]string) ([]transport.ResourceTemplate, error) {
+	return []transport.ResourceTemplate{{
+		URITemplate: "db://table/{name}",
+		Name:        "table",
+		MimeType:    "application/json",
+	}}, nil
+}
+
+func (r *resourceTransport) ReadResource(ctx context.Context, uri string, headers map[string]string) ([]ResourceContents, error) {
+	return []ResourceContents{{URI: uri, MimeType: "text/plain", Text: "hello"}}, nil
+}
+
+func (r *resourceTransport) SubscribeResource(ctx context.Context, uri string, headers map[string]string) error {
+	r.subscribed = append(r.subscribed, uri)
+	return r.subscribeErr
+}
+
+func (r *resourceTransport) UnsubscribeResource(ctx context.Context, uri string, headers map[string]string) error {
+	r.unsubscribed = append(r.unsubscribed, uri)
+	return nil
+}
+
+func newResourceClient(t *testing.T, tr transport.Transport) *ToolboxClient {
+	t.Helper()
+	client, err := NewToolboxClient("https://custom", WithCustomTransport(tr))
+	if err != nil {
+		t.Fatalf("NewToolboxClient failed: %v", err)
+	}
+	return client
+}
+
+func TestListResources(t *testing.T) {
+	client := newResourceClient(t, &resourceTransport{})
+
+	resources, err := client.ListResources(context.Background())
+	if err != nil {
+		t.Fatalf("ListResources failed: %v", err)
+	}
+	if len(resources) != 1 {
+		t.Fatalf("Expected 1 resource, got %d", len(resources))
+	}
+	r := resources[0]
+	if r.URI() != "file:///notes.txt" || r.Name() != "notes" || r.Title() != "Notes" || r.MimeType() != "text/plain" || r.Size() != 5 {
+		t.Errorf("Unexpected resource metadata: %+v", r.descriptor)
+	}
+
+	contents, err := r.Read(context.Background())
+	if err != nil {
+		t.Fatalf("Read failed: %v", err)
+	}
+	if len(contents) != 1 || contents[0].Text != "hello" {
+		t.Errorf("Unexpected contents: %+v", contents)
+	}
+}
+
+func TestListResourceTemplates(t *testing.T) {
+	client := newResourceClient(t, &resourceTransport{})
+
+	templates, err := client.ListResourceTemplates(context.Background())
+	if err != nil {
+		t.Fatalf("ListResourceTemplates failed: %v", err)
+	}
+	if len(templates) != 1 {
+		t.Fatalf("Expected 1 template, got %d", len(templates))
+	}
+	rt := templates[0]
+	if rt.URITemplate() != "db://table/{name}" || rt.Name() != "table" || rt.MimeType() != "application/json" {
+		t.Errorf("Unexpected template metadata: %+v", rt.descriptor)
+	}
+
+	contents, err := rt.Read(context.Background(), map[string]string{"name": "orders"})
+	if err != nil {
+		t.Fatalf("Read failed: %v", err)
+	}
+	if len(contents) != 1 || contents[0].URI != "db://table/orders" {
+		t.Errorf("Expected the expanded URI to be read, got %+v", contents)
+	}
+
+	malformed := &ResourceTemplate{descriptor: transport.ResourceTemplate{URITemplate: "db://{name"}, client: client}
+	if _, err := malformed.Read(context.Background(), nil); err == nil || !strings.Contains(err.Error(), "unclosed expression") {
+		t.Errorf("Expected a template error, got %v", err)
+	}
+}
+
+func TestReadResource_Errors(t *testing.T) {
+	client := newResourceClient(t, &resourceTransport{})
+	if _, err := client.ReadResource(context.Background(), ""); err == nil || !strings.Contains(err.Error(), "URI cannot be empty") {
+		t.Errorf("Expected an empty URI error, got %v", err)
+	}
+
+	client = newResourceClient(t, &dummyTransport{})
+	if _, err := client.ReadResource(context.Background(), "file:///a"); err == nil || !strings.Contains(err.Error(), "does not support resources") {
+		t.Errorf("Expected an unsupported error, got %v", err)
+	}
+}
+
+func TestSubscribeResource(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Runs the callback for updates to the resource", func(t *testing.T) {
+		tr := &resourceTransport{}
+		client := newResourceClient(t, tr)
+		resources, _ := client.ListResources(ctx)
+
+		var updates int
+		if err := resources[0].Subscribe(ctx, func() { updates++ }); err != nil {
+			t.Fatalf("Subscribe failed: %v", err)
+		}
+		if len(tr.subscribed) != 1 || tr.subscribed[0] != "file:///notes.txt" {
+			t.Errorf("Expected a subscribe request, got %v", tr.subscribed)
+		}
+
+		tr.handler(transport.Notification{Method: "notifications/resources/updated", Params: []byte(`{"uri":"file:///other.txt"}`)})
+		tr.handler(transport.Notification{Method: "notifications/resources/updated", Params: []byte(`{"uri":"file:///notes.txt"}`)})
+		if updates != 1 {
+			t.Errorf("Expected 1 update, got %d", updates)
+		}
+
+		if err := resources[0].Unsubscribe(ctx); err != nil {
+			t.Fatalf("Unsubscribe failed: %v", err)
+		}
+		tr.handler(transport.Notification{Method: "notifications/resources/updated", Params: []byte(`{"uri":"file:///notes.txt"}`)})
+		if updates != 1 {
+			t.Errorf("Expected no updates after unsubscribing, got %d", updates)
+		}
+		if len(tr.unsubscribed) != 1 {
+			t.Errorf("Expected an unsubscribe request, got %v", tr.unsubscribed)
+		}
+	})
+
+	t.Run("Negative Test - Failed subscription", func(t *testing.T) {
+		tr := &resourceTransport{subscribeErr: errors.New("boom")}
+		client := newResourceClient(t, tr)
+		if err := client.SubscribeResource(ctx, "file:///a", func() {}); err == nil {
+			t.Fatal("Expected an error")
+		}
+		if len(client.subscriptions) != 0 {
+			t.Errorf("Expected the callback to be removed, got %v", client.subscriptions)
+		}
+	})
+
+	t.Run("Negative Test - Invalid usage", func(t *testing.T) {
+		client := newResourceClient(t, &resourceTransport{})
+		if err := client.SubscribeResource(ctx, "file:///a", nil); err == nil || !strings.Contains(err.Error(), "callback cannot be nil") {
+			t.Errorf("Expected a nil callback error, got %v", err)
+		}
+		if err := client.UnsubscribeResource(ctx, "file:///a"); err == nil || !strings.Contains(err.Error(), "not subscribed") {
+			t.Errorf("Expected a not subscribed error, got %v", err)
+		}
+	})
+}
+
+// linkingTransport is a resourceTransport whose tools return resource links.
+type linkingTransport struct {
+	resourceTransport
+}
+
+func (l *linkingTransport) InvokeTool(ctx context.Context, name string, p map[string]any, h map[string]string) (any, error) {
+	return []ContentBlock{
+		{Type: "text", Text: "See the notes."},
+		{Type: "resource_link", URI: "file:///notes.txt", Name: "notes"},
+	}, nil
+}
+
+func TestResolveResourceLinks(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Keeps resource links by default", func(t *testing.T) {
+		tool := &ToolboxTool{name: "report", transport: &linkingTransport{}}
+		result, err := tool.Invoke(ctx, nil)
+		if err != nil {
+			t.Fatalf("Invoke failed: %v", err)
+		}
+		if blocks := result.([]ContentBlock); blocks[1].Type != "resource_link" {
+			t.Errorf("Expected the resource link to be kept, got %+v", blocks[1])
+		}
+	})
+
+	t.Run("Embeds the linked resources", func(t *testing.T) {
+		tool := &ToolboxTool{name: "report", transport: &linkingTransport{}}
+		result, err := tool.Invoke(ctx, nil, WithResolveResourceLinks())
+		if err != nil {
+			t.Fatalf("Invoke failed: %v", err)
+		}
+		blocks := result.([]ContentBlock)
+		if len(blocks) != 2 || blocks[0].Text != "See the notes." {
+			t.Fatalf("Unexpected blocks: %+v", blocks)
+		}
+		if blocks[1].Type != "resource" || blocks[1].Resource.URI != "file:///notes.txt" || blocks[1].Resource.MimeType != "text/plain" {
+			t.Errorf("Unexpected embedded resource: %+v", blocks[1])
+		}
+		data, err := blocks[1].Resource.Bytes()
+		if err != nil || string(data) != "hello" {
+			t.Errorf("Unexpected resource content %q, %v", data, err)
+		}
+	})
+
+	t.Run("Negative Test - Transport without resources", func(t *testing.T) {
+		tr := &capturingTransport{result: []ContentBlock{{Type: "resource_link", URI: "file:///a"}}}
+		tool := &ToolboxTool{name: "report", transport: tr}
+		_, err := tool.Invoke(ctx, nil, WithResolveResourceLinks())
+		if err == nil || !strings.Contains(err.Error(), "does not support resources") {
+			t.Errorf("Expected an unsupported transport error, got %v", err)
+		}
+	})
+}
+
+func TestResourceContentsBytes(t *testing.T) {
+	blob := ResourceContents{URI: "file:///a.bin", Blob: "AAEC"}
+	if data, err := blob.Bytes(); err != nil || string(data) != "\x00\x01\x02" {
+		t.Errorf("Unexpected blob content %q, %v", data, err)
+	}
+
+	invalid := ResourceContents{URI: "file:///b.bin", Blob: "not base64!"}
+	if _, err := invalid.Bytes(); err == nil || !strings.Contains(err.Error(), "file:///b.bin") {
+		t.Errorf("Expected a decoding error, got %v", err)
+	}
+}
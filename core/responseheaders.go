@@ -0,0 +1,37 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"context"
+
+	"github.com/googleapis/mcp-toolbox-sdk-go/core/transport"
+)
+
+// WithResponseHeaders returns a copy of ctx that records in headers the
+// response headers sent by the server for the requests made with it, such as
+// its request ID and remaining rate limit. Pass the context to LoadTool,
+// LoadToolset or Invoke to inspect the headers of a manifest load or an
+// invocation:
+//
+//	var headers core.ResponseHeaders
+//	result, err := tool.Invoke(core.WithResponseHeaders(ctx, &headers), input)
+//	remaining, ok := headers.RateLimitRemaining()
+//
+// Only the headers of the most recent response are kept. Nothing is recorded
+// over transports that do not use HTTP, such as stdio.
+func WithResponseHeaders(ctx context.Context, headers *ResponseHeaders) context.Context {
+	return transport.WithResponseHeaders(ctx, headers)
+}
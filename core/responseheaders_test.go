@@ -0,0 +1,58 @@
+//go:build unit
+
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestWithResponseHeaders(t *testing.T) {
+	mock := newMockMCPServer(t, []mcpTool{{Name: "search", InputSchema: map[string]any{"type": "object"}}})
+	defer mock.Close()
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("X-Request-Id", fmt.Sprintf("req-%d", requests))
+		w.Header().Set("X-RateLimit-Remaining", "99")
+		w.Header().Set("Server-Timing", "db;dur=12")
+		mock.Config.Handler.ServeHTTP(w, r)
+	}))
+	defer server.Close()
+
+	client, err := NewToolboxClient(server.URL)
+	if err != nil {
+		t.Fatalf("NewToolboxClient failed: %v", err)
+	}
+	var headers ResponseHeaders
+	if _, err := client.LoadToolset("", WithResponseHeaders(context.Background(), &headers)); err != nil {
+		t.Fatalf("LoadToolset failed: %v", err)
+	}
+
+	if got := headers.RequestID(); got != fmt.Sprintf("req-%d", requests) {
+		t.Errorf("Expected the request ID of the last response, got %q after %d requests", got, requests)
+	}
+	if remaining, ok := headers.RateLimitRemaining(); !ok || remaining != 99 {
+		t.Errorf("Expected 99 remaining requests, got %d, %t", remaining, ok)
+	}
+	if got := headers.ServerTiming(); got != "db;dur=12" {
+		t.Errorf("Expected server timing 'db;dur=12', got %q", got)
+	}
+}
@@ -0,0 +1,37 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+)
+
+// rootsMethod is the request a server sends to list the client's roots.
+const rootsMethod = "roots/list"
+
+// Root is a directory the server may operate in, such as a workspace.
+type Root struct {
+	// URI identifies the root and must be a file:// URI.
+	URI string `json:"uri"`
+	// Name is an optional human-readable name for the root.
+	Name string `json:"name,omitempty"`
+}
+
+// handleRootsRequest answers roots/list requests with the configured roots.
+func (tc *ToolboxClient) handleRootsRequest(context.Context, json.RawMessage) (any, error) {
+	return map[string]any{"roots": slices.Clone(tc.roots)}, nil
+}
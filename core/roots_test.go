@@ -0,0 +1,75 @@
+//go:build unit
+
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestWithRoots(t *testing.T) {
+	t.Run("Lists the roots", func(t *testing.T) {
+		tr := &receivingTransport{}
+		roots := []Root{{URI: "file:///home/user/project", Name: "project"}, {URI: "file:///tmp"}}
+		_, err := NewToolboxClient("https://custom", WithCustomTransport(tr), WithRoots(roots...))
+		if err != nil {
+			t.Fatalf("NewToolboxClient failed: %v", err)
+		}
+		roots[0].URI = "file:///changed"
+
+		if _, ok := tr.capabilities["roots"]; !ok {
+			t.Error("Expected the roots capability to be declared")
+		}
+		handler := tr.handlers["roots/list"]
+		if handler == nil {
+			t.Fatal("Expected a roots request handler")
+		}
+		result, err := handler(context.Background(), nil)
+		if err != nil {
+			t.Fatalf("Handler failed: %v", err)
+		}
+		encoded, _ := json.Marshal(result)
+		expected := `{"roots":[{"uri":"file:///home/user/project","name":"project"},{"uri":"file:///tmp"}]}`
+		if string(encoded) != expected {
+			t.Errorf("Unexpected result: %s", encoded)
+		}
+	})
+
+	t.Run("Negative Test - Invalid usage", func(t *testing.T) {
+		root := Root{URI: "file:///tmp"}
+		testCases := []struct {
+			name    string
+			opts    []ClientOption
+			wantErr string
+		}{
+			{"No roots", []ClientOption{WithRoots()}, "at least one root"},
+			{"Not a file URI", []ClientOption{WithRoots(Root{URI: "https://example.com"})}, "must start with file://"},
+			{"Duplicate", []ClientOption{WithRoots(root), WithRoots(root)}, "already set"},
+			{"Unsupported transport", []ClientOption{WithCustomTransport(&dummyTransport{}), WithRoots(root)}, "cannot answer server requests"},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				_, err := NewToolboxClient("https://custom", tc.opts...)
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Errorf("Expected error containing %q, got %v", tc.wantErr, err)
+				}
+			})
+		}
+	})
+}
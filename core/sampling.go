@@ -0,0 +1,131 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/googleapis/mcp-toolbox-sdk-go/core/transport"
+)
+
+// samplingMethod is the request a server sends to have the client sample an
+// LLM.
+const samplingMethod = "sampling/createMessage"
+
+// invalidParamsCode is the JSON-RPC error code for malformed request
+// parameters.
+const invalidParamsCode = -32602
+
+// SamplingMessage is a message of the conversation to sample from.
+type SamplingMessage struct {
+	// Role is either "user" or "assistant".
+	Role    string        `json:"role"`
+	Content PromptContent `json:"content"`
+}
+
+// ModelHint suggests a model, by full or partial name, for sampling.
+type ModelHint struct {
+	Name string `json:"name,omitempty"`
+}
+
+// ModelPreferences expresses the server's priorities when the client selects
+// a model. Priorities range from 0 to 1.
+type ModelPreferences struct {
+	Hints                []ModelHint `json:"hints,omitempty"`
+	CostPriority         *float64    `json:"costPriority,omitempty"`
+	SpeedPriority        *float64    `json:"speedPriority,omitempty"`
+	IntelligencePriority *float64    `json:"intelligencePriority,omitempty"`
+}
+
+// CreateMessageRequest is a request from the server to sample an LLM.
+type CreateMessageRequest struct {
+	Messages         []SamplingMessage `json:"messages"`
+	ModelPreferences *ModelPreferences `json:"modelPreferences,omitempty"`
+	SystemPrompt     string            `json:"systemPrompt,omitempty"`
+	// IncludeContext is "none", "thisServer" or "allServers".
+	IncludeContext string         `json:"includeContext,omitempty"`
+	Temperature    *float64       `json:"temperature,omitempty"`
+	MaxTokens      int            `json:"maxTokens"`
+	StopSequences  []string       `json:"stopSequences,omitempty"`
+	Metadata       map[string]any `json:"metadata,omitempty"`
+}
+
+// CreateMessageResult is the message sampled in answer to a
+// CreateMessageRequest.
+type CreateMessageResult struct {
+	// Role defaults to "assistant" if empty.
+	Role    string        `json:"role"`
+	Content PromptContent `json:"content"`
+	// Model is the name of the model that produced the message.
+	Model string `json:"model"`
+	// StopReason is, for example, "endTurn", "stopSequence" or "maxTokens".
+	StopReason string `json:"stopReason,omitempty"`
+}
+
+// SamplingHandler answers sampling requests from the server, typically by
+// calling an LLM. Returning an error rejects the request.
+type SamplingHandler func(ctx context.Context, req *CreateMessageRequest) (*CreateMessageResult, error)
+
+// registerRequestHandlers registers the handlers for the requests the server
+// may send to the client.
+func (tc *ToolboxClient) registerRequestHandlers() error {
+	if tc.samplingHandler == nil && tc.elicitationHandler == nil && tc.roots == nil && len(tc.clientCapabilities) == 0 {
+		return nil
+	}
+	receiver, ok := tc.transport.(transport.RequestReceiver)
+	if !ok {
+		return fmt.Errorf("transport %T cannot answer server requests", tc.transport)
+	}
+
+	for name, value := range tc.clientCapabilities {
+		receiver.SetClientCapability(name, value)
+	}
+	if tc.samplingHandler != nil {
+		receiver.SetClientCapability("sampling", map[string]any{})
+		receiver.SetRequestHandler(samplingMethod, tc.handleSamplingRequest)
+	}
+	if tc.elicitationHandler != nil {
+		receiver.SetClientCapability("elicitation", map[string]any{})
+		receiver.SetRequestHandler(elicitationMethod, tc.handleElicitationRequest)
+	}
+	if tc.roots != nil {
+		receiver.SetClientCapability("roots", map[string]any{})
+		receiver.SetRequestHandler(rootsMethod, tc.handleRootsRequest)
+	}
+	return nil
+}
+
+// handleSamplingRequest decodes a sampling request and passes it to the
+// configured SamplingHandler.
+func (tc *ToolboxClient) handleSamplingRequest(ctx context.Context, params json.RawMessage) (any, error) {
+	var req CreateMessageRequest
+	if err := json.Unmarshal(params, &req); err != nil {
+		return nil, &transport.ToolInvocationError{Code: invalidParamsCode, Message: fmt.Sprintf("invalid sampling request: %v", err)}
+	}
+
+	result, err := tc.samplingHandler(ctx, &req)
+	if err != nil {
+		return nil, err
+	}
+	if result == nil {
+		return nil, fmt.Errorf("sampling handler returned no result")
+	}
+	if result.Role == "" {
+		result.Role = "assistant"
+	}
+	return result, nil
+}
@@ -0,0 +1,131 @@
+//go:build unit
+
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/googleapis/mcp-toolbox-sdk-go/core/transport"
+)
+
+// receivingTransport is a dummyTransport that records the handlers and
+// capabilities registered for server requests.
+type receivingTransport struct {
+	dummyTransport
+	handlers     map[string]transport.ServerRequestHandler
+	capabilities map[string]map[string]any
+}
+
+func (r *receivingTransport) SetRequestHandler(method string, handler transport.ServerRequestHandler) {
+	if r.handlers == nil {
+		r.handlers = make(map[string]transport.ServerRequestHandler)
+	}
+	r.handlers[method] = handler
+}
+
+func (r *receivingTransport) SetClientCapability(name string, value map[string]any) {
+	if r.capabilities == nil {
+		r.capabilities = make(map[string]map[string]any)
+	}
+	r.capabilities[name] = value
+}
+
+func TestWithSamplingHandler(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Answers sampling requests", func(t *testing.T) {
+		tr := &receivingTransport{}
+		var got *CreateMessageRequest
+		_, err := NewToolboxClient("https://custom",
+			WithCustomTransport(tr),
+			WithSamplingHandler(func(_ context.Context, req *CreateMessageRequest) (*CreateMessageResult, error) {
+				got = req
+				return &CreateMessageResult{Content: PromptContent{Type: "text", Text: "4"}, Model: "test-model"}, nil
+			}),
+		)
+		if err != nil {
+			t.Fatalf("NewToolboxClient failed: %v", err)
+		}
+		if _, ok := tr.capabilities["sampling"]; !ok {
+			t.Error("Expected the sampling capability to be declared")
+		}
+		handler := tr.handlers["sampling/createMessage"]
+		if handler == nil {
+			t.Fatal("Expected a sampling request handler")
+		}
+
+		params := json.RawMessage(`{"messages":[{"role":"user","content":{"type":"text","text":"2+2?"}}],"systemPrompt":"Be brief","maxTokens":5}`)
+		result, err := handler(ctx, params)
+		if err != nil {
+			t.Fatalf("Handler failed: %v", err)
+		}
+		if got.MaxTokens != 5 || got.SystemPrompt != "Be brief" || got.Messages[0].Content.Text != "2+2?" {
+			t.Errorf("Unexpected decoded request: %+v", got)
+		}
+		res := result.(*CreateMessageResult)
+		if res.Role != "assistant" || res.Model != "test-model" || res.Content.Text != "4" {
+			t.Errorf("Unexpected result: %+v", res)
+		}
+	})
+
+	t.Run("Reports handler errors", func(t *testing.T) {
+		tr := &receivingTransport{}
+		_, err := NewToolboxClient("https://custom",
+			WithCustomTransport(tr),
+			WithSamplingHandler(func(context.Context, *CreateMessageRequest) (*CreateMessageResult, error) {
+				return nil, errors.New("declined")
+			}),
+		)
+		if err != nil {
+			t.Fatalf("NewToolboxClient failed: %v", err)
+		}
+		handler := tr.handlers["sampling/createMessage"]
+
+		if _, err := handler(ctx, json.RawMessage(`{"maxTokens":1}`)); err == nil || err.Error() != "declined" {
+			t.Errorf("Expected the handler error, got %v", err)
+		}
+		var invocationErr *ToolInvocationError
+		if _, err := handler(ctx, json.RawMessage(`{"messages":"bad"}`)); !errors.As(err, &invocationErr) || invocationErr.Code != -32602 {
+			t.Errorf("Expected an invalid params error, got %v", err)
+		}
+	})
+
+	t.Run("Negative Test - Invalid usage", func(t *testing.T) {
+		handler := func(context.Context, *CreateMessageRequest) (*CreateMessageResult, error) { return nil, nil }
+		testCases := []struct {
+			name    string
+			opts    []ClientOption
+			wantErr string
+		}{
+			{"Nil handler", []ClientOption{WithSamplingHandler(nil)}, "cannot be nil"},
+			{"Duplicate handler", []ClientOption{WithSamplingHandler(handler), WithSamplingHandler(handler)}, "already set"},
+			{"Unsupported transport", []ClientOption{WithCustomTransport(&dummyTransport{}), WithSamplingHandler(handler)}, "cannot answer server requests"},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				_, err := NewToolboxClient("https://custom", tc.opts...)
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Errorf("Expected error containing %q, got %v", tc.wantErr, err)
+				}
+			})
+		}
+	})
+}
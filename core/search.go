@@ -0,0 +1,118 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// Weights of a query term found in the different parts of a tool.
+const (
+	searchNameWeight        = 3
+	searchDescriptionWeight = 2
+	searchParameterWeight   = 1
+)
+
+// Search returns the tools in the set that match query, best matches first.
+// The query is split into words, and each word scores a tool if a word of
+// its name, description, or parameter names and descriptions starts with
+// it, ignoring case. Matches in names count the most. Tools with equal
+// scores are sorted by name, and tools that match no word are left out.
+//
+// Search is meant for picking candidate tools when a toolset is too large
+// to give to a model as a whole.
+func (ts Toolset) Search(query string) Toolset {
+	terms := searchWords(query)
+	type match struct {
+		tool  *ToolboxTool
+		score int
+	}
+	var matches []match
+	for _, tool := range ts {
+		if tool == nil {
+			continue
+		}
+		if score := searchScore(tool, terms); score > 0 {
+			matches = append(matches, match{tool, score})
+		}
+	}
+	slices.SortStableFunc(matches, func(a, b match) int {
+		if c := cmp.Compare(b.score, a.score); c != 0 {
+			return c
+		}
+		return strings.Compare(a.tool.name, b.tool.name)
+	})
+
+	results := make(Toolset, len(matches))
+	for i, m := range matches {
+		results[i] = m.tool
+	}
+	return results
+}
+
+// SearchTools loads the default toolset, which holds all the tools of a
+// Toolbox server, and returns the tools that match query as described in
+// Toolset.Search. Use LoadToolset and Toolset.Search to search another
+// toolset.
+func (tc *ToolboxClient) SearchTools(ctx context.Context, query string, opts ...ToolOption) ([]*ToolboxTool, error) {
+	tools, err := tc.LoadToolset("", ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return Toolset(tools).Search(query), nil
+}
+
+// searchScore adds up the weights of the terms found in the tool.
+func searchScore(tool *ToolboxTool, terms []string) int {
+	name := searchWords(tool.name)
+	description := searchWords(tool.description)
+	var params []string
+	for _, p := range tool.parameters {
+		params = append(params, searchWords(p.Name)...)
+		params = append(params, searchWords(p.Description)...)
+	}
+
+	score := 0
+	for _, term := range terms {
+		switch {
+		case hasWordPrefix(name, term):
+			score += searchNameWeight
+		case hasWordPrefix(description, term):
+			score += searchDescriptionWeight
+		case hasWordPrefix(params, term):
+			score += searchParameterWeight
+		}
+	}
+	return score
+}
+
+// searchWords splits text into lowercase words at anything that is not a
+// letter or digit, so that "search-hotels" and "search_hotels" both contain
+// "search" and "hotels".
+func searchWords(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+func hasWordPrefix(words []string, prefix string) bool {
+	return slices.ContainsFunc(words, func(word string) bool {
+		return strings.HasPrefix(word, prefix)
+	})
+}
@@ -0,0 +1,78 @@
+//go:build unit
+
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"context"
+	"slices"
+	"testing"
+)
+
+func TestToolset_Search(t *testing.T) {
+	toolset := Toolset{
+		{name: "search-hotels", description: "Find hotels in a city."},
+		{name: "book-flight", description: "Book a flight to a destination."},
+		{
+			name:        "cancel-booking",
+			description: "Cancel a reservation.",
+			parameters:  []ParameterSchema{{Name: "hotel_id", Description: "The hotel to cancel."}},
+		},
+		{name: "list-cities", description: "List the cities Hotels are available in."},
+		nil,
+	}
+
+	testCases := []struct {
+		name  string
+		query string
+		want  []string
+	}{
+		{"Name outranks description and parameters", "hotel", []string{"search-hotels", "list-cities", "cancel-booking"}},
+		{"Terms add up", "cancel hotel", []string{"cancel-booking", "search-hotels", "list-cities"}},
+		{"Ignores case and punctuation", "BOOK, flight!", []string{"book-flight", "cancel-booking"}},
+		{"Ties are sorted by name", "in", []string{"list-cities", "search-hotels"}},
+		{"No match", "weather", []string{}},
+		{"Empty query", "", []string{}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := toolNames(toolset.Search(tc.query)); !slices.Equal(got, tc.want) {
+				t.Errorf("Expected %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestSearchTools(t *testing.T) {
+	emptySchema := map[string]any{"type": "object", "properties": map[string]any{}}
+	server := newMockMCPServer(t, []mcpTool{
+		{Name: "get-weather", Description: "Get the weather forecast.", InputSchema: emptySchema},
+		{Name: "search-hotels", Description: "Find hotels near a place.", InputSchema: emptySchema},
+	})
+	defer server.Close()
+
+	client, err := NewToolboxClient(server.URL, WithHTTPClient(server.Client()))
+	if err != nil {
+		t.Fatalf("NewToolboxClient failed: %v", err)
+	}
+	tools, err := client.SearchTools(context.Background(), "weather")
+	if err != nil {
+		t.Fatalf("SearchTools failed: %v", err)
+	}
+	if got := toolNames(tools); !slices.Equal(got, []string{"get-weather"}) {
+		t.Errorf("Expected [get-weather], got %v", got)
+	}
+}
@@ -0,0 +1,76 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/googleapis/mcp-toolbox-sdk-go/core/transport"
+)
+
+// serverInfo returns what the server declared in the handshake, performing
+// the handshake first if needed.
+func (tc *ToolboxClient) serverInfo(ctx context.Context) (*transport.ServerInfo, error) {
+	source, ok := tc.transport.(transport.ServerInfoSource)
+	if !ok {
+		return nil, fmt.Errorf("transport %T does not expose server information", tc.transport)
+	}
+	resolvedHeaders, err := resolveClientHeaders(ctx, tc.headerSources())
+	if err != nil {
+		return nil, err
+	}
+	return source.ServerInfo(ctx, resolvedHeaders)
+}
+
+// ServerCapabilities returns the capabilities the server declared in the MCP
+// handshake, such as "tools", "prompts" and "resources", keyed by name.
+//
+// Inputs:
+//   - ctx: The context to control the lifecycle of the handshake, if it has
+//     not been performed yet.
+//
+// Returns:
+//
+//	The capabilities and a nil error on success, or nil and an error if the
+//	handshake fails.
+func (tc *ToolboxClient) ServerCapabilities(ctx context.Context) (map[string]any, error) {
+	info, err := tc.serverInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return info.Capabilities, nil
+}
+
+// ServerInstructions returns the instructions the server provided in the MCP
+// handshake, which describe how to use its tools and are typically included
+// in an agent's system prompt.
+//
+// Inputs:
+//   - ctx: The context to control the lifecycle of the handshake, if it has
+//     not been performed yet.
+//
+// Returns:
+//
+//	The instructions, which are empty if the server provides none, and a nil
+//	error on success, or an empty string and an error if the handshake
+//	fails.
+func (tc *ToolboxClient) ServerInstructions(ctx context.Context) (string, error) {
+	info, err := tc.serverInfo(ctx)
+	if err != nil {
+		return "", err
+	}
+	return info.Instructions, nil
+}
@@ -0,0 +1,80 @@
+//go:build unit
+
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/googleapis/mcp-toolbox-sdk-go/core/transport"
+)
+
+// infoTransport is a dummyTransport that exposes server information.
+type infoTransport struct {
+	dummyTransport
+	headers map[string]string
+}
+
+func (i *infoTransport) ServerInfo(_ context.Context, headers map[string]string) (*transport.ServerInfo, error) {
+	i.headers = headers
+	return &transport.ServerInfo{
+		Capabilities: map[string]any{"tools": map[string]any{"listChanged": true}},
+		Instructions: "Use search before fetch.",
+	}, nil
+}
+
+func TestServerInfo(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Exposes the handshake results", func(t *testing.T) {
+		tr := &infoTransport{}
+		client, err := NewToolboxClient("https://custom", WithCustomTransport(tr), WithClientHeaderString("X-Key", "secret"))
+		if err != nil {
+			t.Fatalf("NewToolboxClient failed: %v", err)
+		}
+
+		capabilities, err := client.ServerCapabilities(ctx)
+		if err != nil {
+			t.Fatalf("ServerCapabilities failed: %v", err)
+		}
+		if !reflect.DeepEqual(capabilities, map[string]any{"tools": map[string]any{"listChanged": true}}) {
+			t.Errorf("Unexpected capabilities: %v", capabilities)
+		}
+		instructions, err := client.ServerInstructions(ctx)
+		if err != nil {
+			t.Fatalf("ServerInstructions failed: %v", err)
+		}
+		if instructions != "Use search before fetch." {
+			t.Errorf("Unexpected instructions: %q", instructions)
+		}
+		if tr.headers["X-Key"] != "secret" {
+			t.Errorf("Expected the client headers to be passed, got %v", tr.headers)
+		}
+	})
+
+	t.Run("Negative Test - Unsupported transport", func(t *testing.T) {
+		client, err := NewToolboxClient("https://custom", WithCustomTransport(&dummyTransport{}))
+		if err != nil {
+			t.Fatalf("NewToolboxClient failed: %v", err)
+		}
+		if _, err := client.ServerInstructions(ctx); err == nil || !strings.Contains(err.Error(), "does not expose server information") {
+			t.Errorf("Expected an unsupported transport error, got %v", err)
+		}
+	})
+}
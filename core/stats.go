@@ -0,0 +1,58 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import "time"
+
+// ToolStats is a snapshot of a tool's local invocation statistics.
+type ToolStats struct {
+	// Invocations is the number of times Invoke has been called.
+	Invocations int64
+	// Errors is the number of invocations that returned an error.
+	Errors int64
+	// TotalLatency is the cumulative wall-clock time spent in Invoke.
+	TotalLatency time.Duration
+	// LastError is the error returned by the most recent failed invocation.
+	LastError error
+}
+
+// AverageLatency returns the mean latency per invocation, or 0 if the tool
+// has not been invoked.
+func (s ToolStats) AverageLatency() time.Duration {
+	if s.Invocations == 0 {
+		return 0
+	}
+	return s.TotalLatency / time.Duration(s.Invocations)
+}
+
+// Stats returns a snapshot of the tool's invocation statistics. Tools derived
+// with ToolFrom start with their own, empty statistics.
+func (tt *ToolboxTool) Stats() ToolStats {
+	tt.statsMu.Lock()
+	defer tt.statsMu.Unlock()
+	return tt.stats
+}
+
+// recordInvocation updates the tool's statistics after an invocation.
+func (tt *ToolboxTool) recordInvocation(latency time.Duration, err error) {
+	tt.statsMu.Lock()
+	defer tt.statsMu.Unlock()
+	tt.stats.Invocations++
+	tt.stats.TotalLatency += latency
+	if err != nil {
+		tt.stats.Errors++
+		tt.stats.LastError = err
+	}
+}
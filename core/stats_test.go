@@ -0,0 +1,114 @@
+//go:build unit
+
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestToolboxTool_Stats(t *testing.T) {
+	t.Run("Starts empty", func(t *testing.T) {
+		tool := &ToolboxTool{}
+		stats := tool.Stats()
+		if stats.Invocations != 0 || stats.Errors != 0 || stats.TotalLatency != 0 || stats.LastError != nil {
+			t.Errorf("Expected empty stats, got %+v", stats)
+		}
+		if stats.AverageLatency() != 0 {
+			t.Errorf("Expected zero average latency, got %v", stats.AverageLatency())
+		}
+	})
+
+	t.Run("Records successes and failures", func(t *testing.T) {
+		tool := &ToolboxTool{}
+		failure := errors.New("boom")
+		tool.recordInvocation(10*time.Millisecond, nil)
+		tool.recordInvocation(30*time.Millisecond, failure)
+
+		stats := tool.Stats()
+		if stats.Invocations != 2 {
+			t.Errorf("Expected 2 invocations, got %d", stats.Invocations)
+		}
+		if stats.Errors != 1 {
+			t.Errorf("Expected 1 error, got %d", stats.Errors)
+		}
+		if stats.TotalLatency != 40*time.Millisecond {
+			t.Errorf("Expected 40ms total latency, got %v", stats.TotalLatency)
+		}
+		if stats.AverageLatency() != 20*time.Millisecond {
+			t.Errorf("Expected 20ms average latency, got %v", stats.AverageLatency())
+		}
+		if stats.LastError != failure {
+			t.Errorf("Expected last error %v, got %v", failure, stats.LastError)
+		}
+	})
+
+	t.Run("Invoke records failed invocations", func(t *testing.T) {
+		tool := &ToolboxTool{
+			name:                "secure",
+			requiredAuthzTokens: []string{"google"},
+			transport:           &dummyTransport{baseURL: "http://example.com"},
+		}
+
+		_, err := tool.Invoke(context.Background(), map[string]any{})
+		if err == nil {
+			t.Fatal("Expected Invoke to fail due to missing auth, but got nil")
+		}
+
+		stats := tool.Stats()
+		if stats.Invocations != 1 || stats.Errors != 1 {
+			t.Errorf("Expected 1 invocation and 1 error, got %+v", stats)
+		}
+		if stats.LastError == nil || !strings.Contains(stats.LastError.Error(), "permission error") {
+			t.Errorf("Expected permission error to be recorded, got %v", stats.LastError)
+		}
+	})
+
+	t.Run("Derived tools start with empty stats", func(t *testing.T) {
+		tool := &ToolboxTool{transport: &dummyTransport{baseURL: "http://example.com"}}
+		tool.recordInvocation(time.Millisecond, nil)
+
+		derived, err := tool.ToolFrom()
+		if err != nil {
+			t.Fatalf("ToolFrom failed unexpectedly: %v", err)
+		}
+		if derived.Stats().Invocations != 0 {
+			t.Errorf("Expected derived tool to start with no invocations, got %d", derived.Stats().Invocations)
+		}
+	})
+
+	t.Run("Is safe for concurrent use", func(t *testing.T) {
+		tool := &ToolboxTool{transport: &dummyTransport{baseURL: "http://example.com"}}
+		var wg sync.WaitGroup
+		for range 50 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, _ = tool.Invoke(context.Background(), map[string]any{})
+			}()
+		}
+		wg.Wait()
+
+		if got := tool.Stats().Invocations; got != 50 {
+			t.Errorf("Expected 50 invocations, got %d", got)
+		}
+	})
+}
@@ -15,11 +15,15 @@
 package core
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
 	"reflect"
+	"slices"
 	"strings"
+	"sync"
+	"time"
 
 	"maps"
 
@@ -30,6 +34,8 @@ import (
 // ToolboxTool represents an immutable, universal definition of a Toolbox tool.
 type ToolboxTool struct {
 	name                string
+	remoteName          string
+	title               string
 	description         string
 	parameters          []ParameterSchema
 	transport           transport.Transport
@@ -38,7 +44,28 @@ type ToolboxTool struct {
 	boundParamSchemas   map[string]ParameterSchema
 	requiredAuthnParams map[string][]string
 	requiredAuthzTokens []string
+	requiresAuth        bool
 	clientHeaderSources map[string]oauth2.TokenSource
+	// clientHeaders returns the current header sources of the client that
+	// loaded the tool, if any, and takes precedence over clientHeaderSources.
+	clientHeaders    func() map[string]oauth2.TokenSource
+	tags             []string
+	annotations      map[string]any
+	examples         []ToolExample
+	outputSchema     map[string]any
+	deprecation      *Deprecation
+	beforeInvoke     []BeforeInvokeHook
+	afterInvoke      []AfterInvokeHook
+	auditLoggers     []AuditLogger
+	maxResponseBytes int64
+	resultCache      *resultCache
+	invokeSem        chan struct{}
+	validateTokens   bool
+	typeCoercion     bool
+	tokenAudience    string
+
+	statsMu sync.Mutex
+	stats   ToolStats
 }
 
 // Name returns the tool's name.
@@ -46,11 +73,156 @@ func (tt *ToolboxTool) Name() string {
 	return tt.name
 }
 
+// Title returns the tool's human-readable display name, or an empty string
+// if the server did not provide one.
+func (tt *ToolboxTool) Title() string {
+	return tt.title
+}
+
 // Description returns the tool's description.
 func (tt *ToolboxTool) Description() string {
 	return tt.description
 }
 
+// Tags returns the tags the server associated with the tool.
+func (tt *ToolboxTool) Tags() []string {
+	return slices.Clone(tt.tags)
+}
+
+// Annotations returns the tool's MCP annotations, such as readOnlyHint,
+// destructiveHint and idempotentHint, merged with any custom metadata keys
+// advertised by the server.
+func (tt *ToolboxTool) Annotations() map[string]any {
+	return maps.Clone(tt.annotations)
+}
+
+// Hints returns the tool's behavior hints, as advertised in its MCP
+// annotations. Unset hints are nil; see ReadOnly, Destructive, Idempotent
+// and OpenWorld for the values with the MCP defaults applied.
+func (tt *ToolboxTool) Hints() ToolHints {
+	return ToolSchema{Annotations: tt.annotations}.Hints()
+}
+
+// ReadOnly reports whether the tool declares that it does not modify its
+// environment. It defaults to false.
+func (tt *ToolboxTool) ReadOnly() bool {
+	return hintOr(tt.Hints().ReadOnly, false)
+}
+
+// Destructive reports whether the tool may perform destructive updates. It
+// defaults to true, and is always false for read-only tools.
+func (tt *ToolboxTool) Destructive() bool {
+	return !tt.ReadOnly() && hintOr(tt.Hints().Destructive, true)
+}
+
+// Idempotent reports whether calling the tool repeatedly with the same
+// arguments has no additional effect. It defaults to false, and is always
+// true for read-only tools.
+func (tt *ToolboxTool) Idempotent() bool {
+	return tt.ReadOnly() || hintOr(tt.Hints().Idempotent, false)
+}
+
+// OpenWorld reports whether the tool may interact with external entities,
+// such as the web. It defaults to true.
+func (tt *ToolboxTool) OpenWorld() bool {
+	return hintOr(tt.Hints().OpenWorld, true)
+}
+
+// hintOr returns the value of a behavior hint, or def if it is unset.
+func hintOr(hint *bool, def bool) bool {
+	if hint == nil {
+		return def
+	}
+	return *hint
+}
+
+// Deprecated returns the deprecation the server announced for the tool, and
+// reports whether there is one.
+func (tt *ToolboxTool) Deprecated() (Deprecation, bool) {
+	if tt.deprecation == nil {
+		return Deprecation{}, false
+	}
+	return *tt.deprecation, true
+}
+
+// Examples returns the sample invocations the server advertised for the
+// tool, for use in function-calling definitions or few-shot prompts.
+func (tt *ToolboxTool) Examples() []ToolExample {
+	return slices.Clone(tt.examples)
+}
+
+// RequiredAuthnParams returns the parameters that must be authenticated by
+// an auth service before the tool can be invoked, mapped to the names of the
+// services that can provide them. Requirements already satisfied by the
+// tool's auth token sources are not included.
+func (tt *ToolboxTool) RequiredAuthnParams() map[string][]string {
+	params := make(map[string][]string, len(tt.requiredAuthnParams))
+	for name, services := range tt.requiredAuthnParams {
+		params[name] = slices.Clone(services)
+	}
+	return params
+}
+
+// RequiredAuthzServices returns the auth services that must authorize the
+// invocation of the tool and are not yet satisfied by the tool's auth token
+// sources.
+func (tt *ToolboxTool) RequiredAuthzServices() []string {
+	services := make([]string, len(tt.requiredAuthzTokens))
+	copy(services, tt.requiredAuthzTokens)
+	return services
+}
+
+// RequiresAuth reports whether the tool needs any auth token, whether or not
+// the tokens have already been provided.
+func (tt *ToolboxTool) RequiresAuth() bool {
+	return tt.requiresAuth || len(tt.requiredAuthnParams) > 0 || len(tt.requiredAuthzTokens) > 0
+}
+
+// AuthSatisfied reports whether the tool has every auth token it needs to be
+// invoked. If not, it also returns the sorted names of the missing auth
+// services.
+func (tt *ToolboxTool) AuthSatisfied() (bool, []string) {
+	missing := tt.missingAuthServices(tt.authTokenSources)
+	return len(missing) == 0, missing
+}
+
+// MissingAuthError is returned by Invoke when the tool requires auth services
+// for which no token source was provided.
+type MissingAuthError struct {
+	// Services are the sorted names of the missing auth services.
+	Services []string
+}
+
+func (e *MissingAuthError) Error() string {
+	if len(e.Services) == 0 {
+		return "permission error: an auth service is required to invoke this tool but was not provided"
+	}
+	return fmt.Sprintf("permission error: auth service '%s' is required to invoke this tool but was not provided", e.Services[0])
+}
+
+// missingAuthServices returns the sorted names of the auth services required
+// by the tool that have no corresponding source in authTokenSources.
+func (tt *ToolboxTool) missingAuthServices(authTokenSources map[string]oauth2.TokenSource) []string {
+	reqAuthServices := make(map[string]struct{})
+	for _, services := range tt.requiredAuthnParams {
+		for _, service := range services {
+			reqAuthServices[service] = struct{}{}
+		}
+	}
+	for _, service := range tt.requiredAuthzTokens {
+		reqAuthServices[service] = struct{}{}
+	}
+
+	var missing []string
+	for service := range reqAuthServices {
+		if _, ok := authTokenSources[service]; !ok {
+			missing = append(missing, service)
+		}
+	}
+	slices.Sort(missing)
+	return missing
+}
+
 // Parameters returns the list of parameters that must be provided by a user
 // at invocation time.
 func (tt *ToolboxTool) Parameters() []ParameterSchema {
@@ -92,6 +264,15 @@ func (tt *ToolboxTool) InputSchema() ([]byte, error) {
 	return json.MarshalIndent(finalSchema, "", "  ")
 }
 
+// OutputSchema returns the JSON Schema of the tool's structured output as raw
+// bytes, or nil if the server did not advertise one.
+func (tt *ToolboxTool) OutputSchema() ([]byte, error) {
+	if tt.outputSchema == nil {
+		return nil, nil
+	}
+	return json.MarshalIndent(tt.outputSchema, "", "  ")
+}
+
 // DescribeParameters returns a single, human-readable string that describes all
 // of the tool's unbound parameters, including their names, types, and
 // descriptions.
@@ -114,7 +295,8 @@ func (tt *ToolboxTool) DescribeParameters() string {
 // ToolFrom creates a new, more specialized tool from an existing one by applying
 // additional options. This is useful for creating variations of a tool with
 // different bound parameters without modifying the original and
-// all provided options must be applicable.
+// all provided options must be applicable. Existing bindings can only be
+// replaced or removed explicitly, using WithRebindParam or WithUnbindParam.
 //
 // Inputs:
 //   - opts: A variadic list of ToolOption functions to further configure the
@@ -137,6 +319,27 @@ func (tt *ToolboxTool) ToolFrom(opts ...ToolOption) (*ToolboxTool, error) {
 	if config.strictSet {
 		return nil, fmt.Errorf("ToolFrom: WithStrict option is not applicable as the behavior is always strict")
 	}
+	if config.tagFilterSet {
+		return nil, fmt.Errorf("ToolFrom: WithTagFilter option is only applicable to LoadToolset")
+	}
+	if config.toolFilterSet {
+		return nil, fmt.Errorf("ToolFrom: WithToolFilter option is only applicable to LoadToolset")
+	}
+	if config.ToolNameRegexp != nil {
+		return nil, fmt.Errorf("ToolFrom: WithToolNameRegexp option is only applicable to LoadToolset")
+	}
+	if config.AllowEmpty {
+		return nil, fmt.Errorf("ToolFrom: WithAllowEmptyToolset option is only applicable to LoadToolset")
+	}
+	if config.ToolNamePrefix != "" {
+		return nil, fmt.Errorf("ToolFrom: WithToolNamePrefix option is only applicable to LoadTool and LoadToolset")
+	}
+	if config.QueryParams != nil {
+		return nil, fmt.Errorf("ToolFrom: WithManifestQueryParams option is only applicable to LoadTool and LoadToolset")
+	}
+	if config.allowUnusedSet {
+		return nil, fmt.Errorf("ToolFrom: WithAllowUnusedBindings option is only applicable to LoadTool and LoadToolset")
+	}
 
 	// Clone the parent tool to create a new, mutable instance.
 	newTt := tt.cloneToolboxTool()
@@ -151,9 +354,54 @@ func (tt *ToolboxTool) ToolFrom(opts ...ToolOption) (*ToolboxTool, error) {
 		}
 	}
 
+	if config.MaxResponseBytes > 0 {
+		newTt.maxResponseBytes = config.MaxResponseBytes
+	}
+	if config.ResultCacheTTL > 0 {
+		if !isCacheable(newTt.annotations) {
+			return nil, fmt.Errorf("WithResultCache: tool '%s' is not marked read-only or idempotent, so its results cannot be cached", newTt.name)
+		}
+		newTt.resultCache = newResultCache(config.ResultCacheTTL, config.ResultCacheSize)
+	}
+	if config.MaxConcurrent > 0 {
+		newTt.invokeSem = make(chan struct{}, config.MaxConcurrent)
+	}
+	if config.ValidateTokens {
+		newTt.validateTokens = true
+		newTt.tokenAudience = config.TokenAudience
+	}
+	if config.TypeCoercion {
+		newTt.typeCoercion = true
+	}
+
+	// Hooks are additive: the derived tool runs the parent's hooks first.
+	newTt.beforeInvoke = append(newTt.beforeInvoke, config.BeforeInvoke...)
+	newTt.afterInvoke = append(newTt.afterInvoke, config.AfterInvoke...)
+	newTt.auditLoggers = append(newTt.auditLoggers, config.AuditLoggers...)
+
+	// Release bindings that were explicitly unbound, restoring the parameter
+	// to the list that must be provided at invocation time.
+	for _, name := range slices.Sorted(maps.Keys(config.UnboundParams)) {
+		schema, hasSchema := newTt.boundParamSchemas[name]
+		if _, isBound := newTt.boundParams[name]; !isBound || !hasSchema {
+			return nil, fmt.Errorf("unable to unbind parameter: no bound parameter named '%s' on the tool", name)
+		}
+		delete(newTt.boundParams, name)
+		delete(newTt.boundParamSchemas, name)
+		newTt.parameters = append(newTt.parameters, schema)
+	}
+
+	// Replace the values of existing bindings that were explicitly rebound.
+	for name, val := range config.ReboundParams {
+		if _, isBound := newTt.boundParams[name]; !isBound {
+			return nil, fmt.Errorf("unable to rebind parameter: no bound parameter named '%s' on the tool", name)
+		}
+		newTt.boundParams[name] = val
+	}
+
 	// Validate and merge new BoundParams, preventing overrides.
 	paramNames := make(map[string]ParameterSchema)
-	for _, p := range tt.parameters {
+	for _, p := range newTt.parameters {
 		paramNames[p.Name] = p
 	}
 
@@ -182,7 +430,7 @@ func (tt *ToolboxTool) ToolFrom(opts ...ToolOption) (*ToolboxTool, error) {
 
 	// Recalculate the remaining unbound parameters for the new tool.
 	var newParams []ParameterSchema
-	for _, p := range tt.parameters {
+	for _, p := range newTt.parameters {
 		if _, exists := newTt.boundParams[p.Name]; !exists {
 			newParams = append(newParams, p)
 		}
@@ -192,11 +440,31 @@ func (tt *ToolboxTool) ToolFrom(opts ...ToolOption) (*ToolboxTool, error) {
 	return newTt, nil
 }
 
+// renamed returns a copy of the tool under a new name, which still invokes
+// the server's tool under its original name.
+func (tt *ToolboxTool) renamed(name string) *ToolboxTool {
+	newTt := tt.cloneToolboxTool()
+	newTt.remoteName = tt.serverName()
+	newTt.name = name
+	return newTt
+}
+
+// serverName returns the name of the tool on the server, which differs from
+// Name for tools renamed by MergeTools or WithToolNamePrefix.
+func (tt *ToolboxTool) serverName() string {
+	if tt.remoteName != "" {
+		return tt.remoteName
+	}
+	return tt.name
+}
+
 // cloneToolboxTool creates a deep copy of the ToolboxTool instance to ensure
 // that derivative tools created with ToolFrom cannot mutate the parent.
 func (tt *ToolboxTool) cloneToolboxTool() *ToolboxTool {
 	newTt := &ToolboxTool{
 		name:                tt.name,
+		remoteName:          tt.remoteName,
+		title:               tt.title,
 		description:         tt.description,
 		transport:           tt.transport,
 		parameters:          make([]ParameterSchema, len(tt.parameters)),
@@ -206,6 +474,26 @@ func (tt *ToolboxTool) cloneToolboxTool() *ToolboxTool {
 		requiredAuthnParams: make(map[string][]string, len(tt.requiredAuthnParams)),
 		requiredAuthzTokens: make([]string, len(tt.requiredAuthzTokens)),
 		clientHeaderSources: make(map[string]oauth2.TokenSource, len(tt.clientHeaderSources)),
+		clientHeaders:       tt.clientHeaders,
+		tags:                slices.Clone(tt.tags),
+		annotations:         maps.Clone(tt.annotations),
+		examples:            slices.Clone(tt.examples),
+		outputSchema:        tt.outputSchema,
+		deprecation:         tt.deprecation,
+		beforeInvoke:        slices.Clone(tt.beforeInvoke),
+		afterInvoke:         slices.Clone(tt.afterInvoke),
+		auditLoggers:        slices.Clone(tt.auditLoggers),
+		maxResponseBytes:    tt.maxResponseBytes,
+		requiresAuth:        tt.requiresAuth,
+		validateTokens:      tt.validateTokens,
+		tokenAudience:       tt.tokenAudience,
+		typeCoercion:        tt.typeCoercion,
+		// The cache is keyed by the full payload, including bound parameters,
+		// so derived tools can safely share it with their parent.
+		resultCache: tt.resultCache,
+		// Derived tools call the same backend, so they share the parent's
+		// concurrency limit.
+		invokeSem: tt.invokeSem,
 	}
 
 	if tt.boundParamSchemas != nil {
@@ -249,35 +537,125 @@ func (tt *ToolboxTool) cloneToolboxTool() *ToolboxTool {
 // Invoke executes the tool with the given input.
 //
 // Inputs:
-//   - ctx: The context to control the lifecycle of the API request.
+//   - ctx: The context to control the lifecycle of the API request. If ctx is
+//     cancelled while the call is in flight, MCP servers are notified so they
+//     can stop working on it.
 //   - input: A map of parameter names to values provided by the user for this
 //     specific invocation.
+//   - opts: A variadic list of InvokeOption functions to configure this call,
+//     such as a timeout, extra headers or auth overrides.
 //
 // Returns:
 //
 //	The result from the API call, which can be a structured object (from a JSON
-//	'result' field) or a raw string. Returns an error if any step of the
-//	process fails.
-func (tt *ToolboxTool) Invoke(ctx context.Context, input map[string]any) (any, error) {
+//	'result' field) or a raw string. MCP tools whose result holds non-text
+//	content, such as images, return a []ContentBlock. Returns an error if any
+//	step of the process fails.
+func (tt *ToolboxTool) Invoke(ctx context.Context, input map[string]any, opts ...InvokeOption) (any, error) {
+	start := time.Now()
+	record := AuditRecord{Start: start}
+	result, err := tt.invoke(ctx, input, opts, &record)
+	tt.recordInvocation(time.Since(start), err)
+	if len(tt.auditLoggers) > 0 {
+		tt.audit(ctx, record, err)
+	}
+	return result, err
+}
 
-	// Ensure all authentication tokens required by the tool are available.
-	if len(tt.requiredAuthnParams) > 0 || len(tt.requiredAuthzTokens) > 0 {
-		reqAuthServices := make(map[string]struct{})
-		for _, services := range tt.requiredAuthnParams {
-			for _, service := range services {
-				reqAuthServices[service] = struct{}{}
-			}
+// InvokeRaw executes the tool with arguments supplied as a raw JSON object,
+// such as the function call arguments produced by an LLM.
+//
+// Inputs:
+//   - ctx: The context to control the lifecycle of the API request.
+//   - input: A JSON object mapping parameter names to values. An empty input
+//     or JSON null is treated as an empty object.
+//   - opts: A variadic list of InvokeOption functions to configure this call.
+//
+// Returns:
+//
+//	The result from the API call, as returned by Invoke, or an error if the
+//	input is not a valid JSON object or the invocation fails.
+func (tt *ToolboxTool) InvokeRaw(ctx context.Context, input json.RawMessage, opts ...InvokeOption) (any, error) {
+	args, err := tt.decodeRawInput(input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode tool input: %w", err)
+	}
+	return tt.Invoke(ctx, args, opts...)
+}
+
+// decodeRawInput decodes a raw JSON object into an input map. Numbers are
+// decoded to int64 or float64 according to the matching parameter schema so
+// that integer parameters pass type validation.
+func (tt *ToolboxTool) decodeRawInput(input json.RawMessage) (map[string]any, error) {
+	args := make(map[string]any)
+	if len(bytes.TrimSpace(input)) == 0 {
+		return args, nil
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(input))
+	decoder.UseNumber()
+	if err := decoder.Decode(&args); err != nil {
+		return nil, err
+	}
+	if args == nil {
+		// The input was JSON null.
+		return make(map[string]any), nil
+	}
+
+	for i := range tt.parameters {
+		p := &tt.parameters[i]
+		if v, ok := args[p.Name]; ok {
+			args[p.Name] = normalizeJSONNumbers(v, p)
 		}
-		for _, service := range tt.requiredAuthzTokens {
-			reqAuthServices[service] = struct{}{}
+	}
+	return args, nil
+}
+
+// invoke performs a single invocation of the tool without recording statistics.
+// It fills in the principal and parameters hash of record as they become known.
+func (tt *ToolboxTool) invoke(ctx context.Context, input map[string]any, opts []InvokeOption, record *AuditRecord) (any, error) {
+	config := newInvokeConfig()
+	for _, opt := range opts {
+		if opt == nil {
+			return nil, fmt.Errorf("Invoke: received a nil InvokeOption in options list")
+		}
+		if err := opt(config); err != nil {
+			return nil, err
 		}
+	}
+	record.Principal = config.Principal
 
-		// Check if each required service has a corresponding token source.
-		for service := range reqAuthServices {
-			if _, ok := tt.authTokenSources[service]; !ok {
-				return nil, fmt.Errorf("permission error: auth service '%s' is required to invoke this tool but was not provided", service)
-			}
+	if config.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, config.Timeout)
+		defer cancel()
+	}
+	if len(config.Meta) > 0 {
+		ctx = transport.WithRequestMeta(ctx, config.Meta)
+	}
+	ctx = transport.WithToolName(ctx, tt.serverName())
+	if tt.ReadOnly() || tt.Idempotent() {
+		ctx = transport.WithIdempotent(ctx, true)
+	}
+	if config.OnProgress != nil {
+		ctx = withProgressReporting(ctx, config.OnProgress, config.OnNotification)
+	} else if config.OnNotification != nil {
+		ctx = transport.WithNotificationHandler(ctx, config.OnNotification)
+	}
+
+	// Per-call auth token sources add to, or override, those of the tool.
+	authTokenSources := tt.authTokenSources
+	if len(config.AuthTokenSources) > 0 {
+		authTokenSources = maps.Clone(tt.authTokenSources)
+		if authTokenSources == nil {
+			authTokenSources = make(map[string]oauth2.TokenSource)
 		}
+		maps.Copy(authTokenSources, config.AuthTokenSources)
+	}
+
+	// Ensure all authentication tokens required by the tool are available.
+	if missing := tt.missingAuthServices(authTokenSources); len(missing) > 0 {
+		return nil, &MissingAuthError{Services: missing}
 	}
 
 	// Validate the user's input and merge it with pre-configured bound parameters.
@@ -286,11 +664,22 @@ func (tt *ToolboxTool) Invoke(ctx context.Context, input map[string]any) (any, e
 		return nil, fmt.Errorf("tool payload processing failed: %w", err)
 	}
 
+	// Run the before-invoke hooks, each receiving the previous hook's payload.
+	for _, hook := range tt.beforeInvoke {
+		finalPayload, err = hook(ctx, tt.name, finalPayload)
+		if err != nil {
+			return nil, fmt.Errorf("before-invoke hook failed: %w", err)
+		}
+	}
+	if len(tt.auditLoggers) > 0 {
+		record.ParametersHash = hashParameters(finalPayload)
+	}
+
 	resolvedHeaders := make(map[string]string)
 
 	// Resolve Client Headers
-	for k, source := range tt.clientHeaderSources {
-		token, err := source.Token()
+	for k, source := range tt.headerSources() {
+		token, err := headerToken(ctx, source)
 		if err != nil {
 			return nil, fmt.Errorf("failed to resolve client header %s: %w", k, err)
 		}
@@ -298,23 +687,85 @@ func (tt *ToolboxTool) Invoke(ctx context.Context, input map[string]any) (any, e
 	}
 
 	// Resolve Auth Headers
-	for name, source := range tt.authTokenSources {
+	for name, source := range authTokenSources {
 		token, err := source.Token()
 		if err != nil {
 			return nil, fmt.Errorf("failed to resolve auth token %s: %w", name, err)
 		}
+		if tt.validateTokens {
+			if err := validateAuthToken(name, token, tt.tokenAudience, time.Now()); err != nil {
+				return nil, err
+			}
+		}
 		// Toolbox HTTP protocol expects the suffix "_token"
 		headerName := fmt.Sprintf("%s_token", name)
 		resolvedHeaders[headerName] = token.AccessToken
 	}
 
-	checkSecureHeaders(tt.transport.BaseURL(), len(tt.authTokenSources) > 0)
+	// Apply per-call headers last so they take precedence.
+	maps.Copy(resolvedHeaders, config.Headers)
+	if config.IdempotencyKey != "" {
+		resolvedHeaders["Idempotency-Key"] = config.IdempotencyKey
+	}
+
+	checkSecureHeaders(tt.transport.BaseURL(), len(authTokenSources) > 0)
+
+	if tt.maxResponseBytes > 0 {
+		ctx = transport.WithMaxResponseBytes(ctx, tt.maxResponseBytes)
+	}
+
+	var cacheKey string
+	var response any
+	cached := false
+	if tt.resultCache != nil {
+		cacheKey, err = resultCacheKey(tt.serverName(), finalPayload, resolvedHeaders)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compute result cache key: %w", err)
+		}
+		response, cached = tt.resultCache.get(cacheKey, time.Now())
+	}
+
+	if !cached {
+		// Wait for a free slot if the tool limits concurrent invocations.
+		if tt.invokeSem != nil {
+			select {
+			case tt.invokeSem <- struct{}{}:
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+		}
+		response, err = tt.transport.InvokeTool(ctx, tt.serverName(), finalPayload, resolvedHeaders)
+		if tt.invokeSem != nil {
+			<-tt.invokeSem
+		}
+		if err != nil {
+			return nil, err
+		}
+		if tt.resultCache != nil {
+			tt.resultCache.put(cacheKey, response, time.Now())
+		}
+	}
+
+	if config.ResolveResourceLinks {
+		response, err = tt.resolveResourceLinks(ctx, response, resolvedHeaders)
+		if err != nil {
+			return nil, err
+		}
+	}
 
-	response, err := tt.transport.InvokeTool(ctx, tt.name, finalPayload, resolvedHeaders)
+	response, err = formatResult(response, config.ResultFormat)
 	if err != nil {
 		return nil, err
 	}
 
+	// Run the after-invoke hooks, each receiving the previous hook's result.
+	for _, hook := range tt.afterInvoke {
+		response, err = hook(ctx, tt.name, response)
+		if err != nil {
+			return nil, fmt.Errorf("after-invoke hook failed: %w", err)
+		}
+	}
+
 	return response, nil
 }
 
@@ -334,6 +785,19 @@ func (tt *ToolboxTool) validateAndBuildPayload(input map[string]any) (map[string
 		paramSchema[p.Name] = p
 	}
 
+	// Convert compatible inputs to the types of their parameters, without
+	// modifying the caller's map.
+	if tt.typeCoercion {
+		coerced := make(map[string]any, len(input))
+		for key, value := range input {
+			if param, ok := paramSchema[key]; ok {
+				value = coerceValue(value, &param)
+			}
+			coerced[key] = value
+		}
+		input = coerced
+	}
+
 	// Validate user input against the schema.
 	for key, value := range input {
 		param, isUnbound := paramSchema[key]
@@ -353,10 +817,12 @@ func (tt *ToolboxTool) validateAndBuildPayload(input map[string]any) (map[string
 		}
 	}
 
-	// Initialize the final payload with the validated user input.
+	// Initialize the final payload with the validated user input. An explicit
+	// nil is sent as null for nullable parameters, and is treated like an
+	// omitted parameter otherwise.
 	finalPayload := make(map[string]any, len(input)+len(tt.boundParams))
 	for k, v := range input {
-		if _, ok := paramSchema[k]; ok && v != nil {
+		if param, ok := paramSchema[k]; ok && (v != nil || param.Nullable) {
 			finalPayload[k] = v
 		}
 	}
@@ -407,7 +873,17 @@ func (tt *ToolboxTool) validateAndBuildPayload(input map[string]any) (map[string
 			resolvedValue, resolveErr = v()
 		case func() (map[string]any, error):
 			resolvedValue, resolveErr = v()
+		case func() ([]any, error):
+			resolvedValue, resolveErr = v()
+		case func() ([]map[string]any, error):
+			resolvedValue, resolveErr = v()
+		case func() (any, error):
+			resolvedValue, resolveErr = v()
 		default:
+			// Guard against sending an unresolved function value to the server.
+			if reflect.ValueOf(boundVal).Kind() == reflect.Func {
+				return nil, fmt.Errorf("unsupported function type %T bound to parameter '%s'", boundVal, paramName)
+			}
 			resolvedValue = boundVal
 		}
 		if resolveErr != nil {
@@ -26,9 +26,14 @@ import (
 	"log"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"os"
 	"reflect"
+	"regexp"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/googleapis/mcp-toolbox-sdk-go/core/transport"
 	mcp "github.com/googleapis/mcp-toolbox-sdk-go/core/transport/mcp/v20250618"
@@ -50,6 +55,9 @@ func (d *dummyTransport) ListTools(ctx context.Context, set string, h map[string
 func (d *dummyTransport) InvokeTool(ctx context.Context, name string, p map[string]any, h map[string]string) (any, error) {
 	return nil, nil
 }
+func (d *dummyTransport) Close(ctx context.Context) error {
+	return nil
+}
 
 func TestToolboxTool_Getters(t *testing.T) {
 	sampleParams := []ParameterSchema{
@@ -78,6 +86,118 @@ func TestToolboxTool_Getters(t *testing.T) {
 		}
 	})
 
+	t.Run("Title Method Returns Correct Value", func(t *testing.T) {
+		titled := &ToolboxTool{title: "My Test Tool"}
+		if got := titled.Title(); got != "My Test Tool" {
+			t.Fatalf("Expected Title() to be 'My Test Tool', but got '%s'", got)
+		}
+	})
+
+	t.Run("Annotations Method Returns A Safe Copy", func(t *testing.T) {
+		annotatedTool := &ToolboxTool{
+			annotations: map[string]any{"readOnlyHint": true},
+			transport:   &dummyTransport{baseURL: "http://example.com"},
+		}
+
+		annotations := annotatedTool.Annotations()
+		if annotations["readOnlyHint"] != true {
+			t.Fatalf("Expected readOnlyHint to be true, got %v", annotations["readOnlyHint"])
+		}
+
+		annotations["readOnlyHint"] = false
+		if annotatedTool.annotations["readOnlyHint"] != true {
+			t.Fatal("Annotations() returned a direct reference to the internal map, not a copy.")
+		}
+	})
+
+	t.Run("Hint Methods Apply The MCP Defaults", func(t *testing.T) {
+		unannotated := &ToolboxTool{}
+		if unannotated.ReadOnly() || !unannotated.Destructive() || unannotated.Idempotent() || !unannotated.OpenWorld() {
+			t.Fatalf("Expected the MCP defaults for a tool without hints, got %+v", unannotated.Hints())
+		}
+
+		readOnly := &ToolboxTool{annotations: map[string]any{"readOnlyHint": true, "destructiveHint": true, "openWorldHint": false}}
+		if !readOnly.ReadOnly() || readOnly.Destructive() || !readOnly.Idempotent() || readOnly.OpenWorld() {
+			t.Fatalf("Unexpected hints for a read-only tool: %+v", readOnly.Hints())
+		}
+
+		idempotent := &ToolboxTool{annotations: map[string]any{"destructiveHint": false, "idempotentHint": true}}
+		if idempotent.ReadOnly() || idempotent.Destructive() || !idempotent.Idempotent() {
+			t.Fatalf("Unexpected hints for an idempotent tool: %+v", idempotent.Hints())
+		}
+	})
+
+	t.Run("Examples Method Returns A Safe Copy", func(t *testing.T) {
+		exampleTool := &ToolboxTool{
+			examples: []ToolExample{{Description: "first", Input: map[string]any{"q": "a"}}},
+		}
+
+		examples := exampleTool.Examples()
+		if len(examples) != 1 || examples[0].Description != "first" {
+			t.Fatalf("Unexpected examples: %v", examples)
+		}
+
+		examples[0].Description = "MODIFIED"
+		if exampleTool.examples[0].Description != "first" {
+			t.Fatal("Examples() returned a direct reference to the internal slice, not a copy.")
+		}
+	})
+
+	t.Run("Auth Requirement Methods Return Safe Copies", func(t *testing.T) {
+		authTool := &ToolboxTool{
+			requiredAuthnParams: map[string][]string{"user_id": {"google", "github"}},
+			requiredAuthzTokens: []string{"admin"},
+		}
+
+		authn := authTool.RequiredAuthnParams()
+		if !reflect.DeepEqual(authn, map[string][]string{"user_id": {"google", "github"}}) {
+			t.Fatalf("Unexpected RequiredAuthnParams(): %v", authn)
+		}
+		authn["user_id"][0] = "MODIFIED"
+		if authTool.requiredAuthnParams["user_id"][0] != "google" {
+			t.Fatal("RequiredAuthnParams() returned a reference to the internal slices, not a copy.")
+		}
+
+		authz := authTool.RequiredAuthzServices()
+		if !reflect.DeepEqual(authz, []string{"admin"}) {
+			t.Fatalf("Unexpected RequiredAuthzServices(): %v", authz)
+		}
+		authz[0] = "MODIFIED"
+		if authTool.requiredAuthzTokens[0] != "admin" {
+			t.Fatal("RequiredAuthzServices() returned a reference to the internal slice, not a copy.")
+		}
+
+		if len((&ToolboxTool{}).RequiredAuthzServices()) != 0 {
+			t.Error("Expected an empty slice for a tool without auth requirements")
+		}
+	})
+
+	t.Run("AuthSatisfied Reports Missing Services", func(t *testing.T) {
+		authTool := &ToolboxTool{
+			requiredAuthnParams: map[string][]string{"user_id": {"google"}},
+			requiredAuthzTokens: []string{"admin", "github"},
+			authTokenSources: map[string]oauth2.TokenSource{
+				"github": oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "t"}),
+			},
+		}
+
+		if !authTool.RequiresAuth() {
+			t.Error("Expected RequiresAuth() to be true")
+		}
+		ok, missing := authTool.AuthSatisfied()
+		if ok || !reflect.DeepEqual(missing, []string{"admin", "google"}) {
+			t.Errorf("Expected missing [admin google], got %t, %v", ok, missing)
+		}
+
+		noAuthTool := &ToolboxTool{}
+		if noAuthTool.RequiresAuth() {
+			t.Error("Expected RequiresAuth() to be false for a tool without auth")
+		}
+		if ok, missing := noAuthTool.AuthSatisfied(); !ok || len(missing) != 0 {
+			t.Errorf("Expected auth to be satisfied, got %t, %v", ok, missing)
+		}
+	})
+
 	t.Run("Parameters Method Behavior", func(t *testing.T) {
 		t.Run("Returns Correct Slice Content", func(t *testing.T) {
 			params := tool.Parameters()
@@ -237,6 +357,30 @@ func TestToolFrom(t *testing.T) {
 		}
 	})
 
+	t.Run("Negative Test - fails when using loading options", func(t *testing.T) {
+		testCases := []struct {
+			name    string
+			opt     ToolOption
+			wantErr string
+		}{
+			{"TagFilter", WithTagFilter("a"), "WithTagFilter option is only applicable to LoadToolset"},
+			{"ToolFilter", WithToolFilter([]string{"a"}, nil), "WithToolFilter option is only applicable to LoadToolset"},
+			{"ToolNameRegexp", WithToolNameRegexp(regexp.MustCompile("a")), "WithToolNameRegexp option is only applicable to LoadToolset"},
+			{"AllowEmptyToolset", WithAllowEmptyToolset(), "WithAllowEmptyToolset option is only applicable to LoadToolset"},
+			{"ToolNamePrefix", WithToolNamePrefix("a_"), "WithToolNamePrefix option is only applicable to LoadTool and LoadToolset"},
+			{"ManifestQueryParams", WithManifestQueryParams(url.Values{"a": {"b"}}), "WithManifestQueryParams option is only applicable to LoadTool and LoadToolset"},
+			{"AllowUnusedBindings", WithAllowUnusedBindings(true), "WithAllowUnusedBindings option is only applicable to LoadTool and LoadToolset"},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				_, err := getTestTool().ToolFrom(tc.opt)
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Errorf("Expected error containing %q, got %v", tc.wantErr, err)
+				}
+			})
+		}
+	})
+
 	t.Run("Negative Test - binding a completely unknown parameter", func(t *testing.T) {
 		tool := getTestTool()
 		_, err := tool.ToolFrom(WithBindParamString("country", "UK"))
@@ -261,6 +405,93 @@ func TestToolFrom(t *testing.T) {
 			t.Errorf("Incorrect error message for conflicting options. Got: %q", err.Error())
 		}
 	})
+
+	t.Run("Rebinding an existing bound parameter - Success", func(t *testing.T) {
+		tool := getTestTool()
+		newTool, err := tool.ToolFrom(WithRebindParam("units", "fahrenheit"))
+		if err != nil {
+			t.Fatalf("ToolFrom failed unexpectedly: %v", err)
+		}
+		if val := newTool.boundParams["units"]; val != "fahrenheit" {
+			t.Errorf("Expected 'units' to be rebound to 'fahrenheit', got %v", val)
+		}
+		if val := tool.boundParams["units"]; val != "celsius" {
+			t.Errorf("Parent tool binding was mutated, got %v", val)
+		}
+	})
+
+	t.Run("Negative Test - rebinding a parameter that is not bound", func(t *testing.T) {
+		tool := getTestTool()
+		_, err := tool.ToolFrom(WithRebindParam("city", "Paris"))
+		if err == nil {
+			t.Fatal("Expected an error when rebinding an unbound parameter, but got nil")
+		}
+		if !strings.Contains(err.Error(), "unable to rebind parameter: no bound parameter named 'city'") {
+			t.Errorf("Incorrect error message for rebind. Got: %q", err.Error())
+		}
+	})
+
+	t.Run("Unbinding an existing bound parameter - Success", func(t *testing.T) {
+		tool := getTestTool()
+		tool.boundParamSchemas = map[string]ParameterSchema{
+			"units": {Name: "units", Type: "string"},
+		}
+		newTool, err := tool.ToolFrom(WithUnbindParam("units"))
+		if err != nil {
+			t.Fatalf("ToolFrom failed unexpectedly: %v", err)
+		}
+		if _, ok := newTool.boundParams["units"]; ok {
+			t.Error("Expected 'units' to be removed from the bound parameters")
+		}
+		if _, ok := newTool.boundParamSchemas["units"]; ok {
+			t.Error("Expected 'units' to be removed from the bound parameter schemas")
+		}
+		if len(newTool.parameters) != 3 || newTool.parameters[2].Name != "units" {
+			t.Errorf("Expected 'units' to be restored as an unbound parameter, got %+v", newTool.parameters)
+		}
+		if _, ok := tool.boundParams["units"]; !ok {
+			t.Error("Parent tool binding was removed")
+		}
+	})
+
+	t.Run("Unbinding and binding the same parameter - Success", func(t *testing.T) {
+		tool := getTestTool()
+		tool.boundParamSchemas = map[string]ParameterSchema{
+			"units": {Name: "units", Type: "string"},
+		}
+		newTool, err := tool.ToolFrom(WithUnbindParam("units"), WithBindParamString("units", "kelvin"))
+		if err != nil {
+			t.Fatalf("ToolFrom failed unexpectedly: %v", err)
+		}
+		if val := newTool.boundParams["units"]; val != "kelvin" {
+			t.Errorf("Expected 'units' to be bound to 'kelvin', got %v", val)
+		}
+		if len(newTool.parameters) != 2 {
+			t.Errorf("Expected 2 unbound parameters, got %d", len(newTool.parameters))
+		}
+	})
+
+	t.Run("Negative Test - unbinding a parameter that is not bound", func(t *testing.T) {
+		tool := getTestTool()
+		_, err := tool.ToolFrom(WithUnbindParam("city"))
+		if err == nil {
+			t.Fatal("Expected an error when unbinding an unbound parameter, but got nil")
+		}
+		if !strings.Contains(err.Error(), "unable to unbind parameter: no bound parameter named 'city'") {
+			t.Errorf("Incorrect error message for unbind. Got: %q", err.Error())
+		}
+	})
+
+	t.Run("Negative Test - duplicate unbind options are provided", func(t *testing.T) {
+		tool := getTestTool()
+		_, err := tool.ToolFrom(WithUnbindParam("units"), WithUnbindParam("units"))
+		if err == nil {
+			t.Fatal("Expected an error from a duplicate option, but got nil")
+		}
+		if !strings.Contains(err.Error(), "duplicate parameter unbinding") {
+			t.Errorf("Incorrect error message for duplicate unbind. Got: %q", err.Error())
+		}
+	})
 }
 
 func TestCloneToolboxTool(t *testing.T) {
@@ -400,6 +631,58 @@ func TestValidateAndBuildPayload(t *testing.T) {
 		}
 	})
 
+	t.Run("Sends explicit nils only for nullable parameters", func(t *testing.T) {
+		tool := &ToolboxTool{
+			parameters: []ParameterSchema{
+				{Name: "deleted_at", Type: "string", Required: true, Nullable: true},
+				{Name: "note", Type: "string", Nullable: true},
+				{Name: "limit", Type: "integer", Default: 10},
+				{Name: "offset", Type: "integer"},
+			},
+		}
+
+		payload, err := tool.validateAndBuildPayload(map[string]any{"deleted_at": nil, "limit": nil, "offset": nil})
+		if err != nil {
+			t.Fatalf("validateAndBuildPayload failed unexpectedly: %v", err)
+		}
+		expectedPayload := map[string]any{"deleted_at": nil, "limit": 10}
+		if !reflect.DeepEqual(payload, expectedPayload) {
+			t.Errorf("Payload mismatch.\nExpected: %v\nGot:      %v", expectedPayload, payload)
+		}
+
+		if _, err := tool.validateAndBuildPayload(map[string]any{}); err == nil || !strings.Contains(err.Error(), "missing required parameter 'deleted_at'") {
+			t.Errorf("Expected a missing parameter error, got %v", err)
+		}
+	})
+
+	t.Run("Coerces compatible inputs when enabled", func(t *testing.T) {
+		input := map[string]any{
+			"city": 10115,
+			"days": "5",
+		}
+
+		if _, err := baseTool.validateAndBuildPayload(input); err == nil {
+			t.Fatal("Expected a type error without coercion, got nil")
+		}
+
+		coercingTool := baseTool.cloneToolboxTool()
+		coercingTool.typeCoercion = true
+		payload, err := coercingTool.validateAndBuildPayload(input)
+		if err != nil {
+			t.Fatalf("validateAndBuildPayload failed unexpectedly: %v", err)
+		}
+		if payload["city"] != "10115" || payload["days"] != int64(5) {
+			t.Errorf("Expected coerced inputs, got %v", payload)
+		}
+		if input["days"] != "5" {
+			t.Errorf("Expected the input map to be unchanged, got %v", input)
+		}
+
+		if _, err := coercingTool.validateAndBuildPayload(map[string]any{"days": "five"}); err == nil {
+			t.Error("Expected a type error for an incompatible input, got nil")
+		}
+	})
+
 	t.Run("Happy Path - resolves map and map function bound parameters", func(t *testing.T) {
 		toolWithMaps := &ToolboxTool{
 			parameters: []ParameterSchema{
@@ -433,6 +716,55 @@ func TestValidateAndBuildPayload(t *testing.T) {
 		}
 	})
 
+	t.Run("Happy Path - resolves generic map and any function bound parameters", func(t *testing.T) {
+		config := newToolConfig()
+		opts := []ToolOption{
+			WithBindParamMapFunc("labels", func() (map[string]float32, error) {
+				return map[string]float32{"weight": 1.5}, nil
+			}),
+			WithBindParamAnyFunc("filter", func() (any, error) {
+				return map[string]any{"active": true}, nil
+			}),
+			WithBindParamAny("tags", []any{"a", 1}),
+		}
+		for _, opt := range opts {
+			if err := opt(config); err != nil {
+				t.Fatalf("Applying option failed unexpectedly: %v", err)
+			}
+		}
+		tool := &ToolboxTool{boundParams: config.BoundParams}
+
+		payload, err := tool.validateAndBuildPayload(map[string]any{})
+		if err != nil {
+			t.Fatalf("validateAndBuildPayload failed unexpectedly: %v", err)
+		}
+
+		expectedPayload := map[string]any{
+			"labels": map[string]float32{"weight": 1.5},
+			"filter": map[string]any{"active": true},
+			"tags":   []any{"a", 1},
+		}
+		if !reflect.DeepEqual(payload, expectedPayload) {
+			t.Errorf("Payload mismatch.\nExpected: %v\nGot:      %v", expectedPayload, payload)
+		}
+	})
+
+	t.Run("Negative Test - fails on unsupported bound function type", func(t *testing.T) {
+		tool := &ToolboxTool{
+			boundParams: map[string]any{
+				"callback": func(x int) int { return x },
+			},
+		}
+
+		_, err := tool.validateAndBuildPayload(map[string]any{})
+		if err == nil {
+			t.Fatal("Expected an error for an unsupported function type, but got nil")
+		}
+		if !strings.Contains(err.Error(), "unsupported function type func(int) int bound to parameter 'callback'") {
+			t.Errorf("Incorrect error message. Got: %v", err)
+		}
+	})
+
 	t.Run("Negative Test - fails on type validation error", func(t *testing.T) {
 		input := map[string]any{
 			"city": "Paris",
@@ -775,6 +1107,140 @@ func TestToolboxTool_Invoke(t *testing.T) {
 		}
 	})
 
+	t.Run("InvokeRaw decodes JSON arguments", func(t *testing.T) {
+		server := newMockMCPServer(func(req jsonRPCRequest) (any, error) {
+			var params mcpToolCallParams
+			argsBytes, _ := json.Marshal(req.Params)
+			json.Unmarshal(argsBytes, &params)
+
+			if params.Arguments["city"] != "London" || params.Arguments["days"] != float64(3) {
+				return nil, fmt.Errorf("incorrect args: %v", params.Arguments)
+			}
+			return map[string]any{
+				"content": []map[string]string{
+					{"type": "text", "text": "sunny"},
+				},
+			}, nil
+		})
+		defer server.Close()
+
+		tool := createBaseTool(server.Client(), server.URL)
+		tool.parameters = append(tool.parameters, ParameterSchema{Name: "days", Type: "integer"})
+
+		result, err := tool.InvokeRaw(context.Background(), json.RawMessage(`{"city": "London", "days": 3}`))
+		if err != nil {
+			t.Fatalf("InvokeRaw failed unexpectedly: %v", err)
+		}
+		if result != "sunny" {
+			t.Errorf("Expected result 'sunny', got '%v'", result)
+		}
+	})
+
+	t.Run("Negative Test - InvokeRaw fails on malformed JSON", func(t *testing.T) {
+		tool := createBaseTool(http.DefaultClient, "http://unused.example.com")
+		_, err := tool.InvokeRaw(context.Background(), json.RawMessage(`["not", "an", "object"]`))
+		if err == nil || !strings.Contains(err.Error(), "failed to decode tool input") {
+			t.Errorf("Expected a decode error, got: %v", err)
+		}
+	})
+
+	t.Run("Runs before and after invoke hooks in order", func(t *testing.T) {
+		server := newMockMCPServer(func(req jsonRPCRequest) (any, error) {
+			var params mcpToolCallParams
+			argsBytes, _ := json.Marshal(req.Params)
+			json.Unmarshal(argsBytes, &params)
+
+			if params.Arguments["city"] != "REDACTED" {
+				return nil, fmt.Errorf("expected scrubbed city, got %v", params.Arguments["city"])
+			}
+			return map[string]any{
+				"content": []map[string]string{
+					{"type": "text", "text": "sunny and warm"},
+				},
+			}, nil
+		})
+		defer server.Close()
+
+		tool := createBaseTool(server.Client(), server.URL)
+		var calls []string
+		derived, err := tool.ToolFrom(
+			WithBeforeInvoke(func(ctx context.Context, name string, payload map[string]any) (map[string]any, error) {
+				calls = append(calls, "before:"+name)
+				payload["city"] = "REDACTED"
+				return payload, nil
+			}),
+			WithAfterInvoke(func(ctx context.Context, name string, result any) (any, error) {
+				calls = append(calls, "after:"+name)
+				return strings.ToUpper(result.(string)), nil
+			}),
+			WithAfterInvoke(func(ctx context.Context, name string, result any) (any, error) {
+				return result.(string)[:5], nil
+			}),
+		)
+		if err != nil {
+			t.Fatalf("ToolFrom failed unexpectedly: %v", err)
+		}
+
+		result, err := derived.Invoke(context.Background(), map[string]any{"city": "London"})
+		if err != nil {
+			t.Fatalf("Invoke failed unexpectedly: %v", err)
+		}
+		if result != "SUNNY" {
+			t.Errorf("Expected result 'SUNNY', got '%v'", result)
+		}
+		if !reflect.DeepEqual(calls, []string{"before:weather", "after:weather"}) {
+			t.Errorf("Unexpected hook calls: %v", calls)
+		}
+		if len(tool.beforeInvoke) != 0 || len(tool.afterInvoke) != 0 {
+			t.Error("ToolFrom added hooks to the parent tool")
+		}
+	})
+
+	t.Run("Negative Test - Fails when the response exceeds the size limit", func(t *testing.T) {
+		server := newMockMCPServer(func(req jsonRPCRequest) (any, error) {
+			return map[string]any{
+				"content": []map[string]string{
+					{"type": "text", "text": strings.Repeat("x", 1024)},
+				},
+			}, nil
+		})
+		defer server.Close()
+
+		tool := createBaseTool(server.Client(), server.URL)
+		limited, err := tool.ToolFrom(WithMaxResponseBytes(512))
+		if err != nil {
+			t.Fatalf("ToolFrom failed unexpectedly: %v", err)
+		}
+
+		_, err = limited.Invoke(context.Background(), map[string]any{"city": "London"})
+		var tooLarge *ResponseTooLargeError
+		if !errors.As(err, &tooLarge) {
+			t.Fatalf("Expected a *ResponseTooLargeError, got %v", err)
+		}
+		if tooLarge.Limit != 512 {
+			t.Errorf("Expected limit 512, got %d", tooLarge.Limit)
+		}
+
+		// The parent tool remains unbounded.
+		if _, err := tool.Invoke(context.Background(), map[string]any{"city": "London"}); err != nil {
+			t.Errorf("Expected the parent tool to be unaffected, got %v", err)
+		}
+	})
+
+	t.Run("Negative Test - Fails when a before invoke hook fails", func(t *testing.T) {
+		tool := createBaseTool(http.DefaultClient, "http://unused.example.com")
+		tool.beforeInvoke = []BeforeInvokeHook{
+			func(ctx context.Context, name string, payload map[string]any) (map[string]any, error) {
+				return nil, errors.New("payload rejected")
+			},
+		}
+
+		_, err := tool.Invoke(context.Background(), map[string]any{"city": "London"})
+		if err == nil || !strings.Contains(err.Error(), "before-invoke hook failed: payload rejected") {
+			t.Errorf("Expected before-invoke hook error, got: %v", err)
+		}
+	})
+
 	t.Run("Applies correct _token suffix to auth headers but not client headers", func(t *testing.T) {
 		checkHeaders := func(w http.ResponseWriter, r *http.Request) {
 			if r.Header.Get("X-Custom-Header") != "client-val" {
@@ -870,6 +1336,13 @@ func TestToolboxTool_Invoke(t *testing.T) {
 		if !strings.Contains(err.Error(), "permission error: auth service 'required_service' is required") {
 			t.Errorf("Incorrect error message for missing auth. Got: %v", err)
 		}
+		var missingAuth *MissingAuthError
+		if !errors.As(err, &missingAuth) {
+			t.Fatalf("Expected a *MissingAuthError, got %T", err)
+		}
+		if !reflect.DeepEqual(missingAuth.Services, []string{"required_service"}) {
+			t.Errorf("Expected missing services [required_service], got %v", missingAuth.Services)
+		}
 	})
 
 	t.Run("Negative Test - Fails when payload validation fails", func(t *testing.T) {
@@ -1016,7 +1489,7 @@ func TestToolboxTool_Invoke(t *testing.T) {
 func TestToolboxTool_Invoke_HttpsWarning(t *testing.T) {
 	var buf bytes.Buffer
 	log.SetOutput(&buf)
-	defer log.SetOutput(nil)
+	defer log.SetOutput(os.Stderr)
 	mockTokenSource := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "secret-token"})
 
 	tests := []struct {
@@ -1328,3 +1801,413 @@ func TestInputSchema(t *testing.T) {
 		})
 	}
 }
+
+func TestOutputSchema(t *testing.T) {
+	t.Run("Advertised", func(t *testing.T) {
+		tool := &ToolboxTool{outputSchema: map[string]any{"type": "object"}}
+		schema, err := tool.OutputSchema()
+		if err != nil {
+			t.Fatalf("OutputSchema() returned an unexpected error: %v", err)
+		}
+		var got map[string]any
+		if err := json.Unmarshal(schema, &got); err != nil {
+			t.Fatalf("Failed to unmarshal output schema: %v", err)
+		}
+		if got["type"] != "object" {
+			t.Errorf("Expected an object schema, got %s", schema)
+		}
+	})
+
+	t.Run("NotAdvertised", func(t *testing.T) {
+		schema, err := (&ToolboxTool{}).OutputSchema()
+		if err != nil {
+			t.Fatalf("OutputSchema() returned an unexpected error: %v", err)
+		}
+		if schema != nil {
+			t.Errorf("Expected no output schema, got %s", schema)
+		}
+	})
+}
+
+// capturingTransport records the arguments of the last InvokeTool call.
+type capturingTransport struct {
+	dummyTransport
+	result   any
+	headers  map[string]string
+	deadline bool
+	meta     map[string]any
+	toolName string
+	calls    int
+}
+
+func (c *capturingTransport) InvokeTool(ctx context.Context, name string, p map[string]any, h map[string]string) (any, error) {
+	c.calls++
+	c.headers = h
+	_, c.deadline = ctx.Deadline()
+	c.meta = transport.RequestMeta(ctx)
+	c.toolName = transport.ToolName(ctx)
+	params, _ := json.Marshal(map[string]any{"progressToken": transport.ProgressToken(ctx), "progress": 1, "total": 2})
+	transport.Notify(ctx, transport.Notification{Method: "notifications/progress", Params: params})
+	return c.result, nil
+}
+
+func TestToolboxTool_Invoke_DecodedJSON(t *testing.T) {
+	tool := &ToolboxTool{
+		name:      "lookup",
+		transport: &capturingTransport{result: "ok"},
+		parameters: []ParameterSchema{
+			{Name: "ids", Type: "array", Items: &ParameterSchema{Type: "integer"}},
+			{Name: "limit", Type: "integer"},
+		},
+	}
+
+	// encoding/json decodes every number in a []any or map[string]any as a
+	// float64.
+	var input map[string]any
+	if err := json.Unmarshal([]byte(`{"ids": [1, 2.0, 3], "limit": 10}`), &input); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if _, err := tool.Invoke(context.Background(), input); err != nil {
+		t.Errorf("Expected whole JSON numbers to be valid integers, got: %v", err)
+	}
+
+	if err := json.Unmarshal([]byte(`{"ids": [1, 2.5]}`), &input); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	_, err := tool.Invoke(context.Background(), input)
+	if err == nil || !strings.Contains(err.Error(), "expects an integer, but got float64") {
+		t.Errorf("Expected an integer error for a fractional number, got: %v", err)
+	}
+}
+
+func TestToolboxTool_InvokeOptions(t *testing.T) {
+	newTool := func(tr *capturingTransport) *ToolboxTool {
+		return &ToolboxTool{
+			name:      "weather",
+			transport: tr,
+			parameters: []ParameterSchema{
+				{Name: "city", Type: "string"},
+			},
+			requiredAuthzTokens: []string{"weather_api"},
+		}
+	}
+
+	t.Run("Applies per-call headers, idempotency key and timeout", func(t *testing.T) {
+		tr := &capturingTransport{dummyTransport: dummyTransport{baseURL: "https://example.com"}, result: "ok"}
+		tool := newTool(tr)
+
+		_, err := tool.Invoke(context.Background(), map[string]any{"city": "London"},
+			WithInvokeAuthTokenString("weather_api", "call-token"),
+			WithInvokeHeader("X-Request-Id", "req-1"),
+			WithIdempotencyKey("key-1"),
+			WithInvokeTimeout(time.Minute),
+		)
+		if err != nil {
+			t.Fatalf("Invoke failed unexpectedly: %v", err)
+		}
+		if tr.headers["weather_api_token"] != "call-token" {
+			t.Errorf("Expected per-call auth token header, got %v", tr.headers)
+		}
+		if tr.headers["X-Request-Id"] != "req-1" {
+			t.Errorf("Expected per-call header, got %v", tr.headers)
+		}
+		if tr.headers["Idempotency-Key"] != "key-1" {
+			t.Errorf("Expected Idempotency-Key header, got %v", tr.headers)
+		}
+		if !tr.deadline {
+			t.Error("Expected the invocation context to carry a deadline")
+		}
+		if tr.toolName != "weather" {
+			t.Errorf("Expected the invocation context to carry the tool name, got %q", tr.toolName)
+		}
+	})
+
+	t.Run("Attaches meta to the request", func(t *testing.T) {
+		tr := &capturingTransport{result: "ok"}
+		tool := newTool(tr)
+		tool.requiredAuthzTokens = nil
+
+		_, err := tool.Invoke(context.Background(), nil,
+			WithInvokeMeta("traceparent", "00-abc-01"),
+			WithInvokeMeta("tenant", map[string]any{"id": 7}),
+		)
+		if err != nil {
+			t.Fatalf("Invoke failed unexpectedly: %v", err)
+		}
+		expected := map[string]any{"traceparent": "00-abc-01", "tenant": map[string]any{"id": 7}}
+		if !reflect.DeepEqual(tr.meta, expected) {
+			t.Errorf("Expected meta %v, got %v", expected, tr.meta)
+		}
+	})
+
+	t.Run("Delivers server notifications to the handler", func(t *testing.T) {
+		tr := &capturingTransport{result: "ok"}
+		tool := newTool(tr)
+		tool.requiredAuthzTokens = nil
+
+		var methods []string
+		_, err := tool.Invoke(context.Background(), nil, WithNotificationHandler(func(n Notification) {
+			methods = append(methods, n.Method)
+		}))
+		if err != nil {
+			t.Fatalf("Invoke failed unexpectedly: %v", err)
+		}
+		if !reflect.DeepEqual(methods, []string{"notifications/progress"}) {
+			t.Errorf("Expected one progress notification, got %v", methods)
+		}
+	})
+
+	t.Run("Reports progress with a progress token", func(t *testing.T) {
+		tr := &capturingTransport{result: "ok"}
+		tool := newTool(tr)
+		tool.requiredAuthzTokens = nil
+
+		var updates []Progress
+		var methods []string
+		_, err := tool.Invoke(context.Background(), nil,
+			WithProgressHandler(func(p Progress) { updates = append(updates, p) }),
+			WithNotificationHandler(func(n Notification) { methods = append(methods, n.Method) }),
+		)
+		if err != nil {
+			t.Fatalf("Invoke failed unexpectedly: %v", err)
+		}
+		if !reflect.DeepEqual(updates, []Progress{{Progress: 1, Total: 2}}) {
+			t.Errorf("Expected one progress update, got %+v", updates)
+		}
+		if !reflect.DeepEqual(methods, []string{"notifications/progress"}) {
+			t.Errorf("Expected the update to reach the notification handler too, got %v", methods)
+		}
+	})
+
+	t.Run("Per-call auth overrides the tool's auth source", func(t *testing.T) {
+		tr := &capturingTransport{dummyTransport: dummyTransport{baseURL: "https://example.com"}, result: "ok"}
+		tool := newTool(tr)
+		tool.authTokenSources = map[string]oauth2.TokenSource{
+			"weather_api": oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "tool-token"}),
+		}
+
+		if _, err := tool.Invoke(context.Background(), nil, WithInvokeAuthTokenString("weather_api", "call-token")); err != nil {
+			t.Fatalf("Invoke failed unexpectedly: %v", err)
+		}
+		if tr.headers["weather_api_token"] != "call-token" {
+			t.Errorf("Expected per-call token to win, got %v", tr.headers)
+		}
+		if tool.authTokenSources["weather_api"] == nil || len(tool.authTokenSources) != 1 {
+			t.Error("Per-call auth must not modify the tool")
+		}
+	})
+
+	t.Run("Decodes JSON results when requested", func(t *testing.T) {
+		tr := &capturingTransport{result: `{"temp": 21}`}
+		tool := newTool(tr)
+		tool.requiredAuthzTokens = nil
+
+		result, err := tool.Invoke(context.Background(), nil, WithResultFormat(ResultFormatJSON))
+		if err != nil {
+			t.Fatalf("Invoke failed unexpectedly: %v", err)
+		}
+		if !reflect.DeepEqual(result, map[string]any{"temp": float64(21)}) {
+			t.Errorf("Unexpected decoded result: %#v", result)
+		}
+	})
+
+	t.Run("Negative Test - Fails to decode a non-JSON result", func(t *testing.T) {
+		tr := &capturingTransport{result: "plain text"}
+		tool := newTool(tr)
+		tool.requiredAuthzTokens = nil
+
+		_, err := tool.Invoke(context.Background(), nil, WithResultFormat(ResultFormatJSON))
+		if err == nil || !strings.Contains(err.Error(), "failed to decode tool result as JSON") {
+			t.Errorf("Expected a JSON decode error, got %v", err)
+		}
+	})
+
+	t.Run("Negative Test - Rejects expired tokens when validation is enabled", func(t *testing.T) {
+		tr := &capturingTransport{result: "ok"}
+		tool := newTool(tr)
+		tool.validateTokens = true
+
+		expired := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "t", Expiry: time.Now().Add(-time.Minute)})
+		_, err := tool.Invoke(context.Background(), nil, WithInvokeAuthTokenSource("weather_api", expired))
+		if err == nil || !strings.Contains(err.Error(), "token for service 'weather_api' expired") {
+			t.Errorf("Expected an expired token error, got %v", err)
+		}
+		if tr.calls != 0 {
+			t.Error("Expected the request not to be sent")
+		}
+	})
+
+	t.Run("Negative Test - Fails on a nil option", func(t *testing.T) {
+		tool := newTool(&capturingTransport{})
+
+		_, err := tool.Invoke(context.Background(), nil, nil)
+		if err == nil || !strings.Contains(err.Error(), "received a nil InvokeOption") {
+			t.Errorf("Expected a nil option error, got %v", err)
+		}
+	})
+}
+
+func TestToolboxTool_ResultCache(t *testing.T) {
+	newTool := func(tr *capturingTransport, annotations map[string]any) *ToolboxTool {
+		tool := &ToolboxTool{
+			name:        "lookup",
+			transport:   tr,
+			parameters:  []ParameterSchema{{Name: "id", Type: "string"}},
+			annotations: annotations,
+		}
+		return tool
+	}
+
+	t.Run("Reuses results for identical payloads of read-only tools", func(t *testing.T) {
+		tr := &capturingTransport{result: "cached"}
+		base := newTool(tr, map[string]any{"readOnlyHint": true})
+		tool, err := base.ToolFrom(WithResultCache(time.Minute, 10))
+		if err != nil {
+			t.Fatalf("ToolFrom failed: %v", err)
+		}
+
+		for i := 0; i < 3; i++ {
+			if _, err := tool.Invoke(context.Background(), map[string]any{"id": "a"}); err != nil {
+				t.Fatalf("Invoke failed unexpectedly: %v", err)
+			}
+		}
+		if _, err := tool.Invoke(context.Background(), map[string]any{"id": "b"}); err != nil {
+			t.Fatalf("Invoke failed unexpectedly: %v", err)
+		}
+		if tr.calls != 2 {
+			t.Errorf("Expected 2 server calls, got %d", tr.calls)
+		}
+	})
+
+	t.Run("Reuses results across idempotency keys", func(t *testing.T) {
+		tr := &capturingTransport{result: map[string]any{"id": "a"}}
+		tool, err := newTool(tr, map[string]any{"idempotentHint": true}).ToolFrom(WithResultCache(time.Minute, 10))
+		if err != nil {
+			t.Fatalf("ToolFrom failed: %v", err)
+		}
+
+		first, err := tool.Invoke(context.Background(), map[string]any{"id": "a"}, WithIdempotencyKey("1"))
+		if err != nil {
+			t.Fatalf("Invoke failed unexpectedly: %v", err)
+		}
+		first.(map[string]any)["id"] = "changed"
+		second, err := tool.Invoke(context.Background(), map[string]any{"id": "a"}, WithIdempotencyKey("2"))
+		if err != nil {
+			t.Fatalf("Invoke failed unexpectedly: %v", err)
+		}
+		if tr.calls != 1 {
+			t.Errorf("Expected 1 server call, got %d", tr.calls)
+		}
+		if want := map[string]any{"id": "a"}; !reflect.DeepEqual(second, want) {
+			t.Errorf("Expected the cached result %v, got %v", want, second)
+		}
+	})
+
+	t.Run("Does not share results across tenant headers", func(t *testing.T) {
+		tr := &capturingTransport{result: map[string]any{"id": "a"}}
+		tool, err := newTool(tr, map[string]any{"idempotentHint": true}).ToolFrom(WithResultCache(time.Minute, 10))
+		if err != nil {
+			t.Fatalf("ToolFrom failed: %v", err)
+		}
+
+		for _, tenant := range []string{"a", "b", "a"} {
+			if _, err := tool.Invoke(context.Background(), map[string]any{"id": "a"}, WithInvokeHeader("X-Tenant", tenant)); err != nil {
+				t.Fatalf("Invoke failed unexpectedly: %v", err)
+			}
+		}
+		if tr.calls != 2 {
+			t.Errorf("Expected 2 server calls, got %d", tr.calls)
+		}
+	})
+
+	t.Run("Negative Test - Fails for tools that are not read-only or idempotent", func(t *testing.T) {
+		_, err := newTool(&capturingTransport{}, nil).ToolFrom(WithResultCache(time.Minute, 10))
+		if err == nil || !strings.Contains(err.Error(), "not marked read-only or idempotent") {
+			t.Errorf("Expected a result cache error, got %v", err)
+		}
+	})
+}
+
+// blockingTransport tracks the peak number of concurrent InvokeTool calls.
+type blockingTransport struct {
+	dummyTransport
+	release  chan struct{}
+	mu       sync.Mutex
+	inFlight int
+	peak     int
+}
+
+func (b *blockingTransport) InvokeTool(ctx context.Context, name string, p map[string]any, h map[string]string) (any, error) {
+	b.mu.Lock()
+	b.inFlight++
+	b.peak = max(b.peak, b.inFlight)
+	b.mu.Unlock()
+
+	<-b.release
+
+	b.mu.Lock()
+	b.inFlight--
+	b.mu.Unlock()
+	return "ok", nil
+}
+
+func TestToolboxTool_MaxConcurrentInvocations(t *testing.T) {
+	t.Run("Limits in-flight invocations", func(t *testing.T) {
+		tr := &blockingTransport{release: make(chan struct{})}
+		tool, err := (&ToolboxTool{name: "query", transport: tr}).ToolFrom(WithMaxConcurrentInvocations(2))
+		if err != nil {
+			t.Fatalf("ToolFrom failed: %v", err)
+		}
+
+		var wg sync.WaitGroup
+		for i := 0; i < 5; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, _ = tool.Invoke(context.Background(), nil)
+			}()
+		}
+		for i := 0; i < 5; i++ {
+			tr.release <- struct{}{}
+		}
+		wg.Wait()
+
+		if tr.peak > 2 {
+			t.Errorf("Expected at most 2 concurrent invocations, got %d", tr.peak)
+		}
+	})
+
+	t.Run("Negative Test - Returns when the context is done while waiting", func(t *testing.T) {
+		tr := &blockingTransport{release: make(chan struct{})}
+		tool, err := (&ToolboxTool{name: "query", transport: tr}).ToolFrom(WithMaxConcurrentInvocations(1))
+		if err != nil {
+			t.Fatalf("ToolFrom failed: %v", err)
+		}
+
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			_, _ = tool.Invoke(context.Background(), nil)
+		}()
+		// Wait until the first invocation holds the only slot.
+		for {
+			tr.mu.Lock()
+			inFlight := tr.inFlight
+			tr.mu.Unlock()
+			if inFlight == 1 {
+				break
+			}
+			time.Sleep(time.Millisecond)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		_, err = tool.Invoke(ctx, nil)
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("Expected context.DeadlineExceeded, got %v", err)
+		}
+
+		tr.release <- struct{}{}
+		<-done
+	})
+}
@@ -0,0 +1,181 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package toolboxserver
+
+import (
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/googleapis/mcp-toolbox-sdk-go/core"
+)
+
+// Option configures how Start locates and runs the Toolbox server.
+type Option func(*config) error
+
+// config holds the settings of a server started by Start.
+type config struct {
+	binaryPath    string
+	version       string
+	sha256        string
+	downloadURL   string
+	downloadDir   string
+	httpClient    *http.Client
+	port          int
+	args          []string
+	output        io.Writer
+	readyTimeout  time.Duration
+	clientOptions []core.ClientOption
+}
+
+// WithBinaryPath runs the Toolbox binary at path instead of locating or
+// downloading one.
+func WithBinaryPath(path string) Option {
+	return func(c *config) error {
+		if path == "" {
+			return fmt.Errorf("WithBinaryPath: path cannot be empty")
+		}
+		if c.binaryPath != "" {
+			return fmt.Errorf("binary path is already set and cannot be overridden")
+		}
+		c.binaryPath = path
+		return nil
+	}
+}
+
+// WithVersion downloads the given release of the Toolbox server, such as
+// "0.9.0", unless it was already downloaded. It requires WithSHA256. Without
+// it or WithBinaryPath, a "toolbox" binary is looked up in the PATH.
+func WithVersion(version string) Option {
+	return func(c *config) error {
+		if version == "" {
+			return fmt.Errorf("WithVersion: version cannot be empty")
+		}
+		if c.version != "" {
+			return fmt.Errorf("version is already set and cannot be overridden")
+		}
+		c.version = version
+		return nil
+	}
+}
+
+// WithSHA256 sets the expected SHA-256 checksum, in hexadecimal, of the
+// binary downloaded for WithVersion on this platform. Downloaded and cached
+// binaries are only run if their checksum matches.
+func WithSHA256(checksum string) Option {
+	return func(c *config) error {
+		if b, err := hex.DecodeString(checksum); err != nil || len(b) != 32 {
+			return fmt.Errorf("WithSHA256: checksum must be 64 hexadecimal digits, got %q", checksum)
+		}
+		if c.sha256 != "" {
+			return fmt.Errorf("checksum is already set and cannot be overridden")
+		}
+		c.sha256 = strings.ToLower(checksum)
+		return nil
+	}
+}
+
+// WithDownloadURL sets the base URL releases are downloaded from. Binaries
+// are fetched from "<url>/v<version>/<os>/<arch>/toolbox".
+func WithDownloadURL(url string) Option {
+	return func(c *config) error {
+		if url == "" {
+			return fmt.Errorf("WithDownloadURL: url cannot be empty")
+		}
+		c.downloadURL = url
+		return nil
+	}
+}
+
+// WithDownloadDir sets the directory downloaded binaries are kept in, so
+// that they are reused by later calls. It defaults to a directory in the
+// user's cache directory.
+func WithDownloadDir(dir string) Option {
+	return func(c *config) error {
+		if dir == "" {
+			return fmt.Errorf("WithDownloadDir: dir cannot be empty")
+		}
+		c.downloadDir = dir
+		return nil
+	}
+}
+
+// WithHTTPClient sets the HTTP client used to download the binary and to
+// check that the server is ready. It is not used by the returned
+// ToolboxClient; pass core.WithHTTPClient to WithClientOptions for that.
+func WithHTTPClient(client *http.Client) Option {
+	return func(c *config) error {
+		if client == nil {
+			return fmt.Errorf("WithHTTPClient: provided HTTP client cannot be nil")
+		}
+		c.httpClient = client
+		return nil
+	}
+}
+
+// WithPort makes the server listen on the given port of the loopback
+// interface. By default, a free port is chosen.
+func WithPort(port int) Option {
+	return func(c *config) error {
+		if port <= 0 || port > 65535 {
+			return fmt.Errorf("WithPort: invalid port %d", port)
+		}
+		c.port = port
+		return nil
+	}
+}
+
+// WithArgs passes additional command-line arguments to the server.
+func WithArgs(args ...string) Option {
+	return func(c *config) error {
+		c.args = append(c.args, args...)
+		return nil
+	}
+}
+
+// WithOutput sends the standard output and standard error of the server to
+// w. By default, they are discarded.
+func WithOutput(w io.Writer) Option {
+	return func(c *config) error {
+		if w == nil {
+			return fmt.Errorf("WithOutput: provided writer cannot be nil")
+		}
+		c.output = w
+		return nil
+	}
+}
+
+// WithReadyTimeout bounds how long Start waits for the server to accept
+// requests. It defaults to 30 seconds.
+func WithReadyTimeout(timeout time.Duration) Option {
+	return func(c *config) error {
+		if timeout <= 0 {
+			return fmt.Errorf("WithReadyTimeout: timeout must be positive, got %s", timeout)
+		}
+		c.readyTimeout = timeout
+		return nil
+	}
+}
+
+// WithClientOptions configures the ToolboxClient connected to the server.
+func WithClientOptions(opts ...core.ClientOption) Option {
+	return func(c *config) error {
+		c.clientOptions = append(c.clientOptions, opts...)
+		return nil
+	}
+}
@@ -0,0 +1,318 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package toolboxserver runs a local Toolbox server for development and
+// tests. It locates or downloads the server binary, launches it with a tools
+// file, waits until it accepts requests and returns a ToolboxClient
+// connected to it.
+package toolboxserver
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"time"
+
+	"github.com/googleapis/mcp-toolbox-sdk-go/core"
+)
+
+const (
+	// defaultDownloadURL is the base URL of the published Toolbox releases.
+	defaultDownloadURL = "https://storage.googleapis.com/genai-toolbox"
+	// defaultReadyTimeout bounds how long the server may take to start.
+	defaultReadyTimeout = 30 * time.Second
+	// readyPollInterval is how often the server is checked while starting.
+	readyPollInterval = 100 * time.Millisecond
+)
+
+// shutdownTimeout is how long Shutdown waits for the server to exit after
+// interrupting it before killing it.
+var shutdownTimeout = 5 * time.Second
+
+// ShutdownFunc stops a server started by Start, killing it if it does not
+// exit before ctx is done or within a few seconds. It closes the client and
+// is safe to call more than once.
+type ShutdownFunc func(ctx context.Context) error
+
+// Start launches a local Toolbox server serving the tools in toolsFile and
+// returns a ToolboxClient connected to it, along with the function that
+// stops the server. ctx only bounds the startup.
+//
+// The binary is taken from WithBinaryPath, downloaded for WithVersion and
+// verified against WithSHA256, or looked up in the PATH, in that order.
+//
+// Inputs:
+//   - ctx: The context bounding the download and startup of the server.
+//   - toolsFile: The path to the tools file to serve.
+//   - opts: Options configuring the server and the client.
+//
+// Returns:
+//
+//	A connected ToolboxClient, the function that stops the server and a nil
+//	error on success, or nil values and an error if the server could not be
+//	started.
+func Start(ctx context.Context, toolsFile string, opts ...Option) (*core.ToolboxClient, ShutdownFunc, error) {
+	cfg := &config{
+		downloadURL:  defaultDownloadURL,
+		httpClient:   http.DefaultClient,
+		output:       io.Discard,
+		readyTimeout: defaultReadyTimeout,
+	}
+	for _, opt := range opts {
+		if opt == nil {
+			return nil, nil, fmt.Errorf("Start: received a nil Option in options list")
+		}
+		if err := opt(cfg); err != nil {
+			return nil, nil, err
+		}
+	}
+	if _, err := os.Stat(toolsFile); err != nil {
+		return nil, nil, fmt.Errorf("invalid tools file: %w", err)
+	}
+
+	binary, err := locateBinary(ctx, cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+	port := cfg.port
+	if port == 0 {
+		if port, err = freePort(); err != nil {
+			return nil, nil, fmt.Errorf("failed to find a free port: %w", err)
+		}
+	}
+
+	args := append([]string{"--tools-file", toolsFile, "--address", "127.0.0.1", "--port", strconv.Itoa(port)}, cfg.args...)
+	cmd := exec.Command(binary, args...)
+	cmd.Stdout = cfg.output
+	cmd.Stderr = cfg.output
+	if err := cmd.Start(); err != nil {
+		return nil, nil, fmt.Errorf("failed to start the Toolbox server: %w", err)
+	}
+	proc := &process{cmd: cmd, exited: make(chan struct{})}
+	go func() {
+		proc.err = cmd.Wait()
+		close(proc.exited)
+	}()
+
+	url := fmt.Sprintf("http://127.0.0.1:%d", port)
+	if err := waitReady(ctx, cfg, url, proc); err != nil {
+		_ = proc.stop(context.Background())
+		return nil, nil, err
+	}
+
+	client, err := core.NewToolboxClient(url, cfg.clientOptions...)
+	if err != nil {
+		_ = proc.stop(context.Background())
+		return nil, nil, err
+	}
+
+	shutdown := func(ctx context.Context) error {
+		closeErr := client.Close(ctx)
+		if err := proc.stop(ctx); err != nil {
+			return err
+		}
+		return closeErr
+	}
+	return client, shutdown, nil
+}
+
+// process is a running Toolbox server.
+type process struct {
+	cmd *exec.Cmd
+	// exited is closed once the process has exited, after which err is set.
+	exited chan struct{}
+	err    error
+}
+
+// stop interrupts the process and waits for it to exit, killing it if it
+// does not exit in time.
+func (p *process) stop(ctx context.Context) error {
+	select {
+	case <-p.exited:
+		return nil
+	default:
+	}
+
+	// Interrupts are not supported on Windows.
+	if err := p.cmd.Process.Signal(os.Interrupt); err != nil {
+		_ = p.cmd.Process.Kill()
+	}
+	killCtx, cancel := context.WithTimeout(ctx, shutdownTimeout)
+	defer cancel()
+	select {
+	case <-p.exited:
+	case <-killCtx.Done():
+		if err := p.cmd.Process.Kill(); err != nil && !errors.Is(err, os.ErrProcessDone) {
+			return fmt.Errorf("failed to kill the Toolbox server: %w", err)
+		}
+		<-p.exited
+	}
+	return nil
+}
+
+// waitReady polls the server until it answers, fails if the process exits
+// first.
+func waitReady(ctx context.Context, cfg *config, url string, proc *process) error {
+	ctx, cancel := context.WithTimeout(ctx, cfg.readyTimeout)
+	defer cancel()
+	ticker := time.NewTicker(readyPollInterval)
+	defer ticker.Stop()
+
+	for {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return fmt.Errorf("create request failed: %w", err)
+		}
+		if resp, err := cfg.httpClient.Do(req); err == nil {
+			_, _ = io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+			if resp.StatusCode == http.StatusOK {
+				return nil
+			}
+		}
+
+		select {
+		case <-proc.exited:
+			return fmt.Errorf("the Toolbox server exited before it was ready: %v", proc.err)
+		case <-ctx.Done():
+			return fmt.Errorf("the Toolbox server was not ready after %s: %w", cfg.readyTimeout, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+// freePort returns a port of the loopback interface that is currently free.
+func freePort() (int, error) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return 0, err
+	}
+	defer l.Close()
+	return l.Addr().(*net.TCPAddr).Port, nil
+}
+
+// binaryName is the file name of the Toolbox binary on this platform.
+func binaryName() string {
+	if runtime.GOOS == "windows" {
+		return "toolbox.exe"
+	}
+	return "toolbox"
+}
+
+// locateBinary returns the path of the Toolbox binary to run, downloading it
+// if a version was requested.
+func locateBinary(ctx context.Context, cfg *config) (string, error) {
+	if cfg.binaryPath != "" {
+		return cfg.binaryPath, nil
+	}
+	if cfg.version == "" {
+		path, err := exec.LookPath("toolbox")
+		if err != nil {
+			return "", fmt.Errorf("no Toolbox binary found in the PATH; use WithBinaryPath or WithVersion: %w", err)
+		}
+		return path, nil
+	}
+	if cfg.sha256 == "" {
+		return "", fmt.Errorf("WithVersion requires WithSHA256 to verify the downloaded binary")
+	}
+
+	dir := cfg.downloadDir
+	if dir == "" {
+		cacheDir, err := os.UserCacheDir()
+		if err != nil {
+			return "", fmt.Errorf("failed to locate the cache directory; use WithDownloadDir: %w", err)
+		}
+		dir = filepath.Join(cacheDir, "mcp-toolbox")
+	}
+	path := filepath.Join(dir, "v"+cfg.version, runtime.GOOS, runtime.GOARCH, binaryName())
+	// A cached binary that does not match, for example one downloaded for a
+	// different checksum, is replaced.
+	if sum, err := fileSHA256(path); err == nil && sum == cfg.sha256 {
+		return path, nil
+	}
+	if err := download(ctx, cfg, path); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// download fetches the configured release of the binary to path.
+func download(ctx context.Context, cfg *config, path string) error {
+	url := fmt.Sprintf("%s/v%s/%s/%s/%s", cfg.downloadURL, cfg.version, runtime.GOOS, runtime.GOARCH, binaryName())
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return fmt.Errorf("create request failed: %w", err)
+	}
+	resp, err := cfg.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to download the Toolbox binary: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to download the Toolbox binary from %s: status %d", url, resp.StatusCode)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create the download directory: %w", err)
+	}
+	// Write to a temporary file first, so that an interrupted download is
+	// never mistaken for a complete binary.
+	tmp, err := os.CreateTemp(filepath.Dir(path), binaryName()+".*")
+	if err != nil {
+		return fmt.Errorf("failed to create the Toolbox binary: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	hash := sha256.New()
+	if _, err := io.Copy(io.MultiWriter(tmp, hash), resp.Body); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to download the Toolbox binary: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write the Toolbox binary: %w", err)
+	}
+	if sum := hex.EncodeToString(hash.Sum(nil)); sum != cfg.sha256 {
+		return fmt.Errorf("the Toolbox binary downloaded from %s has SHA-256 checksum %s, want %s", url, sum, cfg.sha256)
+	}
+	if err := os.Chmod(tmp.Name(), 0755); err != nil {
+		return fmt.Errorf("failed to make the Toolbox binary executable: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to write the Toolbox binary: %w", err)
+	}
+	return nil
+}
+
+// fileSHA256 returns the hexadecimal SHA-256 checksum of the file at path.
+func fileSHA256(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	hash := sha256.New()
+	if _, err := io.Copy(hash, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
@@ -0,0 +1,226 @@
+//go:build unit
+
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package toolboxserver
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"flag"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// TestMain makes the test binary act as a fake Toolbox server when it is
+// run by Start with GO_WANT_HELPER_PROCESS set.
+func TestMain(m *testing.M) {
+	if os.Getenv("GO_WANT_HELPER_PROCESS") == "1" {
+		runFakeServer(os.Getenv("HELPER_MODE"))
+		return
+	}
+	os.Exit(m.Run())
+}
+
+func runFakeServer(mode string) {
+	flags := flag.NewFlagSet("toolbox", flag.ExitOnError)
+	toolsFile := flags.String("tools-file", "", "")
+	address := flags.String("address", "", "")
+	port := flags.Int("port", 0, "")
+	_ = flags.Parse(os.Args[1:])
+	fmt.Printf("serving %s\n", *toolsFile)
+	if mode == "exit" {
+		os.Exit(1)
+	}
+
+	interrupted := make(chan os.Signal, 1)
+	signal.Notify(interrupted, os.Interrupt)
+	server := &http.Server{
+		Addr: fmt.Sprintf("%s:%d", *address, *port),
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, "Hello, World!")
+		}),
+	}
+	go func() {
+		<-interrupted
+		fmt.Println("interrupted")
+		_ = server.Close()
+	}()
+	_ = server.ListenAndServe()
+	os.Exit(0)
+}
+
+// startFake starts the fake server in the given mode.
+func startFake(t *testing.T, mode string, opts ...Option) (*bytes.Buffer, ShutdownFunc, error) {
+	t.Helper()
+	t.Setenv("GO_WANT_HELPER_PROCESS", "1")
+	t.Setenv("HELPER_MODE", mode)
+	toolsFile := filepath.Join(t.TempDir(), "tools.yaml")
+	if err := os.WriteFile(toolsFile, []byte("tools: {}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	var output bytes.Buffer
+	opts = append([]Option{WithBinaryPath(os.Args[0]), WithOutput(&output), WithReadyTimeout(10 * time.Second)}, opts...)
+	client, shutdown, err := Start(context.Background(), toolsFile, opts...)
+	if err == nil && client == nil {
+		t.Fatal("Expected a client")
+	}
+	return &output, shutdown, err
+}
+
+func TestStart(t *testing.T) {
+	t.Run("Starts and shuts down the server", func(t *testing.T) {
+		output, shutdown, err := startFake(t, "")
+		if err != nil {
+			t.Fatalf("Start failed: %v", err)
+		}
+		if err := shutdown(context.Background()); err != nil {
+			t.Fatalf("Shutdown failed: %v", err)
+		}
+		if err := shutdown(context.Background()); err != nil {
+			t.Fatalf("Second Shutdown failed: %v", err)
+		}
+
+		if !strings.Contains(output.String(), "tools.yaml") {
+			t.Errorf("Expected the tools file to be served, got output %q", output.String())
+		}
+		if runtime.GOOS != "windows" && !strings.Contains(output.String(), "interrupted") {
+			t.Errorf("Expected the server to be interrupted, got output %q", output.String())
+		}
+	})
+
+	t.Run("Negative Test - Server exits during startup", func(t *testing.T) {
+		_, _, err := startFake(t, "exit")
+		if err == nil || !strings.Contains(err.Error(), "exited before it was ready") {
+			t.Errorf("Expected a startup error, got %v", err)
+		}
+	})
+
+	t.Run("Negative Test - Invalid usage", func(t *testing.T) {
+		ctx := context.Background()
+		if _, _, err := Start(ctx, filepath.Join(t.TempDir(), "missing.yaml")); err == nil || !strings.Contains(err.Error(), "invalid tools file") {
+			t.Errorf("Expected a tools file error, got %v", err)
+		}
+		if _, _, err := Start(ctx, "tools.yaml", WithPort(0)); err == nil || !strings.Contains(err.Error(), "invalid port") {
+			t.Errorf("Expected a port error, got %v", err)
+		}
+		if _, _, err := Start(ctx, "tools.yaml", WithBinaryPath("a"), WithBinaryPath("b")); err == nil || !strings.Contains(err.Error(), "already set") {
+			t.Errorf("Expected a duplicate option error, got %v", err)
+		}
+		if _, _, err := Start(ctx, "tools.yaml", WithSHA256("abc")); err == nil || !strings.Contains(err.Error(), "64 hexadecimal digits") {
+			t.Errorf("Expected a checksum error, got %v", err)
+		}
+	})
+}
+
+func TestLocateBinary(t *testing.T) {
+	var downloads atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != fmt.Sprintf("/v1.2.3/%s/%s/%s", runtime.GOOS, runtime.GOARCH, binaryName()) {
+			http.NotFound(w, r)
+			return
+		}
+		downloads.Add(1)
+		fmt.Fprint(w, "binary")
+	}))
+	defer server.Close()
+
+	sum := sha256.Sum256([]byte("binary"))
+	checksum := hex.EncodeToString(sum[:])
+	newConfig := func(version string) *config {
+		return &config{version: version, sha256: checksum, downloadURL: server.URL, downloadDir: t.TempDir(), httpClient: server.Client()}
+	}
+
+	t.Run("Downloads and caches a release", func(t *testing.T) {
+		cfg := newConfig("1.2.3")
+		path, err := locateBinary(context.Background(), cfg)
+		if err != nil {
+			t.Fatalf("locateBinary failed: %v", err)
+		}
+		content, err := os.ReadFile(path)
+		if err != nil || string(content) != "binary" {
+			t.Fatalf("Unexpected binary content %q: %v", content, err)
+		}
+		if info, _ := os.Stat(path); runtime.GOOS != "windows" && info.Mode()&0100 == 0 {
+			t.Errorf("Expected the binary to be executable, got mode %s", info.Mode())
+		}
+
+		if _, err := locateBinary(context.Background(), cfg); err != nil {
+			t.Fatalf("locateBinary failed: %v", err)
+		}
+		if downloads.Load() != 1 {
+			t.Errorf("Expected the binary to be downloaded once, got %d downloads", downloads.Load())
+		}
+
+		// A modified cached binary is downloaded again.
+		if err := os.WriteFile(path, []byte("tampered"), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := locateBinary(context.Background(), cfg); err != nil {
+			t.Fatalf("locateBinary failed: %v", err)
+		}
+		if content, _ := os.ReadFile(path); string(content) != "binary" || downloads.Load() != 2 {
+			t.Errorf("Expected the binary to be downloaded again, got %q after %d downloads", content, downloads.Load())
+		}
+	})
+
+	t.Run("Negative Test - Checksum mismatch", func(t *testing.T) {
+		cfg := newConfig("1.2.3")
+		cfg.sha256 = strings.Repeat("0", 64)
+		_, err := locateBinary(context.Background(), cfg)
+		if err == nil || !strings.Contains(err.Error(), "SHA-256 checksum "+checksum) {
+			t.Errorf("Expected a checksum error, got %v", err)
+		}
+		if entries, _ := os.ReadDir(filepath.Join(cfg.downloadDir, "v1.2.3", runtime.GOOS, runtime.GOARCH)); len(entries) != 0 {
+			t.Errorf("Expected no binary to be kept, got %v", entries)
+		}
+	})
+
+	t.Run("Negative Test - No checksum", func(t *testing.T) {
+		cfg := newConfig("1.2.3")
+		cfg.sha256 = ""
+		_, err := locateBinary(context.Background(), cfg)
+		if err == nil || !strings.Contains(err.Error(), "requires WithSHA256") {
+			t.Errorf("Expected a missing checksum error, got %v", err)
+		}
+	})
+
+	t.Run("Negative Test - Unknown release", func(t *testing.T) {
+		_, err := locateBinary(context.Background(), newConfig("9.9.9"))
+		if err == nil || !strings.Contains(err.Error(), "status 404") {
+			t.Errorf("Expected a download error, got %v", err)
+		}
+	})
+
+	t.Run("Negative Test - No binary in the PATH", func(t *testing.T) {
+		t.Setenv("PATH", "")
+		_, err := locateBinary(context.Background(), newConfig(""))
+		if err == nil || !strings.Contains(err.Error(), "no Toolbox binary found") {
+			t.Errorf("Expected a lookup error, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,58 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package toolboxtest
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Option configures a snapshot.
+type Option func(*config) error
+
+// config holds the settings of a snapshot.
+type config struct {
+	ignoredFields []string
+}
+
+// newConfig applies opts for the function named caller.
+func newConfig(caller string, opts []Option) (*config, error) {
+	cfg := &config{}
+	for _, opt := range opts {
+		if opt == nil {
+			return nil, fmt.Errorf("%s: received a nil Option", caller)
+		}
+		if err := opt(cfg); err != nil {
+			return nil, err
+		}
+	}
+	return cfg, nil
+}
+
+// WithIgnoredFields leaves fields out of the snapshot, such as the ones that
+// vary between deployments of the same tools. Fields are the JSON names of
+// the snapshot, with nested fields separated by dots and arrays traversed,
+// e.g. "description" or "parameters.description".
+func WithIgnoredFields(fields ...string) Option {
+	return func(c *config) error {
+		for _, field := range fields {
+			if field == "" || strings.HasPrefix(field, ".") || strings.HasSuffix(field, ".") || strings.Contains(field, "..") {
+				return fmt.Errorf("WithIgnoredFields: invalid field '%s'", field)
+			}
+		}
+		c.ignoredFields = append(c.ignoredFields, fields...)
+		return nil
+	}
+}
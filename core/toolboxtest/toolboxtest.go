@@ -0,0 +1,275 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package toolboxtest provides golden-file helpers that record the tools
+// served by a Toolbox server and report when they change. A snapshot is a
+// normalized JSON document: tools and parameters are sorted by name, lists
+// are sorted, and server-specific annotation keys are dropped, so that it only
+// changes when the tools or their schemas do.
+//
+// The package also provides Transport, an in-memory transport that serves a
+// fixed set of tools, for testing code built on core without a server.
+package toolboxtest
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/googleapis/mcp-toolbox-sdk-go/core"
+)
+
+// UpdateEnv is the environment variable that makes CheckGolden rewrite golden
+// files instead of comparing them, when set to a non-empty value.
+const UpdateEnv = "TOOLBOX_UPDATE_GOLDEN"
+
+// Snapshot returns the normalized manifest of tools, ready to be written to a
+// golden file.
+//
+// Inputs:
+//   - tools: The tools to record, such as the result of
+//     core.ToolboxClient.LoadToolset.
+//   - opts: A variadic list of Option functions, such as WithIgnoredFields.
+//
+// Returns:
+//
+//	The indented JSON document, or an error if the tools or options are
+//	invalid.
+func Snapshot(tools []*core.ToolboxTool, opts ...Option) ([]byte, error) {
+	cfg, err := newConfig("Snapshot", opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := core.Toolset(tools).ByName(); err != nil {
+		return nil, fmt.Errorf("Snapshot: %w", err)
+	}
+	entries := make([]any, 0, len(tools))
+	for _, tool := range tools {
+		entry, err := toolEntry(tool)
+		if err != nil {
+			return nil, fmt.Errorf("error recording tool '%s': %w", tool.Name(), err)
+		}
+		for _, field := range cfg.ignoredFields {
+			deleteField(entry, strings.Split(field, "."))
+		}
+		entries = append(entries, entry)
+	}
+	slices.SortFunc(entries, func(a, b any) int {
+		return strings.Compare(a.(map[string]any)["name"].(string), b.(map[string]any)["name"].(string))
+	})
+
+	data, err := json.MarshalIndent(map[string]any{"tools": entries}, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("error encoding the snapshot: %w", err)
+	}
+	return append(data, '\n'), nil
+}
+
+// toolEntry returns the normalized description of tool as generic JSON
+// values, whose object keys are sorted when encoded.
+func toolEntry(tool *core.ToolboxTool) (map[string]any, error) {
+	params := tool.Parameters()
+	slices.SortFunc(params, func(a, b core.ParameterSchema) int { return strings.Compare(a.Name, b.Name) })
+	for i := range params {
+		params[i].AuthSources = sorted(params[i].AuthSources)
+	}
+
+	authParams := tool.RequiredAuthnParams()
+	for name, services := range authParams {
+		authParams[name] = sorted(services)
+	}
+
+	var outputSchema map[string]any
+	if raw, err := tool.OutputSchema(); err != nil {
+		return nil, err
+	} else if raw != nil {
+		if err := json.Unmarshal(raw, &outputSchema); err != nil {
+			return nil, err
+		}
+	}
+
+	// Round-trip through JSON so that ignored fields can be removed from
+	// nested values, such as parameters.
+	data, err := json.Marshal(struct {
+		Name           string                 `json:"name"`
+		Title          string                 `json:"title,omitempty"`
+		Description    string                 `json:"description"`
+		Tags           []string               `json:"tags,omitempty"`
+		Hints          core.ToolHints         `json:"hints"`
+		AuthRequired   []string               `json:"authRequired,omitempty"`
+		AuthParameters map[string][]string    `json:"authParameters,omitempty"`
+		Parameters     []core.ParameterSchema `json:"parameters"`
+		OutputSchema   map[string]any         `json:"outputSchema,omitempty"`
+	}{
+		Name:           tool.Name(),
+		Title:          tool.Title(),
+		Description:    tool.Description(),
+		Tags:           sorted(tool.Tags()),
+		Hints:          tool.Hints(),
+		AuthRequired:   sorted(tool.RequiredAuthzServices()),
+		AuthParameters: authParams,
+		Parameters:     params,
+		OutputSchema:   outputSchema,
+	})
+	if err != nil {
+		return nil, err
+	}
+	var entry map[string]any
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+// sorted returns a sorted copy of s.
+func sorted(s []string) []string {
+	s = slices.Clone(s)
+	slices.Sort(s)
+	return s
+}
+
+// deleteField removes the field at path from v, descending into every
+// element of the arrays along the way.
+func deleteField(v any, path []string) {
+	switch v := v.(type) {
+	case map[string]any:
+		if len(path) == 1 {
+			delete(v, path[0])
+			return
+		}
+		if child, ok := v[path[0]]; ok {
+			deleteField(child, path[1:])
+		}
+	case []any:
+		for _, elem := range v {
+			deleteField(elem, path)
+		}
+	}
+}
+
+// WriteGolden writes the snapshot of tools to the golden file at path,
+// creating its directory if needed.
+func WriteGolden(path string, tools []*core.ToolboxTool, opts ...Option) error {
+	data, err := Snapshot(tools, opts...)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("error creating the golden file directory: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("error writing the golden file: %w", err)
+	}
+	return nil
+}
+
+// CompareGolden compares the snapshot of tools with the golden file at path.
+//
+// Returns:
+//
+//	A line diff from the golden file to the current snapshot, which is empty
+//	when they match, or an error if the snapshot cannot be taken or the file
+//	cannot be read. A missing golden file is reported as an error wrapping
+//	fs.ErrNotExist.
+func CompareGolden(path string, tools []*core.ToolboxTool, opts ...Option) (string, error) {
+	got, err := Snapshot(tools, opts...)
+	if err != nil {
+		return "", err
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("error reading the golden file: %w", err)
+	}
+	if bytes.Equal(got, want) {
+		return "", nil
+	}
+	return diffLines(string(want), string(got)), nil
+}
+
+// CheckGolden fails t if the snapshot of tools differs from the golden file at
+// path. When the UpdateEnv environment variable is set, or the file does not
+// exist yet, it writes the golden file instead.
+func CheckGolden(t testing.TB, path string, tools []*core.ToolboxTool, opts ...Option) {
+	t.Helper()
+	if os.Getenv(UpdateEnv) != "" {
+		if err := WriteGolden(path, tools, opts...); err != nil {
+			t.Fatalf("CheckGolden: %v", err)
+		}
+		return
+	}
+
+	diff, err := CompareGolden(path, tools, opts...)
+	if errors.Is(err, fs.ErrNotExist) {
+		if err := WriteGolden(path, tools, opts...); err != nil {
+			t.Fatalf("CheckGolden: %v", err)
+		}
+		t.Logf("CheckGolden: created the golden file %s", path)
+		return
+	}
+	if err != nil {
+		t.Fatalf("CheckGolden: %v", err)
+	}
+	if diff != "" {
+		t.Errorf("tools differ from the golden file %s (-golden +current); set %s=1 to update it:\n%s", path, UpdateEnv, diff)
+	}
+}
+
+// diffLines returns the lines removed from want, prefixed with "-", and added
+// in got, prefixed with "+", around the unchanged lines, prefixed with " ".
+func diffLines(want, got string) string {
+	a := strings.Split(strings.TrimSuffix(want, "\n"), "\n")
+	b := strings.Split(strings.TrimSuffix(got, "\n"), "\n")
+
+	// lcs[i][j] is the length of the longest common subsequence of a[i:]
+	// and b[j:].
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var sb strings.Builder
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			sb.WriteString(" " + a[i] + "\n")
+			i++
+			j++
+		case j < len(b) && (i == len(a) || lcs[i][j+1] >= lcs[i+1][j]):
+			sb.WriteString("+" + b[j] + "\n")
+			j++
+		default:
+			sb.WriteString("-" + a[i] + "\n")
+			i++
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,197 @@
+//go:build unit
+
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package toolboxtest
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/googleapis/mcp-toolbox-sdk-go/core"
+	"github.com/googleapis/mcp-toolbox-sdk-go/core/transport"
+)
+
+// loadTools loads a "book" tool and a "search" tool with the given parameters
+// and description.
+func loadTools(t *testing.T, searchParams []core.ParameterSchema, description string) []*core.ToolboxTool {
+	t.Helper()
+	return LoadToolset(t, &Transport{Tools: map[string]transport.ToolSchema{
+		"search": {
+			Description: description,
+			Tags:        []string{"travel", "catalog"},
+			Parameters:  searchParams,
+			Annotations: map[string]any{"readOnlyHint": true, "x-deployment": "eu-1"},
+		},
+		"book": {
+			Title:        "Book",
+			Description:  "Book a hotel",
+			Parameters:   []core.ParameterSchema{{Name: "id", Type: "integer", Required: true}},
+			AuthRequired: []string{"google"},
+		},
+	}})
+}
+
+var (
+	queryParam = core.ParameterSchema{Name: "query", Type: "string", Required: true, Description: "Search text"}
+	limitParam = core.ParameterSchema{Name: "limit", Type: "integer", Description: "Maximum results"}
+)
+
+func TestSnapshot(t *testing.T) {
+	t.Run("normalized", func(t *testing.T) {
+		first, err := Snapshot(loadTools(t, []core.ParameterSchema{queryParam, limitParam}, "Search hotels"))
+		if err != nil {
+			t.Fatalf("Snapshot() error = %v", err)
+		}
+		reordered := loadTools(t, []core.ParameterSchema{limitParam, queryParam}, "Search hotels")
+		reordered[0], reordered[1] = reordered[1], reordered[0]
+		second, err := Snapshot(reordered)
+		if err != nil {
+			t.Fatalf("Snapshot() error = %v", err)
+		}
+		if string(first) != string(second) {
+			t.Errorf("snapshots differ with the ordering of tools and parameters:\n%s\n%s", first, second)
+		}
+
+		s := string(first)
+		if strings.Index(s, `"book"`) > strings.Index(s, `"search"`) {
+			t.Errorf("tools are not sorted by name:\n%s", s)
+		}
+		if strings.Index(s, `"catalog"`) > strings.Index(s, `"travel"`) {
+			t.Errorf("tags are not sorted:\n%s", s)
+		}
+		if !strings.Contains(s, `"readOnlyHint": true`) || strings.Contains(s, "x-deployment") {
+			t.Errorf("unexpected hints:\n%s", s)
+		}
+		if !strings.Contains(s, `"authRequired": [`) || !strings.HasSuffix(s, "}\n") {
+			t.Errorf("unexpected snapshot:\n%s", s)
+		}
+	})
+
+	t.Run("ignored fields", func(t *testing.T) {
+		data, err := Snapshot(loadTools(t, []core.ParameterSchema{queryParam}, "Search hotels"),
+			WithIgnoredFields("title", "parameters.description"))
+		if err != nil {
+			t.Fatalf("Snapshot() error = %v", err)
+		}
+		s := string(data)
+		if strings.Contains(s, `"title"`) || strings.Contains(s, "Search text") {
+			t.Errorf("ignored fields are in the snapshot:\n%s", s)
+		}
+		if !strings.Contains(s, "Search hotels") {
+			t.Errorf("tool description is missing from the snapshot:\n%s", s)
+		}
+	})
+
+	tools := loadTools(t, nil, "Search hotels")
+	errCases := []struct {
+		name    string
+		tools   []*core.ToolboxTool
+		opts    []Option
+		wantErr string
+	}{
+		{"nil tool", []*core.ToolboxTool{nil}, nil, "tool 0 is nil"},
+		{"duplicate tool", []*core.ToolboxTool{tools[0], tools[0]}, nil, "duplicate tool name"},
+		{"nil option", tools, []Option{nil}, "received a nil Option"},
+		{"empty field", tools, []Option{WithIgnoredFields("")}, "invalid field"},
+		{"malformed field", tools, []Option{WithIgnoredFields("parameters..name")}, "invalid field"},
+	}
+	for _, tc := range errCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := Snapshot(tc.tools, tc.opts...)
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Errorf("Snapshot() error = %v, want it to contain %q", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestGolden(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "testdata", "tools.golden.json")
+	tools := loadTools(t, []core.ParameterSchema{queryParam}, "Search hotels")
+
+	if _, err := CompareGolden(path, tools); !errors.Is(err, fs.ErrNotExist) {
+		t.Fatalf("CompareGolden() error = %v, want fs.ErrNotExist", err)
+	}
+	if err := WriteGolden(path, tools); err != nil {
+		t.Fatalf("WriteGolden() error = %v", err)
+	}
+	if diff, err := CompareGolden(path, tools); err != nil || diff != "" {
+		t.Fatalf("CompareGolden() = %q, %v, want no diff", diff, err)
+	}
+
+	changed := loadTools(t, []core.ParameterSchema{queryParam}, "Search hotels and flats")
+	diff, err := CompareGolden(path, changed)
+	if err != nil {
+		t.Fatalf("CompareGolden() error = %v", err)
+	}
+	if !strings.Contains(diff, `-      "description": "Search hotels",`) || !strings.Contains(diff, `+      "description": "Search hotels and flats",`) {
+		t.Errorf("unexpected diff:\n%s", diff)
+	}
+	if strings.Count(diff, "\n-") != 1 || strings.Count(diff, "\n+") != 1 {
+		t.Errorf("diff has more than the changed line:\n%s", diff)
+	}
+
+	// CheckGolden passes on a match and rewrites the file when asked to.
+	CheckGolden(t, path, tools)
+	t.Setenv(UpdateEnv, "1")
+	CheckGolden(t, path, changed)
+	if diff, err := CompareGolden(path, changed); err != nil || diff != "" {
+		t.Errorf("CheckGolden() did not update the golden file: %q, %v", diff, err)
+	}
+
+	// CheckGolden creates missing golden files.
+	t.Setenv(UpdateEnv, "")
+	created := filepath.Join(t.TempDir(), "new.golden.json")
+	CheckGolden(t, created, tools)
+	if _, err := os.Stat(created); err != nil {
+		t.Errorf("CheckGolden() did not create the golden file: %v", err)
+	}
+}
+
+func TestDiffLines(t *testing.T) {
+	got := diffLines("a\nb\nc\n", "a\nc\nd\n")
+	want := " a\n-b\n c\n+d\n"
+	if got != want {
+		t.Errorf("diffLines() = %q, want %q", got, want)
+	}
+}
+
+func TestTransport(t *testing.T) {
+	tr := &Transport{
+		Tools: map[string]transport.ToolSchema{
+			"echo": {Parameters: []core.ParameterSchema{{Name: "text", Type: "string", Required: true}}},
+		},
+		Invoke: func(_ context.Context, name string, payload map[string]any) (any, error) {
+			return name + ": " + payload["text"].(string), nil
+		},
+	}
+
+	got, err := LoadTool(t, tr, "echo").Invoke(context.Background(), map[string]any{"text": "hi"})
+	if err != nil || got != "echo: hi" {
+		t.Errorf("Expected the result of Invoke, got %v, %v", got, err)
+	}
+	if invoked := tr.Invoked(); len(invoked) != 1 || invoked[0] != "echo" {
+		t.Errorf("Expected the invocation to be recorded, got %v", invoked)
+	}
+	if _, err := tr.GetTool(context.Background(), "missing", nil); err == nil || !strings.Contains(err.Error(), "no tool named 'missing'") {
+		t.Errorf("Expected an error for a missing tool, got %v", err)
+	}
+}
@@ -0,0 +1,110 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package toolboxtest
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/googleapis/mcp-toolbox-sdk-go/core"
+	"github.com/googleapis/mcp-toolbox-sdk-go/core/transport"
+)
+
+// Transport is an in-memory transport.Transport that serves a fixed set of
+// tools, so that code built on core can be tested without a Toolbox server.
+// Use it with core.WithCustomTransport, or with LoadTool and LoadToolset.
+// Every toolset holds all of its tools.
+type Transport struct {
+	// Tools are the tools served, by name.
+	Tools map[string]transport.ToolSchema
+	// Invoke, if set, computes the result of every invocation. Without it,
+	// invocations return a nil result.
+	Invoke func(ctx context.Context, name string, payload map[string]any) (any, error)
+
+	mu      sync.Mutex
+	invoked []string
+}
+
+// BaseURL returns a placeholder URL.
+func (f *Transport) BaseURL() string { return "http://toolboxtest" }
+
+// GetTool returns the manifest of the named tool, or an error if there is no
+// such tool.
+func (f *Transport) GetTool(_ context.Context, name string, _ map[string]string) (*transport.ManifestSchema, error) {
+	schema, ok := f.Tools[name]
+	if !ok {
+		return nil, fmt.Errorf("toolboxtest: no tool named '%s'", name)
+	}
+	return &transport.ManifestSchema{Tools: map[string]transport.ToolSchema{name: schema}}, nil
+}
+
+// ListTools returns the manifest of all tools.
+func (f *Transport) ListTools(_ context.Context, _ string, _ map[string]string) (*transport.ManifestSchema, error) {
+	return &transport.ManifestSchema{Tools: f.Tools}, nil
+}
+
+// InvokeTool records the invocation and returns the result of Invoke.
+func (f *Transport) InvokeTool(ctx context.Context, name string, payload map[string]any, _ map[string]string) (any, error) {
+	f.mu.Lock()
+	f.invoked = append(f.invoked, name)
+	f.mu.Unlock()
+	if f.Invoke == nil {
+		return nil, nil
+	}
+	return f.Invoke(ctx, name, payload)
+}
+
+// Close does nothing.
+func (f *Transport) Close(context.Context) error { return nil }
+
+// Invoked returns the names of the tools invoked so far, in order.
+func (f *Transport) Invoked() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return slices.Clone(f.invoked)
+}
+
+// LoadTool loads the named tool of tr with opts, and fails the test if it
+// cannot be loaded.
+func LoadTool(t testing.TB, tr *Transport, name string, opts ...core.ToolOption) *core.ToolboxTool {
+	t.Helper()
+	client, err := core.NewToolboxClient(tr.BaseURL(), core.WithCustomTransport(tr))
+	if err != nil {
+		t.Fatalf("Failed to create ToolboxClient: %v", err)
+	}
+	tool, err := client.LoadTool(name, context.Background(), opts...)
+	if err != nil {
+		t.Fatalf("Failed to load tool '%s': %v", name, err)
+	}
+	return tool
+}
+
+// LoadToolset loads all tools of tr with opts, sorted by name, and fails the
+// test if they cannot be loaded.
+func LoadToolset(t testing.TB, tr *Transport, opts ...core.ToolOption) core.Toolset {
+	t.Helper()
+	client, err := core.NewToolboxClient(tr.BaseURL(), core.WithCustomTransport(tr))
+	if err != nil {
+		t.Fatalf("Failed to create ToolboxClient: %v", err)
+	}
+	tools, err := client.LoadToolset("", context.Background(), opts...)
+	if err != nil {
+		t.Fatalf("Failed to load toolset: %v", err)
+	}
+	return tools
+}
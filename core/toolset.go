@@ -0,0 +1,110 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"fmt"
+	"path"
+	"slices"
+)
+
+// Toolset is a collection of tools, such as the result of LoadToolset.
+type Toolset []*ToolboxTool
+
+// FilterByTag returns the tools in the set that carry at least one of the
+// given tags. The original set is not modified.
+func (ts Toolset) FilterByTag(tags ...string) Toolset {
+	filtered := make(Toolset, 0, len(ts))
+	for _, tool := range ts {
+		if tool != nil && hasAnyTag(tool.tags, tags) {
+			filtered = append(filtered, tool)
+		}
+	}
+	return filtered
+}
+
+// ByName indexes the tools in the set by name, for adapters that dispatch
+// calls made by a model to the tool it names.
+//
+// Returns:
+//
+//	The tools keyed by name, or an error if the set contains a nil tool or
+//	several tools with the same name.
+func (ts Toolset) ByName() (map[string]*ToolboxTool, error) {
+	byName := make(map[string]*ToolboxTool, len(ts))
+	for i, tool := range ts {
+		if tool == nil {
+			return nil, fmt.Errorf("tool %d is nil", i)
+		}
+		if _, exists := byName[tool.name]; exists {
+			return nil, fmt.Errorf("duplicate tool name '%s'", tool.name)
+		}
+		byName[tool.name] = tool
+	}
+	return byName, nil
+}
+
+// hasAnyTag reports whether any of the wanted tags is present in toolTags.
+func hasAnyTag(toolTags []string, wanted []string) bool {
+	for _, tag := range wanted {
+		if slices.Contains(toolTags, tag) {
+			return true
+		}
+	}
+	return false
+}
+
+// Filter returns the tools in the set for which keep returns true. The
+// original set is not modified.
+func (ts Toolset) Filter(keep func(*ToolboxTool) bool) Toolset {
+	filtered := make(Toolset, 0, len(ts))
+	for _, tool := range ts {
+		if tool != nil && keep(tool) {
+			filtered = append(filtered, tool)
+		}
+	}
+	return filtered
+}
+
+// FilterByName returns the tools in the set whose names match at least one of
+// the given names or glob patterns, in the syntax of path.Match. Malformed
+// patterns match no tools. The original set is not modified.
+func (ts Toolset) FilterByName(patterns ...string) Toolset {
+	return ts.Filter(func(tool *ToolboxTool) bool {
+		return matchesAny(tool.name, patterns)
+	})
+}
+
+// matchesAny reports whether name matches any of the glob patterns.
+func matchesAny(name string, patterns []string) bool {
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// includesTool reports whether the tool filters of c let the named tool be
+// loaded.
+func (c *ToolConfig) includesTool(name string) bool {
+	if c.ToolNameRegexp != nil && !c.ToolNameRegexp.MatchString(name) {
+		return false
+	}
+	if matchesAny(name, c.ExcludeTools) {
+		return false
+	}
+	return len(c.IncludeTools) == 0 || matchesAny(name, c.IncludeTools)
+}
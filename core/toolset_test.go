@@ -0,0 +1,112 @@
+//go:build unit
+
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"regexp"
+	"slices"
+	"testing"
+)
+
+func TestToolset_FilterByTag(t *testing.T) {
+	toolset := Toolset{
+		{name: "search", tags: []string{"read-only", "search"}},
+		{name: "update", tags: []string{"write"}},
+		{name: "untagged"},
+		nil,
+	}
+
+	t.Run("Returns tools carrying any of the tags", func(t *testing.T) {
+		filtered := toolset.FilterByTag("read-only", "write")
+		if len(filtered) != 2 {
+			t.Fatalf("Expected 2 tools, got %d", len(filtered))
+		}
+		if filtered[0].Name() != "search" || filtered[1].Name() != "update" {
+			t.Errorf("Unexpected tools returned: %s, %s", filtered[0].Name(), filtered[1].Name())
+		}
+	})
+
+	t.Run("Returns an empty set when nothing matches", func(t *testing.T) {
+		filtered := toolset.FilterByTag("admin")
+		if filtered == nil || len(filtered) != 0 {
+			t.Fatalf("Expected a non-nil empty set, got %v", filtered)
+		}
+	})
+
+	t.Run("Does not modify the original set", func(t *testing.T) {
+		_ = toolset.FilterByTag("write")
+		if len(toolset) != 4 || toolset[0].Name() != "search" {
+			t.Error("FilterByTag modified the original toolset")
+		}
+	})
+}
+
+func TestToolset_FilterByName(t *testing.T) {
+	toolset := Toolset{
+		{name: "bq-query"},
+		{name: "bq-list-tables"},
+		{name: "sql-execute"},
+		nil,
+	}
+
+	testCases := []struct {
+		name     string
+		patterns []string
+		want     []string
+	}{
+		{"Glob", []string{"bq-*"}, []string{"bq-query", "bq-list-tables"}},
+		{"Exact names and globs", []string{"sql-execute", "*-query"}, []string{"bq-query", "sql-execute"}},
+		{"Malformed pattern", []string{"bq-["}, []string{}},
+		{"No patterns", nil, []string{}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := toolNames(toolset.FilterByName(tc.patterns...)); !slices.Equal(got, tc.want) {
+				t.Errorf("Expected %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestToolset_Filter(t *testing.T) {
+	toolset := Toolset{{name: "bq-query"}, {name: "sql-execute"}, nil}
+	re := regexp.MustCompile(`^sql-`)
+
+	filtered := toolset.Filter(func(tool *ToolboxTool) bool { return re.MatchString(tool.Name()) })
+	if got := toolNames(filtered); !slices.Equal(got, []string{"sql-execute"}) {
+		t.Errorf("Expected [sql-execute], got %v", got)
+	}
+}
+
+func TestToolset_ByName(t *testing.T) {
+	search, update := &ToolboxTool{name: "search"}, &ToolboxTool{name: "update"}
+
+	byName, err := Toolset{search, update}.ByName()
+	if err != nil {
+		t.Fatalf("ByName failed: %v", err)
+	}
+	if len(byName) != 2 || byName["search"] != search || byName["update"] != update {
+		t.Errorf("Unexpected index: %v", byName)
+	}
+
+	if _, err := (Toolset{search, nil}).ByName(); err == nil || err.Error() != "tool 1 is nil" {
+		t.Errorf("Unexpected error for a nil tool: %v", err)
+	}
+	if _, err := (Toolset{search, update, search}).ByName(); err == nil || err.Error() != "duplicate tool name 'search'" {
+		t.Errorf("Unexpected error for duplicate tools: %v", err)
+	}
+}
@@ -0,0 +1,65 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transport
+
+import "context"
+
+// Completion reference types. MCP defines completions for the arguments of
+// prompts and the variables of resource templates; tool arguments are only
+// completed by servers that extend the protocol to support them.
+const (
+	CompletionRefPrompt   = "ref/prompt"
+	CompletionRefResource = "ref/resource"
+	CompletionRefTool     = "ref/tool"
+)
+
+// CompletionRef identifies the prompt, resource template or tool whose
+// argument is completed.
+type CompletionRef struct {
+	Type string `json:"type"`
+	// Name identifies a prompt or tool.
+	Name string `json:"name,omitempty"`
+	// URI is the URI template of a resource template.
+	URI string `json:"uri,omitempty"`
+}
+
+// CompletionRequest asks for the values an argument may take.
+type CompletionRequest struct {
+	Ref CompletionRef
+	// ArgumentName is the name of the argument to complete.
+	ArgumentName string
+	// Value is the partial value typed so far.
+	Value string
+	// Arguments holds the values of arguments that are already resolved,
+	// which servers may use to narrow down the completions.
+	Arguments map[string]string
+}
+
+// Completion holds the suggested values for an argument.
+type Completion struct {
+	// Values are the suggestions, at most 100.
+	Values []string `json:"values"`
+	// Total is the total number of suggestions, if known.
+	Total int `json:"total,omitempty"`
+	// HasMore reports whether there are more suggestions than Values.
+	HasMore bool `json:"hasMore,omitempty"`
+}
+
+// Completer is implemented by transports that can ask the server to complete
+// arguments.
+type Completer interface {
+	// Complete returns the server's suggestions for an argument.
+	Complete(ctx context.Context, req CompletionRequest, headers map[string]string) (*Completion, error)
+}
@@ -0,0 +1,153 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transport
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// ErrRateLimited matches, with errors.Is, the ToolInvocationErrors of
+// requests rejected with a 429 Too Many Requests response. Their RetryAfter
+// field holds the delay requested by the server, if any.
+var ErrRateLimited = errors.New("rate limited")
+
+// ToolInvocationError describes an error reported by the Toolbox server.
+type ToolInvocationError struct {
+	// StatusCode is the HTTP status code of a failed request, or 0 if the
+	// request itself succeeded.
+	StatusCode int
+	// Code is the JSON-RPC or server-specific error code, if any.
+	Code int
+	// Message is the human-readable error message reported by the server.
+	Message string
+	// Details holds any additional structured error data sent by the server.
+	Details any
+	// Retryable reports whether the request may succeed if sent again.
+	Retryable bool
+	// RetryAfter is how long the server asked clients to wait before sending
+	// the request again, from a Retry-After header or a retryAfter field of
+	// the error body, in seconds, or 0 if it did not say.
+	RetryAfter time.Duration
+}
+
+// Is reports whether the error matches target. A rate limited request
+// matches ErrRateLimited.
+func (e *ToolInvocationError) Is(target error) bool {
+	return target == ErrRateLimited && e.StatusCode == http.StatusTooManyRequests
+}
+
+func (e *ToolInvocationError) Error() string {
+	switch {
+	case e.StatusCode != 0:
+		return fmt.Sprintf("API request failed with status %d: %s", e.StatusCode, e.Message)
+	case e.Code != 0:
+		return fmt.Sprintf("MCP request failed with code %d: %s", e.Code, e.Message)
+	case e.Message != "":
+		return "tool execution resulted in error: " + e.Message
+	default:
+		return "tool execution resulted in error"
+	}
+}
+
+// NewHTTPError builds a ToolInvocationError from a failed HTTP response. If the
+// body is a JSON error object, its code, message and details are extracted;
+// otherwise the raw body is used as the message.
+func NewHTTPError(statusCode int, body []byte) *ToolInvocationError {
+	e := &ToolInvocationError{
+		StatusCode: statusCode,
+		Message:    string(body),
+		Retryable:  isRetryableStatus(statusCode),
+	}
+
+	var envelope struct {
+		Error      json.RawMessage `json:"error"`
+		Code       int             `json:"code"`
+		Message    string          `json:"message"`
+		Details    any             `json:"details"`
+		Data       any             `json:"data"`
+		RetryAfter float64         `json:"retryAfter"`
+	}
+	if err := json.Unmarshal(body, &envelope); err != nil {
+		return e
+	}
+
+	// The error may be nested under an "error" key, either as an object
+	// (e.g. a JSON-RPC error) or as a plain string.
+	if len(envelope.Error) > 0 {
+		var msg string
+		if err := json.Unmarshal(envelope.Error, &msg); err == nil {
+			envelope.Message = msg
+		} else {
+			_ = json.Unmarshal(envelope.Error, &envelope)
+		}
+	}
+
+	if envelope.Message != "" {
+		e.Message = envelope.Message
+	}
+	e.Code = envelope.Code
+	e.Details = envelope.Details
+	if e.Details == nil {
+		e.Details = envelope.Data
+	}
+	if envelope.RetryAfter > 0 {
+		e.RetryAfter = time.Duration(envelope.RetryAfter * float64(time.Second))
+	}
+	return e
+}
+
+// NewHTTPResponseError builds a ToolInvocationError from a failed HTTP
+// response and its body, as NewHTTPError does. A Retry-After header takes
+// precedence over a delay given in the body.
+func NewHTTPResponseError(resp *http.Response, body []byte) *ToolInvocationError {
+	e := NewHTTPError(resp.StatusCode, body)
+	if delay := RetryAfter(resp.Header); delay > 0 {
+		e.RetryAfter = delay
+	}
+	return e
+}
+
+// RetryAfter returns the delay requested by a Retry-After header, given in
+// seconds or as an HTTP date, or 0 if there is none.
+func RetryAfter(header http.Header) time.Duration {
+	value := header.Get("Retry-After")
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return time.Duration(seconds) * time.Second
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return time.Until(date)
+	}
+	return 0
+}
+
+// isRetryableStatus reports whether a request failing with the given HTTP
+// status code may succeed if retried.
+func isRetryableStatus(statusCode int) bool {
+	switch statusCode {
+	case http.StatusRequestTimeout, http.StatusTooManyRequests,
+		http.StatusInternalServerError, http.StatusBadGateway,
+		http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,149 @@
+//go:build unit
+
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transport
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestNewHTTPError(t *testing.T) {
+	testCases := []struct {
+		name     string
+		status   int
+		body     string
+		expected ToolInvocationError
+		errorMsg string
+	}{
+		{
+			name:     "Plain text body",
+			status:   500,
+			body:     "Internal Error",
+			expected: ToolInvocationError{StatusCode: 500, Message: "Internal Error", Retryable: true},
+			errorMsg: "API request failed with status 500: Internal Error",
+		},
+		{
+			name:   "Flat JSON error object",
+			status: 400,
+			body:   `{"code": 3, "message": "invalid argument", "details": {"field": "id"}}`,
+			expected: ToolInvocationError{
+				StatusCode: 400,
+				Code:       3,
+				Message:    "invalid argument",
+				Details:    map[string]any{"field": "id"},
+			},
+			errorMsg: "API request failed with status 400: invalid argument",
+		},
+		{
+			name:   "Nested JSON-RPC error object",
+			status: 503,
+			body:   `{"jsonrpc": "2.0", "error": {"code": -32000, "message": "overloaded", "data": "retry later"}}`,
+			expected: ToolInvocationError{
+				StatusCode: 503,
+				Code:       -32000,
+				Message:    "overloaded",
+				Details:    "retry later",
+				Retryable:  true,
+			},
+			errorMsg: "API request failed with status 503: overloaded",
+		},
+		{
+			name:     "Nested string error",
+			status:   429,
+			body:     `{"error": "rate limited"}`,
+			expected: ToolInvocationError{StatusCode: 429, Message: "rate limited", Retryable: true},
+			errorMsg: "API request failed with status 429: rate limited",
+		},
+		{
+			name:   "Structured rate limit error",
+			status: 429,
+			body:   `{"error": {"code": 8, "message": "quota exceeded", "retryAfter": 1.5}}`,
+			expected: ToolInvocationError{
+				StatusCode: 429,
+				Code:       8,
+				Message:    "quota exceeded",
+				Retryable:  true,
+				RetryAfter: 1500 * time.Millisecond,
+			},
+			errorMsg: "API request failed with status 429: quota exceeded",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := NewHTTPError(tc.status, []byte(tc.body))
+			if !reflect.DeepEqual(*err, tc.expected) {
+				t.Errorf("Unexpected error.\nExpected: %+v\nGot:      %+v", tc.expected, *err)
+			}
+			if err.Error() != tc.errorMsg {
+				t.Errorf("Expected message %q, got %q", tc.errorMsg, err.Error())
+			}
+		})
+	}
+}
+
+func TestToolInvocationError_Error(t *testing.T) {
+	testCases := []struct {
+		name     string
+		err      ToolInvocationError
+		expected string
+	}{
+		{"RPC error", ToolInvocationError{Code: -32601, Message: "method not found"}, "MCP request failed with code -32601: method not found"},
+		{"Tool error with message", ToolInvocationError{Message: "table missing"}, "tool execution resulted in error: table missing"},
+		{"Tool error without message", ToolInvocationError{}, "tool execution resulted in error"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.err.Error(); got != tc.expected {
+				t.Errorf("Expected %q, got %q", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestNewHTTPResponseError(t *testing.T) {
+	body := []byte(`{"error": {"message": "slow down", "retryAfter": 30}}`)
+
+	t.Run("Prefers the Retry-After header", func(t *testing.T) {
+		resp := &http.Response{StatusCode: 429, Header: http.Header{"Retry-After": {"5"}}}
+		if got := NewHTTPResponseError(resp, body).RetryAfter; got != 5*time.Second {
+			t.Errorf("Expected a 5s delay, got %v", got)
+		}
+	})
+
+	t.Run("Falls back to the body", func(t *testing.T) {
+		resp := &http.Response{StatusCode: 429, Header: http.Header{}}
+		if got := NewHTTPResponseError(resp, body).RetryAfter; got != 30*time.Second {
+			t.Errorf("Expected a 30s delay, got %v", got)
+		}
+	})
+}
+
+func TestErrRateLimited(t *testing.T) {
+	rateLimited := fmt.Errorf("failed to list tools: %w", NewHTTPError(429, nil))
+	if !errors.Is(rateLimited, ErrRateLimited) {
+		t.Error("Expected a 429 error to match ErrRateLimited")
+	}
+	if errors.Is(NewHTTPError(503, nil), ErrRateLimited) {
+		t.Error("Expected a 503 error not to match ErrRateLimited")
+	}
+}
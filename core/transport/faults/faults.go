@@ -0,0 +1,320 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package faults injects failures into the requests of a Toolbox client, so
+// that applications can check how their retry, backoff and error handling
+// behave when a server is slow or unreliable. It is meant for tests and
+// staging environments only.
+//
+// An Injector adds random latency and, with the configured probabilities,
+// drops connections, corrupts JSON responses or answers with bursts of server
+// errors. It can be installed at two levels:
+//
+//   - RoundTripper wraps an http.RoundTripper, for use with
+//     core.WithHTTPClient. Faults then happen below the MCP transports, so
+//     they exercise the policy set with core.WithTransportRetry.
+//   - Wrap decorates any transport.Transport, for use with
+//     core.WithCustomTransport. Faults then reach the caller directly.
+package faults
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/googleapis/mcp-toolbox-sdk-go/core/transport"
+)
+
+// ErrConnectionDropped is the error of the requests whose connection was
+// dropped by an Injector.
+var ErrConnectionDropped = errors.New("faults: connection dropped")
+
+// serverErrorBody is the body of injected server errors.
+const serverErrorBody = `{"error":"faults: injected server error"}`
+
+// fault is the kind of failure injected into a request.
+type fault int
+
+const (
+	faultNone fault = iota
+	faultDrop
+	faultMalformed
+	faultServerError
+)
+
+// Stats counts the requests seen by an Injector and the faults it injected.
+type Stats struct {
+	Requests     int
+	Dropped      int
+	Malformed    int
+	ServerErrors int
+	// Latency is the total latency added to the requests.
+	Latency time.Duration
+}
+
+// Injector decides which requests fail and how. An Injector is safe for
+// concurrent use, and bursts of server errors span all the requests going
+// through it.
+type Injector struct {
+	cfg *config
+
+	mu        sync.Mutex
+	rng       *rand.Rand
+	burstLeft int
+	stats     Stats
+}
+
+// NewInjector creates an Injector. Without options it injects no faults.
+//
+// Inputs:
+//   - opts: A variadic list of Option functions, such as WithLatency or
+//     WithDropRate.
+//
+// Returns:
+//
+//	The *Injector, or an error if an option is invalid.
+func NewInjector(opts ...Option) (*Injector, error) {
+	cfg, err := newConfig(opts)
+	if err != nil {
+		return nil, err
+	}
+	seed := cfg.seed
+	if !cfg.seedSet {
+		seed = rand.Uint64()
+	}
+	return &Injector{cfg: cfg, rng: rand.New(rand.NewPCG(seed, seed))}, nil
+}
+
+// Stats returns the counts of the requests and faults so far.
+func (in *Injector) Stats() Stats {
+	in.mu.Lock()
+	defer in.mu.Unlock()
+	return in.stats
+}
+
+// next draws the latency and the fault of a new request.
+func (in *Injector) next() (time.Duration, fault) {
+	in.mu.Lock()
+	defer in.mu.Unlock()
+
+	delay := in.cfg.minLatency
+	if spread := in.cfg.maxLatency - in.cfg.minLatency; spread > 0 {
+		delay += time.Duration(in.rng.Int64N(int64(spread) + 1))
+	}
+	in.stats.Requests++
+	in.stats.Latency += delay
+
+	f := faultNone
+	switch {
+	case in.burstLeft > 0:
+		in.burstLeft--
+		f = faultServerError
+	case in.rng.Float64() < in.cfg.dropRate:
+		f = faultDrop
+	case in.rng.Float64() < in.cfg.malformedRate:
+		f = faultMalformed
+	case in.rng.Float64() < in.cfg.burstRate:
+		in.burstLeft = in.cfg.burstLength - 1
+		f = faultServerError
+	}
+	switch f {
+	case faultDrop:
+		in.stats.Dropped++
+	case faultMalformed:
+		in.stats.Malformed++
+	case faultServerError:
+		in.stats.ServerErrors++
+	}
+	return delay, f
+}
+
+// wait sleeps for delay, or until ctx is done.
+func wait(ctx context.Context, delay time.Duration) error {
+	if delay <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// RoundTripper returns an http.RoundTripper injecting faults into the
+// requests it sends with base, or http.DefaultTransport if base is nil.
+//
+// Dropped connections fail with an error wrapping ErrConnectionDropped,
+// before the request is sent. Server errors are answered without sending the
+// request, with the status set by WithServerErrorBursts. Malformed responses
+// are the responses of base, truncated to half their length.
+func (in *Injector) RoundTripper(base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &roundTripper{injector: in, base: base}
+}
+
+type roundTripper struct {
+	injector *Injector
+	base     http.RoundTripper
+}
+
+func (rt *roundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	delay, f := rt.injector.next()
+	if err := wait(req.Context(), delay); err != nil {
+		closeBody(req)
+		return nil, err
+	}
+
+	switch f {
+	case faultDrop:
+		closeBody(req)
+		return nil, fmt.Errorf("%s %s: %w", req.Method, req.URL.Redacted(), ErrConnectionDropped)
+	case faultServerError:
+		closeBody(req)
+		status := rt.injector.cfg.burstStatus
+		return &http.Response{
+			Status:        strconv.Itoa(status) + " " + http.StatusText(status),
+			StatusCode:    status,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        http.Header{"Content-Type": {"application/json"}},
+			Body:          io.NopCloser(bytes.NewReader([]byte(serverErrorBody))),
+			ContentLength: int64(len(serverErrorBody)),
+			Request:       req,
+		}, nil
+	}
+
+	resp, err := rt.base.RoundTrip(req)
+	if err != nil || f != faultMalformed {
+		return resp, err
+	}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	body = truncate(body)
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	resp.ContentLength = int64(len(body))
+	resp.Header = resp.Header.Clone()
+	resp.Header.Del("Content-Length")
+	return resp, nil
+}
+
+// closeBody closes the body of a request that is not sent, as required of
+// RoundTrippers.
+func closeBody(req *http.Request) {
+	if req.Body != nil {
+		req.Body.Close()
+	}
+}
+
+// truncate cuts body in half, which makes any JSON document invalid.
+func truncate(body []byte) []byte {
+	return body[:len(body)/2]
+}
+
+// Wrap returns a transport injecting faults into the GetTool, ListTools and
+// InvokeTool calls it forwards to t. The returned transport only implements
+// transport.Transport, so it does not support the client options that need
+// optional interfaces, such as core.WithTransportRetry; use RoundTripper to
+// test those.
+//
+// Dropped connections fail with an error wrapping ErrConnectionDropped and
+// server errors with a *transport.ToolInvocationError, both without calling
+// t. Malformed responses are the calls to t whose result fails to decode.
+func (in *Injector) Wrap(t transport.Transport) transport.Transport {
+	return &faultyTransport{injector: in, Transport: t}
+}
+
+type faultyTransport struct {
+	injector *Injector
+	transport.Transport
+}
+
+// inject applies the latency and fault of a new call, and returns the error
+// that replaces the call, if any.
+func (ft *faultyTransport) inject(ctx context.Context) (fault, error) {
+	delay, f := ft.injector.next()
+	if err := wait(ctx, delay); err != nil {
+		return f, err
+	}
+	switch f {
+	case faultDrop:
+		return f, ErrConnectionDropped
+	case faultServerError:
+		return f, transport.NewHTTPError(ft.injector.cfg.burstStatus, []byte(serverErrorBody))
+	}
+	return f, nil
+}
+
+// malformed returns the error of decoding a truncated result.
+func malformed(result any) error {
+	data, err := json.Marshal(result)
+	if err != nil {
+		return err
+	}
+	var v any
+	err = json.Unmarshal(truncate(data), &v)
+	return fmt.Errorf("failed to decode the response: %w", err)
+}
+
+func (ft *faultyTransport) GetTool(ctx context.Context, toolName string, headers map[string]string) (*transport.ManifestSchema, error) {
+	f, err := ft.inject(ctx)
+	if err != nil {
+		return nil, err
+	}
+	manifest, err := ft.Transport.GetTool(ctx, toolName, headers)
+	if err == nil && f == faultMalformed {
+		return nil, malformed(manifest)
+	}
+	return manifest, err
+}
+
+func (ft *faultyTransport) ListTools(ctx context.Context, toolsetName string, headers map[string]string) (*transport.ManifestSchema, error) {
+	f, err := ft.inject(ctx)
+	if err != nil {
+		return nil, err
+	}
+	manifest, err := ft.Transport.ListTools(ctx, toolsetName, headers)
+	if err == nil && f == faultMalformed {
+		return nil, malformed(manifest)
+	}
+	return manifest, err
+}
+
+func (ft *faultyTransport) InvokeTool(ctx context.Context, toolName string, payload map[string]any, headers map[string]string) (any, error) {
+	f, err := ft.inject(ctx)
+	if err != nil {
+		return nil, err
+	}
+	result, err := ft.Transport.InvokeTool(ctx, toolName, payload, headers)
+	if err == nil && f == faultMalformed {
+		return nil, malformed(result)
+	}
+	return result, err
+}
@@ -0,0 +1,301 @@
+//go:build unit
+
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package faults
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/googleapis/mcp-toolbox-sdk-go/core"
+	"github.com/googleapis/mcp-toolbox-sdk-go/core/transport"
+)
+
+// newMockServer returns an MCP server with a single "echo" tool, and the
+// number of requests it received.
+func newMockServer(t *testing.T) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+	var hits atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		var req struct {
+			Method string `json:"method"`
+			ID     any    `json:"id"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "bad request", http.StatusBadRequest)
+			return
+		}
+
+		var result any
+		switch req.Method {
+		case "initialize":
+			result = map[string]any{
+				"protocolVersion": "2025-06-18",
+				"capabilities":    map[string]any{"tools": map[string]any{}},
+				"serverInfo":      map[string]any{"name": "mock-server", "version": "1.0.0"},
+			}
+		case "tools/list":
+			result = map[string]any{"tools": []any{map[string]any{
+				"name":        "echo",
+				"description": "Echo",
+				"inputSchema": map[string]any{"type": "object", "properties": map[string]any{}},
+			}}}
+		default:
+			w.WriteHeader(http.StatusAccepted)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{"jsonrpc": "2.0", "id": req.ID, "result": result})
+	}))
+	t.Cleanup(server.Close)
+	return server, &hits
+}
+
+func newInjector(t *testing.T, opts ...Option) *Injector {
+	t.Helper()
+	in, err := NewInjector(append([]Option{WithSeed(1)}, opts...)...)
+	if err != nil {
+		t.Fatalf("NewInjector() error = %v", err)
+	}
+	return in
+}
+
+func TestNewInjector(t *testing.T) {
+	errCases := []struct {
+		name    string
+		opts    []Option
+		wantErr string
+	}{
+		{"nil option", []Option{nil}, "received a nil Option"},
+		{"negative latency", []Option{WithLatency(-time.Second, time.Second)}, "invalid latency range"},
+		{"inverted latency", []Option{WithLatency(time.Second, time.Millisecond)}, "invalid latency range"},
+		{"drop rate", []Option{WithDropRate(1.5)}, "WithDropRate: rate must be between 0 and 1"},
+		{"malformed rate", []Option{WithMalformedRate(-0.1)}, "WithMalformedRate: rate must be between 0 and 1"},
+		{"burst rate", []Option{WithServerErrorBursts(2, 1, 503)}, "WithServerErrorBursts: rate must be between 0 and 1"},
+		{"burst length", []Option{WithServerErrorBursts(0.1, 0, 503)}, "length must be positive"},
+		{"burst status", []Option{WithServerErrorBursts(0.1, 1, 404)}, "must be a 5xx status"},
+	}
+	for _, tc := range errCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := NewInjector(tc.opts...); err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Errorf("NewInjector() error = %v, want it to contain %q", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestRoundTripper(t *testing.T) {
+	server, hits := newMockServer(t)
+	post := func(t *testing.T, in *Injector) (*http.Response, error) {
+		t.Helper()
+		client := &http.Client{Transport: in.RoundTripper(nil)}
+		return client.Post(server.URL, "application/json", strings.NewReader(`{"jsonrpc":"2.0","id":1,"method":"tools/list"}`))
+	}
+
+	t.Run("no faults", func(t *testing.T) {
+		in := newInjector(t)
+		resp, err := post(t, in)
+		if err != nil {
+			t.Fatalf("Post() error = %v", err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			t.Errorf("StatusCode = %d, want 200", resp.StatusCode)
+		}
+		if got := in.Stats(); got != (Stats{Requests: 1}) {
+			t.Errorf("Stats() = %+v", got)
+		}
+	})
+
+	t.Run("dropped connection", func(t *testing.T) {
+		before := hits.Load()
+		in := newInjector(t, WithDropRate(1))
+		if _, err := post(t, in); !errors.Is(err, ErrConnectionDropped) {
+			t.Errorf("Post() error = %v, want ErrConnectionDropped", err)
+		}
+		if hits.Load() != before {
+			t.Errorf("dropped request reached the server")
+		}
+		if got := in.Stats().Dropped; got != 1 {
+			t.Errorf("Stats().Dropped = %d, want 1", got)
+		}
+	})
+
+	t.Run("malformed response", func(t *testing.T) {
+		resp, err := post(t, newInjector(t, WithMalformedRate(1)))
+		if err != nil {
+			t.Fatalf("Post() error = %v", err)
+		}
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			t.Fatalf("ReadAll() error = %v", err)
+		}
+		if len(body) == 0 || json.Valid(body) {
+			t.Errorf("body = %q, want truncated JSON", body)
+		}
+	})
+
+	t.Run("server error burst", func(t *testing.T) {
+		before := hits.Load()
+		in := newInjector(t, WithServerErrorBursts(1, 3, http.StatusBadGateway))
+		for i := 0; i < 3; i++ {
+			resp, err := post(t, in)
+			if err != nil {
+				t.Fatalf("Post() error = %v", err)
+			}
+			resp.Body.Close()
+			if resp.StatusCode != http.StatusBadGateway {
+				t.Errorf("StatusCode = %d, want 502", resp.StatusCode)
+			}
+		}
+		if hits.Load() != before {
+			t.Errorf("failed requests reached the server")
+		}
+		if got := in.Stats().ServerErrors; got != 3 {
+			t.Errorf("Stats().ServerErrors = %d, want 3", got)
+		}
+	})
+
+	t.Run("latency", func(t *testing.T) {
+		in := newInjector(t, WithLatency(20*time.Millisecond, 30*time.Millisecond))
+		start := time.Now()
+		resp, err := post(t, in)
+		if err != nil {
+			t.Fatalf("Post() error = %v", err)
+		}
+		resp.Body.Close()
+		if elapsed := time.Since(start); elapsed < 20*time.Millisecond {
+			t.Errorf("request took %v, want at least 20ms", elapsed)
+		}
+		if got := in.Stats().Latency; got < 20*time.Millisecond || got > 30*time.Millisecond {
+			t.Errorf("Stats().Latency = %v, want between 20ms and 30ms", got)
+		}
+	})
+
+	t.Run("retries absorb bursts", func(t *testing.T) {
+		in := newInjector(t, WithServerErrorBursts(0.3, 2, http.StatusServiceUnavailable))
+		client, err := core.NewToolboxClient(server.URL,
+			core.WithHTTPClient(&http.Client{Transport: in.RoundTripper(nil)}),
+			core.WithTransportRetry(10, time.Millisecond, time.Millisecond))
+		if err != nil {
+			t.Fatalf("NewToolboxClient() error = %v", err)
+		}
+		for i := 0; i < 10; i++ {
+			if _, err := client.LoadToolset("", context.Background()); err != nil {
+				t.Fatalf("LoadToolset() error = %v", err)
+			}
+		}
+		if in.Stats().ServerErrors == 0 {
+			t.Errorf("no server errors were injected")
+		}
+	})
+}
+
+// fakeTransport serves a single "echo" tool.
+type fakeTransport struct {
+	calls int
+}
+
+func (f *fakeTransport) BaseURL() string { return "http://fake" }
+
+func (f *fakeTransport) GetTool(ctx context.Context, name string, headers map[string]string) (*transport.ManifestSchema, error) {
+	return f.ListTools(ctx, "", headers)
+}
+
+func (f *fakeTransport) ListTools(context.Context, string, map[string]string) (*transport.ManifestSchema, error) {
+	f.calls++
+	return &transport.ManifestSchema{Tools: map[string]transport.ToolSchema{"echo": {Description: "Echo"}}}, nil
+}
+
+func (f *fakeTransport) InvokeTool(context.Context, string, map[string]any, map[string]string) (any, error) {
+	f.calls++
+	return "ok", nil
+}
+
+func (f *fakeTransport) Close(context.Context) error { return nil }
+
+func TestWrap(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("no faults", func(t *testing.T) {
+		base := &fakeTransport{}
+		tr := newInjector(t).Wrap(base)
+		if tr.BaseURL() != "http://fake" {
+			t.Errorf("BaseURL() = %q", tr.BaseURL())
+		}
+		if got, err := tr.InvokeTool(ctx, "echo", nil, nil); err != nil || got != "ok" {
+			t.Errorf("InvokeTool() = %v, %v", got, err)
+		}
+		if _, err := tr.GetTool(ctx, "echo", nil); err != nil {
+			t.Errorf("GetTool() error = %v", err)
+		}
+		if base.calls != 2 {
+			t.Errorf("base transport was called %d times, want 2", base.calls)
+		}
+	})
+
+	t.Run("dropped connection", func(t *testing.T) {
+		base := &fakeTransport{}
+		tr := newInjector(t, WithDropRate(1)).Wrap(base)
+		if _, err := tr.ListTools(ctx, "", nil); !errors.Is(err, ErrConnectionDropped) {
+			t.Errorf("ListTools() error = %v, want ErrConnectionDropped", err)
+		}
+		if base.calls != 0 {
+			t.Errorf("base transport was called %d times, want 0", base.calls)
+		}
+	})
+
+	t.Run("server error", func(t *testing.T) {
+		tr := newInjector(t, WithServerErrorBursts(1, 1, http.StatusServiceUnavailable)).Wrap(&fakeTransport{})
+		_, err := tr.InvokeTool(ctx, "echo", nil, nil)
+		var invocationErr *transport.ToolInvocationError
+		if !errors.As(err, &invocationErr) || invocationErr.StatusCode != http.StatusServiceUnavailable || !invocationErr.Retryable {
+			t.Errorf("InvokeTool() error = %v, want a retryable 503 error", err)
+		}
+	})
+
+	t.Run("malformed response", func(t *testing.T) {
+		tr := newInjector(t, WithMalformedRate(1)).Wrap(&fakeTransport{})
+		_, err := tr.ListTools(ctx, "", nil)
+		var syntaxErr *json.SyntaxError
+		if !errors.As(err, &syntaxErr) {
+			t.Errorf("ListTools() error = %v, want a JSON syntax error", err)
+		}
+	})
+
+	t.Run("canceled during latency", func(t *testing.T) {
+		base := &fakeTransport{}
+		tr := newInjector(t, WithLatency(time.Hour, time.Hour)).Wrap(base)
+		ctx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+		defer cancel()
+		if _, err := tr.InvokeTool(ctx, "echo", nil, nil); !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("InvokeTool() error = %v, want context.DeadlineExceeded", err)
+		}
+		if base.calls != 0 {
+			t.Errorf("base transport was called %d times, want 0", base.calls)
+		}
+	})
+}
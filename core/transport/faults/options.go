@@ -0,0 +1,122 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package faults
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Option configures an Injector.
+type Option func(*config) error
+
+// config holds the settings of an Injector.
+type config struct {
+	minLatency    time.Duration
+	maxLatency    time.Duration
+	dropRate      float64
+	malformedRate float64
+	burstRate     float64
+	burstLength   int
+	burstStatus   int
+	seed          uint64
+	seedSet       bool
+}
+
+// newConfig applies opts to the settings of an Injector.
+func newConfig(opts []Option) (*config, error) {
+	cfg := &config{}
+	for _, opt := range opts {
+		if opt == nil {
+			return nil, fmt.Errorf("NewInjector: received a nil Option")
+		}
+		if err := opt(cfg); err != nil {
+			return nil, err
+		}
+	}
+	return cfg, nil
+}
+
+// checkRate validates the probability given to the option named caller.
+func checkRate(caller string, rate float64) error {
+	if rate < 0 || rate > 1 {
+		return fmt.Errorf("%s: rate must be between 0 and 1, got %v", caller, rate)
+	}
+	return nil
+}
+
+// WithLatency delays every request by a random duration between minLatency
+// and maxLatency.
+func WithLatency(minLatency, maxLatency time.Duration) Option {
+	return func(c *config) error {
+		if minLatency < 0 || maxLatency < minLatency {
+			return fmt.Errorf("WithLatency: invalid latency range [%v, %v]", minLatency, maxLatency)
+		}
+		c.minLatency, c.maxLatency = minLatency, maxLatency
+		return nil
+	}
+}
+
+// WithDropRate drops the connection of the given fraction of requests.
+func WithDropRate(rate float64) Option {
+	return func(c *config) error {
+		if err := checkRate("WithDropRate", rate); err != nil {
+			return err
+		}
+		c.dropRate = rate
+		return nil
+	}
+}
+
+// WithMalformedRate corrupts the JSON response of the given fraction of
+// requests.
+func WithMalformedRate(rate float64) Option {
+	return func(c *config) error {
+		if err := checkRate("WithMalformedRate", rate); err != nil {
+			return err
+		}
+		c.malformedRate = rate
+		return nil
+	}
+}
+
+// WithServerErrorBursts makes the given fraction of requests start a burst of
+// length consecutive responses with an HTTP status, which must be a 5xx
+// status. Use http.StatusServiceUnavailable to test retries, which the MCP
+// transports only attempt for that status and http.StatusTooManyRequests.
+func WithServerErrorBursts(rate float64, length int, status int) Option {
+	return func(c *config) error {
+		if err := checkRate("WithServerErrorBursts", rate); err != nil {
+			return err
+		}
+		if length < 1 {
+			return fmt.Errorf("WithServerErrorBursts: length must be positive, got %d", length)
+		}
+		if status < http.StatusInternalServerError || status > 599 {
+			return fmt.Errorf("WithServerErrorBursts: status must be a 5xx status, got %d", status)
+		}
+		c.burstRate, c.burstLength, c.burstStatus = rate, length, status
+		return nil
+	}
+}
+
+// WithSeed makes the injected faults and latencies reproducible.
+func WithSeed(seed uint64) Option {
+	return func(c *config) error {
+		c.seed, c.seedSet = seed, true
+		return nil
+	}
+}
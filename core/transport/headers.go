@@ -0,0 +1,118 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transport
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+type responseHeadersKey struct{}
+
+// ResponseHeaders collects the headers of the HTTP responses received for
+// the requests made with a context, such as to monitor quotas. Only the
+// headers of the most recent response are kept. The zero value is ready to
+// use, and a ResponseHeaders is safe for concurrent use.
+type ResponseHeaders struct {
+	mu     sync.Mutex
+	header http.Header
+}
+
+// WithResponseHeaders returns a copy of ctx that records the headers of the
+// HTTP responses to the requests made with it in headers. Transports that do
+// not use HTTP, such as stdio, record nothing.
+func WithResponseHeaders(ctx context.Context, headers *ResponseHeaders) context.Context {
+	return context.WithValue(ctx, responseHeadersKey{}, headers)
+}
+
+// RecordResponseHeaders records header in the ResponseHeaders carried by
+// ctx, if any.
+func RecordResponseHeaders(ctx context.Context, header http.Header) {
+	headers, _ := ctx.Value(responseHeadersKey{}).(*ResponseHeaders)
+	if headers == nil {
+		return
+	}
+	headers.mu.Lock()
+	defer headers.mu.Unlock()
+	headers.header = header.Clone()
+}
+
+// Header returns a copy of the recorded headers, or nil if no response was
+// received.
+func (h *ResponseHeaders) Header() http.Header {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.header.Clone()
+}
+
+// Get returns the first value of the recorded header with the given name, or
+// an empty string.
+func (h *ResponseHeaders) Get(name string) string {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.header.Get(name)
+}
+
+// RequestID returns the request ID assigned by the server, from the
+// X-Request-Id header, or an empty string.
+func (h *ResponseHeaders) RequestID() string {
+	return h.Get("X-Request-Id")
+}
+
+// RateLimitRemaining returns the number of requests left in the current rate
+// limit window, from the RateLimit-Remaining or X-RateLimit-Remaining
+// header. It reports false if neither header holds a number.
+func (h *ResponseHeaders) RateLimitRemaining() (int64, bool) {
+	for _, name := range []string{"RateLimit-Remaining", "X-RateLimit-Remaining"} {
+		value := strings.TrimSpace(h.Get(name))
+		if value == "" {
+			continue
+		}
+		if remaining, err := strconv.ParseInt(value, 10, 64); err == nil {
+			return remaining, true
+		}
+	}
+	return 0, false
+}
+
+// Deprecated reports whether the response carries a Deprecation header, by
+// which servers announce that the requested resource is, or will be,
+// deprecated.
+func (h *ResponseHeaders) Deprecated() bool {
+	value := strings.TrimSpace(h.Get("Deprecation"))
+	return value != "" && value != "false"
+}
+
+// Sunset returns when the requested resource is to be removed, from the
+// Sunset header. It reports false if the header does not hold an HTTP date.
+func (h *ResponseHeaders) Sunset() (time.Time, bool) {
+	sunset, err := http.ParseTime(h.Get("Sunset"))
+	if err != nil {
+		return time.Time{}, false
+	}
+	return sunset, true
+}
+
+// ServerTiming returns the Server-Timing header, joining multiple values
+// with commas, or an empty string.
+func (h *ResponseHeaders) ServerTiming() string {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return strings.Join(h.header.Values("Server-Timing"), ", ")
+}
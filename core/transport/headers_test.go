@@ -0,0 +1,120 @@
+//go:build unit
+
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transport
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestResponseHeaders(t *testing.T) {
+	t.Run("Records the headers of the most recent response", func(t *testing.T) {
+		var headers ResponseHeaders
+		ctx := WithResponseHeaders(context.Background(), &headers)
+		RecordResponseHeaders(ctx, http.Header{"X-Request-Id": {"req-1"}})
+		RecordResponseHeaders(ctx, http.Header{"X-Request-Id": {"req-2"}})
+
+		if got := headers.RequestID(); got != "req-2" {
+			t.Errorf("Expected request ID 'req-2', got %q", got)
+		}
+	})
+
+	t.Run("Copies the recorded headers", func(t *testing.T) {
+		var headers ResponseHeaders
+		header := http.Header{"X-Request-Id": {"req-1"}}
+		RecordResponseHeaders(WithResponseHeaders(context.Background(), &headers), header)
+		header.Set("X-Request-Id", "changed")
+		headers.Header().Set("X-Request-Id", "changed")
+
+		if got := headers.RequestID(); got != "req-1" {
+			t.Errorf("Expected request ID 'req-1', got %q", got)
+		}
+	})
+
+	t.Run("Ignores contexts without a collector", func(t *testing.T) {
+		RecordResponseHeaders(context.Background(), http.Header{"X-Request-Id": {"req-1"}})
+	})
+
+	t.Run("Starts empty", func(t *testing.T) {
+		var headers ResponseHeaders
+		if headers.Header() != nil || headers.RequestID() != "" || headers.ServerTiming() != "" {
+			t.Errorf("Expected no headers, got %v", headers.Header())
+		}
+		if _, ok := headers.RateLimitRemaining(); ok {
+			t.Error("Expected no remaining rate limit")
+		}
+	})
+
+	t.Run("Parses the remaining rate limit", func(t *testing.T) {
+		tests := []struct {
+			header    http.Header
+			remaining int64
+			ok        bool
+		}{
+			{http.Header{"Ratelimit-Remaining": {"42"}}, 42, true},
+			{http.Header{"X-Ratelimit-Remaining": {" 7 "}}, 7, true},
+			{http.Header{"Ratelimit-Remaining": {"many"}, "X-Ratelimit-Remaining": {"3"}}, 3, true},
+			{http.Header{"X-Ratelimit-Remaining": {"many"}}, 0, false},
+		}
+		for _, tt := range tests {
+			var headers ResponseHeaders
+			RecordResponseHeaders(WithResponseHeaders(context.Background(), &headers), tt.header)
+			remaining, ok := headers.RateLimitRemaining()
+			if remaining != tt.remaining || ok != tt.ok {
+				t.Errorf("For %v, expected (%d, %t), got (%d, %t)", tt.header, tt.remaining, tt.ok, remaining, ok)
+			}
+		}
+	})
+
+	t.Run("Joins server timing values", func(t *testing.T) {
+		var headers ResponseHeaders
+		RecordResponseHeaders(WithResponseHeaders(context.Background(), &headers),
+			http.Header{"Server-Timing": {"db;dur=53", "app;dur=47.2"}})
+
+		if got := headers.ServerTiming(); got != "db;dur=53, app;dur=47.2" {
+			t.Errorf("Unexpected server timing %q", got)
+		}
+	})
+
+	t.Run("Parses deprecation headers", func(t *testing.T) {
+		var headers ResponseHeaders
+		RecordResponseHeaders(WithResponseHeaders(context.Background(), &headers),
+			http.Header{"Deprecation": {"@1688169599"}, "Sunset": {"Wed, 31 Dec 2025 23:59:59 GMT"}})
+
+		if !headers.Deprecated() {
+			t.Error("Expected the response to be deprecated")
+		}
+		sunset, ok := headers.Sunset()
+		if want := time.Date(2025, 12, 31, 23, 59, 59, 0, time.UTC); !ok || !sunset.Equal(want) {
+			t.Errorf("Expected sunset %v, got %v, %t", want, sunset, ok)
+		}
+	})
+
+	t.Run("Ignores missing deprecation headers", func(t *testing.T) {
+		var headers ResponseHeaders
+		RecordResponseHeaders(WithResponseHeaders(context.Background(), &headers), http.Header{"Deprecation": {"false"}})
+
+		if headers.Deprecated() {
+			t.Error("Expected the response not to be deprecated")
+		}
+		if _, ok := headers.Sunset(); ok {
+			t.Error("Expected no sunset")
+		}
+	})
+}
@@ -16,6 +16,7 @@ package transport
 
 import (
 	"context"
+	"time"
 )
 
 type Transport interface {
@@ -29,4 +30,87 @@ type Transport interface {
 
 	// InvokeTool executes a tool.
 	InvokeTool(ctx context.Context, toolName string, payload map[string]any, headers map[string]string) (any, error)
+
+	// Close releases the resources held by the transport, such as sessions,
+	// open connections or server processes.
+	Close(ctx context.Context) error
+}
+
+// NotificationSource is implemented by transports that can deliver the
+// notifications a server sends outside of any request, such as
+// notifications/tools/list_changed.
+type NotificationSource interface {
+	// SetNotificationHandler registers the handler for server notifications,
+	// replacing any previous one.
+	SetNotificationHandler(handler NotificationHandler)
+}
+
+// Pinger is implemented by transports that keep a connection to the server
+// open and can ping the server to detect when it is lost.
+type Pinger interface {
+	// SetKeepAlive makes the transport ping the server every interval, and
+	// reconnect after maxFailures consecutive pings fail. It must be called
+	// before the first request.
+	SetKeepAlive(interval time.Duration, maxFailures int)
+}
+
+// PromptSource is implemented by transports that can fetch the prompt
+// templates offered by the server.
+type PromptSource interface {
+	// ListPrompts fetches all prompt templates.
+	ListPrompts(ctx context.Context, headers map[string]string) ([]Prompt, error)
+
+	// GetPrompt renders a prompt template with the given arguments.
+	GetPrompt(ctx context.Context, name string, args map[string]string, headers map[string]string) (*PromptResult, error)
+}
+
+// ResourceSource is implemented by transports that can fetch the resources
+// offered by the server.
+type ResourceSource interface {
+	// ListResources fetches all resources.
+	ListResources(ctx context.Context, headers map[string]string) ([]ResourceDescriptor, error)
+
+	// ListResourceTemplates fetches all resource templates.
+	ListResourceTemplates(ctx context.Context, headers map[string]string) ([]ResourceTemplate, error)
+
+	// ReadResource fetches the contents of a resource.
+	ReadResource(ctx context.Context, uri string, headers map[string]string) ([]ResourceContents, error)
+
+	// SubscribeResource asks the server to send notifications/resources/updated
+	// when the resource changes.
+	SubscribeResource(ctx context.Context, uri string, headers map[string]string) error
+
+	// UnsubscribeResource cancels a subscription made with SubscribeResource.
+	UnsubscribeResource(ctx context.Context, uri string, headers map[string]string) error
+}
+
+// RequestReceiver is implemented by transports that can answer the requests
+// a server sends to the client. Handlers and capabilities must be set before
+// the first request, since capabilities are declared in the handshake.
+type RequestReceiver interface {
+	// SetRequestHandler registers the handler for server requests with the
+	// given method, replacing any previous one.
+	SetRequestHandler(method string, handler ServerRequestHandler)
+
+	// SetClientCapability declares a client capability, such as "sampling",
+	// in the handshake.
+	SetClientCapability(name string, value map[string]any)
+}
+
+// ServerInfo is what the server declared about itself in the handshake.
+type ServerInfo struct {
+	// Capabilities are the server's capabilities, such as "tools" and
+	// "prompts", keyed by name.
+	Capabilities map[string]any
+	// Instructions describe how to use the server, for example as a hint to
+	// include in a system prompt. Empty if the server provides none.
+	Instructions string
+}
+
+// ServerInfoSource is implemented by transports that expose what the server
+// declared in the handshake.
+type ServerInfoSource interface {
+	// ServerInfo performs the handshake if needed and returns what the server
+	// declared in it.
+	ServerInfo(ctx context.Context, headers map[string]string) (*ServerInfo, error)
 }
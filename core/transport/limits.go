@@ -0,0 +1,86 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transport
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+)
+
+type maxResponseBytesKey struct{}
+
+// ResponseTooLargeError is returned when a server response exceeds the
+// configured maximum size.
+type ResponseTooLargeError struct {
+	Limit int64
+}
+
+func (e *ResponseTooLargeError) Error() string {
+	return fmt.Sprintf("response body exceeds the maximum allowed size of %d bytes", e.Limit)
+}
+
+// WithMaxResponseBytes returns a copy of ctx that limits the size of response
+// bodies read by transports for requests made with it.
+func WithMaxResponseBytes(ctx context.Context, limit int64) context.Context {
+	return context.WithValue(ctx, maxResponseBytesKey{}, limit)
+}
+
+// MaxResponseBytes returns the response size limit carried by ctx, or 0 if
+// responses are unbounded.
+func MaxResponseBytes(ctx context.Context) int64 {
+	limit, _ := ctx.Value(maxResponseBytesKey{}).(int64)
+	return limit
+}
+
+// ReadBody reads r to completion, honoring the response size limit carried by
+// ctx. It returns a *ResponseTooLargeError if the limit is exceeded.
+func ReadBody(ctx context.Context, r io.Reader) ([]byte, error) {
+	limit := MaxResponseBytes(ctx)
+	if limit <= 0 {
+		return io.ReadAll(r)
+	}
+
+	// Read one byte past the limit to detect oversized bodies.
+	body, err := io.ReadAll(io.LimitReader(r, limit+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(body)) > limit {
+		return nil, &ResponseTooLargeError{Limit: limit}
+	}
+	return body, nil
+}
+
+// ReadBodyInto is like ReadBody, but appends the body to buf, which lets
+// callers reuse buffers across responses.
+func ReadBodyInto(ctx context.Context, buf *bytes.Buffer, r io.Reader) error {
+	limit := MaxResponseBytes(ctx)
+	if limit <= 0 {
+		_, err := buf.ReadFrom(r)
+		return err
+	}
+
+	// Read one byte past the limit to detect oversized bodies.
+	start := buf.Len()
+	if _, err := buf.ReadFrom(io.LimitReader(r, limit+1)); err != nil {
+		return err
+	}
+	if int64(buf.Len()-start) > limit {
+		return &ResponseTooLargeError{Limit: limit}
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+//go:build unit
+
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transport
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestReadBody(t *testing.T) {
+	t.Run("Reads unbounded body without a limit", func(t *testing.T) {
+		body, err := ReadBody(context.Background(), strings.NewReader("hello world"))
+		if err != nil {
+			t.Fatalf("ReadBody failed unexpectedly: %v", err)
+		}
+		if string(body) != "hello world" {
+			t.Errorf("Expected 'hello world', got %q", string(body))
+		}
+	})
+
+	t.Run("Reads body exactly at the limit", func(t *testing.T) {
+		ctx := WithMaxResponseBytes(context.Background(), 5)
+		body, err := ReadBody(ctx, strings.NewReader("hello"))
+		if err != nil {
+			t.Fatalf("ReadBody failed unexpectedly: %v", err)
+		}
+		if string(body) != "hello" {
+			t.Errorf("Expected 'hello', got %q", string(body))
+		}
+	})
+
+	t.Run("Returns a typed error when the limit is exceeded", func(t *testing.T) {
+		ctx := WithMaxResponseBytes(context.Background(), 5)
+		_, err := ReadBody(ctx, strings.NewReader("hello world"))
+
+		var tooLarge *ResponseTooLargeError
+		if !errors.As(err, &tooLarge) {
+			t.Fatalf("Expected a *ResponseTooLargeError, got %v", err)
+		}
+		if tooLarge.Limit != 5 {
+			t.Errorf("Expected limit 5, got %d", tooLarge.Limit)
+		}
+	})
+}
+
+func TestMaxResponseBytes(t *testing.T) {
+	if got := MaxResponseBytes(context.Background()); got != 0 {
+		t.Errorf("Expected no limit on a bare context, got %d", got)
+	}
+	ctx := WithMaxResponseBytes(context.Background(), 1024)
+	if got := MaxResponseBytes(ctx); got != 1024 {
+		t.Errorf("Expected limit 1024, got %d", got)
+	}
+}
+
+func TestReadBodyInto(t *testing.T) {
+	t.Run("Appends the body to the buffer", func(t *testing.T) {
+		buf := bytes.NewBufferString("prefix:")
+		if err := ReadBodyInto(context.Background(), buf, strings.NewReader("hello world")); err != nil {
+			t.Fatalf("ReadBodyInto failed unexpectedly: %v", err)
+		}
+		if buf.String() != "prefix:hello world" {
+			t.Errorf("Expected 'prefix:hello world', got %q", buf.String())
+		}
+	})
+
+	t.Run("Applies the limit to the body only", func(t *testing.T) {
+		ctx := WithMaxResponseBytes(context.Background(), 5)
+		buf := bytes.NewBufferString("prefix:")
+		if err := ReadBodyInto(ctx, buf, strings.NewReader("hello")); err != nil {
+			t.Fatalf("ReadBodyInto failed unexpectedly: %v", err)
+		}
+
+		var tooLarge *ResponseTooLargeError
+		if err := ReadBodyInto(ctx, new(bytes.Buffer), strings.NewReader("hello world")); !errors.As(err, &tooLarge) {
+			t.Fatalf("Expected a *ResponseTooLargeError, got %v", err)
+		}
+	})
+}
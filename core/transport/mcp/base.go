@@ -18,31 +18,58 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"maps"
+	"math"
 	"net/http"
 	"net/url"
 	"strings"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/googleapis/mcp-toolbox-sdk-go/core/transport"
 )
 
 // ToolContent represents a single item in the tool result content list.
-type ToolContent struct {
-	Type string `json:"type"`
-	Text string `json:"text"`
-}
+type ToolContent = transport.ContentBlock
 
 // BaseMcpTransport holds the common state and logic for MCP HTTP transports.
 type BaseMcpTransport struct {
 	baseURL       string
 	HTTPClient    *http.Client
 	ServerVersion string
-	initOnce      sync.Once
-	initErr       error
+	clientTitle   string
+
+	// initMu guards init, which is replaced to allow a new handshake.
+	initMu sync.Mutex
+	init   *initState
+
+	notificationHandler atomic.Pointer[transport.NotificationHandler]
+	serverInfo          atomic.Pointer[transport.ServerInfo]
+
+	// serverMu guards requestHandlers and capabilities, which describe how
+	// the client answers requests sent by the server.
+	serverMu        sync.RWMutex
+	requestHandlers map[string]transport.ServerRequestHandler
+	capabilities    map[string]any
+
+	// progressMu guards progressHandlers, the notification handlers of
+	// in-flight requests keyed by progress token.
+	progressMu       sync.Mutex
+	progressHandlers map[string]transport.NotificationHandler
+
+	retryPolicy    transport.RetryPolicy
+	observer       transport.Observer
+	requestTimeout time.Duration
 
 	// HandshakeHook is the abstract method _initialize_session.
 	// The specific version implementation will assign this function.
 	HandshakeHook func(ctx context.Context, headers map[string]string) error
+
+	// RequestHook sends a JSON-RPC request, performing the handshake first if
+	// needed, and decodes the result into dest.
+	// The specific version implementation will assign this function.
+	RequestHook func(ctx context.Context, method string, params any, headers map[string]string, dest any) error
 }
 
 // BaseURL returns the base URL for the transport.
@@ -82,22 +109,88 @@ func NewBaseTransport(baseURL string, client *http.Client) (*BaseMcpTransport, e
 	}, nil
 }
 
+// initState records the outcome of a single handshake.
+type initState struct {
+	once sync.Once
+	err  error
+}
+
 // EnsureInitialized guarantees the session is ready before making requests.
+// The outcome of the handshake, including a failure, is reused until
+// ResetInitialization is called.
 func (b *BaseMcpTransport) EnsureInitialized(ctx context.Context, headers map[string]string) error {
-	b.initOnce.Do(func() {
+	b.initMu.Lock()
+	if b.init == nil {
+		b.init = &initState{}
+	}
+	state := b.init
+	b.initMu.Unlock()
+
+	state.once.Do(func() {
 		if b.HandshakeHook != nil {
-			b.initErr = b.HandshakeHook(ctx, headers)
+			state.err = b.HandshakeHook(ctx, headers)
 		} else {
-			b.initErr = fmt.Errorf("transport initialization logic (HandshakeHook) not defined")
+			state.err = fmt.Errorf("transport initialization logic (HandshakeHook) not defined")
 		}
 	})
-	return b.initErr
+	return state.err
+}
+
+// Request sends a JSON-RPC request to the server through RequestHook and
+// decodes the result into dest.
+func (b *BaseMcpTransport) Request(ctx context.Context, method string, params any, headers map[string]string, dest any) error {
+	if b.RequestHook == nil {
+		return fmt.Errorf("transport request logic (RequestHook) not defined")
+	}
+	return b.RequestHook(ctx, method, params, headers, dest)
+}
+
+// ResetInitialization discards the outcome of the previous handshake, so the
+// next call to EnsureInitialized performs a new one. Transports call it when
+// the server has invalidated their session.
+func (b *BaseMcpTransport) ResetInitialization() {
+	b.initMu.Lock()
+	b.init = nil
+	b.initMu.Unlock()
+}
+
+// Close does nothing, as HTTP transports hold no resources between requests
+// other than the pooled connections of their HTTP client. Transports that do
+// override it.
+func (b *BaseMcpTransport) Close(ctx context.Context) error {
+	return nil
+}
+
+// SetNotificationHandler registers the handler for notifications sent by the
+// server, in addition to any handler carried by a request's context.
+func (b *BaseMcpTransport) SetNotificationHandler(handler transport.NotificationHandler) {
+	b.notificationHandler.Store(&handler)
+}
+
+// DispatchNotification delivers n to the handler carried by ctx, if any, and
+// to the handler registered on the transport. Transports that receive
+// notifications outside of a request's context pass context.Background(), in
+// which case progress notifications are routed to the request they belong to.
+func (b *BaseMcpTransport) DispatchNotification(ctx context.Context, n transport.Notification) {
+	if !transport.Notify(ctx, n) && n.Method == progressMethod {
+		b.routeProgress(n)
+	}
+	if handler := b.notificationHandler.Load(); handler != nil && *handler != nil {
+		(*handler)(n)
+	}
 }
 
 // ProcessToolResultContent processes the tool result content, handling multiple JSON objects.
-// It filters for text content, attempts to merge valid JSON objects into an array,
+// If the content holds anything other than text, all blocks are returned as
+// they are. Otherwise it attempts to merge valid JSON objects into an array,
 // or falls back to concatenation.
-func (b *BaseMcpTransport) ProcessToolResultContent(content []ToolContent) string {
+func (b *BaseMcpTransport) ProcessToolResultContent(content []ToolContent) any {
+	for _, c := range content {
+		if c.Type != "text" {
+			return content
+		}
+	}
+
 	// Filter content where type is "text"
 	var texts []string
 	for _, c := range content {
@@ -110,8 +203,7 @@ func (b *BaseMcpTransport) ProcessToolResultContent(content []ToolContent) strin
 	if len(texts) > 1 {
 		allValidObjects := true
 		for _, t := range texts {
-			var js map[string]any
-			if err := json.Unmarshal([]byte(t), &js); err != nil {
+			if !isJSONObject(t) {
 				allValidObjects = false
 				break
 			}
@@ -133,10 +225,25 @@ func (b *BaseMcpTransport) ProcessToolResultContent(content []ToolContent) strin
 	return finalStr
 }
 
+// isJSONObject reports whether s holds a JSON object, or null, which can be
+// merged into a JSON array. It only validates s rather than decoding it, as
+// results often hold many objects.
+func isJSONObject(s string) bool {
+	trimmed := strings.TrimLeft(s, " \t\r\n")
+	if !strings.HasPrefix(trimmed, "{") && !strings.HasPrefix(trimmed, "null") {
+		return false
+	}
+	return json.Valid([]byte(s))
+}
+
 // ConvertToolDefinition converts the raw tool dictionary into a transport.ToolSchema.
 func (b *BaseMcpTransport) ConvertToolDefinition(toolData map[string]any) (transport.ToolSchema, error) {
 	var paramAuth map[string]any
 	var invokeAuth []string
+	var tags []string
+	var annotations map[string]any
+	var examples []transport.ToolExample
+	var deprecation *transport.Deprecation
 
 	if meta, ok := toolData["_meta"].(map[string]any); ok {
 		if pa, ok := meta["toolbox/authParam"].(map[string]any); ok {
@@ -150,12 +257,60 @@ func (b *BaseMcpTransport) ConvertToolDefinition(toolData map[string]any) (trans
 				}
 			}
 		}
+		if tg, ok := meta["toolbox/tags"].([]any); ok {
+			tags = make([]string, 0, len(tg))
+			for _, v := range tg {
+				if s, ok := v.(string); ok {
+					tags = append(tags, s)
+				}
+			}
+		}
+		if ex, ok := meta["toolbox/examples"].([]any); ok {
+			examples = parseExamples(ex)
+		}
+		deprecation = parseDeprecation(meta["toolbox/deprecated"])
+		// Surface any custom metadata keys alongside the tool annotations.
+		for k, v := range meta {
+			if k == "toolbox/authParam" || k == "toolbox/authInvoke" || k == "toolbox/tags" || k == "toolbox/examples" || k == "toolbox/deprecated" {
+				continue
+			}
+			if annotations == nil {
+				annotations = make(map[string]any)
+			}
+			annotations[k] = v
+		}
+	}
+
+	// Standard MCP annotations take precedence over custom metadata keys.
+	if ann, ok := toolData["annotations"].(map[string]any); ok {
+		if annotations == nil {
+			annotations = make(map[string]any, len(ann))
+		}
+		maps.Copy(annotations, ann)
 	}
 
 	description, _ := toolData["description"].(string)
+	// Prefer the top-level title introduced in MCP 2025-06-18 over the older
+	// annotation.
+	title, _ := toolData["title"].(string)
+	if title == "" {
+		title, _ = annotations["title"].(string)
+	}
 	inputSchema, _ := toolData["inputSchema"].(map[string]any)
+	outputSchema, _ := toolData["outputSchema"].(map[string]any)
 	properties, _ := inputSchema["properties"].(map[string]any)
 
+	// Fall back to JSON Schema examples, which only carry the arguments.
+	if examples == nil {
+		if ex, ok := inputSchema["examples"].([]any); ok {
+			for _, v := range ex {
+				if input, ok := v.(map[string]any); ok {
+					examples = append(examples, transport.ToolExample{Input: input})
+				}
+			}
+		}
+	}
+
 	// Create lookup set for required fields
 	requiredSet := make(map[string]bool)
 	if reqList, ok := inputSchema["required"].([]any); ok {
@@ -198,30 +353,95 @@ func (b *BaseMcpTransport) ConvertToolDefinition(toolData map[string]any) (trans
 	}
 
 	return transport.ToolSchema{
+		Title:        title,
 		Description:  description,
 		Parameters:   parameters,
 		AuthRequired: invokeAuth,
+		Tags:         tags,
+		Annotations:  annotations,
+		Examples:     examples,
+		OutputSchema: outputSchema,
+		Deprecation:  deprecation,
 	}, nil
 }
 
+// parseDeprecation converts the "toolbox/deprecated" metadata entry, which
+// is either true, a message, or an object with a message and an RFC 3339
+// sunset time, into a Deprecation. It returns nil if the tool is not
+// deprecated.
+func parseDeprecation(raw any) *transport.Deprecation {
+	switch v := raw.(type) {
+	case bool:
+		if v {
+			return &transport.Deprecation{}
+		}
+	case string:
+		return &transport.Deprecation{Message: v}
+	case map[string]any:
+		deprecation := &transport.Deprecation{}
+		deprecation.Message, _ = v["message"].(string)
+		if s, ok := v["sunset"].(string); ok {
+			if sunset, err := time.Parse(time.RFC3339, s); err == nil {
+				deprecation.Sunset = sunset
+			}
+		}
+		return deprecation
+	}
+	return nil
+}
+
+// parseExamples converts the "toolbox/examples" metadata entries into
+// ToolExamples, skipping entries without an input object.
+func parseExamples(raw []any) []transport.ToolExample {
+	examples := make([]transport.ToolExample, 0, len(raw))
+	for _, v := range raw {
+		entry, ok := v.(map[string]any)
+		if !ok {
+			continue
+		}
+		input, ok := entry["input"].(map[string]any)
+		if !ok {
+			continue
+		}
+		examples = append(examples, transport.ToolExample{
+			Description: getString(entry, "description"),
+			Input:       input,
+			Output:      entry["output"],
+		})
+	}
+	return examples
+}
+
 // parseProperty is the recursive helper to create ParameterSchema
 func parseProperty(name string, definitionMap map[string]any, isRequired bool) transport.ParameterSchema {
-	paramType := getString(definitionMap, "type")
+	paramType, nullable := parseType(definitionMap["type"])
 	if paramType == "" {
 		paramType = "string"
 	}
+	if v, ok := definitionMap["nullable"].(bool); ok && v {
+		nullable = true
+	}
 
 	param := transport.ParameterSchema{
 		Name:        name,
 		Type:        paramType,
 		Description: getString(definitionMap, "description"),
 		Required:    isRequired,
+		Nullable:    nullable,
 	}
 
 	if defaultValue, ok := definitionMap["default"]; ok {
 		param.Default = defaultValue
 	}
 
+	param.Minimum = getFloat(definitionMap, "minimum")
+	param.Maximum = getFloat(definitionMap, "maximum")
+	param.MinLength = getInt(definitionMap, "minLength")
+	param.MaxLength = getInt(definitionMap, "maxLength")
+	param.Pattern = getString(definitionMap, "pattern")
+	param.MinItems = getInt(definitionMap, "minItems")
+	param.MaxItems = getInt(definitionMap, "maxItems")
+
 	switch param.Type {
 	case "object":
 		if ap, ok := definitionMap["additionalProperties"]; ok {
@@ -244,6 +464,32 @@ func parseProperty(name string, definitionMap map[string]any, isRequired bool) t
 	return param
 }
 
+// parseType returns the type of a property and whether it is nullable. The
+// type is either a single type name or a union such as ["string", "null"], in
+// which case the first type other than "null" is used.
+func parseType(raw any) (string, bool) {
+	switch v := raw.(type) {
+	case string:
+		return v, false
+	case []any:
+		var paramType string
+		var nullable bool
+		for _, t := range v {
+			s, ok := t.(string)
+			switch {
+			case !ok:
+			case s == "null":
+				nullable = true
+			case paramType == "":
+				paramType = s
+			}
+		}
+		return paramType, nullable
+	default:
+		return "", false
+	}
+}
+
 // Helper to safely extract string values from map
 func getString(m map[string]any, key string) string {
 	if v, ok := m[key]; ok {
@@ -253,3 +499,34 @@ func getString(m map[string]any, key string) string {
 	}
 	return ""
 }
+
+// getFloat returns the number stored under key, or nil if it is missing or
+// not a number.
+func getFloat(m map[string]any, key string) *float64 {
+	var f float64
+	switch v := m[key].(type) {
+	case float64:
+		f = v
+	case int:
+		f = float64(v)
+	case json.Number:
+		var err error
+		if f, err = v.Float64(); err != nil {
+			return nil
+		}
+	default:
+		return nil
+	}
+	return &f
+}
+
+// getInt returns the non-negative integer stored under key, or nil if it is
+// missing or not such an integer.
+func getInt(m map[string]any, key string) *int {
+	f := getFloat(m, key)
+	if f == nil || *f < 0 || *f != math.Trunc(*f) || *f > math.MaxInt32 {
+		return nil
+	}
+	n := int(*f)
+	return &n
+}
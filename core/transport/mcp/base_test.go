@@ -18,8 +18,15 @@ package mcp
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"reflect"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/googleapis/mcp-toolbox-sdk-go/core/transport"
 )
 
 func TestNewBaseTransport(t *testing.T) {
@@ -116,6 +123,32 @@ func TestEnsureInitialized(t *testing.T) {
 		}
 	})
 
+	t.Run("Reset allows a new handshake", func(t *testing.T) {
+		tr, _ := NewBaseTransport("http://example.com", nil)
+		called := 0
+		tr.HandshakeHook = func(ctx context.Context, headers map[string]string) error {
+			called++
+			if called == 1 {
+				return errors.New("handshake failed")
+			}
+			return nil
+		}
+
+		if err := tr.EnsureInitialized(context.Background(), nil); err == nil {
+			t.Error("Expected the first handshake to fail")
+		}
+		tr.ResetInitialization()
+		if err := tr.EnsureInitialized(context.Background(), nil); err != nil {
+			t.Errorf("Expected the second handshake to succeed, got %v", err)
+		}
+		if err := tr.EnsureInitialized(context.Background(), nil); err != nil {
+			t.Errorf("Unexpected error: %v", err)
+		}
+		if called != 2 {
+			t.Errorf("Expected hook to be called twice, got %d", called)
+		}
+	})
+
 	t.Run("MissingHook", func(t *testing.T) {
 		tr, _ := NewBaseTransport("http://example.com", nil)
 		// No hook defined
@@ -253,6 +286,180 @@ func TestConvertToolDefinition(t *testing.T) {
 	}
 }
 
+func TestConvertToolDefinitionAnnotations(t *testing.T) {
+	tr, _ := NewBaseTransport("http://example.com", nil)
+
+	t.Run("Merges annotations with custom metadata", func(t *testing.T) {
+		rawTool := map[string]any{
+			"name":        "annotated_tool",
+			"inputSchema": map[string]any{"type": "object", "properties": map[string]any{}},
+			"annotations": map[string]any{
+				"readOnlyHint":    true,
+				"destructiveHint": false,
+			},
+			"_meta": map[string]any{
+				"toolbox/authInvoke": []any{"oauth2"},
+				"toolbox/tags":       []any{"read-only", "search"},
+				"custom/owner":       "team-a",
+				"readOnlyHint":       false,
+			},
+		}
+
+		schema, err := tr.ConvertToolDefinition(rawTool)
+		if err != nil {
+			t.Fatalf("ConvertToolDefinition failed: %v", err)
+		}
+
+		if len(schema.Annotations) != 3 {
+			t.Fatalf("Expected 3 annotations, got %v", schema.Annotations)
+		}
+		if schema.Annotations["readOnlyHint"] != true {
+			t.Errorf("Expected annotations to take precedence over _meta, got %v", schema.Annotations["readOnlyHint"])
+		}
+		if schema.Annotations["custom/owner"] != "team-a" {
+			t.Errorf("Expected custom metadata key to be surfaced, got %v", schema.Annotations["custom/owner"])
+		}
+		if _, ok := schema.Annotations["toolbox/authInvoke"]; ok {
+			t.Error("Expected internal toolbox auth metadata to be excluded from annotations")
+		}
+		if len(schema.Tags) != 2 || schema.Tags[0] != "read-only" || schema.Tags[1] != "search" {
+			t.Errorf("Expected Tags=['read-only', 'search'], got %v", schema.Tags)
+		}
+	})
+
+	t.Run("Leaves annotations nil when none are present", func(t *testing.T) {
+		rawTool := map[string]any{
+			"name":        "plain_tool",
+			"inputSchema": map[string]any{"type": "object", "properties": map[string]any{}},
+		}
+
+		schema, err := tr.ConvertToolDefinition(rawTool)
+		if err != nil {
+			t.Fatalf("ConvertToolDefinition failed: %v", err)
+		}
+		if schema.Annotations != nil {
+			t.Errorf("Expected nil annotations, got %v", schema.Annotations)
+		}
+	})
+}
+
+func TestConvertToolDefinitionTitle(t *testing.T) {
+	tr, _ := NewBaseTransport("http://example.com", nil)
+
+	testCases := []struct {
+		name     string
+		rawTool  map[string]any
+		expected string
+	}{
+		{
+			name:     "Top-level title",
+			rawTool:  map[string]any{"name": "t", "title": "Top", "annotations": map[string]any{"title": "Annotation"}},
+			expected: "Top",
+		},
+		{
+			name:     "Annotation title fallback",
+			rawTool:  map[string]any{"name": "t", "annotations": map[string]any{"title": "Annotation"}},
+			expected: "Annotation",
+		},
+		{
+			name:     "No title",
+			rawTool:  map[string]any{"name": "t"},
+			expected: "",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			schema, err := tr.ConvertToolDefinition(tc.rawTool)
+			if err != nil {
+				t.Fatalf("ConvertToolDefinition failed: %v", err)
+			}
+			if schema.Title != tc.expected {
+				t.Errorf("Expected title %q, got %q", tc.expected, schema.Title)
+			}
+		})
+	}
+}
+
+func TestConvertToolDefinitionOutputSchema(t *testing.T) {
+	tr, _ := NewBaseTransport("http://example.com", nil)
+
+	outputSchema := map[string]any{
+		"type":       "object",
+		"properties": map[string]any{"temperature": map[string]any{"type": "number"}},
+	}
+	schema, err := tr.ConvertToolDefinition(map[string]any{"name": "t", "outputSchema": outputSchema})
+	if err != nil {
+		t.Fatalf("ConvertToolDefinition failed: %v", err)
+	}
+	if !reflect.DeepEqual(schema.OutputSchema, outputSchema) {
+		t.Errorf("Expected output schema %v, got %v", outputSchema, schema.OutputSchema)
+	}
+
+	schema, err = tr.ConvertToolDefinition(map[string]any{"name": "t"})
+	if err != nil {
+		t.Fatalf("ConvertToolDefinition failed: %v", err)
+	}
+	if schema.OutputSchema != nil {
+		t.Errorf("Expected no output schema, got %v", schema.OutputSchema)
+	}
+}
+
+func TestConvertToolDefinitionExamples(t *testing.T) {
+	tr, _ := NewBaseTransport("http://example.com", nil)
+
+	t.Run("Parses examples from metadata", func(t *testing.T) {
+		rawTool := map[string]any{
+			"name":        "search",
+			"inputSchema": map[string]any{"type": "object", "properties": map[string]any{}},
+			"_meta": map[string]any{
+				"toolbox/examples": []any{
+					map[string]any{
+						"description": "Find hotels in Paris",
+						"input":       map[string]any{"city": "Paris"},
+						"output":      "3 hotels",
+					},
+					map[string]any{"description": "missing input"},
+				},
+			},
+		}
+
+		schema, err := tr.ConvertToolDefinition(rawTool)
+		if err != nil {
+			t.Fatalf("ConvertToolDefinition failed: %v", err)
+		}
+		want := []transport.ToolExample{
+			{Description: "Find hotels in Paris", Input: map[string]any{"city": "Paris"}, Output: "3 hotels"},
+		}
+		if !reflect.DeepEqual(schema.Examples, want) {
+			t.Errorf("Expected examples %v, got %v", want, schema.Examples)
+		}
+		if _, ok := schema.Annotations["toolbox/examples"]; ok {
+			t.Error("Expected examples metadata to be excluded from annotations")
+		}
+	})
+
+	t.Run("Falls back to input schema examples", func(t *testing.T) {
+		rawTool := map[string]any{
+			"name": "search",
+			"inputSchema": map[string]any{
+				"type":       "object",
+				"properties": map[string]any{},
+				"examples":   []any{map[string]any{"city": "Rome"}, "ignored"},
+			},
+		}
+
+		schema, err := tr.ConvertToolDefinition(rawTool)
+		if err != nil {
+			t.Fatalf("ConvertToolDefinition failed: %v", err)
+		}
+		want := []transport.ToolExample{{Input: map[string]any{"city": "Rome"}}}
+		if !reflect.DeepEqual(schema.Examples, want) {
+			t.Errorf("Expected examples %v, got %v", want, schema.Examples)
+		}
+	})
+}
+
 func TestConvertToolDefinitionWithDefaults(t *testing.T) {
 	tr, _ := NewBaseTransport("http://example.com", nil)
 
@@ -309,6 +516,153 @@ func TestConvertToolDefinitionWithDefaults(t *testing.T) {
 	}
 }
 
+func TestConvertToolDefinitionDeprecation(t *testing.T) {
+	tr, _ := NewBaseTransport("http://example.com", nil)
+	sunset := time.Date(2026, 12, 31, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name string
+		meta map[string]any
+		want *transport.Deprecation
+	}{
+		{"Flag", map[string]any{"toolbox/deprecated": true}, &transport.Deprecation{}},
+		{"Message", map[string]any{"toolbox/deprecated": "use search_v2"}, &transport.Deprecation{Message: "use search_v2"}},
+		{
+			"Object",
+			map[string]any{"toolbox/deprecated": map[string]any{"message": "use search_v2", "sunset": "2026-12-31T00:00:00Z"}},
+			&transport.Deprecation{Message: "use search_v2", Sunset: sunset},
+		},
+		{"Not deprecated", map[string]any{"toolbox/deprecated": false}, nil},
+		{"No metadata", nil, nil},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			rawTool := map[string]any{"name": "search", "inputSchema": map[string]any{"type": "object"}}
+			if tc.meta != nil {
+				rawTool["_meta"] = tc.meta
+			}
+			schema, err := tr.ConvertToolDefinition(rawTool)
+			if err != nil {
+				t.Fatalf("ConvertToolDefinition failed: %v", err)
+			}
+			if !reflect.DeepEqual(schema.Deprecation, tc.want) {
+				t.Errorf("Expected deprecation %+v, got %+v", tc.want, schema.Deprecation)
+			}
+			if _, ok := schema.Annotations["toolbox/deprecated"]; ok {
+				t.Error("Expected the deprecation not to be surfaced as an annotation")
+			}
+		})
+	}
+}
+
+func TestConvertToolDefinitionNullable(t *testing.T) {
+	tr, _ := NewBaseTransport("http://example.com", nil)
+
+	rawTool := map[string]any{
+		"name": "nullable_tool",
+		"inputSchema": map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"union":    map[string]any{"type": []any{"null", "integer"}},
+				"flag":     map[string]any{"type": "string", "nullable": true},
+				"plain":    map[string]any{"type": "string"},
+				"only_nil": map[string]any{"type": []any{"null"}},
+				"items": map[string]any{
+					"type":  "array",
+					"items": map[string]any{"type": []any{"string", "null"}},
+				},
+			},
+			"required": []any{"union"},
+		},
+	}
+
+	schema, err := tr.ConvertToolDefinition(rawTool)
+	if err != nil {
+		t.Fatalf("ConvertToolDefinition failed: %v", err)
+	}
+
+	params := make(map[string]transport.ParameterSchema, len(schema.Parameters))
+	for _, p := range schema.Parameters {
+		params[p.Name] = p
+	}
+	expected := map[string]struct {
+		paramType string
+		nullable  bool
+	}{
+		"union":    {"integer", true},
+		"flag":     {"string", true},
+		"plain":    {"string", false},
+		"only_nil": {"string", true},
+		"items":    {"array", false},
+	}
+	for name, want := range expected {
+		p := params[name]
+		if p.Type != want.paramType || p.Nullable != want.nullable {
+			t.Errorf("Parameter %q: expected type %q and nullable %v, got %q and %v", name, want.paramType, want.nullable, p.Type, p.Nullable)
+		}
+	}
+	if !params["union"].Required {
+		t.Error("Expected the union parameter to be required")
+	}
+	if items := params["items"].Items; items == nil || items.Type != "string" || !items.Nullable {
+		t.Errorf("Expected nullable string items, got %+v", items)
+	}
+}
+
+func TestConvertToolDefinitionConstraints(t *testing.T) {
+	tr, _ := NewBaseTransport("http://example.com", nil)
+
+	rawTool := map[string]any{
+		"name": "constrained_tool",
+		"inputSchema": map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"limit": map[string]any{"type": "integer", "minimum": 1.0, "maximum": json.Number("50")},
+				"code":  map[string]any{"type": "string", "minLength": 2.0, "maxLength": 3.0, "pattern": "^[A-Z]+$"},
+				"ids": map[string]any{
+					"type":     "array",
+					"minItems": 1.0,
+					"maxItems": -1.0,
+					"items":    map[string]any{"type": "integer", "minimum": 0.0},
+				},
+			},
+		},
+	}
+
+	schema, err := tr.ConvertToolDefinition(rawTool)
+	if err != nil {
+		t.Fatalf("ConvertToolDefinition failed: %v", err)
+	}
+
+	params := make(map[string]transport.ParameterSchema, len(schema.Parameters))
+	for _, p := range schema.Parameters {
+		params[p.Name] = p
+	}
+	limit, code, ids := params["limit"], params["code"], params["ids"]
+
+	if limit.Minimum == nil || *limit.Minimum != 1 || limit.Maximum == nil || *limit.Maximum != 50 {
+		t.Errorf("Expected limit to be between 1 and 50, got %v and %v", limit.Minimum, limit.Maximum)
+	}
+	if code.MinLength == nil || *code.MinLength != 2 || code.MaxLength == nil || *code.MaxLength != 3 {
+		t.Errorf("Expected code to have 2 to 3 characters, got %v and %v", code.MinLength, code.MaxLength)
+	}
+	if code.Pattern != "^[A-Z]+$" {
+		t.Errorf("Expected the pattern to be kept, got %q", code.Pattern)
+	}
+	if code.Minimum != nil {
+		t.Errorf("Expected no minimum for code, got %v", *code.Minimum)
+	}
+	if ids.MinItems == nil || *ids.MinItems != 1 {
+		t.Errorf("Expected ids to have at least 1 item, got %v", ids.MinItems)
+	}
+	if ids.MaxItems != nil {
+		t.Errorf("Expected a negative maxItems to be ignored, got %v", *ids.MaxItems)
+	}
+	if ids.Items == nil || ids.Items.Minimum == nil || *ids.Items.Minimum != 0 {
+		t.Errorf("Expected the item constraints to be parsed, got %+v", ids.Items)
+	}
+}
+
 func TestProcessToolResultContent(t *testing.T) {
 	// Setup a dummy transport (ProcessToolResultContent is a pure function, so state doesn't matter)
 	tr, _ := NewBaseTransport("http://example.com", nil)
@@ -316,7 +670,7 @@ func TestProcessToolResultContent(t *testing.T) {
 	tests := []struct {
 		name     string
 		content  []ToolContent
-		expected string
+		expected any
 	}{
 		{
 			// Single text
@@ -356,6 +710,33 @@ func TestProcessToolResultContent(t *testing.T) {
 			},
 			expected: "12",
 		},
+		{
+			// Arrays are valid JSON, but not objects either
+			name: "Valid JSON arrays",
+			content: []ToolContent{
+				{Type: "text", Text: "[1]"},
+				{Type: "text", Text: "[2]"},
+			},
+			expected: "[1][2]",
+		},
+		{
+			// Surrounding whitespace does not prevent merging
+			name: "JSON objects with whitespace",
+			content: []ToolContent{
+				{Type: "text", Text: " {\"a\": 1}\n"},
+				{Type: "text", Text: "{\"b\": 2}"},
+			},
+			expected: "[ {\"a\": 1}\n,{\"b\": 2}]",
+		},
+		{
+			// Truncated objects are not valid JSON
+			name: "Truncated JSON object",
+			content: []ToolContent{
+				{Type: "text", Text: `{"a": 1}`},
+				{Type: "text", Text: `{"b": `},
+			},
+			expected: `{"a": 1}{"b": `,
+		},
 		{
 			// Empty
 			name:     "Empty",
@@ -363,23 +744,240 @@ func TestProcessToolResultContent(t *testing.T) {
 			expected: "null",
 		},
 		{
-			// Non-text ignored
-			// Image type should be skipped, leaving only "kept"
-			name: "Non-text ignored",
+			// Non-text kept
+			// Content with an image is returned as blocks, not flattened
+			name: "Non-text kept",
 			content: []ToolContent{
-				{Type: "image", Text: "ignored"},
+				{Type: "image", Data: "aW1n", MimeType: "image/png"},
+				{Type: "text", Text: "kept"},
+			},
+			expected: []ToolContent{
+				{Type: "image", Data: "aW1n", MimeType: "image/png"},
 				{Type: "text", Text: "kept"},
 			},
-			expected: "kept",
 		},
 	}
 
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
 			result := tr.ProcessToolResultContent(tc.content)
-			if result != tc.expected {
-				t.Errorf("\nExpected: %s\nGot:      %s", tc.expected, result)
+			if !reflect.DeepEqual(result, tc.expected) {
+				t.Errorf("\nExpected: %v\nGot:      %v", tc.expected, result)
 			}
 		})
 	}
 }
+
+func BenchmarkProcessToolResultContent(b *testing.B) {
+	tr, _ := NewBaseTransport("http://example.com", nil)
+	// Toolbox returns one text block per row of a query result.
+	content := make([]ToolContent, 100)
+	for i := range content {
+		content[i] = ToolContent{Type: "text", Text: fmt.Sprintf(`{"id": %d, "name": "Hotel %d", "location": "Basel", "price_tier": "Midscale", "booked": false}`, i, i)}
+	}
+
+	b.ReportAllocs()
+	for b.Loop() {
+		tr.ProcessToolResultContent(content)
+	}
+}
+
+func TestDispatchNotification(t *testing.T) {
+	tr, _ := NewBaseTransport("http://example.com", nil)
+	var fromContext, fromTransport []string
+
+	// Without any handler, notifications are dropped.
+	tr.DispatchNotification(context.Background(), transport.Notification{Method: "a"})
+
+	tr.SetNotificationHandler(func(n transport.Notification) {
+		fromTransport = append(fromTransport, n.Method)
+	})
+	ctx := transport.WithNotificationHandler(context.Background(), func(n transport.Notification) {
+		fromContext = append(fromContext, n.Method)
+	})
+	tr.DispatchNotification(ctx, transport.Notification{Method: "b"})
+	tr.DispatchNotification(context.Background(), transport.Notification{Method: "c"})
+
+	if !reflect.DeepEqual(fromContext, []string{"b"}) {
+		t.Errorf("Unexpected context notifications: %v", fromContext)
+	}
+	if !reflect.DeepEqual(fromTransport, []string{"b", "c"}) {
+		t.Errorf("Unexpected transport notifications: %v", fromTransport)
+	}
+}
+
+func TestTrackProgress(t *testing.T) {
+	tr, _ := NewBaseTransport("http://example.com", nil)
+	progress := func(token string) transport.Notification {
+		return transport.Notification{Method: progressMethod, Params: json.RawMessage(`{"progressToken":"` + token + `","progress":1}`)}
+	}
+
+	meta, done := tr.TrackProgress(context.Background())
+	done()
+	if meta != nil {
+		t.Errorf("Expected no meta without a progress token, got %v", meta)
+	}
+
+	var received int
+	ctx := transport.WithNotificationHandler(context.Background(), func(transport.Notification) { received++ })
+	ctx = transport.WithProgressToken(ctx, "tok")
+	meta, done = tr.TrackProgress(ctx)
+	if !reflect.DeepEqual(meta, map[string]any{"progressToken": "tok"}) {
+		t.Errorf("Unexpected meta: %v", meta)
+	}
+
+	// Notifications received outside of the request are routed by token, and
+	// those received within it are not delivered twice.
+	tr.DispatchNotification(context.Background(), progress("tok"))
+	tr.DispatchNotification(context.Background(), progress("other"))
+	tr.DispatchNotification(ctx, progress("tok"))
+	if received != 2 {
+		t.Errorf("Expected 2 progress notifications, got %d", received)
+	}
+
+	done()
+	tr.DispatchNotification(context.Background(), progress("tok"))
+	if received != 2 {
+		t.Errorf("Expected no progress notifications after done, got %d", received-2)
+	}
+}
+
+func TestTrackProgressMeta(t *testing.T) {
+	tr, _ := NewBaseTransport("http://example.com", nil)
+	requestMeta := map[string]any{"tenant": "acme"}
+	ctx := transport.WithRequestMeta(context.Background(), requestMeta)
+
+	meta, done := tr.TrackProgress(ctx)
+	done()
+	if !reflect.DeepEqual(meta, map[string]any{"tenant": "acme"}) {
+		t.Errorf("Unexpected meta: %v", meta)
+	}
+
+	meta, done = tr.TrackProgress(transport.WithProgressToken(ctx, "tok"))
+	done()
+	if !reflect.DeepEqual(meta, map[string]any{"tenant": "acme", "progressToken": "tok"}) {
+		t.Errorf("Unexpected meta: %v", meta)
+	}
+	if len(requestMeta) != 1 {
+		t.Errorf("Expected the meta carried by the context not to be modified, got %v", requestMeta)
+	}
+}
+
+func TestNotifyCancelled(t *testing.T) {
+	tr, _ := NewBaseTransport("http://example.com", nil)
+	sent := make(chan map[string]any, 3)
+	release := make(chan struct{})
+	notify := func(ctx context.Context, method string, params any) error {
+		if ctx.Err() != nil {
+			t.Errorf("Expected the notification context to outlive the request")
+		}
+		if method != cancelledMethod {
+			t.Errorf("Unexpected method: %s", method)
+		}
+		sent <- params.(map[string]any)
+		// A slow notification does not hold up the caller.
+		<-release
+		return errors.New("ignored")
+	}
+	defer close(release)
+
+	tr.NotifyCancelled(context.Background(), "tools/call", 1, notify)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	tr.NotifyCancelled(ctx, "initialize", 2, notify)
+	tr.NotifyCancelled(ctx, "tools/call", 3, notify)
+
+	select {
+	case params := <-sent:
+		expected := map[string]any{"requestId": 3, "reason": "context canceled"}
+		if !reflect.DeepEqual(params, expected) {
+			t.Errorf("Unexpected cancellation: %v", params)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Expected a cancellation notification")
+	}
+	select {
+	case params := <-sent:
+		t.Errorf("Unexpected cancellation: %v", params)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+// recordingObserver records the requests reported to it.
+type recordingObserver struct {
+	events []string
+}
+
+func (o *recordingObserver) OnRPCStart(_ context.Context, method string) {
+	o.events = append(o.events, "start "+method)
+}
+
+func (o *recordingObserver) OnRPCEnd(_ context.Context, method string, duration time.Duration, err error) {
+	if duration < 0 {
+		o.events = append(o.events, fmt.Sprintf("negative duration %s", duration))
+	}
+	o.events = append(o.events, fmt.Sprintf("end %s %v", method, err))
+}
+
+func TestObserveRPC(t *testing.T) {
+	tr, _ := NewBaseTransport("http://example.com", nil)
+	tr.ObserveRPC(context.Background(), "tools/list")(nil)
+
+	observer := &recordingObserver{}
+	tr.SetObserver(observer)
+	end := tr.ObserveRPC(context.Background(), "tools/call")
+	end(errors.New("boom"))
+
+	expected := []string{"start tools/call", "end tools/call boom"}
+	if !reflect.DeepEqual(observer.events, expected) {
+		t.Errorf("Unexpected events: %v", observer.events)
+	}
+}
+
+func TestWithRequestTimeout(t *testing.T) {
+	tr, _ := NewBaseTransport("http://example.com", nil)
+
+	t.Run("Leaves the context alone without a timeout", func(t *testing.T) {
+		ctx := context.Background()
+		reqCtx, done := tr.WithRequestTimeout(ctx, "tools/call")
+		if reqCtx != ctx {
+			t.Error("Expected the caller's context to be used")
+		}
+		if err := done(nil); err != nil {
+			t.Errorf("Expected no error, got %v", err)
+		}
+	})
+
+	tr.SetRequestTimeout(10 * time.Millisecond)
+
+	t.Run("Annotates requests that time out", func(t *testing.T) {
+		reqCtx, done := tr.WithRequestTimeout(context.Background(), "tools/call")
+		<-reqCtx.Done()
+		err := done(reqCtx.Err())
+		if !errors.Is(err, context.DeadlineExceeded) || !strings.Contains(err.Error(), "request 'tools/call' timed out after 10ms") {
+			t.Errorf("Unexpected error: %v", err)
+		}
+	})
+
+	t.Run("Keeps the errors of the caller's context", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		reqCtx, done := tr.WithRequestTimeout(ctx, "tools/call")
+		cancel()
+		<-reqCtx.Done()
+		if err := done(reqCtx.Err()); err != context.Canceled {
+			t.Errorf("Expected the caller's cancellation, got %v", err)
+		}
+	})
+
+	t.Run("Keeps an earlier deadline of the caller", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+		defer cancel()
+		reqCtx, done := tr.WithRequestTimeout(ctx, "tools/call")
+		defer done(nil)
+		deadline, _ := reqCtx.Deadline()
+		if want, _ := ctx.Deadline(); !deadline.Equal(want) {
+			t.Errorf("Expected the deadline %v, got %v", want, deadline)
+		}
+	})
+}
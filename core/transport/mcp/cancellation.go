@@ -0,0 +1,51 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mcp
+
+import (
+	"context"
+	"time"
+)
+
+// cancelledMethod is the notification a client sends to cancel a request.
+const cancelledMethod = "notifications/cancelled"
+
+// cancelTimeout bounds how long sending a cancellation may take once the
+// request it cancels has been abandoned.
+var cancelTimeout = 5 * time.Second
+
+// NotifyCancelled tells the server that the request with the given ID and
+// method, made with ctx, was abandoned because ctx is done, so that the
+// server can stop working on it. It does nothing if ctx is not done. notify
+// sends the notification in the background, so that the caller is not held
+// up, and is called with a context that outlives ctx.
+//
+// The initialize request cannot be cancelled. Failures are ignored, as the
+// server may already be unreachable.
+func (b *BaseMcpTransport) NotifyCancelled(ctx context.Context, method string, id any, notify func(ctx context.Context, method string, params any) error) {
+	if ctx.Err() == nil || method == "initialize" {
+		return
+	}
+
+	params := map[string]any{
+		"requestId": id,
+		"reason":    context.Cause(ctx).Error(),
+	}
+	notifyCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), cancelTimeout)
+	go func() {
+		defer cancel()
+		_ = notify(notifyCtx, cancelledMethod, params)
+	}()
+}
@@ -0,0 +1,72 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mcp
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/googleapis/mcp-toolbox-sdk-go/core/transport"
+)
+
+// completeRequestParams are the parameters of a completion/complete request.
+type completeRequestParams struct {
+	Ref      transport.CompletionRef `json:"ref"`
+	Argument completionArgument      `json:"argument"`
+	Context  *completionContext      `json:"context,omitempty"`
+}
+
+type completionArgument struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type completionContext struct {
+	Arguments map[string]string `json:"arguments"`
+}
+
+// completeResult is the result of a completion/complete request.
+type completeResult struct {
+	Completion transport.Completion `json:"completion"`
+}
+
+// Complete asks the server to complete an argument, if the server declared
+// the completions capability in the handshake.
+func (b *BaseMcpTransport) Complete(ctx context.Context, req transport.CompletionRequest, headers map[string]string) (*transport.Completion, error) {
+	info, err := b.ServerInfo(ctx, headers)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := info.Capabilities["completions"]; !ok {
+		return nil, fmt.Errorf("server does not support the 'completions' capability")
+	}
+
+	params := completeRequestParams{
+		Ref:      req.Ref,
+		Argument: completionArgument{Name: req.ArgumentName, Value: req.Value},
+	}
+	if len(req.Arguments) > 0 {
+		params.Context = &completionContext{Arguments: req.Arguments}
+	}
+
+	var result completeResult
+	if err := b.Request(ctx, "completion/complete", params, headers, &result); err != nil {
+		return nil, fmt.Errorf("failed to complete argument '%s': %w", req.ArgumentName, err)
+	}
+	if result.Completion.Values == nil {
+		result.Completion.Values = []string{}
+	}
+	return &result.Completion, nil
+}
@@ -0,0 +1,56 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mcp
+
+import (
+	"bytes"
+	"encoding/json"
+	"sync"
+)
+
+// maxPooledBufferSize is the capacity above which buffers are dropped rather
+// than returned to the pool, so that an occasional large message does not
+// stay in memory.
+const maxPooledBufferSize = 1 << 20
+
+var bufferPool = sync.Pool{
+	New: func() any { return new(bytes.Buffer) },
+}
+
+// GetBuffer returns an empty buffer from a pool shared by the transports.
+// Return it with PutBuffer once it is no longer used.
+func GetBuffer() *bytes.Buffer {
+	return bufferPool.Get().(*bytes.Buffer)
+}
+
+// PutBuffer returns buf to the pool. Neither buf nor slices of its contents
+// may be used afterwards.
+func PutBuffer(buf *bytes.Buffer) {
+	if buf.Cap() > maxPooledBufferSize {
+		return
+	}
+	buf.Reset()
+	bufferPool.Put(buf)
+}
+
+// DecodeResult decodes the result of a JSON-RPC response into dest without
+// encoding it again first. A missing result leaves dest unchanged, like a
+// null one.
+func DecodeResult(result json.RawMessage, dest any) error {
+	if len(result) == 0 {
+		return nil
+	}
+	return json.Unmarshal(result, dest)
+}
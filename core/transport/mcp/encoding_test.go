@@ -0,0 +1,56 @@
+//go:build unit
+
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mcp
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBufferPool(t *testing.T) {
+	t.Run("Returns empty buffers", func(t *testing.T) {
+		buf := GetBuffer()
+		buf.WriteString("used")
+		PutBuffer(buf)
+		assert.Zero(t, GetBuffer().Len())
+	})
+
+	t.Run("Drops large buffers", func(t *testing.T) {
+		buf := bytes.NewBuffer(make([]byte, 0, maxPooledBufferSize+1))
+		buf.WriteString("large")
+		PutBuffer(buf)
+		assert.Equal(t, "large", buf.String(), "Expected a large buffer to be left untouched")
+	})
+}
+
+func TestDecodeResult(t *testing.T) {
+	var dest struct {
+		Name string `json:"name"`
+	}
+	require.NoError(t, DecodeResult(json.RawMessage(`{"name":"search"}`), &dest))
+	assert.Equal(t, "search", dest.Name)
+
+	require.NoError(t, DecodeResult(nil, &dest), "Expected a missing result to be accepted")
+	require.NoError(t, DecodeResult(json.RawMessage("null"), &dest))
+	assert.Equal(t, "search", dest.Name, "Expected dest to be unchanged")
+
+	assert.Error(t, DecodeResult(json.RawMessage(`{"name":1}`), &dest))
+}
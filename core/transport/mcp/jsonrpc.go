@@ -0,0 +1,203 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"sync"
+	"sync/atomic"
+
+	"github.com/googleapis/mcp-toolbox-sdk-go/core/transport"
+)
+
+// RPCRequest is a JSON-RPC 2.0 request sent to the server.
+type RPCRequest struct {
+	JSONRPC string `json:"jsonrpc"`
+	Method  string `json:"method"`
+	ID      int64  `json:"id"`
+	Params  any    `json:"params,omitempty"`
+}
+
+// RPCNotification is a JSON-RPC 2.0 notification sent to the server.
+type RPCNotification struct {
+	JSONRPC string `json:"jsonrpc"`
+	Method  string `json:"method"`
+	Params  any    `json:"params,omitempty"`
+}
+
+// RPCMessage is any message received from the server: a response to one of
+// our requests, or a request or notification sent by the server.
+type RPCMessage struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method,omitempty"`
+	Params  json.RawMessage `json:"params,omitempty"`
+	Result  json.RawMessage `json:"result,omitempty"`
+	Error   *RPCError       `json:"error,omitempty"`
+}
+
+// RPCError is the error object inside a JSON-RPC response.
+type RPCError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+	Data    any    `json:"data,omitempty"`
+}
+
+// Conn correlates the requests sent over a connection that carries messages
+// in both directions, such as a stdio pipe, an event stream or a WebSocket,
+// with the responses read from it, and dispatches the requests and
+// notifications the server sends on it.
+//
+// The transport reads messages from the connection and passes them to
+// HandleMessage, and calls Close once the connection is gone.
+type Conn struct {
+	base   *BaseMcpTransport
+	reply  func(ctx context.Context, msg any) error
+	nextID atomic.Int64
+
+	pendingMu sync.Mutex
+	pending   map[string]chan *RPCMessage
+
+	// done is closed by Close, after which err is set.
+	done      chan struct{}
+	err       error
+	closeOnce sync.Once
+}
+
+// NewConn returns a Conn that dispatches server messages to the handlers of
+// b, and sends the replies to server requests with reply.
+func NewConn(b *BaseMcpTransport, reply func(ctx context.Context, msg any) error) *Conn {
+	return &Conn{
+		base:    b,
+		reply:   reply,
+		pending: make(map[string]chan *RPCMessage),
+		done:    make(chan struct{}),
+	}
+}
+
+// Call sends a request with send and waits for the response with the same
+// ID, decoding its result into dest unless dest is nil. If ctx is done first,
+// the server is notified of the cancellation, also with send.
+func (c *Conn) Call(ctx context.Context, send func(ctx context.Context, msg any) error, method string, params any, dest any) (err error) {
+	ctx, done := c.base.WithRequestTimeout(ctx, method)
+	end := c.base.ObserveRPC(ctx, method)
+	defer func() {
+		err = done(err)
+		end(err)
+	}()
+
+	id := c.nextID.Add(1)
+	key := strconv.FormatInt(id, 10)
+	respCh := make(chan *RPCMessage, 1)
+
+	c.pendingMu.Lock()
+	c.pending[key] = respCh
+	c.pendingMu.Unlock()
+	defer func() {
+		c.pendingMu.Lock()
+		delete(c.pending, key)
+		c.pendingMu.Unlock()
+	}()
+
+	if err := send(ctx, RPCRequest{JSONRPC: "2.0", Method: method, ID: id, Params: params}); err != nil {
+		return err
+	}
+
+	var resp *RPCMessage
+	select {
+	case resp = <-respCh:
+	case <-ctx.Done():
+		c.base.NotifyCancelled(ctx, method, id, func(ctx context.Context, method string, params any) error {
+			return send(ctx, RPCNotification{JSONRPC: "2.0", Method: method, Params: params})
+		})
+		return ctx.Err()
+	case <-c.done:
+		return c.err
+	}
+
+	if resp.Error != nil {
+		return &transport.ToolInvocationError{
+			Code:    resp.Error.Code,
+			Message: resp.Error.Message,
+			Details: resp.Error.Data,
+		}
+	}
+	if limit := transport.MaxResponseBytes(ctx); limit > 0 && int64(len(resp.Result)) > limit {
+		return &transport.ResponseTooLargeError{Limit: limit}
+	}
+	if dest == nil {
+		return nil
+	}
+	if err := DecodeResult(resp.Result, dest); err != nil {
+		return fmt.Errorf("failed to parse result data: %w", err)
+	}
+	return nil
+}
+
+// HandleMessage dispatches a single message read from the connection.
+// Data that is not a JSON-RPC message is skipped.
+func (c *Conn) HandleMessage(data []byte) {
+	var msg RPCMessage
+	if err := json.Unmarshal(data, &msg); err != nil {
+		return
+	}
+
+	if msg.Method != "" {
+		if len(msg.ID) == 0 {
+			c.base.DispatchNotification(context.Background(), transport.Notification{Method: msg.Method, Params: msg.Params})
+			return
+		}
+		// Answer in the background, since handlers may in turn wait for
+		// messages read from the connection.
+		go func() {
+			_ = c.reply(context.Background(), c.base.AnswerServerRequest(context.Background(), msg.ID, msg.Method, msg.Params))
+		}()
+		return
+	}
+
+	c.pendingMu.Lock()
+	respCh, ok := c.pending[string(msg.ID)]
+	c.pendingMu.Unlock()
+	if ok {
+		respCh <- &msg
+	}
+}
+
+// Close marks the connection as gone, failing pending and later calls with
+// err. Only the first call has an effect.
+func (c *Conn) Close(err error) {
+	c.closeOnce.Do(func() {
+		c.err = err
+		close(c.done)
+	})
+}
+
+// Done returns a channel that is closed once the connection is gone.
+func (c *Conn) Done() <-chan struct{} {
+	return c.done
+}
+
+// Err returns the error passed to Close, or nil if the connection is open.
+func (c *Conn) Err() error {
+	select {
+	case <-c.done:
+		return c.err
+	default:
+		return nil
+	}
+}
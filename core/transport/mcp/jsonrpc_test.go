@@ -0,0 +1,137 @@
+//go:build unit
+
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/googleapis/mcp-toolbox-sdk-go/core/transport"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// pipeConn returns a Conn whose sent messages are delivered on the returned
+// channel, encoded as JSON.
+func pipeConn(b *BaseMcpTransport) (*Conn, chan []byte) {
+	sent := make(chan []byte, 10)
+	send := func(_ context.Context, msg any) error {
+		data, err := json.Marshal(msg)
+		if err != nil {
+			return err
+		}
+		sent <- data
+		return nil
+	}
+	return NewConn(b, send), sent
+}
+
+func TestConn(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Matches responses to requests by ID", func(t *testing.T) {
+		conn, sent := pipeConn(&BaseMcpTransport{})
+		results := make(chan string, 2)
+		for _, method := range []string{"first", "second"} {
+			go func() {
+				var result map[string]string
+				if err := conn.Call(ctx, conn.reply, method, nil, &result); err != nil {
+					results <- err.Error()
+					return
+				}
+				results <- method + ":" + result["method"]
+			}()
+		}
+
+		var requests []RPCMessage
+		for range 2 {
+			var req RPCMessage
+			require.NoError(t, json.Unmarshal(<-sent, &req))
+			requests = append(requests, req)
+		}
+		// Answer in reverse order.
+		for i := len(requests) - 1; i >= 0; i-- {
+			conn.HandleMessage(fmt.Appendf(nil, `{"jsonrpc":"2.0","id":%s,"result":{"method":%q}}`, requests[i].ID, requests[i].Method))
+		}
+		assert.ElementsMatch(t, []string{"first:first", "second:second"}, []string{<-results, <-results})
+	})
+
+	t.Run("Surfaces error responses", func(t *testing.T) {
+		conn, sent := pipeConn(&BaseMcpTransport{})
+		go func() {
+			var req RPCMessage
+			_ = json.Unmarshal(<-sent, &req)
+			conn.HandleMessage(fmt.Appendf(nil, `{"jsonrpc":"2.0","id":%s,"error":{"code":-32601,"message":"method not found"}}`, req.ID))
+		}()
+
+		err := conn.Call(ctx, conn.reply, "missing", nil, nil)
+		var invocationErr *transport.ToolInvocationError
+		require.True(t, errors.As(err, &invocationErr))
+		assert.Equal(t, -32601, invocationErr.Code)
+	})
+
+	t.Run("Fails pending and later calls once closed", func(t *testing.T) {
+		conn, _ := pipeConn(&BaseMcpTransport{})
+		errLost := errors.New("connection lost")
+		errCh := make(chan error, 1)
+		go func() { errCh <- conn.Call(ctx, conn.reply, "pending", nil, nil) }()
+
+		time.Sleep(10 * time.Millisecond)
+		conn.Close(errLost)
+		conn.Close(errors.New("ignored"))
+		assert.ErrorIs(t, <-errCh, errLost)
+		assert.ErrorIs(t, conn.Err(), errLost)
+	})
+
+	t.Run("Notifies the server of cancelled calls", func(t *testing.T) {
+		conn, sent := pipeConn(&BaseMcpTransport{})
+		cancelCtx, cancel := context.WithCancel(ctx)
+		go func() {
+			<-sent
+			cancel()
+		}()
+
+		err := conn.Call(cancelCtx, conn.reply, "slow", nil, nil)
+		require.ErrorIs(t, err, context.Canceled)
+		select {
+		case data := <-sent:
+			assert.JSONEq(t, `{"jsonrpc":"2.0","method":"notifications/cancelled","params":{"requestId":1,"reason":"context canceled"}}`, string(data))
+		case <-time.After(time.Second):
+			t.Fatal("Expected a cancellation notification")
+		}
+	})
+
+	t.Run("Dispatches server notifications and answers server requests", func(t *testing.T) {
+		b := &BaseMcpTransport{}
+		received := make(chan transport.Notification, 1)
+		b.SetNotificationHandler(func(n transport.Notification) { received <- n })
+		conn, sent := pipeConn(b)
+
+		conn.HandleMessage([]byte(`{"jsonrpc":"2.0","method":"notifications/tools/list_changed"}`))
+		assert.Equal(t, "notifications/tools/list_changed", (<-received).Method)
+
+		conn.HandleMessage([]byte(`{"jsonrpc":"2.0","id":"s1","method":"ping"}`))
+		assert.JSONEq(t, `{"jsonrpc":"2.0","id":"s1","result":{}}`, string(<-sent))
+
+		conn.HandleMessage([]byte("not json"))
+		assert.NoError(t, conn.Err())
+	})
+}
@@ -0,0 +1,53 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mcp
+
+import (
+	"context"
+	"time"
+)
+
+// RunKeepAlive pings the server every interval until done is closed. After
+// maxFailures consecutive pings fail or go unanswered for an interval, it
+// calls onDead, which is expected to drop the connection so that the next
+// request opens a new one, and returns.
+//
+// It is meant to run in its own goroutine for each connection of a
+// transport that keeps one open.
+func RunKeepAlive(done <-chan struct{}, interval time.Duration, maxFailures int, ping func(ctx context.Context) error, onDead func()) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	failures := 0
+	for {
+		select {
+		case <-ticker.C:
+		case <-done:
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), interval)
+		err := ping(ctx)
+		cancel()
+		if err == nil {
+			failures = 0
+			continue
+		}
+		if failures++; failures >= maxFailures {
+			onDead()
+			return
+		}
+	}
+}
@@ -0,0 +1,67 @@
+//go:build unit
+
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mcp
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunKeepAlive(t *testing.T) {
+	t.Run("Drops the connection after consecutive failures", func(t *testing.T) {
+		var pings atomic.Int32
+		ping := func(ctx context.Context) error {
+			// Failures must be consecutive: the second ping succeeds.
+			if pings.Add(1) == 2 {
+				return nil
+			}
+			return errors.New("no answer")
+		}
+		dead := make(chan struct{})
+
+		go RunKeepAlive(make(chan struct{}), time.Millisecond, 2, ping, func() { close(dead) })
+		select {
+		case <-dead:
+			assert.Equal(t, int32(4), pings.Load())
+		case <-time.After(time.Second):
+			t.Fatal("Expected the connection to be dropped")
+		}
+	})
+
+	t.Run("Stops when the connection is closed", func(t *testing.T) {
+		done := make(chan struct{})
+		stopped := make(chan struct{})
+		go func() {
+			RunKeepAlive(done, time.Millisecond, 1, func(context.Context) error { return nil }, func() {
+				t.Error("Expected the connection to be kept")
+			})
+			close(stopped)
+		}()
+
+		close(done)
+		select {
+		case <-stopped:
+		case <-time.After(time.Second):
+			t.Fatal("Expected the keep-alive loop to stop")
+		}
+	})
+}
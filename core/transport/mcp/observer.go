@@ -0,0 +1,42 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mcp
+
+import (
+	"context"
+	"time"
+
+	"github.com/googleapis/mcp-toolbox-sdk-go/core/transport"
+)
+
+// SetObserver sets the observer of the requests sent by the transport.
+func (b *BaseMcpTransport) SetObserver(observer transport.Observer) {
+	b.observer = observer
+}
+
+// ObserveRPC reports the start of a request to the observer, if any, and
+// returns the function reporting its end, to be called with the error the
+// request failed with, if any.
+func (b *BaseMcpTransport) ObserveRPC(ctx context.Context, method string) func(err error) {
+	observer := b.observer
+	if observer == nil {
+		return func(error) {}
+	}
+	start := time.Now()
+	observer.OnRPCStart(ctx, method)
+	return func(err error) {
+		observer.OnRPCEnd(ctx, method, time.Since(start), err)
+	}
+}
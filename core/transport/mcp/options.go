@@ -0,0 +1,48 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mcp
+
+// Option configures how an MCP transport presents the client to the server
+// in the handshake.
+type Option func(*BaseMcpTransport)
+
+// WithClientTitle sets the human-readable title of the client, declared
+// alongside its name and version in the handshake. Only protocol versions
+// from 2025-06-18 on carry the title.
+func WithClientTitle(title string) Option {
+	return func(b *BaseMcpTransport) {
+		b.clientTitle = title
+	}
+}
+
+// WithClientCapability declares a client capability, such as "experimental",
+// in the handshake.
+func WithClientCapability(name string, value map[string]any) Option {
+	return func(b *BaseMcpTransport) {
+		b.SetClientCapability(name, value)
+	}
+}
+
+// ApplyOptions configures the transport with opts.
+func (b *BaseMcpTransport) ApplyOptions(opts []Option) {
+	for _, opt := range opts {
+		opt(b)
+	}
+}
+
+// ClientTitle returns the title of the client to declare in the handshake.
+func (b *BaseMcpTransport) ClientTitle() string {
+	return b.clientTitle
+}
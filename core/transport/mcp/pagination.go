@@ -0,0 +1,62 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mcp
+
+import (
+	"context"
+	"fmt"
+)
+
+// paginatedResult holds the cursor of a paginated list result.
+type paginatedResult struct {
+	NextCursor string `json:"nextCursor,omitempty"`
+}
+
+func (p paginatedResult) nextCursor() string {
+	return p.NextCursor
+}
+
+// paginated is implemented by list results that embed paginatedResult.
+type paginated interface {
+	nextCursor() string
+}
+
+// listPages sends a list request for every page of a paginated method,
+// following the cursor returned with each page until there is none.
+func listPages[R paginated](ctx context.Context, b *BaseMcpTransport, method string, headers map[string]string) ([]R, error) {
+	var pages []R
+	cursor := ""
+	for {
+		params := map[string]any{}
+		if cursor != "" {
+			params["cursor"] = cursor
+		}
+
+		var result R
+		if err := b.Request(ctx, method, params, headers, &result); err != nil {
+			return nil, err
+		}
+		pages = append(pages, result)
+
+		next := result.nextCursor()
+		if next == "" {
+			return pages, nil
+		}
+		if next == cursor {
+			return nil, fmt.Errorf("server repeated cursor '%s'", cursor)
+		}
+		cursor = next
+	}
+}
@@ -0,0 +1,79 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+
+	"github.com/googleapis/mcp-toolbox-sdk-go/core/transport"
+)
+
+// progressMethod is the notification a server sends to report the progress
+// of a request.
+const progressMethod = "notifications/progress"
+
+// TrackProgress prepares a request made with ctx for progress reporting and
+// returns the _meta to send with the request: the _meta carried by ctx, if
+// any, and the progress token carried by ctx, if any. With a progress token,
+// the progress notifications tagged with it are routed to the notification
+// handler carried by ctx until done is called. meta is nil if there is
+// nothing to send.
+func (b *BaseMcpTransport) TrackProgress(ctx context.Context) (meta map[string]any, done func()) {
+	meta = maps.Clone(transport.RequestMeta(ctx))
+	token := transport.ProgressToken(ctx)
+	if token == "" {
+		return meta, func() {}
+	}
+
+	if handler := transport.NotificationHandlerFrom(ctx); handler != nil {
+		b.progressMu.Lock()
+		if b.progressHandlers == nil {
+			b.progressHandlers = make(map[string]transport.NotificationHandler)
+		}
+		b.progressHandlers[token] = handler
+		b.progressMu.Unlock()
+	}
+
+	if meta == nil {
+		meta = make(map[string]any, 1)
+	}
+	meta["progressToken"] = token
+	return meta, func() {
+		b.progressMu.Lock()
+		delete(b.progressHandlers, token)
+		b.progressMu.Unlock()
+	}
+}
+
+// routeProgress delivers a progress notification to the handler of the
+// request it belongs to.
+func (b *BaseMcpTransport) routeProgress(n transport.Notification) {
+	var params struct {
+		ProgressToken any `json:"progressToken"`
+	}
+	if err := json.Unmarshal(n.Params, &params); err != nil || params.ProgressToken == nil {
+		return
+	}
+
+	b.progressMu.Lock()
+	handler := b.progressHandlers[fmt.Sprint(params.ProgressToken)]
+	b.progressMu.Unlock()
+	if handler != nil {
+		handler(n)
+	}
+}
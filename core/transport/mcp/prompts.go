@@ -0,0 +1,69 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mcp
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/googleapis/mcp-toolbox-sdk-go/core/transport"
+)
+
+// listPromptsResult is the result of a prompts/list request.
+type listPromptsResult struct {
+	paginatedResult
+	Prompts []transport.Prompt `json:"prompts"`
+}
+
+// getPromptRequestParams are the parameters of a prompts/get request.
+type getPromptRequestParams struct {
+	Name      string            `json:"name"`
+	Arguments map[string]string `json:"arguments,omitempty"`
+}
+
+// ListPrompts fetches the prompt templates offered by the server, following
+// pagination cursors until all pages have been read.
+func (b *BaseMcpTransport) ListPrompts(ctx context.Context, headers map[string]string) ([]transport.Prompt, error) {
+	pages, err := listPages[listPromptsResult](ctx, b, "prompts/list", headers)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list prompts: %w", err)
+	}
+
+	prompts := make([]transport.Prompt, 0)
+	for _, page := range pages {
+		prompts = append(prompts, page.Prompts...)
+	}
+	for i, p := range prompts {
+		if p.Name == "" {
+			return nil, fmt.Errorf("received invalid prompt definition at index %d: missing 'name' field", i)
+		}
+	}
+	return prompts, nil
+}
+
+// GetPrompt asks the server to render a prompt template, substituting the
+// given arguments.
+func (b *BaseMcpTransport) GetPrompt(ctx context.Context, name string, args map[string]string, headers map[string]string) (*transport.PromptResult, error) {
+	params := getPromptRequestParams{
+		Name:      name,
+		Arguments: args,
+	}
+
+	var result transport.PromptResult
+	if err := b.Request(ctx, "prompts/get", params, headers, &result); err != nil {
+		return nil, fmt.Errorf("failed to get prompt '%s': %w", name, err)
+	}
+	return &result, nil
+}
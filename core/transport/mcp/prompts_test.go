@@ -0,0 +1,121 @@
+//go:build unit
+
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/googleapis/mcp-toolbox-sdk-go/core/transport"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newHookTransport returns a BaseMcpTransport whose requests are answered by
+// respond, which returns the JSON result for a method and its parameters.
+func newHookTransport(respond func(method string, params any) (string, error)) *BaseMcpTransport {
+	b := &BaseMcpTransport{}
+	b.RequestHook = func(_ context.Context, method string, params any, _ map[string]string, dest any) error {
+		result, err := respond(method, params)
+		if err != nil {
+			return err
+		}
+		return json.Unmarshal([]byte(result), dest)
+	}
+	return b
+}
+
+func TestListPrompts(t *testing.T) {
+	t.Run("Follows pagination cursors", func(t *testing.T) {
+		var cursors []any
+		b := newHookTransport(func(method string, params any) (string, error) {
+			assert.Equal(t, "prompts/list", method)
+			cursor := params.(map[string]any)["cursor"]
+			cursors = append(cursors, cursor)
+			if cursor == nil {
+				return `{"prompts":[{"name":"summarize","arguments":[{"name":"text","required":true}]}],"nextCursor":"page2"}`, nil
+			}
+			return `{"prompts":[{"name":"greet","title":"Greeting"}]}`, nil
+		})
+
+		prompts, err := b.ListPrompts(context.Background(), nil)
+		require.NoError(t, err)
+		assert.Equal(t, []transport.Prompt{
+			{Name: "summarize", Arguments: []transport.PromptArgument{{Name: "text", Required: true}}},
+			{Name: "greet", Title: "Greeting"},
+		}, prompts)
+		assert.Equal(t, []any{nil, "page2"}, cursors)
+	})
+
+	t.Run("Negative Test - Repeated cursor", func(t *testing.T) {
+		b := newHookTransport(func(string, any) (string, error) {
+			return `{"prompts":[],"nextCursor":"same"}`, nil
+		})
+		_, err := b.ListPrompts(context.Background(), nil)
+		assert.ErrorContains(t, err, "server repeated cursor 'same'")
+	})
+
+	t.Run("Negative Test - Missing name", func(t *testing.T) {
+		b := newHookTransport(func(string, any) (string, error) {
+			return `{"prompts":[{"description":"nameless"}]}`, nil
+		})
+		_, err := b.ListPrompts(context.Background(), nil)
+		assert.ErrorContains(t, err, "missing 'name' field")
+	})
+
+	t.Run("Negative Test - Request fails", func(t *testing.T) {
+		b := newHookTransport(func(string, any) (string, error) {
+			return "", errors.New("boom")
+		})
+		_, err := b.ListPrompts(context.Background(), nil)
+		assert.ErrorContains(t, err, "failed to list prompts: boom")
+	})
+
+	t.Run("Negative Test - No request hook", func(t *testing.T) {
+		_, err := (&BaseMcpTransport{}).ListPrompts(context.Background(), nil)
+		assert.ErrorContains(t, err, "RequestHook")
+	})
+}
+
+func TestGetPrompt(t *testing.T) {
+	b := newHookTransport(func(method string, params any) (string, error) {
+		assert.Equal(t, "prompts/get", method)
+		p := params.(getPromptRequestParams)
+		if p.Name == "missing" {
+			return "", &transport.ToolInvocationError{Code: -32602, Message: "unknown prompt"}
+		}
+		return `{"description":"A summary","messages":[{"role":"user","content":{"type":"text","text":"Summarize: ` + p.Arguments["text"] + `"}}]}`, nil
+	})
+
+	result, err := b.GetPrompt(context.Background(), "summarize", map[string]string{"text": "hello"}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, &transport.PromptResult{
+		Description: "A summary",
+		Messages: []transport.PromptMessage{{
+			Role:    "user",
+			Content: transport.PromptContent{Type: "text", Text: "Summarize: hello"},
+		}},
+	}, result)
+
+	_, err = b.GetPrompt(context.Background(), "missing", nil, nil)
+	var invocationErr *transport.ToolInvocationError
+	require.ErrorAs(t, err, &invocationErr)
+	assert.Equal(t, -32602, invocationErr.Code)
+	assert.ErrorContains(t, err, "failed to get prompt 'missing'")
+}
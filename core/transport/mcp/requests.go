@@ -0,0 +1,137 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mcp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+
+	"github.com/googleapis/mcp-toolbox-sdk-go/core/transport"
+)
+
+// JSON-RPC error codes used when answering server requests.
+const (
+	methodNotFoundCode = -32601
+	internalErrorCode  = -32603
+)
+
+// ServerReply is the client's response to a request sent by the server.
+type ServerReply struct {
+	JSONRPC string            `json:"jsonrpc"`
+	ID      json.RawMessage   `json:"id"`
+	Result  any               `json:"result,omitempty"`
+	Error   *ServerReplyError `json:"error,omitempty"`
+}
+
+// ServerReplyError is the error reported to the server when a request
+// cannot be answered.
+type ServerReplyError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// SetRequestHandler registers the handler for server requests with the given
+// method, replacing any previous one.
+func (b *BaseMcpTransport) SetRequestHandler(method string, handler transport.ServerRequestHandler) {
+	b.serverMu.Lock()
+	defer b.serverMu.Unlock()
+	if b.requestHandlers == nil {
+		b.requestHandlers = make(map[string]transport.ServerRequestHandler)
+	}
+	b.requestHandlers[method] = handler
+}
+
+// SetClientCapability declares a client capability in the handshake.
+func (b *BaseMcpTransport) SetClientCapability(name string, value map[string]any) {
+	b.serverMu.Lock()
+	defer b.serverMu.Unlock()
+	if b.capabilities == nil {
+		b.capabilities = make(map[string]any)
+	}
+	b.capabilities[name] = value
+}
+
+// ClientCapabilities returns the capabilities to declare in the handshake.
+func (b *BaseMcpTransport) ClientCapabilities() map[string]any {
+	b.serverMu.RLock()
+	defer b.serverMu.RUnlock()
+	capabilities := make(map[string]any, len(b.capabilities))
+	maps.Copy(capabilities, b.capabilities)
+	return capabilities
+}
+
+// AnswerServerRequest runs the handler registered for a request sent by the
+// server and returns the reply to send back. Pings are always answered.
+func (b *BaseMcpTransport) AnswerServerRequest(ctx context.Context, id json.RawMessage, method string, params json.RawMessage) *ServerReply {
+	reply := &ServerReply{JSONRPC: "2.0", ID: id}
+	if method == "ping" {
+		reply.Result = map[string]any{}
+		return reply
+	}
+
+	b.serverMu.RLock()
+	handler := b.requestHandlers[method]
+	b.serverMu.RUnlock()
+	if handler == nil {
+		reply.Error = &ServerReplyError{Code: methodNotFoundCode, Message: fmt.Sprintf("method '%s' is not supported by the client", method)}
+		return reply
+	}
+
+	result, err := handler(ctx, params)
+	if err != nil {
+		reply.Error = &ServerReplyError{Code: internalErrorCode, Message: err.Error()}
+		var invocationErr *transport.ToolInvocationError
+		if errors.As(err, &invocationErr) && invocationErr.Code != 0 {
+			reply.Error = &ServerReplyError{Code: invocationErr.Code, Message: invocationErr.Message}
+		}
+		return reply
+	}
+	if result == nil {
+		result = map[string]any{}
+	}
+	reply.Result = result
+	return reply
+}
+
+// postReply answers a server request received in the response to req by
+// posting the reply to the same endpoint with the same headers.
+func (b *BaseMcpTransport) postReply(ctx context.Context, req *http.Request, reply *ServerReply) error {
+	payload, err := json.Marshal(reply)
+	if err != nil {
+		return fmt.Errorf("marshal failed: %w", err)
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, req.URL.String(), bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("create request failed: %w", err)
+	}
+	httpReq.Header = req.Header.Clone()
+
+	resp, err := b.Do(httpReq)
+	if err != nil {
+		return fmt.Errorf("http request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusAccepted && resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
+		body, _ := io.ReadAll(resp.Body)
+		return transport.NewHTTPResponseError(resp, body)
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+//go:build unit
+
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/googleapis/mcp-toolbox-sdk-go/core/transport"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAnswerServerRequest(t *testing.T) {
+	b := &BaseMcpTransport{}
+	b.SetRequestHandler("echo", func(_ context.Context, params json.RawMessage) (any, error) {
+		return map[string]any{"echoed": string(params)}, nil
+	})
+	b.SetRequestHandler("empty", func(context.Context, json.RawMessage) (any, error) {
+		return nil, nil
+	})
+	b.SetRequestHandler("fail", func(context.Context, json.RawMessage) (any, error) {
+		return nil, errors.New("boom")
+	})
+	b.SetRequestHandler("reject", func(context.Context, json.RawMessage) (any, error) {
+		return nil, &transport.ToolInvocationError{Code: -1, Message: "user rejected"}
+	})
+	ctx := context.Background()
+	id := json.RawMessage(`7`)
+
+	testCases := []struct {
+		method string
+		want   string
+	}{
+		{"ping", `{"jsonrpc":"2.0","id":7,"result":{}}`},
+		{"echo", `{"jsonrpc":"2.0","id":7,"result":{"echoed":"{\"a\":1}"}}`},
+		{"empty", `{"jsonrpc":"2.0","id":7,"result":{}}`},
+		{"fail", `{"jsonrpc":"2.0","id":7,"error":{"code":-32603,"message":"boom"}}`},
+		{"reject", `{"jsonrpc":"2.0","id":7,"error":{"code":-1,"message":"user rejected"}}`},
+		{"unknown", `{"jsonrpc":"2.0","id":7,"error":{"code":-32601,"message":"method 'unknown' is not supported by the client"}}`},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.method, func(t *testing.T) {
+			reply := b.AnswerServerRequest(ctx, id, tc.method, json.RawMessage(`{"a":1}`))
+			got, err := json.Marshal(reply)
+			assert.NoError(t, err)
+			assert.JSONEq(t, tc.want, string(got))
+		})
+	}
+}
+
+func TestClientCapabilities(t *testing.T) {
+	b := &BaseMcpTransport{}
+	assert.Empty(t, b.ClientCapabilities())
+
+	b.SetClientCapability("sampling", map[string]any{})
+	b.SetClientCapability("roots", map[string]any{"listChanged": true})
+	capabilities := b.ClientCapabilities()
+	assert.Equal(t, map[string]any{
+		"sampling": map[string]any{},
+		"roots":    map[string]any{"listChanged": true},
+	}, capabilities)
+
+	capabilities["other"] = true
+	assert.NotContains(t, b.ClientCapabilities(), "other", "Expected a copy of the capabilities")
+}
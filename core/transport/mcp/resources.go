@@ -0,0 +1,113 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mcp
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/googleapis/mcp-toolbox-sdk-go/core/transport"
+)
+
+// listResourcesResult is the result of a resources/list request.
+type listResourcesResult struct {
+	paginatedResult
+	Resources []transport.ResourceDescriptor `json:"resources"`
+}
+
+// listResourceTemplatesResult is the result of a resources/templates/list
+// request.
+type listResourceTemplatesResult struct {
+	paginatedResult
+	ResourceTemplates []transport.ResourceTemplate `json:"resourceTemplates"`
+}
+
+// readResourceResult is the result of a resources/read request.
+type readResourceResult struct {
+	Contents []transport.ResourceContents `json:"contents"`
+}
+
+// resourceRequestParams are the parameters of requests naming a resource.
+type resourceRequestParams struct {
+	URI string `json:"uri"`
+}
+
+// ListResources fetches the resources offered by the server, following
+// pagination cursors until all pages have been read.
+func (b *BaseMcpTransport) ListResources(ctx context.Context, headers map[string]string) ([]transport.ResourceDescriptor, error) {
+	pages, err := listPages[listResourcesResult](ctx, b, "resources/list", headers)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list resources: %w", err)
+	}
+
+	resources := make([]transport.ResourceDescriptor, 0)
+	for _, page := range pages {
+		resources = append(resources, page.Resources...)
+	}
+	for i, r := range resources {
+		if r.URI == "" {
+			return nil, fmt.Errorf("received invalid resource definition at index %d: missing 'uri' field", i)
+		}
+	}
+	return resources, nil
+}
+
+// ListResourceTemplates fetches the resource templates offered by the
+// server, following pagination cursors until all pages have been read.
+func (b *BaseMcpTransport) ListResourceTemplates(ctx context.Context, headers map[string]string) ([]transport.ResourceTemplate, error) {
+	pages, err := listPages[listResourceTemplatesResult](ctx, b, "resources/templates/list", headers)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list resource templates: %w", err)
+	}
+
+	templates := make([]transport.ResourceTemplate, 0)
+	for _, page := range pages {
+		templates = append(templates, page.ResourceTemplates...)
+	}
+	for i, rt := range templates {
+		if rt.URITemplate == "" {
+			return nil, fmt.Errorf("received invalid resource template at index %d: missing 'uriTemplate' field", i)
+		}
+	}
+	return templates, nil
+}
+
+// ReadResource fetches the contents of the resource identified by uri.
+func (b *BaseMcpTransport) ReadResource(ctx context.Context, uri string, headers map[string]string) ([]transport.ResourceContents, error) {
+	var result readResourceResult
+	if err := b.Request(ctx, "resources/read", resourceRequestParams{URI: uri}, headers, &result); err != nil {
+		return nil, fmt.Errorf("failed to read resource '%s': %w", uri, err)
+	}
+	return result.Contents, nil
+}
+
+// SubscribeResource asks the server to send notifications/resources/updated
+// when the resource identified by uri changes.
+func (b *BaseMcpTransport) SubscribeResource(ctx context.Context, uri string, headers map[string]string) error {
+	var result struct{}
+	if err := b.Request(ctx, "resources/subscribe", resourceRequestParams{URI: uri}, headers, &result); err != nil {
+		return fmt.Errorf("failed to subscribe to resource '%s': %w", uri, err)
+	}
+	return nil
+}
+
+// UnsubscribeResource cancels a subscription made with SubscribeResource.
+func (b *BaseMcpTransport) UnsubscribeResource(ctx context.Context, uri string, headers map[string]string) error {
+	var result struct{}
+	if err := b.Request(ctx, "resources/unsubscribe", resourceRequestParams{URI: uri}, headers, &result); err != nil {
+		return fmt.Errorf("failed to unsubscribe from resource '%s': %w", uri, err)
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+//go:build unit
+
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mcp
+
+import (
+	"context"
+	"testing"
+
+	"github.com/googleapis/mcp-toolbox-sdk-go/core/transport"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListResources(t *testing.T) {
+	t.Run("Follows pagination cursors", func(t *testing.T) {
+		b := newHookTransport(func(method string, params any) (string, error) {
+			assert.Equal(t, "resources/list", method)
+			if params.(map[string]any)["cursor"] == nil {
+				return `{"resources":[{"uri":"file:///a.txt","name":"a","mimeType":"text/plain"}],"nextCursor":"2"}`, nil
+			}
+			return `{"resources":[{"uri":"file:///b.png","name":"b","size":42}]}`, nil
+		})
+
+		resources, err := b.ListResources(context.Background(), nil)
+		require.NoError(t, err)
+		assert.Equal(t, []transport.ResourceDescriptor{
+			{URI: "file:///a.txt", Name: "a", MimeType: "text/plain"},
+			{URI: "file:///b.png", Name: "b", Size: 42},
+		}, resources)
+	})
+
+	t.Run("Negative Test - Missing URI", func(t *testing.T) {
+		b := newHookTransport(func(string, any) (string, error) {
+			return `{"resources":[{"name":"a"}]}`, nil
+		})
+		_, err := b.ListResources(context.Background(), nil)
+		assert.ErrorContains(t, err, "missing 'uri' field")
+	})
+}
+
+func TestListResourceTemplates(t *testing.T) {
+	b := newHookTransport(func(method string, params any) (string, error) {
+		assert.Equal(t, "resources/templates/list", method)
+		return `{"resourceTemplates":[{"uriTemplate":"db://table/{name}","name":"table","mimeType":"application/json"}]}`, nil
+	})
+
+	templates, err := b.ListResourceTemplates(context.Background(), nil)
+	require.NoError(t, err)
+	assert.Equal(t, []transport.ResourceTemplate{
+		{URITemplate: "db://table/{name}", Name: "table", MimeType: "application/json"},
+	}, templates)
+
+	b = newHookTransport(func(string, any) (string, error) {
+		return `{"resourceTemplates":[{"name":"table"}]}`, nil
+	})
+	_, err = b.ListResourceTemplates(context.Background(), nil)
+	assert.ErrorContains(t, err, "missing 'uriTemplate' field")
+}
+
+func TestReadResource(t *testing.T) {
+	b := newHookTransport(func(method string, params any) (string, error) {
+		assert.Equal(t, "resources/read", method)
+		assert.Equal(t, resourceRequestParams{URI: "file:///a"}, params)
+		return `{"contents":[{"uri":"file:///a","text":"hi"},{"uri":"file:///a#2","blob":"AAE="}]}`, nil
+	})
+
+	contents, err := b.ReadResource(context.Background(), "file:///a", nil)
+	require.NoError(t, err)
+	assert.Equal(t, []transport.ResourceContents{
+		{URI: "file:///a", Text: "hi"},
+		{URI: "file:///a#2", Blob: "AAE="},
+	}, contents)
+}
+
+func TestSubscribeResource(t *testing.T) {
+	var methods []string
+	b := newHookTransport(func(method string, params any) (string, error) {
+		methods = append(methods, method)
+		assert.Equal(t, resourceRequestParams{URI: "file:///a"}, params)
+		return `{}`, nil
+	})
+
+	require.NoError(t, b.SubscribeResource(context.Background(), "file:///a", nil))
+	require.NoError(t, b.UnsubscribeResource(context.Background(), "file:///a", nil))
+	assert.Equal(t, []string{"resources/subscribe", "resources/unsubscribe"}, methods)
+}
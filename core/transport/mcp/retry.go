@@ -0,0 +1,181 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mcp
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/googleapis/mcp-toolbox-sdk-go/core/transport"
+)
+
+// SetRetryPolicy configures the retries of the HTTP requests sent with Do.
+func (b *BaseMcpTransport) SetRetryPolicy(policy transport.RetryPolicy) {
+	b.retryPolicy = policy
+}
+
+// Do sends an HTTP request with the transport's HTTP client. Requests that
+// fail with a connection error or with a 429 or 503 response are retried as
+// configured by the retry policy, unless the request context records them as
+// not idempotent with transport.WithIdempotent, in which case they are only
+// retried after a 429 or when the connection to the server could not be
+// established. Retries happen after the delay requested by the server in
+// a Retry-After header or in its error body, if any, or an exponentially
+// growing, randomized delay. If the server requests a delay longer than the
+// policy's MaxDelay, its response is returned instead. Requests whose body
+// cannot be rewound are not retried. Retries are reported to the observer if it is a
+// transport.RetryObserver. The headers of the final response are recorded in
+// the transport.ResponseHeaders carried by the request context, if any, and
+// the query parameters carried by the context are added to the request URL.
+func (b *BaseMcpTransport) Do(req *http.Request) (*http.Response, error) {
+	req = transport.AddQueryParams(req)
+	client := b.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+	policy := b.retryPolicy
+	rewindable := req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+
+	for attempt := 1; ; attempt++ {
+		resp, err := client.Do(req)
+		retry := attempt < policy.MaxAttempts && rewindable && shouldRetry(req.Context(), resp, err)
+		var delay time.Duration
+		if retry {
+			delay, retry = retryDelay(policy, attempt, resp)
+		}
+		if !retry {
+			if resp != nil {
+				transport.RecordResponseHeaders(req.Context(), resp.Header)
+			}
+			return resp, err
+		}
+
+		if observer, ok := b.observer.(transport.RetryObserver); ok {
+			statusCode := 0
+			if resp != nil {
+				statusCode = resp.StatusCode
+			}
+			observer.OnRetry(req.Context(), attempt, statusCode, err)
+		}
+
+		if resp != nil {
+			_, _ = io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		}
+
+		next := req.Clone(req.Context())
+		if req.GetBody != nil {
+			if next.Body, err = req.GetBody(); err != nil {
+				return nil, err
+			}
+		}
+		req = next
+	}
+}
+
+// shouldRetry reports whether a request that ended with resp and err may
+// succeed if sent again, and can safely be sent again.
+func shouldRetry(ctx context.Context, resp *http.Response, err error) bool {
+	idempotent, ok := transport.Idempotent(ctx)
+	if ok && !idempotent {
+		// A 429 means that the server did not act on the request.
+		if err == nil {
+			return resp.StatusCode == http.StatusTooManyRequests
+		}
+		return ctx.Err() == nil && notSent(err)
+	}
+	if err != nil {
+		return ctx.Err() == nil && !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded)
+	}
+	return resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable
+}
+
+// notSent reports whether err shows that a request never reached the server
+// because the connection to it could not be established.
+func notSent(err error) bool {
+	var opErr *net.OpError
+	if errors.As(err, &opErr) && opErr.Op == "dial" {
+		return true
+	}
+	var dnsErr *net.DNSError
+	return errors.As(err, &dnsErr)
+}
+
+// ToolCallContext returns ctx, recorded as not idempotent with
+// transport.WithIdempotent unless it already records whether its requests are
+// idempotent. Transports use it for tools/call requests, which may have side
+// effects.
+func ToolCallContext(ctx context.Context) context.Context {
+	if _, ok := transport.Idempotent(ctx); ok {
+		return ctx
+	}
+	return transport.WithIdempotent(ctx, false)
+}
+
+// retryDelay returns how long to wait before the attempt following the given
+// one, and false if the server asked to wait longer than the policy allows.
+func retryDelay(policy transport.RetryPolicy, attempt int, resp *http.Response) (time.Duration, bool) {
+	if delay := retryAfter(resp); delay > 0 {
+		return delay, policy.MaxDelay <= 0 || delay <= policy.MaxDelay
+	}
+	// The exponent is bounded so that the delay cannot overflow.
+	delay := policy.BaseDelay << min(attempt-1, 20)
+	if delay > 0 {
+		delay = delay/2 + rand.N(delay/2+1)
+	}
+	if policy.MaxDelay > 0 && delay > policy.MaxDelay {
+		delay = policy.MaxDelay
+	}
+	return delay, true
+}
+
+// maxRetryBodyBytes bounds how much of the body of a failed response is read
+// to look for a retry delay.
+const maxRetryBodyBytes = 64 << 10
+
+// retryAfter returns the delay requested by a Retry-After header or, failing
+// that, by the retryAfter field of a JSON error body, or 0 if there is none.
+// The part of the body it reads is put back, so that the response can still
+// be returned.
+func retryAfter(resp *http.Response) time.Duration {
+	if resp == nil {
+		return 0
+	}
+	if delay := transport.RetryAfter(resp.Header); delay > 0 {
+		return delay
+	}
+	if resp.Body == nil {
+		return 0
+	}
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxRetryBodyBytes))
+	resp.Body = struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(bytes.NewReader(body), resp.Body), resp.Body}
+	return transport.NewHTTPResponseError(resp, body).RetryAfter
+}
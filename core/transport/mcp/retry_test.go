@@ -0,0 +1,284 @@
+//go:build unit
+
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mcp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/googleapis/mcp-toolbox-sdk-go/core/transport"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDo(t *testing.T) {
+	// newTransport returns a transport whose server replies with the given
+	// responses in turn, recording the bodies of the requests it receives.
+	newTransport := func(policy transport.RetryPolicy, replies ...func() (*http.Response, error)) (*BaseMcpTransport, *[]string) {
+		var bodies []string
+		b := &BaseMcpTransport{HTTPClient: &http.Client{Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+			body, _ := io.ReadAll(r.Body)
+			bodies = append(bodies, string(body))
+			reply := replies[min(len(bodies), len(replies))-1]
+			return reply()
+		})}}
+		b.SetRetryPolicy(policy)
+		return b, &bodies
+	}
+	status := func(code int, header http.Header) func() (*http.Response, error) {
+		return func() (*http.Response, error) {
+			return &http.Response{StatusCode: code, Header: header, Body: io.NopCloser(strings.NewReader("body"))}, nil
+		}
+	}
+	refused := func() (*http.Response, error) { return nil, errors.New("connection refused") }
+	newRequest := func(ctx context.Context) *http.Request {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, "http://mcp.test", strings.NewReader("payload"))
+		require.NoError(t, err)
+		return req
+	}
+	policy := transport.RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond}
+
+	t.Run("Retries rejected requests and connection errors", func(t *testing.T) {
+		b, bodies := newTransport(policy,
+			status(http.StatusTooManyRequests, http.Header{"Retry-After": {"0"}}),
+			refused,
+			status(http.StatusOK, nil))
+		resp, err := b.Do(newRequest(context.Background()))
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, []string{"payload", "payload", "payload"}, *bodies, "Expected the body to be resent")
+	})
+
+	t.Run("Reports retries to a retry observer", func(t *testing.T) {
+		b, _ := newTransport(policy,
+			status(http.StatusServiceUnavailable, http.Header{"Retry-After": {"0"}}),
+			refused,
+			status(http.StatusOK, nil))
+		observer := &retryRecordingObserver{}
+		b.SetObserver(observer)
+		_, err := b.Do(newRequest(transport.WithToolName(context.Background(), "search")))
+		require.NoError(t, err)
+		assert.Equal(t, []string{"search 1 503 <nil>", `search 2 0 Post "http://mcp.test": connection refused`}, observer.retries)
+	})
+
+	t.Run("Records the headers of the final response", func(t *testing.T) {
+		b, _ := newTransport(policy,
+			status(http.StatusTooManyRequests, http.Header{"Retry-After": {"0"}, "X-Request-Id": {"first"}}),
+			status(http.StatusOK, http.Header{"X-Request-Id": {"second"}}))
+		var headers transport.ResponseHeaders
+		_, err := b.Do(newRequest(transport.WithResponseHeaders(context.Background(), &headers)))
+		require.NoError(t, err)
+		assert.Equal(t, "second", headers.RequestID())
+	})
+
+	t.Run("Returns the last response once attempts are exhausted", func(t *testing.T) {
+		b, bodies := newTransport(policy, status(http.StatusServiceUnavailable, nil))
+		resp, err := b.Do(newRequest(context.Background()))
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+		assert.Len(t, *bodies, 3)
+	})
+
+	t.Run("Does not retry other failures", func(t *testing.T) {
+		b, bodies := newTransport(policy, status(http.StatusInternalServerError, nil))
+		resp, err := b.Do(newRequest(context.Background()))
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+		assert.Len(t, *bodies, 1)
+	})
+
+	t.Run("Does not retry without a policy", func(t *testing.T) {
+		b, bodies := newTransport(transport.RetryPolicy{}, refused)
+		_, err := b.Do(newRequest(context.Background()))
+		assert.ErrorContains(t, err, "connection refused")
+		assert.Len(t, *bodies, 1)
+	})
+
+	t.Run("Does not resend tool calls that may have reached the server", func(t *testing.T) {
+		b, bodies := newTransport(policy,
+			status(http.StatusServiceUnavailable, http.Header{"Retry-After": {"0"}}),
+			refused,
+			status(http.StatusOK, nil))
+		resp, err := b.Do(newRequest(ToolCallContext(context.Background())))
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+		assert.Len(t, *bodies, 1)
+
+		b, bodies = newTransport(policy, refused, status(http.StatusOK, nil))
+		_, err = b.Do(newRequest(ToolCallContext(context.Background())))
+		assert.ErrorContains(t, err, "connection refused")
+		assert.Len(t, *bodies, 1)
+	})
+
+	t.Run("Resends rate limited tool calls", func(t *testing.T) {
+		b, bodies := newTransport(policy,
+			status(http.StatusTooManyRequests, http.Header{"Retry-After": {"0"}}),
+			status(http.StatusOK, nil))
+		resp, err := b.Do(newRequest(ToolCallContext(context.Background())))
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Len(t, *bodies, 2)
+	})
+
+	t.Run("Returns the response when the server asks to wait longer than MaxDelay", func(t *testing.T) {
+		capped := transport.RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: time.Second}
+		b, bodies := newTransport(capped, func() (*http.Response, error) {
+			return &http.Response{
+				StatusCode: http.StatusTooManyRequests,
+				Header:     http.Header{},
+				Body:       io.NopCloser(strings.NewReader(`{"error": {"message": "quota exceeded", "retryAfter": 60}}`)),
+			}, nil
+		})
+		resp, err := b.Do(newRequest(context.Background()))
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+		assert.Len(t, *bodies, 1)
+		body, _ := io.ReadAll(resp.Body)
+		assert.JSONEq(t, `{"error": {"message": "quota exceeded", "retryAfter": 60}}`, string(body), "Expected the body to be intact")
+	})
+
+	t.Run("Resends tool calls that could not connect", func(t *testing.T) {
+		dialFailed := func() (*http.Response, error) {
+			return nil, &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}
+		}
+		b, bodies := newTransport(policy, dialFailed, status(http.StatusOK, nil))
+		resp, err := b.Do(newRequest(ToolCallContext(context.Background())))
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Len(t, *bodies, 2)
+	})
+
+	t.Run("Resends calls to idempotent tools", func(t *testing.T) {
+		b, bodies := newTransport(policy, refused, status(http.StatusOK, nil))
+		ctx := ToolCallContext(transport.WithIdempotent(context.Background(), true))
+		_, err := b.Do(newRequest(ctx))
+		require.NoError(t, err)
+		assert.Len(t, *bodies, 2)
+	})
+
+	t.Run("Stops waiting when the context is done", func(t *testing.T) {
+		b, bodies := newTransport(policy, status(http.StatusTooManyRequests, http.Header{"Retry-After": {"60"}}))
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+		_, err := b.Do(newRequest(ctx))
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Len(t, *bodies, 1)
+	})
+}
+
+// retryRecordingObserver records the retries reported to it.
+type retryRecordingObserver struct {
+	recordingObserver
+	retries []string
+}
+
+func (o *retryRecordingObserver) OnRetry(ctx context.Context, attempt int, statusCode int, err error) {
+	o.retries = append(o.retries, fmt.Sprintf("%s %d %d %v", transport.ToolName(ctx), attempt, statusCode, err))
+}
+
+func TestRetryDelay(t *testing.T) {
+	withRetryAfter := func(value string) *http.Response {
+		return &http.Response{Header: http.Header{"Retry-After": {value}}}
+	}
+	policy := transport.RetryPolicy{BaseDelay: 100 * time.Millisecond, MaxDelay: 10 * time.Second}
+
+	delay := func(attempt int, resp *http.Response) time.Duration {
+		t.Helper()
+		delay, ok := retryDelay(policy, attempt, resp)
+		require.True(t, ok)
+		return delay
+	}
+
+	t.Run("Grows exponentially with jitter", func(t *testing.T) {
+		for attempt, base := range map[int]time.Duration{1: 100 * time.Millisecond, 3: 400 * time.Millisecond} {
+			delay := delay(attempt, nil)
+			assert.GreaterOrEqual(t, delay, base/2)
+			assert.LessOrEqual(t, delay, base)
+		}
+	})
+
+	t.Run("Honors Retry-After", func(t *testing.T) {
+		assert.Equal(t, 2*time.Second, delay(1, withRetryAfter("2")))
+		date := time.Now().Add(5 * time.Second).UTC().Format(http.TimeFormat)
+		delay := delay(1, withRetryAfter(date))
+		assert.Greater(t, delay, 3*time.Second)
+		assert.LessOrEqual(t, delay, 5*time.Second)
+	})
+
+	t.Run("Honors a delay in the error body", func(t *testing.T) {
+		resp := &http.Response{
+			StatusCode: http.StatusTooManyRequests,
+			Header:     http.Header{},
+			Body:       io.NopCloser(strings.NewReader(`{"error": {"message": "slow down", "retryAfter": 3}}`)),
+		}
+		assert.Equal(t, 3*time.Second, delay(1, resp))
+	})
+
+	t.Run("Is capped by MaxDelay", func(t *testing.T) {
+		assert.Equal(t, 10*time.Second, delay(20, nil))
+	})
+
+	t.Run("Does not shorten delays requested by the server", func(t *testing.T) {
+		delay, ok := retryDelay(policy, 1, withRetryAfter("3600"))
+		assert.False(t, ok)
+		assert.Equal(t, time.Hour, delay)
+	})
+}
+
+func TestDo_ToolCallConnectionLost(t *testing.T) {
+	// The server reads each request in full, then drops the connection
+	// without responding, as if it failed while running the tool.
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.ReadAll(r.Body)
+		requests.Add(1)
+		conn, _, err := w.(http.Hijacker).Hijack()
+		if err != nil {
+			t.Errorf("Failed to hijack the connection: %v", err)
+			return
+		}
+		conn.Close()
+	}))
+	defer server.Close()
+
+	b := &BaseMcpTransport{HTTPClient: server.Client()}
+	b.SetRetryPolicy(transport.RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond})
+	body := `{"jsonrpc":"2.0","id":"1","method":"tools/call","params":{"name":"send-email"}}`
+
+	req, err := http.NewRequestWithContext(ToolCallContext(context.Background()), http.MethodPost, server.URL, strings.NewReader(body))
+	require.NoError(t, err)
+	_, err = b.Do(req)
+	require.Error(t, err)
+	assert.Equal(t, int32(1), requests.Load(), "Expected the tool call not to be resent")
+
+	// Other requests are still retried.
+	requests.Store(0)
+	req, err = http.NewRequestWithContext(context.Background(), http.MethodPost, server.URL, strings.NewReader(body))
+	require.NoError(t, err)
+	_, err = b.Do(req)
+	require.Error(t, err)
+	assert.Equal(t, int32(3), requests.Load())
+}
@@ -0,0 +1,45 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mcp
+
+import (
+	"context"
+	"maps"
+
+	"github.com/googleapis/mcp-toolbox-sdk-go/core/transport"
+)
+
+// SetServerInfo records the capabilities and instructions the server
+// declared in the handshake.
+func (b *BaseMcpTransport) SetServerInfo(capabilities map[string]any, instructions string) {
+	b.serverInfo.Store(&transport.ServerInfo{Capabilities: capabilities, Instructions: instructions})
+}
+
+// ServerInfo performs the handshake if needed and returns the capabilities
+// and instructions the server declared in it.
+func (b *BaseMcpTransport) ServerInfo(ctx context.Context, headers map[string]string) (*transport.ServerInfo, error) {
+	if err := b.EnsureInitialized(ctx, headers); err != nil {
+		return nil, err
+	}
+	info := b.serverInfo.Load()
+	if info == nil {
+		return &transport.ServerInfo{Capabilities: map[string]any{}}, nil
+	}
+	capabilities := maps.Clone(info.Capabilities)
+	if capabilities == nil {
+		capabilities = map[string]any{}
+	}
+	return &transport.ServerInfo{Capabilities: capabilities, Instructions: info.Instructions}, nil
+}
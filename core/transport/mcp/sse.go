@@ -0,0 +1,257 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mcp
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/googleapis/mcp-toolbox-sdk-go/core/transport"
+)
+
+// SSEEvent is a single event read from a text/event-stream.
+type SSEEvent struct {
+	// ID is the last event ID seen on the stream.
+	ID string
+	// Event is the event type. It is empty for unnamed events, which
+	// are treated as "message" events.
+	Event string
+	// Data is the event payload, with multiple data lines joined by "\n".
+	Data string
+}
+
+// ReadSSEEvents parses a text/event-stream from r and calls handle for each
+// complete event, stopping when the stream ends or handle returns false.
+// Reaching the end of the stream is not an error.
+func ReadSSEEvents(r io.Reader, handle func(SSEEvent) bool) error {
+	reader := bufio.NewReader(r)
+
+	var lastID, eventType string
+	var data []string
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return err
+		}
+		complete := err == nil
+		line = strings.TrimRight(line, "\r\n")
+
+		switch {
+		case line == "" && complete:
+			// A blank line dispatches the buffered event.
+			if len(data) > 0 {
+				if !handle(SSEEvent{ID: lastID, Event: eventType, Data: strings.Join(data, "\n")}) {
+					return nil
+				}
+			}
+			eventType, data = "", nil
+		case strings.HasPrefix(line, ":"):
+			// Comment, typically used as a keep-alive.
+		case line != "":
+			field, value, _ := strings.Cut(line, ":")
+			value = strings.TrimPrefix(value, " ")
+			switch field {
+			case "event":
+				eventType = value
+			case "data":
+				data = append(data, value)
+			case "id":
+				if !strings.Contains(value, "\x00") {
+					lastID = value
+				}
+			}
+		}
+
+		if !complete {
+			// An event that is not terminated by a blank line is discarded.
+			return nil
+		}
+	}
+}
+
+// maxStreamResumes is the number of consecutive attempts to resume an event
+// stream that are made without receiving a new event in between.
+const maxStreamResumes = 3
+
+// streamResumeDelay is the delay before the first attempt to resume an
+// event stream, increased linearly for subsequent attempts.
+var streamResumeDelay = 250 * time.Millisecond
+
+// ReadResumableSSEEvents reads the event stream of resp like ReadSSEEvents.
+// If the stream breaks or ends before handle returns false and the server
+// tagged its events with IDs, the stream is resumed by repeating the request
+// that opened it as a GET with a Last-Event-ID header, so that the server can
+// replay the events that were missed. The caller closes resp.Body.
+//
+// It returns nil once handle returns false or the stream ends without a way
+// to resume it, and the error the stream or its resumption failed with
+// otherwise.
+func (b *BaseMcpTransport) ReadResumableSSEEvents(ctx context.Context, resp *http.Response, handle func(SSEEvent) bool) error {
+	var lastID string
+	stopped := false
+	track := func(event SSEEvent) bool {
+		if event.ID != "" {
+			lastID = event.ID
+		}
+		stopped = !handle(event)
+		return !stopped
+	}
+
+	body := resp.Body
+	for attempt := 0; ; {
+		seen := lastID
+		err := ReadSSEEvents(body, track)
+		if body != resp.Body {
+			body.Close()
+		}
+		if stopped || lastID == "" || ctx.Err() != nil {
+			return err
+		}
+		if lastID != seen {
+			attempt = 0
+		}
+		if attempt == maxStreamResumes {
+			return err
+		}
+		attempt++
+
+		resumed, err := b.resumeStream(ctx, resp.Request, lastID, time.Duration(attempt)*streamResumeDelay)
+		if err != nil {
+			return fmt.Errorf("failed to resume event stream: %w", err)
+		}
+		body = resumed.Body
+	}
+}
+
+// resumeStream waits for delay and then repeats req as a GET that asks for
+// the events after lastEventID.
+func (b *BaseMcpTransport) resumeStream(ctx context.Context, req *http.Request, lastEventID string, delay time.Duration) (*http.Response, error) {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, req.URL.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("create request failed: %w", err)
+	}
+	httpReq.Header = req.Header.Clone()
+	httpReq.Header.Del("Content-Type")
+	httpReq.Header.Set("Accept", "text/event-stream")
+	httpReq.Header.Set("Last-Event-ID", lastEventID)
+
+	resp, err := b.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("http request failed: %w", err)
+	}
+	mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if resp.StatusCode != http.StatusOK || mediaType != "text/event-stream" {
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		return nil, transport.NewHTTPResponseError(resp, body)
+	}
+	return resp, nil
+}
+
+// ReadResponseBody reads the JSON-RPC response to a POST request. Servers
+// using the Streamable HTTP transport may answer with a text/event-stream
+// instead of a JSON body, in which case the stream is read until the response
+// arrives. Notifications sent before it are delivered to the notification
+// handler carried by ctx and to the transport's handler, and requests are
+// answered with a POST to the same endpoint. A stream that breaks before the
+// response arrives is resumed if possible; see ReadResumableSSEEvents. The
+// response size limit carried by ctx applies to each message.
+func (b *BaseMcpTransport) ReadResponseBody(ctx context.Context, resp *http.Response) ([]byte, error) {
+	mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if mediaType != "text/event-stream" {
+		return transport.ReadBody(ctx, resp.Body)
+	}
+
+	limit := transport.MaxResponseBytes(ctx)
+	var result []byte
+	var resultErr error
+	err := b.ReadResumableSSEEvents(ctx, resp, func(event SSEEvent) bool {
+		if event.Event != "" && event.Event != "message" {
+			return true
+		}
+		if limit > 0 && int64(len(event.Data)) > limit {
+			resultErr = &transport.ResponseTooLargeError{Limit: limit}
+			return false
+		}
+
+		var msg struct {
+			ID     json.RawMessage `json:"id"`
+			Method string          `json:"method"`
+			Params json.RawMessage `json:"params"`
+		}
+		if err := json.Unmarshal([]byte(event.Data), &msg); err != nil {
+			resultErr = fmt.Errorf("invalid message in event stream: %w", err)
+			return false
+		}
+
+		switch {
+		case msg.Method == "":
+			// A message without a method is the response.
+			result = []byte(event.Data)
+			return false
+		case len(msg.ID) == 0:
+			b.DispatchNotification(ctx, transport.Notification{Method: msg.Method, Params: msg.Params})
+		default:
+			reply := b.AnswerServerRequest(ctx, msg.ID, msg.Method, msg.Params)
+			if err := b.postReply(ctx, resp.Request, reply); err != nil {
+				resultErr = fmt.Errorf("failed to answer server request '%s': %w", msg.Method, err)
+				return false
+			}
+		}
+		return true
+	})
+	if resultErr != nil {
+		return nil, resultErr
+	}
+	if err != nil {
+		return nil, err
+	}
+	if result == nil {
+		return nil, fmt.Errorf("event stream ended without a response")
+	}
+	return result, nil
+}
+
+// ReadResponseBodyInto is like ReadResponseBody, but appends the response to
+// buf, which lets callers reuse buffers across requests.
+func (b *BaseMcpTransport) ReadResponseBodyInto(ctx context.Context, resp *http.Response, buf *bytes.Buffer) error {
+	mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if mediaType != "text/event-stream" {
+		return transport.ReadBodyInto(ctx, buf, resp.Body)
+	}
+	body, err := b.ReadResponseBody(ctx, resp)
+	if err != nil {
+		return err
+	}
+	buf.Write(body)
+	return nil
+}
@@ -0,0 +1,530 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package sse implements the legacy HTTP+SSE transport of MCP 2024-11-05.
+//
+// The client opens a long-lived GET request to the server's /sse endpoint.
+// The server announces a messages endpoint in an "endpoint" event, the client
+// POSTs its JSON-RPC messages there, and all responses are delivered as
+// "message" events on the open stream.
+package sse
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/googleapis/mcp-toolbox-sdk-go/core/transport"
+	"github.com/googleapis/mcp-toolbox-sdk-go/core/transport/mcp"
+)
+
+const (
+	ProtocolVersion = "2024-11-05"
+)
+
+// ErrClosed is returned for requests made after the transport was closed or
+// the event stream ended.
+var ErrClosed = errors.New("sse transport is closed")
+
+// Ensure that McpTransport implements the Transport interface and the
+// optional interfaces for MCP server features.
+var (
+	_ transport.Transport        = &McpTransport{}
+	_ transport.PromptSource     = &McpTransport{}
+	_ transport.ResourceSource   = &McpTransport{}
+	_ transport.Completer        = &McpTransport{}
+	_ transport.Observable       = &McpTransport{}
+	_ transport.RequestReceiver  = &McpTransport{}
+	_ transport.ServerInfoSource = &McpTransport{}
+	_ transport.RequestTimeouter = &McpTransport{}
+	_ transport.Pinger           = &McpTransport{}
+	_ transport.Retrier          = &McpTransport{}
+)
+
+// McpTransport implements the MCP v2024-11-05 HTTP+SSE transport.
+//
+// A separate event stream is opened for each toolset, since the server binds
+// the toolset to the stream. A stream that ends is re-opened, with a new
+// handshake, on the next request, unless the server tagged its events with
+// IDs, in which case the stream is first resumed from the last event.
+type McpTransport struct {
+	*mcp.BaseMcpTransport
+	protocolVersion string
+	clientName      string
+	clientVersion   string
+
+	// keepAliveInterval and keepAliveFailures configure pings, which are
+	// disabled if keepAliveInterval is 0.
+	keepAliveInterval time.Duration
+	keepAliveFailures int
+
+	mu       sync.Mutex
+	sessions map[string]*sessionEntry
+	closed   bool
+}
+
+// sessionEntry holds a session that is being or has been established.
+type sessionEntry struct {
+	ready   chan struct{}
+	session *session
+	err     error
+}
+
+// usable reports whether the entry can serve requests, or is still being
+// established.
+func (e *sessionEntry) usable() bool {
+	select {
+	case <-e.ready:
+		return e.err == nil && !e.session.isDone()
+	default:
+		return true
+	}
+}
+
+// session is a single open event stream and its messages endpoint.
+type session struct {
+	transport *McpTransport
+	endpoint  string
+	headers   map[string]string
+	cancel    context.CancelFunc
+
+	endpointCh chan string
+
+	// rpc is closed once the event stream ends.
+	rpc *mcp.Conn
+}
+
+// New creates a new HTTP+SSE transport instance. No connection is made until
+// the first request.
+func New(baseURL string, client *http.Client, clientName string, clientVersion string, opts ...mcp.Option) (*McpTransport, error) {
+	baseTransport, err := mcp.NewBaseTransport(baseURL, client)
+	if err != nil {
+		return nil, err
+	}
+
+	if clientVersion == "" {
+		clientVersion = mcp.SDKVersion
+	}
+
+	t := &McpTransport{
+		BaseMcpTransport: baseTransport,
+		protocolVersion:  ProtocolVersion,
+		clientName:       clientName,
+		clientVersion:    clientVersion,
+		sessions:         make(map[string]*sessionEntry),
+	}
+	t.HandshakeHook = func(ctx context.Context, headers map[string]string) error {
+		_, err := t.session(ctx, "", headers)
+		return err
+	}
+	t.RequestHook = t.request
+
+	t.ApplyOptions(opts)
+	return t, nil
+}
+
+// Close closes all open event streams. It is safe to call Close more than
+// once.
+func (t *McpTransport) Close(context.Context) error {
+	t.mu.Lock()
+	t.closed = true
+	entries := t.sessions
+	t.sessions = make(map[string]*sessionEntry)
+	t.mu.Unlock()
+
+	for _, entry := range entries {
+		select {
+		case <-entry.ready:
+			if entry.session != nil {
+				entry.session.close()
+			}
+		default:
+			// The session closes itself once it is established.
+		}
+	}
+	return nil
+}
+
+// SetKeepAlive makes the transport ping the server every interval over each
+// open event stream, and close the stream after maxFailures consecutive pings
+// fail, so that the next request reconnects.
+func (t *McpTransport) SetKeepAlive(interval time.Duration, maxFailures int) {
+	t.keepAliveInterval = interval
+	t.keepAliveFailures = maxFailures
+}
+
+// ListTools fetches available tools
+func (t *McpTransport) ListTools(ctx context.Context, toolsetName string, headers map[string]string) (*transport.ManifestSchema, error) {
+	s, err := t.session(ctx, toolsetName, headers)
+	if err != nil {
+		return nil, err
+	}
+
+	var result mcp.ListToolsResult
+	if err := s.request(ctx, "tools/list", map[string]any{}, headers, &result); err != nil {
+		return nil, fmt.Errorf("failed to list tools: %w", err)
+	}
+
+	t.mu.Lock()
+	serverVersion := t.ServerVersion
+	t.mu.Unlock()
+
+	manifest := &transport.ManifestSchema{
+		ServerVersion: serverVersion,
+		Tools:         make(map[string]transport.ToolSchema),
+	}
+
+	for i, tool := range result.Tools {
+		if tool.Name == "" {
+			return nil, fmt.Errorf("received invalid tool definition at index %d: missing 'name' field", i)
+		}
+
+		rawTool := map[string]any{
+			"name":        tool.Name,
+			"description": tool.Description,
+			"inputSchema": tool.InputSchema,
+		}
+
+		if tool.Annotations != nil {
+			rawTool["annotations"] = tool.Annotations
+		}
+		if tool.Meta != nil {
+			rawTool["_meta"] = tool.Meta
+		}
+
+		toolSchema, err := t.ConvertToolDefinition(rawTool)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert schema for tool %s: %w", tool.Name, err)
+		}
+
+		manifest.Tools[tool.Name] = toolSchema
+	}
+
+	return manifest, nil
+}
+
+// GetTool fetches a single tool
+func (t *McpTransport) GetTool(ctx context.Context, toolName string, headers map[string]string) (*transport.ManifestSchema, error) {
+	manifest, err := t.ListTools(ctx, "", headers)
+	if err != nil {
+		return nil, err
+	}
+
+	tool, exists := manifest.Tools[toolName]
+	if !exists {
+		return nil, fmt.Errorf("tool '%s' not found", toolName)
+	}
+
+	return &transport.ManifestSchema{
+		ServerVersion: manifest.ServerVersion,
+		Tools:         map[string]transport.ToolSchema{toolName: tool},
+	}, nil
+}
+
+// InvokeTool executes a tool
+func (t *McpTransport) InvokeTool(ctx context.Context, toolName string, payload map[string]any, headers map[string]string) (any, error) {
+	s, err := t.session(ctx, "", headers)
+	if err != nil {
+		return "", err
+	}
+
+	meta, done := t.TrackProgress(ctx)
+	defer done()
+	params := mcp.CallToolParams{
+		Name:      toolName,
+		Arguments: payload,
+		Meta:      meta,
+	}
+
+	ctx = mcp.ToolCallContext(ctx)
+	var result mcp.CallToolResult
+	if err := s.request(ctx, "tools/call", params, headers, &result); err != nil {
+		return "", fmt.Errorf("failed to invoke tool '%s': %w", toolName, err)
+	}
+
+	if result.IsError {
+		// Surface the error text reported by the tool, if any.
+		var message string
+		for _, c := range result.Content {
+			if c.Type == "text" {
+				message += c.Text
+			}
+		}
+		return "", &transport.ToolInvocationError{Message: message}
+	}
+
+	return t.ProcessToolResultContent(result.Content), nil
+}
+
+// request sends a JSON-RPC request on the default session, connecting first
+// if needed.
+func (t *McpTransport) request(ctx context.Context, method string, params any, headers map[string]string, dest any) error {
+	s, err := t.session(ctx, "", headers)
+	if err != nil {
+		return err
+	}
+	return s.request(ctx, method, params, headers, dest)
+}
+
+// session returns the established session for a toolset, connecting and
+// performing the handshake first if needed.
+func (t *McpTransport) session(ctx context.Context, toolsetName string, headers map[string]string) (*session, error) {
+	t.mu.Lock()
+	if t.closed {
+		t.mu.Unlock()
+		return nil, ErrClosed
+	}
+	entry, ok := t.sessions[toolsetName]
+	if ok && entry.usable() {
+		t.mu.Unlock()
+		select {
+		case <-entry.ready:
+			return entry.session, entry.err
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	entry = &sessionEntry{ready: make(chan struct{})}
+	t.sessions[toolsetName] = entry
+	t.mu.Unlock()
+
+	entry.session, entry.err = t.connect(ctx, toolsetName, headers)
+	close(entry.ready)
+
+	t.mu.Lock()
+	closed := t.closed
+	t.mu.Unlock()
+	if closed && entry.session != nil {
+		entry.session.close()
+		return nil, ErrClosed
+	}
+	return entry.session, entry.err
+}
+
+// connect opens the event stream for a toolset, waits for the messages
+// endpoint and performs the initial handshake.
+func (t *McpTransport) connect(ctx context.Context, toolsetName string, headers map[string]string) (*session, error) {
+	sseURL := t.BaseURL()
+	if toolsetName != "" {
+		var err error
+		sseURL, err = url.JoinPath(sseURL, toolsetName)
+		if err != nil {
+			return nil, fmt.Errorf("failed to construct toolset URL: %w", err)
+		}
+	}
+	sseURL, err := url.JoinPath(sseURL, "sse")
+	if err != nil {
+		return nil, fmt.Errorf("failed to construct event stream URL: %w", err)
+	}
+
+	// The stream outlives the request that opens it, so it gets its own
+	// context, which is only tied to ctx until the endpoint is known.
+	streamCtx, cancel := context.WithCancel(context.Background())
+	stop := context.AfterFunc(ctx, cancel)
+
+	httpReq, err := http.NewRequestWithContext(streamCtx, http.MethodGet, sseURL, nil)
+	if err != nil {
+		cancel()
+		return nil, fmt.Errorf("create request failed: %w", err)
+	}
+	httpReq.Header.Set("Accept", "text/event-stream")
+	for k, v := range headers {
+		httpReq.Header.Set(k, v)
+	}
+
+	resp, err := t.Do(httpReq)
+	if err != nil {
+		cancel()
+		return nil, fmt.Errorf("http request failed: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		cancel()
+		return nil, transport.NewHTTPResponseError(resp, body)
+	}
+
+	s := &session{
+		transport:  t,
+		headers:    headers,
+		cancel:     cancel,
+		endpointCh: make(chan string, 1),
+	}
+	s.rpc = mcp.NewConn(t.BaseMcpTransport, func(ctx context.Context, msg any) error {
+		return s.post(ctx, msg, s.headers)
+	})
+	go s.readLoop(resp)
+
+	select {
+	case s.endpoint = <-s.endpointCh:
+	case <-s.rpc.Done():
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		return nil, fmt.Errorf("event stream ended before the messages endpoint was received: %w", s.rpc.Err())
+	}
+	if !stop() {
+		s.close()
+		return nil, ctx.Err()
+	}
+
+	if err := t.initializeSession(ctx, s); err != nil {
+		s.close()
+		return nil, err
+	}
+	if t.keepAliveInterval > 0 {
+		go mcp.RunKeepAlive(s.rpc.Done(), t.keepAliveInterval, t.keepAliveFailures, func(ctx context.Context) error {
+			return s.request(ctx, "ping", nil, s.headers, nil)
+		}, s.close)
+	}
+	return s, nil
+}
+
+// initializeSession performs the initial handshake with the server.
+func (t *McpTransport) initializeSession(ctx context.Context, s *session) error {
+	params := mcp.InitializeParams{
+		ProtocolVersion: t.protocolVersion,
+		Capabilities:    t.ClientCapabilities(),
+		ClientInfo: mcp.Implementation{
+			Name:    t.clientName,
+			Version: t.clientVersion,
+		},
+	}
+
+	var result mcp.InitializeResult
+	if err := s.request(ctx, "initialize", params, s.headers, &result); err != nil {
+		return err
+	}
+
+	// Protocol Version Check
+	if result.ProtocolVersion != t.protocolVersion {
+		return fmt.Errorf("MCP version mismatch: client (%s) != server (%s)", t.protocolVersion, result.ProtocolVersion)
+	}
+
+	// Capabilities Check
+	if result.Capabilities.Tools == nil {
+		return fmt.Errorf("server does not support the 'tools' capability")
+	}
+
+	t.mu.Lock()
+	t.ServerVersion = result.ServerInfo.Version
+	t.SetServerInfo(result.Capabilities.All, result.Instructions)
+	t.mu.Unlock()
+
+	// Confirm Handshake
+	return s.post(ctx, mcp.RPCNotification{
+		JSONRPC: "2.0",
+		Method:  "notifications/initialized",
+		Params:  map[string]any{},
+	}, s.headers)
+}
+
+// request sends a JSON-RPC request and waits for its response to arrive on
+// the event stream.
+func (s *session) request(ctx context.Context, method string, params any, headers map[string]string, dest any) error {
+	return s.rpc.Call(ctx, func(ctx context.Context, msg any) error {
+		return s.post(ctx, msg, headers)
+	}, method, params, dest)
+}
+
+// post sends a single JSON-RPC message to the messages endpoint. Responses
+// are delivered on the event stream, so the response body is discarded.
+func (s *session) post(ctx context.Context, msg any, headers map[string]string) error {
+	if err := s.rpc.Err(); err != nil {
+		return err
+	}
+
+	payload, err := json.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("marshal failed: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, s.endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("create request failed: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	for k, v := range headers {
+		httpReq.Header.Set(k, v)
+	}
+
+	resp, err := s.transport.Do(httpReq)
+	if err != nil {
+		return fmt.Errorf("http request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusAccepted, http.StatusNoContent:
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return nil
+	default:
+		body, _ := io.ReadAll(resp.Body)
+		return transport.NewHTTPResponseError(resp, body)
+	}
+}
+
+// readLoop reads events until the stream ends, resolving the messages
+// endpoint and dispatching responses to the requests waiting for them. A
+// stream that breaks is resumed if the server supports it, in which case
+// pending requests keep waiting for their responses.
+func (s *session) readLoop(resp *http.Response) {
+	defer resp.Body.Close()
+
+	err := s.transport.ReadResumableSSEEvents(resp.Request.Context(), resp, func(event mcp.SSEEvent) bool {
+		switch event.Event {
+		case "endpoint":
+			endpoint, err := resp.Request.URL.Parse(event.Data)
+			if err != nil {
+				return true
+			}
+			select {
+			case s.endpointCh <- endpoint.String():
+			default:
+			}
+		case "", "message":
+			s.rpc.HandleMessage([]byte(event.Data))
+		}
+		return true
+	})
+	if err == nil || errors.Is(err, ErrClosed) {
+		s.rpc.Close(ErrClosed)
+		return
+	}
+	s.rpc.Close(fmt.Errorf("%w: %v", ErrClosed, err))
+}
+
+// isDone reports whether the event stream has ended.
+func (s *session) isDone() bool {
+	select {
+	case <-s.rpc.Done():
+		return true
+	default:
+		return false
+	}
+}
+
+// close ends the event stream and waits for the read loop to stop.
+func (s *session) close() {
+	s.cancel()
+	<-s.rpc.Done()
+}
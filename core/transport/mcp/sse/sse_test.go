@@ -0,0 +1,350 @@
+//go:build unit
+
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sse
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/googleapis/mcp-toolbox-sdk-go/core/transport"
+	"github.com/googleapis/mcp-toolbox-sdk-go/core/transport/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockSSEServer is a minimal MCP server speaking the HTTP+SSE transport.
+type mockSSEServer struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	streams  map[string]chan []byte
+	paths    []string
+	headers  []http.Header
+	handlers map[string]func(params json.RawMessage) (any, error)
+	nextID   int
+
+	// resumable makes the server tag its events with IDs and resume
+	// streams from a Last-Event-ID.
+	resumable    bool
+	lastEventIDs []string
+	// drop ends the event stream that receives from it.
+	drop chan struct{}
+}
+
+func newMockSSEServer(t *testing.T) *mockSSEServer {
+	m := &mockSSEServer{
+		streams:  make(map[string]chan []byte),
+		handlers: make(map[string]func(json.RawMessage) (any, error)),
+		drop:     make(chan struct{}),
+	}
+	m.handlers["initialize"] = func(json.RawMessage) (any, error) {
+		return map[string]any{
+			"protocolVersion": ProtocolVersion,
+			"capabilities":    map[string]any{"tools": map[string]any{}},
+			"serverInfo":      map[string]any{"name": "mock", "version": "1.2.3"},
+		}, nil
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /", func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.URL.Path, "/sse") {
+			http.NotFound(w, r)
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		m.mu.Lock()
+		sessionID, eventID := "", 0
+		if lastEventID := r.Header.Get("Last-Event-ID"); lastEventID != "" {
+			// Event IDs have the form "<session>-<event>".
+			m.lastEventIDs = append(m.lastEventIDs, lastEventID)
+			id, n, _ := strings.Cut(lastEventID, "-")
+			sessionID = id
+			fmt.Sscan(n, &eventID)
+		} else {
+			m.nextID++
+			sessionID = fmt.Sprint(m.nextID)
+			m.streams[sessionID] = make(chan []byte, 16)
+			m.paths = append(m.paths, r.URL.Path)
+			m.headers = append(m.headers, r.Header.Clone())
+			fmt.Fprintf(w, "event: endpoint\ndata: /messages?session=%s\n\n", sessionID)
+		}
+		stream := m.streams[sessionID]
+		m.mu.Unlock()
+		w.(http.Flusher).Flush()
+
+		for {
+			select {
+			case msg := <-stream:
+				if m.resumable {
+					eventID++
+					fmt.Fprintf(w, "id: %s-%d\n", sessionID, eventID)
+				}
+				fmt.Fprintf(w, "event: message\ndata: %s\n\n", msg)
+				w.(http.Flusher).Flush()
+			case <-m.drop:
+				return
+			case <-r.Context().Done():
+				return
+			}
+		}
+	})
+	mux.HandleFunc("POST /messages", func(w http.ResponseWriter, r *http.Request) {
+		m.mu.Lock()
+		stream, ok := m.streams[r.URL.Query().Get("session")]
+		m.mu.Unlock()
+		if !ok {
+			http.Error(w, "unknown session", http.StatusNotFound)
+			return
+		}
+
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		var req struct {
+			ID     json.RawMessage `json:"id"`
+			Method string          `json:"method"`
+			Params json.RawMessage `json:"params"`
+		}
+		require.NoError(t, json.Unmarshal(body, &req))
+		w.WriteHeader(http.StatusAccepted)
+
+		if len(req.ID) == 0 || req.Method == "" {
+			return
+		}
+		resp := map[string]any{"jsonrpc": "2.0", "id": req.ID}
+		handler, ok := m.handlers[req.Method]
+		if !ok {
+			resp["error"] = map[string]any{"code": -32601, "message": "method not found"}
+		} else if result, err := handler(req.Params); err != nil {
+			resp["error"] = map[string]any{"code": -32000, "message": err.Error()}
+		} else {
+			resp["result"] = result
+		}
+		payload, _ := json.Marshal(resp)
+		stream <- payload
+	})
+
+	m.Server = httptest.NewServer(mux)
+	t.Cleanup(m.Close)
+	return m
+}
+
+func newTestTransport(t *testing.T, server *mockSSEServer) *McpTransport {
+	t.Helper()
+	tr, err := New(server.URL, server.Client(), "test-client", "1.0.0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = tr.Close(context.Background()) })
+	return tr
+}
+
+func TestListTools(t *testing.T) {
+	server := newMockSSEServer(t)
+	server.handlers["tools/list"] = func(json.RawMessage) (any, error) {
+		return map[string]any{"tools": []map[string]any{{
+			"name":        "echo",
+			"description": "Echo the input",
+			"inputSchema": map[string]any{
+				"type":       "object",
+				"properties": map[string]any{"text": map[string]any{"type": "string"}},
+			},
+		}}}, nil
+	}
+	tr := newTestTransport(t, server)
+
+	manifest, err := tr.ListTools(context.Background(), "", map[string]string{"X-Test": "1"})
+	require.NoError(t, err)
+	assert.Equal(t, "1.2.3", manifest.ServerVersion)
+	require.Contains(t, manifest.Tools, "echo")
+	assert.Equal(t, "Echo the input", manifest.Tools["echo"].Description)
+
+	// The toolset gets its own stream; the default one is reused.
+	_, err = tr.ListTools(context.Background(), "my-toolset", nil)
+	require.NoError(t, err)
+	_, err = tr.GetTool(context.Background(), "echo", nil)
+	require.NoError(t, err)
+
+	server.mu.Lock()
+	defer server.mu.Unlock()
+	assert.Equal(t, []string{"/mcp/sse", "/mcp/my-toolset/sse"}, server.paths)
+	assert.Equal(t, "1", server.headers[0].Get("X-Test"))
+	assert.Equal(t, "text/event-stream", server.headers[0].Get("Accept"))
+}
+
+func TestInvokeTool(t *testing.T) {
+	server := newMockSSEServer(t)
+	server.handlers["tools/call"] = func(params json.RawMessage) (any, error) {
+		var p mcp.CallToolParams
+		_ = json.Unmarshal(params, &p)
+		if p.Name == "fail" {
+			return map[string]any{"content": []map[string]any{{"type": "text", "text": "boom"}}, "isError": true}, nil
+		}
+		return map[string]any{"content": []map[string]any{{"type": "text", "text": fmt.Sprint(p.Arguments["text"])}}}, nil
+	}
+	tr := newTestTransport(t, server)
+
+	t.Run("Returns the result", func(t *testing.T) {
+		result, err := tr.InvokeTool(context.Background(), "echo", map[string]any{"text": "hello"}, nil)
+		require.NoError(t, err)
+		assert.Equal(t, "hello", result)
+	})
+
+	t.Run("Surfaces tool errors", func(t *testing.T) {
+		_, err := tr.InvokeTool(context.Background(), "fail", nil, nil)
+		var invocationErr *transport.ToolInvocationError
+		require.True(t, errors.As(err, &invocationErr))
+		assert.Equal(t, "boom", invocationErr.Message)
+	})
+
+	t.Run("Surfaces RPC errors", func(t *testing.T) {
+		delete(server.handlers, "tools/call")
+		_, err := tr.InvokeTool(context.Background(), "echo", nil, nil)
+		var invocationErr *transport.ToolInvocationError
+		require.True(t, errors.As(err, &invocationErr))
+		assert.Equal(t, -32601, invocationErr.Code)
+	})
+}
+
+func TestNotifications(t *testing.T) {
+	server := newMockSSEServer(t)
+	server.handlers["tools/list"] = func(json.RawMessage) (any, error) {
+		return map[string]any{"tools": []any{}}, nil
+	}
+	tr := newTestTransport(t, server)
+	received := make(chan transport.Notification, 1)
+	tr.SetNotificationHandler(func(n transport.Notification) { received <- n })
+
+	_, err := tr.ListTools(context.Background(), "", nil)
+	require.NoError(t, err)
+
+	server.mu.Lock()
+	stream := server.streams["1"]
+	server.mu.Unlock()
+	stream <- []byte(`{"jsonrpc":"2.0","method":"notifications/tools/list_changed"}`)
+
+	select {
+	case n := <-received:
+		assert.Equal(t, "notifications/tools/list_changed", n.Method)
+	case <-time.After(time.Second):
+		t.Fatal("Expected the notification to be delivered")
+	}
+}
+
+func TestInitialize_Errors(t *testing.T) {
+	t.Run("Protocol mismatch", func(t *testing.T) {
+		server := newMockSSEServer(t)
+		server.handlers["initialize"] = func(json.RawMessage) (any, error) {
+			return map[string]any{"protocolVersion": "2099-01-01", "capabilities": map[string]any{"tools": map[string]any{}}}, nil
+		}
+		tr := newTestTransport(t, server)
+
+		_, err := tr.ListTools(context.Background(), "", nil)
+		assert.ErrorContains(t, err, "MCP version mismatch")
+	})
+
+	t.Run("Stream endpoint returns an error", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "nope", http.StatusUnauthorized)
+		}))
+		defer server.Close()
+		tr, err := New(server.URL, nil, "c", "v")
+		require.NoError(t, err)
+
+		_, err = tr.ListTools(context.Background(), "", nil)
+		var httpErr *transport.ToolInvocationError
+		require.True(t, errors.As(err, &httpErr))
+		assert.Equal(t, http.StatusUnauthorized, httpErr.StatusCode)
+	})
+
+	t.Run("Stream ends before the endpoint event", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "text/event-stream")
+		}))
+		defer server.Close()
+		tr, err := New(server.URL, nil, "c", "v")
+		require.NoError(t, err)
+
+		_, err = tr.ListTools(context.Background(), "", nil)
+		assert.ErrorContains(t, err, "before the messages endpoint")
+	})
+
+	t.Run("Context is cancelled while connecting", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "text/event-stream")
+			w.(http.Flusher).Flush()
+			<-r.Context().Done()
+		}))
+		defer server.Close()
+		tr, err := New(server.URL, nil, "c", "v")
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		_, err = tr.ListTools(ctx, "", nil)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+}
+
+func TestClose(t *testing.T) {
+	server := newMockSSEServer(t)
+	server.handlers["tools/list"] = func(json.RawMessage) (any, error) {
+		return map[string]any{"tools": []any{}}, nil
+	}
+	tr := newTestTransport(t, server)
+
+	_, err := tr.ListTools(context.Background(), "", nil)
+	require.NoError(t, err)
+
+	require.NoError(t, tr.Close(context.Background()))
+	require.NoError(t, tr.Close(context.Background()))
+
+	_, err = tr.ListTools(context.Background(), "", nil)
+	assert.ErrorIs(t, err, ErrClosed)
+}
+
+func TestResumeStream(t *testing.T) {
+	server := newMockSSEServer(t)
+	server.resumable = true
+	server.handlers["tools/call"] = func(json.RawMessage) (any, error) {
+		return map[string]any{"content": []map[string]any{{"type": "text", "text": "done"}}}, nil
+	}
+	tr := newTestTransport(t, server)
+	ctx := context.Background()
+
+	_, err := tr.InvokeTool(ctx, "echo", nil, map[string]string{"Authorization": "Bearer token"})
+	require.NoError(t, err)
+
+	// The stream breaks, and the response to the next request is delivered
+	// once it is resumed.
+	server.drop <- struct{}{}
+	result, err := tr.InvokeTool(ctx, "echo", nil, map[string]string{"Authorization": "Bearer token"})
+	require.NoError(t, err)
+	assert.Equal(t, "done", result)
+
+	server.mu.Lock()
+	defer server.mu.Unlock()
+	assert.Len(t, server.paths, 1, "Expected the session to be kept")
+	assert.Equal(t, []string{"1-2"}, server.lastEventIDs, "Expected the stream to resume after the initialize and tools/call responses")
+}
@@ -0,0 +1,221 @@
+//go:build unit
+
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mcp
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/googleapis/mcp-toolbox-sdk-go/core/transport"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadSSEEvents(t *testing.T) {
+	t.Run("Parses events", func(t *testing.T) {
+		stream := ": keep-alive\n" +
+			"event: endpoint\n" +
+			"data: /messages?session=1\n" +
+			"\n" +
+			"id: 7\r\n" +
+			"data: {\"a\":\r\n" +
+			"data:1}\r\n" +
+			"\r\n" +
+			"data: unterminated"
+
+		var events []SSEEvent
+		err := ReadSSEEvents(strings.NewReader(stream), func(e SSEEvent) bool {
+			events = append(events, e)
+			return true
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []SSEEvent{
+			{Event: "endpoint", Data: "/messages?session=1"},
+			{ID: "7", Data: "{\"a\":\n1}"},
+		}, events)
+	})
+
+	t.Run("Stops when the handler returns false", func(t *testing.T) {
+		stream := "data: 1\n\ndata: 2\n\n"
+
+		var count int
+		err := ReadSSEEvents(strings.NewReader(stream), func(SSEEvent) bool {
+			count++
+			return false
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 1, count)
+	})
+
+	t.Run("Skips events without data", func(t *testing.T) {
+		stream := "event: empty\n\nid: 3\n\n"
+
+		err := ReadSSEEvents(strings.NewReader(stream), func(SSEEvent) bool {
+			t.Error("Expected no events")
+			return true
+		})
+		require.NoError(t, err)
+	})
+}
+
+func TestReadResponseBody(t *testing.T) {
+	// Replies to server requests are posted to the endpoint of the request.
+	var replies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		replies = append(replies, r.Header.Get("Authorization")+" "+string(body))
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	newResponse := func(contentType, body string) *http.Response {
+		req, _ := http.NewRequest(http.MethodPost, server.URL, nil)
+		req.Header.Set("Authorization", "Bearer token")
+		return &http.Response{
+			Header:  http.Header{"Content-Type": {contentType}},
+			Body:    io.NopCloser(strings.NewReader(body)),
+			Request: req,
+		}
+	}
+	b := &BaseMcpTransport{HTTPClient: server.Client()}
+
+	t.Run("Reads a JSON body", func(t *testing.T) {
+		body, err := b.ReadResponseBody(context.Background(), newResponse("application/json", `{"id":1}`))
+		require.NoError(t, err)
+		assert.Equal(t, `{"id":1}`, string(body))
+	})
+
+	t.Run("Reads the response from an event stream", func(t *testing.T) {
+		stream := "data: {\"method\":\"notifications/message\",\"params\":{\"level\":\"info\"}}\n\n" +
+			"data: {\"id\":9,\"method\":\"ping\"}\n\n" +
+			"event: message\ndata: {\"id\":1,\"result\":{}}\n\n" +
+			"data: {\"method\":\"ignored\"}\n\n"
+
+		var notifications []transport.Notification
+		ctx := transport.WithNotificationHandler(context.Background(), func(n transport.Notification) {
+			notifications = append(notifications, n)
+		})
+
+		body, err := b.ReadResponseBody(ctx, newResponse("text/event-stream; charset=utf-8", stream))
+		require.NoError(t, err)
+		assert.Equal(t, `{"id":1,"result":{}}`, string(body))
+		require.Len(t, notifications, 1)
+		assert.Equal(t, "notifications/message", notifications[0].Method)
+		assert.JSONEq(t, `{"level":"info"}`, string(notifications[0].Params))
+		assert.Equal(t, []string{`Bearer token {"jsonrpc":"2.0","id":9,"result":{}}`}, replies)
+	})
+
+	t.Run("Negative Test - Reply cannot be delivered", func(t *testing.T) {
+		resp := newResponse("text/event-stream", "data: {\"id\":9,\"method\":\"ping\"}\n\n")
+		failing := &BaseMcpTransport{HTTPClient: &http.Client{Transport: roundTripFunc(func(*http.Request) (*http.Response, error) {
+			return &http.Response{StatusCode: http.StatusNotFound, Body: io.NopCloser(strings.NewReader("gone"))}, nil
+		})}}
+		_, err := failing.ReadResponseBody(context.Background(), resp)
+		assert.ErrorContains(t, err, "failed to answer server request 'ping'")
+	})
+
+	t.Run("Negative Test - Stream ends without a response", func(t *testing.T) {
+		_, err := b.ReadResponseBody(context.Background(), newResponse("text/event-stream", "data: {\"method\":\"x\"}\n\n"))
+		assert.ErrorContains(t, err, "event stream ended without a response")
+	})
+
+	t.Run("Negative Test - Message exceeds the size limit", func(t *testing.T) {
+		ctx := transport.WithMaxResponseBytes(context.Background(), 5)
+		_, err := b.ReadResponseBody(ctx, newResponse("text/event-stream", "data: {\"id\":1,\"result\":{}}\n\n"))
+		var tooLarge *transport.ResponseTooLargeError
+		assert.ErrorAs(t, err, &tooLarge)
+	})
+}
+
+func TestReadResponseBody_ResumesStream(t *testing.T) {
+	oldDelay := streamResumeDelay
+	streamResumeDelay = 0
+	t.Cleanup(func() { streamResumeDelay = oldDelay })
+
+	// The server breaks the stream of the POST after an event, and resumes it
+	// with the response on a GET.
+	var resumes []http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		if r.Method == http.MethodPost {
+			io.WriteString(w, "id: 1\ndata: {\"method\":\"notifications/progress\",\"params\":{}}\n\ndata: {\"id\":1,")
+			return
+		}
+		resumes = append(resumes, r.Header.Clone())
+		if len(resumes) == 1 {
+			// A resumption that yields no new events is retried.
+			return
+		}
+		io.WriteString(w, "id: 2\ndata: {\"id\":1,\"result\":{}}\n\n")
+	}))
+	defer server.Close()
+	b := &BaseMcpTransport{HTTPClient: server.Client()}
+
+	post := func(t *testing.T) *http.Response {
+		req, _ := http.NewRequest(http.MethodPost, server.URL, strings.NewReader("{}"))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Mcp-Session-Id", "abc")
+		resp, err := server.Client().Do(req)
+		require.NoError(t, err)
+		t.Cleanup(func() { resp.Body.Close() })
+		return resp
+	}
+
+	var notifications int
+	ctx := transport.WithNotificationHandler(context.Background(), func(transport.Notification) { notifications++ })
+	body, err := b.ReadResponseBody(ctx, post(t))
+	require.NoError(t, err)
+	assert.Equal(t, `{"id":1,"result":{}}`, string(body))
+	assert.Equal(t, 1, notifications)
+	require.Len(t, resumes, 2)
+	for _, h := range resumes {
+		assert.Equal(t, "1", h.Get("Last-Event-ID"))
+		assert.Equal(t, "abc", h.Get("Mcp-Session-Id"))
+		assert.Equal(t, "text/event-stream", h.Get("Accept"))
+		assert.Empty(t, h.Get("Content-Type"))
+	}
+
+	t.Run("Negative Test - Server cannot resume", func(t *testing.T) {
+		unresumable := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodPost {
+				http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+				return
+			}
+			w.Header().Set("Content-Type", "text/event-stream")
+			io.WriteString(w, "id: 1\ndata: {\"method\":\"x\"}\n\n")
+		}))
+		defer unresumable.Close()
+
+		resp, err := unresumable.Client().Post(unresumable.URL, "application/json", strings.NewReader("{}"))
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		_, err = (&BaseMcpTransport{HTTPClient: unresumable.Client()}).ReadResponseBody(context.Background(), resp)
+		assert.ErrorContains(t, err, "failed to resume event stream")
+		assert.ErrorContains(t, err, "405")
+	})
+}
+
+// roundTripFunc adapts a function to an http.RoundTripper.
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
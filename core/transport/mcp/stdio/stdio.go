@@ -0,0 +1,378 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package stdio implements the MCP stdio transport, which spawns a local MCP
+// server as a subprocess and exchanges newline-delimited JSON-RPC messages
+// with it over stdin and stdout.
+package stdio
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"sync"
+	"time"
+
+	"github.com/googleapis/mcp-toolbox-sdk-go/core/transport"
+	"github.com/googleapis/mcp-toolbox-sdk-go/core/transport/mcp"
+)
+
+const (
+	ProtocolVersion = "2025-06-18"
+
+	// shutdownTimeout is how long Close waits for the server to exit after
+	// its stdin is closed before killing it.
+	shutdownTimeout = 5 * time.Second
+)
+
+// ErrClosed is returned for requests made after the transport was closed or
+// the server process exited.
+var ErrClosed = errors.New("stdio transport is closed")
+
+// Ensure that McpTransport implements the Transport interface and the
+// optional interfaces for MCP server features.
+var (
+	_ transport.Transport        = &McpTransport{}
+	_ transport.PromptSource     = &McpTransport{}
+	_ transport.ResourceSource   = &McpTransport{}
+	_ transport.Completer        = &McpTransport{}
+	_ transport.Observable       = &McpTransport{}
+	_ transport.RequestReceiver  = &McpTransport{}
+	_ transport.ServerInfoSource = &McpTransport{}
+	_ transport.RequestTimeouter = &McpTransport{}
+)
+
+// McpTransport speaks MCP over the stdin and stdout of a subprocess.
+//
+// HTTP headers, including auth token headers, have no equivalent over stdio
+// and are ignored; local servers are expected to take credentials from their
+// environment or configuration.
+type McpTransport struct {
+	*mcp.BaseMcpTransport
+	protocolVersion string
+	clientName      string
+	clientVersion   string
+
+	cmd    *exec.Cmd
+	stdin  io.WriteCloser
+	stdout io.ReadCloser
+
+	writeMu sync.Mutex
+
+	// rpc is closed once the read loop stops.
+	rpc *mcp.Conn
+
+	closeOnce sync.Once
+	closeErr  error
+}
+
+// New starts the server process described by cmd and returns a transport
+// connected to it. The command's Stdin and Stdout must not be set; its
+// Stderr, Env and Dir are used as configured, so callers can pass flags,
+// credentials and a log destination to the server.
+//
+// Inputs:
+//   - cmd: The server command, e.g. exec.Command("toolbox", "--stdio").
+//   - clientName: The client name sent in the handshake.
+//   - clientVersion: The client version sent in the handshake. Defaults to
+//     the SDK version.
+//
+// Returns:
+//
+//	A running transport, or an error if the process could not be started.
+func New(cmd *exec.Cmd, clientName string, clientVersion string, opts ...mcp.Option) (*McpTransport, error) {
+	if cmd == nil {
+		return nil, fmt.Errorf("stdio transport requires a command")
+	}
+	if cmd.Stdin != nil || cmd.Stdout != nil {
+		return nil, fmt.Errorf("stdio transport requires a command without Stdin or Stdout")
+	}
+	if clientVersion == "" {
+		clientVersion = mcp.SDKVersion
+	}
+
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open server stdin: %w", err)
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open server stdout: %w", err)
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("failed to start server process: %w", err)
+	}
+
+	t := &McpTransport{
+		BaseMcpTransport: &mcp.BaseMcpTransport{},
+		protocolVersion:  ProtocolVersion,
+		clientName:       clientName,
+		clientVersion:    clientVersion,
+		cmd:              cmd,
+		stdin:            stdin,
+		stdout:           stdout,
+	}
+	t.rpc = mcp.NewConn(t.BaseMcpTransport, t.writeMessage)
+	t.HandshakeHook = t.initializeSession
+	t.RequestHook = t.request
+
+	go t.readLoop()
+
+	t.ApplyOptions(opts)
+	return t, nil
+}
+
+// BaseURL identifies the server command, since there is no URL over stdio.
+func (t *McpTransport) BaseURL() string {
+	return "stdio://" + t.cmd.Path
+}
+
+// Close shuts the server down by closing its stdin, killing it if it does
+// not exit within a few seconds or before ctx is done. It is safe to call
+// Close more than once.
+func (t *McpTransport) Close(ctx context.Context) error {
+	t.closeOnce.Do(func() {
+		_ = t.stdin.Close()
+
+		// The server's output is read to the end before waiting for it,
+		// since Wait closes the stdout pipe.
+		killCtx, cancel := context.WithTimeout(ctx, shutdownTimeout)
+		defer cancel()
+		select {
+		case <-t.rpc.Done():
+		case <-killCtx.Done():
+			_ = t.cmd.Process.Kill()
+			// Processes started by the server may hold its stdout open.
+			_ = t.stdout.Close()
+			<-t.rpc.Done()
+		}
+
+		err := t.cmd.Wait()
+		var exitErr *exec.ExitError
+		if err != nil && !errors.As(err, &exitErr) {
+			t.closeErr = err
+		}
+	})
+	return t.closeErr
+}
+
+// ListTools fetches available tools. Toolsets are selected when starting the
+// server, so toolsetName must be empty.
+func (t *McpTransport) ListTools(ctx context.Context, toolsetName string, headers map[string]string) (*transport.ManifestSchema, error) {
+	if toolsetName != "" {
+		return nil, fmt.Errorf("toolset '%s' cannot be selected over stdio; configure the toolset when starting the server", toolsetName)
+	}
+	if err := t.EnsureInitialized(ctx, headers); err != nil {
+		return nil, err
+	}
+
+	var result mcp.ListToolsResult
+	if err := t.sendRequest(ctx, "tools/list", map[string]any{}, &result); err != nil {
+		return nil, fmt.Errorf("failed to list tools: %w", err)
+	}
+
+	manifest := &transport.ManifestSchema{
+		ServerVersion: t.ServerVersion,
+		Tools:         make(map[string]transport.ToolSchema),
+	}
+
+	for i, tool := range result.Tools {
+		if tool.Name == "" {
+			return nil, fmt.Errorf("received invalid tool definition at index %d: missing 'name' field", i)
+		}
+
+		rawTool := map[string]any{
+			"name":        tool.Name,
+			"description": tool.Description,
+			"inputSchema": tool.InputSchema,
+		}
+		if tool.Title != "" {
+			rawTool["title"] = tool.Title
+		}
+		if tool.Annotations != nil {
+			rawTool["annotations"] = tool.Annotations
+		}
+		if tool.OutputSchema != nil {
+			rawTool["outputSchema"] = tool.OutputSchema
+		}
+		if tool.Meta != nil {
+			rawTool["_meta"] = tool.Meta
+		}
+
+		toolSchema, err := t.ConvertToolDefinition(rawTool)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert schema for tool %s: %w", tool.Name, err)
+		}
+
+		manifest.Tools[tool.Name] = toolSchema
+	}
+
+	return manifest, nil
+}
+
+// GetTool fetches a single tool
+func (t *McpTransport) GetTool(ctx context.Context, toolName string, headers map[string]string) (*transport.ManifestSchema, error) {
+	manifest, err := t.ListTools(ctx, "", headers)
+	if err != nil {
+		return nil, err
+	}
+
+	tool, exists := manifest.Tools[toolName]
+	if !exists {
+		return nil, fmt.Errorf("tool '%s' not found", toolName)
+	}
+
+	return &transport.ManifestSchema{
+		ServerVersion: manifest.ServerVersion,
+		Tools:         map[string]transport.ToolSchema{toolName: tool},
+	}, nil
+}
+
+// InvokeTool executes a tool
+func (t *McpTransport) InvokeTool(ctx context.Context, toolName string, payload map[string]any, headers map[string]string) (any, error) {
+	if err := t.EnsureInitialized(ctx, headers); err != nil {
+		return "", err
+	}
+	meta, done := t.TrackProgress(ctx)
+	defer done()
+	params := mcp.CallToolParams{
+		Name:      toolName,
+		Arguments: payload,
+		Meta:      meta,
+	}
+
+	var result mcp.CallToolResult
+	if err := t.sendRequest(ctx, "tools/call", params, &result); err != nil {
+		return "", fmt.Errorf("failed to invoke tool '%s': %w", toolName, err)
+	}
+
+	if result.IsError {
+		// Surface the error text reported by the tool, if any.
+		var message string
+		for _, c := range result.Content {
+			if c.Type == "text" {
+				message += c.Text
+			}
+		}
+		return "", &transport.ToolInvocationError{Message: message}
+	}
+
+	// Tools with an output schema return their result as structured
+	// content, which is preserved rather than flattened into text.
+	if result.StructuredContent != nil {
+		return result.StructuredContent, nil
+	}
+
+	return t.ProcessToolResultContent(result.Content), nil
+}
+
+// initializeSession performs the initial handshake with the server.
+func (t *McpTransport) initializeSession(ctx context.Context, _ map[string]string) error {
+	params := mcp.InitializeParams{
+		ProtocolVersion: t.protocolVersion,
+		Capabilities:    t.ClientCapabilities(),
+		ClientInfo: mcp.Implementation{
+			Name:    t.clientName,
+			Title:   t.ClientTitle(),
+			Version: t.clientVersion,
+		},
+	}
+
+	var result mcp.InitializeResult
+	if err := t.sendRequest(ctx, "initialize", params, &result); err != nil {
+		return err
+	}
+
+	// Protocol Version Check
+	if result.ProtocolVersion != t.protocolVersion {
+		return fmt.Errorf("MCP version mismatch: client (%s) != server (%s)", t.protocolVersion, result.ProtocolVersion)
+	}
+
+	// Capabilities Check
+	if result.Capabilities.Tools == nil {
+		return fmt.Errorf("server does not support the 'tools' capability")
+	}
+
+	t.ServerVersion = result.ServerInfo.Version
+	t.SetServerInfo(result.Capabilities.All, result.Instructions)
+
+	// Confirm Handshake
+	return t.writeMessage(ctx, mcp.RPCNotification{
+		JSONRPC: "2.0",
+		Method:  "notifications/initialized",
+		Params:  map[string]any{},
+	})
+}
+
+// request sends a JSON-RPC request to the server, performing the handshake
+// first if needed.
+func (t *McpTransport) request(ctx context.Context, method string, params any, headers map[string]string, dest any) error {
+	if err := t.EnsureInitialized(ctx, headers); err != nil {
+		return err
+	}
+	return t.sendRequest(ctx, method, params, dest)
+}
+
+// sendRequest sends a JSON-RPC request and waits for the matching response.
+func (t *McpTransport) sendRequest(ctx context.Context, method string, params any, dest any) error {
+	return t.rpc.Call(ctx, t.writeMessage, method, params, dest)
+}
+
+// writeMessage writes a single newline-terminated JSON message to the server.
+func (t *McpTransport) writeMessage(_ context.Context, msg any) error {
+	payload, err := json.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("marshal failed: %w", err)
+	}
+	payload = append(payload, '\n')
+
+	if err := t.rpc.Err(); err != nil {
+		return err
+	}
+
+	t.writeMu.Lock()
+	defer t.writeMu.Unlock()
+	if _, err := t.stdin.Write(payload); err != nil {
+		return fmt.Errorf("failed to write to server: %w", err)
+	}
+	return nil
+}
+
+// readLoop reads messages from the server until its stdout is closed,
+// passing them to the JSON-RPC connection. Servers may log non-protocol
+// output to stdout, which is skipped.
+func (t *McpTransport) readLoop() {
+	reader := bufio.NewReader(t.stdout)
+	var err error
+	for {
+		var line []byte
+		line, err = reader.ReadBytes('\n')
+		if line = bytes.TrimSpace(line); len(line) > 0 {
+			t.rpc.HandleMessage(line)
+		}
+		if err != nil {
+			break
+		}
+	}
+	if errors.Is(err, io.EOF) {
+		t.rpc.Close(ErrClosed)
+		return
+	}
+	t.rpc.Close(fmt.Errorf("%w: %v", ErrClosed, err))
+}
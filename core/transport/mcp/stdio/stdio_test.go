@@ -0,0 +1,265 @@
+//go:build unit
+
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stdio
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"testing"
+	"time"
+
+	"github.com/googleapis/mcp-toolbox-sdk-go/core/transport"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestHelperProcess is not a real test. It acts as a minimal MCP server when
+// the test binary is re-executed by helperCommand.
+func TestHelperProcess(t *testing.T) {
+	if os.Getenv("GO_WANT_HELPER_PROCESS") != "1" {
+		return
+	}
+	runFakeServer(os.Getenv("HELPER_MODE"))
+	os.Exit(0)
+}
+
+// helperCommand returns a command that runs the fake server in the given mode.
+func helperCommand(mode string) *exec.Cmd {
+	cmd := exec.Command(os.Args[0], "-test.run=TestHelperProcess")
+	cmd.Env = append(os.Environ(), "GO_WANT_HELPER_PROCESS=1", "HELPER_MODE="+mode)
+	return cmd
+}
+
+func runFakeServer(mode string) {
+	out := json.NewEncoder(os.Stdout)
+	// Non-protocol output on stdout must be skipped by the client.
+	fmt.Println("fake server starting")
+
+	scanner := bufio.NewScanner(os.Stdin)
+	for scanner.Scan() {
+		var req struct {
+			ID     json.RawMessage `json:"id"`
+			Method string          `json:"method"`
+			Params json.RawMessage `json:"params"`
+		}
+		if err := json.Unmarshal(scanner.Bytes(), &req); err != nil || len(req.ID) == 0 {
+			continue
+		}
+
+		var result any
+		switch req.Method {
+		case "initialize":
+			result = map[string]any{
+				"protocolVersion": ProtocolVersion,
+				"capabilities":    map[string]any{"tools": map[string]any{}},
+				"serverInfo":      map[string]any{"name": "fake", "version": "9.9.9"},
+			}
+		case "tools/list":
+			result = map[string]any{"tools": []map[string]any{{
+				"name":        "echo",
+				"description": "Echo the input",
+				"inputSchema": map[string]any{
+					"type":       "object",
+					"properties": map[string]any{"text": map[string]any{"type": "string"}},
+				},
+			}}}
+		case "tools/call":
+			if mode == "crash" {
+				os.Exit(3)
+			}
+			var params struct {
+				Name      string         `json:"name"`
+				Arguments map[string]any `json:"arguments"`
+			}
+			_ = json.Unmarshal(req.Params, &params)
+			if params.Name == "fail" {
+				result = map[string]any{"content": []map[string]any{{"type": "text", "text": "boom"}}, "isError": true}
+			} else if params.Name == "sample" {
+				// Ask the client to sample an LLM and return its answer.
+				_ = out.Encode(map[string]any{"jsonrpc": "2.0", "id": "s1", "method": "sampling/createMessage", "params": map[string]any{"maxTokens": 10}})
+				scanner.Scan()
+				result = map[string]any{"content": []map[string]any{{"type": "text", "text": scanner.Text()}}}
+			} else if params.Name == "slow" {
+				// Never answer, but echo the cancellation back as a notification.
+				scanner.Scan()
+				_ = out.Encode(map[string]any{"jsonrpc": "2.0", "method": "test/received", "params": json.RawMessage(scanner.Bytes())})
+				continue
+			} else if params.Name == "notify" {
+				_ = out.Encode(map[string]any{"jsonrpc": "2.0", "method": "notifications/tools/list_changed"})
+				result = map[string]any{"content": []map[string]any{}}
+			} else {
+				result = map[string]any{"content": []map[string]any{{"type": "text", "text": fmt.Sprint(params.Arguments["text"])}}}
+			}
+		default:
+			_ = out.Encode(map[string]any{"jsonrpc": "2.0", "id": req.ID, "error": map[string]any{"code": -32601, "message": "method not found"}})
+			continue
+		}
+		_ = out.Encode(map[string]any{"jsonrpc": "2.0", "id": req.ID, "result": result})
+	}
+	if mode == "stubborn" {
+		// Ignore the closed stdin and keep running.
+		time.Sleep(time.Hour)
+	}
+}
+
+func newTestTransport(t *testing.T, mode string) *McpTransport {
+	t.Helper()
+	tr, err := New(helperCommand(mode), "test-client", "1.0.0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = tr.Close(context.Background()) })
+	return tr
+}
+
+func TestStdioTransport(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Answers server requests", func(t *testing.T) {
+		tr := newTestTransport(t, "")
+		tr.SetRequestHandler("sampling/createMessage", func(_ context.Context, params json.RawMessage) (any, error) {
+			return map[string]any{"model": "test-model", "params": params}, nil
+		})
+
+		result, err := tr.InvokeTool(ctx, "sample", nil, nil)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"jsonrpc":"2.0","id":"s1","result":{"model":"test-model","params":{"maxTokens":10}}}`, result.(string))
+	})
+
+	t.Run("Notifies the server of cancelled requests", func(t *testing.T) {
+		tr := newTestTransport(t, "")
+		received := make(chan transport.Notification, 1)
+		tr.SetNotificationHandler(func(n transport.Notification) { received <- n })
+
+		cancelCtx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+		defer cancel()
+		_, err := tr.InvokeTool(cancelCtx, "slow", nil, nil)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		select {
+		case n := <-received:
+			assert.JSONEq(t, `{"jsonrpc":"2.0","method":"notifications/cancelled","params":{"requestId":2,"reason":"context deadline exceeded"}}`, string(n.Params))
+		case <-time.After(time.Second):
+			t.Fatal("Expected the server to receive the cancellation")
+		}
+	})
+
+	t.Run("Delivers server notifications", func(t *testing.T) {
+		tr := newTestTransport(t, "")
+		received := make(chan transport.Notification, 1)
+		tr.SetNotificationHandler(func(n transport.Notification) { received <- n })
+
+		_, err := tr.InvokeTool(ctx, "notify", nil, nil)
+		require.NoError(t, err)
+		select {
+		case n := <-received:
+			assert.Equal(t, "notifications/tools/list_changed", n.Method)
+		case <-time.After(time.Second):
+			t.Fatal("Expected the notification to be delivered")
+		}
+	})
+
+	t.Run("Lists and invokes tools", func(t *testing.T) {
+		tr := newTestTransport(t, "")
+
+		manifest, err := tr.ListTools(ctx, "", nil)
+		require.NoError(t, err)
+		assert.Equal(t, "9.9.9", manifest.ServerVersion)
+		require.Contains(t, manifest.Tools, "echo")
+		assert.Equal(t, "Echo the input", manifest.Tools["echo"].Description)
+
+		result, err := tr.InvokeTool(ctx, "echo", map[string]any{"text": "hello"}, map[string]string{"ignored": "header"})
+		require.NoError(t, err)
+		assert.Equal(t, "hello", result)
+
+		single, err := tr.GetTool(ctx, "echo", nil)
+		require.NoError(t, err)
+		assert.Len(t, single.Tools, 1)
+	})
+
+	t.Run("Surfaces tool errors", func(t *testing.T) {
+		tr := newTestTransport(t, "")
+
+		_, err := tr.InvokeTool(ctx, "fail", nil, nil)
+		var invocationErr *transport.ToolInvocationError
+		require.True(t, errors.As(err, &invocationErr))
+		assert.Equal(t, "boom", invocationErr.Message)
+	})
+
+	t.Run("Reports a server that exits", func(t *testing.T) {
+		tr := newTestTransport(t, "crash")
+
+		_, err := tr.InvokeTool(ctx, "echo", map[string]any{"text": "hello"}, nil)
+		assert.ErrorIs(t, err, ErrClosed)
+	})
+
+	t.Run("Rejects toolset names", func(t *testing.T) {
+		tr := newTestTransport(t, "")
+
+		_, err := tr.ListTools(ctx, "my-toolset", nil)
+		assert.ErrorContains(t, err, "cannot be selected over stdio")
+	})
+
+	t.Run("Fails requests after Close", func(t *testing.T) {
+		tr := newTestTransport(t, "")
+		require.NoError(t, tr.Close(context.Background()))
+		require.NoError(t, tr.Close(context.Background()))
+
+		ctx, cancel := context.WithTimeout(ctx, time.Second)
+		defer cancel()
+		_, err := tr.ListTools(ctx, "", nil)
+		assert.ErrorIs(t, err, ErrClosed)
+	})
+
+	t.Run("Shuts the server down cleanly", func(t *testing.T) {
+		tr := newTestTransport(t, "")
+		_, err := tr.ListTools(ctx, "", nil)
+		require.NoError(t, err)
+
+		require.NoError(t, tr.Close(context.Background()))
+		assert.Equal(t, ErrClosed, tr.rpc.Err(), "Expected the server output to be read to the end")
+		assert.True(t, tr.cmd.ProcessState.Success())
+	})
+
+	t.Run("Kills a server that does not exit", func(t *testing.T) {
+		tr := newTestTransport(t, "stubborn")
+		_, err := tr.ListTools(ctx, "", nil)
+		require.NoError(t, err)
+
+		closeCtx, cancel := context.WithTimeout(ctx, 100*time.Millisecond)
+		defer cancel()
+		start := time.Now()
+		require.NoError(t, tr.Close(closeCtx))
+		assert.Less(t, time.Since(start), shutdownTimeout)
+		assert.False(t, tr.cmd.ProcessState.Success())
+	})
+
+	t.Run("Rejects invalid commands", func(t *testing.T) {
+		_, err := New(nil, "c", "v")
+		assert.Error(t, err)
+
+		cmd := helperCommand("")
+		cmd.Stdout = os.Stdout
+		_, err = New(cmd, "c", "v")
+		assert.ErrorContains(t, err, "without Stdin or Stdout")
+
+		_, err = New(exec.Command("/nonexistent/mcp-server"), "c", "v")
+		assert.ErrorContains(t, err, "failed to start server process")
+	})
+}
@@ -0,0 +1,54 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mcp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// SetRequestTimeout bounds every request sent by the transport by timeout.
+// Values of 0 or less disable the timeout.
+func (b *BaseMcpTransport) SetRequestTimeout(timeout time.Duration) {
+	b.requestTimeout = timeout
+}
+
+// WithRequestTimeout derives the context of a single request with the given
+// method from ctx, bounded by the request timeout, if any. The caller's
+// deadline still applies if it is earlier.
+//
+// The returned function must be called with the error the request failed
+// with, if any, once it is done. It releases the context and returns the
+// error, annotated if the request ran out of time because of the request
+// timeout rather than ctx.
+func (b *BaseMcpTransport) WithRequestTimeout(ctx context.Context, method string) (context.Context, func(err error) error) {
+	timeout := b.requestTimeout
+	if timeout <= 0 {
+		return ctx, func(err error) error { return err }
+	}
+
+	errTimeout := fmt.Errorf("request '%s' timed out after %s: %w", method, timeout, context.DeadlineExceeded)
+	reqCtx, cancel := context.WithTimeoutCause(ctx, timeout, errTimeout)
+	return reqCtx, func(err error) error {
+		defer cancel()
+		// HTTP requests already fail with the cause of the context.
+		if err != nil && ctx.Err() == nil && context.Cause(reqCtx) == errTimeout && !errors.Is(err, errTimeout) {
+			return fmt.Errorf("request '%s' timed out after %s: %w", method, timeout, err)
+		}
+		return err
+	}
+}
@@ -0,0 +1,95 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mcp
+
+import (
+	"encoding/json"
+
+	"github.com/googleapis/mcp-toolbox-sdk-go/core/transport"
+)
+
+// The types below are the messages shared by the transports that carry
+// JSON-RPC over a single connection: stdio, HTTP+SSE and WebSocket.
+
+// Implementation describes the name and version of the client or server.
+type Implementation struct {
+	Name    string `json:"name"`
+	Title   string `json:"title,omitempty"`
+	Version string `json:"version"`
+}
+
+// ServerCapabilities describes the features supported by the server.
+type ServerCapabilities struct {
+	Prompts map[string]any `json:"prompts,omitempty"`
+	Tools   map[string]any `json:"tools,omitempty"`
+
+	// All holds every declared capability, including those not listed
+	// above.
+	All map[string]any `json:"-"`
+}
+
+// UnmarshalJSON decodes the capabilities, keeping all of them in All.
+func (c *ServerCapabilities) UnmarshalJSON(data []byte) error {
+	type plain ServerCapabilities
+	if err := json.Unmarshal(data, (*plain)(c)); err != nil {
+		return err
+	}
+	return json.Unmarshal(data, &c.All)
+}
+
+// InitializeParams holds the parameters for the 'initialize' handshake.
+type InitializeParams struct {
+	ProtocolVersion string         `json:"protocolVersion"`
+	Capabilities    map[string]any `json:"capabilities"`
+	ClientInfo      Implementation `json:"clientInfo"`
+}
+
+// InitializeResult holds the response from the 'initialize' handshake.
+type InitializeResult struct {
+	ProtocolVersion string             `json:"protocolVersion"`
+	Capabilities    ServerCapabilities `json:"capabilities"`
+	ServerInfo      Implementation     `json:"serverInfo"`
+	Instructions    string             `json:"instructions,omitempty"`
+}
+
+// Tool represents a single tool definition from the server.
+type Tool struct {
+	Name         string         `json:"name"`
+	Title        string         `json:"title,omitempty"`
+	Description  string         `json:"description,omitempty"`
+	InputSchema  map[string]any `json:"inputSchema"`
+	OutputSchema map[string]any `json:"outputSchema,omitempty"`
+	Annotations  map[string]any `json:"annotations,omitempty"`
+	Meta         map[string]any `json:"_meta,omitempty"`
+}
+
+// ListToolsResult holds the response from the 'tools/list' method.
+type ListToolsResult struct {
+	Tools []Tool `json:"tools"`
+}
+
+// CallToolParams holds the parameters for the 'tools/call' method.
+type CallToolParams struct {
+	Name      string         `json:"name"`
+	Arguments map[string]any `json:"arguments"`
+	Meta      map[string]any `json:"_meta,omitempty"`
+}
+
+// CallToolResult holds the response from the 'tools/call' method.
+type CallToolResult struct {
+	Content           []transport.ContentBlock `json:"content"`
+	StructuredContent map[string]any           `json:"structuredContent,omitempty"`
+	IsError           bool                     `json:"isError"`
+}
@@ -21,13 +21,13 @@ import (
 	"encoding/json"
 	"errors"
 	"io"
+	"maps"
 	"net/http"
 	"net/http/httptest"
-	"github.com/googleapis/mcp-toolbox-sdk-go/core/transport/mcp"
 	"testing"
 
-	"maps"
-
+	"github.com/googleapis/mcp-toolbox-sdk-go/core/transport"
+	"github.com/googleapis/mcp-toolbox-sdk-go/core/transport/mcp"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -230,7 +230,7 @@ func TestInvokeTool(t *testing.T) {
 
 		msg, _ := callParams.Arguments["message"].(string)
 		return callToolResult{
-			Content: []textContent{
+			Content: []transport.ContentBlock{
 				{Type: "text", Text: "Echo: " + msg},
 			},
 			IsError: false,
@@ -414,7 +414,7 @@ func TestInvokeTool_ErrorResult(t *testing.T) {
 
 	server.handlers["tools/call"] = func(params json.RawMessage) (any, error) {
 		return callToolResult{
-			Content: []textContent{{Type: "text", Text: "Something went wrong"}},
+			Content: []transport.ContentBlock{{Type: "text", Text: "Something went wrong"}},
 			IsError: true,
 		}, nil
 	}
@@ -423,6 +423,10 @@ func TestInvokeTool_ErrorResult(t *testing.T) {
 	_, err := client.InvokeTool(context.Background(), "tool", nil, nil)
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "tool execution resulted in error")
+
+	var invErr *transport.ToolInvocationError
+	require.True(t, errors.As(err, &invErr))
+	assert.Equal(t, "Something went wrong", invErr.Message)
 }
 
 func TestInvokeTool_RPCError(t *testing.T) {
@@ -443,20 +447,21 @@ func TestInvokeTool_ComplexContent(t *testing.T) {
 	server := newMockMCPServer(t)
 	defer server.Close()
 
+	// Non-text content is returned as typed blocks alongside the text.
+	content := []transport.ContentBlock{
+		{Type: "text", Text: "Part 1 "},
+		{Type: "image", Data: "base64data", MimeType: "image/png"},
+		{Type: "text", Text: "Part 2"},
+	}
+
 	server.handlers["tools/call"] = func(params json.RawMessage) (any, error) {
-		return callToolResult{
-			Content: []textContent{
-				{Type: "text", Text: "Part 1 "},
-				{Type: "image", Text: "base64data"}, // Should be ignored
-				{Type: "text", Text: "Part 2"},
-			},
-		}, nil
+		return callToolResult{Content: content}, nil
 	}
 
 	client, _ := New(server.URL, server.Client(), "custom-client", "1.0.0")
 	res, err := client.InvokeTool(context.Background(), "t", nil, nil)
 	require.NoError(t, err)
-	assert.Equal(t, "Part 1 Part 2", res)
+	assert.Equal(t, content, res)
 }
 
 func TestInvokeTool_EmptyResult(t *testing.T) {
@@ -465,7 +470,7 @@ func TestInvokeTool_EmptyResult(t *testing.T) {
 
 	server.handlers["tools/call"] = func(params json.RawMessage) (any, error) {
 		return callToolResult{
-			Content: []textContent{},
+			Content: []transport.ContentBlock{},
 		}, nil
 	}
 
@@ -483,7 +488,7 @@ func TestInvokeTool_ContentProcessing_Scenarios(t *testing.T) {
 		// Mock response with distinct JSON objects in separate text blocks
 		server.handlers["tools/call"] = func(params json.RawMessage) (any, error) {
 			return callToolResult{
-				Content: []textContent{
+				Content: []transport.ContentBlock{
 					{Type: "text", Text: `{"foo":"bar", "baz": "qux"}`},
 					{Type: "text", Text: `{"foo":"quux", "baz":"corge"}`},
 				},
@@ -507,7 +512,7 @@ func TestInvokeTool_ContentProcessing_Scenarios(t *testing.T) {
 		// Mock response where text is split across chunks but isn't JSON objects
 		server.handlers["tools/call"] = func(params json.RawMessage) (any, error) {
 			return callToolResult{
-				Content: []textContent{
+				Content: []transport.ContentBlock{
 					{Type: "text", Text: "Hello "},
 					{Type: "text", Text: "World"},
 				},
@@ -531,7 +536,7 @@ func TestInvokeTool_ContentProcessing_Scenarios(t *testing.T) {
 		// Since individual chunks are NOT valid JSON objects, it falls back to concatenation.
 		server.handlers["tools/call"] = func(params json.RawMessage) (any, error) {
 			return callToolResult{
-				Content: []textContent{
+				Content: []transport.ContentBlock{
 					{Type: "text", Text: `{"a": `},
 					{Type: "text", Text: `1}`},
 				},
@@ -635,4 +640,4 @@ func TestNew_ClientVersion(t *testing.T) {
 			t.Errorf("expected clientVersion %q, got %q", mcp.SDKVersion, tr2.clientVersion)
 		}
 	})
-}
\ No newline at end of file
+}
@@ -14,7 +14,11 @@
 
 package v20241105
 
-import "encoding/json"
+import (
+	"encoding/json"
+
+	"github.com/googleapis/mcp-toolbox-sdk-go/core/transport"
+)
 
 // jsonRPCRequest represents a standard JSON-RPC 2.0 request.
 type jsonRPCRequest struct {
@@ -59,6 +63,18 @@ type clientCapabilities map[string]any
 type serverCapabilities struct {
 	Prompts map[string]any `json:"prompts,omitempty"`
 	Tools   map[string]any `json:"tools,omitempty"`
+
+	// all holds every declared capability, including those not listed above.
+	all map[string]any
+}
+
+// UnmarshalJSON decodes the capabilities, keeping all of them in all.
+func (c *serverCapabilities) UnmarshalJSON(data []byte) error {
+	type plain serverCapabilities
+	if err := json.Unmarshal(data, (*plain)(c)); err != nil {
+		return err
+	}
+	return json.Unmarshal(data, &c.all)
 }
 
 // initializeRequestParams holds the parameters for the 'initialize' handshake.
@@ -81,6 +97,7 @@ type mcpTool struct {
 	Name        string         `json:"name"`
 	Description string         `json:"description,omitempty"`
 	InputSchema map[string]any `json:"inputSchema"`
+	Annotations map[string]any `json:"annotations,omitempty"`
 	Meta        map[string]any `json:"_meta,omitempty"`
 }
 
@@ -93,16 +110,11 @@ type listToolsResult struct {
 type callToolRequestParams struct {
 	Name      string         `json:"name"`
 	Arguments map[string]any `json:"arguments"`
-}
-
-// textContent represents a single text block in a tool's output.
-type textContent struct {
-	Type string `json:"type"`
-	Text string `json:"text"`
+	Meta      map[string]any `json:"_meta,omitempty"`
 }
 
 // callToolResult holds the response from the 'tools/call' method.
 type callToolResult struct {
-	Content []textContent `json:"content"`
-	IsError bool          `json:"isError"`
+	Content []transport.ContentBlock `json:"content"`
+	IsError bool                     `json:"isError"`
 }
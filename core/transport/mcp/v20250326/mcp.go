@@ -18,10 +18,14 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"maps"
 	"net/http"
 	"net/url"
+	"strings"
+	"sync"
 
 	"github.com/google/uuid"
 	"github.com/googleapis/mcp-toolbox-sdk-go/core/transport"
@@ -32,21 +36,34 @@ const (
 	ProtocolVersion = "2025-03-26"
 )
 
-// Ensure that McpTransport implements the Transport interface.
-var _ transport.Transport = &McpTransport{}
+// Ensure that McpTransport implements the Transport interface and the
+// optional interfaces for MCP server features.
+var (
+	_ transport.Transport        = &McpTransport{}
+	_ transport.PromptSource     = &McpTransport{}
+	_ transport.ResourceSource   = &McpTransport{}
+	_ transport.Completer        = &McpTransport{}
+	_ transport.Observable       = &McpTransport{}
+	_ transport.RequestReceiver  = &McpTransport{}
+	_ transport.ServerInfoSource = &McpTransport{}
+	_ transport.RequestTimeouter = &McpTransport{}
+	_ transport.Retrier          = &McpTransport{}
+)
 
 // McpTransport implements the MCP v2025-03-26 protocol.
 type McpTransport struct {
 	*mcp.BaseMcpTransport
 
 	protocolVersion string
+	sessionMu       sync.RWMutex
 	sessionId       string // Unique session ID for v2025-03-26
+	sessionHeaders  map[string]string
 	clientName      string
 	clientVersion   string
 }
 
 // New creates a new version-specific transport instance.
-func New(baseURL string, client *http.Client, clientName string, clientVersion string) (*McpTransport, error) {
+func New(baseURL string, client *http.Client, clientName string, clientVersion string, opts ...mcp.Option) (*McpTransport, error) {
 	baseTransport, err := mcp.NewBaseTransport(baseURL, client)
 	if err != nil {
 		return nil, err
@@ -62,7 +79,9 @@ func New(baseURL string, client *http.Client, clientName string, clientVersion s
 		clientVersion:    clientVersion,
 	}
 	t.HandshakeHook = t.initializeSession
+	t.RequestHook = t.request
 
+	t.ApplyOptions(opts)
 	return t, nil
 }
 
@@ -101,6 +120,9 @@ func (t *McpTransport) ListTools(ctx context.Context, toolsetName string, header
 			"description": tool.Description,
 			"inputSchema": tool.InputSchema,
 		}
+		if tool.Annotations != nil {
+			rawTool["annotations"] = tool.Annotations
+		}
 		if tool.Meta != nil {
 			rawTool["_meta"] = tool.Meta
 		}
@@ -139,28 +161,31 @@ func (t *McpTransport) InvokeTool(ctx context.Context, toolName string, payload
 		return "", err
 	}
 
+	meta, done := t.TrackProgress(ctx)
+	defer done()
 	params := callToolRequestParams{
 		Name:      toolName,
 		Arguments: payload,
+		Meta:      meta,
 	}
+	ctx = mcp.ToolCallContext(ctx)
 	var result callToolResult
 	if _, err := t.sendRequest(ctx, t.BaseURL(), "tools/call", params, headers, &result); err != nil {
 		return "", fmt.Errorf("failed to invoke tool '%s': %w", toolName, err)
 	}
 
 	if result.IsError {
-		return "", fmt.Errorf("tool execution resulted in error")
-	}
-
-	baseContent := make([]mcp.ToolContent, len(result.Content))
-	for i, item := range result.Content {
-		baseContent[i] = mcp.ToolContent{
-			Type: item.Type,
-			Text: item.Text,
+		// Surface the error text reported by the tool, if any.
+		var message string
+		for _, c := range result.Content {
+			if c.Type == "text" {
+				message += c.Text
+			}
 		}
+		return "", &transport.ToolInvocationError{Message: message}
 	}
 
-	output := t.ProcessToolResultContent(baseContent)
+	output := t.ProcessToolResultContent(result.Content)
 
 	return output, nil
 }
@@ -169,7 +194,7 @@ func (t *McpTransport) InvokeTool(ctx context.Context, toolName string, payload
 func (t *McpTransport) initializeSession(ctx context.Context, headers map[string]string) error {
 	params := initializeRequestParams{
 		ProtocolVersion: t.protocolVersion,
-		Capabilities:    clientCapabilities{},
+		Capabilities:    clientCapabilities(t.ClientCapabilities()),
 		ClientInfo: implementation{
 			Name:    t.clientName,
 			Version: t.clientVersion,
@@ -200,6 +225,7 @@ func (t *McpTransport) initializeSession(ctx context.Context, headers map[string
 	}
 
 	t.ServerVersion = result.ServerInfo.Version
+	t.SetServerInfo(result.Capabilities.all, result.Instructions)
 
 	// Session ID Extraction: Check the Headers.
 	sessionId := respHeaders.Get("Mcp-Session-Id")
@@ -207,25 +233,33 @@ func (t *McpTransport) initializeSession(ctx context.Context, headers map[string
 	if sessionId == "" {
 		return fmt.Errorf("server did not return an Mcp-Session-Id")
 	}
-	t.sessionId = sessionId
+	t.setSession(sessionId, headers)
 
 	// Confirm Handshake
 	_, err = t.sendNotification(ctx, "notifications/initialized", map[string]any{}, headers)
 	return err
 }
 
-// sendRequest sends a JSON-RPC request and injects the Session ID if active.
-func (t *McpTransport) sendRequest(ctx context.Context, url string, method string, params any, headers map[string]string, dest any) (http.Header, error) {
-
-	// Initialize headers map if it is nil
-	if headers == nil {
-		headers = make(map[string]string)
+// request sends a JSON-RPC request to the server, performing the handshake
+// first if needed.
+func (t *McpTransport) request(ctx context.Context, method string, params any, headers map[string]string, dest any) error {
+	if err := t.EnsureInitialized(ctx, headers); err != nil {
+		return err
 	}
+	_, err := t.sendRequest(ctx, t.BaseURL(), method, params, headers, dest)
+	return err
+}
 
-	// Spec Requirement: Include Mcp-Session-Id in the HEADER for all subsequent requests
-	if method != "initialize" && t.sessionId != "" {
-		headers["Mcp-Session-Id"] = t.sessionId
-	}
+// sendRequest sends a JSON-RPC request and injects the Session ID if active.
+// If the server reports that the session is no longer valid, the handshake
+// is performed again and the request is retried once with the new session.
+func (t *McpTransport) sendRequest(ctx context.Context, url string, method string, params any, headers map[string]string, dest any) (respHeaders http.Header, err error) {
+	ctx, done := t.WithRequestTimeout(ctx, method)
+	end := t.ObserveRPC(ctx, method)
+	defer func() {
+		err = done(err)
+		end(err)
+	}()
 
 	// Construct the standard JSON-RPC request (Params are NOT modified)
 	req := jsonRPCRequest{
@@ -235,22 +269,26 @@ func (t *McpTransport) sendRequest(ctx context.Context, url string, method strin
 		Params:  params,
 	}
 
-	return t.doRPC(ctx, url, req, headers, dest)
+	sessionId := t.session()
+	respHeaders, err = t.doRPC(ctx, url, req, withSession(headers, sessionId), dest)
+	if err != nil && sessionId != "" && isSessionExpired(err) {
+		if err := t.reinitialize(ctx, sessionId, headers); err != nil {
+			return nil, fmt.Errorf("failed to re-establish expired session: %w", err)
+		}
+		req.ID = uuid.New().String()
+		respHeaders, err = t.doRPC(ctx, url, req, withSession(headers, t.session()), dest)
+	}
+	if err != nil {
+		t.NotifyCancelled(ctx, method, req.ID, func(ctx context.Context, method string, params any) error {
+			_, err := t.sendNotification(ctx, method, params, headers)
+			return err
+		})
+	}
+	return respHeaders, err
 }
 
 // sendNotification sends a JSON-RPC notification and injects the Session ID if active.
 func (t *McpTransport) sendNotification(ctx context.Context, method string, params any, headers map[string]string) (http.Header, error) {
-
-	// Initialize headers map
-	if headers == nil {
-		headers = make(map[string]string)
-	}
-
-	// Spec Requirement: Inject Session ID as a HEADER
-	if t.sessionId != "" {
-		headers["Mcp-Session-Id"] = t.sessionId
-	}
-
 	// Construct the standard JSON-RPC notification
 	req := jsonRPCNotification{
 		JSONRPC: "2.0",
@@ -259,7 +297,111 @@ func (t *McpTransport) sendNotification(ctx context.Context, method string, para
 	}
 
 	// Pass the headers to doRPC
-	return t.doRPC(ctx, t.BaseURL(), req, headers, nil)
+	return t.doRPC(ctx, t.BaseURL(), req, withSession(headers, t.session()), nil)
+}
+
+// reinitialize performs a new handshake after the server rejected the
+// session staleId. Concurrent callers that observed the same stale session
+// share a single handshake.
+func (t *McpTransport) reinitialize(ctx context.Context, staleId string, headers map[string]string) error {
+	t.sessionMu.Lock()
+	if t.sessionId == staleId {
+		t.sessionId = ""
+		t.ResetInitialization()
+	}
+	t.sessionMu.Unlock()
+
+	if err := t.EnsureInitialized(ctx, headers); err != nil {
+		// Allow a later request to try again rather than caching the failure.
+		t.ResetInitialization()
+		return err
+	}
+	return nil
+}
+
+// session returns the current session ID, if any.
+func (t *McpTransport) session() string {
+	t.sessionMu.RLock()
+	defer t.sessionMu.RUnlock()
+	return t.sessionId
+}
+
+// setSession records the session ID assigned by the server, and the headers
+// of the handshake that established it.
+func (t *McpTransport) setSession(sessionId string, headers map[string]string) {
+	t.sessionMu.Lock()
+	defer t.sessionMu.Unlock()
+	t.sessionId = sessionId
+	t.sessionHeaders = maps.Clone(headers)
+}
+
+// Close terminates the session, if any, by sending an HTTP DELETE with its
+// session ID, so that the server can release its state. A server that does
+// not let clients terminate sessions answers 405, and one that already
+// dropped the session answers 404; neither is an error. The transport
+// performs a new handshake if it is used again.
+func (t *McpTransport) Close(ctx context.Context) error {
+	t.sessionMu.Lock()
+	sessionId, headers := t.sessionId, t.sessionHeaders
+	t.sessionId, t.sessionHeaders = "", nil
+	t.ResetInitialization()
+	t.sessionMu.Unlock()
+	if sessionId == "" {
+		return nil
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodDelete, t.BaseURL(), nil)
+	if err != nil {
+		return fmt.Errorf("create request failed: %w", err)
+	}
+	for k, v := range withSession(headers, sessionId) {
+		httpReq.Header.Set(k, v)
+	}
+
+	resp, err := t.Do(httpReq)
+	if err != nil {
+		return fmt.Errorf("failed to terminate session: %w", err)
+	}
+	defer resp.Body.Close()
+	switch {
+	case resp.StatusCode == http.StatusMethodNotAllowed, resp.StatusCode == http.StatusNotFound:
+		// The server expires the session on its own, or already has.
+		return nil
+	case resp.StatusCode >= http.StatusMultipleChoices:
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("failed to terminate session: %w", transport.NewHTTPResponseError(resp, body))
+	}
+	return nil
+}
+
+// withSession returns a copy of headers that carries the session ID, as the
+// spec requires for all requests after the handshake.
+func withSession(headers map[string]string, sessionId string) map[string]string {
+	merged := maps.Clone(headers)
+	if merged == nil {
+		merged = make(map[string]string)
+	}
+	if sessionId != "" {
+		merged["Mcp-Session-Id"] = sessionId
+	}
+	return merged
+}
+
+// isSessionExpired reports whether err shows that the server no longer
+// recognizes the session: a 404, or a 400 that refers to the session.
+func isSessionExpired(err error) bool {
+	var invocationErr *transport.ToolInvocationError
+	if !errors.As(err, &invocationErr) {
+		return false
+	}
+	switch invocationErr.StatusCode {
+	case http.StatusNotFound:
+		return true
+	case http.StatusBadRequest:
+		return strings.Contains(strings.ToLower(invocationErr.Message), "session")
+	default:
+		return false
+	}
 }
 
 // doRPC performs the HTTP POST, returns headers, and handles JSON-RPC wrapping.
@@ -270,22 +412,22 @@ func (t *McpTransport) doRPC(ctx context.Context, url string, reqBody any, heade
 	}
 
 	// Create Request
-	httpReq, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(payload))
+	httpReq, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewReader(payload))
 	if err != nil {
 		return nil, fmt.Errorf("create request failed: %w", err)
 	}
 
 	httpReq.Header.Set("Content-Type", "application/json")
 	// Set Accept header for MCP Spec 2025-03-26
-	// Since SSE is not supported, we only accept application/json
-	httpReq.Header.Set("Accept", "application/json")
+	// Servers may answer with a single JSON body or an SSE stream
+	httpReq.Header.Set("Accept", "application/json, text/event-stream")
 
 	// Apply resolved headers
 	for k, v := range headers {
 		httpReq.Header.Set(k, v)
 	}
 
-	resp, err := t.HTTPClient.Do(httpReq)
+	resp, err := t.Do(httpReq)
 	if err != nil {
 		return nil, fmt.Errorf("http request failed: %w", err)
 	}
@@ -298,30 +440,34 @@ func (t *McpTransport) doRPC(ctx context.Context, url string, reqBody any, heade
 	} else {
 		// Any other code, OR a 202/204 when we expected a result, is a failure.
 		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(body))
+		return nil, transport.NewHTTPResponseError(resp, body)
 	}
 
 	if dest == nil {
 		return resp.Header, nil
 	}
 
-	bodyBytes, err := io.ReadAll(resp.Body)
-	if err != nil {
+	body := mcp.GetBuffer()
+	defer mcp.PutBuffer(body)
+	if err := t.ReadResponseBodyInto(ctx, resp, body); err != nil {
 		return nil, fmt.Errorf("read body failed: %w", err)
 	}
 	var rpcResp jsonRPCResponse
-	if err := json.Unmarshal(bodyBytes, &rpcResp); err != nil {
+	if err := json.Unmarshal(body.Bytes(), &rpcResp); err != nil {
 		return nil, fmt.Errorf("response unmarshal failed: %w", err)
 	}
 
 	// Check RPC Error
 	if rpcResp.Error != nil {
-		return nil, fmt.Errorf("MCP request failed with code %d: %s", rpcResp.Error.Code, rpcResp.Error.Message)
+		return nil, &transport.ToolInvocationError{
+			Code:    rpcResp.Error.Code,
+			Message: rpcResp.Error.Message,
+			Details: rpcResp.Error.Data,
+		}
 	}
 
 	// Decode Result into specific struct
-	resultBytes, _ := json.Marshal(rpcResp.Result)
-	if err := json.Unmarshal(resultBytes, dest); err != nil {
+	if err := mcp.DecodeResult(rpcResp.Result, dest); err != nil {
 		return nil, fmt.Errorf("failed to parse result data: %w", err)
 	}
 
@@ -20,14 +20,16 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
+	"maps"
 	"net/http"
 	"net/http/httptest"
-	"github.com/googleapis/mcp-toolbox-sdk-go/core/transport/mcp"
+	"sync"
 	"testing"
 
-	"maps"
-
+	"github.com/googleapis/mcp-toolbox-sdk-go/core/transport"
+	"github.com/googleapis/mcp-toolbox-sdk-go/core/transport/mcp"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -37,6 +39,9 @@ type mockMCPServer struct {
 	*httptest.Server
 	handlers map[string]func(json.RawMessage) (any, map[string]string, error)
 	requests []capturedRequest
+	deletes  []http.Header // Headers of session termination requests
+	// deleteStatus is the status of session termination requests, 200 if 0.
+	deleteStatus int
 }
 
 type capturedRequest struct {
@@ -50,6 +55,16 @@ func newMockMCPServer() *mockMCPServer {
 	}
 
 	m.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodDelete {
+			m.deletes = append(m.deletes, r.Header.Clone())
+			if m.deleteStatus != 0 {
+				w.WriteHeader(m.deleteStatus)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+
 		body, err := io.ReadAll(r.Body)
 		if err != nil {
 			http.Error(w, "read body failed", http.StatusBadRequest)
@@ -159,7 +174,7 @@ func TestInitialize_Success(t *testing.T) {
 	assert.Equal(t, "session-12345", client.sessionId)
 
 	require.NotEmpty(t, server.requests)
-	assert.Equal(t, "application/json", server.requests[0].Headers.Get("Accept"))
+	assert.Equal(t, "application/json, text/event-stream", server.requests[0].Headers.Get("Accept"))
 }
 
 func TestInitialize_MissingSessionId(t *testing.T) {
@@ -186,7 +201,7 @@ func TestSessionId_Injection_InvokeTool(t *testing.T) {
 
 	server.handlers["tools/call"] = func(params json.RawMessage) (any, map[string]string, error) {
 		return callToolResult{
-			Content: []textContent{{Type: "text", Text: "OK"}},
+			Content: []transport.ContentBlock{{Type: "text", Text: "OK"}},
 		}, nil, nil
 	}
 
@@ -207,7 +222,7 @@ func TestSessionId_Injection_InvokeTool(t *testing.T) {
 	assert.Equal(t, "session-12345", callReq.Headers.Get("Mcp-Session-Id"), "Session ID header missing")
 
 	// Verify Accept Header
-	assert.Equal(t, "application/json", callReq.Headers.Get("Accept"), "Accept header missing or incorrect")
+	assert.Equal(t, "application/json, text/event-stream", callReq.Headers.Get("Accept"), "Accept header missing or incorrect")
 }
 
 func TestSessionId_Injection_ListTools(t *testing.T) {
@@ -284,7 +299,7 @@ func TestInvokeTool_ErrorResult(t *testing.T) {
 
 	server.handlers["tools/call"] = func(params json.RawMessage) (any, map[string]string, error) {
 		return callToolResult{
-			Content: []textContent{{Type: "text", Text: "Something went wrong"}},
+			Content: []transport.ContentBlock{{Type: "text", Text: "Something went wrong"}},
 			IsError: true,
 		}, nil, nil
 	}
@@ -293,6 +308,10 @@ func TestInvokeTool_ErrorResult(t *testing.T) {
 	_, err := client.InvokeTool(context.Background(), "tool", nil, nil)
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "tool execution resulted in error")
+
+	var invErr *transport.ToolInvocationError
+	require.True(t, errors.As(err, &invErr))
+	assert.Equal(t, "Something went wrong", invErr.Message)
 }
 
 func TestInvokeTool_RPCError(t *testing.T) {
@@ -480,21 +499,21 @@ func TestInvokeTool_ComplexContent(t *testing.T) {
 	server := newMockMCPServer()
 	defer server.Close()
 
+	// Non-text content is returned as typed blocks alongside the text.
+	content := []transport.ContentBlock{
+		{Type: "text", Text: "Part 1 "},
+		{Type: "image", Data: "base64data", MimeType: "image/png"},
+		{Type: "audio", Data: "base64audio", MimeType: "audio/wav"},
+		{Type: "text", Text: "Part 2"},
+	}
 	server.handlers["tools/call"] = func(params json.RawMessage) (any, map[string]string, error) {
-		return callToolResult{
-			Content: []textContent{
-				{Type: "text", Text: "Part 1 "},
-				{Type: "image", Text: "base64data"}, // Should be ignored
-				{Type: "text", Text: "Part 2"},
-			},
-		}, nil, nil
+		return callToolResult{Content: content}, nil, nil
 	}
 
 	client, _ := New(server.URL, server.Client(), "test-client", "1.0.0")
 	res, err := client.InvokeTool(context.Background(), "t", nil, nil)
 	require.NoError(t, err)
-	// Only text types should be concatenated
-	assert.Equal(t, "Part 1 Part 2", res)
+	assert.Equal(t, content, res)
 }
 
 func TestInvokeTool_EmptyResult(t *testing.T) {
@@ -503,7 +522,7 @@ func TestInvokeTool_EmptyResult(t *testing.T) {
 
 	server.handlers["tools/call"] = func(params json.RawMessage) (any, map[string]string, error) {
 		return callToolResult{
-			Content: []textContent{},
+			Content: []transport.ContentBlock{},
 		}, nil, nil
 	}
 
@@ -553,7 +572,7 @@ func TestInvokeTool_ContentProcessing_Scenarios(t *testing.T) {
 		// Mock response with distinct JSON objects in separate text blocks
 		server.handlers["tools/call"] = func(params json.RawMessage) (any, map[string]string, error) {
 			return callToolResult{
-				Content: []textContent{
+				Content: []transport.ContentBlock{
 					{Type: "text", Text: `{"foo":"bar", "baz": "qux"}`},
 					{Type: "text", Text: `{"foo":"quux", "baz":"corge"}`},
 				},
@@ -577,7 +596,7 @@ func TestInvokeTool_ContentProcessing_Scenarios(t *testing.T) {
 		// Mock response where text is split across chunks but isn't JSON objects
 		server.handlers["tools/call"] = func(params json.RawMessage) (any, map[string]string, error) {
 			return callToolResult{
-				Content: []textContent{
+				Content: []transport.ContentBlock{
 					{Type: "text", Text: "Hello "},
 					{Type: "text", Text: "World"},
 				},
@@ -600,7 +619,7 @@ func TestInvokeTool_ContentProcessing_Scenarios(t *testing.T) {
 		// Mock response where a single JSON object is split across chunks.
 		server.handlers["tools/call"] = func(params json.RawMessage) (any, map[string]string, error) {
 			return callToolResult{
-				Content: []textContent{
+				Content: []transport.ContentBlock{
 					{Type: "text", Text: `{"a": `},
 					{Type: "text", Text: `1}`},
 				},
@@ -709,4 +728,204 @@ func TestNew_ClientVersion(t *testing.T) {
 			t.Errorf("expected clientVersion %q, got %q", mcp.SDKVersion, tr2.clientVersion)
 		}
 	})
-}
\ No newline at end of file
+}
+
+func TestInvokeTool_StreamedResponse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req jsonRPCRequest
+		body, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(body, &req)
+
+		switch req.Method {
+		case "initialize":
+			w.Header().Set("Content-Type", "application/json")
+			w.Header().Set("Mcp-Session-Id", "s1")
+			_ = json.NewEncoder(w).Encode(jsonRPCResponse{
+				JSONRPC: "2.0",
+				ID:      req.ID,
+				Result:  json.RawMessage(`{"protocolVersion":"2025-03-26","capabilities":{"tools":{}},"serverInfo":{"name":"mock","version":"1"}}`),
+			})
+		case "tools/call":
+			id, _ := json.Marshal(req.ID)
+			w.Header().Set("Content-Type", "text/event-stream")
+			fmt.Fprint(w, "event: message\ndata: {\"jsonrpc\":\"2.0\",\"method\":\"notifications/progress\",\"params\":{\"progress\":1}}\n\n")
+			fmt.Fprintf(w, "data: {\"jsonrpc\":\"2.0\",\"id\":%s,\"result\":{\"content\":[{\"type\":\"text\",\"text\":\"done\"}]}}\n\n", id)
+		default:
+			w.WriteHeader(http.StatusAccepted)
+		}
+	}))
+	defer server.Close()
+
+	var notifications []transport.Notification
+	ctx := transport.WithNotificationHandler(context.Background(), func(n transport.Notification) {
+		notifications = append(notifications, n)
+	})
+
+	client, _ := New(server.URL, server.Client(), "test-client", "1.0.0")
+	res, err := client.InvokeTool(ctx, "t", nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "done", res)
+	require.Len(t, notifications, 1)
+	assert.Equal(t, "notifications/progress", notifications[0].Method)
+	assert.JSONEq(t, `{"progress":1}`, string(notifications[0].Params))
+}
+
+func TestSessionExpiry_Reinitializes(t *testing.T) {
+	var mu sync.Mutex
+	initializes := 0
+	var callSessions []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req jsonRPCRequest
+		body, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(body, &req)
+
+		mu.Lock()
+		defer mu.Unlock()
+		switch req.Method {
+		case "initialize":
+			initializes++
+			assert.Empty(t, r.Header.Get("Mcp-Session-Id"), "initialize must not carry a session")
+			w.Header().Set("Content-Type", "application/json")
+			w.Header().Set("Mcp-Session-Id", fmt.Sprintf("s%d", initializes))
+			_ = json.NewEncoder(w).Encode(jsonRPCResponse{
+				JSONRPC: "2.0",
+				ID:      req.ID,
+				Result:  json.RawMessage(`{"protocolVersion":"2025-03-26","capabilities":{"tools":{}},"serverInfo":{"name":"mock","version":"1"}}`),
+			})
+		case "tools/call":
+			session := r.Header.Get("Mcp-Session-Id")
+			callSessions = append(callSessions, session)
+			if session == "s1" {
+				http.Error(w, "session not found", http.StatusNotFound)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(jsonRPCResponse{
+				JSONRPC: "2.0",
+				ID:      req.ID,
+				Result:  json.RawMessage(`{"content":[{"type":"text","text":"ok"}]}`),
+			})
+		default:
+			w.WriteHeader(http.StatusAccepted)
+		}
+	}))
+	defer server.Close()
+
+	client, _ := New(server.URL, server.Client(), "test-client", "1.0.0")
+	headers := map[string]string{"X-Test": "1"}
+	res, err := client.InvokeTool(context.Background(), "t", nil, headers)
+	require.NoError(t, err)
+	assert.Equal(t, "ok", res)
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, 2, initializes)
+	assert.Equal(t, []string{"s1", "s2"}, callSessions)
+	assert.Equal(t, "s2", client.sessionId)
+	assert.Equal(t, map[string]string{"X-Test": "1"}, headers, "caller headers must not be modified")
+}
+
+func TestSessionExpiry_RetriesOnce(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req jsonRPCRequest
+		body, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(body, &req)
+
+		switch req.Method {
+		case "initialize":
+			w.Header().Set("Content-Type", "application/json")
+			w.Header().Set("Mcp-Session-Id", "s1")
+			_ = json.NewEncoder(w).Encode(jsonRPCResponse{
+				JSONRPC: "2.0",
+				ID:      req.ID,
+				Result:  json.RawMessage(`{"protocolVersion":"2025-03-26","capabilities":{"tools":{}},"serverInfo":{"name":"mock","version":"1"}}`),
+			})
+		case "tools/call":
+			calls++
+			http.Error(w, "invalid Mcp-Session-Id", http.StatusBadRequest)
+		default:
+			w.WriteHeader(http.StatusAccepted)
+		}
+	}))
+	defer server.Close()
+
+	client, _ := New(server.URL, server.Client(), "test-client", "1.0.0")
+	_, err := client.InvokeTool(context.Background(), "t", nil, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "status 400")
+	assert.Equal(t, 2, calls)
+}
+
+func TestIsSessionExpired(t *testing.T) {
+	assert.True(t, isSessionExpired(transport.NewHTTPError(http.StatusNotFound, nil)))
+	assert.True(t, isSessionExpired(transport.NewHTTPError(http.StatusBadRequest, []byte("Bad session"))))
+	assert.False(t, isSessionExpired(transport.NewHTTPError(http.StatusBadRequest, []byte("bad params"))))
+	assert.False(t, isSessionExpired(transport.NewHTTPError(http.StatusInternalServerError, nil)))
+	assert.False(t, isSessionExpired(errors.New("network down")))
+}
+
+func TestClose_TerminatesSession(t *testing.T) {
+	server := newMockMCPServer()
+	defer server.Close()
+	server.handlers["tools/list"] = func(params json.RawMessage) (any, map[string]string, error) {
+		return listToolsResult{}, nil, nil
+	}
+
+	client, _ := New(server.URL, server.Client(), "test-client", "1.0.0")
+	ctx := context.Background()
+
+	// Closing before a session is established sends nothing.
+	require.NoError(t, client.Close(ctx))
+	assert.Empty(t, server.deletes)
+
+	_, err := client.ListTools(ctx, "", map[string]string{"Authorization": "Bearer token"})
+	require.NoError(t, err)
+	require.NoError(t, client.Close(ctx))
+	require.Len(t, server.deletes, 1)
+	assert.Equal(t, "session-12345", server.deletes[0].Get("Mcp-Session-Id"))
+	assert.Equal(t, "Bearer token", server.deletes[0].Get("Authorization"))
+	assert.Empty(t, client.session())
+
+	// The transport starts a new session when used again.
+	_, err = client.ListTools(ctx, "", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "session-12345", client.session())
+}
+
+func TestClose_TerminationStatus(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		wantErr string
+	}{
+		{"Termination not allowed", http.StatusMethodNotAllowed, ""},
+		{"Session already gone", http.StatusNotFound, ""},
+		{"Negative Test - Server error", http.StatusInternalServerError, "failed to terminate session"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			server := newMockMCPServer()
+			defer server.Close()
+			server.deleteStatus = tc.status
+			server.handlers["tools/list"] = func(params json.RawMessage) (any, map[string]string, error) {
+				return listToolsResult{}, nil, nil
+			}
+
+			client, _ := New(server.URL, server.Client(), "test-client", "1.0.0")
+			ctx := context.Background()
+			_, err := client.ListTools(ctx, "", nil)
+			require.NoError(t, err)
+
+			err = client.Close(ctx)
+			require.Len(t, server.deletes, 1)
+			if tc.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tc.wantErr)
+			}
+			assert.Empty(t, client.session(), "Expected the session to be forgotten")
+		})
+	}
+}
@@ -32,8 +32,19 @@ const (
 	ProtocolVersion = "2025-06-18"
 )
 
-// Ensure that McpTransport implements the Transport interface.
-var _ transport.Transport = &McpTransport{}
+// Ensure that McpTransport implements the Transport interface and the
+// optional interfaces for MCP server features.
+var (
+	_ transport.Transport        = &McpTransport{}
+	_ transport.PromptSource     = &McpTransport{}
+	_ transport.ResourceSource   = &McpTransport{}
+	_ transport.Completer        = &McpTransport{}
+	_ transport.Observable       = &McpTransport{}
+	_ transport.RequestReceiver  = &McpTransport{}
+	_ transport.ServerInfoSource = &McpTransport{}
+	_ transport.RequestTimeouter = &McpTransport{}
+	_ transport.Retrier          = &McpTransport{}
+)
 
 // McpTransport implements the MCP v2025-06-18 protocol.
 type McpTransport struct {
@@ -44,7 +55,7 @@ type McpTransport struct {
 }
 
 // New creates a new version-specific transport instance.
-func New(baseURL string, client *http.Client, clientName string, clientVersion string) (*McpTransport, error) {
+func New(baseURL string, client *http.Client, clientName string, clientVersion string, opts ...mcp.Option) (*McpTransport, error) {
 	baseTransport, err := mcp.NewBaseTransport(baseURL, client)
 	if err != nil {
 		return nil, err
@@ -60,7 +71,9 @@ func New(baseURL string, client *http.Client, clientName string, clientVersion s
 		clientVersion:    clientVersion,
 	}
 	t.HandshakeHook = t.initializeSession
+	t.RequestHook = t.request
 
+	t.ApplyOptions(opts)
 	return t, nil
 }
 
@@ -99,6 +112,15 @@ func (t *McpTransport) ListTools(ctx context.Context, toolsetName string, header
 			"description": tool.Description,
 			"inputSchema": tool.InputSchema,
 		}
+		if tool.Title != "" {
+			rawTool["title"] = tool.Title
+		}
+		if tool.Annotations != nil {
+			rawTool["annotations"] = tool.Annotations
+		}
+		if tool.OutputSchema != nil {
+			rawTool["outputSchema"] = tool.OutputSchema
+		}
 		if tool.Meta != nil {
 			rawTool["_meta"] = tool.Meta
 		}
@@ -137,29 +159,38 @@ func (t *McpTransport) InvokeTool(ctx context.Context, toolName string, payload
 	if err := t.EnsureInitialized(ctx, headers); err != nil {
 		return "", err
 	}
+	meta, done := t.TrackProgress(ctx)
+	defer done()
 	params := callToolRequestParams{
 		Name:      toolName,
 		Arguments: payload,
+		Meta:      meta,
 	}
 
+	ctx = mcp.ToolCallContext(ctx)
 	var result callToolResult
 	if err := t.sendRequest(ctx, t.BaseURL(), "tools/call", params, headers, &result); err != nil {
 		return "", fmt.Errorf("failed to invoke tool '%s': %w", toolName, err)
 	}
 
 	if result.IsError {
-		return "", fmt.Errorf("tool execution resulted in error")
+		// Surface the error text reported by the tool, if any.
+		var message string
+		for _, c := range result.Content {
+			if c.Type == "text" {
+				message += c.Text
+			}
+		}
+		return "", &transport.ToolInvocationError{Message: message}
 	}
 
-	baseContent := make([]mcp.ToolContent, len(result.Content))
-	for i, item := range result.Content {
-		baseContent[i] = mcp.ToolContent{
-			Type: item.Type,
-			Text: item.Text,
-		}
+	// Tools with an output schema return their result as structured
+	// content, which is preserved rather than flattened into text.
+	if result.StructuredContent != nil {
+		return result.StructuredContent, nil
 	}
 
-	output := t.ProcessToolResultContent(baseContent)
+	output := t.ProcessToolResultContent(result.Content)
 
 	return output, nil
 }
@@ -168,9 +199,10 @@ func (t *McpTransport) InvokeTool(ctx context.Context, toolName string, payload
 func (t *McpTransport) initializeSession(ctx context.Context, headers map[string]string) error {
 	params := initializeRequestParams{
 		ProtocolVersion: t.protocolVersion,
-		Capabilities:    clientCapabilities{},
+		Capabilities:    clientCapabilities(t.ClientCapabilities()),
 		ClientInfo: implementation{
 			Name:    t.clientName,
+			Title:   t.ClientTitle(),
 			Version: t.clientVersion,
 		},
 	}
@@ -191,20 +223,40 @@ func (t *McpTransport) initializeSession(ctx context.Context, headers map[string
 	}
 
 	t.ServerVersion = result.ServerInfo.Version
+	t.SetServerInfo(result.Capabilities.all, result.Instructions)
 
 	// Confirm Handshake
 	return t.sendNotification(ctx, "notifications/initialized", map[string]any{}, headers)
 }
 
+// request sends a JSON-RPC request to the server, performing the handshake
+// first if needed.
+func (t *McpTransport) request(ctx context.Context, method string, params any, headers map[string]string, dest any) error {
+	if err := t.EnsureInitialized(ctx, headers); err != nil {
+		return err
+	}
+	return t.sendRequest(ctx, t.BaseURL(), method, params, headers, dest)
+}
+
 // sendRequest sends a standard JSON-RPC request to the server.
 func (t *McpTransport) sendRequest(ctx context.Context, url string, method string, params any, headers map[string]string, dest any) error {
+	ctx, done := t.WithRequestTimeout(ctx, method)
+	end := t.ObserveRPC(ctx, method)
 	req := jsonRPCRequest{
 		JSONRPC: "2.0",
 		Method:  method,
 		ID:      uuid.New().String(),
 		Params:  params,
 	}
-	return t.doRPC(ctx, url, req, headers, dest)
+	err := t.doRPC(ctx, url, req, headers, dest)
+	if err != nil {
+		t.NotifyCancelled(ctx, method, req.ID, func(ctx context.Context, method string, params any) error {
+			return t.sendNotification(ctx, method, params, headers)
+		})
+	}
+	err = done(err)
+	end(err)
+	return err
 }
 
 // sendNotification sends a standard JSON-RPC notification (no response expected).
@@ -226,15 +278,15 @@ func (t *McpTransport) doRPC(ctx context.Context, url string, reqBody any, heade
 	}
 
 	// Create Request
-	httpReq, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(payload))
+	httpReq, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewReader(payload))
 	if err != nil {
 		return fmt.Errorf("create request failed: %w", err)
 	}
 
 	httpReq.Header.Set("Content-Type", "application/json")
 	// Set Accept header for MCP Spec 2025-03-26
-	// Since SSE is not supported, we only accept application/json
-	httpReq.Header.Set("Accept", "application/json")
+	// Servers may answer with a single JSON body or an SSE stream
+	httpReq.Header.Set("Accept", "application/json, text/event-stream")
 	// v2025-06-18 Specific: Inject Protocol Version Header
 	httpReq.Header.Set("MCP-Protocol-Version", t.protocolVersion)
 
@@ -243,7 +295,7 @@ func (t *McpTransport) doRPC(ctx context.Context, url string, reqBody any, heade
 		httpReq.Header.Set(k, v)
 	}
 
-	resp, err := t.HTTPClient.Do(httpReq)
+	resp, err := t.Do(httpReq)
 	if err != nil {
 		return fmt.Errorf("http request failed: %w", err)
 	}
@@ -256,32 +308,36 @@ func (t *McpTransport) doRPC(ctx context.Context, url string, reqBody any, heade
 	} else {
 		// Any other code, OR a 202/204 when we expected a result, is a failure.
 		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(body))
+		return transport.NewHTTPResponseError(resp, body)
 	}
 
 	if dest == nil {
 		return nil
 	}
 
-	bodyBytes, err := io.ReadAll(resp.Body)
-	if err != nil {
+	body := mcp.GetBuffer()
+	defer mcp.PutBuffer(body)
+	if err := t.ReadResponseBodyInto(ctx, resp, body); err != nil {
 		return fmt.Errorf("read body failed: %w", err)
 	}
 
 	// Decode RPC Envelope
 	var rpcResp jsonRPCResponse
-	if err := json.Unmarshal(bodyBytes, &rpcResp); err != nil {
+	if err := json.Unmarshal(body.Bytes(), &rpcResp); err != nil {
 		return fmt.Errorf("response unmarshal failed: %w", err)
 	}
 
 	// Check RPC Error
 	if rpcResp.Error != nil {
-		return fmt.Errorf("MCP request failed with code %d: %s", rpcResp.Error.Code, rpcResp.Error.Message)
+		return &transport.ToolInvocationError{
+			Code:    rpcResp.Error.Code,
+			Message: rpcResp.Error.Message,
+			Details: rpcResp.Error.Data,
+		}
 	}
 
 	// Decode Result into specific struct
-	resultBytes, _ := json.Marshal(rpcResp.Result)
-	if err := json.Unmarshal(resultBytes, dest); err != nil {
+	if err := mcp.DecodeResult(rpcResp.Result, dest); err != nil {
 		return fmt.Errorf("failed to parse result data: %w", err)
 	}
 
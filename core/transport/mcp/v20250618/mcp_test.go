@@ -17,15 +17,20 @@
 package v20250618
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
-	"github.com/googleapis/mcp-toolbox-sdk-go/core/transport/mcp"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/googleapis/mcp-toolbox-sdk-go/core/transport"
+	"github.com/googleapis/mcp-toolbox-sdk-go/core/transport/mcp"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -140,7 +145,7 @@ func TestHeaders_Presence(t *testing.T) {
 	assert.Equal(t, "2025-06-18", req.Headers.Get("MCP-Protocol-Version"))
 
 	// Requirement: Accept header must be present and application/json
-	assert.Equal(t, "application/json", req.Headers.Get("Accept"))
+	assert.Equal(t, "application/json, text/event-stream", req.Headers.Get("Accept"))
 }
 
 func TestListTools(t *testing.T) {
@@ -153,6 +158,7 @@ func TestListTools(t *testing.T) {
 			Tools: []mcpTool{
 				{
 					Name:        "get_weather",
+					Title:       "Weather Lookup",
 					Description: "Get weather for a location",
 					InputSchema: map[string]any{
 						"type": "object",
@@ -161,6 +167,12 @@ func TestListTools(t *testing.T) {
 						},
 						"required": []string{"location"},
 					},
+					OutputSchema: map[string]any{
+						"type": "object",
+						"properties": map[string]any{
+							"temperature": map[string]any{"type": "number"},
+						},
+					},
 				},
 			},
 		}, nil
@@ -178,8 +190,11 @@ func TestListTools(t *testing.T) {
 		assert.Contains(t, manifest.Tools, "get_weather")
 		tool := manifest.Tools["get_weather"]
 		assert.Equal(t, "Get weather for a location", tool.Description)
+		assert.Equal(t, "Weather Lookup", tool.Title)
 		assert.Len(t, tool.Parameters, 1)
 		assert.Equal(t, "location", tool.Parameters[0].Name)
+		assert.Equal(t, "object", tool.OutputSchema["type"])
+		assert.Contains(t, tool.OutputSchema["properties"], "temperature")
 	})
 
 	t.Run("Verify Handshake Sequence and Headers", func(t *testing.T) {
@@ -262,7 +277,7 @@ func TestInvokeTool(t *testing.T) {
 
 		msg, _ := callParams.Arguments["message"].(string)
 		return callToolResult{
-			Content: []textContent{
+			Content: []transport.ContentBlock{
 				{Type: "text", Text: "Echo: " + msg},
 			},
 			IsError: false,
@@ -287,6 +302,130 @@ func TestInvokeTool(t *testing.T) {
 		assert.Equal(t, "tools/call", lastReq.Body.Method)
 		assert.Equal(t, "2025-06-18", lastReq.Headers.Get("MCP-Protocol-Version"))
 	})
+
+	t.Run("Sends the request meta", func(t *testing.T) {
+		metaCtx := transport.WithRequestMeta(ctx, map[string]any{"tenant": "acme"})
+		_, err := client.InvokeTool(transport.WithProgressToken(metaCtx, "tok"), "echo", nil, nil)
+		require.NoError(t, err)
+
+		params, err := json.Marshal(server.requests[len(server.requests)-1].Body.Params)
+		require.NoError(t, err)
+		var p struct {
+			Meta map[string]any `json:"_meta"`
+		}
+		require.NoError(t, json.Unmarshal(params, &p))
+		assert.Equal(t, map[string]any{"tenant": "acme", "progressToken": "tok"}, p.Meta)
+	})
+}
+
+func TestInvokeTool_CancelNotifiesServer(t *testing.T) {
+	server := newMockMCPServer(t)
+	defer server.Close()
+	release := make(chan struct{})
+	defer close(release)
+
+	called := make(chan any, 1)
+	server.handlers["tools/call"] = func(params json.RawMessage) (any, error) {
+		called <- server.requests[len(server.requests)-1].Body.ID
+		<-release
+		return callToolResult{}, nil
+	}
+	cancelled := make(chan json.RawMessage, 1)
+	server.handlers["notifications/cancelled"] = func(params json.RawMessage) (any, error) {
+		cancelled <- params
+		return nil, nil
+	}
+
+	client, _ := New(server.URL, server.Client(), "test-client", "1.0.0")
+	require.NoError(t, client.EnsureInitialized(context.Background(), nil))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var callID any
+	go func() {
+		callID = <-called
+		cancel()
+	}()
+	_, err := client.InvokeTool(ctx, "slow", nil, nil)
+	require.ErrorIs(t, err, context.Canceled)
+
+	select {
+	case params := <-cancelled:
+		var p struct {
+			RequestID any    `json:"requestId"`
+			Reason    string `json:"reason"`
+		}
+		require.NoError(t, json.Unmarshal(params, &p))
+		assert.Equal(t, callID, p.RequestID)
+		assert.Equal(t, "context canceled", p.Reason)
+	case <-time.After(time.Second):
+		t.Fatal("Expected the server to be notified of the cancellation")
+	}
+}
+
+func TestInitialize_DeclaresClientCapabilities(t *testing.T) {
+	server := newMockMCPServer(t)
+	defer server.Close()
+	server.handlers["tools/list"] = func(params json.RawMessage) (any, error) {
+		return listToolsResult{}, nil
+	}
+
+	client, _ := New(server.URL, server.Client(), "test-client", "1.0.0")
+	client.SetClientCapability("sampling", map[string]any{})
+
+	_, err := client.ListTools(context.Background(), "", nil)
+	require.NoError(t, err)
+
+	params, err := json.Marshal(server.requests[0].Body.Params)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"sampling":{}}`, initializeCapabilities(t, params))
+}
+
+// initializeCapabilities extracts the capabilities from initialize parameters.
+func initializeCapabilities(t *testing.T, params []byte) string {
+	t.Helper()
+	var p struct {
+		Capabilities json.RawMessage `json:"capabilities"`
+	}
+	require.NoError(t, json.Unmarshal(params, &p))
+	return string(p.Capabilities)
+}
+
+func TestPrompts(t *testing.T) {
+	server := newMockMCPServer(t)
+	defer server.Close()
+
+	server.handlers["prompts/list"] = func(params json.RawMessage) (any, error) {
+		return map[string]any{"prompts": []map[string]any{{"name": "greet"}}}, nil
+	}
+	server.handlers["prompts/get"] = func(params json.RawMessage) (any, error) {
+		var p struct {
+			Name      string            `json:"name"`
+			Arguments map[string]string `json:"arguments"`
+		}
+		_ = json.Unmarshal(params, &p)
+		return map[string]any{"messages": []map[string]any{{
+			"role":    "user",
+			"content": map[string]any{"type": "text", "text": "Hello, " + p.Arguments["name"]},
+		}}}, nil
+	}
+
+	client, _ := New(server.URL, server.Client(), "test-client", "1.0.0")
+	ctx := context.Background()
+
+	prompts, err := client.ListPrompts(ctx, nil)
+	require.NoError(t, err)
+	require.Len(t, prompts, 1)
+	assert.Equal(t, "greet", prompts[0].Name)
+	assert.Equal(t, "initialize", server.requests[0].Body.Method, "Expected the handshake to run first")
+
+	result, err := client.GetPrompt(ctx, "greet", map[string]string{"name": "Ada"}, nil)
+	require.NoError(t, err)
+	require.Len(t, result.Messages, 1)
+	assert.Equal(t, "Hello, Ada", result.Messages[0].Content.Text)
+
+	lastReq := server.requests[len(server.requests)-1]
+	assert.Equal(t, "prompts/get", lastReq.Body.Method)
+	assert.Equal(t, "2025-06-18", lastReq.Headers.Get("MCP-Protocol-Version"))
 }
 
 func TestProtocolMismatch(t *testing.T) {
@@ -297,7 +436,7 @@ func TestProtocolMismatch(t *testing.T) {
 	server.handlers["initialize"] = func(params json.RawMessage) (any, error) {
 		return initializeResult{
 			ProtocolVersion: "2099-01-01", // Future version
-			Capabilities:    serverCapabilities{Tools: map[string]any{}},
+			Capabilities:    serverCapabilities{Tools: map[string]any{"listChanged": true}},
 			ServerInfo:      implementation{Name: "mock", Version: "1.0"},
 		}, nil
 	}
@@ -452,7 +591,7 @@ func TestInvokeTool_ErrorResult(t *testing.T) {
 
 	server.handlers["tools/call"] = func(params json.RawMessage) (any, error) {
 		return callToolResult{
-			Content: []textContent{{Type: "text", Text: "Something went wrong"}},
+			Content: []transport.ContentBlock{{Type: "text", Text: "Something went wrong"}},
 			IsError: true,
 		}, nil
 	}
@@ -461,6 +600,10 @@ func TestInvokeTool_ErrorResult(t *testing.T) {
 	_, err := client.InvokeTool(context.Background(), "tool", nil, nil)
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "tool execution resulted in error")
+
+	var invErr *transport.ToolInvocationError
+	require.True(t, errors.As(err, &invErr))
+	assert.Equal(t, "Something went wrong", invErr.Message)
 }
 
 func TestInvokeTool_RPCError(t *testing.T) {
@@ -481,20 +624,24 @@ func TestInvokeTool_ComplexContent(t *testing.T) {
 	server := newMockMCPServer(t)
 	defer server.Close()
 
+	// Non-text content is returned as typed blocks alongside the text.
+	content := []transport.ContentBlock{
+		{Type: "text", Text: "Part 1 "},
+		{Type: "image", Data: "base64data", MimeType: "image/png"},
+		{Type: "audio", Data: "base64audio", MimeType: "audio/wav"},
+		{Type: "resource_link", URI: "file:///report.csv", Name: "report.csv", MimeType: "text/csv"},
+		{Type: "resource", Resource: &transport.ResourceContents{URI: "file:///notes.txt", MimeType: "text/plain", Text: "notes"}},
+		{Type: "text", Text: "Part 2"},
+	}
+
 	server.handlers["tools/call"] = func(params json.RawMessage) (any, error) {
-		return callToolResult{
-			Content: []textContent{
-				{Type: "text", Text: "Part 1 "},
-				{Type: "image", Text: "base64data"}, // Should be ignored
-				{Type: "text", Text: "Part 2"},
-			},
-		}, nil
+		return callToolResult{Content: content}, nil
 	}
 
 	client, _ := New(server.URL, server.Client(), "test-client", "1.0.0")
 	res, err := client.InvokeTool(context.Background(), "t", nil, nil)
 	require.NoError(t, err)
-	assert.Equal(t, "Part 1 Part 2", res)
+	assert.Equal(t, content, res)
 }
 
 func TestInvokeTool_EmptyResult(t *testing.T) {
@@ -503,7 +650,7 @@ func TestInvokeTool_EmptyResult(t *testing.T) {
 
 	server.handlers["tools/call"] = func(params json.RawMessage) (any, error) {
 		return callToolResult{
-			Content: []textContent{},
+			Content: []transport.ContentBlock{},
 		}, nil
 	}
 
@@ -512,6 +659,23 @@ func TestInvokeTool_EmptyResult(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, "null", res)
 }
+
+func TestInvokeTool_StructuredContent(t *testing.T) {
+	server := newMockMCPServer(t)
+	defer server.Close()
+
+	server.handlers["tools/call"] = func(params json.RawMessage) (any, error) {
+		return callToolResult{
+			Content:           []transport.ContentBlock{{Type: "text", Text: `{"temperature":21.5}`}},
+			StructuredContent: map[string]any{"temperature": 21.5},
+		}, nil
+	}
+
+	client, _ := New(server.URL, server.Client(), "test-client", "1.0.0")
+	res, err := client.InvokeTool(context.Background(), "t", nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"temperature": 21.5}, res)
+}
 func TestInvokeTool_ContentProcessing_Scenarios(t *testing.T) {
 	t.Run("Multiple JSON Objects (Merge to Array)", func(t *testing.T) {
 		server := newMockMCPServer(t)
@@ -520,7 +684,7 @@ func TestInvokeTool_ContentProcessing_Scenarios(t *testing.T) {
 		// Mock response with distinct JSON objects in separate text blocks
 		server.handlers["tools/call"] = func(params json.RawMessage) (any, error) {
 			return callToolResult{
-				Content: []textContent{
+				Content: []transport.ContentBlock{
 					{Type: "text", Text: `{"foo":"bar", "baz": "qux"}`},
 					{Type: "text", Text: `{"foo":"quux", "baz":"corge"}`},
 				},
@@ -544,7 +708,7 @@ func TestInvokeTool_ContentProcessing_Scenarios(t *testing.T) {
 		// Mock response where text is split across chunks but isn't JSON objects
 		server.handlers["tools/call"] = func(params json.RawMessage) (any, error) {
 			return callToolResult{
-				Content: []textContent{
+				Content: []transport.ContentBlock{
 					{Type: "text", Text: "Hello "},
 					{Type: "text", Text: "World"},
 				},
@@ -567,7 +731,7 @@ func TestInvokeTool_ContentProcessing_Scenarios(t *testing.T) {
 		// Mock response where a single JSON object is split across chunks.
 		server.handlers["tools/call"] = func(params json.RawMessage) (any, error) {
 			return callToolResult{
-				Content: []textContent{
+				Content: []transport.ContentBlock{
 					{Type: "text", Text: `{"a": `},
 					{Type: "text", Text: `1}`},
 				},
@@ -675,4 +839,260 @@ func TestNew_ClientVersion(t *testing.T) {
 			t.Errorf("expected clientVersion %q, got %q", mcp.SDKVersion, tr2.clientVersion)
 		}
 	})
-}
\ No newline at end of file
+}
+
+func TestInvokeTool_StreamedResponse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req jsonRPCRequest
+		body, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(body, &req)
+
+		switch req.Method {
+		case "initialize":
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(jsonRPCResponse{
+				JSONRPC: "2.0",
+				ID:      req.ID,
+				Result:  json.RawMessage(`{"protocolVersion":"2025-06-18","capabilities":{"tools":{}},"serverInfo":{"name":"mock","version":"1"}}`),
+			})
+		case "tools/call":
+			id, _ := json.Marshal(req.ID)
+			w.Header().Set("Content-Type", "text/event-stream")
+			fmt.Fprint(w, "event: message\ndata: {\"jsonrpc\":\"2.0\",\"method\":\"notifications/progress\",\"params\":{\"progress\":1}}\n\n")
+			fmt.Fprintf(w, "data: {\"jsonrpc\":\"2.0\",\"id\":%s,\"result\":{\"content\":[{\"type\":\"text\",\"text\":\"done\"}]}}\n\n", id)
+		default:
+			w.WriteHeader(http.StatusAccepted)
+		}
+	}))
+	defer server.Close()
+
+	var notifications []transport.Notification
+	ctx := transport.WithNotificationHandler(context.Background(), func(n transport.Notification) {
+		notifications = append(notifications, n)
+	})
+
+	client, _ := New(server.URL, server.Client(), "test-client", "1.0.0")
+	res, err := client.InvokeTool(ctx, "t", nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "done", res)
+	require.Len(t, notifications, 1)
+	assert.Equal(t, "notifications/progress", notifications[0].Method)
+	assert.JSONEq(t, `{"progress":1}`, string(notifications[0].Params))
+}
+
+// recordingObserver records the requests reported to it.
+type recordingObserver struct {
+	methods []string
+	errs    []error
+}
+
+func (o *recordingObserver) OnRPCStart(context.Context, string) {}
+
+func (o *recordingObserver) OnRPCEnd(_ context.Context, method string, _ time.Duration, err error) {
+	o.methods = append(o.methods, method)
+	o.errs = append(o.errs, err)
+}
+
+func TestObserver(t *testing.T) {
+	server := newMockMCPServer(t)
+	defer server.Close()
+	server.handlers["tools/call"] = func(params json.RawMessage) (any, error) {
+		return nil, errors.New("boom")
+	}
+
+	client, _ := New(server.URL, server.Client(), "test-client", "1.0.0")
+	observer := &recordingObserver{}
+	client.SetObserver(observer)
+
+	_, err := client.InvokeTool(context.Background(), "echo", nil, nil)
+	require.Error(t, err)
+
+	assert.Equal(t, []string{"initialize", "tools/call"}, observer.methods, "Expected notifications not to be reported")
+	assert.NoError(t, observer.errs[0])
+	assert.ErrorContains(t, observer.errs[1], "boom")
+}
+
+func TestRequestTimeout(t *testing.T) {
+	server := newMockMCPServer(t)
+	defer server.Close()
+	release := make(chan struct{})
+	server.handlers["tools/call"] = func(params json.RawMessage) (any, error) {
+		<-release
+		return map[string]any{"content": []any{}}, nil
+	}
+	cancelled := make(chan string, 1)
+	server.handlers["notifications/cancelled"] = func(params json.RawMessage) (any, error) {
+		var p struct {
+			Reason string `json:"reason"`
+		}
+		_ = json.Unmarshal(params, &p)
+		cancelled <- p.Reason
+		return nil, nil
+	}
+
+	client, _ := New(server.URL, server.Client(), "test-client", "1.0.0")
+	client.SetRequestTimeout(50 * time.Millisecond)
+
+	_, err := client.InvokeTool(context.Background(), "echo", nil, nil)
+	close(release)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.ErrorContains(t, err, "request 'tools/call' timed out after 50ms")
+	select {
+	case reason := <-cancelled:
+		assert.Equal(t, "request 'tools/call' timed out after 50ms: context deadline exceeded", reason)
+	case <-time.After(time.Second):
+		t.Fatal("Expected the request to be cancelled on the server")
+	}
+}
+
+func TestInitialize_DeclaresClientInfo(t *testing.T) {
+	server := newMockMCPServer(t)
+	defer server.Close()
+
+	client, _ := New(server.URL, server.Client(), "test-client", "1.0.0",
+		mcp.WithClientTitle("Test Client"),
+		mcp.WithClientCapability("experimental", map[string]any{"x": true}))
+	require.NoError(t, client.EnsureInitialized(context.Background(), nil))
+
+	params, err := json.Marshal(server.requests[0].Body.Params)
+	require.NoError(t, err)
+	var p struct {
+		ClientInfo json.RawMessage `json:"clientInfo"`
+	}
+	require.NoError(t, json.Unmarshal(params, &p))
+	assert.JSONEq(t, `{"name":"test-client","title":"Test Client","version":"1.0.0"}`, string(p.ClientInfo))
+	assert.JSONEq(t, `{"experimental":{"x":true}}`, initializeCapabilities(t, params))
+}
+
+func TestServerInfo(t *testing.T) {
+	server := newMockMCPServer(t)
+	defer server.Close()
+	server.handlers["initialize"] = func(params json.RawMessage) (any, error) {
+		return map[string]any{
+			"protocolVersion": "2025-06-18",
+			"capabilities":    map[string]any{"tools": map[string]any{}, "logging": map[string]any{}},
+			"serverInfo":      map[string]any{"name": "mock-server", "version": "1.0.0"},
+			"instructions":    "Call search first.",
+		}, nil
+	}
+
+	client, _ := New(server.URL, server.Client(), "test-client", "1.0.0")
+	info, err := client.ServerInfo(context.Background(), nil)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"tools": map[string]any{}, "logging": map[string]any{}}, info.Capabilities)
+	assert.Equal(t, "Call search first.", info.Instructions)
+
+	// The returned capabilities are a copy.
+	delete(info.Capabilities, "tools")
+	info, err = client.ServerInfo(context.Background(), nil)
+	require.NoError(t, err)
+	assert.Contains(t, info.Capabilities, "tools")
+	assert.Len(t, server.requests, 2, "Expected a single handshake")
+}
+
+func TestComplete(t *testing.T) {
+	req := transport.CompletionRequest{
+		Ref:          transport.CompletionRef{Type: transport.CompletionRefPrompt, Name: "review"},
+		ArgumentName: "language",
+		Value:        "py",
+		Arguments:    map[string]string{"style": "strict"},
+	}
+
+	t.Run("Sends the request", func(t *testing.T) {
+		server := newMockMCPServer(t)
+		defer server.Close()
+		server.handlers["initialize"] = func(params json.RawMessage) (any, error) {
+			return map[string]any{
+				"protocolVersion": "2025-06-18",
+				"capabilities":    map[string]any{"tools": map[string]any{}, "completions": map[string]any{}},
+				"serverInfo":      map[string]any{"name": "mock-server", "version": "1.0.0"},
+			}, nil
+		}
+		var got json.RawMessage
+		server.handlers["completion/complete"] = func(params json.RawMessage) (any, error) {
+			got = params
+			return map[string]any{"completion": map[string]any{"values": []string{"python"}, "total": 1}}, nil
+		}
+
+		client, _ := New(server.URL, server.Client(), "test-client", "1.0.0")
+		completion, err := client.Complete(context.Background(), req, nil)
+		require.NoError(t, err)
+		assert.Equal(t, &transport.Completion{Values: []string{"python"}, Total: 1}, completion)
+		assert.JSONEq(t, `{"ref":{"type":"ref/prompt","name":"review"},"argument":{"name":"language","value":"py"},"context":{"arguments":{"style":"strict"}}}`, string(got))
+	})
+
+	t.Run("Negative Test - Server without completions", func(t *testing.T) {
+		server := newMockMCPServer(t)
+		defer server.Close()
+
+		client, _ := New(server.URL, server.Client(), "test-client", "1.0.0")
+		_, err := client.Complete(context.Background(), req, nil)
+		assert.ErrorContains(t, err, "does not support the 'completions' capability")
+	})
+}
+
+// benchRoundTripper answers requests from memory, so that benchmarks measure
+// the client rather than the network.
+type benchRoundTripper struct {
+	initialize []byte
+	call       []byte
+}
+
+func (b *benchRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	reply := b.call
+	switch {
+	case bytes.Contains(body, []byte(`"method":"initialize"`)):
+		reply = b.initialize
+	case bytes.Contains(body, []byte(`"method":"notifications/`)):
+		reply = nil
+	}
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": {"application/json"}},
+		Body:       io.NopCloser(bytes.NewReader(reply)),
+		Request:    req,
+	}, nil
+}
+
+func BenchmarkInvokeTool(b *testing.B) {
+	envelope := func(result any) []byte {
+		data, err := json.Marshal(result)
+		require.NoError(b, err)
+		resp, err := json.Marshal(jsonRPCResponse{JSONRPC: "2.0", ID: "1", Result: data})
+		require.NoError(b, err)
+		return resp
+	}
+	initialize := envelope(initializeResult{
+		ProtocolVersion: "2025-06-18",
+		Capabilities:    serverCapabilities{Tools: map[string]any{"listChanged": true}},
+		ServerInfo:      implementation{Name: "bench-server", Version: "1.0.0"},
+	})
+
+	for _, size := range []int{64, 16 << 10} {
+		b.Run(fmt.Sprintf("result=%dB", size), func(b *testing.B) {
+			rt := &benchRoundTripper{
+				initialize: initialize,
+				call: envelope(callToolResult{Content: []transport.ContentBlock{
+					{Type: "text", Text: strings.Repeat("x", size)},
+				}}),
+			}
+			client, err := New("http://toolbox.test", &http.Client{Transport: rt}, "bench-client", "1.0.0")
+			require.NoError(b, err)
+			ctx := context.Background()
+			args := map[string]any{"query": "SELECT * FROM hotels WHERE city = ?", "params": []any{"Basel"}, "limit": 10}
+
+			b.ReportAllocs()
+			b.ResetTimer()
+			for b.Loop() {
+				if _, err := client.InvokeTool(ctx, "search", args, nil); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -20,12 +20,14 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
-	"github.com/googleapis/mcp-toolbox-sdk-go/core/transport/mcp"
 	"testing"
 
+	"github.com/googleapis/mcp-toolbox-sdk-go/core/transport"
+	"github.com/googleapis/mcp-toolbox-sdk-go/core/transport/mcp"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -140,7 +142,7 @@ func TestHeaders_Presence(t *testing.T) {
 	assert.Equal(t, "2025-11-25", req.Headers.Get("MCP-Protocol-Version"))
 
 	// Requirement: Accept header must be present and application/json
-	assert.Equal(t, "application/json", req.Headers.Get("Accept"))
+	assert.Equal(t, "application/json, text/event-stream", req.Headers.Get("Accept"))
 }
 
 func TestListTools(t *testing.T) {
@@ -153,6 +155,7 @@ func TestListTools(t *testing.T) {
 			Tools: []mcpTool{
 				{
 					Name:        "get_weather",
+					Title:       "Weather Lookup",
 					Description: "Get weather for a location",
 					InputSchema: map[string]any{
 						"type": "object",
@@ -178,6 +181,7 @@ func TestListTools(t *testing.T) {
 		assert.Contains(t, manifest.Tools, "get_weather")
 		tool := manifest.Tools["get_weather"]
 		assert.Equal(t, "Get weather for a location", tool.Description)
+		assert.Equal(t, "Weather Lookup", tool.Title)
 		assert.Len(t, tool.Parameters, 1)
 		assert.Equal(t, "location", tool.Parameters[0].Name)
 	})
@@ -262,7 +266,7 @@ func TestInvokeTool(t *testing.T) {
 
 		msg, _ := callParams.Arguments["message"].(string)
 		return callToolResult{
-			Content: []textContent{
+			Content: []transport.ContentBlock{
 				{Type: "text", Text: "Echo: " + msg},
 			},
 			IsError: false,
@@ -452,7 +456,7 @@ func TestInvokeTool_ErrorResult(t *testing.T) {
 
 	server.handlers["tools/call"] = func(params json.RawMessage) (any, error) {
 		return callToolResult{
-			Content: []textContent{{Type: "text", Text: "Something went wrong"}},
+			Content: []transport.ContentBlock{{Type: "text", Text: "Something went wrong"}},
 			IsError: true,
 		}, nil
 	}
@@ -461,6 +465,10 @@ func TestInvokeTool_ErrorResult(t *testing.T) {
 	_, err := client.InvokeTool(context.Background(), "tool", nil, nil)
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "tool execution resulted in error")
+
+	var invErr *transport.ToolInvocationError
+	require.True(t, errors.As(err, &invErr))
+	assert.Equal(t, "Something went wrong", invErr.Message)
 }
 
 func TestInvokeTool_RPCError(t *testing.T) {
@@ -481,20 +489,24 @@ func TestInvokeTool_ComplexContent(t *testing.T) {
 	server := newMockMCPServer(t)
 	defer server.Close()
 
+	// Non-text content is returned as typed blocks alongside the text.
+	content := []transport.ContentBlock{
+		{Type: "text", Text: "Part 1 "},
+		{Type: "image", Data: "base64data", MimeType: "image/png"},
+		{Type: "audio", Data: "base64audio", MimeType: "audio/wav"},
+		{Type: "resource_link", URI: "file:///report.csv", Name: "report.csv", MimeType: "text/csv"},
+		{Type: "resource", Resource: &transport.ResourceContents{URI: "file:///notes.txt", MimeType: "text/plain", Text: "notes"}},
+		{Type: "text", Text: "Part 2"},
+	}
+
 	server.handlers["tools/call"] = func(params json.RawMessage) (any, error) {
-		return callToolResult{
-			Content: []textContent{
-				{Type: "text", Text: "Part 1 "},
-				{Type: "image", Text: "base64data"}, // Should be ignored
-				{Type: "text", Text: "Part 2"},
-			},
-		}, nil
+		return callToolResult{Content: content}, nil
 	}
 
 	client, _ := New(server.URL, server.Client(), "test-client", "1.0.0")
 	res, err := client.InvokeTool(context.Background(), "t", nil, nil)
 	require.NoError(t, err)
-	assert.Equal(t, "Part 1 Part 2", res)
+	assert.Equal(t, content, res)
 }
 
 func TestInvokeTool_EmptyResult(t *testing.T) {
@@ -503,7 +515,7 @@ func TestInvokeTool_EmptyResult(t *testing.T) {
 
 	server.handlers["tools/call"] = func(params json.RawMessage) (any, error) {
 		return callToolResult{
-			Content: []textContent{},
+			Content: []transport.ContentBlock{},
 		}, nil
 	}
 
@@ -512,6 +524,23 @@ func TestInvokeTool_EmptyResult(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, "null", res)
 }
+
+func TestInvokeTool_StructuredContent(t *testing.T) {
+	server := newMockMCPServer(t)
+	defer server.Close()
+
+	server.handlers["tools/call"] = func(params json.RawMessage) (any, error) {
+		return callToolResult{
+			Content:           []transport.ContentBlock{{Type: "text", Text: `{"temperature":21.5}`}},
+			StructuredContent: map[string]any{"temperature": 21.5},
+		}, nil
+	}
+
+	client, _ := New(server.URL, server.Client(), "test-client", "1.0.0")
+	res, err := client.InvokeTool(context.Background(), "t", nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"temperature": 21.5}, res)
+}
 func TestInvokeTool_ContentProcessing_Scenarios(t *testing.T) {
 	t.Run("Multiple JSON Objects (Merge to Array)", func(t *testing.T) {
 		server := newMockMCPServer(t)
@@ -520,7 +549,7 @@ func TestInvokeTool_ContentProcessing_Scenarios(t *testing.T) {
 		// Mock response with distinct JSON objects in separate text blocks
 		server.handlers["tools/call"] = func(params json.RawMessage) (any, error) {
 			return callToolResult{
-				Content: []textContent{
+				Content: []transport.ContentBlock{
 					{Type: "text", Text: `{"foo":"bar", "baz": "qux"}`},
 					{Type: "text", Text: `{"foo":"quux", "baz":"corge"}`},
 				},
@@ -544,7 +573,7 @@ func TestInvokeTool_ContentProcessing_Scenarios(t *testing.T) {
 		// Mock response where text is split across chunks but isn't JSON objects
 		server.handlers["tools/call"] = func(params json.RawMessage) (any, error) {
 			return callToolResult{
-				Content: []textContent{
+				Content: []transport.ContentBlock{
 					{Type: "text", Text: "Hello "},
 					{Type: "text", Text: "World"},
 				},
@@ -567,7 +596,7 @@ func TestInvokeTool_ContentProcessing_Scenarios(t *testing.T) {
 		// Mock response where a single JSON object is split across chunks.
 		server.handlers["tools/call"] = func(params json.RawMessage) (any, error) {
 			return callToolResult{
-				Content: []textContent{
+				Content: []transport.ContentBlock{
 					{Type: "text", Text: `{"a": `},
 					{Type: "text", Text: `1}`},
 				},
@@ -675,4 +704,43 @@ func TestNew_ClientVersion(t *testing.T) {
 			t.Errorf("expected clientVersion %q, got %q", mcp.SDKVersion, tr2.clientVersion)
 		}
 	})
-}
\ No newline at end of file
+}
+
+func TestInvokeTool_StreamedResponse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req jsonRPCRequest
+		body, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(body, &req)
+
+		switch req.Method {
+		case "initialize":
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(jsonRPCResponse{
+				JSONRPC: "2.0",
+				ID:      req.ID,
+				Result:  json.RawMessage(`{"protocolVersion":"2025-11-25","capabilities":{"tools":{}},"serverInfo":{"name":"mock","version":"1"}}`),
+			})
+		case "tools/call":
+			id, _ := json.Marshal(req.ID)
+			w.Header().Set("Content-Type", "text/event-stream")
+			fmt.Fprint(w, "event: message\ndata: {\"jsonrpc\":\"2.0\",\"method\":\"notifications/progress\",\"params\":{\"progress\":1}}\n\n")
+			fmt.Fprintf(w, "data: {\"jsonrpc\":\"2.0\",\"id\":%s,\"result\":{\"content\":[{\"type\":\"text\",\"text\":\"done\"}]}}\n\n", id)
+		default:
+			w.WriteHeader(http.StatusAccepted)
+		}
+	}))
+	defer server.Close()
+
+	var notifications []transport.Notification
+	ctx := transport.WithNotificationHandler(context.Background(), func(n transport.Notification) {
+		notifications = append(notifications, n)
+	})
+
+	client, _ := New(server.URL, server.Client(), "test-client", "1.0.0")
+	res, err := client.InvokeTool(ctx, "t", nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "done", res)
+	require.Len(t, notifications, 1)
+	assert.Equal(t, "notifications/progress", notifications[0].Method)
+	assert.JSONEq(t, `{"progress":1}`, string(notifications[0].Params))
+}
@@ -0,0 +1,553 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package ws implements an MCP transport over a WebSocket connection, with
+// each JSON-RPC message sent as a single text frame.
+package ws
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/googleapis/mcp-toolbox-sdk-go/core/transport"
+	"github.com/googleapis/mcp-toolbox-sdk-go/core/transport/mcp"
+	"github.com/gorilla/websocket"
+)
+
+const (
+	ProtocolVersion = "2025-06-18"
+
+	// subprotocol is the WebSocket subprotocol requested during the upgrade.
+	subprotocol = "mcp"
+)
+
+var (
+	// pingInterval is how often a ping frame is sent to keep the connection
+	// alive.
+	pingInterval = 30 * time.Second
+	// pongWait is how long the connection may stay silent, including missed
+	// pongs, before it is considered lost.
+	pongWait = 2 * pingInterval
+	// writeWait bounds the time spent writing a single frame.
+	writeWait = 10 * time.Second
+)
+
+// ErrClosed is returned for requests made after the transport was closed.
+var ErrClosed = errors.New("websocket transport is closed")
+
+// ErrConnectionLost is returned for requests that were in flight when the
+// connection dropped. The next request opens a new connection.
+var ErrConnectionLost = errors.New("websocket connection lost")
+
+// errNotSent marks requests that failed before reaching the server, which
+// are safe to retry on a new connection.
+var errNotSent = errors.New("request was not sent")
+
+// Ensure that McpTransport implements the Transport interface and the
+// optional interfaces for MCP server features.
+var (
+	_ transport.Transport        = &McpTransport{}
+	_ transport.PromptSource     = &McpTransport{}
+	_ transport.ResourceSource   = &McpTransport{}
+	_ transport.Completer        = &McpTransport{}
+	_ transport.Observable       = &McpTransport{}
+	_ transport.RequestReceiver  = &McpTransport{}
+	_ transport.ServerInfoSource = &McpTransport{}
+	_ transport.RequestTimeouter = &McpTransport{}
+	_ transport.Pinger           = &McpTransport{}
+)
+
+// McpTransport speaks MCP over a WebSocket connection.
+//
+// The connection is opened on the first request, kept alive with ping frames
+// and re-opened, with a new handshake, after it drops. Headers passed with the
+// first request are sent in the upgrade request; since a WebSocket cannot
+// carry headers per message, headers of later requests are ignored.
+type McpTransport struct {
+	*mcp.BaseMcpTransport
+	url             string
+	dialer          *websocket.Dialer
+	protocolVersion string
+	clientName      string
+	clientVersion   string
+
+	// keepAliveInterval and keepAliveFailures configure MCP pings, which
+	// are disabled if keepAliveInterval is 0.
+	keepAliveInterval time.Duration
+	keepAliveFailures int
+
+	// connLock serializes connecting, and guards conn and closed. It is a
+	// channel so that waiting for it honors the request context.
+	connLock chan struct{}
+	conn     *connection
+	closed   bool
+}
+
+// connection is a single open WebSocket and the requests waiting on it.
+type connection struct {
+	transport *McpTransport
+	ws        *websocket.Conn
+	writeMu   sync.Mutex
+	// netConn counts the bytes written to the network, if known.
+	netConn *countingConn
+
+	// rpc is closed once the read loop stops.
+	rpc *mcp.Conn
+}
+
+// New creates a new WebSocket transport instance. No connection is made until
+// the first request.
+//
+// Inputs:
+//   - baseURL: The ws:// or wss:// endpoint of the server. http:// and
+//     https:// URLs are converted to their WebSocket equivalents.
+//   - client: Its cookie jar and, for an *http.Transport, its proxy and TLS
+//     settings are used for the upgrade request. Other round trippers are not
+//     used, as the upgrade request is not sent through them. May be nil.
+//   - clientName: The client name sent in the handshake.
+//   - clientVersion: The client version sent in the handshake. Defaults to
+//     the SDK version.
+func New(baseURL string, client *http.Client, clientName string, clientVersion string, opts ...mcp.Option) (*McpTransport, error) {
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid WebSocket URL: %w", err)
+	}
+	switch u.Scheme {
+	case "ws", "wss":
+	case "http":
+		u.Scheme = "ws"
+	case "https":
+		u.Scheme = "wss"
+	default:
+		return nil, fmt.Errorf("unsupported WebSocket URL scheme '%s'", u.Scheme)
+	}
+
+	if clientVersion == "" {
+		clientVersion = mcp.SDKVersion
+	}
+
+	dialer := &websocket.Dialer{
+		Proxy:            http.ProxyFromEnvironment,
+		HandshakeTimeout: 45 * time.Second,
+		Subprotocols:     []string{subprotocol},
+	}
+	if client != nil {
+		dialer.Jar = client.Jar
+		if httpTransport, ok := client.Transport.(*http.Transport); ok {
+			dialer.Proxy = httpTransport.Proxy
+			dialer.TLSClientConfig = httpTransport.TLSClientConfig
+		}
+	}
+
+	t := &McpTransport{
+		BaseMcpTransport: &mcp.BaseMcpTransport{HTTPClient: client},
+		url:              u.String(),
+		dialer:           dialer,
+		protocolVersion:  ProtocolVersion,
+		clientName:       clientName,
+		clientVersion:    clientVersion,
+		connLock:         make(chan struct{}, 1),
+	}
+	t.HandshakeHook = func(ctx context.Context, headers map[string]string) error {
+		_, err := t.connection(ctx, headers)
+		return err
+	}
+	t.RequestHook = t.sendRequest
+
+	t.ApplyOptions(opts)
+	return t, nil
+}
+
+// BaseURL returns the WebSocket endpoint of the server.
+func (t *McpTransport) BaseURL() string {
+	return t.url
+}
+
+// Close closes the connection, if open. It is safe to call Close more than
+// once.
+func (t *McpTransport) Close(context.Context) error {
+	t.connLock <- struct{}{}
+	defer func() { <-t.connLock }()
+
+	t.closed = true
+	if t.conn != nil {
+		t.conn.close()
+		t.conn = nil
+	}
+	return nil
+}
+
+// SetKeepAlive makes the transport send MCP pings every interval, on top of
+// WebSocket ping frames, and drop the connection after maxFailures
+// consecutive pings fail, so that the next request reconnects. This detects
+// servers that are reachable but no longer responsive.
+func (t *McpTransport) SetKeepAlive(interval time.Duration, maxFailures int) {
+	t.keepAliveInterval = interval
+	t.keepAliveFailures = maxFailures
+}
+
+// ListTools fetches available tools. The toolset is determined by the
+// endpoint connected to, so toolsetName must be empty.
+func (t *McpTransport) ListTools(ctx context.Context, toolsetName string, headers map[string]string) (*transport.ManifestSchema, error) {
+	if toolsetName != "" {
+		return nil, fmt.Errorf("toolset '%s' cannot be selected over a WebSocket; connect to the toolset's endpoint instead", toolsetName)
+	}
+
+	var result mcp.ListToolsResult
+	if err := t.sendRequest(ctx, "tools/list", map[string]any{}, headers, &result); err != nil {
+		return nil, fmt.Errorf("failed to list tools: %w", err)
+	}
+
+	manifest := &transport.ManifestSchema{
+		ServerVersion: t.ServerVersion,
+		Tools:         make(map[string]transport.ToolSchema),
+	}
+
+	for i, tool := range result.Tools {
+		if tool.Name == "" {
+			return nil, fmt.Errorf("received invalid tool definition at index %d: missing 'name' field", i)
+		}
+
+		rawTool := map[string]any{
+			"name":        tool.Name,
+			"description": tool.Description,
+			"inputSchema": tool.InputSchema,
+		}
+		if tool.Title != "" {
+			rawTool["title"] = tool.Title
+		}
+		if tool.Annotations != nil {
+			rawTool["annotations"] = tool.Annotations
+		}
+		if tool.OutputSchema != nil {
+			rawTool["outputSchema"] = tool.OutputSchema
+		}
+		if tool.Meta != nil {
+			rawTool["_meta"] = tool.Meta
+		}
+
+		toolSchema, err := t.ConvertToolDefinition(rawTool)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert schema for tool %s: %w", tool.Name, err)
+		}
+
+		manifest.Tools[tool.Name] = toolSchema
+	}
+
+	return manifest, nil
+}
+
+// GetTool fetches a single tool
+func (t *McpTransport) GetTool(ctx context.Context, toolName string, headers map[string]string) (*transport.ManifestSchema, error) {
+	manifest, err := t.ListTools(ctx, "", headers)
+	if err != nil {
+		return nil, err
+	}
+
+	tool, exists := manifest.Tools[toolName]
+	if !exists {
+		return nil, fmt.Errorf("tool '%s' not found", toolName)
+	}
+
+	return &transport.ManifestSchema{
+		ServerVersion: manifest.ServerVersion,
+		Tools:         map[string]transport.ToolSchema{toolName: tool},
+	}, nil
+}
+
+// InvokeTool executes a tool
+func (t *McpTransport) InvokeTool(ctx context.Context, toolName string, payload map[string]any, headers map[string]string) (any, error) {
+	meta, done := t.TrackProgress(ctx)
+	defer done()
+	params := mcp.CallToolParams{
+		Name:      toolName,
+		Arguments: payload,
+		Meta:      meta,
+	}
+
+	var result mcp.CallToolResult
+	if err := t.sendRequest(ctx, "tools/call", params, headers, &result); err != nil {
+		return "", fmt.Errorf("failed to invoke tool '%s': %w", toolName, err)
+	}
+
+	if result.IsError {
+		// Surface the error text reported by the tool, if any.
+		var message string
+		for _, c := range result.Content {
+			if c.Type == "text" {
+				message += c.Text
+			}
+		}
+		return "", &transport.ToolInvocationError{Message: message}
+	}
+
+	// Tools with an output schema return their result as structured
+	// content, which is preserved rather than flattened into text.
+	if result.StructuredContent != nil {
+		return result.StructuredContent, nil
+	}
+
+	return t.ProcessToolResultContent(result.Content), nil
+}
+
+// sendRequest sends a JSON-RPC request on the current connection, opening a
+// new one if needed. A request that could not be written because the
+// connection dropped is retried once on a new connection.
+func (t *McpTransport) sendRequest(ctx context.Context, method string, params any, headers map[string]string, dest any) error {
+	for attempt := 0; ; attempt++ {
+		c, err := t.connection(ctx, headers)
+		if err != nil {
+			return err
+		}
+		err = t.request(ctx, c, method, params, dest)
+		if errors.Is(err, errNotSent) && attempt == 0 {
+			continue
+		}
+		return err
+	}
+}
+
+// connection returns the open connection, dialing and performing the
+// handshake first if there is none.
+func (t *McpTransport) connection(ctx context.Context, headers map[string]string) (*connection, error) {
+	select {
+	case t.connLock <- struct{}{}:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	defer func() { <-t.connLock }()
+
+	if t.closed {
+		return nil, ErrClosed
+	}
+	if t.conn != nil && !t.conn.isDone() {
+		return t.conn, nil
+	}
+
+	c, err := t.dial(ctx, headers)
+	if err != nil {
+		return nil, err
+	}
+	t.conn = c
+	return c, nil
+}
+
+// dial opens a new connection and performs the initial handshake on it.
+func (t *McpTransport) dial(ctx context.Context, headers map[string]string) (*connection, error) {
+	requestHeader := make(http.Header)
+	for k, v := range headers {
+		requestHeader.Set(k, v)
+	}
+
+	// Count the bytes written to the network, so that a failed write can be
+	// told apart from one that sent part of a message.
+	dialer := *t.dialer
+	netDial := dialer.NetDialContext
+	if netDial == nil {
+		netDial = (&net.Dialer{}).DialContext
+	}
+	var netConn *countingConn
+	dialer.NetDialContext = func(ctx context.Context, network, addr string) (net.Conn, error) {
+		conn, err := netDial(ctx, network, addr)
+		if err != nil {
+			return nil, err
+		}
+		netConn = &countingConn{Conn: conn}
+		return netConn, nil
+	}
+
+	wsConn, resp, err := dialer.DialContext(ctx, t.url, requestHeader)
+	if err != nil {
+		if resp != nil && resp.StatusCode != http.StatusSwitchingProtocols {
+			body, _ := io.ReadAll(resp.Body)
+			resp.Body.Close()
+			return nil, transport.NewHTTPResponseError(resp, body)
+		}
+		return nil, fmt.Errorf("websocket dial failed: %w", err)
+	}
+
+	c := &connection{
+		transport: t,
+		ws:        wsConn,
+		netConn:   netConn,
+	}
+	c.rpc = mcp.NewConn(t.BaseMcpTransport, c.write)
+	_ = wsConn.SetReadDeadline(time.Now().Add(pongWait))
+	wsConn.SetPongHandler(func(string) error {
+		return wsConn.SetReadDeadline(time.Now().Add(pongWait))
+	})
+	go c.readLoop()
+	go c.keepAlive()
+
+	if err := t.initializeSession(ctx, c); err != nil {
+		c.close()
+		return nil, err
+	}
+	if t.keepAliveInterval > 0 {
+		go mcp.RunKeepAlive(c.rpc.Done(), t.keepAliveInterval, t.keepAliveFailures, func(ctx context.Context) error {
+			return t.request(ctx, c, "ping", nil, nil)
+		}, c.close)
+	}
+	return c, nil
+}
+
+// initializeSession performs the initial handshake on a new connection.
+func (t *McpTransport) initializeSession(ctx context.Context, c *connection) error {
+	params := mcp.InitializeParams{
+		ProtocolVersion: t.protocolVersion,
+		Capabilities:    t.ClientCapabilities(),
+		ClientInfo: mcp.Implementation{
+			Name:    t.clientName,
+			Title:   t.ClientTitle(),
+			Version: t.clientVersion,
+		},
+	}
+
+	var result mcp.InitializeResult
+	if err := t.request(ctx, c, "initialize", params, &result); err != nil {
+		return err
+	}
+
+	// Protocol Version Check
+	if result.ProtocolVersion != t.protocolVersion {
+		return fmt.Errorf("MCP version mismatch: client (%s) != server (%s)", t.protocolVersion, result.ProtocolVersion)
+	}
+
+	// Capabilities Check
+	if result.Capabilities.Tools == nil {
+		return fmt.Errorf("server does not support the 'tools' capability")
+	}
+
+	t.ServerVersion = result.ServerInfo.Version
+	t.SetServerInfo(result.Capabilities.All, result.Instructions)
+
+	// Confirm Handshake
+	return c.write(ctx, mcp.RPCNotification{
+		JSONRPC: "2.0",
+		Method:  "notifications/initialized",
+		Params:  map[string]any{},
+	})
+}
+
+// request sends a JSON-RPC request on c and waits for the response with the
+// same ID.
+func (t *McpTransport) request(ctx context.Context, c *connection, method string, params any, dest any) error {
+	return c.rpc.Call(ctx, c.write, method, params, dest)
+}
+
+// write sends a single message as a text frame.
+func (c *connection) write(_ context.Context, msg any) error {
+	payload, err := json.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("marshal failed: %w", err)
+	}
+	if c.isDone() {
+		return fmt.Errorf("%w: %w", errNotSent, ErrConnectionLost)
+	}
+
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	_ = c.ws.SetWriteDeadline(time.Now().Add(writeWait))
+	var before int64
+	if c.netConn != nil {
+		before = c.netConn.written.Load()
+	}
+	if err := c.ws.WriteMessage(websocket.TextMessage, payload); err != nil {
+		// The connection cannot be written to anymore. Drop it, so that the
+		// next request opens a new one.
+		_ = c.ws.Close()
+		<-c.rpc.Done()
+		// Only a message of which nothing was written is safe to send again:
+		// the server may act on a partially written one.
+		if c.netConn != nil && c.netConn.written.Load() == before {
+			return fmt.Errorf("%w: failed to write to server: %w", errNotSent, err)
+		}
+		return fmt.Errorf("failed to write to server: %w", err)
+	}
+	return nil
+}
+
+// countingConn counts the bytes written to a network connection.
+type countingConn struct {
+	net.Conn
+	written atomic.Int64
+}
+
+func (c *countingConn) Write(p []byte) (int, error) {
+	n, err := c.Conn.Write(p)
+	c.written.Add(int64(n))
+	return n, err
+}
+
+// readLoop reads messages until the connection fails, dispatching responses
+// to the requests waiting for them.
+func (c *connection) readLoop() {
+	var err error
+	for {
+		var data []byte
+		_, data, err = c.ws.ReadMessage()
+		if err != nil {
+			break
+		}
+		_ = c.ws.SetReadDeadline(time.Now().Add(pongWait))
+		c.rpc.HandleMessage(data)
+	}
+	c.rpc.Close(fmt.Errorf("%w: %v", ErrConnectionLost, err))
+	_ = c.ws.Close()
+}
+
+// keepAlive sends ping frames until the connection is closed.
+func (c *connection) keepAlive() {
+	ticker := time.NewTicker(pingInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := c.ws.WriteControl(websocket.PingMessage, nil, time.Now().Add(writeWait)); err != nil {
+				_ = c.ws.Close()
+				return
+			}
+		case <-c.rpc.Done():
+			return
+		}
+	}
+}
+
+// isDone reports whether the connection has failed or been closed.
+func (c *connection) isDone() bool {
+	select {
+	case <-c.rpc.Done():
+		return true
+	default:
+		return false
+	}
+}
+
+// close sends a close frame, closes the connection and waits for the read
+// loop to stop.
+func (c *connection) close() {
+	_ = c.ws.WriteControl(websocket.CloseMessage,
+		websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""), time.Now().Add(writeWait))
+	_ = c.ws.Close()
+	<-c.rpc.Done()
+}
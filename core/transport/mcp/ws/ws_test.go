@@ -0,0 +1,393 @@
+//go:build unit
+
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ws
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/googleapis/mcp-toolbox-sdk-go/core/transport"
+	"github.com/googleapis/mcp-toolbox-sdk-go/core/transport/mcp"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockWSServer is a minimal MCP server speaking JSON-RPC over WebSocket.
+type mockWSServer struct {
+	*httptest.Server
+
+	mu          sync.Mutex
+	conns       []*websocket.Conn
+	headers     []http.Header
+	initializes int
+	pings       atomic.Int32
+	mcpPings    atomic.Int32
+	// ignorePings makes the server stop answering MCP pings.
+	ignorePings atomic.Bool
+}
+
+func newMockWSServer(t *testing.T) *mockWSServer {
+	m := &mockWSServer{}
+	upgrader := websocket.Upgrader{Subprotocols: []string{subprotocol}}
+
+	m.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		conn.SetPingHandler(func(data string) error {
+			m.pings.Add(1)
+			return conn.WriteControl(websocket.PongMessage, []byte(data), time.Now().Add(time.Second))
+		})
+
+		m.mu.Lock()
+		m.conns = append(m.conns, conn)
+		m.headers = append(m.headers, r.Header.Clone())
+		m.mu.Unlock()
+
+		for {
+			_, data, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			var req struct {
+				ID     json.RawMessage `json:"id"`
+				Method string          `json:"method"`
+				Params json.RawMessage `json:"params"`
+			}
+			require.NoError(t, json.Unmarshal(data, &req))
+			if len(req.ID) == 0 {
+				continue
+			}
+
+			var result any
+			switch req.Method {
+			case "ping":
+				m.mcpPings.Add(1)
+				if m.ignorePings.Load() {
+					continue
+				}
+				result = map[string]any{}
+			case "initialize":
+				m.mu.Lock()
+				m.initializes++
+				m.mu.Unlock()
+				result = map[string]any{
+					"protocolVersion": ProtocolVersion,
+					"capabilities":    map[string]any{"tools": map[string]any{}},
+					"serverInfo":      map[string]any{"name": "mock", "version": "2.0.0"},
+				}
+			case "tools/list":
+				result = map[string]any{"tools": []map[string]any{{
+					"name":        "echo",
+					"title":       "Echo",
+					"inputSchema": map[string]any{"type": "object"},
+				}}}
+			case "tools/call":
+				var params mcp.CallToolParams
+				_ = json.Unmarshal(req.Params, &params)
+				if params.Name == "fail" {
+					result = map[string]any{"content": []map[string]any{{"type": "text", "text": "boom"}}, "isError": true}
+				} else if params.Name == "notify" {
+					note, _ := json.Marshal(map[string]any{"jsonrpc": "2.0", "method": "notifications/tools/list_changed"})
+					if err := conn.WriteMessage(websocket.TextMessage, note); err != nil {
+						return
+					}
+					result = map[string]any{"content": []map[string]any{}}
+				} else {
+					result = map[string]any{"content": []map[string]any{{"type": "text", "text": fmt.Sprint(params.Arguments["text"])}}}
+				}
+			}
+			resp, _ := json.Marshal(map[string]any{"jsonrpc": "2.0", "id": req.ID, "result": result})
+			if err := conn.WriteMessage(websocket.TextMessage, resp); err != nil {
+				return
+			}
+		}
+	}))
+	t.Cleanup(m.Close)
+	return m
+}
+
+// dropConnections closes all server-side connections.
+func (m *mockWSServer) dropConnections() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, conn := range m.conns {
+		_ = conn.Close()
+	}
+}
+
+func newTestTransport(t *testing.T, server *mockWSServer) *McpTransport {
+	t.Helper()
+	tr, err := New(server.URL, server.Client(), "test-client", "1.0.0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = tr.Close(context.Background()) })
+	return tr
+}
+
+func TestNew(t *testing.T) {
+	tests := map[string]string{
+		"ws://host/mcp":    "ws://host/mcp",
+		"wss://host/mcp":   "wss://host/mcp",
+		"http://host/mcp":  "ws://host/mcp",
+		"https://host/mcp": "wss://host/mcp",
+	}
+	for in, want := range tests {
+		tr, err := New(in, nil, "c", "")
+		require.NoError(t, err)
+		assert.Equal(t, want, tr.BaseURL())
+	}
+
+	_, err := New("ftp://host", nil, "c", "")
+	assert.ErrorContains(t, err, "unsupported WebSocket URL scheme 'ftp'")
+}
+
+func TestListAndInvoke(t *testing.T) {
+	server := newMockWSServer(t)
+	tr := newTestTransport(t, server)
+	ctx := context.Background()
+
+	manifest, err := tr.ListTools(ctx, "", map[string]string{"Authorization": "Bearer token"})
+	require.NoError(t, err)
+	assert.Equal(t, "2.0.0", manifest.ServerVersion)
+	require.Contains(t, manifest.Tools, "echo")
+	assert.Equal(t, "Echo", manifest.Tools["echo"].Title)
+
+	result, err := tr.InvokeTool(ctx, "echo", map[string]any{"text": "hello"}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "hello", result)
+
+	_, err = tr.InvokeTool(ctx, "fail", nil, nil)
+	var invocationErr *transport.ToolInvocationError
+	require.True(t, errors.As(err, &invocationErr))
+	assert.Equal(t, "boom", invocationErr.Message)
+
+	server.mu.Lock()
+	defer server.mu.Unlock()
+	assert.Len(t, server.conns, 1, "Expected a single connection to be reused")
+	assert.Equal(t, "Bearer token", server.headers[0].Get("Authorization"))
+}
+
+func TestNotifications(t *testing.T) {
+	server := newMockWSServer(t)
+	tr := newTestTransport(t, server)
+	received := make(chan transport.Notification, 1)
+	tr.SetNotificationHandler(func(n transport.Notification) { received <- n })
+
+	_, err := tr.InvokeTool(context.Background(), "notify", nil, nil)
+	require.NoError(t, err)
+	select {
+	case n := <-received:
+		assert.Equal(t, "notifications/tools/list_changed", n.Method)
+	case <-time.After(time.Second):
+		t.Fatal("Expected the notification to be delivered")
+	}
+}
+
+func TestConcurrentRequests(t *testing.T) {
+	server := newMockWSServer(t)
+	tr := newTestTransport(t, server)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			result, err := tr.InvokeTool(context.Background(), "echo", map[string]any{"text": i}, nil)
+			assert.NoError(t, err)
+			assert.Equal(t, fmt.Sprint(i), result)
+		}(i)
+	}
+	wg.Wait()
+}
+
+func TestReconnect(t *testing.T) {
+	server := newMockWSServer(t)
+	tr := newTestTransport(t, server)
+	ctx := context.Background()
+
+	_, err := tr.InvokeTool(ctx, "echo", map[string]any{"text": "a"}, nil)
+	require.NoError(t, err)
+
+	server.dropConnections()
+	require.Eventually(t, func() bool {
+		tr.connLock <- struct{}{}
+		defer func() { <-tr.connLock }()
+		return tr.conn.isDone()
+	}, time.Second, 10*time.Millisecond)
+
+	result, err := tr.InvokeTool(ctx, "echo", map[string]any{"text": "b"}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "b", result)
+
+	server.mu.Lock()
+	defer server.mu.Unlock()
+	assert.Len(t, server.conns, 2)
+	assert.Equal(t, 2, server.initializes, "Expected a new handshake after reconnecting")
+}
+
+func TestKeepAlive(t *testing.T) {
+	oldInterval := pingInterval
+	pingInterval = 10 * time.Millisecond
+	t.Cleanup(func() { pingInterval = oldInterval })
+
+	server := newMockWSServer(t)
+	tr := newTestTransport(t, server)
+
+	_, err := tr.ListTools(context.Background(), "", nil)
+	require.NoError(t, err)
+	assert.Eventually(t, func() bool { return server.pings.Load() >= 2 }, time.Second, 10*time.Millisecond)
+}
+
+func TestMCPKeepAlive(t *testing.T) {
+	server := newMockWSServer(t)
+	tr := newTestTransport(t, server)
+	tr.SetKeepAlive(20*time.Millisecond, 2)
+	ctx := context.Background()
+
+	_, err := tr.ListTools(ctx, "", nil)
+	require.NoError(t, err)
+	assert.Eventually(t, func() bool { return server.mcpPings.Load() >= 2 }, time.Second, 10*time.Millisecond)
+
+	// A server that stops answering pings is disconnected from.
+	server.ignorePings.Store(true)
+	require.Eventually(t, func() bool {
+		tr.connLock <- struct{}{}
+		defer func() { <-tr.connLock }()
+		return tr.conn.isDone()
+	}, time.Second, 10*time.Millisecond)
+
+	server.ignorePings.Store(false)
+	_, err = tr.ListTools(ctx, "", nil)
+	require.NoError(t, err)
+	server.mu.Lock()
+	defer server.mu.Unlock()
+	assert.Equal(t, 2, server.initializes, "Expected a new handshake after reconnecting")
+}
+
+func TestErrors(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Rejects toolset names", func(t *testing.T) {
+		tr := newTestTransport(t, newMockWSServer(t))
+		_, err := tr.ListTools(ctx, "my-toolset", nil)
+		assert.ErrorContains(t, err, "cannot be selected over a WebSocket")
+	})
+
+	t.Run("Surfaces a rejected upgrade", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "forbidden", http.StatusForbidden)
+		}))
+		defer server.Close()
+		tr, err := New(server.URL, nil, "c", "v")
+		require.NoError(t, err)
+
+		_, err = tr.ListTools(ctx, "", nil)
+		var invocationErr *transport.ToolInvocationError
+		require.True(t, errors.As(err, &invocationErr))
+		assert.Equal(t, http.StatusForbidden, invocationErr.StatusCode)
+		assert.True(t, strings.Contains(invocationErr.Message, "forbidden"))
+	})
+
+	t.Run("Fails requests after Close", func(t *testing.T) {
+		tr := newTestTransport(t, newMockWSServer(t))
+		_, err := tr.ListTools(ctx, "", nil)
+		require.NoError(t, err)
+
+		require.NoError(t, tr.Close(context.Background()))
+		require.NoError(t, tr.Close(context.Background()))
+		_, err = tr.ListTools(ctx, "", nil)
+		assert.ErrorIs(t, err, ErrClosed)
+	})
+}
+
+// failingConn fails the next write as set by failWrite: 1 fails without
+// writing anything, 2 writes half of the data first.
+type failingConn struct {
+	net.Conn
+	failWrite *atomic.Int32
+}
+
+func (c *failingConn) Write(p []byte) (int, error) {
+	switch c.failWrite.Swap(0) {
+	case 1:
+		return 0, errors.New("write failed")
+	case 2:
+		n, _ := c.Conn.Write(p[:len(p)/2])
+		return n, errors.New("write failed")
+	}
+	return c.Conn.Write(p)
+}
+
+func TestWriteFailures(t *testing.T) {
+	ctx := context.Background()
+	newFailingTransport := func(t *testing.T) (*McpTransport, *mockWSServer, *atomic.Int32) {
+		server := newMockWSServer(t)
+		tr := newTestTransport(t, server)
+		failWrite := &atomic.Int32{}
+		tr.dialer.NetDialContext = func(ctx context.Context, network, addr string) (net.Conn, error) {
+			conn, err := (&net.Dialer{}).DialContext(ctx, network, addr)
+			if err != nil {
+				return nil, err
+			}
+			return &failingConn{Conn: conn, failWrite: failWrite}, nil
+		}
+		_, err := tr.InvokeTool(ctx, "echo", map[string]any{"text": "a"}, nil)
+		require.NoError(t, err)
+		return tr, server, failWrite
+	}
+
+	t.Run("Resends requests that were not written on a new connection", func(t *testing.T) {
+		tr, server, failWrite := newFailingTransport(t)
+		failWrite.Store(1)
+		result, err := tr.InvokeTool(ctx, "echo", map[string]any{"text": "b"}, nil)
+		require.NoError(t, err)
+		assert.Equal(t, "b", result)
+
+		server.mu.Lock()
+		defer server.mu.Unlock()
+		assert.Len(t, server.conns, 2)
+	})
+
+	t.Run("Does not resend partially written requests", func(t *testing.T) {
+		tr, server, failWrite := newFailingTransport(t)
+		failWrite.Store(2)
+		_, err := tr.InvokeTool(ctx, "echo", map[string]any{"text": "b"}, nil)
+		require.ErrorContains(t, err, "write failed")
+		assert.NotErrorIs(t, err, errNotSent)
+
+		server.mu.Lock()
+		assert.Len(t, server.conns, 1, "Expected the request not to be resent")
+		server.mu.Unlock()
+
+		// The next request opens a new connection.
+		result, err := tr.InvokeTool(ctx, "echo", map[string]any{"text": "c"}, nil)
+		require.NoError(t, err)
+		assert.Equal(t, "c", result)
+	})
+}
@@ -0,0 +1,74 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transport
+
+import (
+	"context"
+	"encoding/json"
+)
+
+type notificationHandlerKey struct{}
+
+// Notification is a JSON-RPC notification sent by the server while a request
+// is in progress, such as a progress update or a log message.
+type Notification struct {
+	Method string          `json:"method"`
+	Params json.RawMessage `json:"params,omitempty"`
+}
+
+// NotificationHandler receives the notifications sent by the server while a
+// request is in progress. It is called synchronously, in the order the
+// notifications arrive.
+type NotificationHandler func(Notification)
+
+// WithNotificationHandler returns a copy of ctx that delivers the
+// notifications received during requests made with it to handler.
+func WithNotificationHandler(ctx context.Context, handler NotificationHandler) context.Context {
+	return context.WithValue(ctx, notificationHandlerKey{}, handler)
+}
+
+// Notify delivers n to the notification handler carried by ctx, if any, and
+// reports whether there was one.
+func Notify(ctx context.Context, n Notification) bool {
+	handler, ok := ctx.Value(notificationHandlerKey{}).(NotificationHandler)
+	if !ok || handler == nil {
+		return false
+	}
+	handler(n)
+	return true
+}
+
+// NotificationHandlerFrom returns the notification handler carried by ctx, or
+// nil if there is none.
+func NotificationHandlerFrom(ctx context.Context) NotificationHandler {
+	handler, _ := ctx.Value(notificationHandlerKey{}).(NotificationHandler)
+	return handler
+}
+
+type progressTokenKey struct{}
+
+// WithProgressToken returns a copy of ctx that asks the server to report the
+// progress of tool invocations made with it, tagging the progress
+// notifications with token.
+func WithProgressToken(ctx context.Context, token string) context.Context {
+	return context.WithValue(ctx, progressTokenKey{}, token)
+}
+
+// ProgressToken returns the progress token carried by ctx, or an empty string
+// if there is none.
+func ProgressToken(ctx context.Context) string {
+	token, _ := ctx.Value(progressTokenKey{}).(string)
+	return token
+}
@@ -0,0 +1,55 @@
+//go:build unit
+
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transport
+
+import (
+	"context"
+	"testing"
+)
+
+func TestNotify(t *testing.T) {
+	// Without a handler, notifications are dropped.
+	if Notify(context.Background(), Notification{Method: "notifications/progress"}) {
+		t.Error("Expected Notify to report that there is no handler")
+	}
+	if NotificationHandlerFrom(context.Background()) != nil {
+		t.Error("Expected no handler")
+	}
+
+	var got []string
+	ctx := WithNotificationHandler(context.Background(), func(n Notification) {
+		got = append(got, n.Method)
+	})
+	if !Notify(ctx, Notification{Method: "notifications/progress"}) {
+		t.Error("Expected Notify to report delivery")
+	}
+	Notify(ctx, Notification{Method: "notifications/message"})
+	NotificationHandlerFrom(ctx)(Notification{Method: "notifications/other"})
+
+	if len(got) != 3 || got[0] != "notifications/progress" || got[1] != "notifications/message" || got[2] != "notifications/other" {
+		t.Errorf("Expected notifications in order, got %v", got)
+	}
+}
+
+func TestProgressToken(t *testing.T) {
+	if got := ProgressToken(context.Background()); got != "" {
+		t.Errorf("Expected no token, got %q", got)
+	}
+	if got := ProgressToken(WithProgressToken(context.Background(), "abc")); got != "abc" {
+		t.Errorf("Expected token 'abc', got %q", got)
+	}
+}
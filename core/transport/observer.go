@@ -0,0 +1,80 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transport
+
+import (
+	"context"
+	"time"
+)
+
+// Observer receives the start and end of every JSON-RPC request a transport
+// sends, such as tools/list and tools/call, for logging, metrics or tracing.
+// Notifications are not reported. Methods are called synchronously and may be
+// called concurrently, so implementations must be fast and safe for
+// concurrent use.
+type Observer interface {
+	// OnRPCStart is called before a request is sent.
+	OnRPCStart(ctx context.Context, method string)
+	// OnRPCEnd is called once the request completed, with the time it took
+	// and the error it failed with, if any.
+	OnRPCEnd(ctx context.Context, method string, duration time.Duration, err error)
+}
+
+// Observable is implemented by transports that report their requests to an
+// Observer.
+type Observable interface {
+	// SetObserver sets the observer of all subsequent requests.
+	SetObserver(observer Observer)
+}
+
+// RetryObserver is implemented by Observers that are also told about the
+// requests retried by a transport, as configured with SetRetryPolicy.
+type RetryObserver interface {
+	Observer
+	// OnRetry is called before a failed attempt of a request is retried,
+	// with the number of the failed attempt, starting at 1, and the HTTP
+	// status of its response or the error it failed with.
+	OnRetry(ctx context.Context, attempt int, statusCode int, err error)
+}
+
+type toolNameKey struct{}
+
+type toolsetNameKey struct{}
+
+// WithToolName returns a copy of ctx recording that its requests are made for
+// the tool with the given name, so that Observers can report it.
+func WithToolName(ctx context.Context, name string) context.Context {
+	return context.WithValue(ctx, toolNameKey{}, name)
+}
+
+// ToolName returns the tool name recorded in ctx, or an empty string if there
+// is none.
+func ToolName(ctx context.Context) string {
+	name, _ := ctx.Value(toolNameKey{}).(string)
+	return name
+}
+
+// WithToolsetName returns a copy of ctx recording that its requests load the
+// toolset with the given name, so that Observers can report it.
+func WithToolsetName(ctx context.Context, name string) context.Context {
+	return context.WithValue(ctx, toolsetNameKey{}, name)
+}
+
+// ToolsetName returns the toolset name recorded in ctx, or an empty string if
+// there is none, as for the default toolset.
+func ToolsetName(ctx context.Context) string {
+	name, _ := ctx.Value(toolsetNameKey{}).(string)
+	return name
+}
@@ -0,0 +1,72 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transport
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+type queryParamsKey struct{}
+
+// WithQueryParams returns a copy of ctx whose HTTP requests carry the given
+// query parameters, in addition to those of their URL, such as feature flags
+// or API versions understood by the server. Parameters already carried by
+// ctx are kept, except for the keys in params, whose values replace them.
+// Transports that do not use HTTP, such as stdio, ignore them.
+func WithQueryParams(ctx context.Context, params url.Values) context.Context {
+	merged := QueryParams(ctx)
+	if merged == nil {
+		merged = make(url.Values, len(params))
+	}
+	for key, values := range params {
+		merged[key] = append([]string(nil), values...)
+	}
+	return context.WithValue(ctx, queryParamsKey{}, merged)
+}
+
+// QueryParams returns a copy of the query parameters carried by ctx, or nil
+// if there are none.
+func QueryParams(ctx context.Context) url.Values {
+	params, _ := ctx.Value(queryParamsKey{}).(url.Values)
+	if params == nil {
+		return nil
+	}
+	copied := make(url.Values, len(params))
+	for key, values := range params {
+		copied[key] = append([]string(nil), values...)
+	}
+	return copied
+}
+
+// AddQueryParams returns req with the query parameters carried by its
+// context added to its URL. req is returned unchanged if there are none, and
+// is cloned otherwise.
+func AddQueryParams(req *http.Request) *http.Request {
+	params, _ := req.Context().Value(queryParamsKey{}).(url.Values)
+	if len(params) == 0 {
+		return req
+	}
+	req = req.Clone(req.Context())
+	query := req.URL.Query()
+	for key, values := range params {
+		for _, value := range values {
+			query.Add(key, value)
+		}
+	}
+	req.URL.RawQuery = query.Encode()
+	return req
+}
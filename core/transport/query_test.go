@@ -0,0 +1,62 @@
+//go:build unit
+
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transport
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+)
+
+func TestQueryParams(t *testing.T) {
+	t.Run("Merges parameters, replacing repeated keys", func(t *testing.T) {
+		ctx := WithQueryParams(context.Background(), url.Values{"a": {"1"}, "b": {"2"}})
+		ctx = WithQueryParams(ctx, url.Values{"b": {"3", "4"}})
+		if got := QueryParams(ctx).Encode(); got != "a=1&b=3&b=4" {
+			t.Errorf("Expected a=1&b=3&b=4, got %q", got)
+		}
+	})
+
+	t.Run("Returns nil without parameters", func(t *testing.T) {
+		if got := QueryParams(context.Background()); got != nil {
+			t.Errorf("Expected nil, got %v", got)
+		}
+	})
+
+	t.Run("Adds parameters to a copy of the request", func(t *testing.T) {
+		ctx := WithQueryParams(context.Background(), url.Values{"version": {"2"}})
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, "http://example.com/mcp?session=x", nil)
+		if err != nil {
+			t.Fatalf("NewRequest failed: %v", err)
+		}
+		got := AddQueryParams(req)
+		if got.URL.RawQuery != "session=x&version=2" {
+			t.Errorf("Expected session=x&version=2, got %q", got.URL.RawQuery)
+		}
+		if req.URL.RawQuery != "session=x" {
+			t.Errorf("Expected the original request to be unchanged, got %q", req.URL.RawQuery)
+		}
+	})
+
+	t.Run("Leaves requests without parameters unchanged", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodPost, "http://example.com/mcp", nil)
+		if got := AddQueryParams(req); got != req {
+			t.Error("Expected the same request")
+		}
+	})
+}
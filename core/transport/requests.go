@@ -0,0 +1,40 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transport
+
+import (
+	"context"
+	"encoding/json"
+)
+
+// ServerRequestHandler answers a JSON-RPC request sent by the server, such as
+// sampling/createMessage. It returns the result to send back, or an error to
+// report to the server instead. A *ToolInvocationError with a non-zero Code
+// is reported with that code.
+type ServerRequestHandler func(ctx context.Context, params json.RawMessage) (any, error)
+
+type requestMetaKey struct{}
+
+// WithRequestMeta returns a copy of ctx that attaches meta to the _meta of
+// the tool invocations made with it, such as tracing or tenant hints.
+func WithRequestMeta(ctx context.Context, meta map[string]any) context.Context {
+	return context.WithValue(ctx, requestMetaKey{}, meta)
+}
+
+// RequestMeta returns the _meta carried by ctx, or nil if there is none.
+func RequestMeta(ctx context.Context) map[string]any {
+	meta, _ := ctx.Value(requestMetaKey{}).(map[string]any)
+	return meta
+}
@@ -0,0 +1,65 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transport
+
+import (
+	"encoding/base64"
+	"fmt"
+)
+
+// ResourceDescriptor describes a resource offered by the server, as listed by
+// resources/list.
+type ResourceDescriptor struct {
+	URI         string `json:"uri"`
+	Name        string `json:"name"`
+	Title       string `json:"title,omitempty"`
+	Description string `json:"description,omitempty"`
+	MimeType    string `json:"mimeType,omitempty"`
+	// Size is the size of the raw content in bytes, or 0 if unknown.
+	Size int64 `json:"size,omitempty"`
+}
+
+// ResourceContents is the content of a resource, as returned by
+// resources/read. Exactly one of Text and Blob is set; Blob holds
+// base64-encoded binary data.
+type ResourceContents struct {
+	URI      string `json:"uri"`
+	MimeType string `json:"mimeType,omitempty"`
+	Text     string `json:"text,omitempty"`
+	Blob     string `json:"blob,omitempty"`
+}
+
+// Bytes returns the raw content of the resource: the text, or the decoded
+// blob.
+func (c ResourceContents) Bytes() ([]byte, error) {
+	if c.Blob == "" {
+		return []byte(c.Text), nil
+	}
+	data, err := base64.StdEncoding.DecodeString(c.Blob)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode blob of resource '%s': %w", c.URI, err)
+	}
+	return data, nil
+}
+
+// ResourceTemplate describes a family of resources whose URIs follow an RFC
+// 6570 URI template, as listed by resources/templates/list.
+type ResourceTemplate struct {
+	URITemplate string `json:"uriTemplate"`
+	Name        string `json:"name"`
+	Title       string `json:"title,omitempty"`
+	Description string `json:"description,omitempty"`
+	MimeType    string `json:"mimeType,omitempty"`
+}
@@ -0,0 +1,64 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transport
+
+import (
+	"context"
+	"time"
+)
+
+// RetryPolicy configures how transports retry HTTP requests that fail with a
+// connection error or with a 429 or 503 response. Apart from a 429, which
+// means that the server did not act on the request, such failures do not
+// prove that the server did not act on it: a connection can break after the
+// request was sent. Requests that are not idempotent, such as calls to tools
+// with side effects, are therefore only retried when they were rejected with
+// a 429 or when the connection to the server could not be established. See
+// WithIdempotent.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts, including the first.
+	// Values below 2 disable retries.
+	MaxAttempts int
+	// BaseDelay is the delay before the first retry, doubled for each
+	// subsequent retry. Each delay is randomized by up to half its length.
+	BaseDelay time.Duration
+	// MaxDelay caps the delay between attempts. A request for which the
+	// server asks for a longer delay, with a Retry-After header, is not
+	// retried. 0 means no cap.
+	MaxDelay time.Duration
+}
+
+// Retrier is implemented by transports that can retry failed requests.
+type Retrier interface {
+	// SetRetryPolicy configures the retries of failed requests. It must be
+	// called before the first request.
+	SetRetryPolicy(policy RetryPolicy)
+}
+
+type idempotentKey struct{}
+
+// WithIdempotent returns a copy of ctx recording whether its requests can
+// safely be sent more than once, such as calls to tools marked read-only or
+// idempotent.
+func WithIdempotent(ctx context.Context, idempotent bool) context.Context {
+	return context.WithValue(ctx, idempotentKey{}, idempotent)
+}
+
+// Idempotent reports whether ctx records its requests as idempotent, and
+// whether it records anything at all.
+func Idempotent(ctx context.Context) (idempotent, ok bool) {
+	idempotent, ok = ctx.Value(idempotentKey{}).(bool)
+	return idempotent, ok
+}
@@ -15,8 +15,15 @@
 package transport
 
 import (
+	"encoding/json"
 	"fmt"
+	"math"
 	"reflect"
+	"regexp"
+	"strconv"
+	"sync"
+	"time"
+	"unicode/utf8"
 )
 
 // Schema for a tool parameter.
@@ -29,12 +36,27 @@ type ParameterSchema struct {
 	Items                *ParameterSchema `json:"items,omitempty"`
 	AdditionalProperties any              `json:"additionalProperties,omitempty"`
 	Default              any              `json:"default,omitempty"`
+	// Nullable reports whether the parameter accepts an explicit null, such
+	// as a NULL for a SQL-backed tool. Explicit nils are only sent for
+	// nullable parameters; for others, they are treated as omitted.
+	Nullable bool `json:"nullable,omitempty"`
+
+	// JSON Schema constraints, enforced by ValidateType so that invalid
+	// inputs fail before a round trip to the server. Patterns that are not
+	// valid RE2 syntax are left for the server to enforce.
+	Minimum   *float64 `json:"minimum,omitempty"`
+	Maximum   *float64 `json:"maximum,omitempty"`
+	MinLength *int     `json:"minLength,omitempty"`
+	MaxLength *int     `json:"maxLength,omitempty"`
+	Pattern   string   `json:"pattern,omitempty"`
+	MinItems  *int     `json:"minItems,omitempty"`
+	MaxItems  *int     `json:"maxItems,omitempty"`
 }
 
 // ValidateType is a helper for manual type checking.
 func (p *ParameterSchema) ValidateType(value any) error {
 	if value == nil {
-		if p.Required {
+		if p.Required && !p.Nullable {
 			return fmt.Errorf("parameter '%s' is required but received a nil value", p.Name)
 		}
 		return nil
@@ -42,21 +64,26 @@ func (p *ParameterSchema) ValidateType(value any) error {
 
 	switch p.Type {
 	case "string":
-		if _, ok := value.(string); !ok {
+		s, ok := value.(string)
+		if !ok {
 			return fmt.Errorf("parameter '%s' expects a string, but got %T", p.Name, value)
 		}
+		return p.validateString(s)
 	case "integer":
-		switch value.(type) {
-		case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
-		default:
+		if !isInteger(value) {
 			return fmt.Errorf("parameter '%s' expects an integer, but got %T", p.Name, value)
 		}
+		f, _ := toFloat(value)
+		return p.validateRange(f)
 	case "float":
-		switch value.(type) {
-		case float32, float64:
-		default:
-			return fmt.Errorf("parameter '%s' expects an float, but got %T", p.Name, value)
+		f, ok := toFloat(value)
+		if !ok {
+			return fmt.Errorf("parameter '%s' expects a float, but got %T", p.Name, value)
+		}
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			return fmt.Errorf("parameter '%s' expects a finite float, but got %v", p.Name, f)
 		}
+		return p.validateRange(f)
 	case "boolean":
 		if _, ok := value.(bool); !ok {
 			return fmt.Errorf("parameter '%s' expects a boolean, but got %T", p.Name, value)
@@ -66,11 +93,29 @@ func (p *ParameterSchema) ValidateType(value any) error {
 		if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
 			return fmt.Errorf("parameter '%s' expects an array/slice, but got %T", p.Name, value)
 		}
+		// encoding/json sends byte slices as base64 strings, not arrays.
+		if v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.Uint8 {
+			return fmt.Errorf("parameter '%s' expects an array/slice, but got %T, which is encoded as a string", p.Name, value)
+		}
+		if p.MinItems != nil && v.Len() < *p.MinItems {
+			return fmt.Errorf("parameter '%s' expects at least %d items, but got %d", p.Name, *p.MinItems, v.Len())
+		}
+		if p.MaxItems != nil && v.Len() > *p.MaxItems {
+			return fmt.Errorf("parameter '%s' expects at most %d items, but got %d", p.Name, *p.MaxItems, v.Len())
+		}
 		if p.Items != nil {
+			// Items are validated recursively, so that []any values, arrays
+			// of objects and nested arrays are checked element by element.
+			// Each item is named after its position for clearer errors.
+			items := *p.Items
 			for i := range v.Len() {
 				item := v.Index(i).Interface()
+				if item == nil && !items.Nullable {
+					return fmt.Errorf("error in array '%s' at index %d: items cannot be null", p.Name, i)
+				}
 
-				if err := p.Items.ValidateType(item); err != nil {
+				items.Name = fmt.Sprintf("%s[%d]", p.Name, i)
+				if err := items.ValidateType(item); err != nil {
 					return fmt.Errorf("error in array '%s' at index %d: %w", p.Name, i, err)
 				}
 			}
@@ -120,11 +165,115 @@ func (p *ParameterSchema) ValidateType(value any) error {
 	return nil
 }
 
+// validateRange checks a number against the minimum and maximum of p.
+func (p *ParameterSchema) validateRange(f float64) error {
+	if p.Minimum != nil && f < *p.Minimum {
+		return fmt.Errorf("parameter '%s' must be at least %v, but got %v", p.Name, *p.Minimum, f)
+	}
+	if p.Maximum != nil && f > *p.Maximum {
+		return fmt.Errorf("parameter '%s' must be at most %v, but got %v", p.Name, *p.Maximum, f)
+	}
+	return nil
+}
+
+// validateString checks a string against the length constraints and pattern
+// of p. As in JSON Schema, lengths count characters rather than bytes, and
+// the pattern may match anywhere in the string.
+func (p *ParameterSchema) validateString(s string) error {
+	if p.MinLength != nil || p.MaxLength != nil {
+		n := utf8.RuneCountInString(s)
+		if p.MinLength != nil && n < *p.MinLength {
+			return fmt.Errorf("parameter '%s' expects at least %d characters, but got %d", p.Name, *p.MinLength, n)
+		}
+		if p.MaxLength != nil && n > *p.MaxLength {
+			return fmt.Errorf("parameter '%s' expects at most %d characters, but got %d", p.Name, *p.MaxLength, n)
+		}
+	}
+	if p.Pattern != "" {
+		if re := compilePattern(p.Pattern); re != nil && !re.MatchString(s) {
+			return fmt.Errorf("parameter '%s' does not match the pattern %q", p.Name, p.Pattern)
+		}
+	}
+	return nil
+}
+
+// patterns caches the compiled patterns of parameter schemas, as schemas are
+// validated on every invocation. Patterns that fail to compile are cached as
+// nil.
+var patterns sync.Map
+
+// compilePattern returns the compiled pattern, or nil if it is not valid RE2
+// syntax, such as a JSON Schema pattern with lookarounds.
+func compilePattern(pattern string) *regexp.Regexp {
+	if re, ok := patterns.Load(pattern); ok {
+		return re.(*regexp.Regexp)
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		re = nil
+	}
+	patterns.Store(pattern, re)
+	return re
+}
+
+// isInteger reports whether value is a value of a Go integer type, a float64
+// with no fractional part within the range of int64, as decoded from JSON
+// numbers by encoding/json, or a json.Number holding an integer literal.
+func isInteger(value any) bool {
+	switch v := value.(type) {
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return true
+	case float64:
+		return v == math.Trunc(v) && v >= math.MinInt64 && v < math.MaxInt64
+	case json.Number:
+		if _, err := strconv.ParseInt(string(v), 10, 64); err == nil {
+			return true
+		}
+		_, err := strconv.ParseUint(string(v), 10, 64)
+		return err == nil
+	default:
+		return false
+	}
+}
+
+// toFloat returns value as a float64 if it is a value of a Go float or
+// integer type, or a json.Number. Integers are valid floats, as JSON does not
+// tell them apart.
+func toFloat(value any) (float64, bool) {
+	switch v := value.(type) {
+	case float32:
+		return float64(v), true
+	case float64:
+		return v, true
+	case json.Number:
+		f, err := v.Float64()
+		return f, err == nil
+	}
+	rv := reflect.ValueOf(value)
+	switch {
+	case rv.CanInt():
+		return float64(rv.Int()), true
+	case rv.CanUint():
+		return float64(rv.Uint()), true
+	default:
+		return 0, false
+	}
+}
+
 // ValidateDefinition checks if the schema itself is well-formed.
 func (p *ParameterSchema) ValidateDefinition() error {
 	if p.Type == "" {
 		return fmt.Errorf("schema validation failed for '%s': type is missing", p.Name)
 	}
+	if p.Minimum != nil && p.Maximum != nil && *p.Minimum > *p.Maximum {
+		return fmt.Errorf("schema validation failed for '%s': minimum %v is greater than maximum %v", p.Name, *p.Minimum, *p.Maximum)
+	}
+	if p.MinLength != nil && p.MaxLength != nil && *p.MinLength > *p.MaxLength {
+		return fmt.Errorf("schema validation failed for '%s': minLength %d is greater than maxLength %d", p.Name, *p.MinLength, *p.MaxLength)
+	}
+	if p.MinItems != nil && p.MaxItems != nil && *p.MinItems > *p.MaxItems {
+		return fmt.Errorf("schema validation failed for '%s': minItems %d is greater than maxItems %d", p.Name, *p.MinItems, *p.MaxItems)
+	}
 
 	switch p.Type {
 	case "array":
@@ -170,9 +319,63 @@ func (p *ParameterSchema) ValidateDefinition() error {
 
 // Schema for a tool.
 type ToolSchema struct {
+	Title        string            `json:"title,omitempty"`
 	Description  string            `json:"description"`
 	Parameters   []ParameterSchema `json:"parameters"`
 	AuthRequired []string          `json:"authRequired,omitempty"`
+	Tags         []string          `json:"tags,omitempty"`
+	Annotations  map[string]any    `json:"annotations,omitempty"`
+	Examples     []ToolExample     `json:"examples,omitempty"`
+	// OutputSchema is the JSON Schema of the tool's structured output, if
+	// the server advertised one.
+	OutputSchema map[string]any `json:"outputSchema,omitempty"`
+	// Deprecation is set if the server announced that the tool is
+	// deprecated.
+	Deprecation *Deprecation `json:"deprecation,omitempty"`
+}
+
+// Deprecation describes the deprecation of a tool announced by the server.
+type Deprecation struct {
+	// Message explains the deprecation, such as which tool to use instead.
+	Message string `json:"message,omitempty"`
+	// Sunset is when the tool is to be removed, if the server announced it.
+	Sunset time.Time `json:"sunset,omitzero"`
+}
+
+// ToolHints are the behavior hints of a tool, as advertised in its MCP
+// annotations. A nil field means the server did not set the hint, in which
+// case clients should assume the MCP defaults: a tool is not read-only, may
+// be destructive, is not idempotent and interacts with an open world.
+type ToolHints struct {
+	ReadOnly    *bool `json:"readOnlyHint,omitempty"`
+	Destructive *bool `json:"destructiveHint,omitempty"`
+	Idempotent  *bool `json:"idempotentHint,omitempty"`
+	OpenWorld   *bool `json:"openWorldHint,omitempty"`
+}
+
+// Hints returns the behavior hints in the tool's annotations. Hints that are
+// missing or not booleans are left nil.
+func (s ToolSchema) Hints() ToolHints {
+	hint := func(key string) *bool {
+		if v, ok := s.Annotations[key].(bool); ok {
+			return &v
+		}
+		return nil
+	}
+	return ToolHints{
+		ReadOnly:    hint("readOnlyHint"),
+		Destructive: hint("destructiveHint"),
+		Idempotent:  hint("idempotentHint"),
+		OpenWorld:   hint("openWorldHint"),
+	}
+}
+
+// ToolExample is a sample invocation of a tool, suitable for few-shot
+// prompting.
+type ToolExample struct {
+	Description string         `json:"description,omitempty"`
+	Input       map[string]any `json:"input"`
+	Output      any            `json:"output,omitempty"`
 }
 
 // Schema for the Toolbox manifest.
@@ -17,7 +17,9 @@
 package transport
 
 import (
+	"encoding/json"
 	"fmt"
+	"math"
 	"strings"
 	"testing"
 	"time"
@@ -193,6 +195,129 @@ func TestParameterSchemaFloat(t *testing.T) {
 
 }
 
+// Tests the numeric types accepted by 'integer' and 'float' parameters.
+func TestParameterSchemaNumbers(t *testing.T) {
+	integer := ParameterSchema{Name: "count", Type: "integer"}
+	float := ParameterSchema{Name: "price", Type: "float"}
+
+	testCases := []struct {
+		name       string
+		value      any
+		integerErr string
+		floatErr   string
+	}{
+		{"int", 1, "", ""},
+		{"int32", int32(-1), "", ""},
+		{"int64", int64(1) << 62, "", ""},
+		{"uint64", uint64(math.MaxUint64), "", ""},
+		{"float32", float32(1.5), "expects an integer, but got float32", ""},
+		{"float64", 1.5, "expects an integer, but got float64", ""},
+		{"whole float64", 2.0, "", ""},
+		{"float64 out of range", 1e19, "expects an integer, but got float64", ""},
+		{"NaN", math.NaN(), "expects an integer", "expects a finite float, but got NaN"},
+		{"infinity", math.Inf(1), "expects an integer", "expects a finite float, but got +Inf"},
+		{"json.Number integer", json.Number("42"), "", ""},
+		{"json.Number large unsigned integer", json.Number("18446744073709551615"), "", ""},
+		{"json.Number fraction", json.Number("4.2"), "expects an integer, but got json.Number", ""},
+		{"json.Number exponent", json.Number("1e3"), "expects an integer, but got json.Number", ""},
+		{"json.Number out of range", json.Number("1e400"), "expects an integer", "expects a float, but got json.Number"},
+		{"invalid json.Number", json.Number("abc"), "expects an integer", "expects a float, but got json.Number"},
+		{"string", "1", "expects an integer, but got string", "expects a float, but got string"},
+		{"boolean", true, "expects an integer, but got bool", "expects a float, but got bool"},
+	}
+
+	check := func(t *testing.T, schema ParameterSchema, value any, wantErr string) {
+		t.Helper()
+		err := schema.ValidateType(value)
+		if wantErr == "" {
+			if err != nil {
+				t.Errorf("Expected %v to be a valid %s, got: %v", value, schema.Type, err)
+			}
+			return
+		}
+		if err == nil || !strings.Contains(err.Error(), wantErr) {
+			t.Errorf("Expected an error containing %q for %v, got: %v", wantErr, value, err)
+		}
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			check(t, integer, tc.value, tc.integerErr)
+			check(t, float, tc.value, tc.floatErr)
+		})
+	}
+}
+
+// Tests that nullable parameters and items accept explicit nils.
+func TestParameterSchemaNullable(t *testing.T) {
+	required := ParameterSchema{Name: "deleted_at", Type: "string", Required: true}
+	if err := required.ValidateType(nil); err == nil {
+		t.Error("Expected an error for a nil required parameter, got nil")
+	}
+	required.Nullable = true
+	if err := required.ValidateType(nil); err != nil {
+		t.Errorf("Expected a nullable parameter to accept nil, got: %v", err)
+	}
+
+	ids := ParameterSchema{Name: "ids", Type: "array", Items: &ParameterSchema{Type: "integer", Nullable: true}}
+	if err := ids.ValidateType([]any{1, nil, 3}); err != nil {
+		t.Errorf("Expected nullable items to accept nil, got: %v", err)
+	}
+	if err := ids.ValidateType([]any{1, "two"}); err == nil {
+		t.Error("Expected an error for an invalid item, got nil")
+	}
+}
+
+// Tests arrays holding []any values, objects and nested arrays.
+func TestParameterSchemaArrayItems(t *testing.T) {
+	integers := &ParameterSchema{Type: "integer"}
+	matrix := ParameterSchema{Name: "matrix", Type: "array", Items: &ParameterSchema{Type: "array", Items: integers}}
+	rows := ParameterSchema{Name: "rows", Type: "array", Items: &ParameterSchema{Type: "object", AdditionalProperties: integers}}
+	objects := ParameterSchema{Name: "objects", Type: "array", Items: &ParameterSchema{Type: "object"}}
+	floats := ParameterSchema{Name: "floats", Type: "array", Items: &ParameterSchema{Type: "float"}}
+	untyped := ParameterSchema{Name: "untyped", Type: "array"}
+
+	testCases := []struct {
+		name    string
+		schema  ParameterSchema
+		value   any
+		wantErr string
+	}{
+		{"Nested []any arrays", matrix, []any{[]any{1, int64(2)}, []any{json.Number("3")}}, ""},
+		{"Nested typed slices", matrix, [][]int{{1}, {2, 3}}, ""},
+		{"Go array", floats, [2]float64{1.5, 2}, ""},
+		{"Mixed numbers for floats", floats, []any{1, 2.5, json.Number("3")}, ""},
+		{"Objects in []any", rows, []any{map[string]any{"a": 1}, map[string]any{"b": 2}}, ""},
+		{"Typed slice of objects", objects, []map[string]any{{"a": "x"}}, ""},
+		{"Heterogeneous items without an item schema", untyped, []any{1, "two", nil, []any{3}}, ""},
+		{"Empty array", matrix, []any{}, ""},
+		{
+			"Invalid nested item",
+			matrix, []any{[]any{1}, []any{2, "three"}},
+			"error in array 'matrix' at index 1: error in array 'matrix[1]' at index 1: parameter 'matrix[1][1]' expects an integer, but got string",
+		},
+		{"Scalar instead of a nested array", matrix, []any{1}, "parameter 'matrix[0]' expects an array/slice, but got int"},
+		{"Invalid object value", rows, []any{map[string]any{"a": "x"}}, "error in object 'rows[0]' for key 'a'"},
+		{"Non-object item", objects, []any{"x"}, "parameter 'objects[0]' expects a map, but got string"},
+		{"Null item", matrix, []any{[]any{1, nil}}, "error in array 'matrix[0]' at index 1: items cannot be null"},
+		{"Byte slice", floats, []byte{1, 2}, "parameter 'floats' expects an array/slice, but got []uint8, which is encoded as a string"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.schema.ValidateType(tc.value)
+			if tc.wantErr == "" {
+				if err != nil {
+					t.Errorf("Expected no error, got: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Errorf("Expected an error containing %q, got: %v", tc.wantErr, err)
+			}
+		})
+	}
+}
+
 // Tests ParameterSchema with type 'array'.
 func TestParameterSchemaStringArray(t *testing.T) {
 
@@ -409,7 +534,7 @@ func TestValidateTypeObject(t *testing.T) {
 				name:         "float values",
 				valueType:    "float",
 				validInput:   map[string]any{"item_price": 99.99},
-				invalidInput: map[string]any{"bad_price": 99},
+				invalidInput: map[string]any{"bad_price": "99.99"},
 			},
 			{
 				name:         "boolean values",
@@ -484,7 +609,7 @@ func TestValidateTypeObject(t *testing.T) {
 				name:                 "float values",
 				additionalProperties: &ParameterSchema{Type: "float"},
 				validInput:           map[string]any{"item_price": 99.99},
-				invalidInput:         map[string]any{"bad_price": 99},
+				invalidInput:         map[string]any{"bad_price": "99.99"},
 			},
 			{
 				name:                 "boolean values",
@@ -588,6 +713,60 @@ func TestValidateTypeObject(t *testing.T) {
 	})
 }
 
+func ptr[T any](v T) *T {
+	return &v
+}
+
+// Tests the JSON Schema constraints of parameters.
+func TestParameterSchemaConstraints(t *testing.T) {
+	limit := ParameterSchema{Name: "limit", Type: "integer", Minimum: ptr(1.0), Maximum: ptr(100.0)}
+	price := ParameterSchema{Name: "price", Type: "float", Minimum: ptr(0.5)}
+	code := ParameterSchema{Name: "code", Type: "string", MinLength: ptr(2), MaxLength: ptr(3), Pattern: "^[A-Z]+$"}
+	ids := ParameterSchema{Name: "ids", Type: "array", MinItems: ptr(1), MaxItems: ptr(2), Items: &ParameterSchema{Type: "integer", Minimum: ptr(0.0)}}
+	lookaround := ParameterSchema{Name: "lookaround", Type: "string", Pattern: "^(?!admin).*$"}
+
+	testCases := []struct {
+		name    string
+		schema  ParameterSchema
+		value   any
+		wantErr string
+	}{
+		{"Integer in range", limit, 1, ""},
+		{"Integer at maximum", limit, json.Number("100"), ""},
+		{"Integer below minimum", limit, 0, "parameter 'limit' must be at least 1, but got 0"},
+		{"Integer above maximum", limit, int64(101), "parameter 'limit' must be at most 100, but got 101"},
+		{"Float in range", price, 0.5, ""},
+		{"Float below minimum", price, 0.25, "parameter 'price' must be at least 0.5, but got 0.25"},
+		{"String in range", code, "CHF", ""},
+		{"Length counts characters", ParameterSchema{Name: "s", Type: "string", MaxLength: ptr(2)}, "äö", ""},
+		{"String too short", code, "C", "parameter 'code' expects at least 2 characters, but got 1"},
+		{"String too long", code, "EURO", "parameter 'code' expects at most 3 characters, but got 4"},
+		{"String not matching", code, "chf", `parameter 'code' does not match the pattern "^[A-Z]+$"`},
+		{"Pattern matches anywhere", ParameterSchema{Name: "s", Type: "string", Pattern: "[0-9]"}, "a1b", ""},
+		{"Unsupported pattern is skipped", lookaround, "admin", ""},
+		{"Array in range", ids, []int{1, 2}, ""},
+		{"Array too short", ids, []int{}, "parameter 'ids' expects at least 1 items, but got 0"},
+		{"Array too long", ids, []any{1, 2, 3}, "parameter 'ids' expects at most 2 items, but got 3"},
+		{"Item out of range", ids, []any{-1}, "parameter 'ids[0]' must be at least 0, but got -1"},
+		{"Nil is not constrained", ParameterSchema{Name: "n", Type: "integer", Minimum: ptr(1.0)}, nil, ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.schema.ValidateType(tc.value)
+			if tc.wantErr == "" {
+				if err != nil {
+					t.Errorf("Expected no error, got: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Errorf("Expected an error containing %q, got: %v", tc.wantErr, err)
+			}
+		})
+	}
+}
+
 func TestParameterSchema_ValidateDefinition(t *testing.T) {
 	t.Run("should succeed for simple valid types", func(t *testing.T) {
 		testCases := []struct {
@@ -609,6 +788,23 @@ func TestParameterSchema_ValidateDefinition(t *testing.T) {
 		}
 	})
 
+	t.Run("should fail for inconsistent constraints", func(t *testing.T) {
+		testCases := []struct {
+			schema  *ParameterSchema
+			wantErr string
+		}{
+			{&ParameterSchema{Name: "n", Type: "integer", Minimum: ptr(2.0), Maximum: ptr(1.0)}, "minimum 2 is greater than maximum 1"},
+			{&ParameterSchema{Name: "s", Type: "string", MinLength: ptr(2), MaxLength: ptr(1)}, "minLength 2 is greater than maxLength 1"},
+			{&ParameterSchema{Name: "a", Type: "array", MinItems: ptr(2), MaxItems: ptr(1)}, "minItems 2 is greater than maxItems 1"},
+		}
+		for _, tc := range testCases {
+			err := tc.schema.ValidateDefinition()
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Errorf("Expected an error containing %q, got: %v", tc.wantErr, err)
+			}
+		}
+	})
+
 	t.Run("should succeed for a valid array schema", func(t *testing.T) {
 		schema := &ParameterSchema{
 			Name:  "p_array",
@@ -744,3 +940,29 @@ func TestParameterSchema_ValidateDefinition(t *testing.T) {
 		}
 	})
 }
+
+func TestToolSchemaHints(t *testing.T) {
+	schema := ToolSchema{Annotations: map[string]any{
+		"readOnlyHint":    false,
+		"destructiveHint": true,
+		"openWorldHint":   "yes",
+	}}
+
+	hints := schema.Hints()
+	if hints.ReadOnly == nil || *hints.ReadOnly {
+		t.Errorf("expected ReadOnly to be false, got %v", hints.ReadOnly)
+	}
+	if hints.Destructive == nil || !*hints.Destructive {
+		t.Errorf("expected Destructive to be true, got %v", hints.Destructive)
+	}
+	if hints.Idempotent != nil {
+		t.Errorf("expected Idempotent to be unset, got %v", *hints.Idempotent)
+	}
+	if hints.OpenWorld != nil {
+		t.Errorf("expected a non-boolean OpenWorld to be unset, got %v", *hints.OpenWorld)
+	}
+
+	if (ToolSchema{}).Hints() != (ToolHints{}) {
+		t.Error("expected no hints for a tool without annotations")
+	}
+}
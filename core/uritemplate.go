@@ -0,0 +1,198 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// uriTemplateOperator describes how the variables of an expression with a
+// given operator are expanded, as defined in RFC 6570, Appendix A.
+type uriTemplateOperator struct {
+	first         string
+	sep           string
+	named         bool
+	ifEmpty       string
+	allowReserved bool
+}
+
+var uriTemplateOperators = map[byte]uriTemplateOperator{
+	'+': {first: "", sep: ",", allowReserved: true},
+	'#': {first: "#", sep: ",", allowReserved: true},
+	'.': {first: ".", sep: "."},
+	'/': {first: "/", sep: "/"},
+	';': {first: ";", sep: ";", named: true},
+	'?': {first: "?", sep: "&", named: true, ifEmpty: "="},
+	'&': {first: "&", sep: "&", named: true, ifEmpty: "="},
+}
+
+// ExpandURITemplate expands an RFC 6570 URI template, such as the URI
+// template of a ResourceTemplate, with the given variables.
+//
+// All expression operators are supported, as is the prefix modifier
+// ("{name:3}"). Since values are plain strings, the explode modifier
+// ("{name*}") has no effect. Variables missing from vars are undefined and
+// expand to nothing.
+//
+// Inputs:
+//   - template: The URI template, e.g. "db://table/{name}".
+//   - vars: The variable values, keyed by variable name.
+//
+// Returns:
+//
+//	The expanded URI and a nil error on success, or an empty string and an
+//	error if the template is malformed.
+func ExpandURITemplate(template string, vars map[string]string) (string, error) {
+	var sb strings.Builder
+	for i := 0; i < len(template); {
+		switch template[i] {
+		case '{':
+			end := strings.IndexByte(template[i:], '}')
+			if end < 0 {
+				return "", fmt.Errorf("invalid URI template '%s': unclosed expression at offset %d", template, i)
+			}
+			if err := expandExpression(&sb, template[i+1:i+end], vars); err != nil {
+				return "", fmt.Errorf("invalid URI template '%s': %w", template, err)
+			}
+			i += end + 1
+		case '}':
+			return "", fmt.Errorf("invalid URI template '%s': unexpected '}' at offset %d", template, i)
+		default:
+			// Literals are copied, encoding only characters that are not
+			// allowed in a URI.
+			next := strings.IndexAny(template[i:], "{}")
+			if next < 0 {
+				next = len(template) - i
+			}
+			sb.WriteString(encodeURITemplateValue(template[i:i+next], true))
+			i += next
+		}
+	}
+	return sb.String(), nil
+}
+
+// expandExpression writes the expansion of a single "{...}" expression.
+func expandExpression(sb *strings.Builder, expr string, vars map[string]string) error {
+	if expr == "" {
+		return fmt.Errorf("empty expression")
+	}
+	op, ok := uriTemplateOperators[expr[0]]
+	if ok {
+		expr = expr[1:]
+	} else {
+		op = uriTemplateOperator{first: "", sep: ","}
+	}
+
+	first := true
+	for _, spec := range strings.Split(expr, ",") {
+		name, maxLength, err := parseVarSpec(spec)
+		if err != nil {
+			return err
+		}
+		value, defined := vars[name]
+		if !defined {
+			continue
+		}
+
+		if first {
+			sb.WriteString(op.first)
+			first = false
+		} else {
+			sb.WriteString(op.sep)
+		}
+		if op.named {
+			sb.WriteString(name)
+			if value == "" {
+				sb.WriteString(op.ifEmpty)
+				continue
+			}
+			sb.WriteByte('=')
+		}
+		if maxLength > 0 {
+			if runes := []rune(value); len(runes) > maxLength {
+				value = string(runes[:maxLength])
+			}
+		}
+		sb.WriteString(encodeURITemplateValue(value, op.allowReserved))
+	}
+	return nil
+}
+
+// parseVarSpec splits a variable specification into its name and prefix
+// length, which is 0 when there is no prefix modifier.
+func parseVarSpec(spec string) (string, int, error) {
+	name := strings.TrimSuffix(spec, "*")
+	maxLength := 0
+	if before, after, found := strings.Cut(name, ":"); found {
+		n, err := strconv.Atoi(after)
+		if err != nil || n <= 0 || n >= 10000 {
+			return "", 0, fmt.Errorf("invalid prefix modifier in '%s'", spec)
+		}
+		name, maxLength = before, n
+	}
+	if name == "" {
+		return "", 0, fmt.Errorf("missing variable name in '%s'", spec)
+	}
+	for _, r := range name {
+		if !isVarNameChar(r) {
+			return "", 0, fmt.Errorf("invalid variable name '%s'", name)
+		}
+	}
+	return name, maxLength, nil
+}
+
+// encodeURITemplateValue percent-encodes s. Unreserved characters are always
+// kept; reserved characters and existing percent-encoded triplets are kept
+// only if allowReserved is set.
+func encodeURITemplateValue(s string, allowReserved bool) string {
+	const hex = "0123456789ABCDEF"
+	var sb strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case isUnreserved(rune(c)):
+			sb.WriteByte(c)
+		case allowReserved && strings.IndexByte(":/?#[]@!$&'()*+,;=", c) >= 0:
+			sb.WriteByte(c)
+		case allowReserved && c == '%' && i+2 < len(s) && isHexDigit(s[i+1]) && isHexDigit(s[i+2]):
+			sb.WriteString(s[i : i+3])
+			i += 2
+		default:
+			sb.WriteByte('%')
+			sb.WriteByte(hex[c>>4])
+			sb.WriteByte(hex[c&0x0F])
+		}
+	}
+	return sb.String()
+}
+
+// isUnreserved reports whether r is an unreserved URI character.
+func isUnreserved(r rune) bool {
+	return r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' ||
+		r == '-' || r == '.' || r == '_' || r == '~'
+}
+
+// isVarNameChar reports whether r may appear in a variable name. Names
+// consist of letters, digits, '_', '.' and percent-encoded triplets.
+func isVarNameChar(r rune) bool {
+	return r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' ||
+		r == '_' || r == '.' || r == '%'
+}
+
+func isHexDigit(c byte) bool {
+	return c >= '0' && c <= '9' || c >= 'a' && c <= 'f' || c >= 'A' && c <= 'F'
+}
@@ -0,0 +1,88 @@
+//go:build unit
+
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestExpandURITemplate(t *testing.T) {
+	// Variables and expected expansions from RFC 6570, Section 3.2.
+	vars := map[string]string{
+		"var":   "value",
+		"hello": "Hello World!",
+		"path":  "/foo/bar",
+		"empty": "",
+		"x":     "1024",
+		"y":     "768",
+		"name":  "users",
+	}
+
+	testCases := []struct {
+		template string
+		want     string
+	}{
+		{"db://table/{name}", "db://table/users"},
+		{"{var}", "value"},
+		{"{hello}", "Hello%20World%21"},
+		{"{var:3}", "val"},
+		{"{x,y}", "1024,768"},
+		{"{undef}", ""},
+		{"{+path}/here", "/foo/bar/here"},
+		{"{+hello}", "Hello%20World!"},
+		{"{#x,hello,y}", "#1024,Hello%20World!,768"},
+		{"X{.var}", "X.value"},
+		{"{/var,x}/here", "/value/1024/here"},
+		{"{;x,y,empty}", ";x=1024;y=768;empty"},
+		{"{?x,y,empty}", "?x=1024&y=768&empty="},
+		{"?fixed=yes{&x}", "?fixed=yes&x=1024"},
+		{"{?undef}", ""},
+		{"{var*}", "value"},
+		{"file:///with space/{var}", "file:///with%20space/value"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.template, func(t *testing.T) {
+			got, err := ExpandURITemplate(tc.template, vars)
+			if err != nil {
+				t.Fatalf("ExpandURITemplate failed: %v", err)
+			}
+			if got != tc.want {
+				t.Errorf("Expected %q, got %q", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestExpandURITemplate_Errors(t *testing.T) {
+	testCases := map[string]string{
+		"db://{name":      "unclosed expression",
+		"db://name}":      "unexpected '}'",
+		"db://{}":         "empty expression",
+		"db://{name:abc}": "invalid prefix modifier",
+		"db://{na-me}":    "invalid variable name",
+		"db://{?}":        "missing variable name",
+	}
+	for template, wantErr := range testCases {
+		t.Run(template, func(t *testing.T) {
+			_, err := ExpandURITemplate(template, nil)
+			if err == nil || !strings.Contains(err.Error(), wantErr) {
+				t.Errorf("Expected error containing %q, got %v", wantErr, err)
+			}
+		})
+	}
+}
@@ -15,11 +15,17 @@
 package core
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"log"
+	"math"
+	"reflect"
+	"strconv"
 	"strings"
+	"time"
 
+	"github.com/googleapis/mcp-toolbox-sdk-go/core/internal/tokencache"
 	"golang.org/x/oauth2"
 )
 
@@ -121,6 +127,28 @@ func findUnusedKeys(provided, used map[string]struct{}) []string {
 	return unused
 }
 
+// validateLoadConfig rejects options that only make sense when deriving a
+// tool from an existing one with ToolFrom.
+func validateLoadConfig(config *ToolConfig) error {
+	if len(config.UnboundParams) > 0 {
+		return fmt.Errorf("WithUnbindParam option is only applicable to ToolFrom")
+	}
+	if len(config.ReboundParams) > 0 {
+		return fmt.Errorf("WithRebindParam option is only applicable to ToolFrom")
+	}
+	return nil
+}
+
+// reportUnused returns err, an error about unused bound parameters or auth
+// tokens, or logs it as a warning if unused bindings are allowed.
+func reportUnused(config *ToolConfig, err error) error {
+	if config.AllowUnused {
+		log.Printf("WARNING: %v", err)
+		return nil
+	}
+	return err
+}
+
 // stringTokenSource is a custom type that implements the oauth2.TokenSource interface.
 type customTokenSource struct {
 	provider func() string
@@ -146,11 +174,34 @@ func (s *customTokenSource) Token() (*oauth2.Token, error) {
 	}, nil
 }
 
+// headerFunc is a client header source that computes the header value from
+// the context of each request. It implements oauth2.TokenSource so that it
+// can be kept with the other client header sources; Token calls it with a
+// background context.
+type headerFunc func(ctx context.Context) (string, error)
+
+func (f headerFunc) Token() (*oauth2.Token, error) {
+	return headerToken(context.Background(), f)
+}
+
+// headerToken resolves a client header source for a request made with ctx.
+func headerToken(ctx context.Context, source oauth2.TokenSource) (*oauth2.Token, error) {
+	f, ok := source.(headerFunc)
+	if !ok {
+		return source.Token()
+	}
+	value, err := f(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &oauth2.Token{AccessToken: value}, nil
+}
+
 // Helper to resolve client-level headers
-func resolveClientHeaders(clientHeaderSources map[string]oauth2.TokenSource) (map[string]string, error) {
+func resolveClientHeaders(ctx context.Context, clientHeaderSources map[string]oauth2.TokenSource) (map[string]string, error) {
 	resolved := make(map[string]string)
 	for k, source := range clientHeaderSources {
-		token, err := source.Token()
+		token, err := headerToken(ctx, source)
 		if err != nil {
 			return nil, fmt.Errorf("failed to resolve client header '%s': %w", k, err)
 		}
@@ -178,6 +229,35 @@ func schemaToMap(p *ParameterSchema) (map[string]any, error) {
 		schema["default"] = p.Default
 	}
 
+	// Nullability is declared with the OpenAPI keyword rather than a type
+	// union, as the schema of a single type is what most frameworks accept.
+	if p.Nullable {
+		schema["nullable"] = true
+	}
+
+	// Constraints are copied so that models and frameworks see them too.
+	if p.Minimum != nil {
+		schema["minimum"] = *p.Minimum
+	}
+	if p.Maximum != nil {
+		schema["maximum"] = *p.Maximum
+	}
+	if p.MinLength != nil {
+		schema["minLength"] = *p.MinLength
+	}
+	if p.MaxLength != nil {
+		schema["maxLength"] = *p.MaxLength
+	}
+	if p.Pattern != "" {
+		schema["pattern"] = p.Pattern
+	}
+	if p.MinItems != nil {
+		schema["minItems"] = *p.MinItems
+	}
+	if p.MaxItems != nil {
+		schema["maxItems"] = *p.MaxItems
+	}
+
 	// Handle array validation recursively
 	if p.Type == "array" && p.Items != nil {
 		itemSchema, err := schemaToMap(p.Items)
@@ -235,3 +315,184 @@ func checkSecureHeaders(url string, hasSensitiveData bool) {
 		log.Println("WARNING: This connection is using HTTP. To prevent credential exposure, please ensure all communication is sent over HTTPS.")
 	}
 }
+
+// normalizeJSONNumbers recursively converts json.Number values produced by a
+// decoder with UseNumber into int64 or float64, guided by the parameter schema.
+// Numbers without a matching schema are converted to float64, mirroring the
+// default behavior of encoding/json.
+func normalizeJSONNumbers(value any, p *ParameterSchema) any {
+	switch v := value.(type) {
+	case json.Number:
+		if p != nil && p.Type == "integer" {
+			if i, err := v.Int64(); err == nil {
+				return i
+			}
+		}
+		if f, err := v.Float64(); err == nil {
+			return f
+		}
+		return v.String()
+	case []any:
+		var items *ParameterSchema
+		if p != nil {
+			items = p.Items
+		}
+		for i, item := range v {
+			v[i] = normalizeJSONNumbers(item, items)
+		}
+		return v
+	case map[string]any:
+		var values *ParameterSchema
+		if p != nil {
+			values, _ = p.AdditionalProperties.(*ParameterSchema)
+		}
+		for k, item := range v {
+			v[k] = normalizeJSONNumbers(item, values)
+		}
+		return v
+	default:
+		return value
+	}
+}
+
+// coerceValue converts value to the type that the parameter schema p expects
+// if the two are compatible, such as the string "3" for an integer parameter
+// or an int for a float parameter. Arrays and typed maps are converted
+// element by element into new values, leaving value untouched. Values that
+// cannot be converted are returned as they are, for type validation to
+// report.
+func coerceValue(value any, p *ParameterSchema) any {
+	if p == nil || value == nil {
+		return value
+	}
+	switch p.Type {
+	case "integer":
+		switch v := value.(type) {
+		case string:
+			s := strings.TrimSpace(v)
+			if i, err := strconv.ParseInt(s, 10, 64); err == nil {
+				return i
+			}
+			if f, err := strconv.ParseFloat(s, 64); err == nil {
+				if i, ok := wholeNumber(f); ok {
+					return i
+				}
+			}
+		case json.Number:
+			return coerceValue(v.String(), p)
+		case float32:
+			if i, ok := wholeNumber(float64(v)); ok {
+				return i
+			}
+		case float64:
+			if i, ok := wholeNumber(v); ok {
+				return i
+			}
+		}
+	case "float":
+		switch v := value.(type) {
+		case string:
+			if f, err := strconv.ParseFloat(strings.TrimSpace(v), 64); err == nil {
+				return f
+			}
+		case json.Number:
+			if f, err := v.Float64(); err == nil {
+				return f
+			}
+		default:
+			rv := reflect.ValueOf(value)
+			switch {
+			case rv.CanInt():
+				return float64(rv.Int())
+			case rv.CanUint():
+				return float64(rv.Uint())
+			}
+		}
+	case "string":
+		switch v := value.(type) {
+		case json.Number:
+			return v.String()
+		case bool:
+			return strconv.FormatBool(v)
+		case float32:
+			return strconv.FormatFloat(float64(v), 'f', -1, 32)
+		case float64:
+			return strconv.FormatFloat(v, 'f', -1, 64)
+		default:
+			rv := reflect.ValueOf(value)
+			switch {
+			case rv.CanInt():
+				return strconv.FormatInt(rv.Int(), 10)
+			case rv.CanUint():
+				return strconv.FormatUint(rv.Uint(), 10)
+			}
+		}
+	case "boolean":
+		if v, ok := value.(string); ok {
+			if b, err := strconv.ParseBool(strings.TrimSpace(v)); err == nil {
+				return b
+			}
+		}
+	case "array":
+		rv := reflect.ValueOf(value)
+		if p.Items == nil || (rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array) {
+			return value
+		}
+		items := make([]any, rv.Len())
+		for i := range items {
+			items[i] = coerceValue(rv.Index(i).Interface(), p.Items)
+		}
+		return items
+	case "object":
+		values, ok := p.AdditionalProperties.(*ParameterSchema)
+		m, isMap := value.(map[string]any)
+		if !ok || !isMap {
+			return value
+		}
+		coerced := make(map[string]any, len(m))
+		for k, v := range m {
+			coerced[k] = coerceValue(v, values)
+		}
+		return coerced
+	}
+	return value
+}
+
+// wholeNumber returns f as an int64 if it has no fractional part and fits.
+func wholeNumber(f float64) (int64, bool) {
+	if f != math.Trunc(f) || f < math.MinInt64 || f >= math.MaxInt64 {
+		return 0, false
+	}
+	return int64(f), true
+}
+
+// formatResult converts an invocation result into the requested format.
+func formatResult(result any, format ResultFormat) (any, error) {
+	if format != ResultFormatJSON {
+		return result, nil
+	}
+	str, ok := result.(string)
+	if !ok {
+		return result, nil
+	}
+	var decoded any
+	if err := json.Unmarshal([]byte(str), &decoded); err != nil {
+		return nil, fmt.Errorf("failed to decode tool result as JSON: %w", err)
+	}
+	return decoded, nil
+}
+
+// defaultTokenRefreshSkew is how long before their expiry cached tokens are
+// refreshed unless WithTokenRefreshSkew is used, as in oauth2.
+const defaultTokenRefreshSkew = tokencache.DefaultSkew
+
+// reuseTokenSource wraps src so that its tokens are cached until they are
+// within skew of expiring. The default skew is used unless skewSet. Unlike
+// oauth2.ReuseTokenSource, tokens without an expiry are not cached, since
+// sources such as NewCustomTokenSource compute a new value for every request.
+func reuseTokenSource(src oauth2.TokenSource, skew time.Duration, skewSet bool) oauth2.TokenSource {
+	if !skewSet {
+		skew = defaultTokenRefreshSkew
+	}
+	return tokencache.Reuse(src, skew)
+}
@@ -18,11 +18,15 @@ package core
 
 import (
 	"bytes"
+	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"log"
 	"reflect"
 	"sort"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -151,7 +155,7 @@ func TestResolveClientHeaders(t *testing.T) {
 		}
 
 		// Execute function directly
-		headers, err := resolveClientHeaders(sources)
+		headers, err := resolveClientHeaders(context.Background(), sources)
 
 		// Verify
 		require.NoError(t, err)
@@ -163,7 +167,7 @@ func TestResolveClientHeaders(t *testing.T) {
 	t.Run("Success_Empty", func(t *testing.T) {
 		sources := make(map[string]oauth2.TokenSource)
 
-		headers, err := resolveClientHeaders(sources)
+		headers, err := resolveClientHeaders(context.Background(), sources)
 
 		require.NoError(t, err)
 		assert.Empty(t, headers)
@@ -178,7 +182,7 @@ func TestResolveClientHeaders(t *testing.T) {
 		}
 
 		// Execute
-		headers, err := resolveClientHeaders(sources)
+		headers, err := resolveClientHeaders(context.Background(), sources)
 
 		// Verify
 		require.Error(t, err)
@@ -213,6 +217,7 @@ func TestCustomTokenSource(t *testing.T) {
 }
 
 func TestSchemaToMap(t *testing.T) {
+	minLength, maxLength := 2, 3
 	// Define test cases
 	testCases := []struct {
 		name      string
@@ -231,6 +236,29 @@ func TestSchemaToMap(t *testing.T) {
 				"description": "A simple string input.",
 			},
 		},
+		{
+			name: "Constrained Parameter",
+			input: &ParameterSchema{
+				Type:      "string",
+				MinLength: &minLength,
+				MaxLength: &maxLength,
+				Pattern:   "^[A-Z]+$",
+			},
+			expected: map[string]any{
+				"type":      "string",
+				"minLength": 2,
+				"maxLength": 3,
+				"pattern":   "^[A-Z]+$",
+			},
+		},
+		{
+			name:  "Nullable Parameter",
+			input: &ParameterSchema{Type: "integer", Nullable: true},
+			expected: map[string]any{
+				"type":     "integer",
+				"nullable": true,
+			},
+		},
 		{
 			name: "Array of Integers Parameter",
 			input: &ParameterSchema{
@@ -505,3 +533,184 @@ func TestCheckSecureHeaders(t *testing.T) {
 		assert.NotContains(t, output, "WARNING: This connection is using HTTP")
 	})
 }
+
+func TestNormalizeJSONNumbers(t *testing.T) {
+	intSchema := &ParameterSchema{Type: "integer"}
+	floatSchema := &ParameterSchema{Type: "float"}
+
+	testCases := []struct {
+		name     string
+		value    any
+		schema   *ParameterSchema
+		expected any
+	}{
+		{"Integer parameter", json.Number("42"), intSchema, int64(42)},
+		{"Float parameter", json.Number("42"), floatSchema, float64(42)},
+		{"Fractional value for integer parameter", json.Number("1.5"), intSchema, float64(1.5)},
+		{"Number without schema", json.Number("7"), nil, float64(7)},
+		{"Non-number value is unchanged", "text", intSchema, "text"},
+		{
+			"Array of integers",
+			[]any{json.Number("1"), json.Number("2")},
+			&ParameterSchema{Type: "array", Items: intSchema},
+			[]any{int64(1), int64(2)},
+		},
+		{
+			"Typed map of integers",
+			map[string]any{"a": json.Number("3")},
+			&ParameterSchema{Type: "object", AdditionalProperties: intSchema},
+			map[string]any{"a": int64(3)},
+		},
+		{
+			"Generic map",
+			map[string]any{"a": json.Number("3")},
+			&ParameterSchema{Type: "object"},
+			map[string]any{"a": float64(3)},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := normalizeJSONNumbers(tc.value, tc.schema)
+			assert.Equal(t, tc.expected, got)
+		})
+	}
+}
+
+// countingTokenSource returns a new token expiring after ttl on every call.
+type countingTokenSource struct {
+	ttl   time.Duration
+	calls int
+}
+
+func (c *countingTokenSource) Token() (*oauth2.Token, error) {
+	c.calls++
+	return &oauth2.Token{AccessToken: fmt.Sprintf("token-%d", c.calls), Expiry: time.Now().Add(c.ttl)}, nil
+}
+
+func TestCoerceValue(t *testing.T) {
+	intSchema := &ParameterSchema{Type: "integer"}
+	floatSchema := &ParameterSchema{Type: "float"}
+	stringSchema := &ParameterSchema{Type: "string"}
+	boolSchema := &ParameterSchema{Type: "boolean"}
+
+	testCases := []struct {
+		name     string
+		value    any
+		schema   *ParameterSchema
+		expected any
+	}{
+		{"String to integer", "3", intSchema, int64(3)},
+		{"Padded string to integer", " -7 ", intSchema, int64(-7)},
+		{"Whole float string to integer", "2.0", intSchema, int64(2)},
+		{"Fractional string for integer is unchanged", "2.5", intSchema, "2.5"},
+		{"Whole float to integer", float64(4), intSchema, int64(4)},
+		{"Fractional float for integer is unchanged", 4.5, intSchema, 4.5},
+		{"Overflowing float for integer is unchanged", 1e20, intSchema, 1e20},
+		{"JSON number to integer", json.Number("12"), intSchema, int64(12)},
+		{"Integer is unchanged", 5, intSchema, 5},
+		{"Non-numeric string for integer is unchanged", "three", intSchema, "three"},
+		{"Int to float", 2, floatSchema, float64(2)},
+		{"Uint to float", uint8(2), floatSchema, float64(2)},
+		{"String to float", "1.25", floatSchema, 1.25},
+		{"JSON number to float", json.Number("1e3"), floatSchema, float64(1000)},
+		{"Float32 is unchanged", float32(1.5), floatSchema, float32(1.5)},
+		{"Int to string", 42, stringSchema, "42"},
+		{"Float to string", 1.5, stringSchema, "1.5"},
+		{"JSON number to string", json.Number("007"), stringSchema, "007"},
+		{"Bool to string", true, stringSchema, "true"},
+		{"Map for string is unchanged", map[string]any{}, stringSchema, map[string]any{}},
+		{"String to boolean", "true", boolSchema, true},
+		{"Invalid string for boolean is unchanged", "yes", boolSchema, "yes"},
+		{"Nil is unchanged", nil, intSchema, nil},
+		{"No schema", "3", nil, "3"},
+		{
+			"Array items",
+			[]any{"1", 2.0, json.Number("3")},
+			&ParameterSchema{Type: "array", Items: intSchema},
+			[]any{int64(1), int64(2), int64(3)},
+		},
+		{
+			"Typed slice items",
+			[]string{"1", "2"},
+			&ParameterSchema{Type: "array", Items: floatSchema},
+			[]any{float64(1), float64(2)},
+		},
+		{
+			"Array without item schema is unchanged",
+			[]any{"1"},
+			&ParameterSchema{Type: "array"},
+			[]any{"1"},
+		},
+		{
+			"Typed map values",
+			map[string]any{"a": "1", "b": 2},
+			&ParameterSchema{Type: "object", AdditionalProperties: intSchema},
+			map[string]any{"a": int64(1), "b": 2},
+		},
+		{
+			"Generic map is unchanged",
+			map[string]any{"a": "1"},
+			&ParameterSchema{Type: "object", AdditionalProperties: true},
+			map[string]any{"a": "1"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := coerceValue(tc.value, tc.schema)
+			assert.Equal(t, tc.expected, got)
+		})
+	}
+
+	t.Run("Does not modify the input", func(t *testing.T) {
+		items := []any{"1", "2"}
+		coerceValue(items, &ParameterSchema{Type: "array", Items: intSchema})
+		assert.Equal(t, []any{"1", "2"}, items)
+	})
+}
+
+func TestReuseTokenSource(t *testing.T) {
+	t.Run("Reuses tokens until they expire", func(t *testing.T) {
+		src := &countingTokenSource{ttl: time.Hour}
+		ts := reuseTokenSource(src, 0, false)
+		for i := 0; i < 3; i++ {
+			if _, err := ts.Token(); err != nil {
+				t.Fatalf("Token failed: %v", err)
+			}
+		}
+		if src.calls != 1 {
+			t.Errorf("Expected the source to be called once, got %d", src.calls)
+		}
+	})
+
+	t.Run("Does not cache tokens without an expiry", func(t *testing.T) {
+		calls := 0
+		ts := reuseTokenSource(NewCustomTokenSource(func() string {
+			calls++
+			return fmt.Sprint(calls)
+		}), 0, false)
+		for i := 1; i <= 3; i++ {
+			token, err := ts.Token()
+			if err != nil {
+				t.Fatalf("Token failed: %v", err)
+			}
+			if token.AccessToken != fmt.Sprint(i) {
+				t.Errorf("Expected token %d, got %q", i, token.AccessToken)
+			}
+		}
+	})
+
+	t.Run("Refreshes tokens within the configured skew", func(t *testing.T) {
+		src := &countingTokenSource{ttl: 30 * time.Second}
+		ts := reuseTokenSource(src, time.Minute, true)
+		for i := 0; i < 3; i++ {
+			if _, err := ts.Token(); err != nil {
+				t.Fatalf("Token failed: %v", err)
+			}
+		}
+		if src.calls != 3 {
+			t.Errorf("Expected the source to be called on every request, got %d", src.calls)
+		}
+	})
+}
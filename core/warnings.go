@@ -0,0 +1,101 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/googleapis/mcp-toolbox-sdk-go/core/transport"
+)
+
+// Warning is a warning the server issued about the tools loaded by a client,
+// such as the deprecation of a tool, so that applications get notice before
+// the tools are removed.
+type Warning struct {
+	// Tool is the name of the tool the warning is about, or empty if it is
+	// about a whole toolset.
+	Tool string
+	// Toolset is the name of the toolset being loaded, or empty for the
+	// default toolset and for tools loaded with LoadTool.
+	Toolset string
+	// Message describes the warning.
+	Message string
+	// Sunset is when the tool or toolset is to be removed, if the server
+	// announced it.
+	Sunset time.Time
+}
+
+// WarningHandler receives the warnings issued by the server while tools are
+// loaded. See WithWarningHandler.
+type WarningHandler func(Warning)
+
+// warn reports w to the client's warning handler, or logs it if there is
+// none.
+func (tc *ToolboxClient) warn(w Warning) {
+	if tc.warningHandler != nil {
+		tc.warningHandler(w)
+		return
+	}
+	log.Printf("WARNING: %s", w.Message)
+}
+
+// warnDeprecatedTool warns about tool if the server deprecated it.
+func (tc *ToolboxClient) warnDeprecatedTool(tool *ToolboxTool, toolset string) {
+	deprecation, ok := tool.Deprecated()
+	if !ok {
+		return
+	}
+	tc.warn(Warning{
+		Tool:    tool.Name(),
+		Toolset: toolset,
+		Message: deprecationMessage(fmt.Sprintf("tool '%s'", tool.Name()), deprecation.Message, deprecation.Sunset),
+		Sunset:  deprecation.Sunset,
+	})
+}
+
+// warnDeprecatedManifest warns about a tool or toolset whose manifest was
+// served with a Deprecation header.
+func (tc *ToolboxClient) warnDeprecatedManifest(kind, name string, headers *transport.ResponseHeaders) {
+	if !headers.Deprecated() {
+		return
+	}
+	sunset, _ := headers.Sunset()
+	w := Warning{Sunset: sunset}
+	subject := fmt.Sprintf("%s '%s'", kind, name)
+	switch {
+	case kind == "tool":
+		w.Tool = name
+	case name == "":
+		subject = "toolset 'default'"
+	default:
+		w.Toolset = name
+	}
+	w.Message = deprecationMessage(subject, "", sunset)
+	tc.warn(w)
+}
+
+// deprecationMessage describes the deprecation of subject.
+func deprecationMessage(subject, message string, sunset time.Time) string {
+	msg := subject + " is deprecated"
+	if message != "" {
+		msg += ": " + message
+	}
+	if !sunset.IsZero() {
+		msg += fmt.Sprintf(" (to be removed on %s)", sunset.UTC().Format(time.DateOnly))
+	}
+	return msg
+}
@@ -0,0 +1,128 @@
+//go:build unit
+
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"net/http"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDeprecationWarnings(t *testing.T) {
+	emptySchema := map[string]any{"type": "object", "properties": map[string]any{}}
+	server := newMockMCPServer(t, []mcpTool{
+		{
+			Name:        "search",
+			InputSchema: emptySchema,
+			Meta:        map[string]any{"toolbox/deprecated": map[string]any{"message": "use search_v2", "sunset": "2026-12-31T00:00:00Z"}},
+		},
+		{Name: "search_v2", InputSchema: emptySchema},
+	})
+	defer server.Close()
+
+	// Announce the deprecation of the "legacy" toolset in its responses.
+	mockHandler := server.Config.Handler
+	server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/legacy") {
+			w.Header().Set("Deprecation", "true")
+			w.Header().Set("Sunset", "Thu, 01 Oct 2026 00:00:00 GMT")
+		}
+		mockHandler.ServeHTTP(w, r)
+	})
+
+	var warnings []Warning
+	client, err := NewToolboxClient(server.URL,
+		WithHTTPClient(server.Client()),
+		WithWarningHandler(func(w Warning) { warnings = append(warnings, w) }),
+	)
+	if err != nil {
+		t.Fatalf("NewToolboxClient failed: %v", err)
+	}
+	toolSunset := time.Date(2026, 12, 31, 0, 0, 0, 0, time.UTC)
+
+	t.Run("Warns about deprecated tools", func(t *testing.T) {
+		warnings = nil
+		tools, err := client.LoadToolset("", context.Background())
+		if err != nil {
+			t.Fatalf("LoadToolset failed: %v", err)
+		}
+		want := []Warning{{
+			Tool:    "search",
+			Message: "tool 'search' is deprecated: use search_v2 (to be removed on 2026-12-31)",
+			Sunset:  toolSunset,
+		}}
+		if !reflect.DeepEqual(warnings, want) {
+			t.Errorf("Expected warnings %+v, got %+v", want, warnings)
+		}
+
+		deprecation, ok := tools[0].Deprecated()
+		if !ok || deprecation.Message != "use search_v2" || !deprecation.Sunset.Equal(toolSunset) {
+			t.Errorf("Expected tool 'search' to be deprecated, got %+v, %t", deprecation, ok)
+		}
+		if _, ok := tools[1].Deprecated(); ok {
+			t.Error("Expected tool 'search_v2' not to be deprecated")
+		}
+	})
+
+	t.Run("Warns about deprecated toolsets", func(t *testing.T) {
+		warnings = nil
+		if _, err := client.LoadToolset("legacy", context.Background(), WithToolFilter([]string{"search_v2"}, nil)); err != nil {
+			t.Fatalf("LoadToolset failed: %v", err)
+		}
+		want := []Warning{{
+			Toolset: "legacy",
+			Message: "toolset 'legacy' is deprecated (to be removed on 2026-10-01)",
+			Sunset:  time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC),
+		}}
+		if !reflect.DeepEqual(warnings, want) {
+			t.Errorf("Expected warnings %+v, got %+v", want, warnings)
+		}
+	})
+
+	t.Run("Logs warnings without a handler", func(t *testing.T) {
+		var buf bytes.Buffer
+		log.SetOutput(&buf)
+		defer log.SetOutput(os.Stderr)
+
+		client, err := NewToolboxClient(server.URL, WithHTTPClient(server.Client()))
+		if err != nil {
+			t.Fatalf("NewToolboxClient failed: %v", err)
+		}
+		if _, err := client.LoadTool("search", context.Background()); err != nil {
+			t.Fatalf("LoadTool failed: %v", err)
+		}
+		if want := "WARNING: tool 'search' is deprecated: use search_v2"; !strings.Contains(buf.String(), want) {
+			t.Errorf("Expected the logs to contain %q, got:\n%s", want, buf.String())
+		}
+	})
+
+	t.Run("Validates the option", func(t *testing.T) {
+		if _, err := NewToolboxClient(server.URL, WithWarningHandler(nil)); err == nil || !strings.Contains(err.Error(), "cannot be nil") {
+			t.Errorf("Expected a nil handler error, got %v", err)
+		}
+		handler := func(Warning) {}
+		if _, err := NewToolboxClient(server.URL, WithWarningHandler(handler), WithWarningHandler(handler)); err == nil || !strings.Contains(err.Error(), "already set") {
+			t.Errorf("Expected an override error, got %v", err)
+		}
+	})
+}
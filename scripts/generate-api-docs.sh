@@ -17,15 +17,19 @@ set -euo pipefail
 
 export PATH="$PATH:$(go env GOPATH)/bin"
 
-PACKAGE="${1:?package required (core|tbadk|tbgenkit)}"
+PACKAGE="${1:?package required (core|tbadk|tbgenai|tbgenkit|tbmcpserver|tbollama|tbprometheus)}"
 VERSION="${2:?version required (e.g. v1.0.0 or dev)}"
 BASE_URL="${3:-/}"
 
 case "$PACKAGE" in
-  core)     TITLE="Core" ;;
-  tbadk)    TITLE="Tbadk" ;;
-  tbgenkit) TITLE="Tbgenkit" ;;
-  *)        echo "Unknown package: $PACKAGE" >&2; exit 1 ;;
+  core)         TITLE="Core" ;;
+  tbadk)        TITLE="Tbadk" ;;
+  tbgenai)      TITLE="Tbgenai" ;;
+  tbgenkit)     TITLE="Tbgenkit" ;;
+  tbmcpserver)  TITLE="Tbmcpserver" ;;
+  tbollama)     TITLE="Tbollama" ;;
+  tbprometheus) TITLE="Tbprometheus" ;;
+  *)            echo "Unknown package: $PACKAGE" >&2; exit 1 ;;
 esac
 
 go install github.com/princjef/gomarkdoc/cmd/gomarkdoc@latest
@@ -15,6 +15,7 @@
 package tbadk
 
 import (
+	"encoding/json"
 	"fmt"
 
 	"github.com/googleapis/mcp-toolbox-sdk-go/core"
@@ -108,8 +109,17 @@ func (tt ToolboxTool) Run(ctx agent.Context, args any) (result map[string]any, e
 		return nil, fmt.Errorf("error invoking the tool %s: %w", tt.Name(), err)
 	}
 
-	// Convert the result from the custom tool's invocation to a string.
+	// Convert the result from the custom tool's invocation to a string,
+	// encoding structured results and content blocks as JSON.
 	strResult := fmt.Sprintf("%v", toolresult)
+	switch toolresult.(type) {
+	case map[string]any, []core.ContentBlock:
+		encoded, err := json.Marshal(toolresult)
+		if err != nil {
+			return nil, fmt.Errorf("error encoding the result of tool %s: %w", tt.Name(), err)
+		}
+		strResult = string(encoded)
+	}
 	return map[string]any{
 		"output": strResult,
 	}, nil
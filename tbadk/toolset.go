@@ -0,0 +1,91 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tbadk
+
+import (
+	"slices"
+	"sync"
+
+	"github.com/googleapis/mcp-toolbox-sdk-go/core"
+	"google.golang.org/adk/v2/agent"
+	"google.golang.org/adk/v2/tool"
+)
+
+// Toolset is an ADK toolset backed by a Toolbox toolset. It loads its tools
+// the first time an agent asks for them, so that agents can be built before
+// the Toolbox server is reachable, and then reuses them for every later
+// request. A failed load is retried on the next request.
+type Toolset struct {
+	client ToolboxClient
+	name   string
+	opts   []core.ToolOption
+
+	mu    sync.Mutex
+	tools []tool.Tool
+}
+
+var (
+	_ tool.Toolset = (*Toolset)(nil)
+)
+
+// Toolset returns an ADK toolset that lazily loads the named toolset from the
+// Toolbox server:
+//
+//	toolset := client.Toolset("my-toolset", core.WithAuthTokenSource("my-auth", tokenSource))
+//	agent, err := llmagent.New(llmagent.Config{
+//		...
+//		Toolsets: []tool.Toolset{toolset},
+//	})
+//
+// Inputs:
+//   - name: The name of the toolset to load. If empty, all tools are loaded.
+//   - opts: A variadic list of ToolOption functions applied to the loaded
+//     tools, such as auth token sources and bound parameters.
+//
+// Returns:
+//
+//	A *Toolset that loads its tools on first use.
+func (tc ToolboxClient) Toolset(name string, opts ...core.ToolOption) *Toolset {
+	return &Toolset{client: tc, name: name, opts: slices.Clone(opts)}
+}
+
+// Name returns the name of the Toolbox toolset, or "toolbox" for the default
+// toolset.
+func (ts *Toolset) Name() string {
+	if ts.name == "" {
+		return "toolbox"
+	}
+	return ts.name
+}
+
+// Tools returns the tools of the toolset, loading them from the Toolbox
+// server on first use.
+func (ts *Toolset) Tools(ctx agent.ReadonlyContext) ([]tool.Tool, error) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	if ts.tools == nil {
+		loaded, err := ts.client.LoadToolset(ts.name, ctx, ts.opts...)
+		if err != nil {
+			return nil, err
+		}
+		tools := make([]tool.Tool, 0, len(loaded))
+		for _, t := range loaded {
+			tools = append(tools, t)
+		}
+		ts.tools = tools
+	}
+	return slices.Clone(ts.tools), nil
+}
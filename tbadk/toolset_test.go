@@ -0,0 +1,169 @@
+//go:build unit
+
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tbadk
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/googleapis/mcp-toolbox-sdk-go/core"
+	"google.golang.org/adk/v2/agent"
+)
+
+// readonlyContext is a minimal agent.ReadonlyContext for driving a Toolset
+// outside of an agent run.
+type readonlyContext struct {
+	agent.ReadonlyContext
+	context.Context
+}
+
+func (c readonlyContext) Deadline() (deadline time.Time, ok bool) { return c.Context.Deadline() }
+func (c readonlyContext) Done() <-chan struct{}                   { return c.Context.Done() }
+func (c readonlyContext) Err() error                              { return c.Context.Err() }
+func (c readonlyContext) Value(key any) any                       { return c.Context.Value(key) }
+
+// newToolsetServer starts a mock MCP server advertising the given tools. It
+// counts the tools/list requests it receives and fails them while failing is
+// set.
+func newToolsetServer(t *testing.T, toolNames []string, listCalls *atomic.Int32, failing *atomic.Bool) *httptest.Server {
+	t.Helper()
+
+	var tools []any
+	for _, name := range toolNames {
+		tools = append(tools, map[string]any{
+			"name":        name,
+			"description": "Tool " + name,
+			"inputSchema": convertParamsToJSONSchema(nil),
+		})
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Method string `json:"method"`
+			ID     any    `json:"id"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "bad request", http.StatusBadRequest)
+			return
+		}
+
+		var result any
+		switch req.Method {
+		case "initialize":
+			result = map[string]any{
+				"protocolVersion": "2025-06-18",
+				"capabilities":    map[string]any{"tools": map[string]any{}},
+				"serverInfo":      map[string]any{"name": "mock-server", "version": "1.0.0"},
+			}
+		case "notifications/initialized":
+			return
+		case "tools/list":
+			listCalls.Add(1)
+			if failing.Load() {
+				http.Error(w, "unavailable", http.StatusServiceUnavailable)
+				return
+			}
+			result = map[string]any{"tools": tools}
+		default:
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{"jsonrpc": "2.0", "id": req.ID, "result": result})
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestToolset(t *testing.T) {
+	var listCalls atomic.Int32
+	var failing atomic.Bool
+	server := newToolsetServer(t, []string{"tool-a", "tool-b"}, &listCalls, &failing)
+
+	client, err := NewToolboxClient(server.URL, core.WithHTTPClient(server.Client()))
+	if err != nil {
+		t.Fatalf("Failed to create ToolboxClient: %v", err)
+	}
+	ctx := readonlyContext{Context: context.Background()}
+
+	t.Run("Name", func(t *testing.T) {
+		if got := client.Toolset("my-toolset").Name(); got != "my-toolset" {
+			t.Errorf("Name() = %q, want %q", got, "my-toolset")
+		}
+		if got := client.Toolset("").Name(); got != "toolbox" {
+			t.Errorf("Name() = %q, want %q", got, "toolbox")
+		}
+	})
+
+	t.Run("Loads lazily and once", func(t *testing.T) {
+		listCalls.Store(0)
+		toolset := client.Toolset("")
+		if got := listCalls.Load(); got != 0 {
+			t.Fatalf("expected no tools/list calls before Tools, got %d", got)
+		}
+
+		for range 3 {
+			tools, err := toolset.Tools(ctx)
+			if err != nil {
+				t.Fatalf("Tools() returned an unexpected error: %v", err)
+			}
+			if len(tools) != 2 {
+				t.Fatalf("expected 2 tools, got %d", len(tools))
+			}
+			if tools[0].Name() != "tool-a" || tools[1].Name() != "tool-b" {
+				t.Errorf("unexpected tools: %q, %q", tools[0].Name(), tools[1].Name())
+			}
+		}
+		if got := listCalls.Load(); got != 1 {
+			t.Errorf("expected 1 tools/list call, got %d", got)
+		}
+	})
+
+	t.Run("Retries after a failed load", func(t *testing.T) {
+		listCalls.Store(0)
+		toolset := client.Toolset("")
+
+		failing.Store(true)
+		if _, err := toolset.Tools(ctx); err == nil {
+			t.Fatal("expected an error while the server is failing, got nil")
+		}
+
+		failing.Store(false)
+		tools, err := toolset.Tools(ctx)
+		if err != nil {
+			t.Fatalf("Tools() returned an unexpected error: %v", err)
+		}
+		if len(tools) != 2 {
+			t.Errorf("expected 2 tools, got %d", len(tools))
+		}
+		if got := listCalls.Load(); got != 2 {
+			t.Errorf("expected 2 tools/list calls, got %d", got)
+		}
+	})
+
+	t.Run("Applies tool options", func(t *testing.T) {
+		toolset := client.Toolset("", core.WithBindParamString("unknown", "value"))
+		if _, err := toolset.Tools(ctx); err == nil {
+			t.Error("expected an error for a bound parameter no tool uses, got nil")
+		}
+	})
+}
@@ -0,0 +1,124 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tbgenai
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/googleapis/mcp-toolbox-sdk-go/core"
+	"google.golang.org/genai"
+)
+
+// Dispatcher runs the function calls that Gemini makes against the Toolbox
+// tools they name:
+//
+//	dispatcher, err := tbgenai.NewDispatcher(tools...)
+//	...
+//	resp, err := client.Models.GenerateContent(ctx, model, contents, config)
+//	...
+//	parts, err := dispatcher.DispatchAll(ctx, resp.FunctionCalls())
+//	...
+//	contents = append(contents, resp.Candidates[0].Content, genai.NewContentFromParts(parts, genai.RoleUser))
+//
+// A Dispatcher is safe for concurrent use.
+type Dispatcher struct {
+	tools map[string]*core.ToolboxTool
+}
+
+// NewDispatcher creates a Dispatcher for tools, which must have distinct
+// names.
+func NewDispatcher(tools ...*core.ToolboxTool) (*Dispatcher, error) {
+	byName, err := core.Toolset(tools).ByName()
+	if err != nil {
+		return nil, fmt.Errorf("NewDispatcher: %w", err)
+	}
+	return &Dispatcher{tools: byName}, nil
+}
+
+// Dispatch invokes the tool named by call and returns the function response
+// to send back to the model. Following the Gemini conventions, the result of
+// the tool is returned in the "output" field of the response. Unknown tools
+// and failed invocations are reported to the model in the "error" field
+// instead, so that it can correct itself; the error is also returned.
+//
+// Inputs:
+//   - ctx: The context of the tool invocation.
+//   - call: The function call made by the model.
+//   - opts: Options applied to the invocation, such as
+//     core.WithInvokeAuthTokenSource.
+func (d *Dispatcher) Dispatch(ctx context.Context, call *genai.FunctionCall, opts ...core.InvokeOption) (*genai.FunctionResponse, error) {
+	if call == nil {
+		return nil, fmt.Errorf("error: Dispatch received a nil genai.FunctionCall pointer")
+	}
+	resp := &genai.FunctionResponse{ID: call.ID, Name: call.Name}
+
+	tool, ok := d.tools[call.Name]
+	if !ok {
+		err := fmt.Errorf("unknown tool '%s'", call.Name)
+		resp.Response = map[string]any{"error": err.Error()}
+		return resp, err
+	}
+
+	args := call.Args
+	if args == nil {
+		args = map[string]any{}
+	}
+	result, err := tool.Invoke(ctx, args, opts...)
+	if err != nil {
+		err = fmt.Errorf("error invoking the tool %s: %w", tool.Name(), err)
+		resp.Response = map[string]any{"error": err.Error()}
+		return resp, err
+	}
+
+	// Return structured results as they are and convert anything else to a
+	// string, encoding content blocks as JSON.
+	switch result.(type) {
+	case map[string]any, string:
+	case []core.ContentBlock:
+		encoded, err := json.Marshal(result)
+		if err != nil {
+			err = fmt.Errorf("error encoding the result of tool %s: %w", tool.Name(), err)
+			resp.Response = map[string]any{"error": err.Error()}
+			return resp, err
+		}
+		result = string(encoded)
+	default:
+		result = fmt.Sprintf("%v", result)
+	}
+	resp.Response = map[string]any{"output": result}
+	return resp, nil
+}
+
+// DispatchAll dispatches calls in order and returns the parts holding their
+// responses, ready to be sent back to the model in a single content. Every
+// call gets a response even if some fail; the returned error joins the
+// failures, if any.
+func (d *Dispatcher) DispatchAll(ctx context.Context, calls []*genai.FunctionCall, opts ...core.InvokeOption) ([]*genai.Part, error) {
+	parts := make([]*genai.Part, 0, len(calls))
+	var errs []error
+	for _, call := range calls {
+		resp, err := d.Dispatch(ctx, call, opts...)
+		if err != nil {
+			errs = append(errs, err)
+		}
+		if resp != nil {
+			parts = append(parts, &genai.Part{FunctionResponse: resp})
+		}
+	}
+	return parts, errors.Join(errs...)
+}
@@ -0,0 +1,133 @@
+//go:build unit
+
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tbgenai
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/googleapis/mcp-toolbox-sdk-go/core"
+	"github.com/googleapis/mcp-toolbox-sdk-go/core/toolboxtest"
+	"github.com/googleapis/mcp-toolbox-sdk-go/core/transport"
+	"google.golang.org/genai"
+)
+
+// newStoreDispatcher returns a Dispatcher for a "check_stock" tool, which
+// answers with result, and a "place_order" tool, which always fails.
+func newStoreDispatcher(t *testing.T, result any) (*Dispatcher, *toolboxtest.Transport) {
+	t.Helper()
+	tr := &toolboxtest.Transport{
+		Tools: map[string]transport.ToolSchema{
+			"check_stock": {Parameters: []core.ParameterSchema{{Name: "sku", Type: "string", Required: true}}},
+			"place_order": {Parameters: []core.ParameterSchema{{Name: "sku", Type: "string"}, {Name: "quantity", Type: "integer"}}},
+		},
+		Invoke: func(_ context.Context, name string, _ map[string]any) (any, error) {
+			if name == "place_order" {
+				return nil, errors.New("payment declined")
+			}
+			return result, nil
+		},
+	}
+	dispatcher, err := NewDispatcher(toolboxtest.LoadToolset(t, tr)...)
+	if err != nil {
+		t.Fatalf("NewDispatcher failed: %v", err)
+	}
+	return dispatcher, tr
+}
+
+func TestDispatchAll(t *testing.T) {
+	dispatcher, tr := newStoreDispatcher(t, map[string]any{"sku": "A-1", "available": 3.0})
+	parts, err := dispatcher.DispatchAll(context.Background(), []*genai.FunctionCall{
+		{ID: "call-1", Name: "check_stock", Args: map[string]any{"sku": "A-1"}},
+		{ID: "call-2", Name: "place_order", Args: map[string]any{"sku": "A-1", "quantity": 2.0}},
+		{ID: "call-3", Name: "cancel_order"},
+	})
+	if err == nil {
+		t.Fatal("Expected the failed calls to be reported")
+	}
+
+	// Following the Gemini conventions, results go in "output" and failures
+	// in "error", so that the model can correct itself.
+	expected := []*genai.Part{
+		{FunctionResponse: &genai.FunctionResponse{ID: "call-1", Name: "check_stock", Response: map[string]any{
+			"output": map[string]any{"sku": "A-1", "available": 3.0},
+		}}},
+		{FunctionResponse: &genai.FunctionResponse{ID: "call-2", Name: "place_order", Response: map[string]any{
+			"error": "error invoking the tool place_order: payment declined",
+		}}},
+		{FunctionResponse: &genai.FunctionResponse{ID: "call-3", Name: "cancel_order", Response: map[string]any{
+			"error": "unknown tool 'cancel_order'",
+		}}},
+	}
+	if !reflect.DeepEqual(parts, expected) {
+		t.Errorf("Unexpected parts: %+v", parts)
+	}
+	if !reflect.DeepEqual(tr.Invoked(), []string{"check_stock", "place_order"}) {
+		t.Errorf("Expected the known tools to be invoked in order, got %v", tr.Invoked())
+	}
+	if content := genai.NewContentFromParts(parts, genai.RoleUser); len(content.Parts) != 3 {
+		t.Errorf("Expected the parts to form a single content, got %+v", content)
+	}
+}
+
+func TestDispatch_Output(t *testing.T) {
+	// Structured results and text are passed to the model as they are;
+	// anything else is converted to a string.
+	testCases := []struct {
+		name   string
+		result any
+		want   any
+	}{
+		{"Text", "3 in stock", "3 in stock"},
+		{"StructuredResult", map[string]any{"available": 3.0}, map[string]any{"available": 3.0}},
+		{"ContentBlocks", []core.ContentBlock{{Type: "text", Text: "3 in stock"}}, `[{"type":"text","text":"3 in stock"}]`},
+		{"Number", 3.0, "3"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			dispatcher, _ := newStoreDispatcher(t, tc.result)
+			resp, err := dispatcher.Dispatch(context.Background(), &genai.FunctionCall{Name: "check_stock", Args: map[string]any{"sku": "A-1"}})
+			if err != nil {
+				t.Fatalf("Dispatch failed: %v", err)
+			}
+			if !reflect.DeepEqual(resp.Response, map[string]any{"output": tc.want}) {
+				t.Errorf("Expected output %v, got %v", tc.want, resp.Response)
+			}
+		})
+	}
+
+	t.Run("NilCall", func(t *testing.T) {
+		dispatcher, _ := newStoreDispatcher(t, nil)
+		if _, err := dispatcher.Dispatch(context.Background(), nil); err == nil {
+			t.Fatal("Expected an error for a nil call")
+		}
+	})
+}
+
+func TestNewDispatcher(t *testing.T) {
+	tr := &toolboxtest.Transport{Tools: map[string]transport.ToolSchema{"check_stock": {}}}
+	stock := toolboxtest.LoadTool(t, tr, "check_stock")
+
+	if _, err := NewDispatcher(stock, stock); err == nil || err.Error() != "NewDispatcher: duplicate tool name 'check_stock'" {
+		t.Errorf("Unexpected error for duplicate tools: %v", err)
+	}
+	if _, err := NewDispatcher(stock, nil); err == nil || err.Error() != "NewDispatcher: tool 1 is nil" {
+		t.Errorf("Unexpected error for a nil tool: %v", err)
+	}
+}
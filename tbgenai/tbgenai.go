@@ -0,0 +1,158 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package tbgenai converts Toolbox tools into function declarations for the
+// Google Gen AI SDK, and dispatches the function calls that Gemini makes back
+// to the tools.
+package tbgenai
+
+import (
+	"fmt"
+
+	"github.com/googleapis/mcp-toolbox-sdk-go/core"
+	"google.golang.org/genai"
+)
+
+// ToFunctionDeclaration converts a ToolboxTool into a Gemini function
+// declaration, whose parameters are the tool's unbound parameters.
+//
+// Inputs:
+//   - tool: The core.ToolboxTool to be converted.
+//
+// Returns:
+//
+//	The *genai.FunctionDeclaration of the tool, or an error if one of its
+//	parameters cannot be represented as a genai.Schema.
+func ToFunctionDeclaration(tool *core.ToolboxTool) (*genai.FunctionDeclaration, error) {
+	if tool == nil {
+		return nil, fmt.Errorf("error: ToFunctionDeclaration received a nil core.ToolboxTool pointer")
+	}
+	schema, err := ToSchema(tool.Parameters())
+	if err != nil {
+		return nil, fmt.Errorf("error converting the parameters of tool '%s': %w", tool.Name(), err)
+	}
+	return &genai.FunctionDeclaration{
+		Name:        tool.Name(),
+		Description: tool.Description(),
+		Parameters:  schema,
+	}, nil
+}
+
+// ToTool converts a whole toolset, such as the result of
+// core.ToolboxClient.LoadToolset, into a single genai.Tool that can be passed
+// in genai.GenerateContentConfig.Tools.
+//
+// Inputs:
+//   - tools: The core.ToolboxTool pointers to be converted.
+//
+// Returns:
+//
+//	A *genai.Tool declaring every tool, in order, or an error naming the first
+//	tool that could not be converted.
+func ToTool(tools []*core.ToolboxTool) (*genai.Tool, error) {
+	declarations := make([]*genai.FunctionDeclaration, 0, len(tools))
+	for i, tool := range tools {
+		declaration, err := ToFunctionDeclaration(tool)
+		if err != nil {
+			return nil, fmt.Errorf("tool %d: %w", i, err)
+		}
+		declarations = append(declarations, declaration)
+	}
+	return &genai.Tool{FunctionDeclarations: declarations}, nil
+}
+
+// ToSchema converts a list of tool parameters into the object schema of a
+// function declaration.
+func ToSchema(params []core.ParameterSchema) (*genai.Schema, error) {
+	schema := &genai.Schema{
+		Type:       genai.TypeObject,
+		Properties: make(map[string]*genai.Schema, len(params)),
+	}
+	for _, p := range params {
+		property, err := ParameterToSchema(p)
+		if err != nil {
+			return nil, err
+		}
+		schema.Properties[p.Name] = property
+		schema.PropertyOrdering = append(schema.PropertyOrdering, p.Name)
+		if p.Required {
+			schema.Required = append(schema.Required, p.Name)
+		}
+	}
+	return schema, nil
+}
+
+// ParameterToSchema converts a single tool parameter into a genai.Schema.
+func ParameterToSchema(p core.ParameterSchema) (*genai.Schema, error) {
+	schema := &genai.Schema{
+		Description: p.Description,
+		Default:     p.Default,
+		Minimum:     p.Minimum,
+		Maximum:     p.Maximum,
+		MinLength:   int64Ptr(p.MinLength),
+		MaxLength:   int64Ptr(p.MaxLength),
+		Pattern:     p.Pattern,
+		MinItems:    int64Ptr(p.MinItems),
+		MaxItems:    int64Ptr(p.MaxItems),
+	}
+	if p.Nullable {
+		schema.Nullable = genai.Ptr(true)
+	}
+
+	switch p.Type {
+	case "string":
+		schema.Type = genai.TypeString
+	case "integer":
+		schema.Type = genai.TypeInteger
+	case "float", "number":
+		schema.Type = genai.TypeNumber
+	case "boolean":
+		schema.Type = genai.TypeBoolean
+	case "array":
+		schema.Type = genai.TypeArray
+		if p.Items == nil {
+			return nil, fmt.Errorf("parameter '%s' is an array without an item schema", p.Name)
+		}
+		items, err := ParameterToSchema(*p.Items)
+		if err != nil {
+			return nil, err
+		}
+		schema.Items = items
+	case "object":
+		// genai.Schema cannot describe the values of a map, so typed maps
+		// are declared as free-form objects and described in prose.
+		schema.Type = genai.TypeObject
+		if ap, ok := p.AdditionalProperties.(*core.ParameterSchema); ok && ap.Type != "" {
+			schema.Description = appendSentence(schema.Description, fmt.Sprintf("The values of this object are of type %s.", ap.Type))
+		}
+	default:
+		return nil, fmt.Errorf("parameter '%s' has unsupported type '%s'", p.Name, p.Type)
+	}
+	return schema, nil
+}
+
+func int64Ptr(n *int) *int64 {
+	if n == nil {
+		return nil
+	}
+	return genai.Ptr(int64(*n))
+}
+
+// appendSentence appends sentence to text, separated by a space.
+func appendSentence(text, sentence string) string {
+	if text == "" {
+		return sentence
+	}
+	return text + " " + sentence
+}
@@ -0,0 +1,149 @@
+//go:build unit
+
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tbgenai
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/googleapis/mcp-toolbox-sdk-go/core"
+	"github.com/googleapis/mcp-toolbox-sdk-go/core/toolboxtest"
+	"github.com/googleapis/mcp-toolbox-sdk-go/core/transport"
+	"google.golang.org/genai"
+)
+
+func TestToFunctionDeclaration(t *testing.T) {
+	tr := &toolboxtest.Transport{Tools: map[string]transport.ToolSchema{
+		"search": {
+			Description: "Search the catalog",
+			Parameters: []core.ParameterSchema{
+				{Name: "query", Type: "string", Description: "The search query", Required: true, MaxLength: genai.Ptr(100)},
+				{Name: "limit", Type: "integer", Default: 10.0, Minimum: genai.Ptr(1.0)},
+				{Name: "min_price", Type: "float", Nullable: true},
+				{Name: "in_stock", Type: "boolean"},
+				{Name: "tags", Type: "array", Items: &core.ParameterSchema{Type: "string"}},
+				{Name: "filters", Type: "object", AdditionalProperties: &core.ParameterSchema{Type: "string"}},
+			},
+		},
+	}}
+
+	declaration, err := ToFunctionDeclaration(toolboxtest.LoadTool(t, tr, "search"))
+	if err != nil {
+		t.Fatalf("ToFunctionDeclaration failed: %v", err)
+	}
+	if declaration.Name != "search" || declaration.Description != "Search the catalog" {
+		t.Errorf("Unexpected name or description: %q, %q", declaration.Name, declaration.Description)
+	}
+
+	params := declaration.Parameters
+	if params.Type != genai.TypeObject {
+		t.Errorf("Expected an object schema, got %q", params.Type)
+	}
+	if !reflect.DeepEqual(params.Required, []string{"query"}) {
+		t.Errorf("Expected [query] to be required, got %v", params.Required)
+	}
+	if len(params.PropertyOrdering) != 6 || params.PropertyOrdering[0] != "query" {
+		t.Errorf("Expected the properties in declaration order, got %v", params.PropertyOrdering)
+	}
+
+	expectedTypes := map[string]genai.Type{
+		"query":     genai.TypeString,
+		"limit":     genai.TypeInteger,
+		"min_price": genai.TypeNumber,
+		"in_stock":  genai.TypeBoolean,
+		"tags":      genai.TypeArray,
+		"filters":   genai.TypeObject,
+	}
+	for name, expected := range expectedTypes {
+		property, ok := params.Properties[name]
+		if !ok {
+			t.Errorf("Missing property %q", name)
+			continue
+		}
+		if property.Type != expected {
+			t.Errorf("Expected property %q to be %q, got %q", name, expected, property.Type)
+		}
+	}
+	if params.Properties["query"].Description != "The search query" {
+		t.Errorf("Expected the description to be kept, got %q", params.Properties["query"].Description)
+	}
+	if params.Properties["limit"].Default != 10.0 {
+		t.Errorf("Expected the default to be kept, got %v", params.Properties["limit"].Default)
+	}
+	if maxLength := params.Properties["query"].MaxLength; maxLength == nil || *maxLength != 100 {
+		t.Errorf("Expected the maximum length to be kept, got %v", maxLength)
+	}
+	if minimum := params.Properties["limit"].Minimum; minimum == nil || *minimum != 1 {
+		t.Errorf("Expected the minimum to be kept, got %v", minimum)
+	}
+	if nullable := params.Properties["min_price"].Nullable; nullable == nil || !*nullable {
+		t.Error("Expected min_price to be nullable")
+	}
+	if params.Properties["query"].Nullable != nil {
+		t.Errorf("Expected query not to be nullable, got %v", *params.Properties["query"].Nullable)
+	}
+	if items := params.Properties["tags"].Items; items == nil || items.Type != genai.TypeString {
+		t.Errorf("Expected string items, got %+v", items)
+	}
+	if !strings.Contains(params.Properties["filters"].Description, "of type string") {
+		t.Errorf("Expected the value type of the map to be described, got %q", params.Properties["filters"].Description)
+	}
+}
+
+func TestToFunctionDeclaration_Errors(t *testing.T) {
+	t.Run("NilTool", func(t *testing.T) {
+		_, err := ToFunctionDeclaration(nil)
+		if err == nil || err.Error() != "error: ToFunctionDeclaration received a nil core.ToolboxTool pointer" {
+			t.Errorf("Unexpected error: %v", err)
+		}
+	})
+
+	t.Run("UnsupportedType", func(t *testing.T) {
+		_, err := ParameterToSchema(core.ParameterSchema{Name: "p", Type: "date"})
+		if err == nil || !strings.Contains(err.Error(), "parameter 'p' has unsupported type 'date'") {
+			t.Errorf("Unexpected error: %v", err)
+		}
+	})
+
+	t.Run("ArrayWithoutItems", func(t *testing.T) {
+		_, err := ParameterToSchema(core.ParameterSchema{Name: "p", Type: "array"})
+		if err == nil || !strings.Contains(err.Error(), "parameter 'p' is an array without an item schema") {
+			t.Errorf("Unexpected error: %v", err)
+		}
+	})
+}
+
+func TestToTool(t *testing.T) {
+	tr := &toolboxtest.Transport{Tools: map[string]transport.ToolSchema{
+		"a": {Description: "A"},
+		"b": {Description: "B"},
+	}}
+
+	tool, err := ToTool([]*core.ToolboxTool{toolboxtest.LoadTool(t, tr, "a"), toolboxtest.LoadTool(t, tr, "b")})
+	if err != nil {
+		t.Fatalf("ToTool failed: %v", err)
+	}
+	if len(tool.FunctionDeclarations) != 2 || tool.FunctionDeclarations[0].Name != "a" || tool.FunctionDeclarations[1].Name != "b" {
+		t.Errorf("Expected declarations for a and b in order, got %+v", tool.FunctionDeclarations)
+	}
+
+	_, err = ToTool([]*core.ToolboxTool{toolboxtest.LoadTool(t, tr, "a"), nil})
+	if err == nil || !strings.HasPrefix(err.Error(), "tool 1: ") {
+		t.Errorf("Expected the failing tool to be named, got %v", err)
+	}
+}
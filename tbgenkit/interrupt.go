@@ -0,0 +1,67 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tbgenkit
+
+import (
+	"errors"
+
+	"github.com/firebase/genkit/go/ai"
+	"github.com/googleapis/mcp-toolbox-sdk-go/core"
+)
+
+// AuthInterrupt is the metadata of the interrupt raised when a tool is run
+// without a token for every auth service it requires. Instead of failing,
+// a generation stops at the tool request so that the flow can collect the
+// user's consent or login and resume:
+//
+//	for _, part := range resp.Interrupts() {
+//		if auth, ok := ai.InterruptAs[tbgenkit.AuthInterrupt](part); ok {
+//			// Sign the user in to auth.Services, then restart the tool with
+//			// a context from which WithAuthExtractor can read their tokens.
+//		}
+//	}
+type AuthInterrupt struct {
+	// Tool is the name of the interrupted tool.
+	Tool string `json:"tool"`
+	// Services are the sorted names of the auth services without a token.
+	Services []string `json:"authServices"`
+}
+
+// authInterruptError interrupts a tool that is missing auth tokens. It keeps
+// the message of the underlying error, which it also unwraps to, so callers
+// running the tool outside a generation see the same error as before.
+type authInterruptError struct {
+	interrupt error
+	err       error
+}
+
+func (e *authInterruptError) Error() string {
+	return e.err.Error()
+}
+
+func (e *authInterruptError) Unwrap() []error {
+	return []error{e.interrupt, e.err}
+}
+
+// authInterrupt turns err into a tool interrupt if it reports missing auth
+// tokens, and returns it unchanged otherwise.
+func authInterrupt(ctx *ai.ToolContext, toolName string, err error) error {
+	var missing *core.MissingAuthError
+	if !errors.As(err, &missing) {
+		return err
+	}
+	interrupt := ai.InterruptWith(ctx, AuthInterrupt{Tool: toolName, Services: missing.Services})
+	return &authInterruptError{interrupt: interrupt, err: err}
+}
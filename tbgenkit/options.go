@@ -0,0 +1,119 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tbgenkit
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/googleapis/mcp-toolbox-sdk-go/core"
+	"golang.org/x/oauth2"
+)
+
+// AuthExtractor returns the auth token sources to invoke a tool with, keyed
+// by auth service name, from the context of the Genkit action that runs it.
+// Returning nil leaves the tool's own token sources in effect.
+type AuthExtractor func(ctx context.Context) map[string]oauth2.TokenSource
+
+// Option configures the conversion of Toolbox tools into Genkit tools.
+type Option func(*config) error
+
+// config holds the settings applied to converted tools.
+type config struct {
+	authExtractor AuthExtractor
+	namePrefix    string
+}
+
+// newConfig applies opts to a new config, naming caller in errors.
+func newConfig(caller string, opts []Option) (*config, error) {
+	cfg := &config{}
+	for _, opt := range opts {
+		if opt == nil {
+			return nil, fmt.Errorf("%s: received a nil Option", caller)
+		}
+		if err := opt(cfg); err != nil {
+			return nil, err
+		}
+	}
+	return cfg, nil
+}
+
+// WithAuthExtractor makes the converted tools take their auth tokens from the
+// context they are invoked with, rather than only from the token sources
+// bound to the tools when they were loaded. This lets a Genkit flow serving
+// several end users pass each request's tokens in its action context, read
+// here with FromContext from the github.com/firebase/genkit/go/core package:
+//
+//	tool, err := tbgenkit.ToGenkitTool(toolboxTool, g,
+//		tbgenkit.WithAuthExtractor(func(ctx context.Context) map[string]oauth2.TokenSource {
+//			token, _ := genkitcore.FromContext(ctx)["idToken"].(string)
+//			return map[string]oauth2.TokenSource{
+//				"my-auth-service": oauth2.StaticTokenSource(&oauth2.Token{AccessToken: token}),
+//			}
+//		}))
+//
+// Token sources returned by the extractor take precedence over bound ones for
+// the same auth service.
+func WithAuthExtractor(extractor AuthExtractor) Option {
+	return func(c *config) error {
+		if extractor == nil {
+			return fmt.Errorf("WithAuthExtractor: provided extractor cannot be nil")
+		}
+		if c.authExtractor != nil {
+			return fmt.Errorf("auth extractor is already set and cannot be overridden")
+		}
+		c.authExtractor = extractor
+		return nil
+	}
+}
+
+// WithNamePrefix registers the converted tools under prefix, joined to their
+// Toolbox names with a slash as in "toolbox/get-n-rows". Prefixing the tools
+// of each toolset keeps their names apart when several toolsets, or tools
+// from other plugins, share one Genkit instance.
+func WithNamePrefix(prefix string) Option {
+	return func(c *config) error {
+		if prefix == "" {
+			return fmt.Errorf("WithNamePrefix: provided prefix cannot be empty")
+		}
+		if c.namePrefix != "" {
+			return fmt.Errorf("name prefix is already set and cannot be overridden")
+		}
+		c.namePrefix = prefix
+		return nil
+	}
+}
+
+// toolName returns the Genkit name of the Toolbox tool called name.
+func (c *config) toolName(name string) string {
+	if c.namePrefix == "" {
+		return name
+	}
+	return c.namePrefix + "/" + name
+}
+
+// invokeOptions returns the invocation options that apply the token sources
+// extracted from ctx, if any.
+func (c *config) invokeOptions(ctx context.Context) []core.InvokeOption {
+	if c.authExtractor == nil {
+		return nil
+	}
+	sources := c.authExtractor(ctx)
+	opts := make([]core.InvokeOption, 0, len(sources))
+	for name, source := range sources {
+		opts = append(opts, core.WithInvokeAuthTokenSource(name, source))
+	}
+	return opts
+}
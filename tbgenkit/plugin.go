@@ -0,0 +1,134 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tbgenkit
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/firebase/genkit/go/ai"
+	"github.com/firebase/genkit/go/core/api"
+	"github.com/googleapis/mcp-toolbox-sdk-go/core"
+	"golang.org/x/oauth2"
+)
+
+// Plugin is a Genkit plugin that loads a Toolbox toolset when Genkit is
+// initialized and registers all of its tools, so that they can be looked up
+// by name with genkit.LookupTool:
+//
+//	g := genkit.Init(ctx, genkit.WithPlugins(&tbgenkit.Plugin{
+//		ServerURL: "http://localhost:5000",
+//		Toolset:   "my-toolset",
+//	}))
+//
+// Like other Genkit plugins, it panics during genkit.Init if the toolset
+// cannot be loaded.
+type Plugin struct {
+	// ServerURL is the URL of the Toolbox server.
+	ServerURL string
+	// Toolset is the name of the toolset to load. If empty, all tools are
+	// loaded.
+	Toolset string
+	// AuthTokenSources provide the tokens of the auth services the tools
+	// require, keyed by auth service name.
+	AuthTokenSources map[string]oauth2.TokenSource
+	// AuthExtractor, if set, supplies per-request token sources from the
+	// context each tool is invoked with. See WithAuthExtractor.
+	AuthExtractor AuthExtractor
+	// NamePrefix, if set, prefixes the names the tools are registered under,
+	// which keeps them apart from other tools registered with the same Genkit
+	// instance. See WithNamePrefix.
+	NamePrefix string
+	// ClientOptions configure the client that loads and invokes the tools.
+	ClientOptions []core.ClientOption
+	// ToolOptions configure every loaded tool, for example to bind parameters.
+	ToolOptions []core.ToolOption
+
+	client *core.ToolboxClient
+	tools  []ai.Tool
+}
+
+// Name returns the name of the plugin.
+func (p *Plugin) Name() string {
+	return "toolbox"
+}
+
+// Init loads the toolset and returns its tools for Genkit to register.
+func (p *Plugin) Init(ctx context.Context) []api.Action {
+	if p.client != nil {
+		panic("tbgenkit.Plugin.Init: plugin already initialized")
+	}
+	client, err := core.NewToolboxClient(p.ServerURL, p.ClientOptions...)
+	if err != nil {
+		panic(fmt.Errorf("tbgenkit.Plugin.Init: %w", err))
+	}
+
+	opts := append([]core.ToolOption(nil), p.ToolOptions...)
+	for name, source := range p.AuthTokenSources {
+		opts = append(opts, core.WithAuthTokenSource(name, source))
+	}
+	toolboxTools, err := client.LoadToolset(p.Toolset, ctx, opts...)
+	if err != nil {
+		panic(fmt.Errorf("tbgenkit.Plugin.Init: failed to load toolset '%s': %w", p.Toolset, err))
+	}
+
+	cfg := &config{authExtractor: p.AuthExtractor, namePrefix: p.NamePrefix}
+	// The tools are not registered with a Genkit instance yet, so report
+	// names that would collide when Genkit registers them.
+	names := make(map[string]bool, len(toolboxTools))
+	for _, tool := range toolboxTools {
+		name := cfg.toolName(tool.Name())
+		if names[name] {
+			panic(fmt.Errorf("tbgenkit.Plugin.Init: duplicate tool name '%s'", name))
+		}
+		names[name] = true
+	}
+	actions := make([]api.Action, 0, len(toolboxTools))
+	for _, tool := range toolboxTools {
+		genkitTool, err := newGenkitTool(tool, nil, cfg)
+		if err != nil {
+			panic(fmt.Errorf("tbgenkit.Plugin.Init: %w", err))
+		}
+		p.tools = append(p.tools, genkitTool)
+		actions = append(actions, &toolAction{tool: genkitTool})
+	}
+	p.client = client
+	return actions
+}
+
+// Client returns the client the tools were loaded with, or nil if the plugin
+// has not been initialized.
+func (p *Plugin) Client() *core.ToolboxClient {
+	return p.client
+}
+
+// Tools returns the registered tools, for example to pass to ai.WithTools.
+func (p *Plugin) Tools() []ai.Tool {
+	return append([]ai.Tool(nil), p.tools...)
+}
+
+// toolAction adapts a tool to the actions returned by Init. Genkit registers
+// these actions, at which point the tool's own action takes over.
+type toolAction struct {
+	api.Action
+	tool ai.Tool
+}
+
+// Register registers the tool with r.
+func (a *toolAction) Register(r api.Registry) {
+	a.tool.Register(r)
+	provider, id := api.ParseName(a.tool.Name())
+	a.Action = r.LookupAction(api.NewKey(api.ActionTypeToolV2, provider, id))
+}
@@ -0,0 +1,78 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tbgenkit
+
+import (
+	"context"
+
+	"github.com/googleapis/mcp-toolbox-sdk-go/core"
+)
+
+// ToolChunk is partial output reported by a Toolbox tool while Genkit runs
+// it. Exactly one of Progress and Notification is set.
+type ToolChunk struct {
+	// Tool is the name of the tool that reported the chunk.
+	Tool string
+	// Progress is a progress update, whose message may carry partial
+	// results.
+	Progress *core.Progress
+	// Notification is any other notification the server sent while the tool
+	// was running, such as a log message.
+	Notification *core.Notification
+}
+
+// StreamCallback receives the chunks reported by the Toolbox tools that
+// Genkit runs.
+type StreamCallback func(ctx context.Context, chunk ToolChunk)
+
+type streamCallbackKey struct{}
+
+// WithStreamCallback returns a copy of ctx that streams the partial output of
+// the Toolbox tools run with it to callback, instead of only returning their
+// full result. Passing the returned context to genkit.Generate lets a
+// streaming flow render tool output as it arrives:
+//
+//	ctx = tbgenkit.WithStreamCallback(ctx, func(ctx context.Context, chunk tbgenkit.ToolChunk) {
+//		if chunk.Progress != nil {
+//			_ = sendChunk(ctx, chunk.Progress.Message)
+//		}
+//	})
+//
+// Asking for progress updates has no effect on servers that do not report
+// progress.
+func WithStreamCallback(ctx context.Context, callback StreamCallback) context.Context {
+	return context.WithValue(ctx, streamCallbackKey{}, callback)
+}
+
+// streamOptions returns the invocation options that stream the partial
+// output of the named tool to the callback carried by ctx, if any.
+func streamOptions(ctx context.Context, toolName string) []core.InvokeOption {
+	callback, _ := ctx.Value(streamCallbackKey{}).(StreamCallback)
+	if callback == nil {
+		return nil
+	}
+	return []core.InvokeOption{
+		core.WithProgressHandler(func(p core.Progress) {
+			callback(ctx, ToolChunk{Tool: toolName, Progress: &p})
+		}),
+		core.WithNotificationHandler(func(n core.Notification) {
+			// Progress updates are delivered by the progress handler.
+			if n.Method == "notifications/progress" {
+				return
+			}
+			callback(ctx, ToolChunk{Tool: toolName, Notification: &n})
+		}),
+	}
+}
@@ -16,7 +16,9 @@ package tbgenkit
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"maps"
 
 	"github.com/firebase/genkit/go/ai"
 	"github.com/firebase/genkit/go/genkit"
@@ -28,12 +30,13 @@ import (
 //
 //	tool: A pointer to the custom `core.ToolboxTool` to be converted.
 //	g:    A pointer to the `genkit.Genkit` instance to register the tool.
+//	opts: Options such as WithAuthExtractor and WithNamePrefix.
 //
 // Returns:
 //
 //	An `ai.Tool` interface instance representing the Genkit-compatible tool.
 //	Returns `nil` if there are critical errors during the conversion process.
-func ToGenkitTool(tool *core.ToolboxTool, g *genkit.Genkit) (ai.Tool, error) {
+func ToGenkitTool(tool *core.ToolboxTool, g *genkit.Genkit, opts ...Option) (ai.Tool, error) {
 	// Robustness Checks
 	if tool == nil {
 		err := fmt.Errorf("error: ToGenkitTool received a nil core.ToolboxTool pointer")
@@ -44,48 +47,190 @@ func ToGenkitTool(tool *core.ToolboxTool, g *genkit.Genkit) (ai.Tool, error) {
 		return nil, err
 	}
 
+	cfg, err := newConfig("ToGenkitTool", opts)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create a Genkit Tool
+	return newGenkitTool(tool, g, cfg)
+}
+
+// newGenkitTool builds the Genkit tool for tool, registering it with g unless
+// g is nil. Tools that advertise an output schema return their structured
+// content as a map[string]any and carry the schema in their definition.
+func newGenkitTool(tool *core.ToolboxTool, g *genkit.Genkit, cfg *config) (ai.Tool, error) {
+	name := cfg.toolName(tool.Name())
+	// Genkit panics when an action is registered twice, so report collisions
+	// with the tools already registered, for example from another toolset.
+	if g != nil && genkit.LookupTool(g, name) != nil {
+		return nil, fmt.Errorf("error: a Genkit tool named '%s' is already registered", name)
+	}
+
+	inputSchema, outputSchema, executeFn, err := toolFunc(tool, cfg)
+	if err != nil {
+		return nil, err
+	}
+	if outputSchema == nil {
+		return buildTool(g, name, tool, textFunc(tool, executeFn), ai.WithInputSchema(inputSchema)), nil
+	}
+
+	genkitTool := buildTool(g, name, tool, structuredFunc(tool, executeFn), ai.WithInputSchema(inputSchema))
+	// Genkit infers the output schema of a tool from its Go result type, so
+	// replace the generic object schema it inferred for map[string]any with
+	// the schema advertised by the server.
+	if def := genkitTool.Definition(); def.OutputSchema != nil {
+		clear(def.OutputSchema)
+		maps.Copy(def.OutputSchema, outputSchema)
+	}
+	return genkitTool, nil
+}
+
+// buildTool defines fn as the Genkit tool called name for tool, registering
+// it with g unless g is nil.
+func buildTool[Out any](g *genkit.Genkit, name string, tool *core.ToolboxTool, fn ai.ToolFunc[any, Out], opts ...ai.ToolOption) ai.Tool {
+	if g == nil {
+		return ai.NewTool(name, tool.Description(), fn, opts...)
+	}
+	return genkit.DefineTool(g, name, tool.Description(), fn, opts...)
+}
+
+// textFunc adapts fn to return the result of tool as a string, encoding
+// structured results and content blocks as JSON.
+func textFunc(tool *core.ToolboxTool, fn ai.ToolFunc[any, any]) ai.ToolFunc[any, string] {
+	return func(ctx *ai.ToolContext, input any) (string, error) {
+		result, err := fn(ctx, input)
+		if err != nil {
+			return "", err
+		}
+		switch result.(type) {
+		case map[string]any, []core.ContentBlock:
+			encoded, err := json.Marshal(result)
+			if err != nil {
+				return "", fmt.Errorf("error encoding the result of core tool %s: %w", tool.Name(), err)
+			}
+			return string(encoded), nil
+		}
+		strResult := fmt.Sprintf("%v", result)
+		return strResult, nil
+	}
+}
+
+// structuredFunc adapts fn to return the structured result of tool, decoding
+// results that the server only sent as JSON text.
+func structuredFunc(tool *core.ToolboxTool, fn ai.ToolFunc[any, any]) ai.ToolFunc[any, map[string]any] {
+	return func(ctx *ai.ToolContext, input any) (map[string]any, error) {
+		result, err := fn(ctx, input)
+		if err != nil {
+			return nil, err
+		}
+		switch v := result.(type) {
+		case map[string]any:
+			return v, nil
+		case string:
+			var structured map[string]any
+			if err := json.Unmarshal([]byte(v), &structured); err != nil {
+				return nil, fmt.Errorf("error decoding the structured result of core tool %s: %w", tool.Name(), err)
+			}
+			return structured, nil
+		}
+		return nil, fmt.Errorf("core tool %s returned %T instead of a structured result", tool.Name(), result)
+	}
+}
+
+// toolFunc returns the input and output schemas of tool, the latter being nil
+// if the server did not advertise one, and the function that invokes it as a
+// Genkit tool.
+func toolFunc(tool *core.ToolboxTool, cfg *config) (map[string]any, map[string]any, ai.ToolFunc[any, any], error) {
 	// Retrieve the JSON schema bytes from the custom tool.
 	jsonBytes, err := tool.InputSchema()
 	if err != nil {
-		return nil, fmt.Errorf("error fetching input schema for tool '%s': %w", tool.Name(), err)
+		return nil, nil, nil, fmt.Errorf("error fetching input schema for tool '%s': %w", tool.Name(), err)
 	}
 
 	// Unmarshal the JSON schema bytes into a map.
 	var schema map[string]any
 
 	if err := json.Unmarshal(jsonBytes, &schema); err != nil {
-		return nil, fmt.Errorf("error converting input schema into json schema for tool '%s': %w", tool.Name(), err)
+		return nil, nil, nil, fmt.Errorf("error converting input schema into json schema for tool '%s': %w", tool.Name(), err)
+	}
+
+	// Retrieve the schema of the tool's structured output, if any.
+	var outputSchema map[string]any
+	outputBytes, err := tool.OutputSchema()
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("error fetching output schema for tool '%s': %w", tool.Name(), err)
+	}
+	if outputBytes != nil {
+		if err := json.Unmarshal(outputBytes, &outputSchema); err != nil {
+			return nil, nil, nil, fmt.Errorf("error converting output schema into json schema for tool '%s': %w", tool.Name(), err)
+		}
 	}
 
 	// Define the execution function for the Genkit tool.
 	// This function acts as a wrapper around the core.ToolboxTool's Invoke method.
-	// It conforms to the `func(ctx *ai.ToolContext, input any) (string, error)` signature
-	// required by Genkit's tool definition.
-	executeFn := func(ctx *ai.ToolContext, input any) (string, error) {
+	// It conforms to the `func(ctx *ai.ToolContext, input any) (any, error)` signature
+	// required by Genkit's tool definition, and leaves the conversion of the
+	// result to textFunc and structuredFunc.
+	executeFn := func(ctx *ai.ToolContext, input any) (any, error) {
 		// Perform a safe type assertion for the input.
 		inputMap, ok := input.(map[string]any)
 		if !ok {
 			// If the input is not a map, return an error indicating the type mismatch.
-			return "", fmt.Errorf("tool input expected map[string]any, got %T", input)
+			return nil, fmt.Errorf("tool input expected map[string]any, got %T", input)
 		}
-		// Invoke the underlying custom tool with the provided context and input.
-		result, err := tool.Invoke(ctx, inputMap)
+		// Invoke the underlying custom tool with the provided context and input,
+		// streaming its partial output to the callback carried by the context
+		// and applying the auth tokens extracted from it.
+		invokeOpts := append(streamOptions(ctx, tool.Name()), cfg.invokeOptions(ctx)...)
+		result, err := tool.Invoke(ctx, inputMap, invokeOpts...)
 		if err != nil {
-			// Propagate any errors that occurred during the custom tool's invocation.
-			return "", fmt.Errorf("error invoking core tool %s: %w", tool.Name(), err)
+			// Propagate any errors that occurred during the custom tool's invocation,
+			// interrupting the tool instead if it is missing auth tokens.
+			err = fmt.Errorf("error invoking core tool %s: %w", tool.Name(), err)
+			return nil, authInterrupt(ctx, tool.Name(), err)
 		}
+		return result, nil
+	}
 
-		// Convert the result from the custom tool's invocation to a string.
-		strResult := fmt.Sprintf("%v", result)
-		return strResult, nil
+	return schema, outputSchema, executeFn, nil
+}
+
+// ToGenkitTools converts and registers a whole toolset, such as the result of
+// core.ToolboxClient.LoadToolset, at once.
+// Inputs:
+//
+//	tools: The `core.ToolboxTool` pointers to be converted.
+//	g:     A pointer to the `genkit.Genkit` instance to register the tools.
+//	opts:  Options applied to every tool, such as WithNamePrefix.
+//
+// Returns:
+//
+//	The `ai.Tool` instances of the tools that were converted, in order, and
+//	an error joining the errors of every tool that could not be converted,
+//	if any. The converted tools remain registered even if others fail.
+func ToGenkitTools(tools []*core.ToolboxTool, g *genkit.Genkit, opts ...Option) ([]ai.Tool, error) {
+	if g == nil {
+		return nil, fmt.Errorf("error: ToGenkitTools received a nil genkit.Genkit pointer")
+	}
+	// Reject invalid options once rather than for every tool.
+	if _, err := newConfig("ToGenkitTools", opts); err != nil {
+		return nil, err
 	}
 
-	// Create a Genkit Tool
-	return genkit.DefineTool(
-		g,
-		tool.Name(),
-		tool.Description(),
-		executeFn,
-		ai.WithInputSchema(schema),
-	), nil
+	genkitTools := make([]ai.Tool, 0, len(tools))
+	var errs []error
+	for i, tool := range tools {
+		genkitTool, err := ToGenkitTool(tool, g, opts...)
+		if err != nil {
+			if tool == nil {
+				errs = append(errs, fmt.Errorf("tool %d: %w", i, err))
+			} else {
+				errs = append(errs, fmt.Errorf("tool '%s': %w", tool.Name(), err))
+			}
+			continue
+		}
+		genkitTools = append(genkitTools, genkitTool)
+	}
+	return genkitTools, errors.Join(errs...)
 }
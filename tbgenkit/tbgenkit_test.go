@@ -18,12 +18,15 @@ package tbgenkit_test
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"log"
 	"os"
 	"reflect"
 	"testing"
 
+	"github.com/firebase/genkit/go/ai"
+	genkitcore "github.com/firebase/genkit/go/core"
 	"github.com/firebase/genkit/go/genkit"
 	"github.com/googleapis/mcp-toolbox-sdk-go/core"
 	"github.com/googleapis/mcp-toolbox-sdk-go/tbgenkit"
@@ -200,6 +203,168 @@ func TestToGenkitTool(t *testing.T) {
 	}
 }
 
+func TestToGenkitTools(t *testing.T) {
+	ctx := context.Background()
+	client, err := core.NewToolboxClient("http://localhost:5000")
+	require.NoError(t, err, "Failed to create ToolboxClient")
+
+	t.Run("ConvertsAToolset", func(t *testing.T) {
+		tools, err := client.LoadToolset("", ctx)
+		require.NoError(t, err, "Failed to load toolset")
+
+		genkitTools, err := tbgenkit.ToGenkitTools(tools, genkit.Init(ctx))
+		require.NoError(t, err)
+		require.Len(t, genkitTools, len(tools))
+		for i, tool := range tools {
+			assert.Equal(t, tool.Name(), genkitTools[i].Name())
+		}
+	})
+
+	t.Run("AggregatesErrors", func(t *testing.T) {
+		tool, err := client.LoadTool("get-n-rows", ctx)
+		require.NoError(t, err)
+
+		genkitTools, err := tbgenkit.ToGenkitTools([]*core.ToolboxTool{nil, tool, nil}, genkit.Init(ctx))
+		require.Error(t, err)
+		assert.Len(t, genkitTools, 1, "Expected the valid tool to be converted")
+		assert.Contains(t, err.Error(), "tool 0: error: ToGenkitTool received a nil core.ToolboxTool pointer")
+		assert.Contains(t, err.Error(), "tool 2:")
+	})
+
+	t.Run("NilGenkit", func(t *testing.T) {
+		_, err := tbgenkit.ToGenkitTools(nil, nil)
+		assert.EqualError(t, err, "error: ToGenkitTools received a nil genkit.Genkit pointer")
+	})
+}
+
+func TestToGenkitTool_OutputSchema(t *testing.T) {
+	for _, proto := range protocolsToTest {
+		t.Run(proto.name, func(t *testing.T) {
+			opts := []core.ClientOption{}
+			if !proto.isDefault {
+				opts = append(opts, core.WithProtocol(proto.protocol))
+			}
+			client, err := core.NewToolboxClient("http://localhost:5000", opts...)
+			require.NoError(t, err, "Failed to create ToolboxClient")
+
+			ctx := context.Background()
+			tool, err := client.LoadTool("get-n-rows", ctx)
+			require.NoError(t, err, "Failed to load tool 'get-n-rows'")
+			genkitTool, err := tbgenkit.ToGenkitTool(tool, genkit.Init(ctx))
+			require.NoError(t, err)
+
+			outputSchema, err := tool.OutputSchema()
+			require.NoError(t, err)
+			result, err := genkitTool.RunRaw(ctx, map[string]any{"num_rows": "2"})
+			require.NoError(t, err)
+
+			if outputSchema == nil {
+				// Tools without an output schema keep returning text.
+				assert.IsType(t, "", result)
+				return
+			}
+			var expected map[string]any
+			require.NoError(t, json.Unmarshal(outputSchema, &expected))
+			assert.Equal(t, expected, genkitTool.Definition().OutputSchema)
+			assert.IsType(t, map[string]any{}, result, "Expected a structured result")
+		})
+	}
+}
+
+func TestToGenkitTool_NamePrefix(t *testing.T) {
+	ctx := context.Background()
+	client, err := core.NewToolboxClient("http://localhost:5000")
+	require.NoError(t, err, "Failed to create ToolboxClient")
+	tool, err := client.LoadTool("get-n-rows", ctx)
+	require.NoError(t, err)
+
+	t.Run("RegistersUnderPrefix", func(t *testing.T) {
+		g := genkit.Init(ctx)
+		genkitTool, err := tbgenkit.ToGenkitTool(tool, g, tbgenkit.WithNamePrefix("toolbox"))
+		require.NoError(t, err)
+		assert.Equal(t, "toolbox/get-n-rows", genkitTool.Name())
+
+		registered := genkit.LookupTool(g, "toolbox/get-n-rows")
+		require.NotNil(t, registered, "Expected the tool to be registered under its prefixed name")
+		result, err := registered.RunRaw(ctx, map[string]any{"num_rows": "2"})
+		require.NoError(t, err)
+		assert.Contains(t, result, "row2")
+	})
+
+	t.Run("SeparatesToolsets", func(t *testing.T) {
+		g := genkit.Init(ctx)
+		_, err := tbgenkit.ToGenkitTool(tool, g, tbgenkit.WithNamePrefix("first"))
+		require.NoError(t, err)
+		_, err = tbgenkit.ToGenkitTool(tool, g, tbgenkit.WithNamePrefix("second"))
+		require.NoError(t, err)
+	})
+
+	t.Run("DetectsCollisions", func(t *testing.T) {
+		g := genkit.Init(ctx)
+		_, err := tbgenkit.ToGenkitTool(tool, g)
+		require.NoError(t, err)
+
+		_, err = tbgenkit.ToGenkitTool(tool, g)
+		assert.EqualError(t, err, "error: a Genkit tool named 'get-n-rows' is already registered")
+
+		_, err = tbgenkit.ToGenkitTools([]*core.ToolboxTool{tool}, g)
+		assert.ErrorContains(t, err, "tool 'get-n-rows': error: a Genkit tool named 'get-n-rows' is already registered")
+	})
+
+	t.Run("InvalidPrefix", func(t *testing.T) {
+		g := genkit.Init(ctx)
+		_, err := tbgenkit.ToGenkitTool(tool, g, tbgenkit.WithNamePrefix(""))
+		assert.EqualError(t, err, "WithNamePrefix: provided prefix cannot be empty")
+
+		_, err = tbgenkit.ToGenkitTool(tool, g, tbgenkit.WithNamePrefix("a"), tbgenkit.WithNamePrefix("b"))
+		assert.EqualError(t, err, "name prefix is already set and cannot be overridden")
+	})
+}
+
+func TestStreamCallback(t *testing.T) {
+	ctx := context.Background()
+	client, err := core.NewToolboxClient("http://localhost:5000")
+	require.NoError(t, err, "Failed to create ToolboxClient")
+
+	tool, err := client.LoadTool("get-n-rows", ctx)
+	require.NoError(t, err)
+	genkitTool, err := tbgenkit.ToGenkitTool(tool, genkit.Init(ctx))
+	require.NoError(t, err)
+
+	var chunks []tbgenkit.ToolChunk
+	ctx = tbgenkit.WithStreamCallback(ctx, func(_ context.Context, chunk tbgenkit.ToolChunk) {
+		chunks = append(chunks, chunk)
+	})
+	result, err := genkitTool.RunRaw(ctx, map[string]any{"num_rows": "2"})
+	require.NoError(t, err, "Invocation with a stream callback should succeed")
+	assert.Contains(t, result, "row1")
+	for _, chunk := range chunks {
+		assert.Equal(t, "get-n-rows", chunk.Tool)
+	}
+}
+
+func TestPlugin(t *testing.T) {
+	ctx := context.Background()
+	plugin := &tbgenkit.Plugin{ServerURL: "http://localhost:5000"}
+	g := genkit.Init(ctx, genkit.WithPlugins(plugin))
+
+	require.NotNil(t, plugin.Client())
+	assert.NotEmpty(t, plugin.Tools())
+
+	tool := genkit.LookupTool(g, "get-n-rows")
+	require.NotNil(t, tool, "Expected the toolset to be registered")
+	result, err := tool.RunRaw(ctx, map[string]any{"num_rows": "2"})
+	require.NoError(t, err)
+	assert.Contains(t, result, "row2")
+	assert.NotContains(t, result, "row3")
+
+	t.Run("PanicsOnUnknownToolset", func(t *testing.T) {
+		assert.Panics(t, func() {
+			genkit.Init(ctx, genkit.WithPlugins(&tbgenkit.Plugin{ServerURL: "http://localhost:5000", Toolset: "non-existent-toolset"}))
+		})
+	})
+}
+
 func TestToGenkitTool_BoundParams(t *testing.T) {
 	for _, proto := range protocolsToTest {
 		t.Run(proto.name, func(t *testing.T) {
@@ -345,6 +510,26 @@ func TestToGenkitTool_Auth(t *testing.T) {
 				assert.Contains(t, err.Error(), "permission error: auth service 'my-test-auth' is required")
 			})
 
+			t.Run("test_run_tool_no_auth_interrupts", func(t *testing.T) {
+				client := newClient(t)
+				tool, err := client.LoadTool("get-row-by-id-auth", ctx)
+				require.NoError(t, err)
+				g := newGenkit()
+
+				genkitTool, err := tbgenkit.ToGenkitTool(tool, g)
+				require.NoError(t, err)
+
+				_, err = genkitTool.RunRaw(ctx, map[string]any{"id": "2"})
+				interrupted, metadata := ai.IsToolInterruptError(err)
+				require.True(t, interrupted, "Expected missing auth to interrupt the tool, got %v", err)
+				assert.Equal(t, "get-row-by-id-auth", metadata["tool"])
+				assert.Equal(t, []any{"my-test-auth"}, metadata["authServices"])
+
+				var missingAuth *core.MissingAuthError
+				require.ErrorAs(t, err, &missingAuth)
+				assert.Equal(t, []string{"my-test-auth"}, missingAuth.Services)
+			})
+
 			t.Run("test_run_tool_wrong_auth", func(t *testing.T) {
 				client := newClient(t)
 				tool, err := client.LoadTool("get-row-by-id-auth", ctx)
@@ -387,6 +572,64 @@ func TestToGenkitTool_Auth(t *testing.T) {
 				assert.Contains(t, respStr, "row2")
 			})
 
+			// Reads the token of the end user from the Genkit action context.
+			authExtractor := tbgenkit.WithAuthExtractor(func(ctx context.Context) map[string]oauth2.TokenSource {
+				token, ok := genkitcore.FromContext(ctx)["token"].(string)
+				if !ok {
+					return nil
+				}
+				return map[string]oauth2.TokenSource{"my-test-auth": staticTokenSource(token)}
+			})
+
+			t.Run("test_run_tool_auth_extractor", func(t *testing.T) {
+				client := newClient(t)
+				tool, err := client.LoadTool("get-row-by-id-auth", ctx)
+				require.NoError(t, err)
+				g := newGenkit()
+
+				genkitTool, err := tbgenkit.ToGenkitTool(tool, g, authExtractor)
+				require.NoError(t, err)
+
+				_, err = genkitTool.RunRaw(ctx, map[string]any{"id": "2"})
+				require.Error(t, err, "Expected an error without a token in the action context")
+				assert.Contains(t, err.Error(), "permission error: auth service 'my-test-auth' is required")
+
+				userCtx := genkitcore.WithActionContext(ctx, genkitcore.ActionContext{"token": authToken1})
+				response, err := genkitTool.RunRaw(userCtx, map[string]any{"id": "2"})
+				require.NoError(t, err)
+				assert.Contains(t, response, "row2")
+			})
+
+			t.Run("test_run_tool_auth_extractor_overrides_bound_token", func(t *testing.T) {
+				client := newClient(t)
+				tool, err := client.LoadTool("get-row-by-id-auth", ctx,
+					core.WithAuthTokenSource("my-test-auth", staticTokenSource(authToken2)),
+				)
+				require.NoError(t, err)
+				g := newGenkit()
+
+				genkitTool, err := tbgenkit.ToGenkitTool(tool, g, authExtractor)
+				require.NoError(t, err)
+
+				userCtx := genkitcore.WithActionContext(ctx, genkitcore.ActionContext{"token": authToken1})
+				response, err := genkitTool.RunRaw(userCtx, map[string]any{"id": "2"})
+				require.NoError(t, err)
+				assert.Contains(t, response, "row2")
+			})
+
+			t.Run("test_auth_extractor_invalid", func(t *testing.T) {
+				client := newClient(t)
+				tool, err := client.LoadTool("get-row-by-id-auth", ctx)
+				require.NoError(t, err)
+				g := newGenkit()
+
+				_, err = tbgenkit.ToGenkitTool(tool, g, tbgenkit.WithAuthExtractor(nil))
+				assert.EqualError(t, err, "WithAuthExtractor: provided extractor cannot be nil")
+
+				_, err = tbgenkit.ToGenkitTool(tool, g, authExtractor, authExtractor)
+				assert.EqualError(t, err, "auth extractor is already set and cannot be overridden")
+			})
+
 			t.Run("test_run_tool_param_auth_no_auth", func(t *testing.T) {
 				client := newClient(t)
 				tool, err := client.LoadTool("get-row-by-email-auth", ctx)
@@ -0,0 +1,92 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tbmcpserver
+
+import (
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+)
+
+// maxRequestBytes bounds the size of a request body of the streamable HTTP
+// transport.
+const maxRequestBytes = 10 << 20
+
+// ServeHTTP serves the tools over the streamable HTTP transport. Every
+// JSON-RPC message is POSTed to the handler and requests are answered with a
+// single JSON response; the server opens no SSE streams and keeps no
+// sessions, so GET and DELETE requests are rejected.
+//
+// Requests from browser origins other than loopback ones and those allowed
+// with WithAllowedOrigins are rejected with 403 Forbidden, so that web pages
+// cannot reach the tools, and the credentials behind them, through DNS
+// rebinding.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if origin := r.Header.Get("Origin"); origin != "" && !s.allowsOrigin(origin) {
+		http.Error(w, "origin not allowed", http.StatusForbidden)
+		return
+	}
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxRequestBytes))
+	if err != nil {
+		http.Error(w, "error reading the request body", http.StatusBadRequest)
+		return
+	}
+	msg, errResp := decodeMessage(body)
+	if errResp != nil {
+		writeResponse(w, http.StatusBadRequest, errResp)
+		return
+	}
+	if !msg.isRequest() {
+		// Notifications and responses are acknowledged without a body.
+		w.WriteHeader(http.StatusAccepted)
+		return
+	}
+	writeResponse(w, http.StatusOK, s.handle(r.Context(), msg))
+}
+
+// allowsOrigin reports whether requests from the browser origin are served.
+func (s *Server) allowsOrigin(origin string) bool {
+	normalized, err := normalizeOrigin(origin)
+	if err != nil {
+		return false
+	}
+	if s.cfg.allowedOrigins[normalized] {
+		return true
+	}
+	u, _ := url.Parse(normalized)
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return false
+	}
+	host := u.Hostname()
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+func writeResponse(w http.ResponseWriter, status int, resp *response) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(resp)
+}
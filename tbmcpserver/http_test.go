@@ -0,0 +1,167 @@
+//go:build unit
+
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tbmcpserver
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/googleapis/mcp-toolbox-sdk-go/core"
+)
+
+func TestServeHTTP(t *testing.T) {
+	httpServer := httptest.NewServer(newTestServer(t, newTestTransport()))
+	defer httpServer.Close()
+
+	post := func(body string) (*http.Response, map[string]any) {
+		t.Helper()
+		resp, err := http.Post(httpServer.URL, "application/json", strings.NewReader(body))
+		if err != nil {
+			t.Fatalf("POST failed: %v", err)
+		}
+		defer resp.Body.Close()
+		var decoded map[string]any
+		_ = json.NewDecoder(resp.Body).Decode(&decoded)
+		return resp, decoded
+	}
+
+	t.Run("Request", func(t *testing.T) {
+		resp, body := post(`{"jsonrpc":"2.0","id":7,"method":"ping"}`)
+		if resp.StatusCode != http.StatusOK || resp.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("Unexpected status or content type: %d, %q", resp.StatusCode, resp.Header.Get("Content-Type"))
+		}
+		if body["id"] != 7.0 || body["result"] == nil {
+			t.Errorf("Unexpected response: %v", body)
+		}
+	})
+
+	t.Run("Notification", func(t *testing.T) {
+		resp, _ := post(`{"jsonrpc":"2.0","method":"notifications/initialized"}`)
+		if resp.StatusCode != http.StatusAccepted {
+			t.Errorf("Expected 202 Accepted, got %d", resp.StatusCode)
+		}
+	})
+
+	t.Run("ParseError", func(t *testing.T) {
+		resp, body := post(`{`)
+		rpcErr, _ := body["error"].(map[string]any)
+		if resp.StatusCode != http.StatusBadRequest || rpcErr["code"] != float64(codeParseError) {
+			t.Errorf("Expected a parse error, got %d %v", resp.StatusCode, body)
+		}
+	})
+
+	t.Run("MethodNotAllowed", func(t *testing.T) {
+		resp, err := http.Get(httpServer.URL)
+		if err != nil {
+			t.Fatalf("GET failed: %v", err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusMethodNotAllowed || resp.Header.Get("Allow") != http.MethodPost {
+			t.Errorf("Expected 405 allowing POST, got %d %q", resp.StatusCode, resp.Header.Get("Allow"))
+		}
+	})
+}
+
+// TestServeHTTP_CoreClient checks that the bridge can be used by the MCP
+// client of the core SDK.
+func TestServeHTTP_Origin(t *testing.T) {
+	server := newTestServer(t, newTestTransport(), WithAllowedOrigins("https://App.example.com"))
+
+	testCases := []struct {
+		origin string
+		want   int
+	}{
+		{"", http.StatusOK},
+		{"http://localhost:3000", http.StatusOK},
+		{"http://127.0.0.1:8080", http.StatusOK},
+		{"http://[::1]", http.StatusOK},
+		{"https://app.example.com", http.StatusOK},
+		{"https://evil.example.com", http.StatusForbidden},
+		{"http://localhost.evil.example.com", http.StatusForbidden},
+		{"null", http.StatusForbidden},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.origin, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/mcp", strings.NewReader(`{"jsonrpc":"2.0","id":1,"method":"ping"}`))
+			if tc.origin != "" {
+				req.Header.Set("Origin", tc.origin)
+			}
+			rec := httptest.NewRecorder()
+			server.ServeHTTP(rec, req)
+			if rec.Code != tc.want {
+				t.Errorf("Expected status %d, got %d", tc.want, rec.Code)
+			}
+		})
+	}
+}
+
+func TestWithAllowedOrigins(t *testing.T) {
+	testCases := []struct {
+		name    string
+		opts    []Option
+		wantErr string
+	}{
+		{"No origins", []Option{WithAllowedOrigins()}, "at least one origin"},
+		{"Invalid origin", []Option{WithAllowedOrigins("example.com")}, "invalid origin"},
+		{"Origin with a path", []Option{WithAllowedOrigins("https://example.com/app")}, "invalid origin"},
+		{"Set twice", []Option{WithAllowedOrigins("https://a.example.com"), WithAllowedOrigins("https://b.example.com")}, "already set"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := NewServer(nil, tc.opts...)
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Errorf("Expected an error containing %q, got %v", tc.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestServeHTTP_CoreClient(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.Handle("/mcp/", newTestServer(t, newTestTransport()))
+	httpServer := httptest.NewServer(mux)
+	defer httpServer.Close()
+
+	ctx := context.Background()
+	client, err := core.NewToolboxClient(httpServer.URL, core.WithHTTPClient(httpServer.Client()))
+	if err != nil {
+		t.Fatalf("Failed to create ToolboxClient: %v", err)
+	}
+	tools, err := client.LoadToolset("", ctx)
+	if err != nil {
+		t.Fatalf("LoadToolset failed: %v", err)
+	}
+	if len(tools) != 3 {
+		t.Fatalf("Expected 3 tools, got %d", len(tools))
+	}
+
+	tool, err := client.LoadTool("echo", ctx)
+	if err != nil {
+		t.Fatalf("LoadTool failed: %v", err)
+	}
+	result, err := tool.Invoke(ctx, map[string]any{"text": "through the bridge"})
+	if err != nil {
+		t.Fatalf("Invoke failed: %v", err)
+	}
+	if result != "through the bridge" {
+		t.Errorf("Expected the echoed text, got %v", result)
+	}
+}
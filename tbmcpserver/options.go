@@ -0,0 +1,118 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tbmcpserver
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// Option configures a Server.
+type Option func(*config) error
+
+// config holds the settings of a Server.
+type config struct {
+	name         string
+	version      string
+	instructions string
+	// allowedOrigins holds the normalized browser origins, besides loopback
+	// ones, whose HTTP requests are served.
+	allowedOrigins map[string]bool
+}
+
+// newConfig applies opts to the default settings of a Server.
+func newConfig(opts []Option) (*config, error) {
+	cfg := &config{}
+	for _, opt := range opts {
+		if opt == nil {
+			return nil, fmt.Errorf("NewServer: received a nil Option")
+		}
+		if err := opt(cfg); err != nil {
+			return nil, err
+		}
+	}
+	if cfg.name == "" {
+		cfg.name, cfg.version = defaultServerName, Version
+	}
+	return cfg, nil
+}
+
+// WithServerInfo sets the name and version the server reports to MCP clients
+// during initialization. They default to "toolbox-mcp-bridge" and the version
+// of this package.
+func WithServerInfo(name, version string) Option {
+	return func(c *config) error {
+		if name == "" {
+			return fmt.Errorf("WithServerInfo: name cannot be empty")
+		}
+		if c.name != "" {
+			return fmt.Errorf("server info is already set and cannot be overridden")
+		}
+		c.name, c.version = name, version
+		return nil
+	}
+}
+
+// WithInstructions sets the instructions the server sends to MCP clients
+// during initialization, which clients may add to the model's system prompt.
+func WithInstructions(instructions string) Option {
+	return func(c *config) error {
+		if instructions == "" {
+			return fmt.Errorf("WithInstructions: instructions cannot be empty")
+		}
+		if c.instructions != "" {
+			return fmt.Errorf("instructions are already set and cannot be overridden")
+		}
+		c.instructions = instructions
+		return nil
+	}
+}
+
+// WithAllowedOrigins allows browser requests from the given origins, such as
+// "https://app.example.com", over the streamable HTTP transport. Requests
+// carrying an Origin header are rejected unless the origin is allowed or is a
+// loopback origin, such as "http://localhost:3000", which protects the
+// server's credentials against DNS rebinding attacks. Requests without an
+// Origin header, as sent by non-browser clients, are always served.
+func WithAllowedOrigins(origins ...string) Option {
+	return func(c *config) error {
+		if len(origins) == 0 {
+			return fmt.Errorf("WithAllowedOrigins: at least one origin is required")
+		}
+		if c.allowedOrigins != nil {
+			return fmt.Errorf("allowed origins are already set and cannot be overridden")
+		}
+		c.allowedOrigins = make(map[string]bool, len(origins))
+		for _, origin := range origins {
+			normalized, err := normalizeOrigin(origin)
+			if err != nil {
+				return fmt.Errorf("WithAllowedOrigins: %w", err)
+			}
+			c.allowedOrigins[normalized] = true
+		}
+		return nil
+	}
+}
+
+// normalizeOrigin validates an origin of the form scheme://host[:port] and
+// lowercases it for comparison.
+func normalizeOrigin(origin string) (string, error) {
+	u, err := url.Parse(origin)
+	if err != nil || u.Scheme == "" || u.Host == "" || (u.Path != "" && u.Path != "/") || u.RawQuery != "" || u.Fragment != "" || u.User != nil {
+		return "", fmt.Errorf("invalid origin '%s': expected scheme://host[:port]", origin)
+	}
+	return strings.ToLower(u.Scheme + "://" + u.Host), nil
+}
@@ -0,0 +1,294 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package tbmcpserver serves a set of Toolbox tools over the Model Context
+// Protocol, so that MCP clients written in any language, such as IDEs and
+// desktop assistants, can reach a Toolbox server through a single Go bridge.
+// Invocations are proxied back through the core SDK, so the auth token
+// sources and bound parameters applied to the tools on the client side stay
+// in effect and are never exposed to the MCP client.
+package tbmcpserver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	"github.com/googleapis/mcp-toolbox-sdk-go/core"
+)
+
+const (
+	// defaultServerName is the name reported to MCP clients unless
+	// WithServerInfo is used.
+	defaultServerName = "toolbox-mcp-bridge"
+	// latestProtocolVersion is offered to clients requesting a protocol
+	// version the server does not support.
+	latestProtocolVersion = "2025-11-25"
+)
+
+// supportedProtocolVersions are the MCP versions the server can speak.
+var supportedProtocolVersions = []string{"2024-11-05", "2025-03-26", "2025-06-18", latestProtocolVersion}
+
+// JSON-RPC error codes used by the server.
+const (
+	codeParseError     = -32700
+	codeInvalidRequest = -32600
+	codeMethodNotFound = -32601
+	codeInvalidParams  = -32602
+)
+
+// Server is an MCP server exposing Toolbox tools. It serves them over stdio
+// with Serve or ServeStdio, and over the streamable HTTP transport as an
+// http.Handler:
+//
+//	tools, err := client.LoadToolset("my-toolset", ctx, core.WithAuthTokenSource("my-auth", tokenSource))
+//	...
+//	server, err := tbmcpserver.NewServer(tools)
+//	...
+//	err = server.ServeStdio(ctx)
+//	// or
+//	err = http.ListenAndServe("127.0.0.1:8080", server)
+//
+// Anyone who can reach the HTTP handler can run the tools with the
+// credentials applied on the client side, so listen on a loopback address
+// and add authentication in front of the handler before exposing it further.
+//
+// A Server is safe for concurrent use.
+type Server struct {
+	cfg   *config
+	tools map[string]*core.ToolboxTool
+	defs  []toolDefinition
+}
+
+// NewServer creates a Server for tools, which must have distinct names. The
+// tools are listed to MCP clients in the given order.
+func NewServer(tools []*core.ToolboxTool, opts ...Option) (*Server, error) {
+	cfg, err := newConfig(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	byName, err := core.Toolset(tools).ByName()
+	if err != nil {
+		return nil, fmt.Errorf("NewServer: %w", err)
+	}
+	s := &Server{cfg: cfg, tools: byName}
+	for _, tool := range tools {
+		def, err := newToolDefinition(tool)
+		if err != nil {
+			return nil, err
+		}
+		s.defs = append(s.defs, def)
+	}
+	return s, nil
+}
+
+// toolDefinition is an entry of the result of tools/list.
+type toolDefinition struct {
+	Name         string          `json:"name"`
+	Title        string          `json:"title,omitempty"`
+	Description  string          `json:"description,omitempty"`
+	InputSchema  json.RawMessage `json:"inputSchema"`
+	OutputSchema json.RawMessage `json:"outputSchema,omitempty"`
+	Annotations  map[string]any  `json:"annotations,omitempty"`
+}
+
+func newToolDefinition(tool *core.ToolboxTool) (toolDefinition, error) {
+	inputSchema, err := tool.InputSchema()
+	if err != nil {
+		return toolDefinition{}, fmt.Errorf("error generating the input schema of tool '%s': %w", tool.Name(), err)
+	}
+	outputSchema, err := tool.OutputSchema()
+	if err != nil {
+		return toolDefinition{}, fmt.Errorf("error generating the output schema of tool '%s': %w", tool.Name(), err)
+	}
+	return toolDefinition{
+		Name:         tool.Name(),
+		Title:        tool.Title(),
+		Description:  tool.Description(),
+		InputSchema:  inputSchema,
+		OutputSchema: outputSchema,
+		Annotations:  tool.Annotations(),
+	}, nil
+}
+
+// message is an incoming JSON-RPC message. Requests have an ID and a method,
+// notifications only a method, and responses only an ID.
+type message struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method,omitempty"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+// isRequest reports whether msg expects a response.
+func (msg *message) isRequest() bool {
+	return len(msg.ID) > 0 && msg.Method != ""
+}
+
+// response is an outgoing JSON-RPC response.
+type response struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  any             `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func errorResponse(id json.RawMessage, code int, format string, args ...any) *response {
+	if len(id) == 0 {
+		id = json.RawMessage("null")
+	}
+	return &response{JSONRPC: "2.0", ID: id, Error: &rpcError{Code: code, Message: fmt.Sprintf(format, args...)}}
+}
+
+// decodeMessage decodes a single JSON-RPC message. It returns the error
+// response to send if data is not a valid message.
+func decodeMessage(data []byte) (*message, *response) {
+	var msg message
+	if err := json.Unmarshal(data, &msg); err != nil {
+		return nil, errorResponse(nil, codeParseError, "parse error: %v", err)
+	}
+	if msg.JSONRPC != "2.0" || (msg.Method == "" && len(msg.ID) == 0) {
+		return nil, errorResponse(msg.ID, codeInvalidRequest, "invalid request")
+	}
+	return &msg, nil
+}
+
+// handle runs a request and returns its response.
+func (s *Server) handle(ctx context.Context, msg *message) *response {
+	var result any
+	var rpcErr *response
+	switch msg.Method {
+	case "initialize":
+		result, rpcErr = s.initialize(msg)
+	case "ping":
+		result = struct{}{}
+	case "tools/list":
+		result = map[string]any{"tools": s.defs}
+	case "tools/call":
+		result, rpcErr = s.callTool(ctx, msg)
+	default:
+		return errorResponse(msg.ID, codeMethodNotFound, "method not found: %s", msg.Method)
+	}
+	if rpcErr != nil {
+		return rpcErr
+	}
+	return &response{JSONRPC: "2.0", ID: msg.ID, Result: result}
+}
+
+func (s *Server) initialize(msg *message) (any, *response) {
+	var params struct {
+		ProtocolVersion string `json:"protocolVersion"`
+	}
+	if len(msg.Params) > 0 {
+		if err := json.Unmarshal(msg.Params, &params); err != nil {
+			return nil, errorResponse(msg.ID, codeInvalidParams, "invalid initialize params: %v", err)
+		}
+	}
+	// Agree to the requested version if it is supported, and otherwise
+	// offer the latest one and let the client decide.
+	version := params.ProtocolVersion
+	if !slices.Contains(supportedProtocolVersions, version) {
+		version = latestProtocolVersion
+	}
+
+	result := map[string]any{
+		"protocolVersion": version,
+		"capabilities":    map[string]any{"tools": map[string]any{"listChanged": false}},
+		"serverInfo":      map[string]any{"name": s.cfg.name, "version": s.cfg.version},
+	}
+	if s.cfg.instructions != "" {
+		result["instructions"] = s.cfg.instructions
+	}
+	return result, nil
+}
+
+// callToolResult is the result of tools/call.
+type callToolResult struct {
+	Content           []core.ContentBlock `json:"content"`
+	StructuredContent map[string]any      `json:"structuredContent,omitempty"`
+	IsError           bool                `json:"isError,omitempty"`
+}
+
+func (s *Server) callTool(ctx context.Context, msg *message) (any, *response) {
+	var params struct {
+		Name      string          `json:"name"`
+		Arguments json.RawMessage `json:"arguments"`
+	}
+	if err := json.Unmarshal(msg.Params, &params); err != nil {
+		return nil, errorResponse(msg.ID, codeInvalidParams, "invalid tools/call params: %v", err)
+	}
+	tool, ok := s.tools[params.Name]
+	if !ok {
+		return nil, errorResponse(msg.ID, codeInvalidParams, "unknown tool '%s'", params.Name)
+	}
+
+	// Failed invocations are tool errors rather than protocol errors, so
+	// that the model sees them and can correct itself.
+	output, err := tool.InvokeRaw(ctx, params.Arguments)
+	if err != nil {
+		return callToolResult{
+			Content: []core.ContentBlock{{Type: "text", Text: err.Error()}},
+			IsError: true,
+		}, nil
+	}
+	return toCallToolResult(tool, output), nil
+}
+
+// toCallToolResult converts the result of a tool invocation into MCP
+// content. Structured results are also returned as structured content.
+func toCallToolResult(tool *core.ToolboxTool, output any) callToolResult {
+	switch v := output.(type) {
+	case []core.ContentBlock:
+		return callToolResult{Content: v}
+	case string:
+		result := callToolResult{Content: []core.ContentBlock{{Type: "text", Text: v}}}
+		if schema, _ := tool.OutputSchema(); schema != nil {
+			// Tools advertising an output schema must return a JSON
+			// object, which clients validate against the schema.
+			var structured map[string]any
+			if err := json.Unmarshal([]byte(v), &structured); err != nil || structured == nil {
+				return callToolResult{
+					Content: []core.ContentBlock{{Type: "text", Text: fmt.Sprintf("tool %s declares an output schema but did not return a JSON object: %s", tool.Name(), v)}},
+					IsError: true,
+				}
+			}
+			result.StructuredContent = structured
+		}
+		return result
+	case map[string]any:
+		result := callToolResult{StructuredContent: v}
+		encoded, err := json.Marshal(v)
+		if err != nil {
+			return callToolResult{
+				Content: []core.ContentBlock{{Type: "text", Text: fmt.Sprintf("error encoding the result of tool %s: %v", tool.Name(), err)}},
+				IsError: true,
+			}
+		}
+		result.Content = []core.ContentBlock{{Type: "text", Text: string(encoded)}}
+		return result
+	default:
+		text := fmt.Sprintf("%v", v)
+		if encoded, err := json.Marshal(v); err == nil {
+			text = string(encoded)
+		}
+		return callToolResult{Content: []core.ContentBlock{{Type: "text", Text: text}}}
+	}
+}
@@ -0,0 +1,250 @@
+//go:build unit
+
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tbmcpserver
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/googleapis/mcp-toolbox-sdk-go/core"
+	"github.com/googleapis/mcp-toolbox-sdk-go/core/toolboxtest"
+	"github.com/googleapis/mcp-toolbox-sdk-go/core/transport"
+)
+
+// newTestTransport serves an echo tool, a tool that always fails and a tool
+// with an output schema.
+func newTestTransport() *toolboxtest.Transport {
+	return &toolboxtest.Transport{
+		Tools: map[string]transport.ToolSchema{
+			"echo": {
+				Description: "Echo the text",
+				Parameters:  []core.ParameterSchema{{Name: "text", Type: "string", Required: true}},
+				Annotations: map[string]any{"readOnlyHint": true},
+			},
+			"fail":  {},
+			"stats": {OutputSchema: map[string]any{"type": "object"}},
+		},
+		Invoke: func(_ context.Context, name string, payload map[string]any) (any, error) {
+			switch name {
+			case "fail":
+				return nil, errors.New("boom")
+			case "stats":
+				return `{"rows":2}`, nil
+			}
+			return payload["text"], nil
+		},
+	}
+}
+
+func newTestServer(t *testing.T, tr *toolboxtest.Transport, opts ...Option) *Server {
+	t.Helper()
+	server, err := NewServer([]*core.ToolboxTool{toolboxtest.LoadTool(t, tr, "echo"), toolboxtest.LoadTool(t, tr, "fail"), toolboxtest.LoadTool(t, tr, "stats")}, opts...)
+	if err != nil {
+		t.Fatalf("NewServer failed: %v", err)
+	}
+	return server
+}
+
+// call sends a request to server and returns its encoded response.
+func call(t *testing.T, server *Server, method string, params any) map[string]any {
+	t.Helper()
+	rawParams, err := json.Marshal(params)
+	if err != nil {
+		t.Fatalf("Failed to encode params: %v", err)
+	}
+	resp := server.handle(context.Background(), &message{JSONRPC: "2.0", ID: json.RawMessage("1"), Method: method, Params: rawParams})
+	encoded, err := json.Marshal(resp)
+	if err != nil {
+		t.Fatalf("Failed to encode the response: %v", err)
+	}
+	var decoded map[string]any
+	if err := json.Unmarshal(encoded, &decoded); err != nil {
+		t.Fatalf("Failed to decode the response: %v", err)
+	}
+	return decoded
+}
+
+func TestNewServer(t *testing.T) {
+	tr := newTestTransport()
+	echo := toolboxtest.LoadTool(t, tr, "echo")
+
+	if _, err := NewServer([]*core.ToolboxTool{echo, echo}); err == nil || err.Error() != "NewServer: duplicate tool name 'echo'" {
+		t.Errorf("Unexpected error for duplicate tools: %v", err)
+	}
+	if _, err := NewServer([]*core.ToolboxTool{echo, nil}); err == nil || err.Error() != "NewServer: tool 1 is nil" {
+		t.Errorf("Unexpected error for a nil tool: %v", err)
+	}
+	if _, err := NewServer(nil, nil); err == nil || err.Error() != "NewServer: received a nil Option" {
+		t.Errorf("Unexpected error for a nil option: %v", err)
+	}
+	if _, err := NewServer(nil, WithServerInfo("a", "1"), WithServerInfo("b", "2")); err == nil || !strings.Contains(err.Error(), "cannot be overridden") {
+		t.Errorf("Unexpected error for a duplicate option: %v", err)
+	}
+	if _, err := NewServer(nil, WithInstructions("")); err == nil || err.Error() != "WithInstructions: instructions cannot be empty" {
+		t.Errorf("Unexpected error for empty instructions: %v", err)
+	}
+}
+
+func TestInitialize(t *testing.T) {
+	t.Run("Defaults", func(t *testing.T) {
+		resp := call(t, newTestServer(t, newTestTransport()), "initialize", map[string]any{"protocolVersion": "2025-06-18"})
+		result, _ := resp["result"].(map[string]any)
+		if result["protocolVersion"] != "2025-06-18" {
+			t.Errorf("Expected the requested version to be accepted, got %v", result["protocolVersion"])
+		}
+		expectedInfo := map[string]any{"name": defaultServerName, "version": Version}
+		if !reflect.DeepEqual(result["serverInfo"], expectedInfo) {
+			t.Errorf("Expected server info %v, got %v", expectedInfo, result["serverInfo"])
+		}
+		if _, ok := result["instructions"]; ok {
+			t.Errorf("Expected no instructions, got %v", result["instructions"])
+		}
+	})
+
+	t.Run("Options", func(t *testing.T) {
+		server := newTestServer(t, newTestTransport(), WithServerInfo("bridge", "2.0.0"), WithInstructions("Use the tools."))
+		result, _ := call(t, server, "initialize", map[string]any{"protocolVersion": "1999-01-01"})["result"].(map[string]any)
+		if result["protocolVersion"] != latestProtocolVersion {
+			t.Errorf("Expected the latest version to be offered, got %v", result["protocolVersion"])
+		}
+		if !reflect.DeepEqual(result["serverInfo"], map[string]any{"name": "bridge", "version": "2.0.0"}) {
+			t.Errorf("Unexpected server info: %v", result["serverInfo"])
+		}
+		if result["instructions"] != "Use the tools." {
+			t.Errorf("Unexpected instructions: %v", result["instructions"])
+		}
+	})
+}
+
+func TestListTools(t *testing.T) {
+	resp := call(t, newTestServer(t, newTestTransport()), "tools/list", nil)
+	result, _ := resp["result"].(map[string]any)
+	tools, _ := result["tools"].([]any)
+	if len(tools) != 3 {
+		t.Fatalf("Expected 3 tools, got %v", resp)
+	}
+
+	echo := tools[0].(map[string]any)
+	if echo["name"] != "echo" || echo["description"] != "Echo the text" {
+		t.Errorf("Unexpected name or description: %v", echo)
+	}
+	expectedInput := map[string]any{
+		"type":       "object",
+		"properties": map[string]any{"text": map[string]any{"type": "string"}},
+		"required":   []any{"text"},
+	}
+	if !reflect.DeepEqual(echo["inputSchema"], expectedInput) {
+		t.Errorf("Expected input schema %v, got %v", expectedInput, echo["inputSchema"])
+	}
+	if !reflect.DeepEqual(echo["annotations"], map[string]any{"readOnlyHint": true}) {
+		t.Errorf("Expected the annotations to be kept, got %v", echo["annotations"])
+	}
+	if _, ok := echo["outputSchema"]; ok {
+		t.Errorf("Expected no output schema, got %v", echo["outputSchema"])
+	}
+	if stats := tools[2].(map[string]any); !reflect.DeepEqual(stats["outputSchema"], map[string]any{"type": "object"}) {
+		t.Errorf("Expected the output schema to be kept, got %v", stats["outputSchema"])
+	}
+}
+
+func TestCallTool(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		resp := call(t, newTestServer(t, newTestTransport()), "tools/call", map[string]any{"name": "echo", "arguments": map[string]any{"text": "hi"}})
+		expected := map[string]any{"content": []any{map[string]any{"type": "text", "text": "hi"}}}
+		if !reflect.DeepEqual(resp["result"], expected) {
+			t.Errorf("Expected %v, got %v", expected, resp)
+		}
+	})
+
+	t.Run("StructuredContent", func(t *testing.T) {
+		resp := call(t, newTestServer(t, newTestTransport()), "tools/call", map[string]any{"name": "stats"})
+		result, _ := resp["result"].(map[string]any)
+		if !reflect.DeepEqual(result["structuredContent"], map[string]any{"rows": 2.0}) {
+			t.Errorf("Expected structured content, got %v", resp)
+		}
+	})
+
+	t.Run("StructuredContentNotAnObject", func(t *testing.T) {
+		tr := newTestTransport()
+		tr.Invoke = func(context.Context, string, map[string]any) (any, error) { return "2 rows", nil }
+		resp := call(t, newTestServer(t, tr), "tools/call", map[string]any{"name": "stats"})
+		result, _ := resp["result"].(map[string]any)
+		if result["isError"] != true || result["structuredContent"] != nil || !strings.Contains(jsonString(t, result["content"]), "did not return a JSON object") {
+			t.Errorf("Expected a tool error, got %v", resp)
+		}
+	})
+
+	t.Run("ContentBlocks", func(t *testing.T) {
+		tr := newTestTransport()
+		blocks := []core.ContentBlock{{Type: "image", Data: "aGk=", MimeType: "image/png"}}
+		tr.Invoke = func(context.Context, string, map[string]any) (any, error) { return blocks, nil }
+		resp := call(t, newTestServer(t, tr), "tools/call", map[string]any{"name": "echo", "arguments": map[string]any{"text": "hi"}})
+		expected := map[string]any{"content": []any{map[string]any{"type": "image", "data": "aGk=", "mimeType": "image/png"}}}
+		if !reflect.DeepEqual(resp["result"], expected) {
+			t.Errorf("Expected %v, got %v", expected, resp)
+		}
+	})
+
+	t.Run("ToolError", func(t *testing.T) {
+		resp := call(t, newTestServer(t, newTestTransport()), "tools/call", map[string]any{"name": "fail"})
+		result, _ := resp["result"].(map[string]any)
+		if result["isError"] != true || !strings.Contains(jsonString(t, result["content"]), "boom") {
+			t.Errorf("Expected the failure to be reported as a tool error, got %v", resp)
+		}
+	})
+
+	t.Run("InvalidArguments", func(t *testing.T) {
+		tr := newTestTransport()
+		resp := call(t, newTestServer(t, tr), "tools/call", map[string]any{"name": "echo", "arguments": map[string]any{"text": 1}})
+		result, _ := resp["result"].(map[string]any)
+		if result["isError"] != true {
+			t.Errorf("Expected a tool error for invalid arguments, got %v", resp)
+		}
+		if len(tr.Invoked()) != 0 {
+			t.Errorf("Expected no invocation, got %v", tr.Invoked())
+		}
+	})
+
+	t.Run("UnknownTool", func(t *testing.T) {
+		resp := call(t, newTestServer(t, newTestTransport()), "tools/call", map[string]any{"name": "missing"})
+		rpcErr, _ := resp["error"].(map[string]any)
+		if rpcErr["code"] != float64(codeInvalidParams) || rpcErr["message"] != "unknown tool 'missing'" {
+			t.Errorf("Expected an invalid params error, got %v", resp)
+		}
+	})
+}
+
+func TestUnknownMethod(t *testing.T) {
+	resp := call(t, newTestServer(t, newTestTransport()), "resources/list", nil)
+	rpcErr, _ := resp["error"].(map[string]any)
+	if rpcErr["code"] != float64(codeMethodNotFound) {
+		t.Errorf("Expected a method not found error, got %v", resp)
+	}
+}
+
+func jsonString(t *testing.T, v any) string {
+	t.Helper()
+	encoded, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("Failed to encode %v: %v", v, err)
+	}
+	return string(encoded)
+}
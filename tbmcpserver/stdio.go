@@ -0,0 +1,191 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tbmcpserver
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"sync"
+)
+
+// ServeStdio serves the tools over the stdio transport on the standard input
+// and output of the process, as MCP clients expect of servers they launch. It
+// returns when the standard input is closed or ctx is done. Nothing else may
+// write to the standard output while it runs.
+func (s *Server) ServeStdio(ctx context.Context) error {
+	return s.Serve(ctx, os.Stdin, os.Stdout)
+}
+
+// Serve serves the tools over the stdio transport, reading newline-delimited
+// JSON-RPC messages from r and writing the responses to w. Requests are
+// handled concurrently, and can be cancelled by the client with a
+// notifications/cancelled notification.
+//
+// Serve returns nil when r reaches EOF, after the pending requests are
+// answered, ctx.Err() when ctx is done, and any error reading r or writing w
+// otherwise.
+func (s *Server) Serve(ctx context.Context, r io.Reader, w io.Writer) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	sess := &stdioSession{
+		enc:      json.NewEncoder(w),
+		inflight: make(map[string]context.CancelFunc),
+		cancel:   cancel,
+	}
+
+	lines := make(chan []byte)
+	readErr := make(chan error, 1)
+	go func() {
+		reader := bufio.NewReader(r)
+		for {
+			line, err := reader.ReadBytes('\n')
+			if len(bytes.TrimSpace(line)) > 0 {
+				select {
+				case lines <- line:
+				case <-ctx.Done():
+					return
+				}
+			}
+			if err != nil {
+				if errors.Is(err, io.EOF) {
+					err = nil
+				}
+				readErr <- err
+				return
+			}
+		}
+	}()
+
+	var wg sync.WaitGroup
+	for {
+		select {
+		case <-ctx.Done():
+			wg.Wait()
+			if err := sess.writeError(); err != nil {
+				return err
+			}
+			return ctx.Err()
+		case err := <-readErr:
+			wg.Wait()
+			if writeErr := sess.writeError(); writeErr != nil {
+				return writeErr
+			}
+			return err
+		case line := <-lines:
+			msg, errResp := decodeMessage(line)
+			if errResp != nil {
+				sess.write(errResp)
+				continue
+			}
+			if msg.Method == "notifications/cancelled" {
+				sess.cancelRequest(msg.Params)
+				continue
+			}
+			if !msg.isRequest() {
+				// Other notifications and responses need no answer.
+				continue
+			}
+
+			reqCtx := sess.track(ctx, msg.ID)
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				resp := s.handle(reqCtx, msg)
+				// Cancelled requests must not be answered.
+				if sess.untrack(msg.ID) {
+					sess.write(resp)
+				}
+			}()
+		}
+	}
+}
+
+// stdioSession holds the state of a single Serve call.
+type stdioSession struct {
+	cancel context.CancelFunc
+
+	writeMu  sync.Mutex
+	enc      *json.Encoder
+	writeErr error
+
+	mu       sync.Mutex
+	inflight map[string]context.CancelFunc
+}
+
+// write sends resp to the client. The first write error stops the session.
+func (sess *stdioSession) write(resp *response) {
+	sess.writeMu.Lock()
+	defer sess.writeMu.Unlock()
+	if sess.writeErr != nil {
+		return
+	}
+	// Encode terminates the message with the newline delimiting it.
+	if err := sess.enc.Encode(resp); err != nil {
+		sess.writeErr = err
+		sess.cancel()
+	}
+}
+
+func (sess *stdioSession) writeError() error {
+	sess.writeMu.Lock()
+	defer sess.writeMu.Unlock()
+	return sess.writeErr
+}
+
+// track registers the request with the given ID as in flight, and returns
+// the context it runs in.
+func (sess *stdioSession) track(ctx context.Context, id json.RawMessage) context.Context {
+	ctx, cancel := context.WithCancel(ctx)
+	sess.mu.Lock()
+	defer sess.mu.Unlock()
+	sess.inflight[string(id)] = cancel
+	return ctx
+}
+
+// untrack removes the request with the given ID, and reports whether it was
+// still in flight rather than cancelled.
+func (sess *stdioSession) untrack(id json.RawMessage) bool {
+	sess.mu.Lock()
+	defer sess.mu.Unlock()
+	cancel, ok := sess.inflight[string(id)]
+	if ok {
+		cancel()
+		delete(sess.inflight, string(id))
+	}
+	return ok
+}
+
+// cancelRequest cancels the request named by the params of a
+// notifications/cancelled notification.
+func (sess *stdioSession) cancelRequest(params json.RawMessage) {
+	var p struct {
+		RequestID json.RawMessage `json:"requestId"`
+	}
+	if err := json.Unmarshal(params, &p); err != nil || len(p.RequestID) == 0 {
+		return
+	}
+	sess.mu.Lock()
+	defer sess.mu.Unlock()
+	if cancel, ok := sess.inflight[string(p.RequestID)]; ok {
+		cancel()
+		delete(sess.inflight, string(p.RequestID))
+	}
+}
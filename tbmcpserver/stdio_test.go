@@ -0,0 +1,131 @@
+//go:build unit
+
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tbmcpserver
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestServe(t *testing.T) {
+	input := strings.Join([]string{
+		`{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2025-06-18"}}`,
+		`{"jsonrpc":"2.0","method":"notifications/initialized"}`,
+		`not json`,
+		`{"jsonrpc":"2.0","id":2,"method":"tools/call","params":{"name":"echo","arguments":{"text":"hi"}}}`,
+	}, "\n") + "\n"
+
+	var out strings.Builder
+	if err := newTestServer(t, newTestTransport()).Serve(context.Background(), strings.NewReader(input), &out); err != nil {
+		t.Fatalf("Serve returned an unexpected error: %v", err)
+	}
+
+	responses := make(map[string]map[string]any)
+	for _, line := range strings.Split(strings.TrimSpace(out.String()), "\n") {
+		var resp map[string]any
+		if err := json.Unmarshal([]byte(line), &resp); err != nil {
+			t.Fatalf("Failed to decode response %q: %v", line, err)
+		}
+		responses[jsonString(t, resp["id"])] = resp
+	}
+	if len(responses) != 3 {
+		t.Fatalf("Expected responses to the two requests and the parse error, got %s", out.String())
+	}
+	if _, ok := responses["1"]["result"]; !ok {
+		t.Errorf("Expected initialize to succeed, got %v", responses["1"])
+	}
+	if !strings.Contains(jsonString(t, responses["2"]["result"]), `"text":"hi"`) {
+		t.Errorf("Expected the tool result, got %v", responses["2"])
+	}
+	if rpcErr, _ := responses["null"]["error"].(map[string]any); rpcErr["code"] != float64(codeParseError) {
+		t.Errorf("Expected a parse error, got %v", responses["null"])
+	}
+}
+
+func TestServe_Cancellation(t *testing.T) {
+	tr := newTestTransport()
+	started := make(chan struct{})
+	tr.Invoke = func(ctx context.Context, _ string, _ map[string]any) (any, error) {
+		close(started)
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	server := newTestServer(t, tr)
+
+	inR, inW := io.Pipe()
+	outR, outW := io.Pipe()
+	done := make(chan error, 1)
+	go func() { done <- server.Serve(context.Background(), inR, outW) }()
+
+	send := func(line string) {
+		t.Helper()
+		if _, err := io.WriteString(inW, line+"\n"); err != nil {
+			t.Fatalf("Failed to send %q: %v", line, err)
+		}
+	}
+	send(`{"jsonrpc":"2.0","id":"slow","method":"tools/call","params":{"name":"echo","arguments":{"text":"hi"}}}`)
+	<-started
+	send(`{"jsonrpc":"2.0","method":"notifications/cancelled","params":{"requestId":"slow"}}`)
+	send(`{"jsonrpc":"2.0","id":"ping","method":"ping"}`)
+
+	reader := bufio.NewReader(outR)
+	line, err := reader.ReadString('\n')
+	if err != nil {
+		t.Fatalf("Failed to read a response: %v", err)
+	}
+	if !strings.Contains(line, `"id":"ping"`) {
+		t.Errorf("Expected only the ping to be answered, got %s", line)
+	}
+
+	inW.Close()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("Serve returned an unexpected error: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Serve did not return after its input was closed")
+	}
+	outW.Close()
+	if rest, _ := io.ReadAll(reader); len(rest) != 0 {
+		t.Errorf("Expected the cancelled request not to be answered, got %s", rest)
+	}
+}
+
+func TestServe_ContextDone(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	inR, inW := io.Pipe()
+	defer inW.Close()
+
+	done := make(chan error, 1)
+	go func() { done <- newTestServer(t, newTestTransport()).Serve(ctx, inR, io.Discard) }()
+	cancel()
+
+	select {
+	case err := <-done:
+		if err != context.Canceled {
+			t.Errorf("Expected context.Canceled, got %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Serve did not return after its context was cancelled")
+	}
+}
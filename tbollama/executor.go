@@ -0,0 +1,138 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tbollama
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/googleapis/mcp-toolbox-sdk-go/core"
+)
+
+// Message is a message of an Ollama chat conversation. Only the fields used
+// for tool calling are declared.
+type Message struct {
+	Role       string     `json:"role"`
+	Content    string     `json:"content"`
+	ToolCalls  []ToolCall `json:"tool_calls,omitempty"`
+	ToolName   string     `json:"tool_name,omitempty"`
+	ToolCallID string     `json:"tool_call_id,omitempty"`
+}
+
+// ToolCall is an entry of the "tool_calls" field of an assistant message.
+type ToolCall struct {
+	ID       string           `json:"id,omitempty"`
+	Function ToolCallFunction `json:"function"`
+}
+
+// ToolCallFunction is the function called by a ToolCall. Unlike the OpenAI
+// format, Ollama sends the arguments as a JSON object rather than a string.
+type ToolCallFunction struct {
+	Index     int            `json:"index,omitempty"`
+	Name      string         `json:"name"`
+	Arguments map[string]any `json:"arguments"`
+}
+
+// Executor runs the tool calls that an Ollama model makes against the Toolbox
+// tools they name:
+//
+//	executor, err := tbollama.NewExecutor(tools...)
+//	...
+//	// resp is the decoded response of a POST to /api/chat.
+//	messages = append(messages, resp.Message)
+//	results, err := executor.ExecuteAll(ctx, resp.Message.ToolCalls)
+//	...
+//	messages = append(messages, results...)
+//
+// An Executor is safe for concurrent use.
+type Executor struct {
+	tools map[string]*core.ToolboxTool
+}
+
+// NewExecutor creates an Executor for tools, which must have distinct names.
+func NewExecutor(tools ...*core.ToolboxTool) (*Executor, error) {
+	byName, err := core.Toolset(tools).ByName()
+	if err != nil {
+		return nil, fmt.Errorf("NewExecutor: %w", err)
+	}
+	return &Executor{tools: byName}, nil
+}
+
+// Execute invokes the tool named by call and returns the "tool" message to
+// send back to the model. Ollama messages only carry text, so structured
+// results are encoded as JSON. Unknown tools and failed invocations are
+// reported to the model in the content of the message, so that it can correct
+// itself; the error is also returned.
+//
+// Inputs:
+//   - ctx: The context of the tool invocation.
+//   - call: The tool call made by the model.
+//   - opts: Options applied to the invocation, such as
+//     core.WithInvokeAuthTokenSource.
+func (e *Executor) Execute(ctx context.Context, call ToolCall, opts ...core.InvokeOption) (Message, error) {
+	msg := Message{Role: "tool", ToolName: call.Function.Name, ToolCallID: call.ID}
+
+	tool, ok := e.tools[call.Function.Name]
+	if !ok {
+		err := fmt.Errorf("unknown tool '%s'", call.Function.Name)
+		msg.Content = "error: " + err.Error()
+		return msg, err
+	}
+
+	args := call.Function.Arguments
+	if args == nil {
+		args = map[string]any{}
+	}
+	result, err := tool.Invoke(ctx, args, opts...)
+	if err != nil {
+		err = fmt.Errorf("error invoking the tool %s: %w", tool.Name(), err)
+		msg.Content = "error: " + err.Error()
+		return msg, err
+	}
+
+	switch r := result.(type) {
+	case string:
+		msg.Content = r
+	case map[string]any, []core.ContentBlock:
+		encoded, err := json.Marshal(r)
+		if err != nil {
+			err = fmt.Errorf("error encoding the result of tool %s: %w", tool.Name(), err)
+			msg.Content = "error: " + err.Error()
+			return msg, err
+		}
+		msg.Content = string(encoded)
+	default:
+		msg.Content = fmt.Sprintf("%v", r)
+	}
+	return msg, nil
+}
+
+// ExecuteAll executes calls in order and returns the tool messages holding
+// their results. Every call gets a message even if some fail; the returned
+// error joins the failures, if any.
+func (e *Executor) ExecuteAll(ctx context.Context, calls []ToolCall, opts ...core.InvokeOption) ([]Message, error) {
+	messages := make([]Message, 0, len(calls))
+	var errs []error
+	for _, call := range calls {
+		msg, err := e.Execute(ctx, call, opts...)
+		if err != nil {
+			errs = append(errs, err)
+		}
+		messages = append(messages, msg)
+	}
+	return messages, errors.Join(errs...)
+}
@@ -0,0 +1,133 @@
+//go:build unit
+
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tbollama
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/googleapis/mcp-toolbox-sdk-go/core"
+	"github.com/googleapis/mcp-toolbox-sdk-go/core/toolboxtest"
+	"github.com/googleapis/mcp-toolbox-sdk-go/core/transport"
+)
+
+// newForecastExecutor returns an Executor for a "get_forecast" tool, which
+// answers with result, and a "book_table" tool, which always fails.
+func newForecastExecutor(t *testing.T, result any) (*Executor, *toolboxtest.Transport) {
+	t.Helper()
+	tr := &toolboxtest.Transport{
+		Tools: map[string]transport.ToolSchema{
+			"get_forecast": {Parameters: []core.ParameterSchema{
+				{Name: "city", Type: "string", Required: true},
+				{Name: "days", Type: "integer"},
+			}},
+			"book_table": {Parameters: []core.ParameterSchema{{Name: "restaurant", Type: "string"}}},
+		},
+		Invoke: func(_ context.Context, name string, _ map[string]any) (any, error) {
+			if name == "book_table" {
+				return nil, errors.New("fully booked")
+			}
+			return result, nil
+		},
+	}
+	executor, err := NewExecutor(toolboxtest.LoadToolset(t, tr)...)
+	if err != nil {
+		t.Fatalf("NewExecutor failed: %v", err)
+	}
+	return executor, tr
+}
+
+func TestExecuteAll(t *testing.T) {
+	// The assistant message of a response of /api/chat, whose arguments are
+	// JSON objects rather than strings.
+	var assistant Message
+	raw := `{"role":"assistant","content":"","tool_calls":[
+		{"id":"call_1","function":{"index":0,"name":"get_forecast","arguments":{"city":"Paris","days":2}}},
+		{"id":"call_2","function":{"index":1,"name":"book_table","arguments":{"restaurant":"Le Train Bleu"}}},
+		{"id":"call_3","function":{"index":2,"name":"get_directions","arguments":{}}}
+	]}`
+	if err := json.Unmarshal([]byte(raw), &assistant); err != nil {
+		t.Fatalf("Failed to decode the assistant message: %v", err)
+	}
+
+	executor, tr := newForecastExecutor(t, "Sunny, 24°C")
+	messages, err := executor.ExecuteAll(context.Background(), assistant.ToolCalls)
+	if err == nil {
+		t.Fatal("Expected the failed calls to be reported")
+	}
+	encoded, err := json.Marshal(messages)
+	if err != nil {
+		t.Fatalf("Failed to encode the messages: %v", err)
+	}
+	expected := `[
+		{"role":"tool","content":"Sunny, 24°C","tool_name":"get_forecast","tool_call_id":"call_1"},
+		{"role":"tool","content":"error: error invoking the tool book_table: fully booked","tool_name":"book_table","tool_call_id":"call_2"},
+		{"role":"tool","content":"error: unknown tool 'get_directions'","tool_name":"get_directions","tool_call_id":"call_3"}
+	]`
+	var got, want any
+	_ = json.Unmarshal(encoded, &got)
+	_ = json.Unmarshal([]byte(expected), &want)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Unexpected tool messages: %s", encoded)
+	}
+	if !reflect.DeepEqual(tr.Invoked(), []string{"get_forecast", "book_table"}) {
+		t.Errorf("Expected the known tools to be invoked in order, got %v", tr.Invoked())
+	}
+}
+
+func TestExecute_Content(t *testing.T) {
+	// Ollama messages only carry text, so every result is turned into a
+	// string.
+	testCases := []struct {
+		name   string
+		result any
+		want   string
+	}{
+		{"Text", "Sunny", "Sunny"},
+		{"StructuredResult", map[string]any{"high": 24.0, "sky": "clear"}, `{"high":24,"sky":"clear"}`},
+		{"ContentBlocks", []core.ContentBlock{{Type: "text", Text: "Sunny"}}, `[{"type":"text","text":"Sunny"}]`},
+		{"Number", 24.5, "24.5"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			executor, _ := newForecastExecutor(t, tc.result)
+			call := ToolCall{Function: ToolCallFunction{Name: "get_forecast", Arguments: map[string]any{"city": "Paris"}}}
+			msg, err := executor.Execute(context.Background(), call)
+			if err != nil {
+				t.Fatalf("Execute failed: %v", err)
+			}
+			if msg.Content != tc.want {
+				t.Errorf("Expected content %q, got %q", tc.want, msg.Content)
+			}
+		})
+	}
+}
+
+func TestNewExecutor(t *testing.T) {
+	tr := &toolboxtest.Transport{Tools: map[string]transport.ToolSchema{"get_forecast": {}}}
+	forecast := toolboxtest.LoadTool(t, tr, "get_forecast")
+
+	if _, err := NewExecutor(forecast, forecast); err == nil || err.Error() != "NewExecutor: duplicate tool name 'get_forecast'" {
+		t.Errorf("Unexpected error for duplicate tools: %v", err)
+	}
+	if _, err := NewExecutor(forecast, nil); err == nil || err.Error() != "NewExecutor: tool 1 is nil" {
+		t.Errorf("Unexpected error for a nil tool: %v", err)
+	}
+}
@@ -0,0 +1,90 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package tbollama converts Toolbox tools into the tool definitions of the
+// Ollama chat API, and executes the tool calls that local models make back
+// against the tools. It only depends on the core SDK, so it can be used with
+// any Ollama client that speaks the chat API's JSON.
+package tbollama
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/googleapis/mcp-toolbox-sdk-go/core"
+)
+
+// Tool is an entry of the "tools" field of an Ollama chat request.
+type Tool struct {
+	Type     string       `json:"type"`
+	Function ToolFunction `json:"function"`
+}
+
+// ToolFunction describes a function that the model may call.
+type ToolFunction struct {
+	Name        string          `json:"name"`
+	Description string          `json:"description"`
+	Parameters  json.RawMessage `json:"parameters"`
+}
+
+// ToTool converts a ToolboxTool into an Ollama tool definition, whose
+// parameters are the JSON Schema of the tool's unbound parameters.
+//
+// Inputs:
+//   - tool: The core.ToolboxTool to be converted.
+//
+// Returns:
+//
+//	The Tool definition, or an error if the input schema of the tool cannot
+//	be generated.
+func ToTool(tool *core.ToolboxTool) (Tool, error) {
+	if tool == nil {
+		return Tool{}, fmt.Errorf("error: ToTool received a nil core.ToolboxTool pointer")
+	}
+	schema, err := tool.InputSchema()
+	if err != nil {
+		return Tool{}, fmt.Errorf("error generating the input schema of tool '%s': %w", tool.Name(), err)
+	}
+	return Tool{
+		Type: "function",
+		Function: ToolFunction{
+			Name:        tool.Name(),
+			Description: tool.Description(),
+			Parameters:  schema,
+		},
+	}, nil
+}
+
+// ToTools converts a whole toolset, such as the result of
+// core.ToolboxClient.LoadToolset, into the "tools" field of an Ollama chat
+// request.
+//
+// Inputs:
+//   - tools: The core.ToolboxTool pointers to be converted.
+//
+// Returns:
+//
+//	The Tool definitions, in order, or an error naming the first tool that
+//	could not be converted.
+func ToTools(tools []*core.ToolboxTool) ([]Tool, error) {
+	converted := make([]Tool, 0, len(tools))
+	for i, tool := range tools {
+		t, err := ToTool(tool)
+		if err != nil {
+			return nil, fmt.Errorf("tool %d: %w", i, err)
+		}
+		converted = append(converted, t)
+	}
+	return converted, nil
+}
@@ -0,0 +1,101 @@
+//go:build unit
+
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tbollama
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/googleapis/mcp-toolbox-sdk-go/core"
+	"github.com/googleapis/mcp-toolbox-sdk-go/core/toolboxtest"
+	"github.com/googleapis/mcp-toolbox-sdk-go/core/transport"
+)
+
+func TestToTool(t *testing.T) {
+	tr := &toolboxtest.Transport{Tools: map[string]transport.ToolSchema{
+		"search": {
+			Description: "Search the catalog",
+			Parameters: []core.ParameterSchema{
+				{Name: "query", Type: "string", Description: "The search query", Required: true},
+				{Name: "min_price", Type: "float"},
+				{Name: "tags", Type: "array", Items: &core.ParameterSchema{Type: "string"}},
+			},
+		},
+	}}
+
+	tool, err := ToTool(toolboxtest.LoadTool(t, tr, "search"))
+	if err != nil {
+		t.Fatalf("ToTool failed: %v", err)
+	}
+	encoded, err := json.Marshal(tool)
+	if err != nil {
+		t.Fatalf("Failed to encode the tool: %v", err)
+	}
+
+	var got map[string]any
+	if err := json.Unmarshal(encoded, &got); err != nil {
+		t.Fatalf("Failed to decode the tool: %v", err)
+	}
+	expected := map[string]any{
+		"type": "function",
+		"function": map[string]any{
+			"name":        "search",
+			"description": "Search the catalog",
+			"parameters": map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"query":     map[string]any{"type": "string", "description": "The search query"},
+					"min_price": map[string]any{"type": "number"},
+					"tags":      map[string]any{"type": "array", "items": map[string]any{"type": "string"}},
+				},
+				"required": []any{"query"},
+			},
+		},
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected %v, got %v", expected, got)
+	}
+}
+
+func TestToTool_NilTool(t *testing.T) {
+	_, err := ToTool(nil)
+	if err == nil || err.Error() != "error: ToTool received a nil core.ToolboxTool pointer" {
+		t.Errorf("Unexpected error: %v", err)
+	}
+}
+
+func TestToTools(t *testing.T) {
+	tr := &toolboxtest.Transport{Tools: map[string]transport.ToolSchema{
+		"a": {Description: "A"},
+		"b": {Description: "B"},
+	}}
+
+	tools, err := ToTools([]*core.ToolboxTool{toolboxtest.LoadTool(t, tr, "a"), toolboxtest.LoadTool(t, tr, "b")})
+	if err != nil {
+		t.Fatalf("ToTools failed: %v", err)
+	}
+	if len(tools) != 2 || tools[0].Function.Name != "a" || tools[1].Function.Name != "b" {
+		t.Errorf("Expected tools a and b in order, got %+v", tools)
+	}
+
+	_, err = ToTools([]*core.ToolboxTool{toolboxtest.LoadTool(t, tr, "a"), nil})
+	if err == nil || !strings.HasPrefix(err.Error(), "tool 1: ") {
+		t.Errorf("Expected the failing tool to be named, got %v", err)
+	}
+}
@@ -0,0 +1,85 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tbprometheus
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Option configures a Collector.
+type Option func(*config) error
+
+// config holds the settings of a Collector.
+type config struct {
+	namespace   string
+	constLabels prometheus.Labels
+	buckets     []float64
+}
+
+// newConfig applies opts to the default settings of a Collector.
+func newConfig(opts []Option) (*config, error) {
+	cfg := &config{namespace: "toolbox", buckets: prometheus.DefBuckets}
+	for _, opt := range opts {
+		if opt == nil {
+			return nil, fmt.Errorf("NewCollector: received a nil Option")
+		}
+		if err := opt(cfg); err != nil {
+			return nil, err
+		}
+	}
+	return cfg, nil
+}
+
+// WithNamespace sets the prefix of the metric names, which defaults to
+// "toolbox". An empty namespace removes the prefix.
+func WithNamespace(namespace string) Option {
+	return func(c *config) error {
+		c.namespace = namespace
+		return nil
+	}
+}
+
+// WithConstLabels adds labels with fixed values to all metrics, such as the
+// name of the Toolbox server when observing several clients.
+func WithConstLabels(labels prometheus.Labels) Option {
+	return func(c *config) error {
+		if len(labels) == 0 {
+			return fmt.Errorf("WithConstLabels: labels cannot be empty")
+		}
+		if c.constLabels != nil {
+			return fmt.Errorf("constant labels are already set and cannot be overridden")
+		}
+		c.constLabels = labels
+		return nil
+	}
+}
+
+// WithDurationBuckets sets the upper bounds, in seconds, of the buckets of the
+// duration histograms, which default to prometheus.DefBuckets.
+func WithDurationBuckets(buckets ...float64) Option {
+	return func(c *config) error {
+		if len(buckets) == 0 {
+			return fmt.Errorf("WithDurationBuckets: buckets cannot be empty")
+		}
+		if !slices.IsSorted(buckets) {
+			return fmt.Errorf("WithDurationBuckets: buckets must be in increasing order")
+		}
+		c.buckets = slices.Clone(buckets)
+		return nil
+	}
+}
@@ -0,0 +1,180 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package tbprometheus exposes Prometheus metrics about the requests a
+// Toolbox client sends: tool loads, tool invocations, retries and errors,
+// labeled by tool and status. It is an alternative to OpenTelemetry for
+// applications that already export Prometheus metrics.
+package tbprometheus
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/googleapis/mcp-toolbox-sdk-go/core"
+	"github.com/googleapis/mcp-toolbox-sdk-go/core/transport"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Collector is a prometheus.Collector fed by the requests of the Toolbox
+// clients it observes. Register it with a prometheus.Registerer and pass it
+// to core.WithTransportObserver. One Collector may observe several clients.
+//
+// It exports the following metrics, prefixed with the namespace, which
+// defaults to "toolbox":
+//
+//   - loads_total{toolset, tool, status}: tools/list requests made to load a
+//     tool, with its name, or a toolset, with its name.
+//   - load_duration_seconds{toolset, tool}: their duration.
+//   - invocations_total{tool, status}: tools/call requests.
+//   - invocation_duration_seconds{tool}: their duration.
+//   - retries_total{tool, status}: retried HTTP requests, with the status
+//     of the attempt that failed. Retries are enabled with
+//     core.WithTransportRetry.
+//   - errors_total{method, tool, status}: failed requests of any method,
+//     including handshakes.
+//
+// The status label is "ok" for successful requests, the HTTP status code of
+// failed HTTP requests, "timeout", "canceled", "rpc_error" for JSON-RPC errors,
+// "tool_error" for errors reported by tools, or "error" otherwise.
+type Collector struct {
+	loads               *prometheus.CounterVec
+	loadDurations       *prometheus.HistogramVec
+	invocations         *prometheus.CounterVec
+	invocationDurations *prometheus.HistogramVec
+	retries             *prometheus.CounterVec
+	errors              *prometheus.CounterVec
+}
+
+var (
+	_ prometheus.Collector    = (*Collector)(nil)
+	_ transport.RetryObserver = (*Collector)(nil)
+)
+
+// NewCollector creates a Collector.
+//
+// Inputs:
+//   - opts: A variadic list of Option functions, such as WithNamespace.
+//
+// Returns:
+//
+//	The *Collector, or an error if an option is invalid.
+func NewCollector(opts ...Option) (*Collector, error) {
+	cfg, err := newConfig(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	counter := func(name, help string, labels ...string) *prometheus.CounterVec {
+		return prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace:   cfg.namespace,
+			Name:        name,
+			Help:        help,
+			ConstLabels: cfg.constLabels,
+		}, labels)
+	}
+	histogram := func(name, help string, labels ...string) *prometheus.HistogramVec {
+		return prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace:   cfg.namespace,
+			Name:        name,
+			Help:        help,
+			ConstLabels: cfg.constLabels,
+			Buckets:     cfg.buckets,
+		}, labels)
+	}
+
+	return &Collector{
+		loads:               counter("loads_total", "Number of requests made to load Toolbox tools.", "toolset", "tool", "status"),
+		loadDurations:       histogram("load_duration_seconds", "Duration of the requests made to load Toolbox tools.", "toolset", "tool"),
+		invocations:         counter("invocations_total", "Number of Toolbox tool invocations.", "tool", "status"),
+		invocationDurations: histogram("invocation_duration_seconds", "Duration of Toolbox tool invocations.", "tool"),
+		retries:             counter("retries_total", "Number of retried requests to the Toolbox server.", "tool", "status"),
+		errors:              counter("errors_total", "Number of failed requests to the Toolbox server.", "method", "tool", "status"),
+	}, nil
+}
+
+// collectors returns the metrics of c.
+func (c *Collector) collectors() []prometheus.Collector {
+	return []prometheus.Collector{c.loads, c.loadDurations, c.invocations, c.invocationDurations, c.retries, c.errors}
+}
+
+// Describe implements prometheus.Collector.
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	for _, collector := range c.collectors() {
+		collector.Describe(ch)
+	}
+}
+
+// Collect implements prometheus.Collector.
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	for _, collector := range c.collectors() {
+		collector.Collect(ch)
+	}
+}
+
+// OnRPCStart implements core.TransportObserver.
+func (c *Collector) OnRPCStart(context.Context, string) {}
+
+// OnRPCEnd implements core.TransportObserver.
+func (c *Collector) OnRPCEnd(ctx context.Context, method string, duration time.Duration, err error) {
+	tool, st := transport.ToolName(ctx), status(err)
+	switch method {
+	case "tools/list":
+		toolset := transport.ToolsetName(ctx)
+		c.loads.WithLabelValues(toolset, tool, st).Inc()
+		c.loadDurations.WithLabelValues(toolset, tool).Observe(duration.Seconds())
+	case "tools/call":
+		c.invocations.WithLabelValues(tool, st).Inc()
+		c.invocationDurations.WithLabelValues(tool).Observe(duration.Seconds())
+	}
+	if err != nil {
+		c.errors.WithLabelValues(method, tool, st).Inc()
+	}
+}
+
+// OnRetry implements transport.RetryObserver.
+func (c *Collector) OnRetry(ctx context.Context, _ int, statusCode int, err error) {
+	st := status(err)
+	if statusCode != 0 {
+		st = strconv.Itoa(statusCode)
+	}
+	c.retries.WithLabelValues(transport.ToolName(ctx), st).Inc()
+}
+
+// status returns the status label of a request that failed with err.
+func status(err error) string {
+	if err == nil {
+		return "ok"
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return "timeout"
+	}
+	if errors.Is(err, context.Canceled) {
+		return "canceled"
+	}
+	var invocationErr *core.ToolInvocationError
+	if errors.As(err, &invocationErr) {
+		switch {
+		case invocationErr.StatusCode != 0:
+			return strconv.Itoa(invocationErr.StatusCode)
+		case invocationErr.Code != 0:
+			return "rpc_error"
+		default:
+			return "tool_error"
+		}
+	}
+	return "error"
+}
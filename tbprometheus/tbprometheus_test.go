@@ -0,0 +1,214 @@
+//go:build unit
+
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tbprometheus
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/googleapis/mcp-toolbox-sdk-go/core"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+// newMockServer returns an MCP server with an "echo" tool and a "fail" tool
+// that reports an error. The first unavailable requests are rejected with a
+// 503 status.
+func newMockServer(t *testing.T, unavailable int32) *httptest.Server {
+	t.Helper()
+	var rejected atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if rejected.Add(1) <= unavailable {
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		var req struct {
+			Method string         `json:"method"`
+			ID     any            `json:"id"`
+			Params map[string]any `json:"params"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "bad request", http.StatusBadRequest)
+			return
+		}
+
+		var result any
+		switch req.Method {
+		case "initialize":
+			result = map[string]any{
+				"protocolVersion": "2025-06-18",
+				"capabilities":    map[string]any{"tools": map[string]any{}},
+				"serverInfo":      map[string]any{"name": "mock-server", "version": "1.0.0"},
+			}
+		case "tools/list":
+			schema := map[string]any{"type": "object", "properties": map[string]any{}}
+			result = map[string]any{"tools": []any{
+				map[string]any{"name": "echo", "description": "Echo", "inputSchema": schema},
+				map[string]any{"name": "fail", "description": "Fail", "inputSchema": schema},
+			}}
+		case "tools/call":
+			if req.Params["name"] == "fail" {
+				w.Header().Set("Content-Type", "application/json")
+				_ = json.NewEncoder(w).Encode(map[string]any{
+					"jsonrpc": "2.0", "id": req.ID,
+					"error": map[string]any{"code": -32603, "message": "boom"},
+				})
+				return
+			}
+			result = map[string]any{"content": []any{map[string]any{"type": "text", "text": "ok"}}}
+		default:
+			w.WriteHeader(http.StatusAccepted)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{"jsonrpc": "2.0", "id": req.ID, "result": result})
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestCollector(t *testing.T) {
+	ctx := context.Background()
+	collector, err := NewCollector()
+	if err != nil {
+		t.Fatalf("NewCollector() error = %v", err)
+	}
+	registry := prometheus.NewPedanticRegistry()
+	if err := registry.Register(collector); err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+
+	server := newMockServer(t, 1)
+	client, err := core.NewToolboxClient(server.URL,
+		core.WithTransportObserver(collector),
+		core.WithTransportRetry(2, time.Millisecond, time.Millisecond))
+	if err != nil {
+		t.Fatalf("NewToolboxClient() error = %v", err)
+	}
+
+	tools, err := client.LoadToolset("travel", ctx)
+	if err != nil {
+		t.Fatalf("LoadToolset() error = %v", err)
+	}
+	echo, err := client.LoadTool("echo", ctx)
+	if err != nil {
+		t.Fatalf("LoadTool() error = %v", err)
+	}
+	if _, err := echo.Invoke(ctx, nil); err != nil {
+		t.Fatalf("Invoke() error = %v", err)
+	}
+	for _, tool := range tools {
+		if tool.Name() == "fail" {
+			if _, err := tool.Invoke(ctx, nil); err == nil {
+				t.Fatalf("Invoke() succeeded, want an error")
+			}
+		}
+	}
+
+	counters := []struct {
+		name   string
+		metric prometheus.Collector
+		want   float64
+	}{
+		{"toolset load", collector.loads.WithLabelValues("travel", "", "ok"), 1},
+		{"tool load", collector.loads.WithLabelValues("", "echo", "ok"), 1},
+		{"successful invocation", collector.invocations.WithLabelValues("echo", "ok"), 1},
+		{"failed invocation", collector.invocations.WithLabelValues("fail", "rpc_error"), 1},
+		{"error", collector.errors.WithLabelValues("tools/call", "fail", "rpc_error"), 1},
+		{"retry", collector.retries.WithLabelValues("", "503"), 1},
+	}
+	for _, tc := range counters {
+		if got := testutil.ToFloat64(tc.metric); got != tc.want {
+			t.Errorf("%s counter = %v, want %v", tc.name, got, tc.want)
+		}
+	}
+
+	if got := testutil.CollectAndCount(collector, "toolbox_invocation_duration_seconds"); got != 2 {
+		t.Errorf("invocation duration has %d series, want 2", got)
+	}
+	if got := testutil.CollectAndCount(collector, "toolbox_load_duration_seconds"); got != 2 {
+		t.Errorf("load duration has %d series, want 2", got)
+	}
+	if problems, err := testutil.CollectAndLint(collector); err != nil || len(problems) > 0 {
+		t.Errorf("CollectAndLint() = %v, %v", problems, err)
+	}
+}
+
+func TestStatus(t *testing.T) {
+	testCases := []struct {
+		err  error
+		want string
+	}{
+		{nil, "ok"},
+		{context.DeadlineExceeded, "timeout"},
+		{context.Canceled, "canceled"},
+		{&core.ToolInvocationError{StatusCode: 502}, "502"},
+		{&core.ToolInvocationError{Code: -32602}, "rpc_error"},
+		{&core.ToolInvocationError{Message: "bad input"}, "tool_error"},
+		{http.ErrHandlerTimeout, "error"},
+	}
+	for _, tc := range testCases {
+		if got := status(tc.err); got != tc.want {
+			t.Errorf("status(%v) = %q, want %q", tc.err, got, tc.want)
+		}
+	}
+}
+
+func TestOptions(t *testing.T) {
+	collector, err := NewCollector(
+		WithNamespace("myapp"),
+		WithConstLabels(prometheus.Labels{"server": "primary"}),
+		WithDurationBuckets(0.1, 1),
+	)
+	if err != nil {
+		t.Fatalf("NewCollector() error = %v", err)
+	}
+	collector.OnRPCEnd(context.Background(), "tools/call", time.Second, nil)
+	expected := `
+# HELP myapp_invocations_total Number of Toolbox tool invocations.
+# TYPE myapp_invocations_total counter
+myapp_invocations_total{server="primary",status="ok",tool=""} 1
+`
+	if err := testutil.CollectAndCompare(collector, strings.NewReader(expected), "myapp_invocations_total"); err != nil {
+		t.Errorf("CollectAndCompare() error = %v", err)
+	}
+
+	errCases := []struct {
+		name    string
+		opts    []Option
+		wantErr string
+	}{
+		{"nil option", []Option{nil}, "received a nil Option"},
+		{"empty labels", []Option{WithConstLabels(nil)}, "labels cannot be empty"},
+		{"labels set twice", []Option{WithConstLabels(prometheus.Labels{"a": "1"}), WithConstLabels(prometheus.Labels{"b": "2"})}, "already set"},
+		{"empty buckets", []Option{WithDurationBuckets()}, "buckets cannot be empty"},
+		{"unsorted buckets", []Option{WithDurationBuckets(1, 0.1)}, "increasing order"},
+	}
+	for _, tc := range errCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := NewCollector(tc.opts...); err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Errorf("NewCollector() error = %v, want it to contain %q", err, tc.wantErr)
+			}
+		})
+	}
+}